@@ -69,6 +69,9 @@ func runMigrate(cmd *cobra.Command, args []string) {
 
 	// Run migrations
 	log.Println("🔄 Running migrations...")
+	if err := database.EnsureSearchConfig(db); err != nil {
+		log.Fatalf("❌ Migration error: %v", err)
+	}
 	if err := db.AutoMigrate(database.AllModels...); err != nil {
 		log.Fatalf("❌ Migration error: %v", err)
 	}
@@ -0,0 +1,45 @@
+package dtos
+
+// Acciones de POST /applications/bulk
+const (
+	BulkActionMove   = "move"
+	BulkActionReject = "reject"
+	BulkActionRate   = "rate"
+	BulkActionTag    = "tag"
+	BulkActionDelete = "delete"
+)
+
+// BulkApplicationsDTO representa una operación sobre varias postulaciones.
+// Según action: move requiere stage; reject requiere rejection_reason (y
+// rejection_type, por defecto rejected_by_us); rate requiere rating; tag
+// requiere tags (se aplican al candidato de cada postulación).
+type BulkApplicationsDTO struct {
+	Action string   `json:"action" binding:"required,oneof=move reject rate tag delete"`
+	IDs    []uint   `json:"ids" binding:"required,min=1,max=500,dive,min=1"`
+	Stage  string   `json:"stage,omitempty" binding:"omitempty,max=50"`
+	Reason string   `json:"reason,omitempty" binding:"omitempty,max=1000"` // nota para el historial de etapas
+	Rating *int     `json:"rating,omitempty" binding:"omitempty,min=1,max=5"`
+	Tags   []string `json:"tags,omitempty" binding:"omitempty,max=20,dive,required,max=50"`
+
+	RejectionType   string `json:"rejection_type,omitempty" binding:"omitempty,oneof=rejected_by_us candidate_withdrew"`
+	RejectionReason string `json:"rejection_reason,omitempty" binding:"omitempty,max=100"`
+}
+
+// BulkItemResultDTO es el resultado de la operación para una postulación.
+// Status es el código HTTP que habría devuelto la operación individual.
+type BulkItemResultDTO struct {
+	ID     uint   `json:"id"`
+	OK     bool   `json:"ok"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Stage  string `json:"stage,omitempty"` // etapa resultante (move/reject)
+}
+
+// BulkResultDTO resume una operación masiva. Cada postulación se procesa por
+// separado: un fallo no revierte las demás.
+type BulkResultDTO struct {
+	Action    string              `json:"action"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Items     []BulkItemResultDTO `json:"items"`
+}
@@ -14,11 +14,23 @@ type ApplicationResponseDTO struct {
 	CandidateID uint       `json:"candidate_id"`
 	CompanyID   uint       `json:"company_id"`
 	Stage       string     `json:"stage"`
+	Position    string     `json:"position"`
 	Rating      *int       `json:"rating,omitempty"`
 	Notes       string     `json:"notes,omitempty"`
 	AppliedAt   time.Time  `json:"applied_at"`
 	RejectedAt  *time.Time `json:"rejected_at,omitempty"`
 	HiredAt     *time.Time `json:"hired_at,omitempty"`
+
+	RejectionType     string `json:"rejection_type,omitempty"`
+	RejectionReason   string `json:"rejection_reason,omitempty"`
+	RejectedFromStage string `json:"rejected_from_stage,omitempty"`
+}
+
+// ApplicationDetailDTO es la postulación de GET /applications/:id con el
+// resumen de scorecards que el usuario puede ver.
+type ApplicationDetailDTO struct {
+	*models.Application
+	Scorecards *ScorecardSummaryDTO `json:"scorecards"`
 }
 
 // CreateApplicationDTO represents the data needed to create an application
@@ -26,23 +38,73 @@ type CreateApplicationDTO struct {
 	JobID       uint   `json:"job_id" validate:"required,min=1"`
 	CandidateID uint   `json:"candidate_id" validate:"required,min=1"`
 	CompanyID   uint   `json:"company_id" validate:"required,min=1"`
-	Stage       string `json:"stage" validate:"required,oneof=applied screening technical offer hired rejected"`
+	Stage       string `json:"stage,omitempty" validate:"omitempty,max=50"` // key del pipeline; vacío = primera etapa
 	Rating      *int   `json:"rating,omitempty" validate:"omitempty,min=1,max=5"`
-	Notes       string `json:"notes,omitempty"`
+	Notes       string `json:"notes,omitempty"` // se registra como comentario de la postulación
 }
 
 // UpdateApplicationDTO represents the data needed to update an application
 type UpdateApplicationDTO struct {
-	Stage      *string    `json:"stage,omitempty" validate:"omitempty,oneof=applied screening technical offer hired rejected"`
-	Rating     *int       `json:"rating,omitempty" validate:"omitempty,min=1,max=5"`
-	Notes      *string    `json:"notes,omitempty"`
-	RejectedAt *time.Time `json:"rejected_at,omitempty"`
-	HiredAt    *time.Time `json:"hired_at,omitempty"`
+	Stage  *string `json:"stage,omitempty" validate:"omitempty,max=50"`
+	Rating *int    `json:"rating,omitempty" validate:"omitempty,min=1,max=5"`
+	Notes  *string `json:"notes,omitempty"` // agrega un comentario; no reemplaza notas previas
+
+	// Obligatorio si stage es de tipo rejected (ver MoveApplicationDTO)
+	RejectionType   string `json:"rejection_type,omitempty" binding:"omitempty,oneof=rejected_by_us candidate_withdrew"`
+	RejectionReason string `json:"rejection_reason,omitempty" binding:"omitempty,max=100"`
 }
 
 // MoveApplicationDTO represents the data needed to move an application to a stage
 type MoveApplicationDTO struct {
-	Stage string `json:"stage" validate:"required,oneof=applied screening technical offer hired rejected"`
+	Stage  string `json:"stage" binding:"required,max=50" validate:"required,max=50"` // key de una etapa del pipeline de la vacante
+	Reason string `json:"reason,omitempty" binding:"omitempty,max=1000"`              // queda en el historial de etapas
+
+	// Al mover a una etapa de tipo rejected: rejection_reason es obligatorio
+	// (value del catálogo del tipo); rejection_type vacío = rejected_by_us.
+	RejectionType   string `json:"rejection_type,omitempty" binding:"omitempty,oneof=rejected_by_us candidate_withdrew"`
+	RejectionReason string `json:"rejection_reason,omitempty" binding:"omitempty,max=100"`
+}
+
+// OverrideStageDTO represents an admin stage override outside the transition graph
+type OverrideStageDTO struct {
+	Stage  string `json:"stage" binding:"required,max=50"`
+	Reason string `json:"reason" binding:"required,min=5,max=1000"`
+	// Como en MoveApplicationDTO: obligatorio al forzar una etapa rejected.
+	RejectionType   string `json:"rejection_type,omitempty" binding:"omitempty,oneof=rejected_by_us candidate_withdrew"`
+	RejectionReason string `json:"rejection_reason,omitempty" binding:"omitempty,max=100"`
+}
+
+// ReorderApplicationDTO ubica una postulación dentro de su columna entre las
+// tarjetas que el cliente ve a su alrededor: after_id queda justo arriba y
+// before_id justo abajo (omitir uno = extremo de la columna). job_id es el
+// filtro con que se cargó el tablero (omitir = toda la empresa). Si la
+// columna cambió desde que se cargó, la respuesta es 409.
+type ReorderApplicationDTO struct {
+	AfterID  uint `json:"after_id,omitempty"`
+	BeforeID uint `json:"before_id,omitempty"`
+	JobID    uint `json:"job_id,omitempty"`
+}
+
+// Orden de las columnas del tablero
+const (
+	BoardSortPosition     = "position"      // orden manual (por defecto)
+	BoardSortRating       = "rating"        // mejor calificadas primero; sin calificar al final
+	BoardSortAppliedAt    = "applied_at"    // más recientes primero
+	BoardSortLastActivity = "last_activity" // updated_at, más recientes primero
+	BoardSortScore        = "score"         // mayor compatibilidad primero; requiere job_id
+)
+
+// ApplicationBoardQuery pagina y ordena GET /applications/by-stage. limit y
+// offset aplican a cada columna; con stage se pide solo esa columna (cargar
+// más). order invierte el sentido de los órdenes que no son manuales. score
+// ordena por compatibilidad con la vacante y exige job_id.
+type ApplicationBoardQuery struct {
+	JobID  uint   `form:"job_id"`
+	Stage  string `form:"stage" binding:"omitempty,max=100"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=position rating applied_at last_activity score"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
 }
 
 // RateApplicationDTO represents the data needed to rate an application
@@ -60,11 +122,16 @@ func ToApplicationResponse(app *models.Application) ApplicationResponseDTO {
 		CandidateID: app.CandidateID,
 		CompanyID:   app.CompanyID,
 		Stage:       app.Stage,
+		Position:    app.Position,
 		Rating:      app.Rating,
 		Notes:       app.Notes,
 		AppliedAt:   app.AppliedAt,
 		RejectedAt:  app.RejectedAt,
 		HiredAt:     app.HiredAt,
+
+		RejectionType:     app.RejectionType,
+		RejectionReason:   app.RejectionReason,
+		RejectedFromStage: app.RejectedFromStage,
 	}
 }
 
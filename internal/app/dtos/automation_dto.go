@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// AutomationRuleDTO crea o reemplaza una regla de automatización. stage acota
+// stage_changed (etapa destino) y junto con days define time_in_stage. El
+// secret de un webhook no se devuelve; al editar, omitirlo conserva el
+// anterior si la URL no cambió.
+type AutomationRuleDTO struct {
+	Name       string                       `json:"name" binding:"required,max=150"`
+	Trigger    string                       `json:"trigger" binding:"required,oneof=application_created stage_changed rating_set time_in_stage"`
+	Stage      string                       `json:"stage,omitempty" binding:"omitempty,max=100"`
+	Days       int                          `json:"days,omitempty" binding:"omitempty,min=1,max=365"`
+	Conditions []models.AutomationCondition `json:"conditions,omitempty" binding:"max=10"`
+	Actions    []models.AutomationAction    `json:"actions" binding:"required,min=1,max=10"`
+	Active     *bool                        `json:"active,omitempty"` // por defecto true
+}
+
+// StalePolicyDTO configura el barrido de postulaciones estancadas de una
+// etapa: recordatorio al recruiter asignado a los remind_after_days sin
+// actividad y rechazo a los reject_after_days (0 desactiva la fase). El
+// correo al candidato (notify_candidate) se envía tras el rechazo.
+type StalePolicyDTO struct {
+	RemindAfterDays int    `json:"remind_after_days" binding:"min=0,max=365"`
+	RejectAfterDays int    `json:"reject_after_days" binding:"min=0,max=365"`
+	RejectionType   string `json:"rejection_type,omitempty" binding:"omitempty,oneof=rejected_by_us candidate_withdrew"`
+	RejectionReason string `json:"rejection_reason,omitempty" binding:"omitempty,max=100"`
+	NotifyCandidate bool   `json:"notify_candidate"`
+	EmailSubject    string `json:"email_subject,omitempty" binding:"omitempty,max=200"`
+	EmailBody       string `json:"email_body,omitempty"`
+	Active          *bool  `json:"active,omitempty"` // por defecto true
+}
+
+// StaleApplicationDTO es una postulación del dry-run del barrido.
+type StaleApplicationDTO struct {
+	ApplicationID  uint      `json:"application_id"`
+	CandidateName  string    `json:"candidate_name"`
+	JobTitle       string    `json:"job_title"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	IdleDays       int       `json:"idle_days"`
+}
+
+// StalePreviewDTO es el dry-run de una política: qué se avisaría y qué se
+// rechazaría si el barrido corriera ahora (muestras de hasta 50).
+type StalePreviewDTO struct {
+	Stage           string                `json:"stage"`
+	RemindAfterDays int                   `json:"remind_after_days"`
+	RejectAfterDays int                   `json:"reject_after_days"`
+	ReminderCount   int                   `json:"reminder_count"`
+	RejectionCount  int                   `json:"rejection_count"`
+	Reminders       []StaleApplicationDTO `json:"reminders"`
+	Rejections      []StaleApplicationDTO `json:"rejections"`
+}
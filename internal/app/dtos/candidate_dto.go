@@ -19,6 +19,15 @@ type CandidateResponseDTO struct {
 	GithubURL   string    `json:"github_url,omitempty"`
 	LinkedinURL string    `json:"linkedin_url,omitempty"`
 	Source      string    `json:"source,omitempty"`
+
+	CityID            *uint    `json:"city_id,omitempty"`
+	WorkMode          string   `json:"work_mode,omitempty"`
+	OpenToRelocate    bool     `json:"open_to_relocate"`
+	SalaryExpectation *float64 `json:"salary_expectation,omitempty"`
+	YearsOfExperience *float64 `json:"years_of_experience,omitempty"`
+
+	TalentPoolConsentAt *time.Time `json:"talent_pool_consent_at,omitempty"`
+	AnonymizedAt        *time.Time `json:"anonymized_at,omitempty"`
 }
 
 // CreateCandidateDTO represents the data needed to create a candidate
@@ -28,10 +37,18 @@ type CreateCandidateDTO struct {
 	FirstName   string `json:"first_name" validate:"required,min=2,max=100"`
 	LastName    string `json:"last_name" validate:"required,min=2,max=100"`
 	Phone       string `json:"phone,omitempty" validate:"omitempty,max=50"`
-	ResumeURL   string `json:"resume_url,omitempty" validate:"omitempty,url"`
+	ResumeURL   string `json:"resume_url,omitempty" binding:"omitempty,url"` // absoluta: las rutas /uploads/ solo las asigna la subida
 	GithubURL   string `json:"github_url,omitempty" validate:"omitempty,url"`
 	LinkedinURL string `json:"linkedin_url,omitempty" validate:"omitempty,url"`
 	Source      string `json:"source,omitempty" validate:"omitempty,oneof=linkedin referral direct_apply agency"`
+
+	// Preferencias para la compatibilidad con vacantes (work_mode vacío =
+	// cualquiera)
+	CityID            *uint    `json:"city_id,omitempty"`
+	WorkMode          string   `json:"work_mode,omitempty" binding:"omitempty,oneof=remote onsite hybrid"`
+	OpenToRelocate    bool     `json:"open_to_relocate"`
+	SalaryExpectation *float64 `json:"salary_expectation,omitempty" binding:"omitempty,min=0"`
+	YearsOfExperience *float64 `json:"years_of_experience,omitempty" binding:"omitempty,min=0,max=60"`
 }
 
 // UpdateCandidateDTO represents the data needed to update a candidate
@@ -40,10 +57,16 @@ type UpdateCandidateDTO struct {
 	FirstName   *string `json:"first_name,omitempty" validate:"omitempty,min=2,max=100"`
 	LastName    *string `json:"last_name,omitempty" validate:"omitempty,min=2,max=100"`
 	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=50"`
-	ResumeURL   *string `json:"resume_url,omitempty" validate:"omitempty,url"`
+	ResumeURL   *string `json:"resume_url,omitempty" binding:"omitempty,url"` // absoluta: las rutas /uploads/ solo las asigna la subida
 	GithubURL   *string `json:"github_url,omitempty" validate:"omitempty,url"`
 	LinkedinURL *string `json:"linkedin_url,omitempty" validate:"omitempty,url"`
 	Source      *string `json:"source,omitempty" validate:"omitempty,oneof=linkedin referral direct_apply agency"`
+
+	CityID            *uint    `json:"city_id,omitempty"`
+	WorkMode          *string  `json:"work_mode,omitempty" binding:"omitempty,oneof='' remote onsite hybrid"`
+	OpenToRelocate    *bool    `json:"open_to_relocate,omitempty"`
+	SalaryExpectation *float64 `json:"salary_expectation,omitempty" binding:"omitempty,min=0"`
+	YearsOfExperience *float64 `json:"years_of_experience,omitempty" binding:"omitempty,min=0,max=60"`
 }
 
 // ToCandidateResponse converts a Candidate model to CandidateResponseDTO
@@ -61,6 +84,15 @@ func ToCandidateResponse(candidate *models.Candidate) CandidateResponseDTO {
 		GithubURL:   candidate.GithubURL,
 		LinkedinURL: candidate.LinkedinURL,
 		Source:      candidate.Source,
+
+		CityID:            candidate.CityID,
+		WorkMode:          candidate.WorkMode,
+		OpenToRelocate:    candidate.OpenToRelocate,
+		SalaryExpectation: candidate.SalaryExpectation,
+		YearsOfExperience: candidate.YearsOfExperience,
+
+		TalentPoolConsentAt: candidate.TalentPoolConsentAt,
+		AnonymizedAt:        candidate.AnonymizedAt,
 	}
 }
 
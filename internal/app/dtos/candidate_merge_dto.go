@@ -0,0 +1,30 @@
+package dtos
+
+// DuplicateQuery filtra la búsqueda de candidatos duplicados. MinScore es el
+// puntaje mínimo (por defecto 50: basta un email o un perfil iguales).
+type DuplicateQuery struct {
+	MinScore int `form:"min_score" binding:"omitempty,min=1,max=100"`
+	Limit    int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// DuplicatePairDTO es un par de candidatos posiblemente duplicados. Reasons
+// son las señales que coinciden: email, linkedin, github, phone, name.
+type DuplicatePairDTO struct {
+	Score     int                  `json:"score"`
+	Reasons   []string             `json:"reasons"`
+	Candidate CandidateResponseDTO `json:"candidate"`
+	Other     CandidateResponseDTO `json:"other"`
+}
+
+// DuplicateMatchDTO es un posible duplicado de un candidato dado.
+type DuplicateMatchDTO struct {
+	Score     int                  `json:"score"`
+	Reasons   []string             `json:"reasons"`
+	Candidate CandidateResponseDTO `json:"candidate"`
+}
+
+// MergeCandidateDTO indica el duplicado que se fusiona en el candidato de la
+// ruta (que sobrevive).
+type MergeCandidateDTO struct {
+	DuplicateID uint `json:"duplicate_id" binding:"required,min=1"`
+}
@@ -0,0 +1,56 @@
+package dtos
+
+// CandidateSearchQuery es una búsqueda de texto completo de candidatos. q
+// admite varias palabras (todas deben aparecer, como prefijo y sin importar
+// tildes) y "-palabra" para excluir. Los filtros se combinan con AND; varios
+// tag_id exigen todas las etiquetas.
+type CandidateSearchQuery struct {
+	Q           string `form:"q" binding:"required,max=200"`
+	Source      string `form:"source"`
+	TagIDs      []uint `form:"tag_id"`
+	Stage       string `form:"stage"`
+	JobID       uint   `form:"job_id"`
+	Contactable bool   `form:"contactable"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset      int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// CandidateSearchHitDTO es un resultado de la búsqueda. Snippet es HTML
+// escapado con las coincidencias entre <mark> y </mark>.
+type CandidateSearchHitDTO struct {
+	Candidate CandidateResponseDTO `json:"candidate"`
+	Rank      float64              `json:"rank"`
+	Snippet   string               `json:"snippet"`
+}
+
+// CandidateSearchResultDTO es una página de resultados con el total y las
+// facetas de todas las coincidencias.
+type CandidateSearchResultDTO struct {
+	Items  []CandidateSearchHitDTO  `json:"items"`
+	Total  int64                    `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+	Facets CandidateSearchFacetsDTO `json:"facets"`
+}
+
+// SearchFacetDTO es un valor de una faceta y cuántos resultados lo tienen.
+// ID es el de la etiqueta o la vacante (se omite en source y stage).
+type SearchFacetDTO struct {
+	ID    uint   `json:"id,omitempty"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// CandidateSearchFacetsDTO cuenta los resultados por fuente, etiqueta, etapa
+// y vacante de sus postulaciones activas.
+type CandidateSearchFacetsDTO struct {
+	Source []SearchFacetDTO `json:"source"`
+	Tags   []SearchFacetDTO `json:"tags"`
+	Stage  []SearchFacetDTO `json:"stage"`
+	Job    []SearchFacetDTO `json:"job"`
+}
+
+// ReindexResultDTO informa cuántos candidatos se reindexaron.
+type ReindexResultDTO struct {
+	Indexed int `json:"indexed"`
+}
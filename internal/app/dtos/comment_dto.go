@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// CreateCommentDTO representa un comentario nuevo. ParentID responde en el
+// hilo de ese comentario; MentionIDs son usuarios de la empresa a notificar.
+// Una respuesta hereda la visibilidad (internal) de su hilo.
+type CreateCommentDTO struct {
+	Body       string `json:"body" binding:"required,max=10000"`
+	ParentID   *uint  `json:"parent_id,omitempty"`
+	Internal   bool   `json:"internal"`
+	MentionIDs []uint `json:"mention_ids,omitempty" binding:"omitempty,max=20"`
+}
+
+// UpdateCommentDTO representa la edición de un comentario propio. Version es
+// la que se leyó: si otro la cambió, responde 409.
+type UpdateCommentDTO struct {
+	Body       string `json:"body" binding:"required,max=10000"`
+	Version    int    `json:"version" binding:"required,min=1"`
+	MentionIDs []uint `json:"mention_ids,omitempty" binding:"omitempty,max=20"`
+}
+
+// CommentDTO representa un comentario en la respuesta, con sus respuestas si
+// es la raíz de un hilo.
+type CommentDTO struct {
+	ID          uint                       `json:"id"`
+	ParentID    *uint                      `json:"parent_id,omitempty"`
+	AuthorID    *uint                      `json:"author_id,omitempty"`
+	AuthorName  string                     `json:"author_name,omitempty"`
+	Body        string                     `json:"body"`
+	Internal    bool                       `json:"internal"`
+	Source      string                     `json:"source,omitempty"`
+	Version     int                        `json:"version"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	EditedAt    *time.Time                 `json:"edited_at,omitempty"`
+	MentionIDs  []uint                     `json:"mention_ids"`
+	Attachments []models.CommentAttachment `json:"attachments"`
+	Replies     []CommentDTO               `json:"replies,omitempty"`
+}
@@ -0,0 +1,98 @@
+package dtos
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// ConsentEvidence son los datos de la petición que prueban un consentimiento
+// (RN-GDPR-001). El handler los toma del request; nunca vienen en el body.
+type ConsentEvidence struct {
+	IPAddress string
+	UserAgent string
+}
+
+// CreatePrivacyNoticeDTO representa la publicación de una nueva versión del
+// aviso de privacidad. Se requiere content o url.
+type CreatePrivacyNoticeDTO struct {
+	Title   string `json:"title" binding:"required,max=255"`
+	Content string `json:"content,omitempty"`
+	URL     string `json:"url,omitempty" binding:"omitempty,url"`
+}
+
+// PrivacyNoticeResponseDTO representa una versión del aviso de privacidad
+type PrivacyNoticeResponseDTO struct {
+	ID          uint      `json:"id"`
+	Version     int       `json:"version"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	CreatedByID uint      `json:"created_by_id"`
+}
+
+// CandidateConsentResponseDTO representa un consentimiento registrado
+type CandidateConsentResponseDTO struct {
+	ID            uint       `json:"id"`
+	CandidateID   uint       `json:"candidate_id"`
+	ApplicationID *uint      `json:"application_id,omitempty"`
+	Purpose       string     `json:"purpose"`
+	Method        string     `json:"method"`
+	NoticeVersion int        `json:"notice_version"`
+	NoticeURL     string     `json:"notice_url,omitempty"`
+	IPAddress     string     `json:"ip_address,omitempty"`
+	UserAgent     string     `json:"user_agent,omitempty"`
+	GivenAt       time.Time  `json:"given_at"`
+	RecordedByID  *uint      `json:"recorded_by_id,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ToPrivacyNoticeResponse convierte un modelo PrivacyNotice a su DTO de respuesta
+func ToPrivacyNoticeResponse(n *models.PrivacyNotice) PrivacyNoticeResponseDTO {
+	return PrivacyNoticeResponseDTO{
+		ID:          n.ID,
+		Version:     n.Version,
+		Title:       n.Title,
+		Content:     n.Content,
+		URL:         n.URL,
+		PublishedAt: n.PublishedAt,
+		CreatedByID: n.CreatedByID,
+	}
+}
+
+// ToPrivacyNoticeResponseList convierte una lista de avisos a DTOs
+func ToPrivacyNoticeResponseList(notices []models.PrivacyNotice) []PrivacyNoticeResponseDTO {
+	result := make([]PrivacyNoticeResponseDTO, len(notices))
+	for i := range notices {
+		result[i] = ToPrivacyNoticeResponse(&notices[i])
+	}
+	return result
+}
+
+// ToCandidateConsentResponse convierte un modelo CandidateConsent a su DTO de respuesta
+func ToCandidateConsentResponse(c *models.CandidateConsent) CandidateConsentResponseDTO {
+	return CandidateConsentResponseDTO{
+		ID:            c.ID,
+		CandidateID:   c.CandidateID,
+		ApplicationID: c.ApplicationID,
+		Purpose:       c.Purpose,
+		Method:        c.Method,
+		NoticeVersion: c.NoticeVersion,
+		NoticeURL:     c.NoticeURL,
+		IPAddress:     c.IPAddress,
+		UserAgent:     c.UserAgent,
+		GivenAt:       c.GivenAt,
+		RecordedByID:  c.RecordedByID,
+		RevokedAt:     c.RevokedAt,
+	}
+}
+
+// ToCandidateConsentResponseList convierte una lista de consentimientos a DTOs
+func ToCandidateConsentResponseList(consents []models.CandidateConsent) []CandidateConsentResponseDTO {
+	result := make([]CandidateConsentResponseDTO, len(consents))
+	for i := range consents {
+		result[i] = ToCandidateConsentResponse(&consents[i])
+	}
+	return result
+}
@@ -1,12 +1,12 @@
 package dtos
 
-// ApplicationsByStageDTO represents applications count by stage
-type ApplicationsByStageDTO struct {
-	Applied   int `json:"applied"`
-	Screening int `json:"screening"`
-	Technical int `json:"technical"`
-	Offer     int `json:"offer"`
-	Hired     int `json:"hired"`
+// StageCountDTO represents applications count for one pipeline stage
+type StageCountDTO struct {
+	Key   string `json:"key"`
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Color string `json:"color,omitempty"`
+	Count int    `json:"count"`
 }
 
 // DailyCountDTO represents a count for a specific date
@@ -41,8 +41,8 @@ type DashboardStatsDTO struct {
 	TotalCandidates int `json:"total_candidates"`
 
 	// Applications stats
-	TotalApplications   int                    `json:"total_applications"`
-	ApplicationsByStage ApplicationsByStageDTO `json:"applications_by_stage"`
+	TotalApplications   int             `json:"total_applications"`
+	ApplicationsByStage []StageCountDTO `json:"applications_by_stage"` // En el orden del pipeline de la empresa
 
 	// Monthly stats
 	NewCandidatesThisMonth   int `json:"new_candidates_this_month"`
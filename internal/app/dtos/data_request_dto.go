@@ -0,0 +1,583 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// CreateDataSubjectRequestDTO representa el registro de una solicitud del titular.
+// CompanyID solo lo usa el SuperAdmin: vacío = todas las empresas.
+type CreateDataSubjectRequestDTO struct {
+	Type       string     `json:"type" binding:"required,oneof=access erasure"`
+	Email      string     `json:"email" binding:"required,email"`
+	CompanyID  *uint      `json:"company_id,omitempty"`
+	ReceivedAt *time.Time `json:"received_at,omitempty"` // default: ahora
+	Notes      string     `json:"notes,omitempty"`
+}
+
+// DataSubjectRequestFilters representa los filtros del listado de solicitudes
+type DataSubjectRequestFilters struct {
+	Type   string `form:"type" binding:"omitempty,oneof=access erasure"`
+	Status string `form:"status" binding:"omitempty,oneof=pending completed cancelled"`
+}
+
+// DataSubjectRequestResponseDTO representa una solicitud del titular de datos
+type DataSubjectRequestResponseDTO struct {
+	ID            uint            `json:"id"`
+	CompanyID     *uint           `json:"company_id,omitempty"`
+	Type          string          `json:"type"`
+	Status        string          `json:"status"`
+	SubjectEmail  string          `json:"subject_email"`
+	Notes         string          `json:"notes,omitempty"`
+	ReceivedAt    time.Time       `json:"received_at"`
+	DueAt         time.Time       `json:"due_at"`
+	Overdue       bool            `json:"overdue"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	RequestedByID uint            `json:"requested_by_id"`
+	CompletedByID *uint           `json:"completed_by_id,omitempty"`
+	Proof         json.RawMessage `json:"proof,omitempty"`
+	ProofHash     string          `json:"proof_hash,omitempty"`
+}
+
+// DataSubjectExportDTO es el paquete JSON entregado en una solicitud de acceso
+// (RN-GDPR-003): todo lo que la plataforma guarda del titular.
+type DataSubjectExportDTO struct {
+	RequestID    uint                            `json:"request_id"`
+	SubjectEmail string                          `json:"subject_email"`
+	GeneratedAt  time.Time                       `json:"generated_at"`
+	Candidates   []DataSubjectCandidateExportDTO `json:"candidates"`
+}
+
+// DataSubjectRecords reúne, por tabla, lo que se guarda de los candidatos del
+// titular además del perfil. Incluye registros en la papelera.
+type DataSubjectRecords struct {
+	Placements       []models.Placement       // con StaffingClient
+	Comments         []models.Comment         // de candidatos y postulaciones, con adjuntos
+	CommentRevisions []models.CommentRevision // textos anteriores de esos comentarios
+	StageEvents      []models.ApplicationStageEvent
+	Scorecards       []models.Scorecard
+	ScreeningAnswers []models.ScreeningAnswer
+	Interviews       []models.Interview
+	Offers           []models.Offer // con versiones
+	ResumeParses     []models.ResumeParse
+	Documents        []models.Document       // de postulaciones y colocaciones
+	Tags             []models.CandidateTag   // con Tag
+	Skills           []models.CandidateSkill // con Skill
+	PoolMemberships  []models.TalentPoolMember
+}
+
+// DataSubjectCandidateExportDTO agrupa los datos de un perfil de candidato en una empresa
+type DataSubjectCandidateExportDTO struct {
+	Profile      DataSubjectProfileExportDTO       `json:"profile"`
+	Applications []DataSubjectApplicationExportDTO `json:"applications"`
+	Placements   []DataSubjectPlacementExportDTO   `json:"placements"`
+	Comments     []DataSubjectCommentExportDTO     `json:"comments"`
+	Tags         []string                          `json:"tags"`
+	Skills       []DataSubjectSkillExportDTO       `json:"skills"`
+	TalentPools  []DataSubjectPoolExportDTO        `json:"talent_pools"`
+	ResumeParses []DataSubjectResumeParseExportDTO `json:"resume_parses"`
+	Documents    []DataSubjectDocumentExportDTO    `json:"documents"` // contratos de sus colocaciones
+	Files        []DataSubjectFileExportDTO        `json:"files"`
+	Consents     []DataSubjectConsentExportDTO     `json:"consents"`
+}
+
+// DataSubjectProfileExportDTO representa el perfil exportado
+type DataSubjectProfileExportDTO struct {
+	CandidateID         uint       `json:"candidate_id"`
+	CompanyID           uint       `json:"company_id"`
+	CompanyName         string     `json:"company_name,omitempty"`
+	Email               string     `json:"email"`
+	FirstName           string     `json:"first_name"`
+	LastName            string     `json:"last_name"`
+	Phone               string     `json:"phone,omitempty"`
+	GithubURL           string     `json:"github_url,omitempty"`
+	LinkedinURL         string     `json:"linkedin_url,omitempty"`
+	CityID              *uint      `json:"city_id,omitempty"`
+	WorkMode            string     `json:"work_mode,omitempty"`
+	OpenToRelocate      bool       `json:"open_to_relocate"`
+	SalaryExpectation   *float64   `json:"salary_expectation,omitempty"`
+	YearsOfExperience   *float64   `json:"years_of_experience,omitempty"`
+	TalentPoolConsentAt *time.Time `json:"talent_pool_consent_at,omitempty"`
+	Source              string     `json:"source,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+}
+
+// DataSubjectApplicationExportDTO representa una postulación exportada con
+// todo lo registrado durante el proceso
+type DataSubjectApplicationExportDTO struct {
+	ID               uint                             `json:"id"`
+	JobID            uint                             `json:"job_id"`
+	JobTitle         string                           `json:"job_title,omitempty"`
+	Stage            string                           `json:"stage"`
+	Rating           *int                             `json:"rating,omitempty"`
+	Notes            string                           `json:"notes,omitempty"`
+	AppliedAt        time.Time                        `json:"applied_at"`
+	RejectedAt       *time.Time                       `json:"rejected_at,omitempty"`
+	RejectionType    string                           `json:"rejection_type,omitempty"`
+	RejectionReason  string                           `json:"rejection_reason,omitempty"`
+	HiredAt          *time.Time                       `json:"hired_at,omitempty"`
+	DeletedAt        *time.Time                       `json:"deleted_at,omitempty"`
+	Comments         []DataSubjectCommentExportDTO    `json:"comments"`
+	StageHistory     []DataSubjectStageEventExportDTO `json:"stage_history"`
+	ScreeningAnswers []DataSubjectScreeningExportDTO  `json:"screening_answers"`
+	Scorecards       []DataSubjectScorecardExportDTO  `json:"scorecards"`
+	Interviews       []DataSubjectInterviewExportDTO  `json:"interviews"`
+	Offers           []DataSubjectOfferExportDTO      `json:"offers"`
+	Documents        []DataSubjectDocumentExportDTO   `json:"documents"`
+}
+
+// DataSubjectPlacementExportDTO representa una colocación exportada
+type DataSubjectPlacementExportDTO struct {
+	ID                 uint       `json:"id"`
+	ApplicationID      uint       `json:"application_id"`
+	StaffingClientName string     `json:"staffing_client_name,omitempty"`
+	Position           string     `json:"position,omitempty"`
+	ContractType       string     `json:"contract_type,omitempty"`
+	StartDate          *time.Time `json:"start_date,omitempty"`
+	EndDate            *time.Time `json:"end_date,omitempty"`
+	Status             string     `json:"status"`
+	Notes              string     `json:"notes,omitempty"`
+}
+
+// DataSubjectCommentExportDTO representa un comentario sobre el titular, con
+// sus versiones anteriores y adjuntos
+type DataSubjectCommentExportDTO struct {
+	ID          uint                                  `json:"id"`
+	Body        string                                `json:"body"`
+	Internal    bool                                  `json:"internal"`
+	Source      string                                `json:"source,omitempty"`
+	CreatedAt   time.Time                             `json:"created_at"`
+	EditedAt    *time.Time                            `json:"edited_at,omitempty"`
+	Revisions   []DataSubjectCommentRevisionExportDTO `json:"revisions"`
+	Attachments []DataSubjectFileExportDTO            `json:"attachments"`
+}
+
+// DataSubjectCommentRevisionExportDTO representa un texto anterior de un comentario
+type DataSubjectCommentRevisionExportDTO struct {
+	Version  int       `json:"version"`
+	Body     string    `json:"body"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+// DataSubjectStageEventExportDTO representa un cambio de etapa de la postulación
+type DataSubjectStageEventExportDTO struct {
+	FromStage  string    `json:"from_stage,omitempty"`
+	ToStage    string    `json:"to_stage"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// DataSubjectScreeningExportDTO representa una respuesta de screening
+type DataSubjectScreeningExportDTO struct {
+	Prompt     string `json:"prompt"`
+	Kind       string `json:"kind"`
+	Answer     string `json:"answer"`
+	KnockedOut bool   `json:"knocked_out"`
+}
+
+// DataSubjectScorecardExportDTO representa una evaluación de entrevista
+type DataSubjectScorecardExportDTO struct {
+	Stage          string                   `json:"stage"`
+	Recommendation string                   `json:"recommendation"`
+	Summary        string                   `json:"summary,omitempty"`
+	Ratings        []models.ScorecardRating `json:"ratings"`
+	Answers        []models.ScorecardAnswer `json:"answers"`
+	SubmittedAt    time.Time                `json:"submitted_at"`
+}
+
+// DataSubjectInterviewExportDTO representa una entrevista agendada
+type DataSubjectInterviewExportDTO struct {
+	Stage        string    `json:"stage"`
+	Title        string    `json:"title"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Timezone     string    `json:"timezone"`
+	Location     string    `json:"location,omitempty"`
+	Status       string    `json:"status"`
+	CancelReason string    `json:"cancel_reason,omitempty"`
+}
+
+// DataSubjectOfferExportDTO representa una oferta con todas sus versiones
+type DataSubjectOfferExportDTO struct {
+	ID           uint                               `json:"id"`
+	Status       string                             `json:"status"`
+	SentAt       *time.Time                         `json:"sent_at,omitempty"`
+	RespondedAt  *time.Time                         `json:"responded_at,omitempty"`
+	ResponseNote string                             `json:"response_note,omitempty"`
+	Versions     []DataSubjectOfferVersionExportDTO `json:"versions"`
+}
+
+// DataSubjectOfferVersionExportDTO representa los términos de una versión de la oferta
+type DataSubjectOfferVersionExportDTO struct {
+	Version   int        `json:"version"`
+	Salary    float64    `json:"salary"`
+	Currency  string     `json:"currency"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	Equity    string     `json:"equity,omitempty"`
+	Bonus     *float64   `json:"bonus,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	Notes     string     `json:"notes,omitempty"`
+}
+
+// DataSubjectSkillExportDTO representa una habilidad del perfil
+type DataSubjectSkillExportDTO struct {
+	Name   string   `json:"name"`
+	Level  string   `json:"level,omitempty"`
+	Years  *float64 `json:"years,omitempty"`
+	Source string   `json:"source"`
+}
+
+// DataSubjectPoolExportDTO representa la pertenencia a un talent pool
+type DataSubjectPoolExportDTO struct {
+	PoolID  uint      `json:"pool_id"`
+	Note    string    `json:"note,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// DataSubjectResumeParseExportDTO representa los datos extraídos de un CV
+type DataSubjectResumeParseExportDTO struct {
+	ResumeURL string            `json:"resume_url"`
+	Status    string            `json:"status"`
+	ParsedAt  *time.Time        `json:"parsed_at,omitempty"`
+	Data      models.ResumeData `json:"data"`
+}
+
+// DataSubjectDocumentExportDTO representa un documento PDF generado
+type DataSubjectDocumentExportDTO struct {
+	ID        uint      `json:"id"`
+	Kind      string    `json:"kind"`
+	FileName  string    `json:"file_name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DataSubjectConsentExportDTO representa un consentimiento registrado
+type DataSubjectConsentExportDTO struct {
+	Purpose       string     `json:"purpose"`
+	Method        string     `json:"method"`
+	NoticeVersion int        `json:"notice_version"`
+	NoticeURL     string     `json:"notice_url,omitempty"`
+	IPAddress     string     `json:"ip_address,omitempty"`
+	UserAgent     string     `json:"user_agent,omitempty"`
+	GivenAt       time.Time  `json:"given_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+}
+
+// DataSubjectFileExportDTO representa un archivo asociado al titular
+type DataSubjectFileExportDTO struct {
+	Kind        string `json:"kind"` // resume, attachment
+	URL         string `json:"url,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// ToDataSubjectRequestResponse convierte un modelo DataSubjectRequest a su DTO de respuesta
+func ToDataSubjectRequestResponse(r *models.DataSubjectRequest) DataSubjectRequestResponseDTO {
+	dto := DataSubjectRequestResponseDTO{
+		ID:            r.ID,
+		CompanyID:     r.CompanyID,
+		Type:          r.Type,
+		Status:        r.Status,
+		SubjectEmail:  r.SubjectEmail,
+		Notes:         r.Notes,
+		ReceivedAt:    r.ReceivedAt,
+		DueAt:         r.DueAt,
+		Overdue:       r.Status == "pending" && time.Now().After(r.DueAt),
+		CompletedAt:   r.CompletedAt,
+		RequestedByID: r.RequestedByID,
+		CompletedByID: r.CompletedByID,
+		ProofHash:     r.ProofHash,
+	}
+	if len(r.Proof) > 0 {
+		dto.Proof = json.RawMessage(r.Proof)
+	}
+	return dto
+}
+
+// ToDataSubjectRequestResponseList convierte una lista de solicitudes a DTOs
+func ToDataSubjectRequestResponseList(reqs []models.DataSubjectRequest) []DataSubjectRequestResponseDTO {
+	result := make([]DataSubjectRequestResponseDTO, len(reqs))
+	for i := range reqs {
+		result[i] = ToDataSubjectRequestResponse(&reqs[i])
+	}
+	return result
+}
+
+// ToDataSubjectExport arma el paquete de acceso con los candidatos del titular
+// y el resto de sus registros.
+func ToDataSubjectExport(r *models.DataSubjectRequest, candidates []models.Candidate, records *DataSubjectRecords, generatedAt time.Time) DataSubjectExportDTO {
+	if records == nil {
+		records = &DataSubjectRecords{}
+	}
+
+	placementsByCandidate := make(map[uint][]DataSubjectPlacementExportDTO)
+	placementOwner := make(map[uint]uint)
+	for _, p := range records.Placements {
+		item := DataSubjectPlacementExportDTO{
+			ID:            p.ID,
+			ApplicationID: p.ApplicationID,
+			Position:      p.Position,
+			ContractType:  p.ContractType,
+			StartDate:     p.StartDate,
+			EndDate:       p.EndDate,
+			Status:        p.Status,
+			Notes:         p.Notes,
+		}
+		if p.StaffingClient != nil {
+			item.StaffingClientName = p.StaffingClient.Name
+		}
+		placementsByCandidate[p.CandidateID] = append(placementsByCandidate[p.CandidateID], item)
+		placementOwner[p.ID] = p.CandidateID
+	}
+
+	revisions := make(map[uint][]DataSubjectCommentRevisionExportDTO)
+	for _, rv := range records.CommentRevisions {
+		revisions[rv.CommentID] = append(revisions[rv.CommentID], DataSubjectCommentRevisionExportDTO{
+			Version:  rv.Version,
+			Body:     rv.Body,
+			EditedAt: rv.EditedAt,
+		})
+	}
+	commentsByCandidate := make(map[uint][]DataSubjectCommentExportDTO)
+	commentsByApplication := make(map[uint][]DataSubjectCommentExportDTO)
+	for _, cm := range records.Comments {
+		item := DataSubjectCommentExportDTO{
+			ID:          cm.ID,
+			Body:        cm.Body,
+			Internal:    cm.Internal,
+			Source:      cm.Source,
+			CreatedAt:   cm.CreatedAt,
+			EditedAt:    cm.EditedAt,
+			Revisions:   orEmpty(revisions[cm.ID]),
+			Attachments: make([]DataSubjectFileExportDTO, 0, len(cm.Attachments)),
+		}
+		for _, at := range cm.Attachments {
+			item.Attachments = append(item.Attachments, DataSubjectFileExportDTO{
+				Kind:        "attachment",
+				FileName:    at.FileName,
+				ContentType: at.ContentType,
+				Size:        at.Size,
+			})
+		}
+		switch {
+		case cm.ApplicationID != nil:
+			commentsByApplication[*cm.ApplicationID] = append(commentsByApplication[*cm.ApplicationID], item)
+		case cm.CandidateID != nil:
+			commentsByCandidate[*cm.CandidateID] = append(commentsByCandidate[*cm.CandidateID], item)
+		}
+	}
+
+	stageHistory := make(map[uint][]DataSubjectStageEventExportDTO)
+	for _, e := range records.StageEvents {
+		stageHistory[e.ApplicationID] = append(stageHistory[e.ApplicationID], DataSubjectStageEventExportDTO{
+			FromStage:  e.FromStage,
+			ToStage:    e.ToStage,
+			Reason:     e.Reason,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+	screening := make(map[uint][]DataSubjectScreeningExportDTO)
+	for _, a := range records.ScreeningAnswers {
+		screening[a.ApplicationID] = append(screening[a.ApplicationID], DataSubjectScreeningExportDTO{
+			Prompt:     a.Prompt,
+			Kind:       a.Kind,
+			Answer:     a.Answer,
+			KnockedOut: a.KnockedOut,
+		})
+	}
+	scorecards := make(map[uint][]DataSubjectScorecardExportDTO)
+	for _, sc := range records.Scorecards {
+		scorecards[sc.ApplicationID] = append(scorecards[sc.ApplicationID], DataSubjectScorecardExportDTO{
+			Stage:          sc.Stage,
+			Recommendation: sc.Recommendation,
+			Summary:        sc.Summary,
+			Ratings:        orEmpty([]models.ScorecardRating(sc.Ratings)),
+			Answers:        orEmpty([]models.ScorecardAnswer(sc.Answers)),
+			SubmittedAt:    sc.SubmittedAt,
+		})
+	}
+	interviews := make(map[uint][]DataSubjectInterviewExportDTO)
+	for _, iv := range records.Interviews {
+		interviews[iv.ApplicationID] = append(interviews[iv.ApplicationID], DataSubjectInterviewExportDTO{
+			Stage:        iv.Stage,
+			Title:        iv.Title,
+			StartsAt:     iv.StartsAt,
+			EndsAt:       iv.EndsAt,
+			Timezone:     iv.Timezone,
+			Location:     iv.Location,
+			Status:       iv.Status,
+			CancelReason: iv.CancelReason,
+		})
+	}
+	offers := make(map[uint][]DataSubjectOfferExportDTO)
+	for _, o := range records.Offers {
+		item := DataSubjectOfferExportDTO{
+			ID:           o.ID,
+			Status:       o.Status,
+			SentAt:       o.SentAt,
+			RespondedAt:  o.RespondedAt,
+			ResponseNote: o.ResponseNote,
+			Versions:     make([]DataSubjectOfferVersionExportDTO, 0, len(o.Versions)),
+		}
+		for _, v := range o.Versions {
+			item.Versions = append(item.Versions, DataSubjectOfferVersionExportDTO{
+				Version:   v.Version,
+				Salary:    v.Salary,
+				Currency:  v.Currency,
+				StartDate: v.StartDate,
+				Equity:    v.Equity,
+				Bonus:     v.Bonus,
+				ExpiresAt: v.ExpiresAt,
+				Notes:     v.Notes,
+			})
+		}
+		offers[o.ApplicationID] = append(offers[o.ApplicationID], item)
+	}
+	documentsByApplication := make(map[uint][]DataSubjectDocumentExportDTO)
+	documentsByCandidate := make(map[uint][]DataSubjectDocumentExportDTO)
+	for _, d := range records.Documents {
+		item := DataSubjectDocumentExportDTO{ID: d.ID, Kind: d.Kind, FileName: d.FileName, Size: d.Size, CreatedAt: d.CreatedAt}
+		switch {
+		case d.ApplicationID != nil:
+			documentsByApplication[*d.ApplicationID] = append(documentsByApplication[*d.ApplicationID], item)
+		case d.PlacementID != nil:
+			owner := placementOwner[*d.PlacementID]
+			documentsByCandidate[owner] = append(documentsByCandidate[owner], item)
+		}
+	}
+
+	tags := make(map[uint][]string)
+	for _, t := range records.Tags {
+		if t.Tag != nil {
+			tags[t.CandidateID] = append(tags[t.CandidateID], t.Tag.Name)
+		}
+	}
+	skills := make(map[uint][]DataSubjectSkillExportDTO)
+	for _, sk := range records.Skills {
+		item := DataSubjectSkillExportDTO{Level: sk.Level, Years: sk.Years, Source: sk.Source}
+		if sk.Skill != nil {
+			item.Name = sk.Skill.Name
+		}
+		skills[sk.CandidateID] = append(skills[sk.CandidateID], item)
+	}
+	pools := make(map[uint][]DataSubjectPoolExportDTO)
+	for _, m := range records.PoolMemberships {
+		pools[m.CandidateID] = append(pools[m.CandidateID], DataSubjectPoolExportDTO{PoolID: m.PoolID, Note: m.Note, AddedAt: m.CreatedAt})
+	}
+	parses := make(map[uint][]DataSubjectResumeParseExportDTO)
+	for _, rp := range records.ResumeParses {
+		parses[rp.CandidateID] = append(parses[rp.CandidateID], DataSubjectResumeParseExportDTO{
+			ResumeURL: rp.ResumeURL,
+			Status:    rp.Status,
+			ParsedAt:  rp.ParsedAt,
+			Data:      rp.Data.Data(),
+		})
+	}
+
+	export := DataSubjectExportDTO{
+		RequestID:    r.ID,
+		SubjectEmail: r.SubjectEmail,
+		GeneratedAt:  generatedAt,
+		Candidates:   make([]DataSubjectCandidateExportDTO, 0, len(candidates)),
+	}
+	for _, c := range candidates {
+		item := DataSubjectCandidateExportDTO{
+			Profile: DataSubjectProfileExportDTO{
+				CandidateID:         c.ID,
+				CompanyID:           c.CompanyID,
+				Email:               c.Email,
+				FirstName:           c.FirstName,
+				LastName:            c.LastName,
+				Phone:               c.Phone,
+				GithubURL:           c.GithubURL,
+				LinkedinURL:         c.LinkedinURL,
+				CityID:              c.CityID,
+				WorkMode:            c.WorkMode,
+				OpenToRelocate:      c.OpenToRelocate,
+				SalaryExpectation:   c.SalaryExpectation,
+				YearsOfExperience:   c.YearsOfExperience,
+				TalentPoolConsentAt: c.TalentPoolConsentAt,
+				Source:              c.Source,
+				CreatedAt:           c.CreatedAt,
+				UpdatedAt:           c.UpdatedAt,
+				DeletedAt:           deletedAt(c.BaseModel),
+			},
+			Applications: make([]DataSubjectApplicationExportDTO, 0, len(c.Applications)),
+			Placements:   orEmpty(placementsByCandidate[c.ID]),
+			Comments:     orEmpty(commentsByCandidate[c.ID]),
+			Tags:         orEmpty(tags[c.ID]),
+			Skills:       orEmpty(skills[c.ID]),
+			TalentPools:  orEmpty(pools[c.ID]),
+			ResumeParses: orEmpty(parses[c.ID]),
+			Documents:    orEmpty(documentsByCandidate[c.ID]),
+			Files:        []DataSubjectFileExportDTO{},
+			Consents:     make([]DataSubjectConsentExportDTO, 0, len(c.Consents)),
+		}
+		for _, cs := range c.Consents {
+			item.Consents = append(item.Consents, DataSubjectConsentExportDTO{
+				Purpose:       cs.Purpose,
+				Method:        cs.Method,
+				NoticeVersion: cs.NoticeVersion,
+				NoticeURL:     cs.NoticeURL,
+				IPAddress:     cs.IPAddress,
+				UserAgent:     cs.UserAgent,
+				GivenAt:       cs.GivenAt,
+				RevokedAt:     cs.RevokedAt,
+			})
+		}
+		if c.Company != nil {
+			item.Profile.CompanyName = c.Company.Name
+		}
+		if c.ResumeURL != "" {
+			item.Files = append(item.Files, DataSubjectFileExportDTO{Kind: "resume", URL: c.ResumeURL})
+		}
+		for _, a := range c.Applications {
+			app := DataSubjectApplicationExportDTO{
+				ID:               a.ID,
+				JobID:            a.JobID,
+				Stage:            a.Stage,
+				Rating:           a.Rating,
+				Notes:            a.Notes,
+				AppliedAt:        a.AppliedAt,
+				RejectedAt:       a.RejectedAt,
+				RejectionType:    a.RejectionType,
+				RejectionReason:  a.RejectionReason,
+				HiredAt:          a.HiredAt,
+				DeletedAt:        deletedAt(a.BaseModel),
+				Comments:         orEmpty(commentsByApplication[a.ID]),
+				StageHistory:     orEmpty(stageHistory[a.ID]),
+				ScreeningAnswers: orEmpty(screening[a.ID]),
+				Scorecards:       orEmpty(scorecards[a.ID]),
+				Interviews:       orEmpty(interviews[a.ID]),
+				Offers:           orEmpty(offers[a.ID]),
+				Documents:        orEmpty(documentsByApplication[a.ID]),
+			}
+			if a.Job != nil {
+				app.JobTitle = a.Job.Title
+			}
+			item.Applications = append(item.Applications, app)
+		}
+		export.Candidates = append(export.Candidates, item)
+	}
+	return export
+}
+
+// orEmpty evita que una sección vacía salga como null en el paquete.
+func orEmpty[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func deletedAt(m models.BaseModel) *time.Time {
+	if !m.DeletedAt.Valid {
+		return nil
+	}
+	return &m.DeletedAt.Time
+}
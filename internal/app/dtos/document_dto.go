@@ -0,0 +1,20 @@
+package dtos
+
+// DocumentTemplateDTO crea o reemplaza una plantilla de documento. body usa
+// la sintaxis de text/template ({{.Candidate.FullName}}); las líneas que
+// empiezan con "# " son títulos. Ver GET /document-templates/variables.
+type DocumentTemplateDTO struct {
+	Name   string `json:"name" binding:"required,max=150"`
+	Kind   string `json:"kind" binding:"required,oneof=offer_letter placement_contract general"`
+	Locale string `json:"locale,omitempty" binding:"omitempty,oneof=es en pt"` // por defecto es
+	Title  string `json:"title" binding:"required,max=255"`
+	Body   string `json:"body" binding:"required"`
+	Active *bool  `json:"active,omitempty"` // por defecto true
+}
+
+// GenerateDocumentDTO genera un PDF desde una plantilla. offer_id elige la
+// oferta cuyos términos se usan; sin él se toma la más reciente vigente.
+type GenerateDocumentDTO struct {
+	TemplateID uint `json:"template_id" binding:"required,min=1"`
+	OfferID    uint `json:"offer_id,omitempty"`
+}
@@ -0,0 +1,199 @@
+package dtos
+
+import "time"
+
+// ScheduleInterviewDTO representa una entrevista nueva. Las horas sin zona
+// (2006-01-02T15:04) se interpretan en la zona de la empresa; sin ends_at
+// dura duration_minutes (por defecto 60). Stage vacío = etapa actual de la
+// postulación. Con allow_conflicts se agenda aunque un entrevistador ya
+// tenga otra entrevista en ese horario.
+type ScheduleInterviewDTO struct {
+	ApplicationID   uint   `json:"application_id" binding:"required,min=1"`
+	Stage           string `json:"stage,omitempty" binding:"omitempty,max=50"`
+	Title           string `json:"title,omitempty" binding:"omitempty,max=200"`
+	StartsAt        string `json:"starts_at" binding:"required"`
+	EndsAt          string `json:"ends_at,omitempty"`
+	DurationMinutes int    `json:"duration_minutes,omitempty" binding:"omitempty,min=5,max=720"`
+	Location        string `json:"location,omitempty" binding:"omitempty,max=300"`
+	VideoURL        string `json:"video_url,omitempty" binding:"omitempty,url,max=500"`
+	PanelIDs        []uint `json:"panel_ids" binding:"required,min=1,max=20"`
+	NotifyCandidate *bool  `json:"notify_candidate,omitempty"` // por defecto true
+	AllowConflicts  bool   `json:"allow_conflicts"`
+}
+
+// UpdateInterviewDTO reemplaza horario, lugar y panel de una entrevista
+// agendada. Si cambia algo se envía la invitación actualizada; quienes salen
+// del panel reciben la cancelación.
+type UpdateInterviewDTO struct {
+	Title           string `json:"title,omitempty" binding:"omitempty,max=200"`
+	StartsAt        string `json:"starts_at" binding:"required"`
+	EndsAt          string `json:"ends_at,omitempty"`
+	DurationMinutes int    `json:"duration_minutes,omitempty" binding:"omitempty,min=5,max=720"`
+	Location        string `json:"location,omitempty" binding:"omitempty,max=300"`
+	VideoURL        string `json:"video_url,omitempty" binding:"omitempty,url,max=500"`
+	PanelIDs        []uint `json:"panel_ids" binding:"required,min=1,max=20"`
+	NotifyCandidate *bool  `json:"notify_candidate,omitempty"`
+	AllowConflicts  bool   `json:"allow_conflicts"`
+}
+
+// CancelInterviewDTO representa la cancelación de una entrevista.
+type CancelInterviewDTO struct {
+	Reason          string `json:"reason,omitempty" binding:"omitempty,max=500"`
+	NotifyCandidate *bool  `json:"notify_candidate,omitempty"`
+}
+
+// InterviewStatusDTO cierra una entrevista agendada.
+type InterviewStatusDTO struct {
+	Status string `json:"status" binding:"required,oneof=completed no_show"`
+}
+
+// InterviewPanelistDTO es un entrevistador en la respuesta.
+type InterviewPanelistDTO struct {
+	UserID uint   `json:"user_id"`
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+// InterviewDTO representa una entrevista en la respuesta. StartsAt/EndsAt van
+// con el offset de la zona de la empresa (Timezone).
+type InterviewDTO struct {
+	ID            uint                   `json:"id"`
+	ApplicationID uint                   `json:"application_id"`
+	CandidateName string                 `json:"candidate_name,omitempty"`
+	JobTitle      string                 `json:"job_title,omitempty"`
+	Stage         string                 `json:"stage"`
+	Title         string                 `json:"title"`
+	StartsAt      time.Time              `json:"starts_at"`
+	EndsAt        time.Time              `json:"ends_at"`
+	Timezone      string                 `json:"timezone"`
+	Location      string                 `json:"location,omitempty"`
+	VideoURL      string                 `json:"video_url,omitempty"`
+	Status        string                 `json:"status"`
+	CancelReason  string                 `json:"cancel_reason,omitempty"`
+	Sequence      int                    `json:"sequence"`
+	OrganizerID   *uint                  `json:"organizer_id,omitempty"`
+	Panel         []InterviewPanelistDTO `json:"panel"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+// InterviewConflictDTO es una entrevista existente que se solapa con el
+// horario pedido para un entrevistador.
+type InterviewConflictDTO struct {
+	UserID      uint      `json:"user_id"`
+	UserName    string    `json:"user_name,omitempty"`
+	InterviewID uint      `json:"interview_id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+}
+
+// AvailabilityWindowDTO es una franja semanal: weekday 0 (domingo) a 6
+// (sábado) y horas HH:MM en la zona de la empresa.
+type AvailabilityWindowDTO struct {
+	Weekday int    `json:"weekday" binding:"min=0,max=6"`
+	Start   string `json:"start" binding:"required"`
+	End     string `json:"end" binding:"required"`
+}
+
+// SetAvailabilityDTO reemplaza las franjas semanales de un entrevistador
+// (user_id vacío = el usuario autenticado). Una lista vacía lo deja sin
+// horarios para autoagenda.
+type SetAvailabilityDTO struct {
+	UserID  uint                    `json:"user_id,omitempty"`
+	Windows []AvailabilityWindowDTO `json:"windows" binding:"max=50,dive"`
+}
+
+// CreateBlackoutDTO bloquea un período: from/to son fechas (to inclusive) u
+// horas en la zona de la empresa.
+type CreateBlackoutDTO struct {
+	UserID uint   `json:"user_id,omitempty"`
+	From   string `json:"from" binding:"required"`
+	To     string `json:"to" binding:"required"`
+	Reason string `json:"reason,omitempty" binding:"omitempty,max=200"`
+}
+
+// BlackoutDTO es un bloqueo en la respuesta.
+type BlackoutDTO struct {
+	ID       uint      `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// AvailabilityDTO es la disponibilidad de un entrevistador: franjas
+// semanales y bloqueos vigentes.
+type AvailabilityDTO struct {
+	UserID    uint                    `json:"user_id"`
+	Timezone  string                  `json:"timezone"`
+	Windows   []AvailabilityWindowDTO `json:"windows"`
+	Blackouts []BlackoutDTO           `json:"blackouts"`
+}
+
+// CreateSchedulingLinkDTO crea un enlace de autoagenda para una postulación:
+// el candidato elige entre los horarios en que todo el panel está libre en
+// los próximos days días (por defecto 14). buffer_minutes es el margen libre
+// que se exige antes y después de otras entrevistas del panel.
+type CreateSchedulingLinkDTO struct {
+	ApplicationID   uint   `json:"application_id" binding:"required,min=1"`
+	Stage           string `json:"stage,omitempty" binding:"omitempty,max=50"`
+	Title           string `json:"title,omitempty" binding:"omitempty,max=200"`
+	PanelIDs        []uint `json:"panel_ids" binding:"required,min=1,max=20"`
+	DurationMinutes int    `json:"duration_minutes,omitempty" binding:"omitempty,min=15,max=480"`
+	BufferMinutes   int    `json:"buffer_minutes,omitempty" binding:"omitempty,min=0,max=120"`
+	Days            int    `json:"days,omitempty" binding:"omitempty,min=1,max=60"`
+	Location        string `json:"location,omitempty" binding:"omitempty,max=300"`
+	VideoURL        string `json:"video_url,omitempty" binding:"omitempty,url,max=500"`
+	SendEmail       *bool  `json:"send_email,omitempty"` // por defecto true
+}
+
+// SchedulingLinkDTO es un enlace de autoagenda en la respuesta. Token y URL
+// solo vienen al crearlo: después no se pueden recuperar.
+type SchedulingLinkDTO struct {
+	ID              uint       `json:"id"`
+	ApplicationID   uint       `json:"application_id"`
+	Stage           string     `json:"stage"`
+	Title           string     `json:"title,omitempty"`
+	PanelIDs        []uint     `json:"panel_ids"`
+	DurationMinutes int        `json:"duration_minutes"`
+	BufferMinutes   int        `json:"buffer_minutes"`
+	Status          string     `json:"status"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	InterviewID     *uint      `json:"interview_id,omitempty"`
+	BookedAt        *time.Time `json:"booked_at,omitempty"`
+	Token           string     `json:"token,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// SlotDTO es un horario libre ofrecido al candidato.
+type SlotDTO struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// PublicSchedulingDTO es lo que ve el candidato al abrir su enlace.
+type PublicSchedulingDTO struct {
+	CompanyName     string    `json:"company_name"`
+	JobTitle        string    `json:"job_title"`
+	CandidateName   string    `json:"candidate_name,omitempty"`
+	Title           string    `json:"title"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Timezone        string    `json:"timezone"`
+	Location        string    `json:"location,omitempty"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	Slots           []SlotDTO `json:"slots"`
+}
+
+// BookSlotDTO reserva uno de los horarios ofrecidos.
+type BookSlotDTO struct {
+	StartsAt string `json:"starts_at" binding:"required"`
+}
+
+// BookedInterviewDTO confirma la reserva al candidato (sin datos internos).
+type BookedInterviewDTO struct {
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Timezone string    `json:"timezone"`
+	Location string    `json:"location,omitempty"`
+	VideoURL string    `json:"video_url,omitempty"`
+}
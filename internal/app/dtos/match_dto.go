@@ -0,0 +1,8 @@
+package dtos
+
+// MatchQuery pagina GET /jobs/:id/matches: los limit candidatos con mayor
+// puntaje (por defecto 20, hasta 100) desde min_score.
+type MatchQuery struct {
+	Limit    int `form:"limit" binding:"omitempty,min=1,max=100"`
+	MinScore int `form:"min_score" binding:"omitempty,min=0,max=100"`
+}
@@ -0,0 +1,69 @@
+package dtos
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// OfferTermsDTO son los términos de una versión de la oferta. start_date es
+// una fecha (2006-01-02); expires_at una fecha (vence al terminar ese día en
+// la zona de la empresa) o una hora RFC 3339.
+type OfferTermsDTO struct {
+	Salary    float64  `json:"salary" binding:"required,gt=0"`
+	Currency  string   `json:"currency" binding:"required,len=3"`
+	StartDate string   `json:"start_date,omitempty"`
+	Equity    string   `json:"equity,omitempty" binding:"omitempty,max=200"`
+	Bonus     *float64 `json:"bonus,omitempty" binding:"omitempty,min=0"`
+	ExpiresAt string   `json:"expires_at" binding:"required"`
+	Notes     string   `json:"notes,omitempty" binding:"omitempty,max=5000"`
+}
+
+// CreateOfferDTO crea la oferta de una postulación con su primera versión.
+type CreateOfferDTO struct {
+	ApplicationID uint `json:"application_id" binding:"required,min=1"`
+	OfferTermsDTO
+}
+
+// OfferDecisionDTO acompaña la aprobación o el rechazo de un paso.
+type OfferDecisionDTO struct {
+	Comment string `json:"comment,omitempty" binding:"omitempty,max=2000"`
+}
+
+// OfferResponseDTO registra la respuesta del candidato a una oferta enviada.
+type OfferResponseDTO struct {
+	Response string `json:"response" binding:"required,oneof=accepted declined negotiating"`
+	Note     string `json:"note,omitempty" binding:"omitempty,max=5000"`
+}
+
+// OfferApprovalStepDTO es un paso de la cadena de aprobación de la empresa.
+type OfferApprovalStepDTO struct {
+	Role      string `json:"role" binding:"required,oneof=admin recruiter hiring_manager"`
+	Condition string `json:"condition,omitempty" binding:"omitempty,oneof=always above_salary_max"` // por defecto always
+}
+
+// OfferApprovalChainDTO reemplaza la cadena de aprobación (en orden). Vacía:
+// solo las ofertas sobre el máximo de la vacante requieren aprobación (admin).
+type OfferApprovalChainDTO struct {
+	Steps []OfferApprovalStepDTO `json:"steps" binding:"max=10,dive"`
+}
+
+// OfferDTO es una oferta en la respuesta: términos vigentes, historial de
+// versiones y aprobaciones. AwaitingRole es el rol del paso pendiente.
+type OfferDTO struct {
+	ID             uint                   `json:"id"`
+	ApplicationID  uint                   `json:"application_id"`
+	CandidateName  string                 `json:"candidate_name,omitempty"`
+	JobTitle       string                 `json:"job_title,omitempty"`
+	Status         string                 `json:"status"`
+	CurrentVersion int                    `json:"current_version"`
+	Terms          *models.OfferVersion   `json:"terms,omitempty"`
+	AwaitingRole   string                 `json:"awaiting_role,omitempty"`
+	SentAt         *time.Time             `json:"sent_at,omitempty"`
+	RespondedAt    *time.Time             `json:"responded_at,omitempty"`
+	ResponseNote   string                 `json:"response_note,omitempty"`
+	CreatedByID    *uint                  `json:"created_by_id,omitempty"`
+	Versions       []models.OfferVersion  `json:"versions,omitempty"`
+	Approvals      []models.OfferApproval `json:"approvals,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
@@ -0,0 +1,114 @@
+package dtos
+
+import (
+	"dvra-api/internal/app/models"
+
+	"gorm.io/datatypes"
+)
+
+// PipelineStageDTO representa una etapa al definir un pipeline. El orden del
+// array es el orden de las etapas.
+type PipelineStageDTO struct {
+	Key   string `json:"key" binding:"required,max=50"`
+	Name  string `json:"name" binding:"required,max=100"`
+	Type  string `json:"type" binding:"required,oneof=active hired rejected"`
+	Color string `json:"color,omitempty"`
+	// Keys destino permitidas desde esta etapa. Vacío = siguiente etapa
+	// activa + rejected.
+	Transitions []string `json:"transitions,omitempty" binding:"omitempty,dive,max=50"`
+}
+
+// CreatePipelineDTO representa la creación de un pipeline propio para una
+// vacante. Sin stages se copian las del pipeline de la empresa.
+type CreatePipelineDTO struct {
+	JobID  uint               `json:"job_id" binding:"required,min=1"`
+	Name   string             `json:"name,omitempty" binding:"omitempty,max=100"`
+	Stages []PipelineStageDTO `json:"stages,omitempty" binding:"omitempty,dive"`
+}
+
+// UpdatePipelineDTO representa el reemplazo de las etapas de un pipeline
+type UpdatePipelineDTO struct {
+	Name   string             `json:"name,omitempty" binding:"omitempty,max=100"`
+	Stages []PipelineStageDTO `json:"stages" binding:"required,min=3,dive"`
+}
+
+// PipelineStageResponseDTO representa una etapa en las respuestas
+type PipelineStageResponseDTO struct {
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Color       string   `json:"color,omitempty"`
+	Position    int      `json:"position"`
+	Transitions []string `json:"transitions,omitempty"`
+}
+
+// PipelineResponseDTO representa un pipeline con sus etapas ordenadas
+type PipelineResponseDTO struct {
+	ID        uint                       `json:"id"`
+	CompanyID uint                       `json:"company_id"`
+	JobID     *uint                      `json:"job_id,omitempty"`
+	Name      string                     `json:"name"`
+	IsDefault bool                       `json:"is_default"`
+	Stages    []PipelineStageResponseDTO `json:"stages"`
+}
+
+// ToPipelineStages convierte las etapas del request a modelos, con la
+// posición según su orden.
+func ToPipelineStages(stages []PipelineStageDTO) []models.PipelineStage {
+	result := make([]models.PipelineStage, len(stages))
+	for i, s := range stages {
+		result[i] = models.PipelineStage{
+			Key:      s.Key,
+			Name:     s.Name,
+			Type:     s.Type,
+			Color:    s.Color,
+			Position: i + 1,
+		}
+		if len(s.Transitions) > 0 {
+			result[i].Transitions = datatypes.NewJSONSlice(s.Transitions)
+		}
+	}
+	return result
+}
+
+// ToPipelineStageResponseList convierte etapas a su DTO de respuesta
+func ToPipelineStageResponseList(stages []models.PipelineStage) []PipelineStageResponseDTO {
+	result := make([]PipelineStageResponseDTO, len(stages))
+	for i, s := range stages {
+		result[i] = PipelineStageResponseDTO{
+			Key:         s.Key,
+			Name:        s.Name,
+			Type:        s.Type,
+			Color:       s.Color,
+			Position:    s.Position,
+			Transitions: s.Transitions,
+		}
+	}
+	return result
+}
+
+// ToPipelineResponse convierte un modelo Pipeline a su DTO de respuesta. Las
+// transiciones salen resueltas (incluida la regla por defecto).
+func ToPipelineResponse(p *models.Pipeline) PipelineResponseDTO {
+	stages := ToPipelineStageResponseList(p.Stages)
+	for i := range stages {
+		stages[i].Transitions = p.NextStages(stages[i].Key)
+	}
+	return PipelineResponseDTO{
+		ID:        p.ID,
+		CompanyID: p.CompanyID,
+		JobID:     p.JobID,
+		Name:      p.Name,
+		IsDefault: p.JobID == nil,
+		Stages:    stages,
+	}
+}
+
+// ToPipelineResponseList convierte una lista de pipelines a DTOs
+func ToPipelineResponseList(pipelines []models.Pipeline) []PipelineResponseDTO {
+	result := make([]PipelineResponseDTO, len(pipelines))
+	for i := range pipelines {
+		result[i] = ToPipelineResponse(&pipelines[i])
+	}
+	return result
+}
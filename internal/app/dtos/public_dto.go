@@ -27,10 +27,13 @@ type PublicJobResponseDTO struct {
 	Benefits     string                 `json:"benefits,omitempty"`
 	SalaryMin    *float64               `json:"salary_min,omitempty"`
 	SalaryMax    *float64               `json:"salary_max,omitempty"`
+	Currency     string                 `json:"salary_currency,omitempty"`
 	LocationType string                 `json:"location_type"`
 	City         *PublicCityDTO         `json:"city,omitempty"`
 	CreatedAt    time.Time              `json:"created_at"`
 	Company      *PublicCompanyShortDTO `json:"company,omitempty"`
+	// Questions son las preguntas de screening (solo en el detalle).
+	Questions []PublicScreeningQuestionDTO `json:"questions,omitempty"`
 }
 
 // PublicCityDTO for public responses (simplified version)
@@ -56,8 +59,22 @@ type PublicApplicationDTO struct {
 	LinkedinURL string `json:"linkedin_url,omitempty"`
 	GithubURL   string `json:"github_url,omitempty"`
 	CoverLetter string `json:"cover_letter,omitempty"`
+
+	// Consentimiento (RN-GDPR-001). Consent es obligatorio; TalentPoolConsent
+	// autoriza a contactarlo por futuras vacantes. NoticeVersion es la versión
+	// del aviso mostrada (obligatoria; nil = no se informó y se rechaza).
+	Consent           bool `json:"consent"`
+	TalentPoolConsent bool `json:"talent_pool_consent"`
+	NoticeVersion     *int `json:"notice_version,omitempty"`
+
+	// Answers son las respuestas de screening (pregunta → respuesta); el
+	// handler las lee de los campos answers[<question_id>].
+	Answers map[uint]string `json:"-"`
+
 	// ResumeURL will be set after file upload
 	ResumeURL string `json:"-"`
+	// Evidence la completa el handler (IP, user agent)
+	Evidence ConsentEvidence `json:"-"`
 }
 
 // PublicApplicationResponseDTO represents the response after applying
@@ -95,6 +112,7 @@ func ToPublicJobResponse(job *models.Job) PublicJobResponseDTO {
 		Benefits:     job.Benefits,
 		SalaryMin:    job.SalaryMin,
 		SalaryMax:    job.SalaryMax,
+		Currency:     job.SalaryCurrency,
 		LocationType: job.LocationType,
 		CreatedAt:    job.CreatedAt,
 	}
@@ -0,0 +1,98 @@
+package dtos
+
+// RejectionCountRow es una fila agregada de postulaciones rechazadas: cuántas
+// comparten etapa de salida, vacante, fuente, tipo y motivo. El servicio la
+// reparte en las vistas por etapa, vacante y fuente.
+type RejectionCountRow struct {
+	Stage    string
+	JobID    uint
+	JobTitle string
+	Source   string
+	Type     string
+	Reason   string
+	Count    int
+}
+
+// RejectionReasonCountDTO representa cuántas postulaciones se cerraron con un
+// motivo. Reason vacío = rechazo sin motivo (anterior al catálogo u override).
+type RejectionReasonCountDTO struct {
+	Type   string `json:"type,omitempty"`
+	Reason string `json:"reason"`
+	Label  string `json:"label"`
+	Count  int    `json:"count"`
+}
+
+// RejectionBreakdownDTO son los totales de un grupo con sus motivos de mayor
+// a menor.
+type RejectionBreakdownDTO struct {
+	Total        int                       `json:"total"`
+	RejectedByUs int                       `json:"rejected_by_us"`
+	Withdrew     int                       `json:"candidate_withdrew"`
+	Reasons      []RejectionReasonCountDTO `json:"reasons"`
+}
+
+// RejectionStageDTO representa las pérdidas de una etapa. Reached es cuántas
+// postulaciones llegaron a ella y LossRate el porcentaje de esas que se
+// cerraron desde ahí.
+type RejectionStageDTO struct {
+	Key      string  `json:"key"`
+	Name     string  `json:"name,omitempty"`
+	Reached  int     `json:"reached"`
+	LossRate float64 `json:"loss_rate"`
+	RejectionBreakdownDTO
+}
+
+// RejectionJobDTO representa los rechazos de una vacante
+type RejectionJobDTO struct {
+	JobID uint   `json:"job_id"`
+	Title string `json:"title"`
+	RejectionBreakdownDTO
+}
+
+// RejectionSourceDTO representa los rechazos por fuente del candidato
+type RejectionSourceDTO struct {
+	Source string `json:"source"`
+	RejectionBreakdownDTO
+}
+
+// RejectionStatsDTO resume por qué se cierran las postulaciones de una
+// empresa o de una vacante, por etapa (en el orden del pipeline), vacante y
+// fuente.
+type RejectionStatsDTO struct {
+	CompanyID uint `json:"company_id"`
+	JobID     uint `json:"job_id,omitempty"`
+	RejectionBreakdownDTO
+	ByStage  []RejectionStageDTO  `json:"by_stage"`
+	ByJob    []RejectionJobDTO    `json:"by_job"`
+	BySource []RejectionSourceDTO `json:"by_source"`
+}
+
+// RejectionReasonDTO representa un motivo del catálogo. Global = definido por
+// la plataforma (no editable por la empresa).
+type RejectionReasonDTO struct {
+	ID           uint    `json:"id"`
+	Type         string  `json:"type"`
+	Value        string  `json:"value"`
+	Label        string  `json:"label"`
+	Description  *string `json:"description,omitempty"`
+	DisplayOrder int     `json:"display_order"`
+	Global       bool    `json:"global"`
+}
+
+// CreateRejectionReasonDTO representa un motivo propio de la empresa
+type CreateRejectionReasonDTO struct {
+	Type         string  `json:"type" binding:"required,oneof=rejected_by_us candidate_withdrew"`
+	Value        string  `json:"value" binding:"required,max=100"`
+	Label        string  `json:"label" binding:"required,max=200"`
+	Description  *string `json:"description,omitempty"`
+	DisplayOrder int     `json:"display_order"`
+}
+
+// UpdateRejectionReasonDTO representa los cambios a un motivo de la empresa.
+// Desactivarlo lo oculta del catálogo sin afectar a los rechazos ya hechos.
+type UpdateRejectionReasonDTO struct {
+	Label        *string `json:"label,omitempty" binding:"omitempty,max=200"`
+	Description  *string `json:"description,omitempty"`
+	DisplayOrder *int    `json:"display_order,omitempty"`
+	IsActive     *bool   `json:"is_active,omitempty"`
+}
@@ -0,0 +1,53 @@
+package dtos
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// ResumeParseDTO es el análisis del CV vigente de un candidato. Data está
+// vacío mientras Status sea pending o si falló.
+type ResumeParseDTO struct {
+	ID        uint              `json:"id"`
+	ResumeURL string            `json:"resume_url"`
+	Status    string            `json:"status"`
+	Error     string            `json:"error,omitempty"`
+	Data      models.ResumeData `json:"data"`
+	ParsedAt  *time.Time        `json:"parsed_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ToResumeParseDTO convierte el modelo.
+func ToResumeParseDTO(p *models.ResumeParse) *ResumeParseDTO {
+	return &ResumeParseDTO{
+		ID:        p.ID,
+		ResumeURL: p.ResumeURL,
+		Status:    p.Status,
+		Error:     p.Error,
+		Data:      p.Data.Data(),
+		ParsedAt:  p.ParsedAt,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// ResumeFieldSuggestionDTO es un dato del CV que se puede copiar a la ficha.
+// Current vacío = la ficha no lo tiene.
+type ResumeFieldSuggestionDTO struct {
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Current string `json:"current"`
+}
+
+// CandidateResumeDTO es el análisis del CV vigente (nil si el candidato no
+// tiene CV o todavía no se analizó) y los campos que se pueden completar.
+type CandidateResumeDTO struct {
+	Parse       *ResumeParseDTO            `json:"parse"`
+	Suggestions []ResumeFieldSuggestionDTO `json:"suggestions"`
+}
+
+// ApplyResumeFieldsDTO elige qué sugerencias copiar a la ficha del
+// candidato (first_name, last_name, phone, linkedin_url, github_url).
+type ApplyResumeFieldsDTO struct {
+	Fields []string `json:"fields" binding:"required,min=1,dive,oneof=first_name last_name phone linkedin_url github_url"`
+}
@@ -0,0 +1,108 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// RetentionPolicyResponseDTO representa una política de retención efectiva.
+// Source indica de dónde sale: "company" (override de la empresa) o "platform".
+type RetentionPolicyResponseDTO struct {
+	ID              uint      `json:"id"`
+	Rule            string    `json:"rule"`
+	RetentionMonths int       `json:"retention_months"`
+	Action          string    `json:"action"`
+	IsActive        bool      `json:"is_active"`
+	Source          string    `json:"source"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// UpsertRetentionPolicyDTO representa la configuración de una regla de retención
+type UpsertRetentionPolicyDTO struct {
+	RetentionMonths int    `json:"retention_months" binding:"required,min=1,max=240"`
+	Action          string `json:"action" binding:"required,oneof=anonymize delete"`
+	IsActive        *bool  `json:"is_active,omitempty"`
+}
+
+// RetentionPreviewDTO representa lo que una política afectaría si corriera ahora
+type RetentionPreviewDTO struct {
+	Rule            string    `json:"rule"`
+	Action          string    `json:"action"`
+	RetentionMonths int       `json:"retention_months"`
+	Cutoff          time.Time `json:"cutoff"`
+	AffectedCount   int       `json:"affected_count"`
+	SampleIDs       []uint    `json:"sample_ids"`
+}
+
+// RetentionRunResponseDTO representa una ejecución registrada de una política
+type RetentionRunResponseDTO struct {
+	ID            uint       `json:"id"`
+	Rule          string     `json:"rule"`
+	Action        string     `json:"action"`
+	Cutoff        time.Time  `json:"cutoff"`
+	AffectedCount int        `json:"affected_count"`
+	AffectedIDs   []uint     `json:"affected_ids"`
+	Trigger       string     `json:"trigger"`
+	TriggeredByID *uint      `json:"triggered_by_id,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// ToRetentionPolicyResponse convierte un modelo RetentionPolicy a su DTO de respuesta
+func ToRetentionPolicyResponse(p *models.RetentionPolicy) RetentionPolicyResponseDTO {
+	source := "platform"
+	if p.CompanyID != nil {
+		source = "company"
+	}
+	return RetentionPolicyResponseDTO{
+		ID:              p.ID,
+		Rule:            p.Rule,
+		RetentionMonths: p.RetentionMonths,
+		Action:          p.Action,
+		IsActive:        p.IsActive,
+		Source:          source,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+// ToRetentionPolicyResponseList convierte un slice de RetentionPolicy a DTOs
+func ToRetentionPolicyResponseList(policies []models.RetentionPolicy) []RetentionPolicyResponseDTO {
+	result := make([]RetentionPolicyResponseDTO, len(policies))
+	for i := range policies {
+		result[i] = ToRetentionPolicyResponse(&policies[i])
+	}
+	return result
+}
+
+// ToRetentionRunResponse convierte un modelo RetentionRun a su DTO de respuesta
+func ToRetentionRunResponse(r *models.RetentionRun) RetentionRunResponseDTO {
+	ids := []uint{}
+	if len(r.AffectedIDs) > 0 {
+		_ = json.Unmarshal(r.AffectedIDs, &ids)
+	}
+	return RetentionRunResponseDTO{
+		ID:            r.ID,
+		Rule:          r.Rule,
+		Action:        r.Action,
+		Cutoff:        r.Cutoff,
+		AffectedCount: r.AffectedCount,
+		AffectedIDs:   ids,
+		Trigger:       r.Trigger,
+		TriggeredByID: r.TriggeredByID,
+		Error:         r.Error,
+		StartedAt:     r.StartedAt,
+		FinishedAt:    r.FinishedAt,
+	}
+}
+
+// ToRetentionRunResponseList convierte un slice de RetentionRun a DTOs
+func ToRetentionRunResponseList(runs []models.RetentionRun) []RetentionRunResponseDTO {
+	result := make([]RetentionRunResponseDTO, len(runs))
+	for i := range runs {
+		result[i] = ToRetentionRunResponse(&runs[i])
+	}
+	return result
+}
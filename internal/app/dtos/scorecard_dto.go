@@ -0,0 +1,71 @@
+package dtos
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// ScorecardTemplateDTO representa una plantilla en create/update. Stage vacío
+// = plantilla general de la vacante; ScaleMax 0 = escala por defecto (1-5).
+type ScorecardTemplateDTO struct {
+	JobID        uint                         `json:"job_id" binding:"required,min=1"`
+	Stage        string                       `json:"stage,omitempty" binding:"omitempty,max=50"`
+	Name         string                       `json:"name" binding:"required,max=150"`
+	Competencies []models.ScorecardCompetency `json:"competencies" binding:"required,min=1,max=30"`
+	Questions    []models.ScorecardQuestion   `json:"questions,omitempty" binding:"omitempty,max=30"`
+}
+
+// SubmitScorecardDTO representa la evaluación de un entrevistador. Stage
+// vacío = etapa actual de la postulación.
+type SubmitScorecardDTO struct {
+	Stage          string                   `json:"stage,omitempty" binding:"omitempty,max=50"`
+	Ratings        []models.ScorecardRating `json:"ratings,omitempty" binding:"omitempty,max=30"`
+	Answers        []models.ScorecardAnswer `json:"answers,omitempty" binding:"omitempty,max=30"`
+	Recommendation string                   `json:"recommendation" binding:"required,oneof=strong_no no yes strong_yes"`
+	Summary        string                   `json:"summary,omitempty" binding:"omitempty,max=5000"`
+}
+
+// ScorecardDTO representa una evaluación en la respuesta. Si Hidden, el
+// contenido se omite: el usuario aún no envió la suya para esa etapa.
+type ScorecardDTO struct {
+	ID              uint                     `json:"id"`
+	Stage           string                   `json:"stage"`
+	InterviewerID   uint                     `json:"interviewer_id"`
+	InterviewerName string                   `json:"interviewer_name,omitempty"`
+	SubmittedAt     time.Time                `json:"submitted_at"`
+	Hidden          bool                     `json:"hidden"`
+	Recommendation  string                   `json:"recommendation,omitempty"`
+	Ratings         []models.ScorecardRating `json:"ratings,omitempty"`
+	Answers         []models.ScorecardAnswer `json:"answers,omitempty"`
+	Summary         string                   `json:"summary,omitempty"`
+}
+
+// CompetencyScoreDTO es el promedio de una competencia entre evaluaciones.
+// AvgPercent lo expresa sobre su escala (para comparar escalas distintas).
+type CompetencyScoreDTO struct {
+	Key        string  `json:"key"`
+	Count      int     `json:"count"`
+	ScaleMax   int     `json:"scale_max"`
+	AvgScore   float64 `json:"avg_score"`
+	AvgPercent float64 `json:"avg_percent"`
+}
+
+// ScorecardSummaryDTO agrega las evaluaciones que el usuario puede ver.
+// Submitted cuenta todas; Hidden, las que aún no puede ver.
+// AverageRecommendation va de 1 (strong_no) a 4 (strong_yes).
+type ScorecardSummaryDTO struct {
+	Submitted             int                  `json:"submitted"`
+	Hidden                int                  `json:"hidden"`
+	Recommendations       map[string]int       `json:"recommendations"`
+	AverageRecommendation float64              `json:"average_recommendation"`
+	Competencies          []CompetencyScoreDTO `json:"competencies"`
+}
+
+// ApplicationScorecardsDTO son las evaluaciones de una postulación con su
+// resumen y la plantilla de la etapa actual (nil si la vacante no tiene).
+type ApplicationScorecardsDTO struct {
+	Items    []ScorecardDTO            `json:"items"`
+	Summary  ScorecardSummaryDTO       `json:"summary"`
+	Template *models.ScorecardTemplate `json:"template,omitempty"`
+}
@@ -0,0 +1,70 @@
+package dtos
+
+import "dvra-api/internal/app/models"
+
+// ScreeningQuestionInput es una pregunta de la vacante. Con id actualiza la
+// existente; sin id la crea. Las reglas knockout dependen de kind:
+// expected_answer (yes_no), accepted_options (choice) o min_value/max_value
+// (number); text no admite knockout.
+type ScreeningQuestionInput struct {
+	ID              uint     `json:"id,omitempty"`
+	Kind            string   `json:"kind" binding:"required,oneof=yes_no choice number text"`
+	Prompt          string   `json:"prompt" binding:"required,max=500"`
+	Options         []string `json:"options,omitempty" binding:"max=20,dive,max=200"`
+	Required        bool     `json:"required"`
+	Knockout        bool     `json:"knockout"`
+	ExpectedAnswer  *bool    `json:"expected_answer,omitempty"`
+	AcceptedOptions []string `json:"accepted_options,omitempty" binding:"max=20,dive,max=200"`
+	MinValue        *float64 `json:"min_value,omitempty"`
+	MaxValue        *float64 `json:"max_value,omitempty"`
+	// RejectionReason es un motivo del catálogo rejected_by_us; obligatorio
+	// si la pregunta es knockout.
+	RejectionReason string `json:"rejection_reason,omitempty" binding:"max=100"`
+}
+
+// SetScreeningQuestionsDTO reemplaza las preguntas de una vacante, en el
+// orden dado (lista vacía = quitarlas todas).
+type SetScreeningQuestionsDTO struct {
+	Questions []ScreeningQuestionInput `json:"questions" binding:"max=20,dive"`
+}
+
+// ScreeningOutcome es el resultado de evaluar las respuestas de una
+// postulación: las respuestas normalizadas y, si alguna pregunta knockout no
+// se cumplió, el motivo de rechazo (catálogo rejected_by_us) y el resumen
+// para el historial de etapas.
+type ScreeningOutcome struct {
+	Answers         []models.ScreeningAnswer
+	RejectionReason string
+	Reason          string
+}
+
+// KnockedOut indica que la postulación se descarta.
+func (o *ScreeningOutcome) KnockedOut() bool {
+	return o != nil && o.RejectionReason != ""
+}
+
+// PublicScreeningQuestionDTO es una pregunta tal como la ve el candidato en
+// la career page: sin las reglas knockout.
+type PublicScreeningQuestionDTO struct {
+	ID       uint     `json:"id"`
+	Kind     string   `json:"kind"`
+	Prompt   string   `json:"prompt"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// ToPublicScreeningQuestions convierte las preguntas de la vacante a su
+// versión pública.
+func ToPublicScreeningQuestions(questions []models.ScreeningQuestion) []PublicScreeningQuestionDTO {
+	result := make([]PublicScreeningQuestionDTO, len(questions))
+	for i, q := range questions {
+		result[i] = PublicScreeningQuestionDTO{
+			ID:       q.ID,
+			Kind:     q.Kind,
+			Prompt:   q.Prompt,
+			Options:  q.Options,
+			Required: q.Required,
+		}
+	}
+	return result
+}
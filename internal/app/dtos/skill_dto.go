@@ -0,0 +1,58 @@
+package dtos
+
+// SkillFilters filtra GET /skills: q busca en nombre y alias.
+type SkillFilters struct {
+	Q        string `form:"q"`
+	Category string `form:"category"`
+}
+
+// CreateSkillDTO agrega una habilidad al catálogo de la empresa. Los alias
+// son otras formas de escribirla ("JS" para JavaScript).
+type CreateSkillDTO struct {
+	Name     string   `json:"name" binding:"required,max=100"`
+	Category string   `json:"category" binding:"required,oneof=language frontend backend data cloud mobile testing management soft other"`
+	Aliases  []string `json:"aliases,omitempty" binding:"omitempty,max=20,dive,required,max=100"`
+}
+
+// UpdateSkillDTO renombra, recategoriza o cambia los alias (reemplaza la
+// lista completa) de una habilidad.
+type UpdateSkillDTO struct {
+	Name     *string  `json:"name,omitempty" binding:"omitempty,max=100"`
+	Category *string  `json:"category,omitempty" binding:"omitempty,oneof=language frontend backend data cloud mobile testing management soft other"`
+	Aliases  []string `json:"aliases,omitempty" binding:"omitempty,max=20,dive,required,max=100"`
+}
+
+// JobSkillInput es una habilidad que pide la vacante. Level es el nivel
+// mínimo (vacío = cualquiera).
+type JobSkillInput struct {
+	SkillID     uint     `json:"skill_id" binding:"required"`
+	Requirement string   `json:"requirement" binding:"required,oneof=required nice_to_have"`
+	Level       string   `json:"level,omitempty" binding:"omitempty,oneof=basic intermediate advanced expert"`
+	MinYears    *float64 `json:"min_years,omitempty" binding:"omitempty,min=0,max=50"`
+}
+
+// SetJobSkillsDTO reemplaza las habilidades de una vacante (lista vacía =
+// quitarlas todas).
+type SetJobSkillsDTO struct {
+	Skills []JobSkillInput `json:"skills" binding:"max=50,dive"`
+}
+
+// CandidateSkillInput es una habilidad del candidato; nivel y años son
+// opcionales.
+type CandidateSkillInput struct {
+	SkillID uint     `json:"skill_id" binding:"required"`
+	Level   string   `json:"level,omitempty" binding:"omitempty,oneof=basic intermediate advanced expert"`
+	Years   *float64 `json:"years,omitempty" binding:"omitempty,min=0,max=50"`
+}
+
+// SetCandidateSkillsDTO reemplaza las habilidades de un candidato (lista
+// vacía = quitarlas todas).
+type SetCandidateSkillsDTO struct {
+	Skills []CandidateSkillInput `json:"skills" binding:"max=100,dive"`
+}
+
+// SkillGapsQuery elige el candidato cuyas habilidades se comparan con las
+// de la vacante.
+type SkillGapsQuery struct {
+	CandidateID uint `form:"candidate_id" binding:"required"`
+}
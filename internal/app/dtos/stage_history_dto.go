@@ -0,0 +1,43 @@
+package dtos
+
+import "time"
+
+// StageEventDTO representa un cambio de etapa en la línea de tiempo de una
+// postulación. HoursInStage es el tiempo que pasó en ToStage: hasta el
+// siguiente evento o, para la etapa actual, hasta ahora.
+type StageEventDTO struct {
+	ID           uint      `json:"id"`
+	FromStage    string    `json:"from_stage,omitempty"`
+	ToStage      string    `json:"to_stage"`
+	ActorID      *uint     `json:"actor_id,omitempty"`
+	ActorName    string    `json:"actor_name,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	Override     bool      `json:"override"`
+	Backfilled   bool      `json:"backfilled"`
+	OccurredAt   time.Time `json:"occurred_at"`
+	HoursInStage float64   `json:"hours_in_stage"`
+	Current      bool      `json:"current"`
+}
+
+// StageTimeDTO representa el tiempo que pasan las postulaciones en una etapa.
+// Los promedios se calculan sobre los pasos ya cerrados (Exited); las
+// postulaciones que siguen en la etapa se reportan aparte (InStage).
+type StageTimeDTO struct {
+	Key                string  `json:"key"`
+	Name               string  `json:"name,omitempty"`
+	Type               string  `json:"type,omitempty"`
+	Exited             int     `json:"exited"`
+	AvgHours           float64 `json:"avg_hours"`
+	MedianHours        float64 `json:"median_hours"`
+	InStage            int     `json:"in_stage"`
+	AvgCurrentAgeHours float64 `json:"avg_current_age_hours"`
+}
+
+// StageStatsDTO representa el tiempo por etapa de una empresa o de una
+// vacante. BottleneckStage es la etapa activa con mayor tiempo promedio.
+type StageStatsDTO struct {
+	CompanyID       uint           `json:"company_id"`
+	JobID           uint           `json:"job_id,omitempty"`
+	Stages          []StageTimeDTO `json:"stages"`
+	BottleneckStage string         `json:"bottleneck_stage,omitempty"`
+}
@@ -0,0 +1,52 @@
+package dtos
+
+// CreateTagDTO crea una etiqueta de la empresa. Color es #RRGGBB (por
+// defecto gris).
+type CreateTagDTO struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color,omitempty" binding:"omitempty,hexcolor,len=7"`
+}
+
+// UpdateTagDTO renombra o cambia el color de una etiqueta.
+type UpdateTagDTO struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,max=50"`
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor,len=7"`
+}
+
+// CandidateTagsDTO asigna etiquetas a un candidato por nombre; las que no
+// existen se crean.
+type CandidateTagsDTO struct {
+	Tags []string `json:"tags" binding:"required,min=1,max=20,dive,required,max=50"`
+}
+
+// BulkCandidateTagsDTO agrega y/o quita etiquetas (por nombre) a varios
+// candidatos. Las de add que no existen se crean; las de remove que no
+// existen se ignoran.
+type BulkCandidateTagsDTO struct {
+	CandidateIDs []uint   `json:"candidate_ids" binding:"required,min=1,max=500,dive,min=1"`
+	Add          []string `json:"add,omitempty" binding:"omitempty,max=20,dive,required,max=50"`
+	Remove       []string `json:"remove,omitempty" binding:"omitempty,max=20,dive,required,max=50"`
+}
+
+// BulkCandidateTagsResultDTO resume el etiquetado masivo. Added y Removed
+// cuentan asignaciones (candidato × etiqueta) nuevas o quitadas; Skipped son
+// los IDs que no existen o son de otra empresa.
+type BulkCandidateTagsResultDTO struct {
+	Candidates int    `json:"candidates"`
+	Added      int64  `json:"added"`
+	Removed    int64  `json:"removed"`
+	Skipped    []uint `json:"skipped"`
+}
+
+// CandidateFilters filtra GET /candidates. Varios tag_id exigen todas las
+// etiquetas; pool_id limita a los miembros de un talent pool visible para
+// el usuario (y, como toda vista de sourcing, a los contactables). Varios
+// skill_id exigen todas las habilidades, cada una con al menos
+// min_skill_years años si se indica.
+type CandidateFilters struct {
+	TagIDs        []uint  `form:"tag_id"`
+	PoolID        uint    `form:"pool_id"`
+	Contactable   bool    `form:"contactable"`
+	SkillIDs      []uint  `form:"skill_id"`
+	MinSkillYears float64 `form:"min_skill_years" binding:"omitempty,min=0"`
+}
@@ -0,0 +1,43 @@
+package dtos
+
+// CreateTalentPoolDTO crea un talent pool. Shared (por defecto true) lo hace
+// visible para todo el equipo; si es false solo lo ve su dueño.
+type CreateTalentPoolDTO struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description,omitempty" binding:"omitempty,max=2000"`
+	Shared      *bool  `json:"shared,omitempty"`
+}
+
+// UpdateTalentPoolDTO renombra, describe o cambia la visibilidad de un pool.
+type UpdateTalentPoolDTO struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=2000"`
+	Shared      *bool   `json:"shared,omitempty"`
+}
+
+// AddPoolMembersDTO agrega candidatos a un pool con la nota de por qué.
+// ApplicationID (la postulación de la que sale, p. ej. un silver medalist)
+// solo se admite con un único candidato.
+type AddPoolMembersDTO struct {
+	CandidateIDs  []uint `json:"candidate_ids" binding:"required,min=1,max=500,dive,min=1"`
+	ApplicationID *uint  `json:"application_id,omitempty" binding:"omitempty,min=1"`
+	Note          string `json:"note,omitempty" binding:"omitempty,max=2000"`
+}
+
+// UpdatePoolMemberDTO cambia la nota de un miembro.
+type UpdatePoolMemberDTO struct {
+	Note string `json:"note" binding:"max=2000"`
+}
+
+// SkippedPoolCandidateDTO es un candidato que no se agregó y el motivo:
+// not_found, not_contactable o already_member.
+type SkippedPoolCandidateDTO struct {
+	CandidateID uint   `json:"candidate_id"`
+	Reason      string `json:"reason"`
+}
+
+// AddPoolMembersResultDTO resume el alta de miembros.
+type AddPoolMembersResultDTO struct {
+	Added   int64                     `json:"added"`
+	Skipped []SkippedPoolCandidateDTO `json:"skipped"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+import "time"
+
+// TrashFilters representa los filtros para listar la papelera
+type TrashFilters struct {
+	Type string `form:"type" binding:"omitempty,oneof=job candidate application staffing_client placement talent_pool"`
+}
+
+// TrashItemDTO representa un registro eliminado (soft delete) en la papelera
+type TrashItemDTO struct {
+	Type      string    `json:"type"`
+	ID        uint      `json:"id"`
+	CompanyID uint      `json:"company_id"`
+	Label     string    `json:"label"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashRestoreResultDTO representa el resultado de restaurar un registro
+type TrashRestoreResultDTO struct {
+	Type               string `json:"type"`
+	ID                 uint   `json:"id"`
+	RestoredDependents int64  `json:"restored_dependents"`
+}
@@ -1,13 +1,15 @@
 package handlers
 
 import (
-	"dvra-api/internal/shared/authctx"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"dvra-api/internal/app/dtos"
 	"dvra-api/internal/app/services"
 	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+	"dvra-api/internal/shared/permissions"
 
 	"github.com/geomark27/loom-go/pkg/helpers"
 	"github.com/gin-gonic/gin"
@@ -88,7 +90,15 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "success", "data": application})
+	// Resumen de scorecards: solo lo que el usuario puede ver sin sesgo.
+	viewerID, _ := authctx.UserID(c)
+	summary, err := h.applicationService.GetScorecardSummary(application.ID, viewerID, permissions.Can(authctx.Role(c), permissions.ScorecardsViewAll))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ApplicationDetailDTO{Application: application, Scorecards: summary}})
 }
 
 // CreateApplication godoc
@@ -122,7 +132,8 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 		dto.CompanyID = companyID
 	}
 
-	application, err := h.applicationService.CreateApplication(dto)
+	actorID, _ := authctx.UserID(c)
+	application, err := h.applicationService.CreateApplication(dto, actorID)
 	if err != nil {
 		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -161,7 +172,8 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	application, err := h.applicationService.UpdateApplication(uint(id), dto)
+	actorID, _ := authctx.UserID(c)
+	application, err := h.applicationService.UpdateApplication(uint(id), dto, actorID)
 	if err != nil {
 		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -204,51 +216,93 @@ func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 
 // GetApplicationsByStage godoc
 // @Summary      Get applications grouped by stage
-// @Description  Obtiene postulaciones agrupadas por etapa (para Kanban board)
+// @Description  Obtiene postulaciones agrupadas por etapa (para Kanban board), una página por columna. "stages" trae las columnas en orden según el pipeline y "counts" el total de cada etapa. Con stage se pide solo esa columna (cargar más).
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
+// @Param        sort        query     string  false  "position (orden manual, por defecto), rating, applied_at, last_activity, score (compatibilidad con la vacante, requiere job_id; cada tarjeta trae match_score)"
+// @Param        order       query     string  false  "asc o desc (no aplica a position)"
+// @Param        limit       query     int     false  "Postulaciones por columna (por defecto 50, hasta 200)"
+// @Param        offset      query     int     false  "Desplazamiento dentro de cada columna"
+// @Param        stage       query     string  false  "Solo esta columna"
+// @Param        job_id      query     int     false  "Solo esta vacante"
+// @Param        company_id  query     int     false  "Empresa (obligatorio para SuperAdmin)"
 // @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
 // @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}  "La vacante no es de la empresa (sort=score)"
 // @Failure      500  {object}  map[string]interface{}
 // @Security     BearerAuth
 // @Router       /applications/by-stage [get]
 func (h *ApplicationHandler) GetApplicationsByStage(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
 
-	var companyID uint
-	if authctx.IsSuperAdmin(c) {
-		// SuperAdmin needs to specify company_id via query param
-		queryCompanyID := c.Query("company_id")
-		if queryCompanyID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "SuperAdmin must provide company_id query parameter"})
-			return
-		}
-		id, err := strconv.ParseUint(queryCompanyID, 10, 32)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company_id"})
-			return
-		}
-		companyID = uint(id)
-	} else {
-		companyIDVal, exists := c.Get("company_id")
-		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+	var query dtos.ApplicationBoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stages, applicationsByStage, counts, err := h.applicationService.GetApplicationsGroupedByStage(companyID, query)
+	if err != nil {
+		if apperr.StatusCode(err) != http.StatusInternalServerError {
+			c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
 			return
 		}
-		companyID = companyIDVal.(uint)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve applications by stage"})
+		return
 	}
+	// stages: columnas del tablero en orden (pipeline de la empresa + etapas
+	// exclusivas de pipelines de vacantes).
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": applicationsByStage, "counts": counts, "stages": dtos.ToPipelineStageResponseList(stages)})
+}
 
-	applicationsByStage, err := h.applicationService.GetApplicationsGroupedByStage(companyID)
+// ReorderApplication godoc
+// @Summary      Reorder application within its stage column
+// @Description  Ubica la tarjeta entre after_id (arriba) y before_id (abajo) de su columna; omitir uno = extremo. job_id = filtro del tablero, si lo tiene. Solo reescribe su posición. 409 si la columna cambió desde que se cargó.
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true  "Application ID"
+// @Param        body  body      dtos.ReorderApplicationDTO  true  "Vecinos"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Failure      409   {object}  map[string]interface{}  "La columna cambió"
+// @Security     BearerAuth
+// @Router       /applications/{id}/reorder [patch]
+func (h *ApplicationHandler) ReorderApplication(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve applications by stage"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	if !h.canAccess(c, uint(id)) {
+		return
+	}
+
+	var dto dtos.ReorderApplicationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": "success", "data": applicationsByStage})
+
+	application, err := h.applicationService.ReorderApplication(uint(id), dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": application})
 }
 
 // MoveApplication godoc
 // @Summary      Move application to a stage
-// @Description  Mueve una postulación a una etapa del pipeline
+// @Description  Mueve una postulación a una etapa del pipeline. Solo se permiten las transiciones de la etapa actual (RN-APP-002); hired y rejected son finales.
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
@@ -258,6 +312,7 @@ func (h *ApplicationHandler) GetApplicationsByStage(c *gin.Context) {
 // @Failure      400   {object}  map[string]interface{}
 // @Failure      403   {object}  map[string]interface{}
 // @Failure      404   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]interface{}  "Transición no permitida"
 // @Failure      500   {object}  map[string]interface{}
 // @Security     BearerAuth
 // @Router       /applications/{id}/move [patch]
@@ -293,7 +348,49 @@ func (h *ApplicationHandler) MoveApplication(c *gin.Context) {
 		return
 	}
 
-	application, err := h.applicationService.MoveToStage(uint(id), dto.Stage)
+	actorID, _ := authctx.UserID(c)
+	application, err := h.applicationService.MoveToStage(uint(id), dto, actorID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": application})
+}
+
+// OverrideApplicationStage godoc
+// @Summary      Override application stage
+// @Description  Mueve una postulación a cualquier etapa de su pipeline saltando el grafo de transiciones (p. ej. reabrir una hired/rejected). Requiere motivo y queda auditado; corrige rejected_at/hired_at. Forzar una etapa rejected exige rejection_reason del catálogo.
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                       true  "Application ID"
+// @Param        body  body      dtos.OverrideStageDTO     true  "Stage and reason"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/override-stage [patch]
+func (h *ApplicationHandler) OverrideApplicationStage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	if !h.canAccess(c, uint(id)) {
+		return
+	}
+
+	var dto dtos.OverrideStageDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actorID, _ := authctx.UserID(c)
+	application, err := h.applicationService.OverrideStage(uint(id), dto, actorID)
 	if err != nil {
 		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -301,6 +398,181 @@ func (h *ApplicationHandler) MoveApplication(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "success", "data": application})
 }
 
+// bulkActionPermissions es el permiso que exige cada acción masiva: el
+// mismo que su endpoint individual.
+var bulkActionPermissions = map[string]string{
+	dtos.BulkActionMove:   permissions.ApplicationsMove,
+	dtos.BulkActionReject: permissions.ApplicationsMove,
+	dtos.BulkActionRate:   permissions.ApplicationsRate,
+	dtos.BulkActionTag:    permissions.ApplicationsUpdate,
+	dtos.BulkActionDelete: permissions.ApplicationsDelete,
+}
+
+// BulkApplications godoc
+// @Summary      Bulk operations on applications
+// @Description  Aplica move, reject (con rejection_reason del catálogo), rate, tag (al candidato) o delete a varias postulaciones (máx. 500). Cada una se valida y guarda por separado; la respuesta trae el resultado por ID. Exige el mismo permiso que la acción individual.
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dtos.BulkApplicationsDTO  true  "Acción e IDs"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/bulk [post]
+func (h *ApplicationHandler) BulkApplications(c *gin.Context) {
+	var dto dtos.BulkApplicationsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	permission, ok := bulkActionPermissions[dto.Action]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown action '%s'", dto.Action)})
+		return
+	}
+	if !permissions.Can(authctx.Role(c), permission) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	// companyID 0 = SuperAdmin: sin restricción de empresa
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+
+	actorID, _ := authctx.UserID(c)
+	result, err := h.applicationService.BulkApply(companyID, dto, actorID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// GetApplicationTimeline godoc
+// @Summary      Application stage timeline
+// @Description  Historial de etapas de una postulación: de/a, actor, fecha, motivo y horas en cada etapa
+// @Tags         Applications
+// @Produce      json
+// @Param        id   path      int  true  "Application ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/timeline [get]
+func (h *ApplicationHandler) GetApplicationTimeline(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+	if !h.canAccess(c, uint(id)) {
+		return
+	}
+
+	events, err := h.applicationService.GetTimeline(uint(id))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": events, "count": len(events)}})
+}
+
+// GetStageStats godoc
+// @Summary      Time in stage statistics
+// @Description  Tiempo promedio y mediano por etapa (horas) de la empresa o de una vacante, y la etapa cuello de botella. SuperAdmin debe enviar company_id.
+// @Tags         Applications
+// @Produce      json
+// @Param        job_id      query     int  false  "Job ID (sin él: toda la empresa)"
+// @Param        company_id  query     int  false  "Company ID (solo SuperAdmin)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/stage-stats [get]
+func (h *ApplicationHandler) GetStageStats(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var jobID uint
+	if raw := c.Query("job_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job_id"})
+			return
+		}
+		jobID = uint(id)
+	}
+
+	stats, err := h.applicationService.GetStageStats(companyID, jobID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": stats})
+}
+
+// GetRejectionStats godoc
+// @Summary      Rejection analytics
+// @Description  Postulaciones cerradas en etapa rejected por tipo (rejected_by_us / candidate_withdrew) y motivo, desglosadas por etapa de salida (con tasa de pérdida sobre las que llegaron a la etapa), vacante y fuente del candidato. SuperAdmin debe enviar company_id.
+// @Tags         Applications
+// @Produce      json
+// @Param        job_id      query     int  false  "Job ID (sin él: toda la empresa)"
+// @Param        company_id  query     int  false  "Company ID (solo SuperAdmin)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/rejection-stats [get]
+func (h *ApplicationHandler) GetRejectionStats(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var jobID uint
+	if raw := c.Query("job_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job_id"})
+			return
+		}
+		jobID = uint(id)
+	}
+
+	stats, err := h.applicationService.GetRejectionStats(companyID, jobID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": stats})
+}
+
+// canAccess verifica que la postulación exista y sea de la empresa del
+// usuario (SuperAdmin accede a todas). Si no, ya respondió el error.
+func (h *ApplicationHandler) canAccess(c *gin.Context, id uint) bool {
+	application, err := h.applicationService.GetApplicationByID(id)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return false
+	}
+	if authctx.IsSuperAdmin(c) {
+		return true
+	}
+	companyID, ok := authctx.CompanyID(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return false
+	}
+	if application.CompanyID != companyID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return false
+	}
+	return true
+}
+
 // RateApplication godoc
 // @Summary      Rate an application
 // @Description  Asigna una calificación a una postulación
@@ -4,6 +4,9 @@ import (
 	"dvra-api/internal/shared/authctx"
 	"fmt"
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -24,7 +27,33 @@ func NewCandidateHandler(candidateService services.CandidateService) *CandidateH
 	return &CandidateHandler{candidateService: candidateService, logger: helpers.NewLogger()}
 }
 
+// GetCandidates godoc
+// @Summary      Listar candidatos
+// @Description  Lista los candidatos de la empresa. Filtros opcionales: tag_id (repetible; exige todas), pool_id (miembros de un talent pool visible), contactable=true (solo con consentimiento de talent pool vigente) y skill_id (repetible; exige todas las habilidades, cada una con al menos min_skill_years años si se indica). pool_id implica contactable.
+// @Tags         candidates
+// @Produce      json
+// @Param        tag_id           query  []int   false  "ID de etiqueta (repetible)"  collectionFormat(multi)
+// @Param        pool_id          query  int     false  "ID de talent pool"
+// @Param        contactable      query  bool    false  "Solo candidatos contactables"
+// @Param        skill_id         query  []int   false  "ID de habilidad (repetible)"  collectionFormat(multi)
+// @Param        min_skill_years  query  number  false  "Años mínimos en cada habilidad de skill_id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /candidates [get]
 func (h *CandidateHandler) GetCandidates(c *gin.Context) {
+	var filters dtos.CandidateFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Con filtros (etiquetas, pool, habilidades o vista de sourcing) se
+	// busca; sin ellos se listan todos.
+	if len(filters.TagIDs) > 0 || filters.PoolID != 0 || filters.Contactable || len(filters.SkillIDs) > 0 {
+		h.searchCandidates(c, filters)
+		return
+	}
 
 	// SuperAdmin puede ver todos los candidates
 	if authctx.IsSuperAdmin(c) {
@@ -55,6 +84,29 @@ func (h *CandidateHandler) GetCandidates(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"candidates": candidatesDTO, "count": len(candidatesDTO)}})
 }
 
+// searchCandidates aplica los filtros. SuperAdmin busca en todas las
+// empresas y ve también los pools privados.
+func (h *CandidateHandler) searchCandidates(c *gin.Context, filters dtos.CandidateFilters) {
+	var companyID, viewerID uint
+	if !authctx.IsSuperAdmin(c) {
+		id, ok := authctx.CompanyID(c)
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+			return
+		}
+		companyID = id
+		viewerID, _ = authctx.UserID(c)
+	}
+
+	candidates, err := h.candidateService.SearchCandidates(companyID, viewerID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve candidates"})
+		return
+	}
+	candidatesDTO := dtos.ToCandidateResponseList(candidates)
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"candidates": candidatesDTO, "count": len(candidatesDTO)}})
+}
+
 func (h *CandidateHandler) GetCandidate(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -207,13 +259,14 @@ func (h *CandidateHandler) UploadResume(c *gin.Context) {
 		return
 	}
 
-	// Validate access
+	// Validate access (el candidato se carga siempre: su empresa define la
+	// carpeta del CV)
+	candidate, err := h.candidateService.GetCandidateByID(uint(id))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
 	if !authctx.IsSuperAdmin(c) {
-		candidate, err := h.candidateService.GetCandidateByID(uint(id))
-		if err != nil {
-			c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
-			return
-		}
 		companyIDVal, exists := c.Get("company_id")
 		if !exists {
 			c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
@@ -245,14 +298,21 @@ func (h *CandidateHandler) UploadResume(c *gin.Context) {
 		return
 	}
 
-	// For now, we'll generate a simple file path/URL
-	// In production, you would upload to S3, GCS, or similar
-	filename := fmt.Sprintf("resumes/%d_%d_%s", id, time.Now().Unix(), file.Filename)
+	// Same structure as the career page: uploads/companies/{company-slug}/resumes/
+	// (the resume parser only reads files from the candidate's company folder)
+	dir := path.Join("companies", candidate.Company.Slug, "resumes")
+	filename := path.Join(dir, fmt.Sprintf("%d_%d_%s", id, time.Now().Unix(), filepath.Base(file.Filename)))
 
 	// Save file locally (in production use cloud storage)
+	if err := os.MkdirAll("./uploads/"+dir, os.ModePerm); err != nil {
+		h.logger.Error("Failed to create upload directory", map[string]interface{}{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save resume"})
+		return
+	}
 	if err := c.SaveUploadedFile(file, "./uploads/"+filename); err != nil {
 		h.logger.Error("Failed to save resume", map[string]interface{}{"error": err.Error()})
-		// Even if local save fails, we'll update the URL for demo purposes
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save resume"})
+		return
 	}
 
 	// Generate URL (in production this would be a cloud storage URL)
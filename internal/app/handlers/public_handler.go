@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -95,7 +96,7 @@ func (h *PublicHandler) GetPublishedJobsByCompany(c *gin.Context) {
 
 // GetPublishedJobByID godoc
 // @Summary      Get published job by ID
-// @Description  Returns a published job's details for career page
+// @Description  Returns a published job's details for career page, including its screening questions (id, kind yes_no/choice/number/text, prompt, options, required) without the knockout rules
 // @Tags         Public
 // @Accept       json
 // @Produce      json
@@ -123,9 +124,21 @@ func (h *PublicHandler) GetPublishedJobByID(c *gin.Context) {
 		return
 	}
 
+	questions, err := h.publicService.GetScreeningQuestions(job.ID)
+	if err != nil {
+		h.logger.Error("Failed to get screening questions for job %d: %v", id, err)
+		c.JSON(apperr.StatusCode(err), gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+	response := dtos.ToPublicJobResponse(job)
+	response.Questions = dtos.ToPublicScreeningQuestions(questions)
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   dtos.ToPublicJobResponse(job),
+		"data":   response,
 	})
 }
 
@@ -143,7 +156,11 @@ func (h *PublicHandler) GetPublishedJobByID(c *gin.Context) {
 // @Param        linkedin_url formData  string  false  "LinkedIn URL"
 // @Param        github_url   formData  string  false  "GitHub URL"
 // @Param        cover_letter formData  string  false  "Cover letter"
+// @Param        consent      formData  bool    true   "Acepta el aviso de privacidad (debe ser true)"
+// @Param        talent_pool_consent formData bool false "Acepta ser contactado por futuras vacantes"
+// @Param        notice_version formData int    true   "Versión del aviso mostrada (GET /public/companies/{slug}/privacy-notice)"
 // @Param        resume       formData  file    false  "Resume file (PDF, DOC, DOCX)"
+// @Param        answers[question_id] formData string false "Respuesta a cada pregunta de screening (answers[12]=yes); las obligatorias no pueden faltar. Si una knockout no se cumple, la postulación queda rechazada y la respuesta es la misma"
 // @Success      201  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      404  {object}  map[string]interface{}
@@ -194,6 +211,37 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 	dto.LinkedinURL = strings.TrimSpace(c.PostForm("linkedin_url"))
 	dto.GithubURL = strings.TrimSpace(c.PostForm("github_url"))
 	dto.CoverLetter = strings.TrimSpace(c.PostForm("cover_letter"))
+	dto.Consent = c.PostForm("consent") == "true"
+	dto.TalentPoolConsent = c.PostForm("talent_pool_consent") == "true"
+	if v := c.PostForm("notice_version"); v != "" {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "Invalid notice version",
+			})
+			return
+		}
+		dto.NoticeVersion = &version
+	}
+	if answers := c.PostFormMap("answers"); len(answers) > 0 {
+		dto.Answers = make(map[uint]string, len(answers))
+		for key, value := range answers {
+			questionID, err := strconv.ParseUint(key, 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"status":  "error",
+					"message": "Invalid screening question ID",
+				})
+				return
+			}
+			dto.Answers[uint(questionID)] = value
+		}
+	}
+	dto.Evidence = dtos.ConsentEvidence{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
 
 	// Basic validation
 	if dto.FirstName == "" || dto.LastName == "" || dto.Email == "" {
@@ -203,8 +251,16 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 		})
 		return
 	}
+	if !dto.Consent {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "You must accept the privacy notice to apply",
+		})
+		return
+	}
 
 	// Handle resume file upload (optional)
+	savedResume := ""
 	file, header, err := c.Request.FormFile("resume")
 	if err == nil {
 		defer file.Close()
@@ -245,9 +301,10 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 			return
 		}
 
-		// Generate unique filename
+		// Generate unique filename (nanoseconds: a double submit must not
+		// reuse, and later delete, the file of the first request)
 		filename := fmt.Sprintf("%d_%s_%s%s",
-			time.Now().Unix(),
+			time.Now().UnixNano(),
 			sanitizeFilename(dto.Email),
 			sanitizeFilename(dto.LastName),
 			ext,
@@ -255,7 +312,7 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 		filePath := filepath.Join(uploadDir, filename)
 
 		// Save file
-		out, err := os.Create(filePath)
+		out, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
 		if err != nil {
 			h.logger.Error("Failed to create resume file: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -264,10 +321,13 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 			})
 			return
 		}
-		defer out.Close()
-
-		if _, err := io.Copy(out, file); err != nil {
+		_, err = io.Copy(out, file)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
 			h.logger.Error("Failed to write resume file: %v", err)
+			h.removeResume(filePath)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"status":  "error",
 				"message": "Failed to save resume",
@@ -277,12 +337,18 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 
 		// Set resume URL (relative path for now, could be S3 URL in production)
 		dto.ResumeURL = "/" + filePath
+		savedResume = filePath
 	}
 
 	// Process application
 	application, err := h.publicService.ApplyToJob(uint(jobID), dto)
 	if err != nil {
 		h.logger.Error("Failed to apply to job %d: %v", jobID, err)
+		// Nothing references the resume of a failed application: remove it
+		// so retries don't leave orphan files behind.
+		if savedResume != "" {
+			h.removeResume(savedResume)
+		}
 
 		// apperr clasifica el código; los errores internos (no-apperr) caen en 500
 		// con mensaje genérico para no filtrar detalles al público.
@@ -319,6 +385,13 @@ func (h *PublicHandler) ApplyToJob(c *gin.Context) {
 	})
 }
 
+// removeResume deletes a saved resume that no application references
+func (h *PublicHandler) removeResume(path string) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		h.logger.Error("Failed to remove resume file %s: %v", path, err)
+	}
+}
+
 // sanitizeFilename removes special characters from filename
 func sanitizeFilename(s string) string {
 	// Keep only alphanumeric characters and replace others with underscore
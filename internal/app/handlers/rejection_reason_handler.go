@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/services"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RejectionReasonHandler struct {
+	service services.RejectionReasonService
+}
+
+func NewRejectionReasonHandler(service services.RejectionReasonService) *RejectionReasonHandler {
+	return &RejectionReasonHandler{service: service}
+}
+
+// List godoc
+// @Summary      List rejection reasons
+// @Description  Catálogo de motivos de la empresa: globales (global=true) y propios, de ambos tipos (rejected_by_us / candidate_withdrew). SuperAdmin debe enviar company_id.
+// @Tags         Rejection Reasons
+// @Produce      json
+// @Param        company_id  query     int  false  "Company ID (solo SuperAdmin)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /rejection-reasons [get]
+func (h *RejectionReasonHandler) List(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	reasons, err := h.service.List(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": reasons, "count": len(reasons)}})
+}
+
+// Create godoc
+// @Summary      Create a company rejection reason
+// @Description  Agrega un motivo propio de la empresa. El value no puede repetir uno global ni otro de la empresa.
+// @Tags         Rejection Reasons
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     int                            false  "Company ID (solo SuperAdmin)"
+// @Param        body        body      dtos.CreateRejectionReasonDTO  true   "Motivo"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /rejection-reasons [post]
+func (h *RejectionReasonHandler) Create(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var dto dtos.CreateRejectionReasonDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reason, err := h.service.Create(companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": reason})
+}
+
+// Update godoc
+// @Summary      Update a company rejection reason
+// @Description  Cambia etiqueta, descripción u orden de un motivo propio. Los globales no se modifican (403).
+// @Tags         Rejection Reasons
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                            true   "Reason ID"
+// @Param        company_id  query     int                            false  "Company ID (solo SuperAdmin)"
+// @Param        body        body      dtos.UpdateRejectionReasonDTO  true   "Cambios"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /rejection-reasons/{id} [put]
+func (h *RejectionReasonHandler) Update(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var dto dtos.UpdateRejectionReasonDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reason, err := h.service.Update(uint(id), companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": reason})
+}
+
+// Delete godoc
+// @Summary      Delete a company rejection reason
+// @Description  Quita un motivo propio del catálogo. Las postulaciones ya rechazadas conservan su motivo.
+// @Tags         Rejection Reasons
+// @Produce      json
+// @Param        id          path      int  true   "Reason ID"
+// @Param        company_id  query     int  false  "Company ID (solo SuperAdmin)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /rejection-reasons/{id} [delete]
+func (h *RejectionReasonHandler) Delete(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id), companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Rejection reason deleted successfully"})
+}
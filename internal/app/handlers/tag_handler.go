@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/services"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	service services.TagService
+}
+
+func NewTagHandler(service services.TagService) *TagHandler {
+	return &TagHandler{service: service}
+}
+
+// List godoc
+// @Summary      List candidate tags
+// @Description  Catálogo de etiquetas de la empresa con la cantidad de candidatos de cada una. SuperAdmin debe enviar company_id.
+// @Tags         Tags
+// @Produce      json
+// @Param        company_id  query     int  false  "Company ID (solo SuperAdmin)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /tags [get]
+func (h *TagHandler) List(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	tags, err := h.service.ListTags(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": tags, "count": len(tags)}})
+}
+
+// Create godoc
+// @Summary      Create a candidate tag
+// @Description  Agrega una etiqueta al catálogo. El nombre es único por empresa sin distinguir mayúsculas; el color por defecto es gris.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     int                false  "Company ID (solo SuperAdmin)"
+// @Param        body        body      dtos.CreateTagDTO  true   "Etiqueta"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /tags [post]
+func (h *TagHandler) Create(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var dto dtos.CreateTagDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.service.CreateTag(companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": tag})
+}
+
+// Update godoc
+// @Summary      Update a candidate tag
+// @Description  Renombra o cambia el color de una etiqueta. Los candidatos etiquetados la conservan.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                true  "Tag ID"
+// @Param        body  body      dtos.UpdateTagDTO  true  "Cambios"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /tags/{id} [put]
+func (h *TagHandler) Update(c *gin.Context) {
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var dto dtos.UpdateTagDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.service.UpdateTag(uint(id), companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": tag})
+}
+
+// Delete godoc
+// @Summary      Delete a candidate tag
+// @Description  Elimina la etiqueta y la quita de todos los candidatos.
+// @Tags         Tags
+// @Produce      json
+// @Param        id  path      int  true  "Tag ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /tags/{id} [delete]
+func (h *TagHandler) Delete(c *gin.Context) {
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	if err := h.service.DeleteTag(uint(id), companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Tag deleted successfully"})
+}
+
+// GetCandidateTags godoc
+// @Summary      List a candidate's tags
+// @Tags         Tags
+// @Produce      json
+// @Param        id  path      int  true  "Candidate ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/tags [get]
+func (h *TagHandler) GetCandidateTags(c *gin.Context) {
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+
+	tags, err := h.service.GetCandidateTags(uint(id), companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": tags, "count": len(tags)}})
+}
+
+// TagCandidate godoc
+// @Summary      Tag a candidate
+// @Description  Asigna etiquetas por nombre; las que no existen se crean con el color por defecto. Devuelve todas las etiquetas del candidato.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Candidate ID"
+// @Param        body  body      dtos.CandidateTagsDTO  true  "Etiquetas"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/tags [post]
+func (h *TagHandler) TagCandidate(c *gin.Context) {
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+	var dto dtos.CandidateTagsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := h.service.TagCandidate(uint(id), companyID, dto, userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": tags, "count": len(tags)}})
+}
+
+// UntagCandidate godoc
+// @Summary      Remove a tag from a candidate
+// @Tags         Tags
+// @Produce      json
+// @Param        id     path      int  true  "Candidate ID"
+// @Param        tagId  path      int  true  "Tag ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/tags/{tagId} [delete]
+func (h *TagHandler) UntagCandidate(c *gin.Context) {
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+	tagID, err := strconv.ParseUint(c.Param("tagId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	if err := h.service.UntagCandidate(uint(id), uint(tagID), companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Tag removed successfully"})
+}
+
+// BulkTagCandidates godoc
+// @Summary      Bulk tag candidates
+// @Description  Agrega (add) y/o quita (remove) etiquetas por nombre a hasta 500 candidatos de la empresa. Los IDs inexistentes o ajenos se devuelven en skipped. SuperAdmin debe enviar company_id.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     int                        false  "Company ID (solo SuperAdmin)"
+// @Param        body        body      dtos.BulkCandidateTagsDTO  true   "Candidatos y etiquetas"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/bulk-tags [post]
+func (h *TagHandler) BulkTagCandidates(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+	var dto dtos.BulkCandidateTagsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.BulkTagCandidates(companyID, dto, userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
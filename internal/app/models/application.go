@@ -16,12 +16,22 @@ type Application struct {
 
 	// Pipeline
 	Stage string `gorm:"type:varchar(100);not null;index:idx_applications_company_stage,priority:2" json:"stage"`
-	// Valores: "applied", "screening", "technical", "offer", "hired", "rejected"
+	// Key de una etapa del pipeline de la vacante (ver models.Pipeline)
+
+	// Position ordena la tarjeta dentro de su columna del tablero (empresa +
+	// etapa): key fraccionaria de internal/shared/rank, comparada con
+	// COLLATE "C". Al entrar a una etapa la postulación va al final.
+	Position string `gorm:"type:varchar(64);not null;default:''" json:"position"`
 
 	// Rating
 	Rating *int `gorm:"type:integer" json:"rating,omitempty"` // 1-5 estrellas
 
-	// Notes
+	// AssigneeID es el miembro responsable de la postulación (lo fija la
+	// acción assign_user de las automatizaciones).
+	AssigneeID *uint `gorm:"index" json:"assignee_id,omitempty"`
+
+	// Notes es el campo de notas previo a los comentarios (RN-APP-009): se
+	// migró al primer comentario del hilo y ya no se escribe. Solo lectura.
 	Notes string `gorm:"type:text" json:"notes,omitempty"`
 
 	// Timestamps de estado
@@ -29,6 +39,21 @@ type Application struct {
 	RejectedAt *time.Time `gorm:"type:timestamp" json:"rejected_at,omitempty"`
 	HiredAt    *time.Time `gorm:"type:timestamp" json:"hired_at,omitempty"`
 
+	// Cierre por rechazo: tipo (RejectionType*), motivo del catálogo de su
+	// categoría y etapa desde la que se rechazó. Se limpian al reabrir.
+	RejectionType     string `gorm:"type:varchar(30)" json:"rejection_type,omitempty"`
+	RejectionReason   string `gorm:"type:varchar(100)" json:"rejection_reason,omitempty"`
+	RejectedFromStage string `gorm:"type:varchar(100)" json:"rejected_from_stage,omitempty"`
+
+	// MatchScore es la compatibilidad del candidato con la vacante (0–100,
+	// RN-JOB-006). No se guarda: lo completa el tablero al ordenar por
+	// puntaje.
+	MatchScore *int `gorm:"-" json:"match_score,omitempty"`
+
+	// AnonymizedAt se fija cuando se borra el contenido libre (notas) por
+	// retención o derecho al olvido; stage y timestamps se conservan.
+	AnonymizedAt *time.Time `gorm:"type:timestamp" json:"anonymized_at,omitempty"`
+
 	// Relaciones
 	Job       *Job       `gorm:"foreignKey:JobID" json:"job,omitempty"`
 	Candidate *Candidate `gorm:"foreignKey:CandidateID" json:"candidate,omitempty"`
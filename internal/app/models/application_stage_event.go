@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ApplicationStageEvent registra un cambio de etapa de una postulación: el
+// historial del que salen la línea de tiempo y el tiempo por etapa. El primer
+// evento de cada postulación tiene FromStage vacío (entrada al pipeline).
+type ApplicationStageEvent struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index" json:"company_id"`
+	ApplicationID uint   `gorm:"not null;index" json:"application_id"`
+	FromStage     string `gorm:"type:varchar(50)" json:"from_stage"`
+	ToStage       string `gorm:"type:varchar(50);not null" json:"to_stage"`
+	ActorID       *uint  `gorm:"" json:"actor_id,omitempty"` // usuario que hizo el cambio
+	Reason        string `gorm:"type:text" json:"reason,omitempty"`
+	Override      bool   `gorm:"not null;default:false" json:"override"` // movimiento fuera del grafo de transiciones
+	// Backfilled = reconstruido a partir de applied_at/hired_at/rejected_at de
+	// postulaciones anteriores al historial. No cuenta para tiempos por etapa.
+	Backfilled bool      `gorm:"not null;default:false" json:"backfilled"`
+	OccurredAt time.Time `gorm:"type:timestamp;not null;index" json:"occurred_at"`
+}
+
+// TableName overrides the table name (optional)
+func (ApplicationStageEvent) TableName() string {
+	return "application_stage_events"
+}
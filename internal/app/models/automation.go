@@ -0,0 +1,205 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Disparadores de una regla de automatización
+const (
+	AutomationTriggerApplicationCreated = "application_created"
+	AutomationTriggerStageChanged       = "stage_changed"
+	AutomationTriggerRatingSet          = "rating_set"
+	AutomationTriggerTimeInStage        = "time_in_stage" // lo genera el barrido periódico
+)
+
+// Acciones de una regla
+const (
+	AutomationActionSendEmail  = "send_email"
+	AutomationActionAssignUser = "assign_user"
+	AutomationActionAddTag     = "add_tag"
+	AutomationActionMoveStage  = "move_stage"
+	AutomationActionWebhook    = "webhook"
+	AutomationActionAddToPool  = "add_to_pool"
+)
+
+// Campos y operadores de las condiciones
+const (
+	AutomationFieldJob    = "job"    // IDs de vacante
+	AutomationFieldSource = "source" // Candidate.Source
+	AutomationFieldStage  = "stage"  // etapa actual
+	AutomationFieldRating = "rating" // 1-5
+	AutomationFieldTag    = "tag"    // etiquetas del candidato (por nombre)
+
+	AutomationOpIn    = "in"
+	AutomationOpNotIn = "not_in"
+	AutomationOpGte   = "gte"
+	AutomationOpLte   = "lte"
+)
+
+// Estados de una ejecución y de cada acción
+const (
+	AutomationRunSuccess = "success"
+	AutomationRunFailed  = "failed"
+
+	AutomationActionOK      = "ok"
+	AutomationActionFailed  = "failed"
+	AutomationActionSkipped = "skipped"
+)
+
+// AutomationCondition filtra los eventos de una regla: Field comparado con
+// Values según Operator (in / not_in para listas, gte / lte para rating).
+type AutomationCondition struct {
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+// AutomationAction es un paso de la regla. Solo se usan los campos de su
+// tipo: Subject/Body (send_email, text/template), UserID (assign_user), Tag
+// (add_tag), Stage y el motivo de rechazo (move_stage), URL/Secret (webhook),
+// PoolID/Note (add_to_pool).
+type AutomationAction struct {
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Body            string `json:"body,omitempty"`
+	UserID          uint   `json:"user_id,omitempty"`
+	Tag             string `json:"tag,omitempty"`
+	Stage           string `json:"stage,omitempty"`
+	RejectionType   string `json:"rejection_type,omitempty"`
+	RejectionReason string `json:"rejection_reason,omitempty"`
+	URL             string `json:"url,omitempty"`
+	Secret          string `json:"secret,omitempty"` // firma HMAC; no se devuelve en la API
+	PoolID          uint   `json:"pool_id,omitempty"`
+	Note            string `json:"note,omitempty"`
+}
+
+// AutomationRule es una regla de automatización de la empresa: cuando ocurre
+// Trigger sobre una postulación y se cumplen todas las Conditions, se
+// ejecutan las Actions en orden. Stage acota stage_changed (etapa destino,
+// vacío = cualquiera) y es obligatoria en time_in_stage junto con Days.
+type AutomationRule struct {
+	BaseModel
+
+	CompanyID   uint                                     `gorm:"not null;index:idx_automation_rules_company_trigger,priority:1" json:"company_id"`
+	Name        string                                   `gorm:"type:varchar(150);not null" json:"name"`
+	Trigger     string                                   `gorm:"type:varchar(30);not null;index:idx_automation_rules_company_trigger,priority:2" json:"trigger"`
+	Stage       string                                   `gorm:"type:varchar(100)" json:"stage,omitempty"`
+	Days        int                                      `gorm:"not null;default:0" json:"days,omitempty"`
+	Conditions  datatypes.JSONSlice[AutomationCondition] `gorm:"type:jsonb" json:"conditions"`
+	Actions     datatypes.JSONSlice[AutomationAction]    `gorm:"type:jsonb;not null" json:"actions"`
+	Active      bool                                     `gorm:"not null;default:true" json:"active"`
+	CreatedByID *uint                                    `gorm:"" json:"created_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (AutomationRule) TableName() string {
+	return "automation_rules"
+}
+
+// AutomationEvent es la cola de eventos de postulaciones pendientes de
+// evaluar. Las operaciones solo insertan la fila; las reglas corren en
+// segundo plano. RuleID y StageEventID los fija el barrido de time_in_stage
+// (el evento es para esa regla y esa entrada a la etapa). Depth cuenta
+// cuántas automatizaciones encadenadas lo originaron.
+type AutomationEvent struct {
+	BaseModel
+
+	CompanyID     uint       `gorm:"not null;index" json:"company_id"`
+	ApplicationID uint       `gorm:"not null;index" json:"application_id"`
+	Trigger       string     `gorm:"type:varchar(30);not null" json:"trigger"`
+	FromStage     string     `gorm:"type:varchar(100)" json:"from_stage,omitempty"`
+	ToStage       string     `gorm:"type:varchar(100)" json:"to_stage,omitempty"`
+	RuleID        *uint      `gorm:"index" json:"rule_id,omitempty"`
+	StageEventID  *uint      `gorm:"index" json:"stage_event_id,omitempty"`
+	Depth         int        `gorm:"not null;default:0" json:"depth"`
+	ProcessedAt   *time.Time `gorm:"type:timestamp;index" json:"processed_at,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (AutomationEvent) TableName() string {
+	return "automation_events"
+}
+
+// AutomationActionResult es el resultado de una acción en una ejecución.
+type AutomationActionResult struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// AutomationRun es el registro de ejecución de una regla sobre un evento.
+type AutomationRun struct {
+	BaseModel
+
+	CompanyID     uint                                        `gorm:"not null;index" json:"company_id"`
+	RuleID        uint                                        `gorm:"not null;index" json:"rule_id"`
+	EventID       uint                                        `gorm:"not null;index" json:"event_id"`
+	ApplicationID uint                                        `gorm:"not null;index" json:"application_id"`
+	Trigger       string                                      `gorm:"type:varchar(30);not null" json:"trigger"`
+	Status        string                                      `gorm:"type:varchar(20);not null;index" json:"status"`
+	Results       datatypes.JSONSlice[AutomationActionResult] `gorm:"type:jsonb" json:"results"`
+	StartedAt     time.Time                                   `gorm:"type:timestamptz;not null" json:"started_at"`
+	FinishedAt    time.Time                                   `gorm:"type:timestamptz;not null" json:"finished_at"`
+}
+
+// TableName overrides the table name (optional)
+func (AutomationRun) TableName() string {
+	return "automation_runs"
+}
+
+// Fases del barrido de postulaciones estancadas
+const (
+	StaleActionReminder  = "reminder"  // aviso al recruiter asignado
+	StaleActionRejection = "rejection" // rechazo automático
+)
+
+// StalePolicy define cuándo una postulación está estancada en una etapa de
+// la empresa (cualquier pipeline que use esa key): a los RemindAfterDays sin
+// actividad se avisa al recruiter asignado y a los RejectAfterDays se rechaza
+// con el motivo configurado. 0 desactiva la fase.
+type StalePolicy struct {
+	BaseModel
+
+	CompanyID       uint   `gorm:"not null;index:idx_stale_policies_company_stage,priority:1" json:"company_id"`
+	Stage           string `gorm:"type:varchar(100);not null;index:idx_stale_policies_company_stage,priority:2" json:"stage"`
+	RemindAfterDays int    `gorm:"not null;default:0" json:"remind_after_days"`
+	RejectAfterDays int    `gorm:"not null;default:0" json:"reject_after_days"`
+	RejectionType   string `gorm:"type:varchar(30)" json:"rejection_type,omitempty"`
+	RejectionReason string `gorm:"type:varchar(100)" json:"rejection_reason,omitempty"`
+	NotifyCandidate bool   `gorm:"not null;default:false" json:"notify_candidate"`
+	EmailSubject    string `gorm:"type:varchar(200)" json:"email_subject,omitempty"`
+	EmailBody       string `gorm:"type:text" json:"email_body,omitempty"`
+	Active          bool   `gorm:"not null;default:true" json:"active"`
+	UpdatedByID     *uint  `gorm:"" json:"updated_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (StalePolicy) TableName() string {
+	return "stale_policies"
+}
+
+// StaleAction registra cada recordatorio o rechazo del barrido. Una fase se
+// aplica una sola vez por entrada a la etapa (StageEventID; 0 si la
+// postulación no tiene historial); si falló, la pasada siguiente la
+// reintenta sobre el mismo registro.
+type StaleAction struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index" json:"company_id"`
+	PolicyID      uint   `gorm:"not null" json:"policy_id"`
+	ApplicationID uint   `gorm:"not null;uniqueIndex:idx_stale_actions_entry,priority:1" json:"application_id"`
+	StageEventID  uint   `gorm:"not null;uniqueIndex:idx_stale_actions_entry,priority:2" json:"stage_event_id"`
+	Action        string `gorm:"type:varchar(20);not null;uniqueIndex:idx_stale_actions_entry,priority:3" json:"action"`
+	Stage         string `gorm:"type:varchar(100);not null" json:"stage"`
+	IdleDays      int    `gorm:"not null" json:"idle_days"`
+	RecipientID   *uint  `gorm:"" json:"recipient_id,omitempty"`          // recruiter avisado
+	Status        string `gorm:"type:varchar(20);not null" json:"status"` // ok, failed, skipped
+	Detail        string `gorm:"type:text" json:"detail,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (StaleAction) TableName() string {
+	return "stale_actions"
+}
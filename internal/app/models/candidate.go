@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Candidate represents the candidate entity in the database
 type Candidate struct {
 	BaseModel
@@ -17,16 +19,48 @@ type Candidate struct {
 	GithubURL   string `gorm:"type:text" json:"github_url,omitempty"`
 	LinkedinURL string `gorm:"type:text" json:"linkedin_url,omitempty"`
 
+	// ResumeText es el texto extraído del CV. No se expone en la API: solo
+	// alimenta la búsqueda (ver CandidateSearchDocument).
+	ResumeText string `gorm:"type:text" json:"-"`
+
+	// Preferencias y perfil para la compatibilidad con vacantes (RN-JOB-006).
+	// WorkMode es la modalidad preferida (remote, onsite, hybrid; vacío =
+	// cualquiera). YearsOfExperience, si falta, se toma del CV analizado.
+	CityID            *uint    `gorm:"index" json:"city_id,omitempty"`
+	WorkMode          string   `gorm:"type:varchar(20)" json:"work_mode,omitempty"`
+	OpenToRelocate    bool     `gorm:"not null;default:false" json:"open_to_relocate"`
+	SalaryExpectation *float64 `gorm:"type:decimal(12,2)" json:"salary_expectation,omitempty"`
+	YearsOfExperience *float64 `gorm:"type:decimal(4,1)" json:"years_of_experience,omitempty"`
+
 	// Source tracking
 	Source string `gorm:"type:varchar(100)" json:"source,omitempty"`
 	// Valores: "linkedin", "referral", "direct_apply", "agency"
 
+	// AnonymizedAt se fija cuando se borra la PII del candidato (retención o
+	// derecho al olvido). El registro se conserva para las métricas agregadas.
+	AnonymizedAt *time.Time `gorm:"type:timestamp" json:"anonymized_at,omitempty"`
+
+	// TalentPoolConsentAt se fija mientras el candidato tenga vigente el
+	// consentimiento para ser contactado por futuras vacantes (talent pool).
+	// Sin él queda fuera de cualquier función de sourcing.
+	TalentPoolConsentAt *time.Time `gorm:"type:timestamp" json:"talent_pool_consent_at,omitempty"`
+
 	// Relaciones
-	Company      *Company      `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
-	Applications []Application `gorm:"foreignKey:CandidateID" json:"applications,omitempty"`
+	Company      *Company           `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	City         *City              `gorm:"foreignKey:CityID" json:"city,omitempty"`
+	Applications []Application      `gorm:"foreignKey:CandidateID" json:"applications,omitempty"`
+	Consents     []CandidateConsent `gorm:"foreignKey:CandidateID" json:"consents,omitempty"`
 }
 
 // TableName overrides the table name (optional)
 func (Candidate) TableName() string {
 	return "candidates"
 }
+
+// ContactableCondition es la condición SQL de sourcing (RN-GDPR-001) sobre
+// la tabla o alias de candidates indicado: consentimiento de talent pool
+// vigente y sin anonimizar. Toda función de sourcing (búsqueda de talento,
+// pools, matching) la aplica.
+func ContactableCondition(table string) string {
+	return table + ".talent_pool_consent_at IS NOT NULL AND " + table + ".anonymized_at IS NULL"
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// CandidateMerge registra la fusión de un candidato duplicado (MergedID) en
+// otro (SurvivorID). El duplicado queda eliminado (soft delete) con sus datos
+// intactos; las filas que pasaron al sobreviviente y los campos que se
+// completaron con los del duplicado se guardan para poder deshacerla hasta
+// UndoDeadline.
+type CandidateMerge struct {
+	BaseModel
+
+	CompanyID  uint                        `gorm:"not null;index" json:"company_id"`
+	SurvivorID uint                        `gorm:"not null;index" json:"survivor_id"`
+	MergedID   uint                        `gorm:"not null;index" json:"merged_id"`
+	Score      int                         `gorm:"not null;default:0" json:"score"` // puntaje de duplicado al fusionar
+	Reasons    datatypes.JSONSlice[string] `gorm:"type:jsonb" json:"reasons"`
+
+	// Filas que pasaron del duplicado al sobreviviente. Deshacer devuelve
+	// solo estas (las creadas después de la fusión se quedan).
+	MovedApplicationIDs datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_application_ids"`
+	MovedPlacementIDs   datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_placement_ids"`
+	MovedCommentIDs     datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_comment_ids"`
+	MovedConsentIDs     datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_consent_ids"`
+	MovedTagIDs         datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_tag_ids"`         // filas de candidate_tags
+	MovedPoolMemberIDs  datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_pool_member_ids"` // filas de talent_pool_members
+	MovedResumeParseIDs datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_resume_parse_ids"`
+	MovedSkillIDs       datatypes.JSONSlice[uint] `gorm:"type:jsonb" json:"moved_skill_ids"` // filas de candidate_skills
+
+	// FilledFields son las columnas del sobreviviente que estaban vacías y
+	// tomaron el valor del duplicado (p. ej. "phone", "resume_url").
+	FilledFields datatypes.JSONSlice[string] `gorm:"type:jsonb" json:"filled_fields"`
+
+	MergedByID   uint       `gorm:"not null" json:"merged_by_id"`
+	UndoDeadline time.Time  `gorm:"type:timestamp;not null" json:"undo_deadline"`
+	UndoneAt     *time.Time `gorm:"type:timestamp" json:"undone_at,omitempty"`
+	UndoneByID   *uint      `gorm:"" json:"undone_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (CandidateMerge) TableName() string {
+	return "candidate_merges"
+}
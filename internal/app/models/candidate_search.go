@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// SearchConfig es la configuración de búsqueda de texto de PostgreSQL que usa
+// el índice de candidatos: tokens sin stemming (sirve igual para español,
+// portugués e inglés) y sin tildes (unaccent). La crea
+// database.EnsureSearchConfig antes de migrar.
+const SearchConfig = "dvra_search"
+
+// CandidateSearchDocument es el documento de búsqueda de texto completo de un
+// candidato: un tsvector con su nombre y email (peso A), fuente (B), notas y
+// comentarios no internos (C) y el texto del CV (D). Es un dato derivado que
+// se reconstruye desde las tablas de origen; no guarda texto legible. Se
+// borra (sin papelera) cuando el candidato se elimina o se anonimiza.
+type CandidateSearchDocument struct {
+	BaseModel
+
+	CandidateID uint      `gorm:"not null;uniqueIndex" json:"candidate_id"`
+	CompanyID   uint      `gorm:"not null;index" json:"company_id"`
+	Document    string    `gorm:"type:tsvector;not null;index:idx_candidate_search_documents_document,type:gin" json:"-"`
+	IndexedAt   time.Time `gorm:"type:timestamp;not null" json:"indexed_at"`
+}
+
+// TableName overrides the table name (optional)
+func (CandidateSearchDocument) TableName() string {
+	return "candidate_search_documents"
+}
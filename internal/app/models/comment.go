@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// Recursos que admiten comentarios.
+const (
+	CommentSubjectCandidate   = "candidate"
+	CommentSubjectApplication = "application"
+)
+
+// Origen de un comentario que no escribió un usuario.
+const (
+	CommentSourceNotes       = "notes"        // migrado de Application.Notes
+	CommentSourceCoverLetter = "cover_letter" // carta del candidato en la career page
+)
+
+// Comment es un comentario del equipo sobre un candidato o una postulación
+// (exactamente uno de CandidateID / ApplicationID). Las respuestas apuntan a
+// la raíz del hilo (ParentID) y heredan su visibilidad. Version sube con cada
+// edición: editar exige la versión leída, así dos ediciones simultáneas no se
+// pisan.
+type Comment struct {
+	BaseModel
+
+	CompanyID     uint  `gorm:"not null;index" json:"company_id"`
+	CandidateID   *uint `gorm:"index" json:"candidate_id,omitempty"`
+	ApplicationID *uint `gorm:"index" json:"application_id,omitempty"`
+	ParentID      *uint `gorm:"index" json:"parent_id,omitempty"`
+	AuthorID      *uint `gorm:"index" json:"author_id,omitempty"` // NULL = sistema (migración, career page)
+
+	Body     string     `gorm:"type:text;not null" json:"body"`
+	Internal bool       `gorm:"not null;default:false" json:"internal"` // solo el equipo de reclutamiento
+	Source   string     `gorm:"type:varchar(30)" json:"source,omitempty"`
+	Version  int        `gorm:"not null;default:1" json:"version"`
+	EditedAt *time.Time `gorm:"type:timestamp" json:"edited_at,omitempty"`
+
+	Mentions    []CommentMention    `gorm:"foreignKey:CommentID" json:"mentions,omitempty"`
+	Attachments []CommentAttachment `gorm:"foreignKey:CommentID" json:"attachments,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentRevision guarda el texto anterior de un comentario en cada edición.
+type CommentRevision struct {
+	BaseModel
+
+	CommentID  uint      `gorm:"not null;index" json:"comment_id"`
+	Version    int       `gorm:"not null" json:"version"` // versión que tenía ese texto
+	Body       string    `gorm:"type:text;not null" json:"body"`
+	EditedByID *uint     `gorm:"" json:"edited_by_id,omitempty"`
+	EditedAt   time.Time `gorm:"type:timestamp;not null" json:"edited_at"`
+}
+
+// TableName overrides the table name (optional)
+func (CommentRevision) TableName() string {
+	return "comment_revisions"
+}
+
+// CommentMention registra a un miembro de la empresa mencionado en un comentario.
+type CommentMention struct {
+	BaseModel
+
+	CommentID uint `gorm:"not null;uniqueIndex:idx_comment_mentions_comment_user,priority:1" json:"comment_id"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_comment_mentions_comment_user,priority:2;index" json:"user_id"`
+}
+
+// TableName overrides the table name (optional)
+func (CommentMention) TableName() string {
+	return "comment_mentions"
+}
+
+// CommentAttachment es un archivo adjunto a un comentario. StoragePath es
+// relativo al directorio de uploads y no se expone.
+type CommentAttachment struct {
+	BaseModel
+
+	CommentID    uint   `gorm:"not null;index" json:"comment_id"`
+	CompanyID    uint   `gorm:"not null;index" json:"company_id"`
+	FileName     string `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType  string `gorm:"type:varchar(100)" json:"content_type,omitempty"`
+	Size         int64  `gorm:"not null" json:"size"`
+	StoragePath  string `gorm:"type:varchar(500);not null" json:"-"`
+	UploadedByID *uint  `gorm:"" json:"uploaded_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (CommentAttachment) TableName() string {
+	return "comment_attachments"
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// PrivacyNotice es una versión del aviso de privacidad de una empresa. Las
+// versiones son inmutables: publicar cambios crea una versión nueva y la
+// vigente es la de mayor Version. Sin versiones, rige PlatformSettings.PrivacyURL.
+type PrivacyNotice struct {
+	BaseModel
+
+	CompanyID   uint      `gorm:"not null;uniqueIndex:idx_privacy_notices_company_version,priority:1" json:"company_id"`
+	Version     int       `gorm:"not null;uniqueIndex:idx_privacy_notices_company_version,priority:2" json:"version"`
+	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
+	Content     string    `gorm:"type:text" json:"content,omitempty"`
+	URL         string    `gorm:"type:text" json:"url,omitempty"`
+	PublishedAt time.Time `gorm:"type:timestamp;not null" json:"published_at"`
+	CreatedByID uint      `gorm:"not null" json:"created_by_id"`
+
+	// Relaciones
+	Company *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (PrivacyNotice) TableName() string {
+	return "privacy_notices"
+}
+
+// CandidateConsent es la evidencia de un consentimiento (RN-GDPR-001): qué
+// aceptó el candidato, bajo qué versión del aviso, cuándo y desde dónde.
+// Los registros no se editan; revocar solo fija RevokedAt.
+type CandidateConsent struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index" json:"company_id"`
+	CandidateID   uint   `gorm:"not null;index:idx_candidate_consents_candidate_purpose,priority:1" json:"candidate_id"`
+	ApplicationID *uint  `gorm:"index" json:"application_id,omitempty"`
+	Purpose       string `gorm:"type:varchar(50);not null;index:idx_candidate_consents_candidate_purpose,priority:2" json:"purpose"` // application, talent_pool
+	Method        string `gorm:"type:varchar(20);not null" json:"method"`                                                            // career_page, recruiter
+
+	// Aviso aceptado. NoticeVersion 0 = aviso de plataforma (NoticeURL).
+	NoticeID      *uint  `gorm:"" json:"notice_id,omitempty"`
+	NoticeVersion int    `gorm:"not null;default:0" json:"notice_version"`
+	NoticeURL     string `gorm:"type:text" json:"notice_url,omitempty"`
+
+	// Evidencia
+	IPAddress    string     `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent    string     `gorm:"type:text" json:"user_agent,omitempty"`
+	GivenAt      time.Time  `gorm:"type:timestamp;not null" json:"given_at"`
+	RecordedByID *uint      `gorm:"" json:"recorded_by_id,omitempty"` // usuario que lo registró (method=recruiter)
+	RevokedAt    *time.Time `gorm:"type:timestamp" json:"revoked_at,omitempty"`
+
+	// Relaciones
+	Candidate *Candidate `gorm:"foreignKey:CandidateID" json:"candidate,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (CandidateConsent) TableName() string {
+	return "candidate_consents"
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// DataSubjectRequest registra una solicitud de un titular de datos (RN-GDPR-002
+// derecho al olvido, RN-GDPR-003 portabilidad) sobre los candidatos con un
+// email dado. CompanyID NULL = todas las empresas (solo SuperAdmin).
+//
+// Al completarse una solicitud de borrado el email se enmascara y solo se
+// conserva su hash: la prueba de cumplimiento no debe volver a guardar la PII.
+type DataSubjectRequest struct {
+	BaseModel
+
+	CompanyID        *uint  `gorm:"index" json:"company_id,omitempty"`                               // NULL = todas las empresas
+	Type             string `gorm:"type:varchar(20);not null" json:"type"`                           // access, erasure
+	Status           string `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"` // pending, completed, cancelled
+	SubjectEmail     string `gorm:"type:varchar(255);not null" json:"subject_email"`
+	SubjectEmailHash string `gorm:"type:varchar(64);not null;index" json:"subject_email_hash"` // sha256 del email normalizado
+	Notes            string `gorm:"type:text" json:"notes,omitempty"`
+
+	// Plazos
+	ReceivedAt  time.Time  `gorm:"type:timestamp;not null" json:"received_at"`
+	DueAt       time.Time  `gorm:"type:timestamp;not null;index" json:"due_at"`
+	CompletedAt *time.Time `gorm:"type:timestamp" json:"completed_at,omitempty"`
+
+	// Auditoría
+	RequestedByID uint  `gorm:"not null" json:"requested_by_id"`
+	CompletedByID *uint `gorm:"" json:"completed_by_id,omitempty"`
+
+	// Prueba de cumplimiento: qué registros se procesaron (y, en access, el
+	// hash del export entregado). ProofHash = sha256 de Proof.
+	Proof     datatypes.JSON `gorm:"type:jsonb" json:"proof,omitempty"`
+	ProofHash string         `gorm:"type:varchar(64)" json:"proof_hash,omitempty"`
+
+	// Relaciones
+	Company *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (DataSubjectRequest) TableName() string {
+	return "data_subject_requests"
+}
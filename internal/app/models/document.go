@@ -0,0 +1,53 @@
+package models
+
+// Tipos de plantilla de documento
+const (
+	DocumentKindOfferLetter       = "offer_letter"
+	DocumentKindPlacementContract = "placement_contract"
+	DocumentKindGeneral           = "general"
+)
+
+// DocumentTemplate es una plantilla de documento de la empresa. Body usa la
+// sintaxis de text/template sobre las variables del candidato, la vacante,
+// la oferta y el placement; las líneas que empiezan con "# " son títulos.
+// Locale fija el idioma de fechas y montos (es, en, pt).
+type DocumentTemplate struct {
+	BaseModel
+
+	CompanyID   uint   `gorm:"not null;index" json:"company_id"`
+	Name        string `gorm:"type:varchar(150);not null" json:"name"`
+	Kind        string `gorm:"type:varchar(30);not null;default:'general'" json:"kind"`
+	Locale      string `gorm:"type:varchar(5);not null;default:'es'" json:"locale"`
+	Title       string `gorm:"type:varchar(255);not null" json:"title"`
+	Body        string `gorm:"type:text;not null" json:"body"`
+	Active      bool   `gorm:"not null;default:true" json:"active"`
+	CreatedByID *uint  `gorm:"" json:"created_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (DocumentTemplate) TableName() string {
+	return "document_templates"
+}
+
+// Document es un PDF generado a partir de una plantilla y guardado como
+// adjunto de una postulación o de un placement (exactamente uno de los dos).
+// OfferID indica la oferta cuyos términos se usaron, si aplica.
+type Document struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index" json:"company_id"`
+	TemplateID    *uint  `gorm:"index" json:"template_id,omitempty"`
+	Kind          string `gorm:"type:varchar(30);not null" json:"kind"`
+	ApplicationID *uint  `gorm:"index" json:"application_id,omitempty"`
+	PlacementID   *uint  `gorm:"index" json:"placement_id,omitempty"`
+	OfferID       *uint  `gorm:"" json:"offer_id,omitempty"`
+	FileName      string `gorm:"type:varchar(255);not null" json:"file_name"`
+	StoragePath   string `gorm:"type:varchar(500);not null" json:"-"`
+	Size          int64  `gorm:"not null" json:"size"`
+	GeneratedByID *uint  `gorm:"" json:"generated_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Document) TableName() string {
+	return "documents"
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Estados de una entrevista
+const (
+	InterviewStatusScheduled = "scheduled"
+	InterviewStatusCompleted = "completed"
+	InterviewStatusNoShow    = "no_show"
+	InterviewStatusCancelled = "cancelled"
+)
+
+// Interview es una entrevista de una postulación en una etapa, con su panel de
+// entrevistadores. StartsAt/EndsAt son instantes absolutos (timestamptz);
+// Timezone es la zona de la empresa al agendarla y con ella se muestran.
+// UID y Sequence identifican el evento iCalendar: cada cambio que se envía
+// como invitación incrementa Sequence para que los calendarios lo actualicen.
+type Interview struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index:idx_interviews_company_starts,priority:1" json:"company_id"`
+	ApplicationID uint   `gorm:"not null;index" json:"application_id"`
+	Stage         string `gorm:"type:varchar(100);not null" json:"stage"`
+	Title         string `gorm:"type:varchar(200);not null" json:"title"`
+
+	StartsAt time.Time `gorm:"type:timestamptz;not null;index:idx_interviews_company_starts,priority:2" json:"starts_at"`
+	EndsAt   time.Time `gorm:"type:timestamptz;not null" json:"ends_at"`
+	Timezone string    `gorm:"type:varchar(100);not null" json:"timezone"`
+
+	Location string `gorm:"type:varchar(300)" json:"location,omitempty"`
+	VideoURL string `gorm:"type:varchar(500)" json:"video_url,omitempty"`
+
+	Status       string     `gorm:"type:varchar(20);not null;default:'scheduled';index" json:"status"`
+	CancelReason string     `gorm:"type:varchar(500)" json:"cancel_reason,omitempty"`
+	CancelledAt  *time.Time `gorm:"type:timestamp" json:"cancelled_at,omitempty"`
+
+	UID         string `gorm:"type:varchar(100);not null;uniqueIndex" json:"uid"`
+	Sequence    int    `gorm:"not null;default:0" json:"sequence"`
+	OrganizerID *uint  `gorm:"" json:"organizer_id,omitempty"`
+
+	Panel []InterviewPanelist `gorm:"foreignKey:InterviewID" json:"panel,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Interview) TableName() string {
+	return "interviews"
+}
+
+// InterviewPanelist es un entrevistador (usuario de la empresa) del panel.
+type InterviewPanelist struct {
+	BaseModel
+
+	InterviewID uint `gorm:"not null;uniqueIndex:idx_interview_panelists_interview_user,priority:1" json:"interview_id"`
+	UserID      uint `gorm:"not null;uniqueIndex:idx_interview_panelists_interview_user,priority:2;index" json:"user_id"`
+}
+
+// TableName overrides the table name (optional)
+func (InterviewPanelist) TableName() string {
+	return "interview_panelists"
+}
+
+// InterviewerAvailability es una franja semanal en que un entrevistador
+// acepta entrevistas, en la zona horaria de la empresa. Weekday sigue
+// time.Weekday (0 = domingo); los minutos cuentan desde medianoche.
+type InterviewerAvailability struct {
+	BaseModel
+
+	CompanyID   uint `gorm:"not null;index:idx_interviewer_availability_company_user,priority:1" json:"company_id"`
+	UserID      uint `gorm:"not null;index:idx_interviewer_availability_company_user,priority:2" json:"user_id"`
+	Weekday     int  `gorm:"not null" json:"weekday"`
+	StartMinute int  `gorm:"not null" json:"start_minute"`
+	EndMinute   int  `gorm:"not null" json:"end_minute"`
+}
+
+// TableName overrides the table name (optional)
+func (InterviewerAvailability) TableName() string {
+	return "interviewer_availability"
+}
+
+// AvailabilityBlackout es un período en que el entrevistador no está
+// disponible aunque caiga en sus franjas (vacaciones, feriados).
+type AvailabilityBlackout struct {
+	BaseModel
+
+	CompanyID uint      `gorm:"not null;index" json:"company_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	StartsAt  time.Time `gorm:"type:timestamptz;not null" json:"starts_at"`
+	EndsAt    time.Time `gorm:"type:timestamptz;not null" json:"ends_at"`
+	Reason    string    `gorm:"type:varchar(200)" json:"reason,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (AvailabilityBlackout) TableName() string {
+	return "availability_blackouts"
+}
+
+// Estados de un enlace de autoagenda
+const (
+	SchedulingLinkOpen    = "open"
+	SchedulingLinkBooked  = "booked"
+	SchedulingLinkRevoked = "revoked"
+)
+
+// SchedulingLink es un enlace público con el que el candidato elige su
+// horario entre los libres del panel. Solo se guarda el hash del token; el
+// token se muestra una vez al crearlo. Reservar crea la entrevista
+// (InterviewID) y cierra el enlace.
+type SchedulingLink struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index" json:"company_id"`
+	ApplicationID uint   `gorm:"not null;index" json:"application_id"`
+	Stage         string `gorm:"type:varchar(100);not null" json:"stage"`
+	Title         string `gorm:"type:varchar(200)" json:"title,omitempty"`
+	TokenHash     string `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+
+	PanelIDs        datatypes.JSONSlice[uint] `gorm:"type:jsonb;not null" json:"panel_ids"`
+	DurationMinutes int                       `gorm:"not null" json:"duration_minutes"`
+	BufferMinutes   int                       `gorm:"not null;default:0" json:"buffer_minutes"`
+	Location        string                    `gorm:"type:varchar(300)" json:"location,omitempty"`
+	VideoURL        string                    `gorm:"type:varchar(500)" json:"video_url,omitempty"`
+
+	Status      string     `gorm:"type:varchar(20);not null;default:'open'" json:"status"`
+	ExpiresAt   time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	InterviewID *uint      `gorm:"" json:"interview_id,omitempty"`
+	BookedAt    *time.Time `gorm:"type:timestamp" json:"booked_at,omitempty"`
+	CreatedByID *uint      `gorm:"" json:"created_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (SchedulingLink) TableName() string {
+	return "scheduling_links"
+}
@@ -11,7 +11,15 @@ type Job struct {
 	SalaryMax    *float64 `gorm:"type:decimal(12,2)" json:"salary_max,omitempty"`
 	Requirements string   `gorm:"type:text" json:"requirements,omitempty"`
 	Benefits     string   `gorm:"type:text" json:"benefits,omitempty"`
-	Status       string   `gorm:"type:varchar(50);default:'draft';index:idx_jobs_company_status,priority:2" json:"status"`
+
+	// SalaryCurrency es la moneda de la banda salarial (ISO 4217): una oferta
+	// en otra moneda no se compara con SalaryMax.
+	SalaryCurrency string `gorm:"type:varchar(3);not null;default:'USD'" json:"salary_currency"`
+
+	// ExperienceYearsMin son los años de experiencia que pide la vacante
+	// (alimenta el puntaje de compatibilidad, RN-JOB-006).
+	ExperienceYearsMin *float64 `gorm:"type:decimal(4,1)" json:"experience_years_min,omitempty"`
+	Status             string   `gorm:"type:varchar(50);default:'draft';index:idx_jobs_company_status,priority:2" json:"status"`
 
 	// LocationType uses system_values with category='work_mode' (remote, onsite, hybrid)
 	LocationType string `gorm:"type:varchar(50);default:'onsite'" json:"location_type"`
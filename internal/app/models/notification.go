@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Tipos de notificación
+const (
+	NotificationTypeMention       = "mention"        // mencionado en un comentario
+	NotificationTypeOfferApproval = "offer_approval" // una oferta espera su aprobación
+	NotificationTypeOfferDecision = "offer_decision" // aprobaron o rechazaron su oferta
+	NotificationTypeAssignment    = "assignment"     // una automatización le asignó una postulación
+	NotificationTypeStaleReminder = "stale_reminder" // una postulación suya lleva días sin actividad
+)
+
+// Notification es un aviso para un usuario dentro de una empresa. Resource
+// apunta a lo que la originó (p. ej. el comentario) para que el cliente arme
+// el enlace.
+type Notification struct {
+	BaseModel
+
+	CompanyID    uint       `gorm:"not null;index" json:"company_id"`
+	UserID       uint       `gorm:"not null;index:idx_notifications_user_read,priority:1" json:"user_id"`
+	Type         string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title        string     `gorm:"type:varchar(200);not null" json:"title"`
+	Body         string     `gorm:"type:text" json:"body,omitempty"`
+	ResourceType string     `gorm:"type:varchar(50)" json:"resource_type,omitempty"`
+	ResourceID   uint       `gorm:"" json:"resource_id,omitempty"`
+	ActorID      *uint      `gorm:"" json:"actor_id,omitempty"`
+	ReadAt       *time.Time `gorm:"type:timestamp;index:idx_notifications_user_read,priority:2" json:"read_at,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,113 @@
+package models
+
+import "time"
+
+// Estados de una oferta
+const (
+	OfferStatusDraft           = "draft"            // en edición o con aprobación rechazada
+	OfferStatusPendingApproval = "pending_approval" // esperando la cadena de aprobación
+	OfferStatusApproved        = "approved"         // lista para enviar
+	OfferStatusSent            = "sent"             // enviada, esperando respuesta
+	OfferStatusNegotiating     = "negotiating"      // el candidato contrapropuso
+	OfferStatusAccepted        = "accepted"
+	OfferStatusDeclined        = "declined"
+	OfferStatusWithdrawn       = "withdrawn"
+	OfferStatusExpired         = "expired"
+)
+
+// Estados de un paso de aprobación
+const (
+	OfferApprovalPending  = "pending"
+	OfferApprovalApproved = "approved"
+	OfferApprovalRejected = "rejected"
+)
+
+// Condiciones de un paso de la cadena de aprobación
+const (
+	OfferStepAlways         = "always"
+	OfferStepAboveSalaryMax = "above_salary_max"
+)
+
+// Offer es la oferta de empleo de una postulación. Sus términos viven en
+// versiones (OfferVersion): cada revisión crea una nueva y vuelve a pasar por
+// la cadena de aprobación.
+type Offer struct {
+	BaseModel
+
+	CompanyID      uint       `gorm:"not null;index" json:"company_id"`
+	ApplicationID  uint       `gorm:"not null;index" json:"application_id"`
+	Status         string     `gorm:"type:varchar(30);not null;default:'draft';index" json:"status"`
+	CurrentVersion int        `gorm:"not null;default:1" json:"current_version"`
+	SentAt         *time.Time `gorm:"type:timestamp" json:"sent_at,omitempty"`
+	RespondedAt    *time.Time `gorm:"type:timestamp" json:"responded_at,omitempty"`
+	ResponseNote   string     `gorm:"type:text" json:"response_note,omitempty"`
+	CreatedByID    *uint      `gorm:"" json:"created_by_id,omitempty"`
+
+	Versions  []OfferVersion  `gorm:"foreignKey:OfferID" json:"versions,omitempty"`
+	Approvals []OfferApproval `gorm:"foreignKey:OfferID" json:"approvals,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Offer) TableName() string {
+	return "offers"
+}
+
+// OfferVersion son los términos de una oferta en una revisión.
+// AboveSalaryMax queda fijado al crear la versión (salario > Job.SalaryMax).
+type OfferVersion struct {
+	BaseModel
+
+	OfferID        uint       `gorm:"not null;uniqueIndex:idx_offer_versions_offer_version,priority:1" json:"offer_id"`
+	Version        int        `gorm:"not null;uniqueIndex:idx_offer_versions_offer_version,priority:2" json:"version"`
+	Salary         float64    `gorm:"type:decimal(12,2);not null" json:"salary"`
+	Currency       string     `gorm:"type:varchar(3);not null" json:"currency"`
+	StartDate      *time.Time `gorm:"type:date" json:"start_date,omitempty"`
+	Equity         string     `gorm:"type:varchar(200)" json:"equity,omitempty"`
+	Bonus          *float64   `gorm:"type:decimal(12,2)" json:"bonus,omitempty"`
+	ExpiresAt      time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	Notes          string     `gorm:"type:text" json:"notes,omitempty"`
+	AboveSalaryMax bool       `gorm:"not null;default:false" json:"above_salary_max"`
+	CreatedByID    *uint      `gorm:"" json:"created_by_id,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (OfferVersion) TableName() string {
+	return "offer_versions"
+}
+
+// OfferApproval es un paso de la cadena para una versión de la oferta. Los
+// pasos se aprueban en orden (Step); los decide cualquier miembro con Role.
+type OfferApproval struct {
+	BaseModel
+
+	OfferID        uint       `gorm:"not null;index" json:"offer_id"`
+	OfferVersionID uint       `gorm:"not null;index" json:"offer_version_id"`
+	Step           int        `gorm:"not null" json:"step"`
+	Role           string     `gorm:"type:varchar(50);not null" json:"role"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ApproverID     *uint      `gorm:"" json:"approver_id,omitempty"`
+	DecidedAt      *time.Time `gorm:"type:timestamp" json:"decided_at,omitempty"`
+	Comment        string     `gorm:"type:text" json:"comment,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (OfferApproval) TableName() string {
+	return "offer_approvals"
+}
+
+// OfferApprovalStep es un paso de la cadena de aprobación configurada por la
+// empresa: quién aprueba (rol) y cuándo aplica (siempre o solo si la oferta
+// supera el salario máximo de la vacante).
+type OfferApprovalStep struct {
+	BaseModel
+
+	CompanyID uint   `gorm:"not null;index" json:"company_id"`
+	Position  int    `gorm:"not null" json:"position"`
+	Role      string `gorm:"type:varchar(50);not null" json:"role"`
+	Condition string `gorm:"type:varchar(30);not null;default:'always'" json:"condition"`
+}
+
+// TableName overrides the table name (optional)
+func (OfferApprovalStep) TableName() string {
+	return "offer_approval_steps"
+}
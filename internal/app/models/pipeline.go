@@ -0,0 +1,108 @@
+package models
+
+import "gorm.io/datatypes"
+
+// Tipos de etapa del pipeline. Cada pipeline tiene exactamente una etapa
+// hired y una rejected (ambas finales) y al menos una active.
+const (
+	StageTypeActive   = "active"
+	StageTypeHired    = "hired"
+	StageTypeRejected = "rejected"
+)
+
+// Pipeline define el proceso de selección de una empresa. JobID NULL = el
+// pipeline por defecto de la empresa; con JobID, reemplaza al de la empresa
+// solo para esa vacante.
+type Pipeline struct {
+	BaseModel
+
+	CompanyID uint   `gorm:"not null;index;uniqueIndex:idx_pipelines_company_default,where:job_id IS NULL" json:"company_id"`
+	JobID     *uint  `gorm:"uniqueIndex:idx_pipelines_job" json:"job_id,omitempty"` // NULL = pipeline por defecto de la empresa
+	Name      string `gorm:"type:varchar(100);not null" json:"name"`
+
+	// Relaciones
+	Company *Company        `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Stages  []PipelineStage `gorm:"foreignKey:PipelineID" json:"stages,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Pipeline) TableName() string {
+	return "pipelines"
+}
+
+// Stage devuelve la etapa con la key dada, o nil si el pipeline no la tiene.
+func (p *Pipeline) Stage(key string) *PipelineStage {
+	for i := range p.Stages {
+		if p.Stages[i].Key == key {
+			return &p.Stages[i]
+		}
+	}
+	return nil
+}
+
+// StageOfType devuelve la primera etapa (por posición) del tipo dado.
+func (p *Pipeline) StageOfType(stageType string) *PipelineStage {
+	var found *PipelineStage
+	for i := range p.Stages {
+		s := &p.Stages[i]
+		if s.Type == stageType && (found == nil || s.Position < found.Position) {
+			found = s
+		}
+	}
+	return found
+}
+
+// NextStages devuelve las keys alcanzables desde la etapa dada según su
+// configuración o la regla por defecto. nil si la etapa no existe o es final.
+func (p *Pipeline) NextStages(key string) []string {
+	stage := p.Stage(key)
+	if stage == nil || stage.Type != StageTypeActive {
+		return nil
+	}
+	if len(stage.Transitions) > 0 {
+		return append([]string(nil), stage.Transitions...)
+	}
+
+	var next *PipelineStage
+	for i := range p.Stages {
+		s := &p.Stages[i]
+		if s.Type == StageTypeActive && s.Position > stage.Position && (next == nil || s.Position < next.Position) {
+			next = s
+		}
+	}
+	if next == nil {
+		next = p.StageOfType(StageTypeHired)
+	}
+
+	keys := []string{}
+	if next != nil {
+		keys = append(keys, next.Key)
+	}
+	if rejected := p.StageOfType(StageTypeRejected); rejected != nil {
+		keys = append(keys, rejected.Key)
+	}
+	return keys
+}
+
+// PipelineStage es una etapa del pipeline. Key es el valor que se guarda en
+// Application.Stage: no cambia una vez creada (Name y Color sí).
+type PipelineStage struct {
+	BaseModel
+
+	PipelineID uint   `gorm:"not null;uniqueIndex:idx_pipeline_stages_pipeline_key,priority:1" json:"pipeline_id"`
+	Key        string `gorm:"type:varchar(50);not null;uniqueIndex:idx_pipeline_stages_pipeline_key,priority:2" json:"key"`
+	Name       string `gorm:"type:varchar(100);not null" json:"name"`
+	Type       string `gorm:"type:varchar(20);not null;default:'active'" json:"type"` // active, hired, rejected
+	Color      string `gorm:"type:varchar(7)" json:"color,omitempty"`                 // #RRGGBB
+	Position   int    `gorm:"not null;default:0" json:"position"`
+
+	// Keys a las que se puede mover una postulación desde esta etapa (RN-APP-002).
+	// Vacío = regla por defecto: la siguiente etapa activa (o hired desde la
+	// última) y rejected. Las etapas finales no tienen salidas.
+	Transitions datatypes.JSONSlice[string] `gorm:"type:jsonb" json:"transitions,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (PipelineStage) TableName() string {
+	return "pipeline_stages"
+}
@@ -0,0 +1,26 @@
+package models
+
+// Quién cerró la postulación al pasar a la etapa rejected.
+const (
+	RejectionTypeRejected = "rejected_by_us"     // la empresa descartó al candidato
+	RejectionTypeWithdrew = "candidate_withdrew" // el candidato se retiró
+)
+
+// Categorías de SystemValue con el catálogo de motivos de cada tipo: valores
+// globales (company_id NULL) más los propios de cada empresa.
+const (
+	SystemValueCategoryRejectionReason  = "rejection_reason"
+	SystemValueCategoryWithdrawalReason = "withdrawal_reason"
+)
+
+// RejectionCategory devuelve la categoría de motivos del tipo de rechazo, o ""
+// si el tipo no existe.
+func RejectionCategory(rejectionType string) string {
+	switch rejectionType {
+	case RejectionTypeRejected:
+		return SystemValueCategoryRejectionReason
+	case RejectionTypeWithdrew:
+		return SystemValueCategoryWithdrawalReason
+	}
+	return ""
+}
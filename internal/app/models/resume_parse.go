@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Estados del análisis de un CV.
+const (
+	ResumeParsePending     = "pending"
+	ResumeParseCompleted   = "completed"
+	ResumeParseFailed      = "failed"      // no se pudo leer (cifrado, dañado, sin texto)
+	ResumeParseUnsupported = "unsupported" // formato distinto de PDF o DOCX
+)
+
+// ResumeParse es el análisis de un CV subido: el texto plano va a
+// Candidate.ResumeText (búsqueda) y los datos estructurados quedan acá para
+// sugerir valores al completar la ficha. Hay uno por archivo; el vigente es
+// el del ResumeURL actual del candidato.
+type ResumeParse struct {
+	BaseModel
+
+	CompanyID   uint                           `gorm:"not null;index" json:"company_id"`
+	CandidateID uint                           `gorm:"not null;index" json:"candidate_id"`
+	ResumeURL   string                         `gorm:"type:text;not null" json:"resume_url"`
+	Status      string                         `gorm:"type:varchar(20);not null;index" json:"status"` // ResumeParse*
+	Error       string                         `gorm:"type:text" json:"error,omitempty"`
+	Data        datatypes.JSONType[ResumeData] `gorm:"type:jsonb" json:"data"`
+	ParsedAt    *time.Time                     `gorm:"type:timestamp" json:"parsed_at,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (ResumeParse) TableName() string {
+	return "resume_parses"
+}
+
+// ResumeData son los datos que se pudieron inferir del CV (best-effort:
+// cualquier campo puede faltar).
+type ResumeData struct {
+	FirstName   string   `json:"first_name,omitempty"`
+	LastName    string   `json:"last_name,omitempty"`
+	Emails      []string `json:"emails,omitempty"`
+	Phones      []string `json:"phones,omitempty"`
+	LinkedinURL string   `json:"linkedin_url,omitempty"`
+	GithubURL   string   `json:"github_url,omitempty"`
+	Links       []string `json:"links,omitempty"` // otros sitios (portfolio, blog)
+	Skills      []string `json:"skills,omitempty"`
+
+	Education  []ResumeEducation  `json:"education,omitempty"`
+	Experience []ResumeExperience `json:"experience,omitempty"`
+	// YearsOfExperience suma los períodos de Experience sin contar dos veces
+	// los que se superponen.
+	YearsOfExperience float64 `json:"years_of_experience"`
+	// SkillYears son los años de experiencia con cada habilidad de Skills,
+	// sumando los puestos que la mencionan (como YearsOfExperience).
+	SkillYears map[string]float64 `json:"skill_years,omitempty"`
+}
+
+// ResumeEducation es un estudio mencionado en el CV.
+type ResumeEducation struct {
+	Institution string `json:"institution,omitempty"`
+	Degree      string `json:"degree,omitempty"`
+	Year        int    `json:"year,omitempty"` // de finalización
+}
+
+// ResumeExperience es un puesto de trabajo; las fechas van como "YYYY-MM".
+type ResumeExperience struct {
+	Title   string `json:"title,omitempty"`
+	Start   string `json:"start"`
+	End     string `json:"end,omitempty"`
+	Current bool   `json:"current"`
+	Months  int    `json:"months"`
+	// Skills son las habilidades que se mencionan en la descripción del
+	// puesto.
+	Skills []string `json:"skills,omitempty"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RetentionPolicy define cuánto tiempo se conserva un tipo de dato antes de
+// anonimizarlo o eliminarlo (§6.7). CompanyID NULL = política de plataforma
+// (la configura el SuperAdmin); una política de empresa para la misma regla
+// la reemplaza.
+type RetentionPolicy struct {
+	BaseModel
+
+	CompanyID       *uint  `gorm:"index:idx_retention_policies_company_rule,priority:1" json:"company_id,omitempty"` // NULL = plataforma
+	Rule            string `gorm:"type:varchar(50);not null;index:idx_retention_policies_company_rule,priority:2" json:"rule"`
+	RetentionMonths int    `gorm:"not null" json:"retention_months"`
+	Action          string `gorm:"type:varchar(20);not null;default:'anonymize'" json:"action"` // anonymize, delete
+	IsActive        bool   `gorm:"not null;default:false" json:"is_active"`
+	UpdatedByID     *uint  `gorm:"" json:"updated_by_id,omitempty"`
+
+	// Relaciones
+	Company *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (RetentionPolicy) TableName() string {
+	return "retention_policies"
+}
+
+// RetentionRun registra cada aplicación de una política (qué se hizo, sobre
+// cuántos registros y cuáles). Es la evidencia que piden las auditorías.
+type RetentionRun struct {
+	BaseModel
+
+	CompanyID     uint           `gorm:"not null;index" json:"company_id"`
+	Rule          string         `gorm:"type:varchar(50);not null" json:"rule"`
+	Action        string         `gorm:"type:varchar(20);not null" json:"action"`
+	Cutoff        time.Time      `gorm:"type:timestamp;not null" json:"cutoff"`
+	AffectedCount int            `gorm:"not null;default:0" json:"affected_count"`
+	AffectedIDs   datatypes.JSON `gorm:"type:jsonb" json:"affected_ids,omitempty"`
+	Trigger       string         `gorm:"type:varchar(20);not null;default:'scheduler'" json:"trigger"` // scheduler, manual
+	TriggeredByID *uint          `gorm:"" json:"triggered_by_id,omitempty"`
+	Error         string         `gorm:"type:text" json:"error,omitempty"`
+	StartedAt     time.Time      `gorm:"type:timestamp;not null" json:"started_at"`
+	FinishedAt    *time.Time     `gorm:"type:timestamp" json:"finished_at,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (RetentionRun) TableName() string {
+	return "retention_runs"
+}
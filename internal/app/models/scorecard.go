@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Recomendación global de un scorecard, de peor a mejor.
+const (
+	RecommendationStrongNo  = "strong_no"
+	RecommendationNo        = "no"
+	RecommendationYes       = "yes"
+	RecommendationStrongYes = "strong_yes"
+)
+
+// ScorecardTemplate define qué evalúan los entrevistadores de una vacante:
+// competencias con su escala y preguntas. Stage vacío = aplica a todas las
+// etapas que no tengan una plantilla propia.
+type ScorecardTemplate struct {
+	BaseModel
+
+	CompanyID uint   `gorm:"not null;index" json:"company_id"`
+	JobID     uint   `gorm:"not null;uniqueIndex:idx_scorecard_templates_job_stage,where:deleted_at IS NULL" json:"job_id"`
+	Stage     string `gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_scorecard_templates_job_stage,where:deleted_at IS NULL" json:"stage,omitempty"`
+	Name      string `gorm:"type:varchar(150);not null" json:"name"`
+
+	Competencies datatypes.JSONSlice[ScorecardCompetency] `gorm:"type:jsonb" json:"competencies"`
+	Questions    datatypes.JSONSlice[ScorecardQuestion]   `gorm:"type:jsonb" json:"questions"`
+}
+
+// TableName overrides the table name (optional)
+func (ScorecardTemplate) TableName() string {
+	return "scorecard_templates"
+}
+
+// ScorecardCompetency es una competencia a calificar de 1 a ScaleMax.
+type ScorecardCompetency struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ScaleMax    int    `json:"scale_max"`
+	Required    bool   `json:"required"`
+}
+
+// ScorecardQuestion es una pregunta de respuesta libre del scorecard.
+type ScorecardQuestion struct {
+	Key      string `json:"key"`
+	Text     string `json:"text"`
+	Required bool   `json:"required"`
+}
+
+// Scorecard es la evaluación de un entrevistador sobre una postulación en una
+// etapa. Cada entrevistador tiene uno por etapa; reenviarlo lo reemplaza.
+type Scorecard struct {
+	BaseModel
+
+	CompanyID     uint   `gorm:"not null;index" json:"company_id"`
+	ApplicationID uint   `gorm:"not null;uniqueIndex:idx_scorecards_app_stage_interviewer,where:deleted_at IS NULL" json:"application_id"`
+	Stage         string `gorm:"type:varchar(100);not null;uniqueIndex:idx_scorecards_app_stage_interviewer,where:deleted_at IS NULL" json:"stage"`
+	InterviewerID uint   `gorm:"not null;uniqueIndex:idx_scorecards_app_stage_interviewer,where:deleted_at IS NULL" json:"interviewer_id"`
+	TemplateID    *uint  `gorm:"index" json:"template_id,omitempty"` // plantilla vigente al enviarlo
+
+	Ratings        datatypes.JSONSlice[ScorecardRating] `gorm:"type:jsonb" json:"ratings"`
+	Answers        datatypes.JSONSlice[ScorecardAnswer] `gorm:"type:jsonb" json:"answers"`
+	Recommendation string                               `gorm:"type:varchar(20);not null" json:"recommendation"` // Recommendation*
+	Summary        string                               `gorm:"type:text" json:"summary,omitempty"`
+	SubmittedAt    time.Time                            `gorm:"type:timestamp;not null" json:"submitted_at"`
+}
+
+// TableName overrides the table name (optional)
+func (Scorecard) TableName() string {
+	return "scorecards"
+}
+
+// ScorecardRating es la nota de una competencia (1..ScaleMax de la plantilla).
+type ScorecardRating struct {
+	Competency string `json:"competency"`
+	Score      int    `json:"score"`
+	ScaleMax   int    `json:"scale_max"` // copiada de la plantilla para agregar sin ella
+	Note       string `json:"note,omitempty"`
+}
+
+// ScorecardAnswer es la respuesta a una pregunta de la plantilla.
+type ScorecardAnswer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "gorm.io/datatypes"
+
+// Tipos de pregunta de screening.
+const (
+	ScreeningYesNo  = "yes_no"
+	ScreeningChoice = "choice" // una opción de Options
+	ScreeningNumber = "number" // p. ej. expectativa salarial o años con Go
+	ScreeningText   = "text"   // respuesta libre, nunca eliminatoria
+)
+
+// ScreeningQuestion es una pregunta que la vacante hace al postularse por la
+// career page. Una pregunta knockout descarta la postulación si la respuesta
+// no cumple su regla: ExpectedAnswer (yes_no), AcceptedOptions (choice) o
+// MinValue/MaxValue (number). RejectionReason es el motivo del catálogo
+// rejected_by_us con que se rechaza.
+type ScreeningQuestion struct {
+	BaseModel
+
+	CompanyID uint   `gorm:"not null;index" json:"company_id"`
+	JobID     uint   `gorm:"not null;index" json:"job_id"`
+	Position  int    `gorm:"not null;default:0" json:"position"`
+	Kind      string `gorm:"type:varchar(20);not null" json:"kind"` // Screening*
+	Prompt    string `gorm:"type:varchar(500);not null" json:"prompt"`
+	// Options son las opciones de una pregunta choice.
+	Options  datatypes.JSONSlice[string] `gorm:"type:jsonb" json:"options,omitempty"`
+	Required bool                        `gorm:"not null;default:false" json:"required"`
+	Knockout bool                        `gorm:"not null;default:false" json:"knockout"`
+
+	ExpectedAnswer  *bool                       `json:"expected_answer,omitempty"`
+	AcceptedOptions datatypes.JSONSlice[string] `gorm:"type:jsonb" json:"accepted_options,omitempty"`
+	MinValue        *float64                    `gorm:"type:decimal(14,2)" json:"min_value,omitempty"`
+	MaxValue        *float64                    `gorm:"type:decimal(14,2)" json:"max_value,omitempty"`
+	RejectionReason string                      `gorm:"type:varchar(100)" json:"rejection_reason,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (ScreeningQuestion) TableName() string {
+	return "screening_questions"
+}
+
+// ScreeningAnswer es la respuesta de un candidato a una pregunta, guardada
+// con su postulación. Prompt y Kind copian la pregunta al responder: editarla
+// o quitarla después no cambia lo que se respondió. Answer va normalizado
+// ("yes"/"no", la opción elegida, el número).
+type ScreeningAnswer struct {
+	BaseModel
+
+	ApplicationID uint   `gorm:"not null;uniqueIndex:idx_screening_answers_application_question" json:"application_id"`
+	QuestionID    uint   `gorm:"not null;uniqueIndex:idx_screening_answers_application_question" json:"question_id"`
+	Prompt        string `gorm:"type:varchar(500);not null" json:"prompt"`
+	Kind          string `gorm:"type:varchar(20);not null" json:"kind"`
+	Answer        string `gorm:"type:text" json:"answer"`
+	// KnockedOut indica que la respuesta no cumplió la regla de una pregunta
+	// knockout.
+	KnockedOut bool `gorm:"not null;default:false" json:"knocked_out"`
+}
+
+// TableName overrides the table name (optional)
+func (ScreeningAnswer) TableName() string {
+	return "screening_answers"
+}
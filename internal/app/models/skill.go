@@ -0,0 +1,96 @@
+package models
+
+import "gorm.io/datatypes"
+
+// Categorías del catálogo de habilidades.
+const (
+	SkillCategoryLanguage   = "language" // lenguajes de programación
+	SkillCategoryFrontend   = "frontend"
+	SkillCategoryBackend    = "backend"
+	SkillCategoryData       = "data"  // bases de datos, analítica y ML
+	SkillCategoryCloud      = "cloud" // cloud, DevOps e infraestructura
+	SkillCategoryMobile     = "mobile"
+	SkillCategoryTesting    = "testing"
+	SkillCategoryManagement = "management" // producto, diseño y metodologías
+	SkillCategorySoft       = "soft"
+	SkillCategoryOther      = "other"
+)
+
+// Niveles de dominio de una habilidad, de menor a mayor.
+const (
+	SkillLevelBasic        = "basic"
+	SkillLevelIntermediate = "intermediate"
+	SkillLevelAdvanced     = "advanced"
+	SkillLevelExpert       = "expert"
+)
+
+// Skill es una habilidad del catálogo. Las globales (CompanyID NULL) las
+// mantiene la plataforma; cada empresa agrega las suyas. Nombre y alias
+// ("JS" → JavaScript) identifican a la habilidad sin distinguir mayúsculas
+// ni acentos y no se repiten entre las que ve una empresa.
+type Skill struct {
+	BaseModel
+
+	CompanyID *uint                       `gorm:"index" json:"company_id,omitempty"` // NULL = global
+	Name      string                      `gorm:"type:varchar(100);not null" json:"name"`
+	Category  string                      `gorm:"type:varchar(20);not null;index" json:"category"` // SkillCategory*
+	Aliases   datatypes.JSONSlice[string] `gorm:"type:jsonb" json:"aliases"`
+}
+
+// TableName overrides the table name (optional)
+func (Skill) TableName() string {
+	return "skills"
+}
+
+// Requisitos de una habilidad en una vacante.
+const (
+	JobSkillRequired   = "required"
+	JobSkillNiceToHave = "nice_to_have"
+)
+
+// JobSkill es una habilidad que pide una vacante. Level y MinYears vacíos =
+// alcanza con tenerla. Quitarla elimina la fila.
+type JobSkill struct {
+	BaseModel
+
+	JobID       uint     `gorm:"not null;uniqueIndex:idx_job_skills_job_skill,priority:1" json:"job_id"`
+	SkillID     uint     `gorm:"not null;uniqueIndex:idx_job_skills_job_skill,priority:2;index" json:"skill_id"`
+	Requirement string   `gorm:"type:varchar(20);not null" json:"requirement"` // JobSkill*
+	Level       string   `gorm:"type:varchar(20)" json:"level,omitempty"`      // SkillLevel* mínimo
+	MinYears    *float64 `gorm:"type:decimal(4,1)" json:"min_years,omitempty"`
+
+	// Relaciones
+	Skill *Skill `gorm:"foreignKey:SkillID" json:"skill,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (JobSkill) TableName() string {
+	return "job_skills"
+}
+
+// Origen de una habilidad del candidato.
+const (
+	CandidateSkillManual = "manual"
+	CandidateSkillResume = "resume" // inferida del análisis del CV
+)
+
+// CandidateSkill es una habilidad del candidato con su nivel y años de
+// experiencia (opcionales). Las que vienen del CV no pisan las cargadas a
+// mano. Quitarla elimina la fila.
+type CandidateSkill struct {
+	BaseModel
+
+	CandidateID uint     `gorm:"not null;uniqueIndex:idx_candidate_skills_candidate_skill,priority:1" json:"candidate_id"`
+	SkillID     uint     `gorm:"not null;uniqueIndex:idx_candidate_skills_candidate_skill,priority:2;index" json:"skill_id"`
+	Level       string   `gorm:"type:varchar(20)" json:"level,omitempty"` // SkillLevel*
+	Years       *float64 `gorm:"type:decimal(4,1)" json:"years,omitempty"`
+	Source      string   `gorm:"type:varchar(20);not null" json:"source"` // CandidateSkill*
+
+	// Relaciones
+	Skill *Skill `gorm:"foreignKey:SkillID" json:"skill,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (CandidateSkill) TableName() string {
+	return "candidate_skills"
+}
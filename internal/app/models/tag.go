@@ -0,0 +1,36 @@
+package models
+
+// Tag es una etiqueta de la empresa para clasificar candidatos (p. ej.
+// "silver-medalist"). El nombre es único por empresa sin distinguir mayúsculas.
+type Tag struct {
+	BaseModel
+
+	CompanyID uint   `gorm:"not null;uniqueIndex:idx_tags_company_name,priority:1" json:"company_id"`
+	Name      string `gorm:"type:varchar(50);not null;uniqueIndex:idx_tags_company_name,priority:2" json:"name"`
+	Color     string `gorm:"type:varchar(7)" json:"color,omitempty"` // #RRGGBB
+
+	// Relaciones
+	Company *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// CandidateTag asigna una etiqueta a un candidato. Quitarla elimina la fila.
+type CandidateTag struct {
+	BaseModel
+
+	CandidateID uint  `gorm:"not null;uniqueIndex:idx_candidate_tags_candidate_tag,priority:1" json:"candidate_id"`
+	TagID       uint  `gorm:"not null;uniqueIndex:idx_candidate_tags_candidate_tag,priority:2;index" json:"tag_id"`
+	CreatedByID *uint `gorm:"" json:"created_by_id,omitempty"`
+
+	// Relaciones
+	Tag *Tag `gorm:"foreignKey:TagID" json:"tag,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (CandidateTag) TableName() string {
+	return "candidate_tags"
+}
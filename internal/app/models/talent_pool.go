@@ -0,0 +1,42 @@
+package models
+
+// TalentPool es una lista con nombre de candidatos de la empresa para
+// futuras búsquedas (p. ej. los silver medalists de una vacante). La
+// pertenencia es del candidato, no de una postulación: rechazar la
+// postulación no lo saca del pool. Shared la hace visible para todo el
+// equipo; si no, solo la ve y edita su dueño.
+type TalentPool struct {
+	BaseModel
+
+	CompanyID   uint   `gorm:"not null;index" json:"company_id"`
+	Name        string `gorm:"type:varchar(100);not null" json:"name"`
+	Description string `gorm:"type:text" json:"description,omitempty"`
+	Shared      bool   `gorm:"not null;default:true" json:"shared"`
+	OwnerID     uint   `gorm:"not null;index" json:"owner_id"`
+}
+
+// TableName overrides the table name (optional)
+func (TalentPool) TableName() string {
+	return "talent_pools"
+}
+
+// TalentPoolMember es un candidato dentro de un pool con la nota de por qué
+// se agregó. ApplicationID es la postulación de la que salió, si la hay.
+// Quitarlo elimina la fila.
+type TalentPoolMember struct {
+	BaseModel
+
+	PoolID        uint   `gorm:"not null;uniqueIndex:idx_talent_pool_members_pool_candidate,priority:1" json:"pool_id"`
+	CandidateID   uint   `gorm:"not null;uniqueIndex:idx_talent_pool_members_pool_candidate,priority:2;index" json:"candidate_id"`
+	ApplicationID *uint  `gorm:"index" json:"application_id,omitempty"`
+	Note          string `gorm:"type:text" json:"note,omitempty"`
+	AddedByID     *uint  `gorm:"" json:"added_by_id,omitempty"` // NULL = automatización
+
+	// Relaciones
+	Candidate *Candidate `gorm:"foreignKey:CandidateID" json:"candidate,omitempty"`
+}
+
+// TableName overrides the table name (optional)
+func (TalentPoolMember) TableName() string {
+	return "talent_pool_members"
+}
@@ -1,12 +1,44 @@
 package repositories
 
 import (
+	"errors"
+
+	"dvra-api/internal/app/dtos"
 	"dvra-api/internal/app/models"
 	"dvra-api/internal/database"
+	"dvra-api/internal/shared/rank"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrBoardChanged indica que la columna del tablero cambió desde que el
+// cliente la cargó (los vecinos se movieron o hay tarjetas nuevas entre ellos).
+var ErrBoardChanged = errors.New("the stage column changed since it was loaded")
+
+// errRepositionMissing corta la transacción de Reposition cuando la
+// postulación ya no existe (eliminada mientras se arrastraba); Reposition lo
+// devuelve como nil, nil y el servicio responde 404. Es propio para no
+// confundirlo con un ErrRecordNotFound de otra consulta de la transacción.
+var errRepositionMissing = errors.New("application to reposition not found")
+
+// ErrStageChanged indica que la postulación cambió de etapa desde que se
+// leyó: otra transición se guardó antes.
+var ErrStageChanged = errors.New("the application changed stage since it was loaded")
+
+// stageColumns son las columnas que escribe un cambio de etapa (más rating,
+// que UpdateApplication puede cambiar en la misma petición). Update no las
+// toca: guardar otra cosa no pisa una transición o un reordenamiento
+// concurrentes.
+var stageColumns = []string{
+	"stage", "position", "rejected_at", "hired_at",
+	"rejection_type", "rejection_reason", "rejected_from_stage",
+}
+
+// byPosition es el orden manual de una columna. Las postulaciones previas a
+// Position (vacía hasta que corre el seeder) quedan primero, por id.
+const byPosition = `position COLLATE "C" ASC, id ASC`
+
 // ApplicationRepository define el contrato del repositorio de applications
 type ApplicationRepository interface {
 	GetAll() ([]models.Application, error)
@@ -17,21 +49,64 @@ type ApplicationRepository interface {
 	GetByStage(stage string, companyID uint) ([]models.Application, error)
 	GetByCandidateAndJob(candidateID, jobID uint) (*models.Application, error)
 	Create(application *models.Application) (*models.Application, error)
+	// CreateWithEvent crea la postulación y su evento de entrada al pipeline
+	// en una transacción (event.ApplicationID se completa aquí).
+	CreateWithEvent(application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error)
+	// Update guarda la postulación sin tocar su etapa ni su posición.
 	Update(application *models.Application) (*models.Application, error)
+	// UpdateWithEvent guarda la postulación y su evento de etapa en una
+	// transacción, solo si sigue en event.FromStage; si no, ErrStageChanged.
+	UpdateWithEvent(application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error)
 	Delete(id uint) error
+	// GetRejectionCounts agrupa las postulaciones cerradas en etapa rejected
+	// (jobID 0 = todas las vacantes de la empresa).
+	GetRejectionCounts(companyID, jobID uint) ([]dtos.RejectionCountRow, error)
+	// GetBoard devuelve una página de cada columna (empresa + etapa) en el
+	// orden pedido y el total de postulaciones por etapa.
+	GetBoard(companyID uint, query dtos.ApplicationBoardQuery) ([]models.Application, map[string]int64, error)
+	// GetBoardCards devuelve todas las tarjetas del tablero (empresa, y
+	// vacante y etapa si vienen) solo con id, etapa y candidato, para
+	// ordenarlas fuera de la base (orden por compatibilidad).
+	GetBoardCards(companyID uint, query dtos.ApplicationBoardQuery) ([]models.Application, error)
+	// GetByIDs devuelve las postulaciones con su vacante y candidato, sin
+	// orden.
+	GetByIDs(ids []uint) ([]models.Application, error)
+	// Reposition ubica la postulación entre afterID (arriba) y beforeID
+	// (abajo) de su columna tal como la ve el cliente (jobID 0 = tablero de
+	// toda la empresa); 0 = extremo. ErrBoardChanged si los vecinos ya no
+	// son contiguos o cambiaron de columna; nil, nil si la postulación no
+	// existe.
+	Reposition(id, afterID, beforeID, jobID uint) (*models.Application, error)
+	// WithTx devuelve el repositorio ligado a una transacción en curso.
+	WithTx(tx *gorm.DB) ApplicationRepository
 }
 
 // applicationRepository es la implementación con GORM
-type applicationRepository struct{}
+type applicationRepository struct {
+	// tx es la transacción a la que está ligado (WithTx); nil = database.DB.
+	tx *gorm.DB
+}
 
 // NewApplicationRepository crea una nueva instancia de ApplicationRepository
 func NewApplicationRepository() ApplicationRepository {
 	return &applicationRepository{}
 }
 
+// WithTx devuelve el repositorio ligado a la transacción tx.
+func (r *applicationRepository) WithTx(tx *gorm.DB) ApplicationRepository {
+	return &applicationRepository{tx: tx}
+}
+
+func (r *applicationRepository) conn() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return database.DB
+}
+
 func (r *applicationRepository) GetAll() ([]models.Application, error) {
 	var applications []models.Application
-	if err := database.DB.Preload("Job").Preload("Candidate").Preload("Company").Find(&applications).Error; err != nil {
+	if err := r.conn().Preload("Job").Preload("Candidate").Preload("Company").Find(&applications).Error; err != nil {
 		return nil, err
 	}
 	return applications, nil
@@ -39,7 +114,7 @@ func (r *applicationRepository) GetAll() ([]models.Application, error) {
 
 func (r *applicationRepository) GetByID(id uint) (*models.Application, error) {
 	var application models.Application
-	if err := database.DB.Preload("Job").Preload("Candidate").Preload("Company").First(&application, id).Error; err != nil {
+	if err := r.conn().Preload("Job").Preload("Candidate").Preload("Company").First(&application, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -50,7 +125,7 @@ func (r *applicationRepository) GetByID(id uint) (*models.Application, error) {
 
 func (r *applicationRepository) GetByJobID(jobID uint) ([]models.Application, error) {
 	var applications []models.Application
-	if err := database.DB.Where("job_id = ?", jobID).Preload("Candidate").Find(&applications).Error; err != nil {
+	if err := r.conn().Where("job_id = ?", jobID).Preload("Candidate").Find(&applications).Error; err != nil {
 		return nil, err
 	}
 	return applications, nil
@@ -58,7 +133,7 @@ func (r *applicationRepository) GetByJobID(jobID uint) ([]models.Application, er
 
 func (r *applicationRepository) GetByCandidateID(candidateID uint) ([]models.Application, error) {
 	var applications []models.Application
-	if err := database.DB.Where("candidate_id = ?", candidateID).Preload("Job").Find(&applications).Error; err != nil {
+	if err := r.conn().Where("candidate_id = ?", candidateID).Preload("Job").Find(&applications).Error; err != nil {
 		return nil, err
 	}
 	return applications, nil
@@ -66,7 +141,7 @@ func (r *applicationRepository) GetByCandidateID(candidateID uint) ([]models.App
 
 func (r *applicationRepository) GetByCompanyID(companyID uint) ([]models.Application, error) {
 	var applications []models.Application
-	if err := database.DB.Where("company_id = ?", companyID).Preload("Job").Preload("Candidate").Find(&applications).Error; err != nil {
+	if err := r.conn().Where("company_id = ?", companyID).Preload("Job").Preload("Candidate").Find(&applications).Error; err != nil {
 		return nil, err
 	}
 	return applications, nil
@@ -74,7 +149,7 @@ func (r *applicationRepository) GetByCompanyID(companyID uint) ([]models.Applica
 
 func (r *applicationRepository) GetByStage(stage string, companyID uint) ([]models.Application, error) {
 	var applications []models.Application
-	if err := database.DB.Where("stage = ? AND company_id = ?", stage, companyID).Preload("Job").Preload("Candidate").Find(&applications).Error; err != nil {
+	if err := r.conn().Where("stage = ? AND company_id = ?", stage, companyID).Preload("Job").Preload("Candidate").Find(&applications).Error; err != nil {
 		return nil, err
 	}
 	return applications, nil
@@ -83,7 +158,7 @@ func (r *applicationRepository) GetByStage(stage string, companyID uint) ([]mode
 // GetByCandidateAndJob checks if a candidate has already applied to a specific job
 func (r *applicationRepository) GetByCandidateAndJob(candidateID, jobID uint) (*models.Application, error) {
 	var application models.Application
-	if err := database.DB.Where("candidate_id = ? AND job_id = ?", candidateID, jobID).First(&application).Error; err != nil {
+	if err := r.conn().Where("candidate_id = ? AND job_id = ?", candidateID, jobID).First(&application).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -93,19 +168,352 @@ func (r *applicationRepository) GetByCandidateAndJob(candidateID, jobID uint) (*
 }
 
 func (r *applicationRepository) Create(application *models.Application) (*models.Application, error) {
-	if err := database.DB.Create(application).Error; err != nil {
+	if err := r.conn().Create(application).Error; err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+func (r *applicationRepository) CreateWithEvent(application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error) {
+	err := r.conn().Transaction(func(tx *gorm.DB) error {
+		if err := placeLast(tx, application); err != nil {
+			return err
+		}
+		if err := tx.Create(application).Error; err != nil {
+			return err
+		}
+		event.ApplicationID = application.ID
+		return tx.Create(event).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	return application, nil
 }
 
 func (r *applicationRepository) Update(application *models.Application) (*models.Application, error) {
-	if err := database.DB.Save(application).Error; err != nil {
+	if err := r.conn().Omit(stageColumns...).Save(application).Error; err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+func (r *applicationRepository) UpdateWithEvent(application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error) {
+	err := r.conn().Transaction(func(tx *gorm.DB) error {
+		if err := placeLast(tx, application); err != nil {
+			return err
+		}
+		// UPDATE condicional: de dos transiciones que parten de la misma
+		// etapa solo se guarda la primera (RN-APP-002).
+		res := tx.Model(application).Where("stage = ?", event.FromStage).
+			Select(append(stageColumns, "rating", "updated_at")).
+			Updates(application)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrStageChanged
+		}
+		return tx.Create(event).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	return application, nil
 }
 
 func (r *applicationRepository) Delete(id uint) error {
-	return database.DB.Delete(&models.Application{}, id).Error
+	return r.conn().Delete(&models.Application{}, id).Error
+}
+
+func (r *applicationRepository) GetRejectionCounts(companyID, jobID uint) ([]dtos.RejectionCountRow, error) {
+	// La etapa de salida se guarda desde el catálogo de motivos; para los
+	// rechazos anteriores se toma del último evento que entró a la etapa.
+	query := r.conn().Table("applications a").
+		Select(`COALESCE(NULLIF(a.rejected_from_stage, ''), (
+				SELECT e.from_stage FROM application_stage_events e
+				WHERE e.application_id = a.id AND e.to_stage = a.stage AND e.deleted_at IS NULL
+				ORDER BY e.occurred_at DESC, e.id DESC LIMIT 1), '') AS stage,
+			a.job_id, COALESCE(j.title, '') AS job_title, COALESCE(NULLIF(c.source, ''), 'unknown') AS source,
+			COALESCE(a.rejection_type, '') AS type, COALESCE(a.rejection_reason, '') AS reason, COUNT(*) AS count`).
+		Joins("LEFT JOIN jobs j ON j.id = a.job_id").
+		Joins("LEFT JOIN candidates c ON c.id = a.candidate_id").
+		Where("a.company_id = ? AND a.rejected_at IS NOT NULL AND a.deleted_at IS NULL", companyID)
+	if jobID != 0 {
+		query = query.Where("a.job_id = ?", jobID)
+	}
+
+	var rows []dtos.RejectionCountRow
+	if err := query.Group("1, 2, 3, 4, 5, 6").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// boardOrders son los ORDER BY de cada orden del tablero (asc, desc).
+var boardOrders = map[string][2]string{
+	dtos.BoardSortRating:       {"rating ASC NULLS LAST, id ASC", "rating DESC NULLS LAST, id ASC"},
+	dtos.BoardSortAppliedAt:    {"applied_at ASC, id ASC", "applied_at DESC, id DESC"},
+	dtos.BoardSortLastActivity: {"updated_at ASC, id ASC", "updated_at DESC, id DESC"},
+}
+
+func (r *applicationRepository) GetBoard(companyID uint, query dtos.ApplicationBoardQuery) ([]models.Application, map[string]int64, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("company_id = ?", companyID)
+		if query.JobID != 0 {
+			db = db.Where("job_id = ?", query.JobID)
+		}
+		if query.Stage != "" {
+			db = db.Where("stage = ?", query.Stage)
+		}
+		return db
+	}
+
+	var totals []struct {
+		Stage string
+		Total int64
+	}
+	if err := r.conn().Model(&models.Application{}).Scopes(scope).
+		Select("stage, COUNT(*) AS total").Group("stage").
+		Scan(&totals).Error; err != nil {
+		return nil, nil, err
+	}
+	counts := make(map[string]int64, len(totals))
+	for _, t := range totals {
+		counts[t.Stage] = t.Total
+	}
+
+	order := byPosition
+	if o, ok := boardOrders[query.Sort]; ok {
+		order = o[1]
+		if query.Order == "asc" {
+			order = o[0]
+		}
+	}
+	// Una página por columna: numerar dentro de cada etapa y cortar.
+	page := r.conn().Model(&models.Application{}).Scopes(scope).
+		Select("id, ROW_NUMBER() OVER (PARTITION BY stage ORDER BY " + order + ") AS rn")
+	var ids []uint
+	if err := r.conn().Table("(?) AS ranked", page).
+		Where("rn > ? AND rn <= ?", query.Offset, query.Offset+query.Limit).
+		Order("rn ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return []models.Application{}, counts, nil
+	}
+
+	var applications []models.Application
+	if err := r.conn().Where("id IN ?", ids).
+		Preload("Job").Preload("Candidate").
+		Order(order).
+		Find(&applications).Error; err != nil {
+		return nil, nil, err
+	}
+	return applications, counts, nil
+}
+
+func (r *applicationRepository) GetBoardCards(companyID uint, query dtos.ApplicationBoardQuery) ([]models.Application, error) {
+	db := r.conn().Select("id", "stage", "candidate_id").Where("company_id = ?", companyID)
+	if query.JobID != 0 {
+		db = db.Where("job_id = ?", query.JobID)
+	}
+	if query.Stage != "" {
+		db = db.Where("stage = ?", query.Stage)
+	}
+	var cards []models.Application
+	if err := db.Order("id ASC").Find(&cards).Error; err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func (r *applicationRepository) GetByIDs(ids []uint) ([]models.Application, error) {
+	var applications []models.Application
+	if len(ids) == 0 {
+		return applications, nil
+	}
+	if err := r.conn().Where("id IN ?", ids).
+		Preload("Job").Preload("Candidate").
+		Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *applicationRepository) Reposition(id, afterID, beforeID, jobID uint) (*models.Application, error) {
+	var moved models.Application
+	err := r.conn().Transaction(func(tx *gorm.DB) error {
+		// Bloquear la tarjeta y sus vecinos (en orden de id, sin deadlocks)
+		// serializa los movimientos que los comparten: el segundo en llegar
+		// ve el resultado del primero y recibe ErrBoardChanged.
+		ids := []uint{id}
+		for _, n := range []uint{afterID, beforeID} {
+			if n != 0 {
+				ids = append(ids, n)
+			}
+		}
+		var rows []models.Application
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", ids).Order("id ASC").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]*models.Application, len(rows))
+		for i := range rows {
+			byID[rows[i].ID] = &rows[i]
+		}
+		app := byID[id]
+		if app == nil {
+			return errRepositionMissing
+		}
+		if jobID != 0 && app.JobID != jobID {
+			return ErrBoardChanged
+		}
+		neighbor := func(nid uint) (*models.Application, error) {
+			if nid == 0 {
+				return nil, nil
+			}
+			n := byID[nid]
+			if n == nil || n.CompanyID != app.CompanyID || n.Stage != app.Stage || (jobID != 0 && n.JobID != jobID) {
+				return nil, ErrBoardChanged
+			}
+			return n, nil
+		}
+		above, err := neighbor(afterID)
+		if err != nil {
+			return err
+		}
+		below, err := neighbor(beforeID)
+		if err != nil {
+			return err
+		}
+		if above != nil && below != nil && !positionLess(above, below) {
+			return ErrBoardChanged
+		}
+
+		// La vista del cliente sigue vigente si no hay nada entre los vecinos.
+		var count int64
+		if err := between(tx, app, above, below, jobID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrBoardChanged
+		}
+
+		position, err := keyBetween(above, below)
+		if err != nil || len(position) > rank.MaxLength {
+			// Sin hueco (keys iguales, previas a Position o demasiado largas):
+			// redistribuir la columna y volver a calcular.
+			keys, err := rebalance(tx, app.CompanyID, app.Stage)
+			if err != nil {
+				return err
+			}
+			if above != nil {
+				above.Position = keys[above.ID]
+			}
+			if below != nil {
+				below.Position = keys[below.ID]
+			}
+			if position, err = keyBetween(above, below); err != nil {
+				return err
+			}
+		}
+
+		// UpdateColumn: reordenar no es actividad de la postulación.
+		if err := tx.Model(app).UpdateColumn("position", position).Error; err != nil {
+			return err
+		}
+		app.Position = position
+		moved = *app
+		return nil
+	})
+	if errors.Is(err, errRepositionMissing) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+// between son las tarjetas de la columna que el cliente ve (la vacante
+// filtrada, si hay) entre above y below, sin contar app. Las de otras
+// vacantes que caen en el medio no cuentan: el tablero filtrado no las
+// muestra y la key nueva igual queda entre los vecinos.
+func between(tx *gorm.DB, app, above, below *models.Application, jobID uint) *gorm.DB {
+	db := tx.Model(&models.Application{}).
+		Where("company_id = ? AND stage = ? AND id <> ?", app.CompanyID, app.Stage, app.ID)
+	if jobID != 0 {
+		db = db.Where("job_id = ?", jobID)
+	}
+	if above != nil {
+		db = db.Where(`(position COLLATE "C", id) > (?, ?)`, above.Position, above.ID)
+	}
+	if below != nil {
+		db = db.Where(`(position COLLATE "C", id) < (?, ?)`, below.Position, below.ID)
+	}
+	return db
+}
+
+// placeLast ubica la postulación al final de la columna de su etapa.
+func placeLast(tx *gorm.DB, application *models.Application) error {
+	var last []string
+	if err := tx.Model(&models.Application{}).
+		Where("company_id = ? AND stage = ?", application.CompanyID, application.Stage).
+		Order(`position COLLATE "C" DESC`).Limit(1).
+		Pluck("position", &last).Error; err != nil {
+		return err
+	}
+	application.Position = rank.After("")
+	if len(last) > 0 && last[0] != "" {
+		application.Position = rank.After(last[0])
+	}
+	return nil
+}
+
+// rebalance reparte keys nuevas de igual largo en toda la columna,
+// conservando su orden, y devuelve la key de cada postulación.
+func rebalance(tx *gorm.DB, companyID uint, stage string) (map[uint]string, error) {
+	var ids []uint
+	if err := tx.Model(&models.Application{}).
+		Where("company_id = ? AND stage = ?", companyID, stage).
+		Order(byPosition).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	keys := make(map[uint]string, len(ids))
+	for i, key := range rank.Spread(len(ids)) {
+		if err := tx.Model(&models.Application{}).Where("id = ?", ids[i]).UpdateColumn("position", key).Error; err != nil {
+			return nil, err
+		}
+		keys[ids[i]] = key
+	}
+	return keys, nil
+}
+
+func keyBetween(above, below *models.Application) (string, error) {
+	lower, upper := "", ""
+	if above != nil {
+		if above.Position == "" {
+			return "", rank.ErrNoRoom
+		}
+		lower = above.Position
+	}
+	if below != nil {
+		if below.Position == "" {
+			return "", rank.ErrNoRoom
+		}
+		upper = below.Position
+	}
+	return rank.Between(lower, upper)
+}
+
+// positionLess compara como byPosition.
+func positionLess(a, b *models.Application) bool {
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	return a.ID < b.ID
 }
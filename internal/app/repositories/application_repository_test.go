@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+
+	"dvra-api/internal/app/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRun arma las consultas sin conectarse a la base.
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func card(id, jobID uint, position string) *models.Application {
+	application := &models.Application{CompanyID: 1, JobID: jobID, Stage: "screening", Position: position}
+	application.ID = id
+	return application
+}
+
+// explain devuelve la consulta con sus parámetros ya interpolados.
+func explain(stmt *gorm.Statement) string {
+	return stmt.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+}
+
+// Dos vacantes intercaladas en la misma etapa (a0 y a2 de la 7, a1 de la 8):
+// en el tablero filtrado por la vacante 7, la tarjeta de la 8 que cae entre
+// los vecinos no cuenta como un cambio de la columna.
+func TestBetweenInterleavedJobs(t *testing.T) {
+	above, below, moved := card(11, 7, "a0"), card(13, 7, "a2"), card(14, 7, "a3")
+
+	var count int64
+	sql := explain(between(dryRun(t), moved, above, below, 7).Count(&count).Statement)
+	for _, want := range []string{"job_id = 7", "stage = 'screening'", "> ('a0', 11)", "< ('a2', 13)"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("falta %q en %s", want, sql)
+		}
+	}
+
+	// Sin filtro (tablero de toda la empresa) cuentan todas las vacantes.
+	sql = explain(between(dryRun(t), moved, above, below, 0).Count(&count).Statement)
+	if strings.Contains(sql, "job_id") {
+		t.Errorf("tablero de la empresa filtrado por vacante: %s", sql)
+	}
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/database"
+)
+
+// ApplicationStageEventRepository define el contrato del historial de etapas
+type ApplicationStageEventRepository interface {
+	// GetTimeline devuelve los eventos de la postulación en orden, con el
+	// nombre del actor. El tiempo en cada etapa lo calcula el servicio.
+	GetTimeline(applicationID uint) ([]dtos.StageEventDTO, error)
+	// GetStageEvents devuelve los eventos de las postulaciones vigentes de la
+	// empresa (jobID 0 = todas las vacantes) ordenados por postulación y
+	// fecha, solo con postulación, etapa, backfilled y fecha.
+	GetStageEvents(companyID, jobID uint) ([]models.ApplicationStageEvent, error)
+	// GetStageReach cuenta cuántas postulaciones distintas entraron a cada
+	// etapa (jobID 0 = todas las vacantes).
+	GetStageReach(companyID, jobID uint) (map[string]int, error)
+}
+
+// applicationStageEventRepository es la implementación con GORM
+type applicationStageEventRepository struct{}
+
+// NewApplicationStageEventRepository crea una nueva instancia de ApplicationStageEventRepository
+func NewApplicationStageEventRepository() ApplicationStageEventRepository {
+	return &applicationStageEventRepository{}
+}
+
+func (r *applicationStageEventRepository) GetTimeline(applicationID uint) ([]dtos.StageEventDTO, error) {
+	var events []dtos.StageEventDTO
+	if err := database.DB.Table("application_stage_events e").
+		Select(`e.id, e.from_stage, e.to_stage, e.actor_id,
+			NULLIF(CONCAT_WS(' ', u.first_name, u.last_name), '') AS actor_name,
+			e.reason, e.override, e.backfilled, e.occurred_at`).
+		Joins("LEFT JOIN users u ON u.id = e.actor_id").
+		Where("e.application_id = ? AND e.deleted_at IS NULL", applicationID).
+		Order("e.occurred_at ASC, e.id ASC").
+		Scan(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *applicationStageEventRepository) GetStageEvents(companyID, jobID uint) ([]models.ApplicationStageEvent, error) {
+	query := database.DB.Table("application_stage_events e").
+		Select("e.id, e.application_id, e.to_stage, e.backfilled, e.occurred_at").
+		Joins("JOIN applications a ON a.id = e.application_id AND a.deleted_at IS NULL").
+		Where("e.company_id = ? AND e.deleted_at IS NULL", companyID)
+	if jobID != 0 {
+		query = query.Where("a.job_id = ?", jobID)
+	}
+
+	var events []models.ApplicationStageEvent
+	if err := query.Order("e.application_id ASC, e.occurred_at ASC, e.id ASC").Scan(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *applicationStageEventRepository) GetStageReach(companyID, jobID uint) (map[string]int, error) {
+	query := database.DB.Table("application_stage_events e").
+		Select("e.to_stage AS stage, COUNT(DISTINCT e.application_id) AS reached").
+		Joins("JOIN applications a ON a.id = e.application_id AND a.deleted_at IS NULL").
+		Where("e.company_id = ? AND e.deleted_at IS NULL", companyID)
+	if jobID != 0 {
+		query = query.Where("a.job_id = ?", jobID)
+	}
+
+	var rows []struct {
+		Stage   string
+		Reached int
+	}
+	if err := query.Group("e.to_stage").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	reach := make(map[string]int, len(rows))
+	for _, row := range rows {
+		reach[row.Stage] = row.Reached
+	}
+	return reach, nil
+}
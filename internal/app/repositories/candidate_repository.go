@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"dvra-api/internal/app/dtos"
 	"dvra-api/internal/app/models"
 	"dvra-api/internal/database"
 
@@ -12,23 +13,46 @@ type CandidateRepository interface {
 	GetAll() ([]models.Candidate, error)
 	GetByID(id uint) (*models.Candidate, error)
 	GetByCompanyID(companyID uint) ([]models.Candidate, error)
+	// Search aplica los filtros de GET /candidates (companyID 0 = todas las
+	// empresas; viewerID 0 = sin restricción de pools privados).
+	Search(companyID, viewerID uint, filters dtos.CandidateFilters) ([]models.Candidate, error)
+	// IDsInCompany devuelve cuáles de ids son candidatos activos de la
+	// empresa (0 = cualquiera).
+	IDsInCompany(companyID uint, ids []uint) ([]uint, error)
 	GetByEmail(email string, companyID uint) (*models.Candidate, error)
 	Create(candidate *models.Candidate) (*models.Candidate, error)
 	Update(candidate *models.Candidate) (*models.Candidate, error)
 	Delete(id uint) error
+	// WithTx devuelve el repositorio ligado a una transacción en curso.
+	WithTx(tx *gorm.DB) CandidateRepository
 }
 
 // candidateRepository es la implementación con GORM
-type candidateRepository struct{}
+type candidateRepository struct {
+	// tx es la transacción a la que está ligado (WithTx); nil = database.DB.
+	tx *gorm.DB
+}
 
 // NewCandidateRepository crea una nueva instancia de CandidateRepository
 func NewCandidateRepository() CandidateRepository {
 	return &candidateRepository{}
 }
 
+// WithTx devuelve el repositorio ligado a la transacción tx.
+func (r *candidateRepository) WithTx(tx *gorm.DB) CandidateRepository {
+	return &candidateRepository{tx: tx}
+}
+
+func (r *candidateRepository) conn() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return database.DB
+}
+
 func (r *candidateRepository) GetAll() ([]models.Candidate, error) {
 	var candidates []models.Candidate
-	if err := database.DB.Preload("Company").Find(&candidates).Error; err != nil {
+	if err := r.conn().Preload("Company").Find(&candidates).Error; err != nil {
 		return nil, err
 	}
 	return candidates, nil
@@ -36,7 +60,7 @@ func (r *candidateRepository) GetAll() ([]models.Candidate, error) {
 
 func (r *candidateRepository) GetByID(id uint) (*models.Candidate, error) {
 	var candidate models.Candidate
-	if err := database.DB.Preload("Company").Preload("Applications").First(&candidate, id).Error; err != nil {
+	if err := r.conn().Preload("Company").Preload("Applications").First(&candidate, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -47,15 +71,70 @@ func (r *candidateRepository) GetByID(id uint) (*models.Candidate, error) {
 
 func (r *candidateRepository) GetByCompanyID(companyID uint) ([]models.Candidate, error) {
 	var candidates []models.Candidate
-	if err := database.DB.Where("company_id = ?", companyID).Find(&candidates).Error; err != nil {
+	if err := r.conn().Where("company_id = ?", companyID).Find(&candidates).Error; err != nil {
 		return nil, err
 	}
 	return candidates, nil
 }
 
+func (r *candidateRepository) Search(companyID, viewerID uint, filters dtos.CandidateFilters) ([]models.Candidate, error) {
+	query := r.conn().Model(&models.Candidate{})
+	if companyID != 0 {
+		query = query.Where("candidates.company_id = ?", companyID)
+	}
+	if filters.Contactable || filters.PoolID != 0 {
+		query = query.Scopes(ContactableCandidates)
+	}
+	for _, tagID := range filters.TagIDs {
+		query = query.Where("EXISTS (SELECT 1 FROM candidate_tags ct WHERE ct.candidate_id = candidates.id AND ct.tag_id = ? AND ct.deleted_at IS NULL)", tagID)
+	}
+	for _, skillID := range filters.SkillIDs {
+		if filters.MinSkillYears > 0 {
+			query = query.Where("EXISTS (SELECT 1 FROM candidate_skills cs WHERE cs.candidate_id = candidates.id AND cs.skill_id = ? AND cs.years >= ? AND cs.deleted_at IS NULL)", skillID, filters.MinSkillYears)
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM candidate_skills cs WHERE cs.candidate_id = candidates.id AND cs.skill_id = ? AND cs.deleted_at IS NULL)", skillID)
+		}
+	}
+	if filters.PoolID != 0 {
+		members := r.conn().Table("talent_pool_members m").
+			Select("m.candidate_id").
+			Joins("JOIN talent_pools p ON p.id = m.pool_id AND p.deleted_at IS NULL").
+			Where("m.pool_id = ? AND m.deleted_at IS NULL", filters.PoolID)
+		if viewerID != 0 {
+			members = members.Where("(p.shared OR p.owner_id = ?)", viewerID)
+		}
+		query = query.Where("candidates.id IN (?)", members)
+	}
+
+	var candidates []models.Candidate
+	if err := query.Order("candidates.id ASC").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *candidateRepository) IDsInCompany(companyID uint, ids []uint) ([]uint, error) {
+	var found []uint
+	query := r.conn().Model(&models.Candidate{}).Where("id IN ?", ids)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if err := query.Order("id ASC").Pluck("id", &found).Error; err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// ContactableCandidates restringe una consulta sobre candidates a los que
+// aceptaron ser contactados por futuras vacantes (RN-GDPR-001). Toda función
+// de sourcing (búsqueda de talento, pools, matching) debe aplicarlo.
+func ContactableCandidates(db *gorm.DB) *gorm.DB {
+	return db.Where(models.ContactableCondition("candidates"))
+}
+
 func (r *candidateRepository) GetByEmail(email string, companyID uint) (*models.Candidate, error) {
 	var candidate models.Candidate
-	if err := database.DB.Where("email = ? AND company_id = ?", email, companyID).First(&candidate).Error; err != nil {
+	if err := r.conn().Where("email = ? AND company_id = ?", email, companyID).First(&candidate).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -65,19 +144,19 @@ func (r *candidateRepository) GetByEmail(email string, companyID uint) (*models.
 }
 
 func (r *candidateRepository) Create(candidate *models.Candidate) (*models.Candidate, error) {
-	if err := database.DB.Create(candidate).Error; err != nil {
+	if err := r.conn().Create(candidate).Error; err != nil {
 		return nil, err
 	}
 	return candidate, nil
 }
 
 func (r *candidateRepository) Update(candidate *models.Candidate) (*models.Candidate, error) {
-	if err := database.DB.Save(candidate).Error; err != nil {
+	if err := r.conn().Save(candidate).Error; err != nil {
 		return nil, err
 	}
 	return candidate, nil
 }
 
 func (r *candidateRepository) Delete(id uint) error {
-	return database.DB.Delete(&models.Candidate{}, id).Error
+	return r.conn().Delete(&models.Candidate{}, id).Error
 }
@@ -8,7 +8,9 @@ import (
 
 // DashboardRepository define el contrato del repositorio de dashboard
 type DashboardRepository interface {
-	GetStats(companyID uint) (*dtos.DashboardStatsDTO, error)
+	// hiredStages son las keys de tipo hired de los pipelines de la empresa.
+	// ApplicationsByStage sale solo con Key y Count; el servicio lo completa.
+	GetStats(companyID uint, hiredStages []string) (*dtos.DashboardStatsDTO, error)
 }
 
 // dashboardRepository es la implementación con GORM
@@ -19,7 +21,7 @@ func NewDashboardRepository() DashboardRepository {
 	return &dashboardRepository{}
 }
 
-func (r *dashboardRepository) GetStats(companyID uint) (*dtos.DashboardStatsDTO, error) {
+func (r *dashboardRepository) GetStats(companyID uint, hiredStages []string) (*dtos.DashboardStatsDTO, error) {
 	stats := &dtos.DashboardStatsDTO{}
 
 	// ========== JOBS STATS ==========
@@ -66,8 +68,6 @@ func (r *dashboardRepository) GetStats(companyID uint) (*dtos.DashboardStatsDTO,
 	stats.TotalApplications = int(totalApplications)
 
 	// Applications by stage
-	stats.ApplicationsByStage = dtos.ApplicationsByStageDTO{}
-
 	type stageCount struct {
 		Stage string
 		Count int
@@ -81,18 +81,14 @@ func (r *dashboardRepository) GetStats(companyID uint) (*dtos.DashboardStatsDTO,
 		return nil, err
 	}
 
-	for _, sc := range stageCounts {
-		switch sc.Stage {
-		case "applied":
-			stats.ApplicationsByStage.Applied = sc.Count
-		case "screening":
-			stats.ApplicationsByStage.Screening = sc.Count
-		case "technical":
-			stats.ApplicationsByStage.Technical = sc.Count
-		case "offer":
-			stats.ApplicationsByStage.Offer = sc.Count
-		case "hired":
-			stats.ApplicationsByStage.Hired = sc.Count
+	hiredTotal := 0
+	stats.ApplicationsByStage = make([]dtos.StageCountDTO, len(stageCounts))
+	for i, sc := range stageCounts {
+		stats.ApplicationsByStage[i] = dtos.StageCountDTO{Key: sc.Stage, Count: sc.Count}
+		for _, hired := range hiredStages {
+			if sc.Stage == hired {
+				hiredTotal += sc.Count
+			}
 		}
 	}
 
@@ -120,7 +116,7 @@ func (r *dashboardRepository) GetStats(companyID uint) (*dtos.DashboardStatsDTO,
 	// Hired this month
 	var hiredThisMonth int64
 	if err := database.DB.Table("applications").
-		Where("company_id = ? AND stage = ? AND hired_at >= ? AND deleted_at IS NULL", companyID, "hired", startOfMonth).
+		Where("company_id = ? AND stage IN ? AND hired_at >= ? AND deleted_at IS NULL", companyID, hiredStages, startOfMonth).
 		Count(&hiredThisMonth).Error; err != nil {
 		return nil, err
 	}
@@ -134,7 +130,7 @@ func (r *dashboardRepository) GetStats(companyID uint) (*dtos.DashboardStatsDTO,
 	var avgTimeToHire avgResult
 	if err := database.DB.Table("applications").
 		Select("AVG(EXTRACT(EPOCH FROM (hired_at - applied_at)) / 86400) as avg_days").
-		Where("company_id = ? AND stage = ? AND hired_at IS NOT NULL AND deleted_at IS NULL", companyID, "hired").
+		Where("company_id = ? AND stage IN ? AND hired_at IS NOT NULL AND deleted_at IS NULL", companyID, hiredStages).
 		Scan(&avgTimeToHire).Error; err != nil {
 		return nil, err
 	}
@@ -142,7 +138,7 @@ func (r *dashboardRepository) GetStats(companyID uint) (*dtos.DashboardStatsDTO,
 
 	// Conversion rate
 	if totalApplications > 0 {
-		stats.ConversionRate = float64(stats.ApplicationsByStage.Hired) / float64(totalApplications) * 100
+		stats.ConversionRate = float64(hiredTotal) / float64(totalApplications) * 100
 	}
 
 	// ========== TRENDS (Last 30 days) ==========
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/database"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultTagColor es el color de las etiquetas creadas al vuelo
+const DefaultTagColor = "#64748b"
+
+// TagRepository define el contrato del repositorio de etiquetas
+type TagRepository interface {
+	// FindOrCreateByNames devuelve las etiquetas de la empresa con esos
+	// nombres (sin distinguir mayúsculas), creando las que no existan.
+	FindOrCreateByNames(companyID uint, names []string) ([]models.Tag, error)
+	// AttachToCandidate asigna las etiquetas al candidato; las que ya tenía se ignoran.
+	AttachToCandidate(candidateID uint, tagIDs []uint, createdByID *uint) error
+	// AttachToCandidates asigna las etiquetas a varios candidatos; devuelve
+	// cuántas asignaciones nuevas creó.
+	AttachToCandidates(candidateIDs, tagIDs []uint, createdByID *uint) (int64, error)
+	// DetachFromCandidates quita las etiquetas a los candidatos; devuelve
+	// cuántas asignaciones eliminó.
+	DetachFromCandidates(candidateIDs, tagIDs []uint) (int64, error)
+
+	// List devuelve las etiquetas de la empresa con cuántos candidatos
+	// activos tiene cada una.
+	List(companyID uint) ([]TagWithCount, error)
+	GetByID(id uint) (*models.Tag, error)
+	GetByName(companyID uint, name string) (*models.Tag, error)
+	Create(tag *models.Tag) error
+	Update(tag *models.Tag) error
+	// Delete elimina la etiqueta y sus asignaciones (definitivo: el nombre
+	// queda libre para una nueva).
+	Delete(id uint) error
+	// CandidateTags devuelve las etiquetas de un candidato por nombre.
+	CandidateTags(candidateID uint) ([]models.Tag, error)
+}
+
+// TagWithCount es una etiqueta con su cantidad de candidatos.
+type TagWithCount struct {
+	models.Tag
+	CandidateCount int64 `json:"candidate_count"`
+}
+
+// tagRepository es la implementación con GORM
+type tagRepository struct{}
+
+// NewTagRepository crea una nueva instancia de TagRepository
+func NewTagRepository() TagRepository {
+	return &tagRepository{}
+}
+
+func (r *tagRepository) FindOrCreateByNames(companyID uint, names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, name := range names {
+			var tag models.Tag
+			err := tx.Where("company_id = ? AND LOWER(name) = ?", companyID, strings.ToLower(name)).First(&tag).Error
+			if err == gorm.ErrRecordNotFound {
+				tag = models.Tag{CompanyID: companyID, Name: name, Color: DefaultTagColor}
+				err = tx.Create(&tag).Error
+			}
+			if err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) AttachToCandidate(candidateID uint, tagIDs []uint, createdByID *uint) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	rows := make([]models.CandidateTag, len(tagIDs))
+	for i, tagID := range tagIDs {
+		rows[i] = models.CandidateTag{CandidateID: candidateID, TagID: tagID, CreatedByID: createdByID}
+	}
+	return database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (r *tagRepository) AttachToCandidates(candidateIDs, tagIDs []uint, createdByID *uint) (int64, error) {
+	if len(candidateIDs) == 0 || len(tagIDs) == 0 {
+		return 0, nil
+	}
+	rows := make([]models.CandidateTag, 0, len(candidateIDs)*len(tagIDs))
+	for _, candidateID := range candidateIDs {
+		for _, tagID := range tagIDs {
+			rows = append(rows, models.CandidateTag{CandidateID: candidateID, TagID: tagID, CreatedByID: createdByID})
+		}
+	}
+	result := database.DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&rows, 500)
+	return result.RowsAffected, result.Error
+}
+
+func (r *tagRepository) DetachFromCandidates(candidateIDs, tagIDs []uint) (int64, error) {
+	if len(candidateIDs) == 0 || len(tagIDs) == 0 {
+		return 0, nil
+	}
+	result := database.DB.Unscoped().
+		Where("candidate_id IN ? AND tag_id IN ?", candidateIDs, tagIDs).
+		Delete(&models.CandidateTag{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *tagRepository) List(companyID uint) ([]TagWithCount, error) {
+	var tags []TagWithCount
+	if err := database.DB.Model(&models.Tag{}).
+		Select("tags.*, (SELECT COUNT(*) FROM candidate_tags ct JOIN candidates c ON c.id = ct.candidate_id AND c.deleted_at IS NULL"+
+			" WHERE ct.tag_id = tags.id AND ct.deleted_at IS NULL) AS candidate_count").
+		Where("tags.company_id = ?", companyID).
+		Order("LOWER(tags.name) ASC").
+		Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) GetByID(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	if err := database.DB.First(&tag, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) GetByName(companyID uint, name string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := database.DB.Where("company_id = ? AND LOWER(name) = ?", companyID, strings.ToLower(name)).First(&tag).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) Create(tag *models.Tag) error {
+	return database.DB.Create(tag).Error
+}
+
+func (r *tagRepository) Update(tag *models.Tag) error {
+	return database.DB.Save(tag).Error
+}
+
+func (r *tagRepository) Delete(id uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("tag_id = ?", id).Delete(&models.CandidateTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.Tag{}, id).Error
+	})
+}
+
+func (r *tagRepository) CandidateTags(candidateID uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := database.DB.
+		Joins("JOIN candidate_tags ct ON ct.tag_id = tags.id AND ct.deleted_at IS NULL").
+		Where("ct.candidate_id = ?", candidateID).
+		Order("LOWER(tags.name) ASC").
+		Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
@@ -1,6 +1,12 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"dvra-api/internal/app/dtos"
@@ -17,20 +23,105 @@ type ApplicationService interface {
 	GetApplicationsByCandidateID(candidateID uint) ([]models.Application, error)
 	GetApplicationsByCompanyID(companyID uint) ([]models.Application, error)
 	GetApplicationsByStage(stage string, companyID uint) ([]models.Application, error)
-	GetApplicationsGroupedByStage(companyID uint) (map[string][]models.Application, error)
-	CreateApplication(dto dtos.CreateApplicationDTO) (*models.Application, error)
-	UpdateApplication(id uint, dto dtos.UpdateApplicationDTO) (*models.Application, error)
-	MoveToStage(id uint, stage string) (*models.Application, error)
+	GetApplicationsGroupedByStage(companyID uint, query dtos.ApplicationBoardQuery) ([]models.PipelineStage, map[string][]models.Application, map[string]int64, error)
+	ReorderApplication(id uint, dto dtos.ReorderApplicationDTO) (*models.Application, error)
+	CreateApplication(dto dtos.CreateApplicationDTO, actorID uint) (*models.Application, error)
+	UpdateApplication(id uint, dto dtos.UpdateApplicationDTO, actorID uint) (*models.Application, error)
+	MoveToStage(id uint, dto dtos.MoveApplicationDTO, actorID uint) (*models.Application, error)
+	// MoveToStageTx es MoveToStage leyendo y escribiendo con applications
+	// (ligado a la transacción del que llama) y sin avisar a las
+	// automatizaciones: devuelve el evento de etapa (nil si no hubo cambio)
+	// para que el llamador avise tras el commit.
+	MoveToStageTx(applications repositories.ApplicationRepository, id uint, dto dtos.MoveApplicationDTO, actorID uint) (*models.Application, *models.ApplicationStageEvent, error)
+	OverrideStage(id uint, dto dtos.OverrideStageDTO, actorID uint) (*models.Application, error)
+	SystemReject(id uint, reason, rejectionType, rejectionReason string) (*models.Application, error)
+	// HireTx contrata la postulación (oferta aceptada) con applications,
+	// como MoveToStageTx; el evento es nil si ya estaba contratada.
+	HireTx(applications repositories.ApplicationRepository, id uint, reason string, actorID uint) (*models.Application, *models.ApplicationStageEvent, error)
+	GetTimeline(id uint) ([]dtos.StageEventDTO, error)
+	GetStageStats(companyID, jobID uint) (*dtos.StageStatsDTO, error)
+	GetRejectionStats(companyID, jobID uint) (*dtos.RejectionStatsDTO, error)
+	GetScorecardSummary(id, viewerID uint, viewAll bool) (*dtos.ScorecardSummaryDTO, error)
+	BulkApply(companyID uint, dto dtos.BulkApplicationsDTO, actorID uint) (*dtos.BulkResultDTO, error)
 	RateApplication(id uint, rating int) (*models.Application, error)
 	DeleteApplication(id uint) error
 }
 
+// pipelineResolver es lo que applications necesita del módulo pipeline: las
+// etapas válidas de cada vacante y su tipo. Puerto definido por el consumidor;
+// el composition root inyecta el servicio del módulo.
+type pipelineResolver interface {
+	Resolve(companyID, jobID uint) (*models.Pipeline, error)
+	CompanyStages(companyID uint) ([]models.PipelineStage, error)
+	CheckTransition(pipeline *models.Pipeline, from, to string) error
+}
+
+// rejectionCatalog da los motivos de rechazo vigentes de una empresa
+// (globales + propios) por categoría de SystemValue.
+type rejectionCatalog interface {
+	GetByCategory(category string, companyID *uint) ([]models.SystemValue, error)
+}
+
+// scorecardSummaries es lo que applications necesita del módulo scorecard:
+// el resumen de evaluaciones que ve un usuario (oculta las de otros hasta que
+// envía la suya).
+type scorecardSummaries interface {
+	Summary(applicationID, viewerID uint, viewAll bool) (*dtos.ScorecardSummaryDTO, error)
+}
+
+// noteAppender registra un texto en el hilo de comentarios de un recurso
+// (módulo comment). Las notas ya no se guardan en Application.Notes: cada
+// una queda como comentario, así dos usuarios no se pisan (RN-APP-009).
+type noteAppender interface {
+	AppendNote(companyID uint, subjectType string, subjectID uint, authorID *uint, body, source string) error
+}
+
+// automationHook avisa al motor de automatizaciones (módulo automation) de
+// los eventos de una postulación. Solo encola: las reglas corren en segundo
+// plano y un fallo nunca afecta la operación que lo originó.
+type automationHook interface {
+	Fire(trigger string, application *models.Application, fromStage string)
+}
+
+// matchScorer da la compatibilidad (0–100) de cada candidato con una vacante
+// de la empresa (módulo match), para ordenar el tablero por puntaje.
+type matchScorer interface {
+	Scores(companyID, jobID uint, candidateIDs []uint) (map[uint]int, error)
+}
+
+// rejection es el tipo y motivo con que se cierra una postulación al moverla
+// a una etapa rejected.
+type rejection struct {
+	Type   string
+	Reason string
+}
+
 type applicationService struct {
 	applicationRepo repositories.ApplicationRepository
+	eventRepo       repositories.ApplicationStageEventRepository
+	tagRepo         repositories.TagRepository
+	reasons         rejectionCatalog
+	pipelines       pipelineResolver
+	scorecards      scorecardSummaries
+	notes           noteAppender
+	automation      automationHook
+	matches         matchScorer
+	trash           trashCascade
 }
 
-func NewApplicationService(applicationRepo repositories.ApplicationRepository) ApplicationService {
-	return &applicationService{applicationRepo: applicationRepo}
+func NewApplicationService(
+	applicationRepo repositories.ApplicationRepository,
+	eventRepo repositories.ApplicationStageEventRepository,
+	tagRepo repositories.TagRepository,
+	reasons rejectionCatalog,
+	pipelines pipelineResolver,
+	scorecards scorecardSummaries,
+	notes noteAppender,
+	automation automationHook,
+	matches matchScorer,
+	trash trashCascade,
+) ApplicationService {
+	return &applicationService{applicationRepo: applicationRepo, eventRepo: eventRepo, tagRepo: tagRepo, reasons: reasons, pipelines: pipelines, scorecards: scorecards, notes: notes, automation: automation, matches: matches, trash: trash}
 }
 
 func (s *applicationService) GetAllApplications() ([]models.Application, error) {
@@ -64,56 +155,218 @@ func (s *applicationService) GetApplicationsByStage(stage string, companyID uint
 	return s.applicationRepo.GetByStage(stage, companyID)
 }
 
-func (s *applicationService) CreateApplication(dto dtos.CreateApplicationDTO) (*models.Application, error) {
+func (s *applicationService) CreateApplication(dto dtos.CreateApplicationDTO, actorID uint) (*models.Application, error) {
 	now := time.Now()
 	application := &models.Application{
 		JobID:       dto.JobID,
 		CandidateID: dto.CandidateID,
 		CompanyID:   dto.CompanyID,
-		Stage:       dto.Stage,
 		Rating:      dto.Rating,
-		Notes:       dto.Notes,
 		AppliedAt:   now,
 	}
 
-	return s.applicationRepo.Create(application)
+	// Sin stage explícito entra en la primera etapa del pipeline de la vacante.
+	pipeline, err := s.pipelines.Resolve(dto.CompanyID, dto.JobID)
+	if err != nil {
+		return nil, err
+	}
+	stage := pipeline.StageOfType(models.StageTypeActive)
+	if dto.Stage != "" {
+		if stage = pipeline.Stage(dto.Stage); stage == nil {
+			return nil, apperr.BadRequest(fmt.Sprintf("stage '%s' is not part of this job's pipeline", dto.Stage))
+		}
+	}
+	setStage(application, stage)
+
+	event := newStageEvent(application, "", actorID, "")
+	if application, err = s.applicationRepo.CreateWithEvent(application, event); err != nil {
+		return nil, err
+	}
+	if err := s.appendNote(application, dto.Notes, actorID); err != nil {
+		return nil, err
+	}
+	s.automation.Fire(models.AutomationTriggerApplicationCreated, application, "")
+	return application, nil
 }
 
-func (s *applicationService) UpdateApplication(id uint, dto dtos.UpdateApplicationDTO) (*models.Application, error) {
-	application, err := s.applicationRepo.GetByID(id)
+// appendNote deja las notas enviadas con la postulación como comentario de
+// actorID en su hilo (RN-APP-009).
+func (s *applicationService) appendNote(application *models.Application, notes string, actorID uint) error {
+	if strings.TrimSpace(notes) == "" {
+		return nil
+	}
+	var authorID *uint
+	if actorID != 0 {
+		authorID = &actorID
+	}
+	return s.notes.AppendNote(application.CompanyID, models.CommentSubjectApplication, application.ID, authorID, notes, models.CommentSourceNotes)
+}
+
+// transition mueve la postulación a la etapa key respetando el grafo de
+// transiciones de su pipeline (RN-APP-002) y devuelve el evento a registrar,
+// o nil si la postulación ya estaba en esa etapa. Entrar a una etapa rejected
+// exige un motivo del catálogo (RN-APP-007).
+func (s *applicationService) transition(application *models.Application, key string, actorID uint, reason string, rej rejection) (*models.ApplicationStageEvent, error) {
+	pipeline, err := s.pipelines.Resolve(application.CompanyID, application.JobID)
 	if err != nil {
 		return nil, err
 	}
-	if application == nil {
-		return nil, apperr.NotFound("application not found")
+	if err := s.pipelines.CheckTransition(pipeline, application.Stage, key); err != nil {
+		return nil, err
+	}
+	if application.Stage == key {
+		return nil, nil
 	}
 
-	if dto.Stage != nil {
-		application.Stage = *dto.Stage
+	from := application.Stage
+	target := pipeline.Stage(key)
+	if target.Type == models.StageTypeRejected {
+		if err := s.applyRejection(application, rej); err != nil {
+			return nil, err
+		}
+	}
+	setStage(application, target)
+	return newStageEvent(application, from, actorID, reason), nil
+}
 
-		// Auto-actualizar timestamps según el stage
-		now := time.Now()
-		if *dto.Stage == "rejected" && application.RejectedAt == nil {
+// applyRejection valida el motivo contra el catálogo de la empresa para el
+// tipo de rechazo y lo guarda junto con la etapa desde la que se rechaza.
+func (s *applicationService) applyRejection(application *models.Application, rej rejection) error {
+	if rej.Type == "" {
+		rej.Type = models.RejectionTypeRejected
+	}
+	category := models.RejectionCategory(rej.Type)
+	if category == "" {
+		return apperr.BadRequest(fmt.Sprintf("unknown rejection_type '%s'", rej.Type))
+	}
+	rej.Reason = strings.TrimSpace(rej.Reason)
+	if rej.Reason == "" {
+		return apperr.BadRequest("rejection_reason is required when moving to a rejected stage")
+	}
+
+	companyID := application.CompanyID
+	values, err := s.reasons.GetByCategory(category, &companyID)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if v.Value == rej.Reason {
+			application.RejectionType = rej.Type
+			application.RejectionReason = rej.Reason
+			application.RejectedFromStage = application.Stage
+			return nil
+		}
+	}
+	return apperr.BadRequest(fmt.Sprintf("rejection_reason '%s' is not in the %s catalog", rej.Reason, rej.Type))
+}
+
+// save guarda la postulación junto con su evento de etapa, si lo hay, y
+// avisa del cambio de etapa a las automatizaciones. Si otra transición se
+// guardó desde que se leyó la postulación, 409.
+func (s *applicationService) save(application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error) {
+	application, err := write(s.applicationRepo, application, event)
+	if err != nil {
+		return nil, err
+	}
+	if event != nil {
+		s.automation.Fire(models.AutomationTriggerStageChanged, application, event.FromStage)
+	}
+	return application, nil
+}
+
+// write es save sin el aviso a las automatizaciones, con el repositorio
+// que corresponda (global o ligado a una transacción).
+func write(applications repositories.ApplicationRepository, application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error) {
+	if event == nil {
+		return applications.Update(application)
+	}
+	application, err := applications.UpdateWithEvent(application, event)
+	if errors.Is(err, repositories.ErrStageChanged) {
+		return nil, apperr.Conflict("the application changed stage since it was loaded; reload it")
+	}
+	return application, err
+}
+
+// newStageEvent arma el evento de historial para la etapa actual de la
+// postulación (from vacío = entrada al pipeline). actorID 0 = sin usuario
+// (career page, procesos automáticos).
+func newStageEvent(application *models.Application, from string, actorID uint, reason string) *models.ApplicationStageEvent {
+	event := &models.ApplicationStageEvent{
+		CompanyID:     application.CompanyID,
+		ApplicationID: application.ID,
+		FromStage:     from,
+		ToStage:       application.Stage,
+		Reason:        strings.TrimSpace(reason),
+		OccurredAt:    time.Now(),
+	}
+	if actorID != 0 {
+		event.ActorID = &actorID
+	}
+	return event
+}
+
+// setStage aplica la etapa y deja RejectedAt/HiredAt coherentes con su tipo
+// (RN-APP-003): se fijan al entrar en la etapa final y se limpian al salir de
+// ella (reapertura por override).
+func setStage(application *models.Application, stage *models.PipelineStage) {
+	application.Stage = stage.Key
+
+	now := time.Now()
+	switch stage.Type {
+	case models.StageTypeRejected:
+		if application.RejectedAt == nil {
 			application.RejectedAt = &now
 		}
-		if *dto.Stage == "hired" && application.HiredAt == nil {
+		application.HiredAt = nil
+	case models.StageTypeHired:
+		if application.HiredAt == nil {
 			application.HiredAt = &now
 		}
+		application.RejectedAt = nil
+	default:
+		application.RejectedAt = nil
+		application.HiredAt = nil
+	}
+	if stage.Type != models.StageTypeRejected {
+		application.RejectionType = ""
+		application.RejectionReason = ""
+		application.RejectedFromStage = ""
+	}
+}
+
+func (s *applicationService) UpdateApplication(id uint, dto dtos.UpdateApplicationDTO, actorID uint) (*models.Application, error) {
+	application, err := s.applicationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperr.NotFound("application not found")
+	}
+
+	var event *models.ApplicationStageEvent
+	if dto.Stage != nil {
+		rej := rejection{Type: dto.RejectionType, Reason: dto.RejectionReason}
+		if event, err = s.transition(application, *dto.Stage, actorID, "", rej); err != nil {
+			return nil, err
+		}
 	}
+	rated := dto.Rating != nil && (application.Rating == nil || *application.Rating != *dto.Rating)
 	if dto.Rating != nil {
 		application.Rating = dto.Rating
 	}
-	if dto.Notes != nil {
-		application.Notes = *dto.Notes
+
+	if application, err = s.save(application, event); err != nil {
+		return nil, err
 	}
-	if dto.RejectedAt != nil {
-		application.RejectedAt = dto.RejectedAt
+	if rated {
+		s.automation.Fire(models.AutomationTriggerRatingSet, application, "")
 	}
-	if dto.HiredAt != nil {
-		application.HiredAt = dto.HiredAt
+	if dto.Notes != nil {
+		if err := s.appendNote(application, *dto.Notes, actorID); err != nil {
+			return nil, err
+		}
 	}
-
-	return s.applicationRepo.Update(application)
+	return application, nil
 }
 
 func (s *applicationService) DeleteApplication(id uint) error {
@@ -124,31 +377,175 @@ func (s *applicationService) DeleteApplication(id uint) error {
 	if application == nil {
 		return apperr.NotFound("application not found")
 	}
-	return s.applicationRepo.Delete(id)
+	return s.trash.Delete("application", id)
 }
 
-func (s *applicationService) GetApplicationsGroupedByStage(companyID uint) (map[string][]models.Application, error) {
-	applications, err := s.applicationRepo.GetByCompanyID(companyID)
+// Paginado por columna del tablero
+const (
+	defaultBoardLimit = 50
+	maxBoardLimit     = 200
+)
+
+// GetApplicationsGroupedByStage agrupa las postulaciones de la empresa por
+// etapa, una página por columna en el orden pedido, con el total de cada
+// etapa. Devuelve también las etapas de sus pipelines en orden, para que el
+// tablero dibuje todas las columnas (incluidas las vacías).
+func (s *applicationService) GetApplicationsGroupedByStage(companyID uint, query dtos.ApplicationBoardQuery) ([]models.PipelineStage, map[string][]models.Application, map[string]int64, error) {
+	if query.Limit <= 0 || query.Limit > maxBoardLimit {
+		query.Limit = defaultBoardLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	stages, err := s.pipelines.CompanyStages(companyID)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	var applications []models.Application
+	var counts map[string]int64
+	if query.Sort == dtos.BoardSortScore {
+		applications, counts, err = s.boardByScore(companyID, query)
+	} else {
+		applications, counts, err = s.applicationRepo.GetBoard(companyID, query)
+	}
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	// Initialize all stages
-	stages := []string{"applied", "screening", "technical", "offer", "hired"}
 	result := make(map[string][]models.Application)
 	for _, stage := range stages {
-		result[stage] = []models.Application{}
+		if query.Stage != "" && stage.Key != query.Stage {
+			continue
+		}
+		result[stage.Key] = []models.Application{}
+		if _, ok := counts[stage.Key]; !ok {
+			counts[stage.Key] = 0
+		}
 	}
 
-	// Group applications by stage
+	// Group applications by stage (the repository already returns them in order)
 	for _, app := range applications {
 		result[app.Stage] = append(result[app.Stage], app)
 	}
 
-	return result, nil
+	return stages, result, counts, nil
+}
+
+// boardByScore pagina las columnas de una vacante por compatibilidad de sus
+// candidatos (RN-JOB-006). El puntaje se calcula al vuelo, así que se
+// puntúan todas las tarjetas y se pagina en memoria; a igual puntaje, por
+// antigüedad de la postulación.
+func (s *applicationService) boardByScore(companyID uint, query dtos.ApplicationBoardQuery) ([]models.Application, map[string]int64, error) {
+	if query.JobID == 0 {
+		return nil, nil, apperr.BadRequest("sort=score requires job_id")
+	}
+	cards, err := s.applicationRepo.GetBoardCards(companyID, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidateIDs := make([]uint, 0, len(cards))
+	for _, card := range cards {
+		candidateIDs = append(candidateIDs, card.CandidateID)
+	}
+	scores, err := s.matches.Scores(companyID, query.JobID, candidateIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := scores[cards[i].CandidateID], scores[cards[j].CandidateID]
+		if query.Order == "asc" {
+			return a < b
+		}
+		return a > b
+	})
+
+	counts := map[string]int64{}
+	order := map[uint]int{}
+	var ids []uint
+	for _, card := range cards {
+		counts[card.Stage]++
+		if n := counts[card.Stage]; n > int64(query.Offset) && n <= int64(query.Offset+query.Limit) {
+			order[card.ID] = len(ids)
+			ids = append(ids, card.ID)
+		}
+	}
+	applications, err := s.applicationRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range applications {
+		score := scores[applications[i].CandidateID]
+		applications[i].MatchScore = &score
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return order[applications[i].ID] < order[applications[j].ID]
+	})
+	return applications, counts, nil
+}
+
+// ReorderApplication ubica la postulación dentro de su columna entre los
+// vecinos que ve el cliente (en el tablero filtrado por vacante, si lo
+// está). Si la columna cambió en el medio, 409: el tablero debe recargarse.
+func (s *applicationService) ReorderApplication(id uint, dto dtos.ReorderApplicationDTO) (*models.Application, error) {
+	if dto.AfterID == id || dto.BeforeID == id || (dto.AfterID != 0 && dto.AfterID == dto.BeforeID) {
+		return nil, apperr.BadRequest("after_id and before_id must be other applications")
+	}
+	application, err := s.applicationRepo.Reposition(id, dto.AfterID, dto.BeforeID, dto.JobID)
+	if errors.Is(err, repositories.ErrBoardChanged) {
+		return nil, apperr.Conflict("the stage column changed since it was loaded; reload the board")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperr.NotFound("application not found")
+	}
+	return application, nil
+}
+
+func (s *applicationService) MoveToStage(id uint, dto dtos.MoveApplicationDTO, actorID uint) (*models.Application, error) {
+	application, event, err := s.MoveToStageTx(s.applicationRepo, id, dto, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if event != nil {
+		s.automation.Fire(models.AutomationTriggerStageChanged, application, event.FromStage)
+	}
+	return application, nil
+}
+
+func (s *applicationService) MoveToStageTx(applications repositories.ApplicationRepository, id uint, dto dtos.MoveApplicationDTO, actorID uint) (*models.Application, *models.ApplicationStageEvent, error) {
+	application, err := applications.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if application == nil {
+		return nil, nil, apperr.NotFound("application not found")
+	}
+
+	rej := rejection{Type: dto.RejectionType, Reason: dto.RejectionReason}
+	event, err := s.transition(application, dto.Stage, actorID, dto.Reason, rej)
+	if err != nil {
+		return nil, nil, err
+	}
+	if application, err = write(applications, application, event); err != nil {
+		return nil, nil, err
+	}
+	return application, event, nil
 }
 
-func (s *applicationService) MoveToStage(id uint, stage string) (*models.Application, error) {
+// OverrideStage mueve la postulación a cualquier etapa de su pipeline, aunque
+// el grafo no lo permita (p. ej. reabrir una postulación hired o rejected).
+// Exige motivo y queda registrado como evento de etapa con override. Forzar
+// una etapa rejected exige, como MoveToStage, un motivo del catálogo
+// (RN-APP-007).
+func (s *applicationService) OverrideStage(id uint, dto dtos.OverrideStageDTO, actorID uint) (*models.Application, error) {
+	stage := dto.Stage
+	reason := strings.TrimSpace(dto.Reason)
+	if reason == "" {
+		return nil, apperr.BadRequest("a reason is required to override the stage")
+	}
+
 	application, err := s.applicationRepo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -156,19 +553,386 @@ func (s *applicationService) MoveToStage(id uint, stage string) (*models.Applica
 	if application == nil {
 		return nil, apperr.NotFound("application not found")
 	}
+	if application.Stage == stage {
+		return nil, apperr.BadRequest(fmt.Sprintf("application is already in stage '%s'", stage))
+	}
 
-	application.Stage = stage
+	pipeline, err := s.pipelines.Resolve(application.CompanyID, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+	target := pipeline.Stage(stage)
+	if target == nil {
+		return nil, apperr.BadRequest(fmt.Sprintf("stage '%s' is not part of this job's pipeline", stage))
+	}
 
-	// Auto-update timestamps based on stage
-	now := time.Now()
-	if stage == "rejected" && application.RejectedAt == nil {
-		application.RejectedAt = &now
+	from := application.Stage
+	if target.Type == models.StageTypeRejected {
+		if err := s.applyRejection(application, rejection{Type: dto.RejectionType, Reason: dto.RejectionReason}); err != nil {
+			return nil, err
+		}
+	}
+	setStage(application, target)
+	event := newStageEvent(application, from, actorID, reason)
+	event.Override = true
+	return s.save(application, event)
+}
+
+// SystemReject cierra la postulación en la etapa rejected de su pipeline con
+// un motivo del catálogo, sin actor (barrido de estancadas). Como knockOut,
+// es un rechazo del sistema: si el grafo no permite rechazar desde la etapa
+// actual (applied → rejected en el pipeline por defecto), el evento queda
+// marcado como override. Solo rechaza desde etapas activas.
+func (s *applicationService) SystemReject(id uint, reason, rejectionType, rejectionReason string) (*models.Application, error) {
+	application, err := s.applicationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperr.NotFound("application not found")
+	}
+
+	pipeline, err := s.pipelines.Resolve(application.CompanyID, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+	rejected := pipeline.StageOfType(models.StageTypeRejected)
+	if rejected == nil {
+		return nil, apperr.Unprocessable("the job's pipeline has no rejected stage")
 	}
-	if stage == "hired" && application.HiredAt == nil {
-		application.HiredAt = &now
+	if current := pipeline.Stage(application.Stage); current == nil || current.Type != models.StageTypeActive {
+		return nil, apperr.Unprocessable(fmt.Sprintf("stage '%s' is final", application.Stage))
+	}
+
+	from := application.Stage
+	override := s.pipelines.CheckTransition(pipeline, from, rejected.Key) != nil
+	if err := s.applyRejection(application, rejection{Type: rejectionType, Reason: rejectionReason}); err != nil {
+		return nil, err
 	}
+	setStage(application, rejected)
+	event := newStageEvent(application, from, 0, reason)
+	event.Override = override
+	return s.save(application, event)
+}
+
+// HireTx mueve la postulación a la etapa hired de su pipeline al aceptarse
+// una oferta. Como SystemReject, la decisión ya la tomó el candidato: si el
+// grafo no permite contratar desde la etapa actual (p. ej. interview →
+// hired), el evento queda marcado como override. Solo contrata desde etapas
+// activas; si ya estaba contratada no hay nada que mover.
+func (s *applicationService) HireTx(applications repositories.ApplicationRepository, id uint, reason string, actorID uint) (*models.Application, *models.ApplicationStageEvent, error) {
+	application, err := applications.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if application == nil {
+		return nil, nil, apperr.NotFound("application not found")
+	}
+
+	pipeline, err := s.pipelines.Resolve(application.CompanyID, application.JobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	hired := pipeline.StageOfType(models.StageTypeHired)
+	if hired == nil {
+		return nil, nil, apperr.Unprocessable("the job's pipeline has no hired stage")
+	}
+	if application.Stage == hired.Key {
+		return application, nil, nil
+	}
+	if current := pipeline.Stage(application.Stage); current == nil || current.Type != models.StageTypeActive {
+		return nil, nil, apperr.Unprocessable(fmt.Sprintf("stage '%s' is final", application.Stage))
+	}
+
+	from := application.Stage
+	override := s.pipelines.CheckTransition(pipeline, from, hired.Key) != nil
+	setStage(application, hired)
+	event := newStageEvent(application, from, actorID, reason)
+	event.Override = override
+	if application, err = write(applications, application, event); err != nil {
+		return nil, nil, err
+	}
+	return application, event, nil
+}
 
-	return s.applicationRepo.Update(application)
+// GetTimeline devuelve el historial de etapas de la postulación.
+func (s *applicationService) GetTimeline(id uint) ([]dtos.StageEventDTO, error) {
+	events, err := s.eventRepo.GetTimeline(id)
+	if err != nil {
+		return nil, err
+	}
+	timeInStage(events, time.Now())
+	return events, nil
+}
+
+// timeInStage completa las horas en cada etapa de una línea de tiempo
+// ordenada: cada evento dura hasta el siguiente y el último, la etapa
+// actual, hasta now. Volver a una etapa abre un paso nuevo.
+func timeInStage(events []dtos.StageEventDTO, now time.Time) {
+	for i := range events {
+		until := now
+		if i+1 < len(events) {
+			until = events[i+1].OccurredAt
+		}
+		events[i].HoursInStage = until.Sub(events[i].OccurredAt).Hours()
+		events[i].Current = i+1 == len(events)
+	}
+}
+
+// GetStageStats calcula el tiempo por etapa de la empresa o de una vacante
+// (jobID 0) en el orden de su pipeline, con la etapa cuello de botella.
+func (s *applicationService) GetStageStats(companyID, jobID uint) (*dtos.StageStatsDTO, error) {
+	stages, err := s.reportStages(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.GetStageEvents(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	byKey := stageTimes(events, time.Now())
+
+	result := &dtos.StageStatsDTO{CompanyID: companyID, JobID: jobID, Stages: make([]dtos.StageTimeDTO, 0, len(stages))}
+	var slowest float64
+	for _, st := range stages {
+		row := byKey[st.Key]
+		row.Key, row.Name, row.Type = st.Key, st.Name, st.Type
+		if st.Type != models.StageTypeActive {
+			// En una etapa final no se "espera": no hay edad actual que medir.
+			row.AvgCurrentAgeHours = 0
+		} else if row.Exited > 0 && row.AvgHours > slowest {
+			slowest = row.AvgHours
+			result.BottleneckStage = st.Key
+		}
+		result.Stages = append(result.Stages, row)
+	}
+	return result, nil
+}
+
+// stageTimes agrega el tiempo por etapa de eventos ordenados por postulación
+// y fecha. Cada evento abre un paso en ToStage que se cierra con el siguiente
+// evento de la misma postulación; el último sigue abierto (InStage). Los
+// eventos reconstruidos (backfilled) cuentan como presencia en la etapa pero
+// no como duración.
+func stageTimes(events []models.ApplicationStageEvent, now time.Time) map[string]dtos.StageTimeDTO {
+	closed := map[string][]float64{}
+	open := map[string][]float64{}
+	inStage := map[string]int{}
+	for i, e := range events {
+		last := i+1 == len(events) || events[i+1].ApplicationID != e.ApplicationID
+		if last {
+			inStage[e.ToStage]++
+		}
+		if e.Backfilled {
+			continue
+		}
+		if last {
+			open[e.ToStage] = append(open[e.ToStage], now.Sub(e.OccurredAt).Hours())
+		} else {
+			closed[e.ToStage] = append(closed[e.ToStage], events[i+1].OccurredAt.Sub(e.OccurredAt).Hours())
+		}
+	}
+
+	stats := map[string]dtos.StageTimeDTO{}
+	for _, e := range events {
+		if _, ok := stats[e.ToStage]; ok {
+			continue
+		}
+		hours := closed[e.ToStage]
+		stats[e.ToStage] = dtos.StageTimeDTO{
+			Key:                e.ToStage,
+			Exited:             len(hours),
+			AvgHours:           mean(hours),
+			MedianHours:        median(hours),
+			InStage:            inStage[e.ToStage],
+			AvgCurrentAgeHours: mean(open[e.ToStage]),
+		}
+	}
+	return stats
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// median interpola entre los dos valores centrales si son pares (como
+// PERCENTILE_CONT(0.5)).
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// GetScorecardSummary devuelve el resumen de evaluaciones de la postulación
+// visible para viewerID (viewAll = ve las de todos los entrevistadores).
+func (s *applicationService) GetScorecardSummary(id, viewerID uint, viewAll bool) (*dtos.ScorecardSummaryDTO, error) {
+	return s.scorecards.Summary(id, viewerID, viewAll)
+}
+
+// reportStages devuelve las etapas en las que se reporta: las del pipeline de
+// la vacante o, con jobID 0, todas las de la empresa.
+func (s *applicationService) reportStages(companyID, jobID uint) ([]models.PipelineStage, error) {
+	if jobID == 0 {
+		return s.pipelines.CompanyStages(companyID)
+	}
+	pipeline, err := s.pipelines.Resolve(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.Stages, nil
+}
+
+// GetRejectionStats explica las pérdidas del pipeline: cuántas postulaciones
+// se cierran, con qué motivo y desde qué etapa, por vacante y por fuente.
+func (s *applicationService) GetRejectionStats(companyID, jobID uint) (*dtos.RejectionStatsDTO, error) {
+	stages, err := s.reportStages(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.applicationRepo.GetRejectionCounts(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	reach, err := s.eventRepo.GetStageReach(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.reasonLabels(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := newRejectionGroup()
+	byStage := map[string]*rejectionGroup{}
+	byJob := map[uint]*rejectionGroup{}
+	jobTitles := map[uint]string{}
+	bySource := map[string]*rejectionGroup{}
+	var jobOrder []uint
+	var sourceOrder []string
+	for _, row := range rows {
+		total.add(row)
+		if byStage[row.Stage] == nil {
+			byStage[row.Stage] = newRejectionGroup()
+		}
+		byStage[row.Stage].add(row)
+		if byJob[row.JobID] == nil {
+			byJob[row.JobID] = newRejectionGroup()
+			jobTitles[row.JobID] = row.JobTitle
+			jobOrder = append(jobOrder, row.JobID)
+		}
+		byJob[row.JobID].add(row)
+		if bySource[row.Source] == nil {
+			bySource[row.Source] = newRejectionGroup()
+			sourceOrder = append(sourceOrder, row.Source)
+		}
+		bySource[row.Source].add(row)
+	}
+
+	result := &dtos.RejectionStatsDTO{
+		CompanyID:             companyID,
+		JobID:                 jobID,
+		RejectionBreakdownDTO: total.breakdown(labels),
+		ByStage:               []dtos.RejectionStageDTO{},
+		ByJob:                 []dtos.RejectionJobDTO{},
+		BySource:              []dtos.RejectionSourceDTO{},
+	}
+	for _, st := range stages {
+		if st.Type != models.StageTypeActive {
+			continue // desde una etapa final no se rechaza
+		}
+		group := byStage[st.Key]
+		if group == nil {
+			group = newRejectionGroup()
+		}
+		row := dtos.RejectionStageDTO{Key: st.Key, Name: st.Name, Reached: reach[st.Key], RejectionBreakdownDTO: group.breakdown(labels)}
+		if row.Reached > 0 {
+			row.LossRate = math.Round(float64(row.Total)*1000/float64(row.Reached)) / 10
+		}
+		result.ByStage = append(result.ByStage, row)
+	}
+	for _, id := range jobOrder {
+		result.ByJob = append(result.ByJob, dtos.RejectionJobDTO{JobID: id, Title: jobTitles[id], RejectionBreakdownDTO: byJob[id].breakdown(labels)})
+	}
+	for _, source := range sourceOrder {
+		result.BySource = append(result.BySource, dtos.RejectionSourceDTO{Source: source, RejectionBreakdownDTO: bySource[source].breakdown(labels)})
+	}
+	sort.SliceStable(result.ByJob, func(i, j int) bool { return result.ByJob[i].Total > result.ByJob[j].Total })
+	sort.SliceStable(result.BySource, func(i, j int) bool { return result.BySource[i].Total > result.BySource[j].Total })
+	return result, nil
+}
+
+// reasonLabels indexa las etiquetas del catálogo por tipo y value.
+func (s *applicationService) reasonLabels(companyID uint) (map[rejection]string, error) {
+	labels := map[rejection]string{}
+	for _, t := range []string{models.RejectionTypeRejected, models.RejectionTypeWithdrew} {
+		values, err := s.reasons.GetByCategory(models.RejectionCategory(t), &companyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			labels[rejection{Type: t, Reason: v.Value}] = v.Label
+		}
+	}
+	return labels, nil
+}
+
+// rejectionGroup acumula los rechazos de un grupo por tipo y motivo.
+type rejectionGroup struct {
+	total, withdrew int
+	reasons         map[rejection]int
+}
+
+func newRejectionGroup() *rejectionGroup {
+	return &rejectionGroup{reasons: map[rejection]int{}}
+}
+
+func (g *rejectionGroup) add(row dtos.RejectionCountRow) {
+	g.total += row.Count
+	if row.Type == models.RejectionTypeWithdrew {
+		g.withdrew += row.Count
+	}
+	g.reasons[rejection{Type: row.Type, Reason: row.Reason}] += row.Count
+}
+
+func (g *rejectionGroup) breakdown(labels map[rejection]string) dtos.RejectionBreakdownDTO {
+	result := dtos.RejectionBreakdownDTO{
+		Total:        g.total,
+		RejectedByUs: g.total - g.withdrew,
+		Withdrew:     g.withdrew,
+		Reasons:      make([]dtos.RejectionReasonCountDTO, 0, len(g.reasons)),
+	}
+	for key, count := range g.reasons {
+		label := labels[key]
+		switch {
+		case key.Reason == "":
+			label = "Sin motivo registrado"
+		case label == "":
+			label = key.Reason // motivo desactivado o eliminado del catálogo
+		}
+		result.Reasons = append(result.Reasons, dtos.RejectionReasonCountDTO{Type: key.Type, Reason: key.Reason, Label: label, Count: count})
+	}
+	sort.Slice(result.Reasons, func(i, j int) bool {
+		if result.Reasons[i].Count != result.Reasons[j].Count {
+			return result.Reasons[i].Count > result.Reasons[j].Count
+		}
+		return result.Reasons[i].Label < result.Reasons[j].Label
+	})
+	return result
 }
 
 func (s *applicationService) RateApplication(id uint, rating int) (*models.Application, error) {
@@ -181,5 +945,152 @@ func (s *applicationService) RateApplication(id uint, rating int) (*models.Appli
 	}
 
 	application.Rating = &rating
-	return s.applicationRepo.Update(application)
+	return s.rate(application)
+}
+
+// rate guarda la calificación y avisa a las automatizaciones.
+func (s *applicationService) rate(application *models.Application) (*models.Application, error) {
+	application, err := s.applicationRepo.Update(application)
+	if err != nil {
+		return nil, err
+	}
+	s.automation.Fire(models.AutomationTriggerRatingSet, application, "")
+	return application, nil
+}
+
+// BulkApply ejecuta la misma acción sobre varias postulaciones. Cada una se
+// valida (tenant, reglas de transición) y se guarda por separado: el
+// resultado indica por ID si se aplicó y, si no, el código y el motivo.
+// companyID 0 = SuperAdmin (sin restricción de empresa).
+func (s *applicationService) BulkApply(companyID uint, dto dtos.BulkApplicationsDTO, actorID uint) (*dtos.BulkResultDTO, error) {
+	if err := validateBulk(dto); err != nil {
+		return nil, err
+	}
+
+	// Las etiquetas son por empresa: se resuelven una vez por empresa.
+	tagIDs := map[uint][]uint{}
+
+	result := &dtos.BulkResultDTO{Action: dto.Action, Items: []dtos.BulkItemResultDTO{}}
+	seen := make(map[uint]bool, len(dto.IDs))
+	for _, id := range dto.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		item := dtos.BulkItemResultDTO{ID: id}
+		application, err := s.bulkApplyOne(companyID, id, dto, actorID, tagIDs)
+		if err != nil {
+			item.Status = apperr.StatusCode(err)
+			item.Error = err.Error()
+			result.Failed++
+		} else {
+			item.OK = true
+			item.Status = http.StatusOK
+			if application != nil {
+				item.Stage = application.Stage
+			}
+			result.Succeeded++
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+func validateBulk(dto dtos.BulkApplicationsDTO) error {
+	switch dto.Action {
+	case dtos.BulkActionMove:
+		if dto.Stage == "" {
+			return apperr.BadRequest("stage is required for action 'move'")
+		}
+	case dtos.BulkActionReject:
+		if strings.TrimSpace(dto.RejectionReason) == "" {
+			return apperr.BadRequest("rejection_reason is required for action 'reject'")
+		}
+	case dtos.BulkActionRate:
+		if dto.Rating == nil {
+			return apperr.BadRequest("rating is required for action 'rate'")
+		}
+	case dtos.BulkActionTag:
+		if len(normalizeTagNames(dto.Tags)) == 0 {
+			return apperr.BadRequest("tags are required for action 'tag'")
+		}
+	case dtos.BulkActionDelete:
+	default:
+		return apperr.BadRequest(fmt.Sprintf("unknown action '%s'", dto.Action))
+	}
+	return nil
+}
+
+func (s *applicationService) bulkApplyOne(companyID, id uint, dto dtos.BulkApplicationsDTO, actorID uint, tagIDs map[uint][]uint) (*models.Application, error) {
+	application, err := s.applicationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperr.NotFound("application not found")
+	}
+	if companyID != 0 && application.CompanyID != companyID {
+		return nil, apperr.Forbidden("access denied")
+	}
+
+	switch dto.Action {
+	case dtos.BulkActionMove, dtos.BulkActionReject:
+		stage := dto.Stage
+		if dto.Action == dtos.BulkActionReject {
+			pipeline, err := s.pipelines.Resolve(application.CompanyID, application.JobID)
+			if err != nil {
+				return nil, err
+			}
+			stage = pipeline.StageOfType(models.StageTypeRejected).Key
+		}
+		rej := rejection{Type: dto.RejectionType, Reason: dto.RejectionReason}
+		event, err := s.transition(application, stage, actorID, dto.Reason, rej)
+		if err != nil {
+			return nil, err
+		}
+		return s.save(application, event)
+
+	case dtos.BulkActionRate:
+		application.Rating = dto.Rating
+		return s.rate(application)
+
+	case dtos.BulkActionTag:
+		ids, ok := tagIDs[application.CompanyID]
+		if !ok {
+			tags, err := s.tagRepo.FindOrCreateByNames(application.CompanyID, normalizeTagNames(dto.Tags))
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range tags {
+				ids = append(ids, t.ID)
+			}
+			tagIDs[application.CompanyID] = ids
+		}
+		var createdBy *uint
+		if actorID != 0 {
+			createdBy = &actorID
+		}
+		return application, s.tagRepo.AttachToCandidate(application.CandidateID, ids, createdBy)
+
+	default: // delete
+		return nil, s.trash.Delete("application", application.ID)
+	}
+}
+
+// normalizeTagNames recorta espacios y quita vacíos y repetidos (sin
+// distinguir mayúsculas), conservando el primer formato recibido.
+func normalizeTagNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
 }
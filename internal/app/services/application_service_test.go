@@ -0,0 +1,392 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/app/repositories"
+	"dvra-api/internal/shared/apperr"
+)
+
+// fakeApplications guarda las postulaciones en memoria; solo implementa lo
+// que usa BulkApply.
+type fakeApplications struct {
+	repositories.ApplicationRepository
+	rows   map[uint]*models.Application
+	events []*models.ApplicationStageEvent
+	stale  map[uint]models.Application // si está, GetByID devuelve esta lectura vieja
+}
+
+func (f *fakeApplications) GetByID(id uint) (*models.Application, error) {
+	if row, ok := f.stale[id]; ok {
+		return &row, nil
+	}
+	a, ok := f.rows[id]
+	if !ok {
+		return nil, nil
+	}
+	row := *a
+	return &row, nil
+}
+
+func (f *fakeApplications) Update(application *models.Application) (*models.Application, error) {
+	f.rows[application.ID] = application
+	return application, nil
+}
+
+// UpdateWithEvent simula el UPDATE condicional: solo guarda si la fila sigue
+// en la etapa de origen del evento.
+func (f *fakeApplications) UpdateWithEvent(application *models.Application, event *models.ApplicationStageEvent) (*models.Application, error) {
+	if f.rows[application.ID].Stage != event.FromStage {
+		return nil, repositories.ErrStageChanged
+	}
+	f.events = append(f.events, event)
+	return f.Update(application)
+}
+
+// Reposition sigue el contrato del repositorio: nil, nil si no existe.
+func (f *fakeApplications) Reposition(id, _, _, _ uint) (*models.Application, error) {
+	return f.GetByID(id)
+}
+
+// fakePipelines resuelve siempre el mismo pipeline y valida con su grafo.
+type fakePipelines struct {
+	pipeline *models.Pipeline
+}
+
+func (f fakePipelines) Resolve(uint, uint) (*models.Pipeline, error) { return f.pipeline, nil }
+
+func (f fakePipelines) CompanyStages(uint) ([]models.PipelineStage, error) {
+	return f.pipeline.Stages, nil
+}
+
+func (f fakePipelines) CheckTransition(pipeline *models.Pipeline, from, to string) error {
+	if pipeline.Stage(to) == nil {
+		return apperr.BadRequest("unknown stage " + to)
+	}
+	if from == to {
+		return nil
+	}
+	for _, next := range pipeline.NextStages(from) {
+		if next == to {
+			return nil
+		}
+	}
+	return apperr.Unprocessable("transition not allowed")
+}
+
+type fakeAutomation struct{ fired []string }
+
+func (f *fakeAutomation) Fire(trigger string, _ *models.Application, _ string) {
+	f.fired = append(f.fired, trigger)
+}
+
+type fakeReasons []string
+
+func (f fakeReasons) GetByCategory(string, *uint) ([]models.SystemValue, error) {
+	values := make([]models.SystemValue, len(f))
+	for i, v := range f {
+		values[i] = models.SystemValue{Value: v}
+	}
+	return values, nil
+}
+
+func bulkRow(id, companyID uint, stage string) models.Application {
+	application := models.Application{CompanyID: companyID, Stage: stage}
+	application.ID = id
+	return application
+}
+
+func newBulkService(rows ...models.Application) (*applicationService, *fakeApplications, *fakeAutomation) {
+	apps := &fakeApplications{rows: map[uint]*models.Application{}}
+	for i := range rows {
+		apps.rows[rows[i].ID] = &rows[i]
+	}
+	automation := &fakeAutomation{}
+	svc := &applicationService{
+		applicationRepo: apps,
+		reasons:         fakeReasons{"skills_gap"},
+		pipelines:       fakePipelines{knockoutPipeline()},
+		automation:      automation,
+	}
+	return svc, apps, automation
+}
+
+func TestBulkApplyMovePartialFailure(t *testing.T) {
+	svc, apps, automation := newBulkService(
+		bulkRow(1, 1, "applied"),
+		bulkRow(2, 2, "applied"),   // otra empresa
+		bulkRow(3, 1, "screening"), // screening → screening: sin cambio
+		bulkRow(4, 1, "hired"),     // etapa final
+	)
+
+	dto := dtos.BulkApplicationsDTO{Action: dtos.BulkActionMove, Stage: "screening", IDs: []uint{1, 2, 3, 4, 9, 1}}
+	result, err := svc.BulkApply(1, dto, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[uint]int{
+		1: http.StatusOK,
+		2: http.StatusForbidden,
+		3: http.StatusOK,
+		4: http.StatusUnprocessableEntity,
+		9: http.StatusNotFound,
+	}
+	if len(result.Items) != len(want) {
+		t.Fatalf("items = %+v, want one per distinct id", result.Items)
+	}
+	for _, item := range result.Items {
+		if item.Status != want[item.ID] || item.OK != (want[item.ID] == http.StatusOK) {
+			t.Errorf("id %d: status %d ok %v (%s), want %d", item.ID, item.Status, item.OK, item.Error, want[item.ID])
+		}
+	}
+	if result.Succeeded != 2 || result.Failed != 3 {
+		t.Errorf("succeeded %d failed %d, want 2 and 3", result.Succeeded, result.Failed)
+	}
+
+	// Solo la 1 cambia de etapa; la de otra empresa queda intacta.
+	if len(apps.events) != 1 || apps.events[0].ApplicationID != 1 || *apps.events[0].ActorID != 7 {
+		t.Errorf("events = %+v", apps.events)
+	}
+	if apps.rows[2].Stage != "applied" || apps.rows[4].Stage != "hired" {
+		t.Errorf("failed applications changed: %+v %+v", apps.rows[2], apps.rows[4])
+	}
+	if len(automation.fired) != 1 || automation.fired[0] != models.AutomationTriggerStageChanged {
+		t.Errorf("fired = %v", automation.fired)
+	}
+}
+
+func TestBulkApplyReject(t *testing.T) {
+	svc, apps, _ := newBulkService(
+		bulkRow(1, 1, "screening"),
+		bulkRow(2, 1, "applied"), // applied → rejected no está en el grafo
+	)
+
+	dto := dtos.BulkApplicationsDTO{Action: dtos.BulkActionReject, RejectionReason: "skills_gap", IDs: []uint{1, 2}}
+	result, err := svc.BulkApply(1, dto, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Items[0].OK || result.Items[0].Stage != "rejected" {
+		t.Errorf("id 1: %+v", result.Items[0])
+	}
+	if result.Items[1].Status != http.StatusUnprocessableEntity {
+		t.Errorf("id 2: %+v", result.Items[1])
+	}
+	rejected := apps.rows[1]
+	if rejected.RejectedAt == nil || rejected.RejectionReason != "skills_gap" || rejected.RejectedFromStage != "screening" {
+		t.Errorf("rejected application = %+v", rejected)
+	}
+}
+
+func TestBulkApplyValidation(t *testing.T) {
+	svc, _, _ := newBulkService()
+	cases := []dtos.BulkApplicationsDTO{
+		{Action: "archive", IDs: []uint{1}},
+		{Action: dtos.BulkActionMove, IDs: []uint{1}},
+		{Action: dtos.BulkActionReject, IDs: []uint{1}},
+		{Action: dtos.BulkActionRate, IDs: []uint{1}},
+		{Action: dtos.BulkActionTag, Tags: []string{" "}, IDs: []uint{1}},
+	}
+	for _, dto := range cases {
+		if _, err := svc.BulkApply(1, dto, 7); apperr.StatusCode(err) != http.StatusBadRequest {
+			t.Errorf("%+v: err = %v, want 400", dto, err)
+		}
+	}
+}
+
+func TestTimeInStage(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(h float64) time.Time { return t0.Add(time.Duration(h * float64(time.Hour))) }
+	cases := []struct {
+		name    string
+		stages  []string
+		entered []float64
+		now     float64
+		want    []float64
+	}{
+		{"only entry, stage still open", []string{"applied"}, []float64{0}, 5, []float64{5}},
+		{"closed steps and open current stage", []string{"applied", "screening", "interview"}, []float64{0, 24, 30}, 48, []float64{24, 6, 18}},
+		// Volver a una etapa es un paso nuevo: no se suma al anterior.
+		{"re-entering a stage", []string{"applied", "screening", "applied", "screening"}, []float64{0, 2, 10, 11}, 20, []float64{2, 8, 1, 9}},
+		{"events at the same instant", []string{"applied", "rejected"}, []float64{3, 3}, 4, []float64{0, 1}},
+	}
+	for _, tc := range cases {
+		events := make([]dtos.StageEventDTO, len(tc.stages))
+		for i, stage := range tc.stages {
+			events[i] = dtos.StageEventDTO{ToStage: stage, OccurredAt: at(tc.entered[i])}
+		}
+
+		timeInStage(events, at(tc.now))
+
+		for i, e := range events {
+			if e.HoursInStage != tc.want[i] {
+				t.Errorf("%s: event %d (%s) hours = %v, want %v", tc.name, i, e.ToStage, e.HoursInStage, tc.want[i])
+			}
+			if e.Current != (i == len(events)-1) {
+				t.Errorf("%s: event %d current = %v", tc.name, i, e.Current)
+			}
+		}
+	}
+}
+
+func TestStageTimes(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	event := func(applicationID uint, stage string, h float64, backfilled bool) models.ApplicationStageEvent {
+		return models.ApplicationStageEvent{ApplicationID: applicationID, ToStage: stage, Backfilled: backfilled, OccurredAt: t0.Add(time.Duration(h * float64(time.Hour)))}
+	}
+	events := []models.ApplicationStageEvent{
+		// 1: applied 2h, screening 4h, de vuelta a applied 6h, screening abierta.
+		event(1, "applied", 0, false),
+		event(1, "screening", 2, false),
+		event(1, "applied", 6, false),
+		event(1, "screening", 12, false),
+		// 2: applied 10h, sigue en interview.
+		event(2, "applied", 0, false),
+		event(2, "interview", 10, false),
+		// 3: reconstruida: cuenta en la etapa, no en la duración.
+		event(3, "applied", 0, true),
+		event(3, "hired", 30, true),
+	}
+
+	stats := stageTimes(events, t0.Add(40*time.Hour))
+
+	want := map[string]dtos.StageTimeDTO{
+		"applied":   {Key: "applied", Exited: 3, AvgHours: 6, MedianHours: 6, InStage: 0},
+		"screening": {Key: "screening", Exited: 1, AvgHours: 4, MedianHours: 4, InStage: 1, AvgCurrentAgeHours: 28},
+		"interview": {Key: "interview", InStage: 1, AvgCurrentAgeHours: 30},
+		"hired":     {Key: "hired", InStage: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("stats = %+v", stats)
+	}
+	for key, w := range want {
+		if stats[key] != w {
+			t.Errorf("%s = %+v, want %+v", key, stats[key], w)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	cases := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{5}, 5},
+		{[]float64{9, 1, 4}, 4},
+		{[]float64{10, 2, 4, 8}, 6},
+	}
+	for _, tc := range cases {
+		if got := median(tc.values); got != tc.want {
+			t.Errorf("median(%v) = %v, want %v", tc.values, got, tc.want)
+		}
+	}
+}
+
+// Dos movimientos desde la misma etapa: el segundo parte de una lectura vieja
+// y no debe pisar al primero ni dejar un evento desde una etapa que ya no es
+// la actual.
+func TestMoveToStageConcurrentTransition(t *testing.T) {
+	svc, apps, _ := newBulkService(bulkRow(1, 1, "screening"))
+
+	if _, err := svc.MoveToStage(1, dtos.MoveApplicationDTO{Stage: "hired"}, 7); err != nil {
+		t.Fatal(err)
+	}
+	apps.stale = map[uint]models.Application{1: bulkRow(1, 1, "screening")}
+
+	_, err := svc.MoveToStage(1, dtos.MoveApplicationDTO{Stage: "rejected", RejectionReason: "skills_gap"}, 8)
+	if apperr.StatusCode(err) != http.StatusConflict {
+		t.Fatalf("err = %v, quería 409", err)
+	}
+	if apps.rows[1].Stage != "hired" || apps.rows[1].RejectionReason != "" {
+		t.Errorf("fila = %+v, quería hired sin rechazo", apps.rows[1])
+	}
+	if len(apps.events) != 1 || apps.events[0].ToStage != "hired" {
+		t.Errorf("eventos = %+v, quería solo screening → hired", apps.events)
+	}
+}
+
+// El barrido de estancadas rechaza como sistema: desde applied (sin arista a
+// rejected) queda como override; desde una etapa final no rechaza.
+func TestSystemReject(t *testing.T) {
+	svc, apps, _ := newBulkService(bulkRow(1, 1, "applied"), bulkRow(2, 1, "hired"))
+
+	application, err := svc.SystemReject(1, "auto-rejected after 30 days without activity", "", "skills_gap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if application.Stage != "rejected" || application.RejectionReason != "skills_gap" || application.RejectedFromStage != "applied" || application.RejectedAt == nil {
+		t.Errorf("postulación = %+v", application)
+	}
+	if len(apps.events) != 1 || !apps.events[0].Override || apps.events[0].ActorID != nil {
+		t.Errorf("eventos = %+v, quería un override sin actor", apps.events)
+	}
+
+	if _, err := svc.SystemReject(2, "auto-rejected", "", "skills_gap"); apperr.StatusCode(err) != http.StatusUnprocessableEntity {
+		t.Errorf("desde hired: err = %v, quería 422", err)
+	}
+	if _, err := svc.SystemReject(1, "auto-rejected", "", "otro"); apperr.StatusCode(err) != http.StatusUnprocessableEntity {
+		t.Errorf("ya rechazada: err = %v, quería 422", err)
+	}
+}
+
+func TestOverrideStageARejectedExigeMotivoDelCatalogo(t *testing.T) {
+	svc, _, _ := newBulkService(bulkRow(1, 1, "hired"))
+
+	dto := dtos.OverrideStageDTO{Stage: "rejected", Reason: "contratación cancelada"}
+	if _, err := svc.OverrideStage(1, dto, 7); apperr.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("sin motivo: err = %v, quería 400", err)
+	}
+	dto.RejectionReason = "otro"
+	if _, err := svc.OverrideStage(1, dto, 7); apperr.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("fuera del catálogo: err = %v, quería 400", err)
+	}
+
+	dto.RejectionReason = "skills_gap"
+	application, err := svc.OverrideStage(1, dto, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if application.Stage != "rejected" || application.RejectionReason != "skills_gap" || application.RejectedFromStage != "hired" || application.HiredAt != nil {
+		t.Errorf("postulación = %+v", application)
+	}
+}
+
+func TestHireTx(t *testing.T) {
+	svc, apps, automation := newBulkService(bulkRow(1, 1, "applied"), bulkRow(2, 1, "hired"), bulkRow(3, 1, "rejected"))
+
+	// applied → hired no está en el grafo: la oferta aceptada contrata igual.
+	application, event, err := svc.HireTx(apps, 1, "offer accepted", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if application.Stage != "hired" || application.HiredAt == nil {
+		t.Errorf("postulación = %+v", application)
+	}
+	if event == nil || !event.Override || event.FromStage != "applied" || len(apps.events) != 1 {
+		t.Errorf("evento = %+v, eventos = %+v, quería un override desde applied", event, apps.events)
+	}
+	if len(automation.fired) != 0 {
+		t.Errorf("avisos = %v, el llamador avisa tras el commit", automation.fired)
+	}
+
+	if _, event, err := svc.HireTx(apps, 2, "offer accepted", 7); err != nil || event != nil {
+		t.Errorf("ya contratada: evento = %+v, err = %v", event, err)
+	}
+	if _, _, err := svc.HireTx(apps, 3, "offer accepted", 7); apperr.StatusCode(err) != http.StatusUnprocessableEntity {
+		t.Errorf("desde rejected: err = %v, quería 422", err)
+	}
+}
+
+func TestReorderApplicationEliminadaEs404(t *testing.T) {
+	svc, _, _ := newBulkService(bulkRow(1, 1, "applied"))
+
+	if _, err := svc.ReorderApplication(9, dtos.ReorderApplicationDTO{AfterID: 1}); apperr.StatusCode(err) != http.StatusNotFound {
+		t.Errorf("err = %v, quería 404", err)
+	}
+}
@@ -14,17 +14,35 @@ type CandidateService interface {
 	GetAllCandidates() ([]models.Candidate, error)
 	GetCandidateByID(id uint) (*models.Candidate, error)
 	GetCandidatesByCompanyID(companyID uint) ([]models.Candidate, error)
+	SearchCandidates(companyID, viewerID uint, filters dtos.CandidateFilters) ([]models.Candidate, error)
 	CreateCandidate(dto dtos.CreateCandidateDTO) (*models.Candidate, error)
 	UpdateCandidate(id uint, dto dtos.UpdateCandidateDTO) (*models.Candidate, error)
 	DeleteCandidate(id uint) error
 }
 
+// resumeParser agenda el análisis en segundo plano de un CV recién guardado
+// (módulo resume): extrae su texto para la búsqueda y los datos con que se
+// puede completar la ficha. Solo encola; un fallo nunca afecta la operación
+// que lo originó.
+type resumeParser interface {
+	Enqueue(companyID, candidateID uint, resumeURL string)
+}
+
+// trashCascade manda a la papelera un registro junto con sus dependientes
+// (módulo trash), en una transacción: así restaurarlo los recupera a todos.
+// Lo usan candidates, jobs y applications al eliminar.
+type trashCascade interface {
+	Delete(entityType string, id uint) error
+}
+
 type candidateService struct {
 	candidateRepo repositories.CandidateRepository
+	resumes       resumeParser
+	trash         trashCascade
 }
 
-func NewCandidateService(candidateRepo repositories.CandidateRepository) CandidateService {
-	return &candidateService{candidateRepo: candidateRepo}
+func NewCandidateService(candidateRepo repositories.CandidateRepository, resumes resumeParser, trash trashCascade) CandidateService {
+	return &candidateService{candidateRepo: candidateRepo, resumes: resumes, trash: trash}
 }
 
 func (s *candidateService) GetAllCandidates() ([]models.Candidate, error) {
@@ -46,6 +64,13 @@ func (s *candidateService) GetCandidatesByCompanyID(companyID uint) ([]models.Ca
 	return s.candidateRepo.GetByCompanyID(companyID)
 }
 
+// SearchCandidates filtra por etiquetas, talent pool y consentimiento.
+// companyID 0 = todas; viewerID 0 = también los pools privados de otros
+// (SuperAdmin).
+func (s *candidateService) SearchCandidates(companyID, viewerID uint, filters dtos.CandidateFilters) ([]models.Candidate, error) {
+	return s.candidateRepo.Search(companyID, viewerID, filters)
+}
+
 func (s *candidateService) CreateCandidate(dto dtos.CreateCandidateDTO) (*models.Candidate, error) {
 	// Verificar duplicado por email en la misma company
 	existing, err := s.candidateRepo.GetByEmail(dto.Email, dto.CompanyID)
@@ -66,9 +91,20 @@ func (s *candidateService) CreateCandidate(dto dtos.CreateCandidateDTO) (*models
 		GithubURL:   dto.GithubURL,
 		LinkedinURL: dto.LinkedinURL,
 		Source:      dto.Source,
+
+		CityID:            dto.CityID,
+		WorkMode:          dto.WorkMode,
+		OpenToRelocate:    dto.OpenToRelocate,
+		SalaryExpectation: dto.SalaryExpectation,
+		YearsOfExperience: dto.YearsOfExperience,
 	}
 
-	return s.candidateRepo.Create(candidate)
+	created, err := s.candidateRepo.Create(candidate)
+	if err != nil {
+		return nil, err
+	}
+	s.resumes.Enqueue(created.CompanyID, created.ID, created.ResumeURL)
+	return created, nil
 }
 
 func (s *candidateService) UpdateCandidate(id uint, dto dtos.UpdateCandidateDTO) (*models.Candidate, error) {
@@ -92,8 +128,12 @@ func (s *candidateService) UpdateCandidate(id uint, dto dtos.UpdateCandidateDTO)
 	if dto.Phone != nil {
 		candidate.Phone = *dto.Phone
 	}
-	if dto.ResumeURL != nil {
+	resumeChanged := dto.ResumeURL != nil && *dto.ResumeURL != candidate.ResumeURL
+	if resumeChanged {
+		// El texto del CV anterior deja de valer; el del nuevo lo completa el
+		// análisis.
 		candidate.ResumeURL = *dto.ResumeURL
+		candidate.ResumeText = ""
 	}
 	if dto.GithubURL != nil {
 		candidate.GithubURL = *dto.GithubURL
@@ -104,8 +144,30 @@ func (s *candidateService) UpdateCandidate(id uint, dto dtos.UpdateCandidateDTO)
 	if dto.Source != nil {
 		candidate.Source = *dto.Source
 	}
+	if dto.CityID != nil {
+		candidate.CityID = dto.CityID
+	}
+	if dto.WorkMode != nil {
+		candidate.WorkMode = *dto.WorkMode
+	}
+	if dto.OpenToRelocate != nil {
+		candidate.OpenToRelocate = *dto.OpenToRelocate
+	}
+	if dto.SalaryExpectation != nil {
+		candidate.SalaryExpectation = dto.SalaryExpectation
+	}
+	if dto.YearsOfExperience != nil {
+		candidate.YearsOfExperience = dto.YearsOfExperience
+	}
 
-	return s.candidateRepo.Update(candidate)
+	updated, err := s.candidateRepo.Update(candidate)
+	if err != nil {
+		return nil, err
+	}
+	if resumeChanged {
+		s.resumes.Enqueue(updated.CompanyID, updated.ID, updated.ResumeURL)
+	}
+	return updated, nil
 }
 
 func (s *candidateService) DeleteCandidate(id uint) error {
@@ -116,5 +178,5 @@ func (s *candidateService) DeleteCandidate(id uint) error {
 	if candidate == nil {
 		return apperr.NotFound("candidate not found")
 	}
-	return s.candidateRepo.Delete(id)
+	return s.trash.Delete("candidate", id)
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
 	"dvra-api/internal/app/repositories"
 )
 
@@ -10,15 +11,59 @@ type DashboardService interface {
 	GetStats(companyID uint) (*dtos.DashboardStatsDTO, error)
 }
 
+// stageCatalog es lo que el dashboard necesita del módulo pipeline.
+type stageCatalog interface {
+	CompanyStages(companyID uint) ([]models.PipelineStage, error)
+}
+
 type dashboardService struct {
 	dashboardRepo repositories.DashboardRepository
+	pipelines     stageCatalog
 }
 
 // NewDashboardService crea una nueva instancia de DashboardService
-func NewDashboardService(dashboardRepo repositories.DashboardRepository) DashboardService {
-	return &dashboardService{dashboardRepo: dashboardRepo}
+func NewDashboardService(dashboardRepo repositories.DashboardRepository, pipelines stageCatalog) DashboardService {
+	return &dashboardService{dashboardRepo: dashboardRepo, pipelines: pipelines}
 }
 
 func (s *dashboardService) GetStats(companyID uint) (*dtos.DashboardStatsDTO, error) {
-	return s.dashboardRepo.GetStats(companyID)
+	stages, err := s.pipelines.CompanyStages(companyID)
+	if err != nil {
+		return nil, err
+	}
+	hiredStages := []string{}
+	for _, st := range stages {
+		if st.Type == models.StageTypeHired {
+			hiredStages = append(hiredStages, st.Key)
+		}
+	}
+
+	stats, err := s.dashboardRepo.GetStats(companyID, hiredStages)
+	if err != nil {
+		return nil, err
+	}
+	stats.ApplicationsByStage = stageCounts(stages, stats.ApplicationsByStage)
+	return stats, nil
+}
+
+// stageCounts ordena los conteos según el pipeline y rellena con 0 las etapas
+// sin postulaciones. Las keys que ya no existen en ningún pipeline quedan al
+// final para no perderlas del total.
+func stageCounts(stages []models.PipelineStage, raw []dtos.StageCountDTO) []dtos.StageCountDTO {
+	counts := make(map[string]int, len(raw))
+	for _, r := range raw {
+		counts[r.Key] = r.Count
+	}
+
+	result := make([]dtos.StageCountDTO, 0, len(stages))
+	for _, st := range stages {
+		result = append(result, dtos.StageCountDTO{Key: st.Key, Name: st.Name, Type: st.Type, Color: st.Color, Count: counts[st.Key]})
+		delete(counts, st.Key)
+	}
+	for _, r := range raw {
+		if _, orphan := counts[r.Key]; orphan {
+			result = append(result, r)
+		}
+	}
+	return result
 }
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"strings"
+
 	"dvra-api/internal/app/dtos"
 	"dvra-api/internal/app/models"
 	"dvra-api/internal/app/repositories"
@@ -32,10 +34,11 @@ type staffingClientReader interface {
 type jobService struct {
 	jobRepo      repositories.JobRepository
 	staffingRepo staffingClientReader
+	trash        trashCascade
 }
 
-func NewJobService(jobRepo repositories.JobRepository, staffingRepo staffingClientReader) JobService {
-	return &jobService{jobRepo: jobRepo, staffingRepo: staffingRepo}
+func NewJobService(jobRepo repositories.JobRepository, staffingRepo staffingClientReader, trash trashCascade) JobService {
+	return &jobService{jobRepo: jobRepo, staffingRepo: staffingRepo, trash: trash}
 }
 
 // validateStaffingClient asegura que el cliente final exista y pertenezca a la
@@ -99,20 +102,27 @@ func (s *jobService) CreateJob(dto dtos.CreateJobDTO) (*models.Job, error) {
 		}
 	}
 
+	currency := strings.ToUpper(dto.SalaryCurrency)
+	if currency == "" {
+		currency = "USD"
+	}
+
 	job := &models.Job{
-		CompanyID:         dto.CompanyID,
-		Title:             dto.Title,
-		Description:       dto.Description,
-		LocationType:      dto.LocationType,
-		CityID:            dto.CityID,
-		SalaryMin:         dto.SalaryMin,
-		SalaryMax:         dto.SalaryMax,
-		Requirements:      dto.Requirements,
-		Benefits:          dto.Benefits,
-		Status:            status,
-		AssignedRecruiter: dto.AssignedRecruiter,
-		HiringManager:     dto.HiringManager,
-		StaffingClientID:  dto.StaffingClientID,
+		CompanyID:          dto.CompanyID,
+		Title:              dto.Title,
+		Description:        dto.Description,
+		LocationType:       dto.LocationType,
+		CityID:             dto.CityID,
+		SalaryMin:          dto.SalaryMin,
+		SalaryMax:          dto.SalaryMax,
+		SalaryCurrency:     currency,
+		Requirements:       dto.Requirements,
+		Benefits:           dto.Benefits,
+		ExperienceYearsMin: dto.ExperienceYearsMin,
+		Status:             status,
+		AssignedRecruiter:  dto.AssignedRecruiter,
+		HiringManager:      dto.HiringManager,
+		StaffingClientID:   dto.StaffingClientID,
 	}
 
 	return s.jobRepo.Create(job)
@@ -145,12 +155,18 @@ func (s *jobService) UpdateJob(id uint, dto dtos.UpdateJobDTO) (*models.Job, err
 	if dto.SalaryMax != nil {
 		job.SalaryMax = dto.SalaryMax
 	}
+	if dto.SalaryCurrency != nil {
+		job.SalaryCurrency = strings.ToUpper(*dto.SalaryCurrency)
+	}
 	if dto.Requirements != nil {
 		job.Requirements = *dto.Requirements
 	}
 	if dto.Benefits != nil {
 		job.Benefits = *dto.Benefits
 	}
+	if dto.ExperienceYearsMin != nil {
+		job.ExperienceYearsMin = dto.ExperienceYearsMin
+	}
 	if dto.Status != nil {
 		job.Status = *dto.Status
 	}
@@ -209,5 +225,5 @@ func (s *jobService) DeleteJob(id uint) error {
 	if job == nil {
 		return apperr.NotFound("job not found")
 	}
-	return s.jobRepo.Delete(id)
+	return s.trash.Delete("job", id)
 }
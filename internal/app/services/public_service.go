@@ -18,30 +18,77 @@ type PublicService interface {
 	// Jobs
 	GetPublishedJobsByCompanySlug(slug string) ([]models.Job, error)
 	GetPublishedJobByID(jobID uint) (*models.Job, error)
+	GetScreeningQuestions(jobID uint) ([]models.ScreeningQuestion, error)
 
 	// Applications
 	ApplyToJob(jobID uint, dto dtos.PublicApplicationDTO) (*models.Application, error)
 }
 
+// consentRecorder es lo que la career page necesita del módulo privacy:
+// validar el aviso aceptado y guardar la evidencia del consentimiento
+// (RN-GDPR-001). Puerto definido por el consumidor; lo inyecta el composition root.
+type consentRecorder interface {
+	ValidateNoticeVersion(companyID uint, version *int) error
+	RecordApplicationConsent(companyID, candidateID, applicationID uint, talentPool bool, evidence dtos.ConsentEvidence) error
+}
+
+// screeningGate es lo que la career page necesita del módulo screening: las
+// preguntas de la vacante, evaluar las respuestas y guardarlas con la
+// postulación.
+type screeningGate interface {
+	Questions(jobID uint) ([]models.ScreeningQuestion, error)
+	Evaluate(jobID uint, answers map[uint]string) (*dtos.ScreeningOutcome, error)
+	Record(applicationID uint, answers []models.ScreeningAnswer) error
+}
+
+// ApplyTx son los puertos con los que ApplyToJob escribe, ligados a una
+// misma transacción: candidato, postulación, carta, consentimiento y
+// respuestas de screening se confirman o se revierten juntos.
+type ApplyTx struct {
+	Candidates   repositories.CandidateRepository
+	Applications repositories.ApplicationRepository
+	Consents     consentRecorder
+	Notes        noteAppender
+	Screening    screeningGate
+}
+
+// applyTransactor abre la transacción de ApplyToJob; si fn devuelve error
+// se revierte. Lo implementa el composition root.
+type applyTransactor interface {
+	Transaction(fn func(tx ApplyTx) error) error
+}
+
 type publicService struct {
-	companyRepo     repositories.CompanyRepository
-	jobRepo         repositories.JobRepository
-	candidateRepo   repositories.CandidateRepository
-	applicationRepo repositories.ApplicationRepository
+	companyRepo repositories.CompanyRepository
+	jobRepo     repositories.JobRepository
+	consents    consentRecorder
+	pipelines   pipelineResolver
+	automation  automationHook
+	resumes     resumeParser
+	screening   screeningGate
+	tx          applyTransactor
 }
 
 // NewPublicService crea una nueva instancia de PublicService
 func NewPublicService(
 	companyRepo repositories.CompanyRepository,
 	jobRepo repositories.JobRepository,
-	candidateRepo repositories.CandidateRepository,
-	applicationRepo repositories.ApplicationRepository,
+	consents consentRecorder,
+	pipelines pipelineResolver,
+	automation automationHook,
+	resumes resumeParser,
+	screening screeningGate,
+	tx applyTransactor,
 ) PublicService {
 	return &publicService{
-		companyRepo:     companyRepo,
-		jobRepo:         jobRepo,
-		candidateRepo:   candidateRepo,
-		applicationRepo: applicationRepo,
+		companyRepo: companyRepo,
+		jobRepo:     jobRepo,
+		consents:    consents,
+		pipelines:   pipelines,
+		automation:  automation,
+		resumes:     resumes,
+		screening:   screening,
+		tx:          tx,
 	}
 }
 
@@ -95,8 +142,22 @@ func (s *publicService) GetPublishedJobByID(jobID uint) (*models.Job, error) {
 	return job, nil
 }
 
+// GetScreeningQuestions obtiene las preguntas de screening de un job
+// publicado
+func (s *publicService) GetScreeningQuestions(jobID uint) ([]models.ScreeningQuestion, error) {
+	if _, err := s.GetPublishedJobByID(jobID); err != nil {
+		return nil, err
+	}
+	return s.screening.Questions(jobID)
+}
+
 // ApplyToJob permite a un candidato aplicar a un job
 func (s *publicService) ApplyToJob(jobID uint, dto dtos.PublicApplicationDTO) (*models.Application, error) {
+	// RN-GDPR-001: sin consentimiento explícito no se guarda ningún dato.
+	if !dto.Consent {
+		return nil, apperr.BadRequest("consent to the privacy notice is required")
+	}
+
 	// 1. Obtener el job y verificar que esté activo
 	job, err := s.jobRepo.GetByID(jobID)
 	if err != nil {
@@ -108,17 +169,114 @@ func (s *publicService) ApplyToJob(jobID uint, dto dtos.PublicApplicationDTO) (*
 	if job.Status != "published" {
 		return nil, apperr.NotFound("job is not accepting applications")
 	}
+	if err := s.consents.ValidateNoticeVersion(job.CompanyID, dto.NoticeVersion); err != nil {
+		return nil, err
+	}
+	// Las respuestas de screening se validan antes de guardar nada.
+	screening, err := s.screening.Evaluate(jobID, dto.Answers)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := s.pipelines.Resolve(job.CompanyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2–7 van en una sola transacción: si falla cualquier paso no queda
+	// candidato, postulación ni consentimiento a medias, y el candidato
+	// puede reintentar.
+	var candidate *models.Candidate
+	var application *models.Application
+	var entry string
+	err = s.tx.Transaction(func(tx ApplyTx) error {
+		// 2. Buscar o crear candidato
+		var err error
+		candidate, err = upsertApplicant(tx.Candidates, job.CompanyID, dto)
+		if err != nil {
+			return err
+		}
+
+		// 3. Verificar si ya existe una aplicación para este job
+		existingApp, err := tx.Applications.GetByCandidateAndJob(candidate.ID, jobID)
+		if err != nil {
+			return err
+		}
+		if existingApp != nil {
+			return apperr.Conflict("you have already applied to this job")
+		}
+
+		// 4. Crear la aplicación en la primera etapa del pipeline de la vacante
+		application = &models.Application{
+			JobID:       jobID,
+			CandidateID: candidate.ID,
+			CompanyID:   job.CompanyID,
+			Stage:       pipeline.StageOfType(models.StageTypeActive).Key,
+			AppliedAt:   time.Now(),
+		}
+		application, err = tx.Applications.CreateWithEvent(application, newStageEvent(application, "", 0, ""))
+		if err != nil {
+			return fmt.Errorf("failed to create application: %w", err)
+		}
+
+		// 5. La carta de presentación abre el hilo de comentarios (RN-APP-009).
+		if err := tx.Notes.AppendNote(job.CompanyID, models.CommentSubjectApplication, application.ID, nil, dto.CoverLetter, models.CommentSourceCoverLetter); err != nil {
+			return fmt.Errorf("failed to record cover letter: %w", err)
+		}
+
+		// 6. Guardar la evidencia del consentimiento: sin ella la postulación
+		// no puede quedar. Con ella, las respuestas de screening.
+		if err := tx.Consents.RecordApplicationConsent(job.CompanyID, candidate.ID, application.ID, dto.TalentPoolConsent, dto.Evidence); err != nil {
+			return fmt.Errorf("failed to record consent: %w", err)
+		}
+		if err := tx.Screening.Record(application.ID, screening.Answers); err != nil {
+			return fmt.Errorf("failed to record screening answers: %w", err)
+		}
+
+		// 7. Si una pregunta knockout no se cumplió, rechazar la postulación
+		// con su motivo (RN-APP-017). El candidato ve la misma confirmación.
+		entry = application.Stage
+		if !screening.KnockedOut() {
+			return nil
+		}
+		event, err := knockOut(application, pipeline, screening, s.pipelines.CheckTransition)
+		if err != nil {
+			return fmt.Errorf("failed to reject application: %w", err)
+		}
+		if application, err = tx.Applications.UpdateWithEvent(application, event); err != nil {
+			return fmt.Errorf("failed to reject application: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dto.ResumeURL != "" {
+		s.resumes.Enqueue(candidate.CompanyID, candidate.ID, dto.ResumeURL)
+	}
+
+	s.automation.Fire(models.AutomationTriggerApplicationCreated, application, "")
+	if screening.KnockedOut() {
+		s.automation.Fire(models.AutomationTriggerStageChanged, application, entry)
+	}
+
+	// Cargar relaciones para la respuesta
+	application.Job = job
+	application.Candidate = candidate
 
-	// 2. Buscar o crear candidato
-	candidate, err := s.candidateRepo.GetByEmail(dto.Email, job.CompanyID)
+	return application, nil
+}
+
+// upsertApplicant busca al candidato por email en la empresa o lo crea; si
+// ya existe completa los datos que le faltan y reemplaza el CV.
+func upsertApplicant(candidates repositories.CandidateRepository, companyID uint, dto dtos.PublicApplicationDTO) (*models.Candidate, error) {
+	candidate, err := candidates.GetByEmail(dto.Email, companyID)
 	if err != nil {
 		return nil, err
 	}
 
 	if candidate == nil {
-		// Crear nuevo candidato
 		candidate = &models.Candidate{
-			CompanyID:   job.CompanyID,
+			CompanyID:   companyID,
 			Email:       dto.Email,
 			FirstName:   dto.FirstName,
 			LastName:    dto.LastName,
@@ -128,64 +286,59 @@ func (s *publicService) ApplyToJob(jobID uint, dto dtos.PublicApplicationDTO) (*
 			ResumeURL:   dto.ResumeURL,
 			Source:      "career_page",
 		}
-		candidate, err = s.candidateRepo.Create(candidate)
+		candidate, err = candidates.Create(candidate)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create candidate: %w", err)
 		}
-	} else {
-		// Actualizar datos del candidato si están vacíos
-		updated := false
-		if candidate.Phone == "" && dto.Phone != "" {
-			candidate.Phone = dto.Phone
-			updated = true
-		}
-		if candidate.LinkedinURL == "" && dto.LinkedinURL != "" {
-			candidate.LinkedinURL = dto.LinkedinURL
-			updated = true
-		}
-		if candidate.GithubURL == "" && dto.GithubURL != "" {
-			candidate.GithubURL = dto.GithubURL
-			updated = true
-		}
-		if dto.ResumeURL != "" {
-			candidate.ResumeURL = dto.ResumeURL
-			updated = true
-		}
-		if updated {
-			candidate, err = s.candidateRepo.Update(candidate)
-			if err != nil {
-				return nil, fmt.Errorf("failed to update candidate: %w", err)
-			}
-		}
+		return candidate, nil
 	}
 
-	// 3. Verificar si ya existe una aplicación para este job
-	existingApp, err := s.applicationRepo.GetByCandidateAndJob(candidate.ID, jobID)
-	if err != nil {
-		return nil, err
+	// Actualizar datos del candidato si están vacíos
+	updated := false
+	if candidate.Phone == "" && dto.Phone != "" {
+		candidate.Phone = dto.Phone
+		updated = true
 	}
-	if existingApp != nil {
-		return nil, apperr.Conflict("you have already applied to this job")
+	if candidate.LinkedinURL == "" && dto.LinkedinURL != "" {
+		candidate.LinkedinURL = dto.LinkedinURL
+		updated = true
 	}
-
-	// 4. Crear la aplicación
-	application := &models.Application{
-		JobID:       jobID,
-		CandidateID: candidate.ID,
-		CompanyID:   job.CompanyID,
-		Stage:       "applied",
-		Notes:       dto.CoverLetter,
-		AppliedAt:   time.Now(),
+	if candidate.GithubURL == "" && dto.GithubURL != "" {
+		candidate.GithubURL = dto.GithubURL
+		updated = true
 	}
-
-	application, err = s.applicationRepo.Create(application)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create application: %w", err)
+	if dto.ResumeURL != "" {
+		candidate.ResumeURL = dto.ResumeURL
+		candidate.ResumeText = ""
+		updated = true
 	}
+	if updated {
+		candidate, err = candidates.Update(candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update candidate: %w", err)
+		}
+	}
+	return candidate, nil
+}
 
-	// Cargar relaciones para la respuesta
-	application.Job = job
-	application.Candidate = candidate
-
-	return application, nil
+// knockOut pasa la postulación recién creada a la etapa rejected del
+// pipeline con el motivo de la pregunta knockout (tipo rejected_by_us) y
+// devuelve su evento, sin actor. Es un rechazo del sistema: si el grafo no
+// permite rechazar desde la etapa de entrada (applied → rejected en el
+// pipeline por defecto, RN-APP-002), el evento queda marcado como override,
+// igual que un movimiento fuera del grafo hecho por un admin.
+func knockOut(application *models.Application, pipeline *models.Pipeline, outcome *dtos.ScreeningOutcome, check func(pipeline *models.Pipeline, from, to string) error) (*models.ApplicationStageEvent, error) {
+	rejected := pipeline.StageOfType(models.StageTypeRejected)
+	if rejected == nil {
+		return nil, fmt.Errorf("pipeline has no rejected stage")
+	}
+	from := application.Stage
+	override := check(pipeline, from, rejected.Key) != nil
+	setStage(application, rejected)
+	application.RejectionType = models.RejectionTypeRejected
+	application.RejectionReason = outcome.RejectionReason
+	application.RejectedFromStage = from
+	event := newStageEvent(application, from, 0, outcome.Reason)
+	event.Override = override
+	return event, nil
 }
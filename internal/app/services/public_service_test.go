@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+)
+
+func knockoutPipeline() *models.Pipeline {
+	return &models.Pipeline{Stages: []models.PipelineStage{
+		{Key: "applied", Type: models.StageTypeActive, Position: 0, Transitions: []string{"screening"}},
+		{Key: "screening", Type: models.StageTypeActive, Position: 1},
+		{Key: "hired", Type: models.StageTypeHired, Position: 2},
+		{Key: "rejected", Type: models.StageTypeRejected, Position: 3},
+	}}
+}
+
+func TestKnockOut(t *testing.T) {
+	outcome := &dtos.ScreeningOutcome{RejectionReason: "skills_gap", Reason: "screening knockout: English"}
+	cases := []struct {
+		name     string
+		check    error
+		override bool
+	}{
+		{"transition allowed", nil, false},
+		// applied → rejected no está en el grafo por defecto (RN-APP-002).
+		{"transition not allowed", errors.New("not allowed"), true},
+	}
+	for _, tc := range cases {
+		application := &models.Application{CompanyID: 1, Stage: "applied"}
+		var from, to string
+		check := func(_ *models.Pipeline, f, t string) error {
+			from, to = f, t
+			return tc.check
+		}
+
+		event, err := knockOut(application, knockoutPipeline(), outcome, check)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if from != "applied" || to != "rejected" {
+			t.Errorf("%s: checked %s → %s", tc.name, from, to)
+		}
+		if event.Override != tc.override {
+			t.Errorf("%s: override = %v, want %v", tc.name, event.Override, tc.override)
+		}
+		if event.FromStage != "applied" || event.ToStage != "rejected" || event.ActorID != nil || event.Reason != outcome.Reason {
+			t.Errorf("%s: event = %+v", tc.name, event)
+		}
+		if application.Stage != "rejected" || application.RejectedAt == nil ||
+			application.RejectionType != models.RejectionTypeRejected ||
+			application.RejectionReason != "skills_gap" || application.RejectedFromStage != "applied" {
+			t.Errorf("%s: application = %+v", tc.name, application)
+		}
+	}
+}
+
+func TestKnockOutWithoutRejectedStage(t *testing.T) {
+	pipeline := &models.Pipeline{Stages: []models.PipelineStage{{Key: "applied", Type: models.StageTypeActive}}}
+	application := &models.Application{Stage: "applied"}
+	allow := func(*models.Pipeline, string, string) error { return nil }
+
+	if _, err := knockOut(application, pipeline, &dtos.ScreeningOutcome{RejectionReason: "other"}, allow); err == nil {
+		t.Fatal("expected an error without a rejected stage")
+	}
+	if application.Stage != "applied" {
+		t.Errorf("stage = %q, want applied", application.Stage)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/app/repositories"
+	"dvra-api/internal/shared/apperr"
+)
+
+// RejectionReasonService administra el catálogo de motivos de rechazo de una
+// empresa. Los motivos viven en SystemValue: los globales (company_id NULL)
+// los define la plataforma; cada empresa agrega, edita y elimina los suyos.
+type RejectionReasonService interface {
+	List(companyID uint) ([]dtos.RejectionReasonDTO, error)
+	Create(companyID uint, dto dtos.CreateRejectionReasonDTO) (*dtos.RejectionReasonDTO, error)
+	Update(id, companyID uint, dto dtos.UpdateRejectionReasonDTO) (*dtos.RejectionReasonDTO, error)
+	Delete(id, companyID uint) error
+}
+
+type rejectionReasonService struct {
+	repo repositories.SystemValueRepository
+}
+
+func NewRejectionReasonService(repo repositories.SystemValueRepository) RejectionReasonService {
+	return &rejectionReasonService{repo: repo}
+}
+
+// List devuelve los motivos vigentes de ambos tipos: globales y de la empresa.
+func (s *rejectionReasonService) List(companyID uint) ([]dtos.RejectionReasonDTO, error) {
+	result := []dtos.RejectionReasonDTO{}
+	for _, t := range []string{models.RejectionTypeRejected, models.RejectionTypeWithdrew} {
+		values, err := s.repo.GetByCategory(models.RejectionCategory(t), &companyID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range values {
+			result = append(result, toRejectionReason(t, &values[i]))
+		}
+	}
+	return result, nil
+}
+
+func (s *rejectionReasonService) Create(companyID uint, dto dtos.CreateRejectionReasonDTO) (*dtos.RejectionReasonDTO, error) {
+	category := models.RejectionCategory(dto.Type)
+	value := strings.TrimSpace(dto.Value)
+	if category == "" || value == "" {
+		return nil, apperr.BadRequest("type and value are required")
+	}
+
+	// El value identifica el motivo en las postulaciones: no puede repetir
+	// uno global ni otro de la empresa.
+	existing, err := s.repo.GetByCategory(category, &companyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range existing {
+		if v.Value == value {
+			return nil, apperr.Conflict(fmt.Sprintf("rejection reason '%s' already exists", value))
+		}
+	}
+
+	reason := &models.SystemValue{
+		Category:     category,
+		Value:        value,
+		Label:        dto.Label,
+		Description:  dto.Description,
+		DisplayOrder: dto.DisplayOrder,
+		IsActive:     true,
+		CompanyID:    &companyID,
+	}
+	if err := s.repo.Create(reason); err != nil {
+		return nil, err
+	}
+	result := toRejectionReason(dto.Type, reason)
+	return &result, nil
+}
+
+// Update modifica un motivo propio. El value no cambia: las postulaciones ya
+// rechazadas lo referencian.
+func (s *rejectionReasonService) Update(id, companyID uint, dto dtos.UpdateRejectionReasonDTO) (*dtos.RejectionReasonDTO, error) {
+	reason, rejectionType, err := s.getOwn(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Label != nil {
+		reason.Label = *dto.Label
+	}
+	if dto.Description != nil {
+		reason.Description = dto.Description
+	}
+	if dto.DisplayOrder != nil {
+		reason.DisplayOrder = *dto.DisplayOrder
+	}
+	if err := s.repo.Update(reason); err != nil {
+		return nil, err
+	}
+	result := toRejectionReason(rejectionType, reason)
+	return &result, nil
+}
+
+// Delete quita un motivo propio del catálogo (soft delete). Los rechazos ya
+// hechos conservan el value y se reportan con él.
+func (s *rejectionReasonService) Delete(id, companyID uint) error {
+	if _, _, err := s.getOwn(id, companyID); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// getOwn devuelve el motivo si pertenece a la empresa. Los globales solo se
+// leen.
+func (s *rejectionReasonService) getOwn(id, companyID uint) (*models.SystemValue, string, error) {
+	reason, err := s.repo.GetByID(id)
+	if err != nil || reason == nil {
+		return nil, "", apperr.NotFound("rejection reason not found")
+	}
+	rejectionType := rejectionTypeOf(reason.Category)
+	if rejectionType == "" || (reason.CompanyID != nil && *reason.CompanyID != companyID) {
+		return nil, "", apperr.NotFound("rejection reason not found")
+	}
+	if reason.CompanyID == nil {
+		return nil, "", apperr.Forbidden("global rejection reasons cannot be modified")
+	}
+	return reason, rejectionType, nil
+}
+
+func rejectionTypeOf(category string) string {
+	for _, t := range []string{models.RejectionTypeRejected, models.RejectionTypeWithdrew} {
+		if models.RejectionCategory(t) == category {
+			return t
+		}
+	}
+	return ""
+}
+
+func toRejectionReason(rejectionType string, v *models.SystemValue) dtos.RejectionReasonDTO {
+	return dtos.RejectionReasonDTO{
+		ID:           v.ID,
+		Type:         rejectionType,
+		Value:        v.Value,
+		Label:        v.Label,
+		Description:  v.Description,
+		DisplayOrder: v.DisplayOrder,
+		Global:       v.CompanyID == nil,
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/app/repositories"
+	"dvra-api/internal/shared/apperr"
+)
+
+// TagService define el contrato del catálogo de etiquetas de candidatos y
+// de su asignación (individual y masiva).
+type TagService interface {
+	ListTags(companyID uint) ([]repositories.TagWithCount, error)
+	CreateTag(companyID uint, dto dtos.CreateTagDTO) (*models.Tag, error)
+	UpdateTag(id, companyID uint, dto dtos.UpdateTagDTO) (*models.Tag, error)
+	DeleteTag(id, companyID uint) error
+	GetCandidateTags(candidateID, companyID uint) ([]models.Tag, error)
+	TagCandidate(candidateID, companyID uint, dto dtos.CandidateTagsDTO, actorID uint) ([]models.Tag, error)
+	UntagCandidate(candidateID, tagID, companyID uint) error
+	BulkTagCandidates(companyID uint, dto dtos.BulkCandidateTagsDTO, actorID uint) (*dtos.BulkCandidateTagsResultDTO, error)
+}
+
+type tagService struct {
+	tagRepo       repositories.TagRepository
+	candidateRepo repositories.CandidateRepository
+}
+
+func NewTagService(tagRepo repositories.TagRepository, candidateRepo repositories.CandidateRepository) TagService {
+	return &tagService{tagRepo: tagRepo, candidateRepo: candidateRepo}
+}
+
+func (s *tagService) ListTags(companyID uint) ([]repositories.TagWithCount, error) {
+	return s.tagRepo.List(companyID)
+}
+
+func (s *tagService) CreateTag(companyID uint, dto dtos.CreateTagDTO) (*models.Tag, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, apperr.BadRequest("name is required")
+	}
+	if err := s.checkNameFree(companyID, name, 0); err != nil {
+		return nil, err
+	}
+	tag := &models.Tag{CompanyID: companyID, Name: name, Color: dto.Color}
+	if tag.Color == "" {
+		tag.Color = repositories.DefaultTagColor
+	}
+	if err := s.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (s *tagService) UpdateTag(id, companyID uint, dto dtos.UpdateTagDTO) (*models.Tag, error) {
+	tag, err := s.tag(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, apperr.BadRequest("name cannot be empty")
+		}
+		if err := s.checkNameFree(tag.CompanyID, name, tag.ID); err != nil {
+			return nil, err
+		}
+		tag.Name = name
+	}
+	if dto.Color != nil {
+		tag.Color = *dto.Color
+	}
+	if err := s.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag elimina la etiqueta y la quita de todos los candidatos. Las
+// automatizaciones add_tag que la nombran la vuelven a crear.
+func (s *tagService) DeleteTag(id, companyID uint) error {
+	tag, err := s.tag(id, companyID)
+	if err != nil {
+		return err
+	}
+	return s.tagRepo.Delete(tag.ID)
+}
+
+func (s *tagService) GetCandidateTags(candidateID, companyID uint) ([]models.Tag, error) {
+	if _, err := s.candidate(candidateID, companyID); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.CandidateTags(candidateID)
+}
+
+// TagCandidate asigna etiquetas por nombre (creando las que falten) y
+// devuelve todas las del candidato.
+func (s *tagService) TagCandidate(candidateID, companyID uint, dto dtos.CandidateTagsDTO, actorID uint) ([]models.Tag, error) {
+	candidate, err := s.candidate(candidateID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	names := normalizeTagNames(dto.Tags)
+	if len(names) == 0 {
+		return nil, apperr.BadRequest("tags are required")
+	}
+	tags, err := s.tagRepo.FindOrCreateByNames(candidate.CompanyID, names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.AttachToCandidate(candidate.ID, tagIDs(tags), &actorID); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.CandidateTags(candidate.ID)
+}
+
+func (s *tagService) UntagCandidate(candidateID, tagID, companyID uint) error {
+	candidate, err := s.candidate(candidateID, companyID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.tag(tagID, candidate.CompanyID); err != nil {
+		return err
+	}
+	_, err = s.tagRepo.DetachFromCandidates([]uint{candidate.ID}, []uint{tagID})
+	return err
+}
+
+// BulkTagCandidates agrega y quita etiquetas a varios candidatos de la
+// empresa en una sola operación. Los IDs ajenos o inexistentes se informan
+// en Skipped sin cortar el resto.
+func (s *tagService) BulkTagCandidates(companyID uint, dto dtos.BulkCandidateTagsDTO, actorID uint) (*dtos.BulkCandidateTagsResultDTO, error) {
+	add, remove := normalizeTagNames(dto.Add), normalizeTagNames(dto.Remove)
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, apperr.BadRequest("add or remove is required")
+	}
+
+	ids, err := s.candidateRepo.IDsInCompany(companyID, dto.CandidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := &dtos.BulkCandidateTagsResultDTO{Candidates: len(ids), Skipped: []uint{}}
+	found := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		found[id] = true
+	}
+	for _, id := range dto.CandidateIDs {
+		if !found[id] {
+			result.Skipped = append(result.Skipped, id)
+			found[id] = true // no repetir
+		}
+	}
+
+	if len(add) > 0 {
+		tags, err := s.tagRepo.FindOrCreateByNames(companyID, add)
+		if err != nil {
+			return nil, err
+		}
+		if result.Added, err = s.tagRepo.AttachToCandidates(ids, tagIDs(tags), &actorID); err != nil {
+			return nil, err
+		}
+	}
+	if len(remove) > 0 {
+		var removeIDs []uint
+		for _, name := range remove {
+			tag, err := s.tagRepo.GetByName(companyID, name)
+			if err != nil {
+				return nil, err
+			}
+			if tag != nil {
+				removeIDs = append(removeIDs, tag.ID)
+			}
+		}
+		if result.Removed, err = s.tagRepo.DetachFromCandidates(ids, removeIDs); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *tagService) checkNameFree(companyID uint, name string, exceptID uint) error {
+	existing, err := s.tagRepo.GetByName(companyID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return apperr.Conflict(fmt.Sprintf("tag '%s' already exists", existing.Name))
+	}
+	return nil
+}
+
+// tag devuelve una etiqueta de la empresa (companyID 0 = cualquiera).
+func (s *tagService) tag(id, companyID uint) (*models.Tag, error) {
+	tag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil || (companyID != 0 && tag.CompanyID != companyID) {
+		return nil, apperr.NotFound("tag not found")
+	}
+	return tag, nil
+}
+
+func (s *tagService) candidate(id, companyID uint) (*models.Candidate, error) {
+	candidate, err := s.candidateRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || (companyID != 0 && candidate.CompanyID != companyID) {
+		return nil, apperr.NotFound("candidate not found")
+	}
+	return candidate, nil
+}
+
+func tagIDs(tags []models.Tag) []uint {
+	ids := make([]uint, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	return ids
+}
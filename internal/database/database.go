@@ -72,6 +72,9 @@ func CloseDB() error {
 func AutoMigrate(db *gorm.DB) error {
 	log.Println("🔄 Running database migrations...")
 
+	if err := EnsureSearchConfig(db); err != nil {
+		return err
+	}
 	if err := db.AutoMigrate(AllModels...); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
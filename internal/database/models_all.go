@@ -22,4 +22,47 @@ var AllModels = []interface{}{
 	&models.State{},
 	&models.City{},
 	&models.PlatformSettings{},
+	&models.RetentionPolicy{},
+	&models.RetentionRun{},
+	&models.DataSubjectRequest{},
+	&models.PrivacyNotice{},
+	&models.CandidateConsent{},
+	&models.Pipeline{},
+	&models.PipelineStage{},
+	&models.ApplicationStageEvent{},
+	&models.Tag{},
+	&models.CandidateTag{},
+	&models.ScorecardTemplate{},
+	&models.Scorecard{},
+	&models.Comment{},
+	&models.CommentRevision{},
+	&models.CommentMention{},
+	&models.CommentAttachment{},
+	&models.Notification{},
+	&models.Interview{},
+	&models.InterviewPanelist{},
+	&models.InterviewerAvailability{},
+	&models.AvailabilityBlackout{},
+	&models.SchedulingLink{},
+	&models.Offer{},
+	&models.OfferVersion{},
+	&models.OfferApproval{},
+	&models.OfferApprovalStep{},
+	&models.DocumentTemplate{},
+	&models.Document{},
+	&models.AutomationRule{},
+	&models.AutomationEvent{},
+	&models.AutomationRun{},
+	&models.StalePolicy{},
+	&models.StaleAction{},
+	&models.CandidateMerge{},
+	&models.TalentPool{},
+	&models.TalentPoolMember{},
+	&models.CandidateSearchDocument{},
+	&models.ResumeParse{},
+	&models.Skill{},
+	&models.JobSkill{},
+	&models.CandidateSkill{},
+	&models.ScreeningQuestion{},
+	&models.ScreeningAnswer{},
 }
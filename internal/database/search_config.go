@@ -0,0 +1,37 @@
+package database
+
+import (
+	"fmt"
+
+	"dvra-api/internal/app/models"
+
+	"gorm.io/gorm"
+)
+
+// searchConfigStatements crean la extensión unaccent y la configuración de
+// búsqueda models.SearchConfig: copia de simple (sin stemming ni stopwords,
+// el índice mezcla español, portugués e inglés) que quita las tildes de las
+// palabras. Son idempotentes: se ejecutan en cada migración.
+var searchConfigStatements = []string{
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+	`DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = '` + models.SearchConfig + `') THEN
+		CREATE TEXT SEARCH CONFIGURATION ` + models.SearchConfig + ` (COPY = simple);
+		ALTER TEXT SEARCH CONFIGURATION ` + models.SearchConfig + `
+			ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+	END IF;
+END
+$$`,
+}
+
+// EnsureSearchConfig prepara lo que AutoMigrate no crea y la búsqueda de
+// candidatos necesita (extensión unaccent y configuración de texto).
+func EnsureSearchConfig(db *gorm.DB) error {
+	for _, stmt := range searchConfigStatements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare text search configuration: %w", err)
+		}
+	}
+	return nil
+}
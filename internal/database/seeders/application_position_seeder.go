@@ -0,0 +1,63 @@
+package seeders
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/shared/rank"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationPositionSeeder assigns a board position to applications created
+// before manual ordering existed
+type ApplicationPositionSeeder struct{}
+
+// Run executes the application position seeder
+func (s *ApplicationPositionSeeder) Run(db *gorm.DB) error {
+	return SeedApplicationPositions(db)
+}
+
+// SeedApplicationPositions redistribuye las columnas (empresa + etapa) que
+// tienen postulaciones sin posición, conservando el orden en que el tablero
+// ya las mostraba (sin posición primero, por id). Incluye las de la papelera
+// para que vuelvan ordenadas al restaurarse. Es idempotente.
+func SeedApplicationPositions(db *gorm.DB) error {
+	var columns []struct {
+		CompanyID uint
+		Stage     string
+	}
+	if err := db.Unscoped().Model(&models.Application{}).
+		Select("DISTINCT company_id, stage").
+		Where("position = ''").
+		Scan(&columns).Error; err != nil {
+		return err
+	}
+
+	var total int
+	for _, col := range columns {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var ids []uint
+			if err := tx.Unscoped().Model(&models.Application{}).
+				Where("company_id = ? AND stage = ?", col.CompanyID, col.Stage).
+				Order(`position COLLATE "C" ASC, id ASC`).
+				Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for i, key := range rank.Spread(len(ids)) {
+				if err := tx.Unscoped().Model(&models.Application{}).
+					Where("id = ?", ids[i]).
+					UpdateColumn("position", key).Error; err != nil {
+					return err
+				}
+			}
+			total += len(ids)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("✅ Board positions assigned in %d columns (%d applications)", len(columns), total)
+	return nil
+}
@@ -0,0 +1,53 @@
+package seeders
+
+import (
+	"dvra-api/internal/app/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// CommentNotesSeeder migrates the legacy applications.notes into the comment
+// thread of each application
+type CommentNotesSeeder struct{}
+
+// Run executes the comment notes seeder
+func (s *CommentNotesSeeder) Run(db *gorm.DB) error {
+	return SeedCommentNotes(db)
+}
+
+// SeedCommentNotes copia las notas de cada postulación como primer comentario
+// de su hilo: autor sistema, source "notes" y la fecha de la postulación. El
+// campo queda de solo lectura (RN-APP-009). Es idempotente: omite las
+// postulaciones que ya tienen su comentario migrado, aunque se haya borrado.
+func SeedCommentNotes(db *gorm.DB) error {
+	var total int
+	var apps []models.Application
+	err := db.Unscoped().
+		Select("id, company_id, notes, created_at").
+		Where("TRIM(COALESCE(notes, '')) <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM comments c WHERE c.application_id = applications.id AND c.source = ?)", models.CommentSourceNotes).
+		FindInBatches(&apps, 500, func(tx *gorm.DB, batch int) error {
+			comments := make([]models.Comment, 0, len(apps))
+			for _, app := range apps {
+				appID := app.ID
+				comment := models.Comment{
+					CompanyID:     app.CompanyID,
+					ApplicationID: &appID,
+					Body:          app.Notes,
+					Source:        models.CommentSourceNotes,
+				}
+				comment.CreatedAt = app.CreatedAt
+				comment.UpdatedAt = app.CreatedAt
+				comments = append(comments, comment)
+			}
+			total += len(apps)
+			return db.Create(&comments).Error
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Application notes migrated to comments for %d applications", total)
+	return nil
+}
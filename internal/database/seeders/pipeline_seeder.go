@@ -0,0 +1,42 @@
+package seeders
+
+import (
+	"dvra-api/internal/app/models"
+	pipelinedomain "dvra-api/internal/modules/pipeline/domain"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// PipelineSeeder seeds the default pipeline of every company (RN-APP-001)
+type PipelineSeeder struct{}
+
+// Run executes the pipeline seeder
+func (s *PipelineSeeder) Run(db *gorm.DB) error {
+	return SeedPipelines(db)
+}
+
+// SeedPipelines crea el pipeline por defecto de las empresas que aún no lo
+// tienen. La API también lo crea bajo demanda; el seeder solo lo adelanta.
+func SeedPipelines(db *gorm.DB) error {
+	var companyIDs []uint
+	if err := db.Model(&models.Company{}).
+		Where("NOT EXISTS (SELECT 1 FROM pipelines WHERE pipelines.company_id = companies.id AND pipelines.job_id IS NULL AND pipelines.deleted_at IS NULL)").
+		Pluck("id", &companyIDs).Error; err != nil {
+		return err
+	}
+
+	for _, companyID := range companyIDs {
+		pipeline := models.Pipeline{
+			CompanyID: companyID,
+			Name:      pipelinedomain.DefaultPipelineName,
+			Stages:    pipelinedomain.DefaultStages(),
+		}
+		if err := db.Create(&pipeline).Error; err != nil {
+			return err
+		}
+	}
+
+	log.Println("✅ Pipelines seeded successfully")
+	return nil
+}
@@ -0,0 +1,47 @@
+package seeders
+
+import (
+	"dvra-api/internal/app/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// RetentionPolicySeeder seeds the platform-level retention policies (§6.7)
+type RetentionPolicySeeder struct{}
+
+// Run executes the retention policy seeder
+func (s *RetentionPolicySeeder) Run(db *gorm.DB) error {
+	return SeedRetentionPolicies(db)
+}
+
+// SeedRetentionPolicies crea las políticas de plataforma INACTIVAS: borrar o
+// anonimizar datos es opt-in, cada empresa (o el SuperAdmin) las activa
+// después de revisar el dry-run.
+func SeedRetentionPolicies(db *gorm.DB) error {
+	defaults := []models.RetentionPolicy{
+		{Rule: "rejected_applications", RetentionMonths: 12, Action: "anonymize"},
+		{Rule: "inactive_candidates", RetentionMonths: 24, Action: "anonymize"},
+		{Rule: "prospect_staffing_clients", RetentionMonths: 6, Action: "delete"},
+	}
+
+	for _, policy := range defaults {
+		var count int64
+		if err := db.Model(&models.RetentionPolicy{}).
+			Where("company_id IS NULL AND rule = ?", policy.Rule).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		policy.IsActive = false
+		if err := db.Create(&policy).Error; err != nil {
+			return err
+		}
+	}
+
+	log.Println("✅ Retention policies seeded successfully")
+	return nil
+}
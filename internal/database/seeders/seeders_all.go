@@ -9,10 +9,16 @@ type Seeder interface {
 }
 
 var AllSeeders = []Seeder{
-	&PlatformSettingsSeeder{}, // 0. Configuración global de la plataforma (primero)
-	&RoleSeeder{},             // 1. Primero roles del sistema
-	&PlanSeeder{},             // 2. Planes de suscripción
-	&SystemValueSeeder{},      // 3. Valores del sistema (catálogos)
-	&UserSeeder{},             // 4. Usuarios (Admin de empresa)
-	&CompanySeeder{},          // 5. Empresas y membership del admin
+	&PlatformSettingsSeeder{},    // 0. Configuración global de la plataforma (primero)
+	&RoleSeeder{},                // 1. Primero roles del sistema
+	&PlanSeeder{},                // 2. Planes de suscripción
+	&SystemValueSeeder{},         // 3. Valores del sistema (catálogos)
+	&UserSeeder{},                // 4. Usuarios (Admin de empresa)
+	&CompanySeeder{},             // 5. Empresas y membership del admin
+	&RetentionPolicySeeder{},     // 6. Políticas de retención de plataforma (inactivas)
+	&PipelineSeeder{},            // 7. Pipeline por defecto de cada empresa
+	&StageHistorySeeder{},        // 8. Historial de etapas de postulaciones previas (backfill)
+	&CommentNotesSeeder{},        // 9. Notas de postulaciones previas como primer comentario
+	&ApplicationPositionSeeder{}, // 10. Posición en el tablero de postulaciones previas
+	&SkillSeeder{},               // 11. Catálogo global de habilidades
 }
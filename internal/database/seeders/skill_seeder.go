@@ -0,0 +1,164 @@
+package seeders
+
+import (
+	"dvra-api/internal/app/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// SkillSeeder seeds the global skills catalog
+type SkillSeeder struct{}
+
+// Run executes the skill seeder
+func (s *SkillSeeder) Run(db *gorm.DB) error {
+	return SeedSkills(db)
+}
+
+// skill arma una habilidad global del catálogo.
+func skill(category, name string, aliases ...string) models.Skill {
+	return models.Skill{Name: name, Category: category, Aliases: aliases}
+}
+
+// SeedSkills crea las habilidades globales que falten (por nombre). Los
+// nombres coinciden con los que reconoce el análisis de CVs para que sus
+// habilidades pasen a la ficha del candidato; las existentes no se tocan,
+// así que los cambios de la plataforma sobre ellas se conservan.
+func SeedSkills(db *gorm.DB) error {
+	const (
+		lang = models.SkillCategoryLanguage
+		fe   = models.SkillCategoryFrontend
+		be   = models.SkillCategoryBackend
+		data = models.SkillCategoryData
+		ops  = models.SkillCategoryCloud
+		mob  = models.SkillCategoryMobile
+		qa   = models.SkillCategoryTesting
+		mgmt = models.SkillCategoryManagement
+		soft = models.SkillCategorySoft
+	)
+	defaults := []models.Skill{
+		skill(lang, "Go", "Golang"),
+		skill(lang, "Python"),
+		skill(lang, "Java"),
+		skill(lang, "JavaScript", "JS", "ECMAScript"),
+		skill(lang, "TypeScript", "TS"),
+		skill(lang, "C#", "CSharp"),
+		skill(lang, "C++", "CPP"),
+		skill(lang, "C"),
+		skill(lang, "PHP"),
+		skill(lang, "Ruby"),
+		skill(lang, "Rust"),
+		skill(lang, "Kotlin"),
+		skill(lang, "Swift"),
+		skill(lang, "Scala"),
+		skill(lang, "R"),
+		skill(lang, "Elixir"),
+		skill(lang, "Dart"),
+		skill(lang, "SQL"),
+		skill(lang, "Bash", "Shell scripting"),
+
+		skill(fe, "React", "React.js", "ReactJS"),
+		skill(fe, "Angular", "AngularJS"),
+		skill(fe, "Vue.js", "Vue", "VueJS"),
+		skill(fe, "Next.js", "NextJS"),
+		skill(fe, "Svelte"),
+		skill(fe, "HTML", "HTML5"),
+		skill(fe, "CSS", "CSS3"),
+		skill(fe, "Sass", "SCSS"),
+		skill(fe, "Tailwind CSS", "Tailwind", "TailwindCSS"),
+		skill(fe, "jQuery"),
+		skill(fe, "Redux"),
+
+		skill(be, "Node.js", "Node", "NodeJS"),
+		skill(be, "Express", "Express.js", "ExpressJS"),
+		skill(be, "Django"),
+		skill(be, "Flask"),
+		skill(be, "FastAPI"),
+		skill(be, "Spring", "Spring Boot", "Spring Framework"),
+		skill(be, ".NET", "dotnet", "ASP.NET"),
+		skill(be, "Laravel"),
+		skill(be, "Ruby on Rails", "Rails"),
+		skill(be, "GraphQL"),
+		skill(be, "REST", "REST API", "API REST", "RESTful"),
+		skill(be, "gRPC"),
+		skill(be, "Microservicios", "Microservices", "Microsserviços"),
+
+		skill(data, "PostgreSQL", "Postgres"),
+		skill(data, "MySQL"),
+		skill(data, "SQL Server", "MSSQL"),
+		skill(data, "Oracle"),
+		skill(data, "MongoDB", "Mongo"),
+		skill(data, "Redis"),
+		skill(data, "Elasticsearch"),
+		skill(data, "Kafka"),
+		skill(data, "RabbitMQ"),
+		skill(data, "Spark", "Apache Spark", "PySpark"),
+		skill(data, "Pandas"),
+		skill(data, "NumPy"),
+		skill(data, "TensorFlow"),
+		skill(data, "PyTorch"),
+		skill(data, "scikit-learn", "sklearn"),
+		skill(data, "Machine Learning", "Aprendizaje automático", "Aprendizado de máquina", "ML"),
+		skill(data, "Power BI", "PowerBI"),
+		skill(data, "Tableau"),
+		skill(data, "Excel"),
+
+		skill(ops, "AWS", "Amazon Web Services"),
+		skill(ops, "Azure"),
+		skill(ops, "Google Cloud", "GCP"),
+		skill(ops, "Docker"),
+		skill(ops, "Kubernetes", "K8s"),
+		skill(ops, "Terraform"),
+		skill(ops, "Ansible"),
+		skill(ops, "Jenkins"),
+		skill(ops, "GitHub Actions"),
+		skill(ops, "GitLab CI", "GitLab-CI"),
+		skill(ops, "CI/CD"),
+		skill(ops, "Linux"),
+		skill(ops, "Git"),
+		skill(ops, "Nginx"),
+
+		skill(mob, "Android"),
+		skill(mob, "iOS"),
+		skill(mob, "Flutter"),
+		skill(mob, "React Native"),
+
+		skill(qa, "Selenium"),
+		skill(qa, "Cypress"),
+		skill(qa, "Jest"),
+		skill(qa, "JUnit"),
+
+		skill(mgmt, "Figma"),
+		skill(mgmt, "Scrum"),
+		skill(mgmt, "Kanban"),
+		skill(mgmt, "Jira"),
+		skill(mgmt, "UX", "UX/UI", "UI/UX"),
+		skill(mgmt, "SAP"),
+		skill(mgmt, "Salesforce"),
+
+		skill(soft, "Comunicación", "Communication", "Comunicação"),
+		skill(soft, "Liderazgo", "Leadership", "Liderança"),
+		skill(soft, "Trabajo en equipo", "Teamwork", "Trabalho em equipe"),
+		skill(soft, "Resolución de problemas", "Problem solving", "Resolução de problemas"),
+
+		skill(models.SkillCategoryOther, "Inglés", "English", "Inglês"),
+	}
+
+	for _, s := range defaults {
+		var count int64
+		if err := db.Model(&models.Skill{}).
+			Where("company_id IS NULL AND LOWER(name) = LOWER(?)", s.Name).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := db.Create(&s).Error; err != nil {
+			return err
+		}
+	}
+
+	log.Println("✅ Skills catalog seeded successfully")
+	return nil
+}
@@ -0,0 +1,120 @@
+package seeders
+
+import (
+	"dvra-api/internal/app/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StageHistorySeeder backfills the stage history of applications created
+// before application_stage_events existed
+type StageHistorySeeder struct{}
+
+// Run executes the stage history seeder
+func (s *StageHistorySeeder) Run(db *gorm.DB) error {
+	return SeedStageHistory(db)
+}
+
+// SeedStageHistory reconstruye el historial mínimo de las postulaciones que no
+// tienen eventos: la entrada al pipeline en applied_at y, si ya no están en la
+// primera etapa, el paso a la actual (en hired_at / rejected_at, o updated_at).
+// Las etapas intermedias no se conocen; los eventos quedan backfilled y no
+// cuentan para el tiempo por etapa. Es idempotente.
+func SeedStageHistory(db *gorm.DB) error {
+	firstStages, err := firstActiveStages(db)
+	if err != nil {
+		return err
+	}
+
+	var total int
+	var apps []models.Application
+	err = db.Unscoped().
+		Where("NOT EXISTS (SELECT 1 FROM application_stage_events e WHERE e.application_id = applications.id)").
+		FindInBatches(&apps, 500, func(tx *gorm.DB, batch int) error {
+			events := backfillEvents(apps, firstStages)
+			total += len(apps)
+			return db.Create(&events).Error
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Stage history backfilled for %d applications", total)
+	return nil
+}
+
+// backfillEvents arma el historial mínimo de cada postulación: siempre la
+// entrada al pipeline (así una segunda corrida ya no la selecciona) y el paso
+// a la etapa actual si no es la primera.
+func backfillEvents(apps []models.Application, firstStages stageIndex) []models.ApplicationStageEvent {
+	events := make([]models.ApplicationStageEvent, 0, len(apps)*2)
+	for _, app := range apps {
+		first := firstStages.forJob(app.CompanyID, app.JobID)
+		if first == "" {
+			first = app.Stage
+		}
+		events = append(events, models.ApplicationStageEvent{
+			CompanyID: app.CompanyID, ApplicationID: app.ID,
+			ToStage: first, OccurredAt: app.AppliedAt, Backfilled: true,
+		})
+		if app.Stage != first {
+			events = append(events, models.ApplicationStageEvent{
+				CompanyID: app.CompanyID, ApplicationID: app.ID,
+				FromStage: first, ToStage: app.Stage, OccurredAt: reachedAt(app), Backfilled: true,
+			})
+		}
+	}
+	return events
+}
+
+// reachedAt estima cuándo la postulación llegó a su etapa actual.
+func reachedAt(app models.Application) time.Time {
+	switch {
+	case app.HiredAt != nil:
+		return *app.HiredAt
+	case app.RejectedAt != nil:
+		return *app.RejectedAt
+	case app.UpdatedAt.After(app.AppliedAt):
+		return app.UpdatedAt
+	default:
+		return app.AppliedAt
+	}
+}
+
+// stageIndex guarda la primera etapa activa de cada pipeline: por vacante y
+// por empresa (pipeline por defecto).
+type stageIndex struct {
+	byJob     map[uint]string
+	byCompany map[uint]string
+}
+
+func (i stageIndex) forJob(companyID, jobID uint) string {
+	if key, ok := i.byJob[jobID]; ok {
+		return key
+	}
+	return i.byCompany[companyID]
+}
+
+func firstActiveStages(db *gorm.DB) (stageIndex, error) {
+	index := stageIndex{byJob: map[uint]string{}, byCompany: map[uint]string{}}
+
+	var pipelines []models.Pipeline
+	if err := db.Preload("Stages").Find(&pipelines).Error; err != nil {
+		return index, err
+	}
+	for i := range pipelines {
+		p := &pipelines[i]
+		stage := p.StageOfType(models.StageTypeActive)
+		if stage == nil {
+			continue
+		}
+		if p.JobID != nil {
+			index.byJob[*p.JobID] = stage.Key
+		} else {
+			index.byCompany[p.CompanyID] = stage.Key
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,172 @@
+package seeders
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func backfillApp(id, companyID, jobID uint, stage string) models.Application {
+	app := models.Application{CompanyID: companyID, JobID: jobID, Stage: stage}
+	app.ID = id
+	return app
+}
+
+func TestBackfillEvents(t *testing.T) {
+	applied := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	hired := applied.Add(72 * time.Hour)
+	updated := applied.Add(24 * time.Hour)
+	index := stageIndex{byJob: map[uint]string{20: "sourced"}, byCompany: map[uint]string{1: "applied"}}
+
+	cases := []struct {
+		name  string
+		app   models.Application
+		want  []models.ApplicationStageEvent
+		setup func(*models.Application)
+	}{
+		{
+			name: "still in the first stage",
+			app:  backfillApp(1, 1, 10, "applied"),
+			want: []models.ApplicationStageEvent{{ToStage: "applied", OccurredAt: applied}},
+		},
+		{
+			name:  "hired: reaches the stage at hired_at",
+			app:   backfillApp(2, 1, 10, "hired"),
+			setup: func(a *models.Application) { a.HiredAt = &hired; a.UpdatedAt = updated },
+			want: []models.ApplicationStageEvent{
+				{ToStage: "applied", OccurredAt: applied},
+				{FromStage: "applied", ToStage: "hired", OccurredAt: hired},
+			},
+		},
+		{
+			name:  "active stage: reaches it at updated_at",
+			app:   backfillApp(3, 1, 10, "interview"),
+			setup: func(a *models.Application) { a.UpdatedAt = updated },
+			want: []models.ApplicationStageEvent{
+				{ToStage: "applied", OccurredAt: applied},
+				{FromStage: "applied", ToStage: "interview", OccurredAt: updated},
+			},
+		},
+		{
+			name: "job pipeline wins over the company default",
+			app:  backfillApp(4, 1, 20, "sourced"),
+			want: []models.ApplicationStageEvent{{ToStage: "sourced", OccurredAt: applied}},
+		},
+		{
+			name: "company without pipeline: enters at its current stage",
+			app:  backfillApp(5, 2, 30, "screening"),
+			want: []models.ApplicationStageEvent{{ToStage: "screening", OccurredAt: applied}},
+		},
+	}
+	for _, tc := range cases {
+		app := tc.app
+		app.AppliedAt = applied
+		if tc.setup != nil {
+			tc.setup(&app)
+		}
+
+		events := backfillEvents([]models.Application{app}, index)
+
+		if len(events) != len(tc.want) {
+			t.Fatalf("%s: events = %+v", tc.name, events)
+		}
+		for i, e := range events {
+			w := tc.want[i]
+			if e.ApplicationID != app.ID || e.CompanyID != app.CompanyID || !e.Backfilled ||
+				e.FromStage != w.FromStage || e.ToStage != w.ToStage || !e.OccurredAt.Equal(w.OccurredAt) {
+				t.Errorf("%s: event %d = %+v, want %+v", tc.name, i, e, w)
+			}
+		}
+	}
+}
+
+// TestSeedStageHistoryIsIdempotent corre el seeder dos veces contra
+// Postgres (TEST_DATABASE_DSN) en un esquema temporal dentro de una
+// transacción que se revierte.
+func TestSeedStageHistoryIsIdempotent(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		IgnoreRelationshipsWhenMigrating: true,
+		Logger:                           logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := db.Begin()
+	defer tx.Rollback()
+	for _, stmt := range []string{"CREATE SCHEMA stage_history_seeder_test", "SET LOCAL search_path TO stage_history_seeder_test"} {
+		if err := tx.Exec(stmt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.AutoMigrate(&models.Pipeline{}, &models.PipelineStage{}, &models.Application{}, &models.ApplicationStageEvent{}); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline := models.Pipeline{CompanyID: 1, Name: "Default", Stages: []models.PipelineStage{
+		{Key: "applied", Name: "Applied", Type: models.StageTypeActive, Position: 0},
+		{Key: "hired", Name: "Hired", Type: models.StageTypeHired, Position: 1},
+	}}
+	if err := tx.Create(&pipeline).Error; err != nil {
+		t.Fatal(err)
+	}
+	applied := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	hired := applied.Add(48 * time.Hour)
+	apps := []models.Application{
+		{CompanyID: 1, JobID: 10, CandidateID: 100, Stage: "applied", AppliedAt: applied},
+		{CompanyID: 1, JobID: 10, CandidateID: 101, Stage: "hired", AppliedAt: applied, HiredAt: &hired},
+		{CompanyID: 1, JobID: 10, CandidateID: 102, Stage: "hired", AppliedAt: applied},
+	}
+	if err := tx.Create(&apps).Error; err != nil {
+		t.Fatal(err)
+	}
+	// La tercera ya tiene historial: el seeder no la toca.
+	if err := tx.Create(&models.ApplicationStageEvent{CompanyID: 1, ApplicationID: apps[2].ID, ToStage: "applied", OccurredAt: applied}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	count := func() map[uint]int64 {
+		var rows []struct {
+			ApplicationID uint
+			N             int64
+		}
+		if err := tx.Model(&models.ApplicationStageEvent{}).Select("application_id, COUNT(*) AS n").Group("application_id").Scan(&rows).Error; err != nil {
+			t.Fatal(err)
+		}
+		counts := map[uint]int64{}
+		for _, r := range rows {
+			counts[r.ApplicationID] = r.N
+		}
+		return counts
+	}
+
+	if err := SeedStageHistory(tx); err != nil {
+		t.Fatal(err)
+	}
+	first := count()
+	want := map[uint]int64{apps[0].ID: 1, apps[1].ID: 2, apps[2].ID: 1}
+	for id, n := range want {
+		if first[id] != n {
+			t.Errorf("after first run application %d has %d events, want %d", id, first[id], n)
+		}
+	}
+
+	if err := SeedStageHistory(tx); err != nil {
+		t.Fatal(err)
+	}
+	second := count()
+	for id, n := range first {
+		if second[id] != n {
+			t.Errorf("second run changed application %d: %d → %d events", id, n, second[id])
+		}
+	}
+}
@@ -65,6 +65,26 @@ func SystemValueSeederFunc(db *gorm.DB) error {
 		{Category: "candidate_source", Value: "job_board", Label: "Bolsa de Trabajo", DisplayOrder: 4, IsActive: true},
 		{Category: "candidate_source", Value: "direct", Label: "Aplicación Directa", DisplayOrder: 5, IsActive: true},
 		{Category: "candidate_source", Value: "other", Label: "Otro", DisplayOrder: 6, IsActive: true},
+
+		// Rejection reasons (rejected_by_us): las empresas agregan los suyos
+		{Category: models.SystemValueCategoryRejectionReason, Value: "skills_gap", Label: "No cumple requisitos técnicos", DisplayOrder: 1, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "failed_technical_test", Label: "No aprobó la prueba técnica", DisplayOrder: 2, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "insufficient_experience", Label: "Experiencia insuficiente", DisplayOrder: 3, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "culture_fit", Label: "Fit cultural / comunicación", DisplayOrder: 4, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "salary_expectations", Label: "Expectativa salarial fuera de rango", DisplayOrder: 5, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "location_or_availability", Label: "Ubicación o disponibilidad", DisplayOrder: 6, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "stronger_candidate", Label: "Se eligió a otro candidato", DisplayOrder: 7, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "position_closed", Label: "La vacante se cerró", DisplayOrder: 8, IsActive: true},
+		{Category: models.SystemValueCategoryRejectionReason, Value: "other", Label: "Otro", DisplayOrder: 99, IsActive: true},
+
+		// Withdrawal reasons (candidate_withdrew)
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "accepted_other_offer", Label: "Aceptó otra oferta", DisplayOrder: 1, IsActive: true},
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "compensation", Label: "Compensación insuficiente", DisplayOrder: 2, IsActive: true},
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "process_too_long", Label: "El proceso fue demasiado largo", DisplayOrder: 3, IsActive: true},
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "role_mismatch", Label: "El rol no era lo esperado", DisplayOrder: 4, IsActive: true},
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "personal_reasons", Label: "Motivos personales", DisplayOrder: 5, IsActive: true},
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "no_response", Label: "Dejó de responder", DisplayOrder: 6, IsActive: true},
+		{Category: models.SystemValueCategoryWithdrawalReason, Value: "other", Label: "Otro", DisplayOrder: 99, IsActive: true},
 	}
 
 	for _, value := range systemValues {
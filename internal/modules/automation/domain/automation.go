@@ -0,0 +1,313 @@
+// Package domain define el centro del módulo automation: reglas por empresa
+// (disparador, condiciones y acciones), la evaluación de un evento contra
+// ellas y la validación de cada acción. No importa gin ni gorm.
+package domain
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"dvra-api/internal/app/models"
+)
+
+// Límites de una regla y de las cadenas de automatizaciones.
+const (
+	MaxConditions = 10
+	MaxActions    = 10
+	MaxDays       = 365
+	// MaxDepth corta las cadenas: una acción move_stage dispara
+	// stage_changed, que puede volver a mover la postulación. Los eventos
+	// originados más allá de esta profundidad se descartan.
+	MaxDepth = 3
+	// EventBatch es cuántos eventos pendientes se procesan por pasada.
+	EventBatch = 200
+)
+
+// SignatureHeader lleva la firma HMAC-SHA256 del cuerpo de un webhook.
+const SignatureHeader = "X-Dvra-Signature"
+
+// ErrInvalidRule envuelve los errores de validación de una regla.
+var ErrInvalidRule = errors.New("invalid automation rule")
+
+// Facts son los datos de un evento contra los que se evalúan las reglas.
+type Facts struct {
+	Trigger string
+	JobID   uint
+	Source  string
+	Stage   string // etapa actual
+	ToStage string // stage_changed: etapa destino
+	Rating  *int
+	RuleID  uint     // time_in_stage: el evento es solo para esta regla
+	Tags    []string // nombres de las etiquetas del candidato
+}
+
+// Matches reporta si la regla aplica al evento: mismo disparador, etapa
+// destino si la regla la acota y todas las condiciones.
+func Matches(rule *models.AutomationRule, facts Facts) bool {
+	if !rule.Active || rule.Trigger != facts.Trigger {
+		return false
+	}
+	switch rule.Trigger {
+	case models.AutomationTriggerStageChanged:
+		if rule.Stage != "" && rule.Stage != facts.ToStage {
+			return false
+		}
+	case models.AutomationTriggerTimeInStage:
+		if facts.RuleID != rule.ID || rule.Stage != facts.Stage {
+			return false
+		}
+	}
+	for _, condition := range rule.Conditions {
+		if !evaluate(condition, facts) {
+			return false
+		}
+	}
+	return true
+}
+
+func evaluate(condition models.AutomationCondition, facts Facts) bool {
+	switch condition.Field {
+	case models.AutomationFieldRating:
+		if facts.Rating == nil || len(condition.Values) == 0 {
+			return false
+		}
+		limit, err := strconv.Atoi(condition.Values[0])
+		if err != nil {
+			return false
+		}
+		if condition.Operator == models.AutomationOpGte {
+			return *facts.Rating >= limit
+		}
+		return *facts.Rating <= limit
+	case models.AutomationFieldJob:
+		return inList(condition, strconv.FormatUint(uint64(facts.JobID), 10))
+	case models.AutomationFieldSource:
+		return inList(condition, facts.Source)
+	case models.AutomationFieldStage:
+		return inList(condition, facts.Stage)
+	case models.AutomationFieldTag:
+		return anyInList(condition, facts.Tags)
+	}
+	return false
+}
+
+// anyInList evalúa un campo con varios valores: in exige que alguno esté en
+// la lista; not_in, que ninguno lo esté.
+func anyInList(condition models.AutomationCondition, values []string) bool {
+	in := condition
+	in.Operator = models.AutomationOpIn
+	found := false
+	for _, v := range values {
+		if inList(in, v) {
+			found = true
+			break
+		}
+	}
+	if condition.Operator == models.AutomationOpNotIn {
+		return !found
+	}
+	return found
+}
+
+// UsesField reporta si alguna regla tiene una condición sobre field (p. ej.
+// para cargar las etiquetas del candidato solo si hace falta).
+func UsesField(rules []models.AutomationRule, field string) bool {
+	for i := range rules {
+		for _, c := range rules[i].Conditions {
+			if c.Field == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func inList(condition models.AutomationCondition, value string) bool {
+	found := false
+	for _, v := range condition.Values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			found = true
+			break
+		}
+	}
+	if condition.Operator == models.AutomationOpNotIn {
+		return !found
+	}
+	return found
+}
+
+// ValidateRule revisa disparador, condiciones y acciones. Los errores
+// envuelven ErrInvalidRule.
+func ValidateRule(rule *models.AutomationRule) error {
+	switch rule.Trigger {
+	case models.AutomationTriggerApplicationCreated, models.AutomationTriggerRatingSet:
+		rule.Stage, rule.Days = "", 0
+	case models.AutomationTriggerStageChanged:
+		rule.Days = 0
+	case models.AutomationTriggerTimeInStage:
+		if rule.Stage == "" || rule.Days < 1 || rule.Days > MaxDays {
+			return invalid("time_in_stage requires a stage and days between 1 and %d", MaxDays)
+		}
+	default:
+		return invalid("unknown trigger '%s'", rule.Trigger)
+	}
+
+	if len(rule.Conditions) > MaxConditions {
+		return invalid("a rule admits at most %d conditions", MaxConditions)
+	}
+	for i, c := range rule.Conditions {
+		if err := validateCondition(c); err != nil {
+			return invalid("condition %d: %v", i+1, err)
+		}
+	}
+
+	if len(rule.Actions) == 0 || len(rule.Actions) > MaxActions {
+		return invalid("a rule needs between 1 and %d actions", MaxActions)
+	}
+	for i := range rule.Actions {
+		if err := validateAction(&rule.Actions[i]); err != nil {
+			return invalid("action %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+func validateCondition(c models.AutomationCondition) error {
+	if len(c.Values) == 0 {
+		return errors.New("values are required")
+	}
+	switch c.Field {
+	case models.AutomationFieldRating:
+		if c.Operator != models.AutomationOpGte && c.Operator != models.AutomationOpLte {
+			return errors.New("rating admits gte or lte")
+		}
+		if n, err := strconv.Atoi(c.Values[0]); err != nil || n < 1 || n > 5 {
+			return errors.New("rating must be between 1 and 5")
+		}
+	case models.AutomationFieldJob, models.AutomationFieldSource, models.AutomationFieldStage, models.AutomationFieldTag:
+		if c.Operator != models.AutomationOpIn && c.Operator != models.AutomationOpNotIn {
+			return fmt.Errorf("%s admits in or not_in", c.Field)
+		}
+		if c.Field == models.AutomationFieldJob {
+			for _, v := range c.Values {
+				if _, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32); err != nil {
+					return fmt.Errorf("'%s' is not a job ID", v)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unknown field '%s'", c.Field)
+	}
+	return nil
+}
+
+func validateAction(a *models.AutomationAction) error {
+	switch a.Type {
+	case models.AutomationActionSendEmail:
+		if strings.TrimSpace(a.Subject) == "" || strings.TrimSpace(a.Body) == "" {
+			return errors.New("send_email requires subject and body")
+		}
+		if _, _, err := RenderEmail(a.Subject, a.Body, SampleEmailVars()); err != nil {
+			return err
+		}
+	case models.AutomationActionAssignUser:
+		if a.UserID == 0 {
+			return errors.New("assign_user requires user_id")
+		}
+	case models.AutomationActionAddTag:
+		a.Tag = strings.TrimSpace(a.Tag)
+		if a.Tag == "" || len(a.Tag) > 50 {
+			return errors.New("add_tag requires a tag of up to 50 characters")
+		}
+	case models.AutomationActionMoveStage:
+		if a.Stage == "" {
+			return errors.New("move_stage requires stage")
+		}
+	case models.AutomationActionWebhook:
+		u, err := url.Parse(a.URL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("webhook requires an https url")
+		}
+	case models.AutomationActionAddToPool:
+		if a.PoolID == 0 {
+			return errors.New("add_to_pool requires pool_id")
+		}
+		if len(a.Note) > 2000 {
+			return errors.New("add_to_pool admits a note of up to 2000 characters")
+		}
+	default:
+		return fmt.Errorf("unknown action '%s'", a.Type)
+	}
+	return nil
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRule, fmt.Sprintf(format, args...))
+}
+
+// EmailVars son las variables de la acción send_email.
+type EmailVars struct {
+	Candidate struct {
+		FirstName string
+		LastName  string
+		FullName  string
+	}
+	Job struct {
+		Title string
+	}
+	Company struct {
+		Name string
+	}
+	Stage string
+}
+
+// SampleEmailVars sirve para validar las plantillas al guardar la regla.
+func SampleEmailVars() EmailVars {
+	var vars EmailVars
+	vars.Candidate.FirstName, vars.Candidate.LastName, vars.Candidate.FullName = "Ana", "Pérez", "Ana Pérez"
+	vars.Job.Title = "Backend Developer"
+	vars.Company.Name = "Acme"
+	vars.Stage = "screening"
+	return vars
+}
+
+// RenderEmail ejecuta el asunto y el cuerpo (text/template) con las variables.
+func RenderEmail(subject, body string, vars EmailVars) (string, string, error) {
+	renderedSubject, err := execute(subject, vars)
+	if err != nil {
+		return "", "", fmt.Errorf("subject: %w", err)
+	}
+	renderedBody, err := execute(body, vars)
+	if err != nil {
+		return "", "", fmt.Errorf("body: %w", err)
+	}
+	return strings.TrimSpace(renderedSubject), renderedBody, nil
+}
+
+func execute(text string, vars EmailVars) (string, error) {
+	tpl, err := template.New("email").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, vars); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Sign devuelve el valor del header de firma de un webhook:
+// "sha256=" + HMAC-SHA256 hex del cuerpo con el secreto de la acción.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func rule(trigger string, conditions ...models.AutomationCondition) *models.AutomationRule {
+	r := &models.AutomationRule{
+		Trigger:    trigger,
+		Active:     true,
+		Conditions: conditions,
+		Actions:    []models.AutomationAction{{Type: models.AutomationActionAddTag, Tag: "fast-track"}},
+	}
+	r.ID = 7
+	return r
+}
+
+func TestMatchesConditions(t *testing.T) {
+	four := 4
+	facts := Facts{Trigger: models.AutomationTriggerRatingSet, JobID: 12, Source: "referral", Stage: "screening", Rating: &four}
+
+	cases := []struct {
+		name string
+		rule *models.AutomationRule
+		want bool
+	}{
+		{"sin condiciones", rule(models.AutomationTriggerRatingSet), true},
+		{"otro disparador", rule(models.AutomationTriggerApplicationCreated), false},
+		{"rating gte", rule(models.AutomationTriggerRatingSet, models.AutomationCondition{Field: "rating", Operator: "gte", Values: []string{"4"}}), true},
+		{"rating lte", rule(models.AutomationTriggerRatingSet, models.AutomationCondition{Field: "rating", Operator: "lte", Values: []string{"2"}}), false},
+		{"vacante", rule(models.AutomationTriggerRatingSet, models.AutomationCondition{Field: "job", Operator: "in", Values: []string{"3", "12"}}), true},
+		{"source not_in", rule(models.AutomationTriggerRatingSet, models.AutomationCondition{Field: "source", Operator: "not_in", Values: []string{"Referral"}}), false},
+		{"todas deben cumplirse", rule(models.AutomationTriggerRatingSet,
+			models.AutomationCondition{Field: "stage", Operator: "in", Values: []string{"screening"}},
+			models.AutomationCondition{Field: "job", Operator: "in", Values: []string{"99"}}), false},
+	}
+	for _, c := range cases {
+		if got := Matches(c.rule, facts); got != c.want {
+			t.Errorf("%s: Matches = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	inactive := rule(models.AutomationTriggerRatingSet)
+	inactive.Active = false
+	if Matches(inactive, facts) {
+		t.Error("inactive rule must not match")
+	}
+}
+
+func TestMatchesTags(t *testing.T) {
+	facts := Facts{Trigger: models.AutomationTriggerApplicationCreated, Tags: []string{"Java", "silver-medalist"}}
+	cases := []struct {
+		operator string
+		values   []string
+		want     bool
+	}{
+		{"in", []string{"java"}, true},
+		{"in", []string{"go", "rust"}, false},
+		{"not_in", []string{"Silver-Medalist"}, false},
+		{"not_in", []string{"go"}, true},
+	}
+	for _, c := range cases {
+		r := rule(models.AutomationTriggerApplicationCreated, models.AutomationCondition{Field: "tag", Operator: c.operator, Values: c.values})
+		if got := Matches(r, facts); got != c.want {
+			t.Errorf("tag %s %v: Matches = %v, want %v", c.operator, c.values, got, c.want)
+		}
+	}
+	untagged := rule(models.AutomationTriggerApplicationCreated, models.AutomationCondition{Field: "tag", Operator: "not_in", Values: []string{"java"}})
+	if !Matches(untagged, Facts{Trigger: models.AutomationTriggerApplicationCreated}) {
+		t.Error("not_in must match a candidate without tags")
+	}
+}
+
+func TestMatchesStageFilters(t *testing.T) {
+	moved := rule(models.AutomationTriggerStageChanged)
+	moved.Stage = "offer"
+	if Matches(moved, Facts{Trigger: models.AutomationTriggerStageChanged, ToStage: "interview"}) {
+		t.Error("stage_changed must respect the target stage")
+	}
+	if !Matches(moved, Facts{Trigger: models.AutomationTriggerStageChanged, ToStage: "offer"}) {
+		t.Error("stage_changed to the rule stage must match")
+	}
+
+	stale := rule(models.AutomationTriggerTimeInStage)
+	stale.Stage, stale.Days = "screening", 5
+	if Matches(stale, Facts{Trigger: models.AutomationTriggerTimeInStage, Stage: "screening", RuleID: 8}) {
+		t.Error("time_in_stage events belong to a single rule")
+	}
+	if Matches(stale, Facts{Trigger: models.AutomationTriggerTimeInStage, Stage: "interview", RuleID: 7}) {
+		t.Error("time_in_stage must not run after the application left the stage")
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	valid := rule(models.AutomationTriggerApplicationCreated)
+	valid.Actions = append(valid.Actions,
+		models.AutomationAction{Type: models.AutomationActionSendEmail, Subject: "Hola {{.Candidate.FirstName}}", Body: "Gracias por postularte a {{.Job.Title}}."},
+		models.AutomationAction{Type: models.AutomationActionWebhook, URL: "https://hooks.slack.com/services/x"},
+		models.AutomationAction{Type: models.AutomationActionAddToPool, PoolID: 3, Note: "silver medalist"},
+	)
+	if err := ValidateRule(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalidRules := []*models.AutomationRule{
+		rule("unknown"),
+		rule(models.AutomationTriggerTimeInStage), // sin etapa ni días
+		rule(models.AutomationTriggerRatingSet, models.AutomationCondition{Field: "rating", Operator: "in", Values: []string{"4"}}),
+		rule(models.AutomationTriggerRatingSet, models.AutomationCondition{Field: "custom", Operator: "in", Values: []string{"x"}}),
+	}
+	webhook := rule(models.AutomationTriggerRatingSet)
+	webhook.Actions = []models.AutomationAction{{Type: models.AutomationActionWebhook, URL: "http://example.com"}}
+	email := rule(models.AutomationTriggerRatingSet)
+	email.Actions = []models.AutomationAction{{Type: models.AutomationActionSendEmail, Subject: "x", Body: "{{.Candidate.Email}}"}}
+	pool := rule(models.AutomationTriggerRatingSet)
+	pool.Actions = []models.AutomationAction{{Type: models.AutomationActionAddToPool}}
+	invalidRules = append(invalidRules, webhook, email, pool)
+
+	for i, r := range invalidRules {
+		if err := ValidateRule(r); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("rule %d: expected ErrInvalidRule, got %v", i, err)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	want := "sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13"
+	if got := Sign("secret", []byte("{}")); got != want {
+		t.Fatalf("Sign = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// RunFilter acota el registro de ejecuciones.
+type RunFilter struct {
+	RuleID        uint
+	ApplicationID uint
+	Status        string
+	Limit         int
+}
+
+// StaleEntry es una postulación que lleva en la etapa de una regla
+// time_in_stage más días de los indicados. StageEventID identifica la entrada
+// a la etapa: cada entrada dispara la regla una sola vez.
+type StaleEntry struct {
+	ApplicationID uint
+	StageEventID  uint
+}
+
+// StaleFilter acota el registro del barrido de estancadas.
+type StaleFilter struct {
+	Stage         string
+	Action        string
+	ApplicationID uint
+	Limit         int
+}
+
+// StageMover mueve una postulación de etapa a través del servicio de
+// applications (grafo de transiciones, motivos de rechazo, historial). Lo
+// implementa un adaptador del composition root.
+type StageMover interface {
+	Move(applicationID uint, stage, rejectionType, rejectionReason string) error
+	// Reject mueve la postulación a la etapa rejected de su pipeline como
+	// rechazo del sistema: si el grafo no lo permite desde la etapa actual,
+	// queda como override. reason queda en el historial.
+	Reject(applicationID uint, reason, rejectionType, rejectionReason string) error
+}
+
+// StageCatalog da las etapas que usa la empresa (módulo pipeline).
+type StageCatalog interface {
+	CompanyStages(companyID uint) ([]models.PipelineStage, error)
+}
+
+// ReasonCatalog da los motivos de rechazo vigentes de la empresa por
+// categoría (globales + propios).
+type ReasonCatalog interface {
+	GetByCategory(category string, companyID *uint) ([]models.SystemValue, error)
+}
+
+// Tagger etiqueta candidatos (repositorio de tags de recruitment).
+type Tagger interface {
+	AddTag(companyID, candidateID uint, name string) error
+	// CandidateTags devuelve los nombres de las etiquetas del candidato.
+	CandidateTags(candidateID uint) ([]string, error)
+}
+
+// ErrNotContactable lo devuelve Pooler cuando el candidato no consintió ser
+// contactado por futuras vacantes: la acción add_to_pool se omite.
+var ErrNotContactable = errors.New("candidate is not contactable")
+
+// Pooler agrega candidatos a talent pools (módulo talentpool).
+type Pooler interface {
+	// PoolExists reporta si el pool existe en la empresa.
+	PoolExists(companyID, poolID uint) (bool, error)
+	// AddToPool agrega al candidato sin autor; estar ya en el pool no es
+	// error. Devuelve ErrNotContactable si no consintió.
+	AddToPool(companyID, poolID, candidateID, applicationID uint, note string) error
+}
+
+// Mailer envía correos (platform/mail, vía el composition root).
+type Mailer interface {
+	SendEmail(to []string, subject, body string) error
+}
+
+// Notifier entrega notificaciones in-app (módulo notification).
+type Notifier interface {
+	Notify(notifications []models.Notification) error
+}
+
+// AutomationRepository es el puerto de salida hacia la persistencia. Los Get
+// devuelven nil, nil si el registro no existe.
+type AutomationRepository interface {
+	ListRules(companyID uint) ([]models.AutomationRule, error)
+	GetRule(id uint) (*models.AutomationRule, error)
+	CreateRule(rule *models.AutomationRule) error
+	SaveRule(rule *models.AutomationRule) error
+	DeleteRule(id uint) error
+	// ActiveRules devuelve las reglas activas de la empresa para el disparador.
+	ActiveRules(companyID uint, trigger string) ([]models.AutomationRule, error)
+	// TimeInStageRules devuelve las reglas time_in_stage activas de todas las
+	// empresas.
+	TimeInStageRules() ([]models.AutomationRule, error)
+	// StaleEntries devuelve las postulaciones activas que siguen en la etapa
+	// de la regla desde hace más de sus días y aún no tienen evento para esa
+	// entrada a la etapa.
+	StaleEntries(rule *models.AutomationRule) ([]StaleEntry, error)
+
+	CreateEvents(events []models.AutomationEvent) error
+	// PendingEvents devuelve los eventos sin procesar, los más antiguos primero.
+	PendingEvents(limit int) ([]models.AutomationEvent, error)
+	MarkProcessed(id uint) error
+
+	// Application devuelve la postulación con candidato, vacante y empresa.
+	Application(id uint) (*models.Application, error)
+	SetAssignee(applicationID, userID uint) error
+	// IsMember reporta si el usuario tiene membresía activa en la empresa.
+	IsMember(companyID, userID uint) (bool, error)
+
+	CreateRun(run *models.AutomationRun) error
+	ListRuns(companyID uint, filter RunFilter) ([]models.AutomationRun, error)
+}
+
+// StaleRepository es el puerto de salida del barrido de postulaciones
+// estancadas. GetPolicy devuelve nil, nil si no existe.
+type StaleRepository interface {
+	ListPolicies(companyID uint) ([]models.StalePolicy, error)
+	GetPolicy(companyID uint, stage string) (*models.StalePolicy, error)
+	SavePolicy(policy *models.StalePolicy) error
+	DeletePolicy(id uint) error
+	// ActivePolicies devuelve las políticas activas de todas las empresas.
+	ActivePolicies() ([]models.StalePolicy, error)
+	// Candidates devuelve las postulaciones de la etapa de la política sin
+	// actividad desde before que aún no recibieron la fase en su entrada
+	// actual a la etapa (una acción fallida no cuenta: se reintenta). Para
+	// el rechazo, si reminded no es cero, exige un recordatorio no fallido
+	// anterior a reminded. limit 0 = sin límite.
+	Candidates(policy *models.StalePolicy, action string, before, reminded time.Time, limit int) ([]StaleCandidate, error)
+	// SaveAction registra la acción; si la fase ya tenía un intento fallido
+	// en esa entrada a la etapa, lo reemplaza.
+	SaveAction(action *models.StaleAction) error
+	ListActions(companyID uint, filter StaleFilter) ([]models.StaleAction, error)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// StaleSampleSize limita las postulaciones que devuelve el dry-run por fase.
+const StaleSampleSize = 50
+
+// SettledStaleStatuses son los estados que dan por aplicada una fase en una
+// entrada a la etapa. Una acción fallida no está entre ellos: el barrido la
+// reintenta en la pasada siguiente.
+var SettledStaleStatuses = []string{models.AutomationActionOK, models.AutomationActionSkipped}
+
+// ErrInvalidStalePolicy envuelve los errores de validación de una política
+// de estancamiento.
+var ErrInvalidStalePolicy = errors.New("invalid stale policy")
+
+// StaleCandidate es una postulación que cumple el umbral de una fase del
+// barrido y aún no la recibió en su entrada actual a la etapa.
+type StaleCandidate struct {
+	ApplicationID  uint
+	StageEventID   uint
+	CandidateName  string
+	JobTitle       string
+	LastActivityAt time.Time
+}
+
+// ValidateStalePolicy revisa los umbrales, el motivo de rechazo y la
+// plantilla del correo al candidato. Normaliza los textos.
+func ValidateStalePolicy(p *models.StalePolicy) error {
+	p.Stage = strings.TrimSpace(p.Stage)
+	p.RejectionReason = strings.TrimSpace(p.RejectionReason)
+	if p.Stage == "" {
+		return staleInvalid("stage is required")
+	}
+	if p.RemindAfterDays < 0 || p.RemindAfterDays > MaxDays || p.RejectAfterDays < 0 || p.RejectAfterDays > MaxDays {
+		return staleInvalid("days must be between 0 and %d", MaxDays)
+	}
+	if p.RemindAfterDays == 0 && p.RejectAfterDays == 0 {
+		return staleInvalid("remind_after_days or reject_after_days is required")
+	}
+
+	if p.RejectAfterDays == 0 {
+		if p.NotifyCandidate {
+			return staleInvalid("notify_candidate requires reject_after_days")
+		}
+		p.RejectionType, p.RejectionReason = "", ""
+		return nil
+	}
+	if p.RemindAfterDays > 0 && p.RejectAfterDays <= p.RemindAfterDays {
+		return staleInvalid("reject_after_days must be greater than remind_after_days")
+	}
+	if p.RejectionType == "" {
+		p.RejectionType = models.RejectionTypeRejected
+	}
+	if models.RejectionCategory(p.RejectionType) == "" {
+		return staleInvalid("unknown rejection_type '%s'", p.RejectionType)
+	}
+	if p.RejectionReason == "" {
+		return staleInvalid("rejection_reason is required")
+	}
+	if p.NotifyCandidate {
+		if strings.TrimSpace(p.EmailSubject) == "" || strings.TrimSpace(p.EmailBody) == "" {
+			return staleInvalid("notify_candidate requires email_subject and email_body")
+		}
+		if _, _, err := RenderEmail(p.EmailSubject, p.EmailBody, SampleEmailVars()); err != nil {
+			return staleInvalid("%v", err)
+		}
+	}
+	return nil
+}
+
+// ReminderGrace es lo mínimo que debe pasar entre el recordatorio y el
+// rechazo: el recruiter tiene ese margen para actuar. 0 si la política no
+// avisa antes de rechazar.
+func ReminderGrace(p *models.StalePolicy) time.Duration {
+	if p.RemindAfterDays == 0 {
+		return 0
+	}
+	return time.Duration(p.RejectAfterDays-p.RemindAfterDays) * 24 * time.Hour
+}
+
+// IdleDays son los días completos sin actividad hasta now.
+func IdleDays(lastActivity, now time.Time) int {
+	return int(now.Sub(lastActivity) / (24 * time.Hour))
+}
+
+func staleInvalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidStalePolicy, fmt.Sprintf(format, args...))
+}
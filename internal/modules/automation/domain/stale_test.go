@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestValidateStalePolicy(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy models.StalePolicy
+		ok     bool
+	}{
+		{"solo recordatorio", models.StalePolicy{Stage: "screening", RemindAfterDays: 14}, true},
+		{"recordatorio y rechazo", models.StalePolicy{Stage: "screening", RemindAfterDays: 20, RejectAfterDays: 30, RejectionReason: "no_response"}, true},
+		{"sin fases", models.StalePolicy{Stage: "screening"}, false},
+		{"sin etapa", models.StalePolicy{RemindAfterDays: 7}, false},
+		{"rechazo antes del aviso", models.StalePolicy{Stage: "screening", RemindAfterDays: 30, RejectAfterDays: 30, RejectionReason: "no_response"}, false},
+		{"rechazo sin motivo", models.StalePolicy{Stage: "screening", RejectAfterDays: 30}, false},
+		{"tipo desconocido", models.StalePolicy{Stage: "screening", RejectAfterDays: 30, RejectionType: "ghosted", RejectionReason: "x"}, false},
+		{"correo sin rechazo", models.StalePolicy{Stage: "screening", RemindAfterDays: 7, NotifyCandidate: true, EmailSubject: "Hola", EmailBody: "Hola"}, false},
+		{"correo que no renderiza", models.StalePolicy{Stage: "screening", RejectAfterDays: 30, RejectionReason: "no_response", NotifyCandidate: true, EmailSubject: "Hola", EmailBody: "{{ .Candidate.Age }}"}, false},
+		{"correo válido", models.StalePolicy{Stage: "screening", RejectAfterDays: 30, RejectionReason: "no_response", NotifyCandidate: true, EmailSubject: "{{ .Job.Title }}", EmailBody: "Hola {{ .Candidate.FirstName }}"}, true},
+	}
+	for _, c := range cases {
+		policy := c.policy
+		err := ValidateStalePolicy(&policy)
+		if (err == nil) != c.ok {
+			t.Errorf("%s: err = %v, ok esperado %v", c.name, err, c.ok)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidStalePolicy) {
+			t.Errorf("%s: el error no envuelve ErrInvalidStalePolicy: %v", c.name, err)
+		}
+	}
+}
+
+func TestValidateStalePolicyDefaultsRejectionType(t *testing.T) {
+	policy := models.StalePolicy{Stage: " screening ", RejectAfterDays: 30, RejectionReason: " no_response "}
+	if err := ValidateStalePolicy(&policy); err != nil {
+		t.Fatal(err)
+	}
+	if policy.Stage != "screening" || policy.RejectionReason != "no_response" || policy.RejectionType != models.RejectionTypeRejected {
+		t.Errorf("política normalizada = %+v", policy)
+	}
+}
+
+func TestReminderGraceAndIdleDays(t *testing.T) {
+	if got := ReminderGrace(&models.StalePolicy{RemindAfterDays: 20, RejectAfterDays: 30}); got != 10*24*time.Hour {
+		t.Errorf("ReminderGrace = %v", got)
+	}
+	if got := ReminderGrace(&models.StalePolicy{RejectAfterDays: 30}); got != 0 {
+		t.Errorf("ReminderGrace sin recordatorio = %v", got)
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	if got := IdleDays(now.Add(-(72*time.Hour - time.Minute)), now); got != 2 {
+		t.Errorf("IdleDays = %d, want 2", got)
+	}
+}
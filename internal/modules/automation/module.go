@@ -0,0 +1,58 @@
+// Package automation es el punto de ensamblaje del motor de automatizaciones
+// del pipeline: reglas por empresa que reaccionan a eventos de las
+// postulaciones. Nadie importa este paquete salvo el composition root.
+package automation
+
+import (
+	"time"
+
+	"dvra-api/internal/modules/automation/domain"
+	"dvra-api/internal/modules/automation/repository"
+	"dvra-api/internal/modules/automation/service"
+	"dvra-api/internal/modules/automation/transport"
+	"dvra-api/internal/platform/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Intervalos de las tareas del módulo.
+const (
+	processInterval = 30 * time.Second // eventos encolados → reglas
+	scanInterval    = time.Hour        // barrido de time_in_stage
+	sweepInterval   = time.Hour        // recordatorios y rechazos de estancadas
+)
+
+// Module agrupa las dependencias ya cableadas del módulo automation.
+type Module struct {
+	// Service se expone porque applications y la career page le avisan de
+	// los eventos de las postulaciones (Fire).
+	Service *service.AutomationService
+
+	stale *service.StaleService
+}
+
+// New construye el módulo. mover y tagger actúan sobre recruitment, pooler
+// sobre los talent pools, mailer envía los correos y notifier avisa
+// asignaciones y recordatorios; stages y reasons validan las políticas de
+// estancadas. Todos son adaptadores o servicios que inyecta el composition
+// root.
+func New(db *gorm.DB, mover domain.StageMover, tagger domain.Tagger, pooler domain.Pooler, mailer domain.Mailer, notifier domain.Notifier, stages domain.StageCatalog, reasons domain.ReasonCatalog) *Module {
+	repo := repository.NewAutomationRepository(db)
+	return &Module{
+		Service: service.NewAutomationService(repo, mover, tagger, pooler, mailer, notifier),
+		stale:   service.NewStaleService(repository.NewStaleRepository(db), repo, mover, mailer, notifier, stages, reasons),
+	}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service, m.stale)
+}
+
+// RegisterJobs registra las tareas periódicas del módulo.
+func (m *Module) RegisterJobs(s *scheduler.Scheduler) {
+	s.Add(scheduler.Job{Name: "automation.process", Interval: processInterval, Run: m.Service.ProcessEvents})
+	s.Add(scheduler.Job{Name: "automation.time_in_stage", Interval: scanInterval, Run: m.Service.ScanTimeInStage})
+	s.Add(scheduler.Job{Name: "automation.stale_sweep", Interval: sweepInterval, Run: m.stale.Sweep})
+}
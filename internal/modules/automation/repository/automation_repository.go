@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/automation/domain"
+
+	"gorm.io/gorm"
+)
+
+// staleBatch acota cuántas postulaciones marca el barrido por regla y pasada.
+const staleBatch = 500
+
+type automationRepository struct {
+	db *gorm.DB
+}
+
+// NewAutomationRepository devuelve la implementación del puerto.
+func NewAutomationRepository(db *gorm.DB) domain.AutomationRepository {
+	return &automationRepository{db: db}
+}
+
+func (r *automationRepository) ListRules(companyID uint) ([]models.AutomationRule, error) {
+	var rules []models.AutomationRule
+	if err := r.db.Where("company_id = ?", companyID).Order("name ASC, id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *automationRepository) GetRule(id uint) (*models.AutomationRule, error) {
+	var rule models.AutomationRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *automationRepository) CreateRule(rule *models.AutomationRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *automationRepository) SaveRule(rule *models.AutomationRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *automationRepository) DeleteRule(id uint) error {
+	return r.db.Delete(&models.AutomationRule{}, id).Error
+}
+
+func (r *automationRepository) ActiveRules(companyID uint, trigger string) ([]models.AutomationRule, error) {
+	var rules []models.AutomationRule
+	if err := r.db.Where("company_id = ? AND trigger = ? AND active = ?", companyID, trigger, true).
+		Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *automationRepository) TimeInStageRules() ([]models.AutomationRule, error) {
+	var rules []models.AutomationRule
+	if err := r.db.Where("trigger = ? AND active = ?", models.AutomationTriggerTimeInStage, true).
+		Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *automationRepository) StaleEntries(rule *models.AutomationRule) ([]domain.StaleEntry, error) {
+	cutoff := time.Now().AddDate(0, 0, -rule.Days)
+	var entries []domain.StaleEntry
+	// La entrada vigente a la etapa es el último evento de la postulación.
+	err := r.db.Raw(`
+		SELECT a.id AS application_id, se.id AS stage_event_id
+		FROM applications a
+		JOIN LATERAL (
+			SELECT e.id, e.to_stage, e.occurred_at FROM application_stage_events e
+			WHERE e.application_id = a.id AND e.deleted_at IS NULL
+			ORDER BY e.occurred_at DESC, e.id DESC LIMIT 1
+		) se ON se.to_stage = a.stage
+		WHERE a.company_id = ? AND a.stage = ? AND a.deleted_at IS NULL AND se.occurred_at < ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM automation_events ae
+			WHERE ae.rule_id = ? AND ae.stage_event_id = se.id AND ae.deleted_at IS NULL
+		  )
+		ORDER BY a.id
+		LIMIT ?`, rule.CompanyID, rule.Stage, cutoff, rule.ID, staleBatch).
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *automationRepository) CreateEvents(events []models.AutomationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Create(&events).Error
+}
+
+func (r *automationRepository) PendingEvents(limit int) ([]models.AutomationEvent, error) {
+	var events []models.AutomationEvent
+	if err := r.db.Where("processed_at IS NULL").Order("id ASC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *automationRepository) MarkProcessed(id uint) error {
+	return r.db.Model(&models.AutomationEvent{}).Where("id = ?", id).Update("processed_at", time.Now()).Error
+}
+
+func (r *automationRepository) Application(id uint) (*models.Application, error) {
+	var app models.Application
+	if err := r.db.Preload("Company").Preload("Candidate").Preload("Job").First(&app, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &app, nil
+}
+
+func (r *automationRepository) SetAssignee(applicationID, userID uint) error {
+	return r.db.Model(&models.Application{}).Where("id = ?", applicationID).Update("assignee_id", userID).Error
+}
+
+func (r *automationRepository) IsMember(companyID, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Membership{}).
+		Where("company_id = ? AND user_id = ? AND status = ?", companyID, userID, "active").
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *automationRepository) CreateRun(run *models.AutomationRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *automationRepository) ListRuns(companyID uint, filter domain.RunFilter) ([]models.AutomationRun, error) {
+	query := r.db.Where("company_id = ?", companyID)
+	if filter.RuleID != 0 {
+		query = query.Where("rule_id = ?", filter.RuleID)
+	}
+	if filter.ApplicationID != 0 {
+		query = query.Where("application_id = ?", filter.ApplicationID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var runs []models.AutomationRun
+	if err := query.Order("started_at DESC, id DESC").Limit(filter.Limit).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/automation/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type staleRepository struct {
+	db *gorm.DB
+}
+
+// NewStaleRepository devuelve la implementación del puerto.
+func NewStaleRepository(db *gorm.DB) domain.StaleRepository {
+	return &staleRepository{db: db}
+}
+
+func (r *staleRepository) ListPolicies(companyID uint) ([]models.StalePolicy, error) {
+	var policies []models.StalePolicy
+	if err := r.db.Where("company_id = ?", companyID).Order("stage ASC").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *staleRepository) GetPolicy(companyID uint, stage string) (*models.StalePolicy, error) {
+	var policy models.StalePolicy
+	if err := r.db.Where("company_id = ? AND stage = ?", companyID, stage).First(&policy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *staleRepository) SavePolicy(policy *models.StalePolicy) error {
+	return r.db.Save(policy).Error
+}
+
+func (r *staleRepository) DeletePolicy(id uint) error {
+	return r.db.Delete(&models.StalePolicy{}, id).Error
+}
+
+func (r *staleRepository) ActivePolicies() ([]models.StalePolicy, error) {
+	var policies []models.StalePolicy
+	if err := r.db.Where("active = ?", true).Order("company_id ASC, stage ASC").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *staleRepository) Candidates(policy *models.StalePolicy, action string, before, reminded time.Time, limit int) ([]domain.StaleCandidate, error) {
+	query, args := candidatesQuery(policy, action, before, reminded, limit)
+	var candidates []domain.StaleCandidate
+	if err := r.db.Raw(query, args...).Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// candidatesQuery arma la consulta de Candidates.
+func candidatesQuery(policy *models.StalePolicy, action string, before, reminded time.Time, limit int) (string, []interface{}) {
+	// La entrada vigente a la etapa es el último evento hacia ella; sin
+	// historial, cuenta desde la postulación. Cualquier cambio de la
+	// postulación (nota, calificación, asignación) también es actividad.
+	query := `
+		SELECT a.id AS application_id, COALESCE(se.id, 0) AS stage_event_id,
+			TRIM(CONCAT_WS(' ', c.first_name, c.last_name)) AS candidate_name, j.title AS job_title,
+			GREATEST(COALESCE(se.occurred_at, a.applied_at), a.updated_at) AS last_activity_at
+		FROM applications a
+		JOIN candidates c ON c.id = a.candidate_id
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN LATERAL (
+			SELECT e.id, e.occurred_at FROM application_stage_events e
+			WHERE e.application_id = a.id AND e.to_stage = a.stage AND e.deleted_at IS NULL
+			ORDER BY e.occurred_at DESC, e.id DESC LIMIT 1
+		) se ON true
+		WHERE a.company_id = ? AND a.stage = ? AND a.deleted_at IS NULL
+		  AND GREATEST(COALESCE(se.occurred_at, a.applied_at), a.updated_at) < ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM stale_actions sa
+			WHERE sa.application_id = a.id AND sa.stage_event_id = COALESCE(se.id, 0) AND sa.action = ?
+			  AND sa.status IN ? AND sa.deleted_at IS NULL
+		  )`
+	args := []interface{}{policy.CompanyID, policy.Stage, before, action, domain.SettledStaleStatuses}
+	if !reminded.IsZero() {
+		query += `
+		  AND EXISTS (
+			SELECT 1 FROM stale_actions sa
+			WHERE sa.application_id = a.id AND sa.stage_event_id = COALESCE(se.id, 0) AND sa.action = ?
+			  AND sa.status IN ? AND sa.created_at < ? AND sa.deleted_at IS NULL
+		  )`
+		args = append(args, models.StaleActionReminder, domain.SettledStaleStatuses, reminded)
+	}
+	query += `
+		ORDER BY last_activity_at ASC, a.id ASC`
+	if limit > 0 {
+		query += `
+		LIMIT ?`
+		args = append(args, limit)
+	}
+	return query, args
+}
+
+func (r *staleRepository) SaveAction(action *models.StaleAction) error {
+	// La entrada es única por (postulación, evento, fase): el reintento de
+	// una acción fallida pisa el registro, con la fecha del nuevo intento
+	// (el margen antes del rechazo cuenta desde el recordatorio que sí salió).
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "application_id"}, {Name: "stage_event_id"}, {Name: "action"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"policy_id", "stage", "idle_days", "recipient_id", "status", "detail",
+			"created_at", "updated_at", "deleted_at",
+		}),
+	}).Create(action).Error
+}
+
+func (r *staleRepository) ListActions(companyID uint, filter domain.StaleFilter) ([]models.StaleAction, error) {
+	query := r.db.Where("company_id = ?", companyID)
+	if filter.Stage != "" {
+		query = query.Where("stage = ?", filter.Stage)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ApplicationID != 0 {
+		query = query.Where("application_id = ?", filter.ApplicationID)
+	}
+	var actions []models.StaleAction
+	if err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&actions).Error; err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
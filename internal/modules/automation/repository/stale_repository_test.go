@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlLog guarda las consultas que arma GORM (DryRun: sin base).
+type sqlLog struct {
+	logger.Interface
+	queries []string
+}
+
+func (l *sqlLog) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	l.queries = append(l.queries, sql)
+}
+
+func dryRun(t *testing.T) (*gorm.DB, *sqlLog) {
+	t.Helper()
+	log := &sqlLog{Interface: logger.Discard}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true, Logger: log})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, log
+}
+
+// Una acción fallida no cierra la fase (ni cuenta como recordatorio previo
+// al rechazo) y su reintento pisa el registro de la misma entrada.
+func TestStaleActionsRetryFailed(t *testing.T) {
+	db, log := dryRun(t)
+	repo := NewStaleRepository(db)
+	policy := &models.StalePolicy{CompanyID: 1, Stage: "screening", RemindAfterDays: 14, RejectAfterDays: 30}
+
+	query, args := candidatesQuery(policy, models.StaleActionRejection, time.Now(), time.Now(), 10)
+	var candidates []struct{ ApplicationID uint }
+	stmt := db.Raw(query, args...).Find(&candidates).Statement
+	if sql := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); strings.Count(sql, "sa.status IN ('ok','skipped')") != 2 {
+		t.Errorf("candidatos sin filtrar por estado: %s", sql)
+	}
+
+	if err := repo.SaveAction(&models.StaleAction{ApplicationID: 1, Action: models.StaleActionReminder, Status: models.AutomationActionOK}); err != nil {
+		t.Fatal(err)
+	}
+	upsert := log.queries[len(log.queries)-1]
+	for _, want := range []string{`ON CONFLICT ("application_id","stage_event_id","action") DO UPDATE`, `"status"="excluded"."status"`, `"created_at"="excluded"."created_at"`} {
+		if !strings.Contains(upsert, want) {
+			t.Errorf("falta %q en %s", want, upsert)
+		}
+	}
+}
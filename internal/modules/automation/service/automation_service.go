@@ -0,0 +1,517 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/automation/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// webhookTimeout acota cada llamada a un webhook.
+const webhookTimeout = 10 * time.Second
+
+// AutomationService gestiona las reglas de automatización de la empresa y
+// las ejecuta en segundo plano: Fire encola el evento de una postulación y
+// ProcessEvents (tarea periódica) evalúa las reglas, ejecuta sus acciones y
+// deja el registro de cada ejecución.
+type AutomationService struct {
+	repo     domain.AutomationRepository
+	mover    domain.StageMover
+	tagger   domain.Tagger
+	pooler   domain.Pooler
+	mailer   domain.Mailer
+	notifier domain.Notifier
+	client   *http.Client
+
+	// inflight guarda, por postulación, la profundidad de la ejecución en
+	// curso: los eventos que provoca una acción (move_stage) la heredan.
+	mu       sync.Mutex
+	inflight map[uint]int
+}
+
+func NewAutomationService(repo domain.AutomationRepository, mover domain.StageMover, tagger domain.Tagger, pooler domain.Pooler, mailer domain.Mailer, notifier domain.Notifier) *AutomationService {
+	return &AutomationService{
+		repo:     repo,
+		mover:    mover,
+		tagger:   tagger,
+		pooler:   pooler,
+		mailer:   mailer,
+		notifier: notifier,
+		client:   newWebhookClient(webhookTimeout, publicIP),
+		inflight: map[uint]int{},
+	}
+}
+
+// ListRules devuelve las reglas de la empresa.
+func (s *AutomationService) ListRules(companyID uint) ([]models.AutomationRule, error) {
+	rules, err := s.repo.ListRules(companyID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		redact(&rules[i])
+	}
+	return rules, nil
+}
+
+// GetRule devuelve una regla validando el tenant.
+func (s *AutomationService) GetRule(id, companyID uint) (*models.AutomationRule, error) {
+	rule, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	redact(rule)
+	return rule, nil
+}
+
+// CreateRule valida y guarda una regla nueva.
+func (s *AutomationService) CreateRule(companyID, actorID uint, dto dtos.AutomationRuleDTO) (*models.AutomationRule, error) {
+	rule := &models.AutomationRule{CompanyID: companyID, Active: true}
+	if actorID != 0 {
+		rule.CreatedByID = &actorID
+	}
+	if err := s.apply(rule, dto, nil); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRule(rule); err != nil {
+		return nil, err
+	}
+	redact(rule)
+	return rule, nil
+}
+
+// UpdateRule reemplaza una regla. Los eventos ya encolados se evalúan con
+// la versión vigente al procesarlos.
+func (s *AutomationService) UpdateRule(id, companyID uint, dto dtos.AutomationRuleDTO) (*models.AutomationRule, error) {
+	rule, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	previous := append([]models.AutomationAction(nil), rule.Actions...)
+	if err := s.apply(rule, dto, previous); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveRule(rule); err != nil {
+		return nil, err
+	}
+	redact(rule)
+	return rule, nil
+}
+
+// DeleteRule elimina una regla; su registro de ejecuciones se conserva.
+func (s *AutomationService) DeleteRule(id, companyID uint) error {
+	rule, err := s.get(id, companyID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteRule(rule.ID)
+}
+
+// ListRuns devuelve el registro de ejecuciones de la empresa, más recientes
+// primero.
+func (s *AutomationService) ListRuns(companyID uint, filter domain.RunFilter) ([]models.AutomationRun, error) {
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	return s.repo.ListRuns(companyID, filter)
+}
+
+// Fire encola un evento de la postulación para evaluarlo en segundo plano.
+// Nunca falla la operación que lo origina: los errores quedan en el log.
+func (s *AutomationService) Fire(trigger string, application *models.Application, fromStage string) {
+	s.mu.Lock()
+	depth := s.inflight[application.ID]
+	s.mu.Unlock()
+	if depth > domain.MaxDepth {
+		log.Printf("⚠️  Automatización: se descarta %s de la postulación %d (cadena de %d automatizaciones)", trigger, application.ID, depth)
+		return
+	}
+
+	event := models.AutomationEvent{
+		CompanyID:     application.CompanyID,
+		ApplicationID: application.ID,
+		Trigger:       trigger,
+		FromStage:     fromStage,
+		ToStage:       application.Stage,
+		Depth:         depth,
+	}
+	if err := s.repo.CreateEvents([]models.AutomationEvent{event}); err != nil {
+		log.Printf("⚠️  Automatización: no se pudo encolar %s de la postulación %d: %v", trigger, application.ID, err)
+	}
+}
+
+// ScanTimeInStage encola un evento time_in_stage por cada postulación que
+// superó los días de una regla en su etapa (una vez por entrada a la etapa).
+func (s *AutomationService) ScanTimeInStage(ctx context.Context) error {
+	rules, err := s.repo.TimeInStageRules()
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rule := &rules[i]
+		entries, err := s.repo.StaleEntries(rule)
+		if err != nil {
+			return err
+		}
+		events := make([]models.AutomationEvent, 0, len(entries))
+		for _, entry := range entries {
+			ruleID, stageEventID := rule.ID, entry.StageEventID
+			events = append(events, models.AutomationEvent{
+				CompanyID:     rule.CompanyID,
+				ApplicationID: entry.ApplicationID,
+				Trigger:       models.AutomationTriggerTimeInStage,
+				ToStage:       rule.Stage,
+				RuleID:        &ruleID,
+				StageEventID:  &stageEventID,
+			})
+		}
+		if err := s.repo.CreateEvents(events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProcessEvents evalúa los eventos pendientes contra las reglas de su
+// empresa y ejecuta las que aplican. Cada evento se marca procesado aunque
+// alguna acción falle (el fallo queda en el registro de la ejecución).
+func (s *AutomationService) ProcessEvents(ctx context.Context) error {
+	events, err := s.repo.PendingEvents(domain.EventBatch)
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.process(ctx, &events[i]); err != nil {
+			return err
+		}
+		if err := s.repo.MarkProcessed(events[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AutomationService) process(ctx context.Context, event *models.AutomationEvent) error {
+	app, err := s.repo.Application(event.ApplicationID)
+	if err != nil {
+		return err
+	}
+	if app == nil {
+		return nil // eliminada antes de procesar el evento
+	}
+	rules, err := s.repo.ActiveRules(event.CompanyID, event.Trigger)
+	if err != nil {
+		return err
+	}
+
+	facts := domain.Facts{
+		Trigger: event.Trigger,
+		JobID:   app.JobID,
+		Stage:   app.Stage,
+		ToStage: event.ToStage,
+		Rating:  app.Rating,
+	}
+	if app.Candidate != nil {
+		facts.Source = app.Candidate.Source
+	}
+	if event.RuleID != nil {
+		facts.RuleID = *event.RuleID
+	}
+	if domain.UsesField(rules, models.AutomationFieldTag) {
+		if facts.Tags, err = s.tagger.CandidateTags(app.CandidateID); err != nil {
+			return err
+		}
+	}
+
+	for i := range rules {
+		if !domain.Matches(&rules[i], facts) {
+			continue
+		}
+		run := s.execute(ctx, &rules[i], event, app)
+		if err := s.repo.CreateRun(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute corre las acciones de la regla en orden. Un fallo no detiene las
+// siguientes; la ejecución queda failed si alguna falló.
+func (s *AutomationService) execute(ctx context.Context, rule *models.AutomationRule, event *models.AutomationEvent, app *models.Application) *models.AutomationRun {
+	s.mu.Lock()
+	s.inflight[app.ID] = event.Depth + 1
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, app.ID)
+		s.mu.Unlock()
+	}()
+
+	run := &models.AutomationRun{
+		CompanyID:     rule.CompanyID,
+		RuleID:        rule.ID,
+		EventID:       event.ID,
+		ApplicationID: app.ID,
+		Trigger:       event.Trigger,
+		Status:        models.AutomationRunSuccess,
+		StartedAt:     time.Now(),
+	}
+	for _, action := range rule.Actions {
+		result := models.AutomationActionResult{Type: action.Type, Status: models.AutomationActionOK}
+		detail, err := s.act(ctx, action, rule, event, app)
+		switch {
+		case errors.Is(err, errSkipped):
+			result.Status = models.AutomationActionSkipped
+		case err != nil:
+			result.Status = models.AutomationActionFailed
+			detail = err.Error()
+			run.Status = models.AutomationRunFailed
+		}
+		result.Detail = detail
+		run.Results = append(run.Results, result)
+	}
+	run.FinishedAt = time.Now()
+	return run
+}
+
+// errSkipped marca una acción que no aplica a la postulación (p. ej. correo
+// a un candidato anonimizado).
+var errSkipped = errors.New("skipped")
+
+func (s *AutomationService) act(ctx context.Context, action models.AutomationAction, rule *models.AutomationRule, event *models.AutomationEvent, app *models.Application) (string, error) {
+	switch action.Type {
+	case models.AutomationActionSendEmail:
+		candidate := app.Candidate
+		if candidate == nil || candidate.AnonymizedAt != nil || candidate.Email == "" {
+			return "candidate has no contactable email", errSkipped
+		}
+		subject, body, err := domain.RenderEmail(action.Subject, action.Body, emailVars(app))
+		if err != nil {
+			return "", err
+		}
+		return "email sent to the candidate", s.mailer.SendEmail([]string{candidate.Email}, subject, body)
+
+	case models.AutomationActionAssignUser:
+		member, err := s.repo.IsMember(app.CompanyID, action.UserID)
+		if err != nil {
+			return "", err
+		}
+		if !member {
+			return "", fmt.Errorf("user %d is no longer an active member", action.UserID)
+		}
+		if err := s.repo.SetAssignee(app.ID, action.UserID); err != nil {
+			return "", err
+		}
+		s.notifyAssignee(app, action.UserID, rule)
+		return fmt.Sprintf("assigned to user %d", action.UserID), nil
+
+	case models.AutomationActionAddTag:
+		return "tag " + action.Tag, s.tagger.AddTag(app.CompanyID, app.CandidateID, action.Tag)
+
+	case models.AutomationActionMoveStage:
+		if app.Stage == action.Stage {
+			return "already in stage " + action.Stage, errSkipped
+		}
+		if err := s.mover.Move(app.ID, action.Stage, action.RejectionType, action.RejectionReason); err != nil {
+			return "", err
+		}
+		from := app.Stage
+		app.Stage = action.Stage
+		return fmt.Sprintf("%s → %s", from, action.Stage), nil
+
+	case models.AutomationActionWebhook:
+		return s.callWebhook(ctx, action, rule, event, app)
+
+	case models.AutomationActionAddToPool:
+		err := s.pooler.AddToPool(app.CompanyID, action.PoolID, app.CandidateID, app.ID, action.Note)
+		if errors.Is(err, domain.ErrNotContactable) {
+			return "candidate has not consented to be contacted for future jobs", errSkipped
+		}
+		return fmt.Sprintf("added to talent pool %d", action.PoolID), err
+	}
+	return "", fmt.Errorf("unknown action '%s'", action.Type)
+}
+
+// webhookPayload es el cuerpo JSON de un webhook. text permite apuntar la
+// acción directamente a un incoming webhook de Slack.
+type webhookPayload struct {
+	Text          string    `json:"text"`
+	Event         string    `json:"event"`
+	RuleID        uint      `json:"rule_id"`
+	RuleName      string    `json:"rule_name"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	ApplicationID uint      `json:"application_id"`
+	JobID         uint      `json:"job_id"`
+	JobTitle      string    `json:"job_title,omitempty"`
+	CandidateID   uint      `json:"candidate_id"`
+	CandidateName string    `json:"candidate_name,omitempty"`
+	FromStage     string    `json:"from_stage,omitempty"`
+	Stage         string    `json:"stage"`
+	Rating        *int      `json:"rating,omitempty"`
+}
+
+func (s *AutomationService) callWebhook(ctx context.Context, action models.AutomationAction, rule *models.AutomationRule, event *models.AutomationEvent, app *models.Application) (string, error) {
+	vars := emailVars(app)
+	payload := webhookPayload{
+		Event:         event.Trigger,
+		RuleID:        rule.ID,
+		RuleName:      rule.Name,
+		OccurredAt:    event.CreatedAt,
+		ApplicationID: app.ID,
+		JobID:         app.JobID,
+		JobTitle:      vars.Job.Title,
+		CandidateID:   app.CandidateID,
+		CandidateName: vars.Candidate.FullName,
+		FromStage:     event.FromStage,
+		Stage:         app.Stage,
+		Rating:        app.Rating,
+	}
+	payload.Text = fmt.Sprintf("[%s] %s — %s: %s", rule.Name, payload.CandidateName, payload.JobTitle, app.Stage)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if action.Secret != "" {
+		req.Header.Set(domain.SignatureHeader, domain.Sign(action.Secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("webhook responded %d", resp.StatusCode)
+	}
+	return fmt.Sprintf("webhook responded %d", resp.StatusCode), nil
+}
+
+// notifyAssignee avisa in-app al usuario asignado. Es best-effort.
+func (s *AutomationService) notifyAssignee(app *models.Application, userID uint, rule *models.AutomationRule) {
+	if s.notifier == nil {
+		return
+	}
+	vars := emailVars(app)
+	notification := models.Notification{
+		CompanyID:    app.CompanyID,
+		UserID:       userID,
+		Type:         models.NotificationTypeAssignment,
+		Title:        "Te asignaron una postulación",
+		Body:         fmt.Sprintf("%s — %s (regla \"%s\")", vars.Candidate.FullName, vars.Job.Title, rule.Name),
+		ResourceType: models.CommentSubjectApplication,
+		ResourceID:   app.ID,
+	}
+	if err := s.notifier.Notify([]models.Notification{notification}); err != nil {
+		log.Printf("⚠️  Automatización: no se pudo notificar la asignación de la postulación %d: %v", app.ID, err)
+	}
+}
+
+// apply valida el DTO y lo copia en la regla. previous son las acciones
+// guardadas: un webhook sin secret conserva el de la misma URL.
+func (s *AutomationService) apply(rule *models.AutomationRule, dto dtos.AutomationRuleDTO, previous []models.AutomationAction) error {
+	rule.Name = strings.TrimSpace(dto.Name)
+	rule.Trigger = dto.Trigger
+	rule.Stage = strings.TrimSpace(dto.Stage)
+	rule.Days = dto.Days
+	rule.Conditions = dto.Conditions
+	rule.Actions = dto.Actions
+	if dto.Active != nil {
+		rule.Active = *dto.Active
+	}
+
+	if err := domain.ValidateRule(rule); err != nil {
+		return apperr.BadRequest(err.Error())
+	}
+	for i := range rule.Actions {
+		action := &rule.Actions[i]
+		switch action.Type {
+		case models.AutomationActionAssignUser:
+			member, err := s.repo.IsMember(rule.CompanyID, action.UserID)
+			if err != nil {
+				return err
+			}
+			if !member {
+				return apperr.BadRequest(fmt.Sprintf("action %d: user %d is not an active member of the company", i+1, action.UserID))
+			}
+		case models.AutomationActionAddToPool:
+			exists, err := s.pooler.PoolExists(rule.CompanyID, action.PoolID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return apperr.BadRequest(fmt.Sprintf("action %d: talent pool %d does not exist", i+1, action.PoolID))
+			}
+		case models.AutomationActionWebhook:
+			if action.Secret == "" {
+				for _, p := range previous {
+					if p.Type == models.AutomationActionWebhook && p.URL == action.URL {
+						action.Secret = p.Secret
+						break
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *AutomationService) get(id, companyID uint) (*models.AutomationRule, error) {
+	rule, err := s.repo.GetRule(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || (companyID != 0 && rule.CompanyID != companyID) {
+		return nil, apperr.NotFound("automation rule not found")
+	}
+	return rule, nil
+}
+
+// redact quita los secretos de los webhooks antes de devolver la regla.
+func redact(rule *models.AutomationRule) {
+	actions := make([]models.AutomationAction, len(rule.Actions))
+	copy(actions, rule.Actions)
+	for i := range actions {
+		actions[i].Secret = ""
+	}
+	rule.Actions = actions
+}
+
+func emailVars(app *models.Application) domain.EmailVars {
+	var vars domain.EmailVars
+	if c := app.Candidate; c != nil {
+		vars.Candidate.FirstName = c.FirstName
+		vars.Candidate.LastName = c.LastName
+		vars.Candidate.FullName = strings.TrimSpace(c.FirstName + " " + c.LastName)
+	}
+	if app.Job != nil {
+		vars.Job.Title = app.Job.Title
+	}
+	if app.Company != nil {
+		vars.Company.Name = app.Company.Name
+	}
+	vars.Stage = app.Stage
+	return vars
+}
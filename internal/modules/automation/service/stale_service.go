@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/automation/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// sweepBatch acota cuántas postulaciones procesa el barrido por fase y
+// política en cada pasada; el resto queda para la siguiente.
+const sweepBatch = 500
+
+// StaleService configura y aplica el barrido de postulaciones estancadas:
+// políticas por empresa y etapa, recordatorio al recruiter asignado y luego
+// rechazo automático. Todo lo que hace queda en stale_actions.
+type StaleService struct {
+	repo     domain.StaleRepository
+	apps     domain.AutomationRepository
+	mover    domain.StageMover
+	mailer   domain.Mailer
+	notifier domain.Notifier
+	stages   domain.StageCatalog
+	reasons  domain.ReasonCatalog
+}
+
+func NewStaleService(repo domain.StaleRepository, apps domain.AutomationRepository, mover domain.StageMover, mailer domain.Mailer, notifier domain.Notifier, stages domain.StageCatalog, reasons domain.ReasonCatalog) *StaleService {
+	return &StaleService{repo: repo, apps: apps, mover: mover, mailer: mailer, notifier: notifier, stages: stages, reasons: reasons}
+}
+
+// ListPolicies devuelve las políticas de la empresa.
+func (s *StaleService) ListPolicies(companyID uint) ([]models.StalePolicy, error) {
+	return s.repo.ListPolicies(companyID)
+}
+
+// UpsertPolicy crea o reemplaza la política de una etapa. La etapa debe ser
+// activa en algún pipeline de la empresa y el motivo debe estar en su
+// catálogo de rechazos.
+func (s *StaleService) UpsertPolicy(companyID uint, stage string, actorID uint, dto dtos.StalePolicyDTO) (*models.StalePolicy, error) {
+	stage = strings.TrimSpace(stage)
+	policy, err := s.repo.GetPolicy(companyID, stage)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &models.StalePolicy{CompanyID: companyID, Stage: stage, Active: true}
+	}
+
+	policy.RemindAfterDays = dto.RemindAfterDays
+	policy.RejectAfterDays = dto.RejectAfterDays
+	policy.RejectionType = dto.RejectionType
+	policy.RejectionReason = dto.RejectionReason
+	policy.NotifyCandidate = dto.NotifyCandidate
+	policy.EmailSubject = dto.EmailSubject
+	policy.EmailBody = dto.EmailBody
+	if dto.Active != nil {
+		policy.Active = *dto.Active
+	}
+	if actorID != 0 {
+		policy.UpdatedByID = &actorID
+	}
+
+	if err := domain.ValidateStalePolicy(policy); err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+	if err := s.checkStage(companyID, policy.Stage); err != nil {
+		return nil, err
+	}
+	if policy.RejectAfterDays > 0 {
+		if err := s.checkReason(companyID, policy.RejectionType, policy.RejectionReason); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeletePolicy elimina la política de la etapa; el registro se conserva.
+func (s *StaleService) DeletePolicy(companyID uint, stage string) error {
+	policy, err := s.repo.GetPolicy(companyID, stage)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return apperr.NotFound("stale policy not found")
+	}
+	return s.repo.DeletePolicy(policy.ID)
+}
+
+// Preview es el dry-run: qué postulaciones avisaría y cuáles rechazaría cada
+// política activa si el barrido corriera ahora. No modifica nada.
+func (s *StaleService) Preview(companyID uint) ([]dtos.StalePreviewDTO, error) {
+	policies, err := s.repo.ListPolicies(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	previews := []dtos.StalePreviewDTO{}
+	for i := range policies {
+		p := &policies[i]
+		if !p.Active {
+			continue
+		}
+		preview := dtos.StalePreviewDTO{
+			Stage:           p.Stage,
+			RemindAfterDays: p.RemindAfterDays,
+			RejectAfterDays: p.RejectAfterDays,
+			Reminders:       []dtos.StaleApplicationDTO{},
+			Rejections:      []dtos.StaleApplicationDTO{},
+		}
+		if p.RemindAfterDays > 0 {
+			candidates, err := s.repo.Candidates(p, models.StaleActionReminder, daysBefore(now, p.RemindAfterDays), time.Time{}, 0)
+			if err != nil {
+				return nil, err
+			}
+			preview.ReminderCount = len(candidates)
+			preview.Reminders = staleSample(candidates, now)
+		}
+		if p.RejectAfterDays > 0 {
+			candidates, err := s.repo.Candidates(p, models.StaleActionRejection, daysBefore(now, p.RejectAfterDays), remindedBefore(p, now), 0)
+			if err != nil {
+				return nil, err
+			}
+			preview.RejectionCount = len(candidates)
+			preview.Rejections = staleSample(candidates, now)
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// ListActions devuelve el registro del barrido de la empresa, más recientes
+// primero.
+func (s *StaleService) ListActions(companyID uint, filter domain.StaleFilter) ([]models.StaleAction, error) {
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	return s.repo.ListActions(companyID, filter)
+}
+
+// Sweep es la tarea programada: por cada política activa envía los
+// recordatorios vencidos y después rechaza las postulaciones que cumplieron
+// el plazo (y el margen desde el recordatorio).
+func (s *StaleService) Sweep(ctx context.Context) error {
+	policies, err := s.repo.ActivePolicies()
+	if err != nil {
+		return err
+	}
+	for i := range policies {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		p := &policies[i]
+		now := time.Now()
+		if p.RemindAfterDays > 0 {
+			if err := s.sweepPhase(ctx, p, models.StaleActionReminder, daysBefore(now, p.RemindAfterDays), time.Time{}); err != nil {
+				return fmt.Errorf("company %d stage %s: %w", p.CompanyID, p.Stage, err)
+			}
+		}
+		if p.RejectAfterDays > 0 {
+			if err := s.sweepPhase(ctx, p, models.StaleActionRejection, daysBefore(now, p.RejectAfterDays), remindedBefore(p, now)); err != nil {
+				return fmt.Errorf("company %d stage %s: %w", p.CompanyID, p.Stage, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *StaleService) sweepPhase(ctx context.Context, p *models.StalePolicy, phase string, before, reminded time.Time) error {
+	candidates, err := s.repo.Candidates(p, phase, before, reminded, sweepBatch)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		app, err := s.apps.Application(candidate.ApplicationID)
+		if err != nil {
+			return err
+		}
+		if app == nil {
+			continue
+		}
+
+		action := &models.StaleAction{
+			CompanyID:     p.CompanyID,
+			PolicyID:      p.ID,
+			ApplicationID: app.ID,
+			StageEventID:  candidate.StageEventID,
+			Action:        phase,
+			Stage:         p.Stage,
+			IdleDays:      domain.IdleDays(candidate.LastActivityAt, time.Now()),
+			Status:        models.AutomationActionOK,
+		}
+		if phase == models.StaleActionReminder {
+			s.remind(p, app, action)
+		} else {
+			s.reject(p, app, action)
+		}
+		if err := s.repo.SaveAction(action); err != nil {
+			return err
+		}
+		if action.Status == models.AutomationActionFailed {
+			log.Printf("⚠️  Estancadas: %s de la postulación %d falló: %s", phase, app.ID, action.Detail)
+		}
+	}
+	return nil
+}
+
+// remind avisa in-app al recruiter de la vacante (o, si no tiene, a quien
+// tenga asignada la postulación).
+func (s *StaleService) remind(p *models.StalePolicy, app *models.Application, action *models.StaleAction) {
+	recipient, err := s.recipient(app)
+	if err != nil {
+		action.Status, action.Detail = models.AutomationActionFailed, err.Error()
+		return
+	}
+	if recipient == 0 {
+		action.Status, action.Detail = models.AutomationActionSkipped, "no active assigned recruiter"
+		return
+	}
+	action.RecipientID = &recipient
+
+	vars := emailVars(app)
+	body := fmt.Sprintf("%s — %s: %d días sin actividad en %s", vars.Candidate.FullName, vars.Job.Title, action.IdleDays, p.Stage)
+	if p.RejectAfterDays > 0 {
+		body += fmt.Sprintf("; se rechazará automáticamente en %d días", p.RejectAfterDays-p.RemindAfterDays)
+	}
+	notification := models.Notification{
+		CompanyID:    app.CompanyID,
+		UserID:       recipient,
+		Type:         models.NotificationTypeStaleReminder,
+		Title:        "Postulación sin actividad",
+		Body:         body,
+		ResourceType: models.CommentSubjectApplication,
+		ResourceID:   app.ID,
+	}
+	if err := s.notifier.Notify([]models.Notification{notification}); err != nil {
+		action.Status, action.Detail = models.AutomationActionFailed, err.Error()
+		return
+	}
+	action.Detail = fmt.Sprintf("reminder sent to user %d", recipient)
+}
+
+func (s *StaleService) recipient(app *models.Application) (uint, error) {
+	candidates := []*uint{app.AssigneeID}
+	if app.Job != nil {
+		candidates = []*uint{app.Job.AssignedRecruiter, app.AssigneeID}
+	}
+	for _, userID := range candidates {
+		if userID == nil {
+			continue
+		}
+		member, err := s.apps.IsMember(app.CompanyID, *userID)
+		if err != nil {
+			return 0, err
+		}
+		if member {
+			return *userID, nil
+		}
+	}
+	return 0, nil
+}
+
+// reject mueve la postulación a rejected con el motivo de la política y, si
+// corresponde, escribe al candidato. Un fallo del correo no revierte el
+// rechazo: queda en el detalle.
+func (s *StaleService) reject(p *models.StalePolicy, app *models.Application, action *models.StaleAction) {
+	reason := fmt.Sprintf("auto-rejected after %d days without activity", action.IdleDays)
+	if err := s.mover.Reject(app.ID, reason, p.RejectionType, p.RejectionReason); err != nil {
+		action.Status, action.Detail = models.AutomationActionFailed, err.Error()
+		return
+	}
+	action.Detail = fmt.Sprintf("rejected (%s)", p.RejectionReason)
+	if !p.NotifyCandidate {
+		return
+	}
+
+	candidate := app.Candidate
+	if candidate == nil || candidate.AnonymizedAt != nil || candidate.Email == "" {
+		action.Detail += "; email skipped: candidate has no contactable email"
+		return
+	}
+	subject, body, err := domain.RenderEmail(p.EmailSubject, p.EmailBody, emailVars(app))
+	if err == nil {
+		err = s.mailer.SendEmail([]string{candidate.Email}, subject, body)
+	}
+	if err != nil {
+		action.Detail += "; email failed: " + err.Error()
+		return
+	}
+	action.Detail += "; email sent to the candidate"
+}
+
+func (s *StaleService) checkStage(companyID uint, key string) error {
+	stages, err := s.stages.CompanyStages(companyID)
+	if err != nil {
+		return err
+	}
+	for _, stage := range stages {
+		if stage.Key == key {
+			if stage.Type != models.StageTypeActive {
+				return apperr.BadRequest(fmt.Sprintf("stage '%s' is final", key))
+			}
+			return nil
+		}
+	}
+	return apperr.BadRequest(fmt.Sprintf("stage '%s' is not in the company's pipelines", key))
+}
+
+func (s *StaleService) checkReason(companyID uint, rejectionType, reason string) error {
+	values, err := s.reasons.GetByCategory(models.RejectionCategory(rejectionType), &companyID)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if v.Value == reason {
+			return nil
+		}
+	}
+	return apperr.BadRequest(fmt.Sprintf("rejection_reason '%s' is not in the %s catalog", reason, rejectionType))
+}
+
+func daysBefore(now time.Time, days int) time.Time {
+	return now.AddDate(0, 0, -days)
+}
+
+// remindedBefore es el tope para el recordatorio previo al rechazo; cero si
+// la política no avisa antes.
+func remindedBefore(p *models.StalePolicy, now time.Time) time.Time {
+	grace := domain.ReminderGrace(p)
+	if grace == 0 {
+		return time.Time{}
+	}
+	return now.Add(-grace)
+}
+
+func staleSample(candidates []domain.StaleCandidate, now time.Time) []dtos.StaleApplicationDTO {
+	if len(candidates) > domain.StaleSampleSize {
+		candidates = candidates[:domain.StaleSampleSize]
+	}
+	sample := make([]dtos.StaleApplicationDTO, len(candidates))
+	for i, c := range candidates {
+		sample[i] = dtos.StaleApplicationDTO{
+			ApplicationID:  c.ApplicationID,
+			CandidateName:  c.CandidateName,
+			JobTitle:       c.JobTitle,
+			LastActivityAt: c.LastActivityAt,
+			IdleDays:       domain.IdleDays(c.LastActivityAt, now),
+		}
+	}
+	return sample
+}
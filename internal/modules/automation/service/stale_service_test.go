@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/automation/domain"
+)
+
+// fakeStale guarda las acciones por entrada como el índice único de
+// stale_actions y filtra los candidatos como la consulta: solo una acción
+// con estado asentado cierra la fase.
+type fakeStale struct {
+	domain.StaleRepository
+	policy  models.StalePolicy
+	actions map[uint]models.StaleAction // por ApplicationID (una sola fase)
+	saves   int
+}
+
+func (f *fakeStale) ActivePolicies() ([]models.StalePolicy, error) {
+	return []models.StalePolicy{f.policy}, nil
+}
+
+func (f *fakeStale) Candidates(_ *models.StalePolicy, action string, _, _ time.Time, _ int) ([]domain.StaleCandidate, error) {
+	if a, ok := f.actions[1]; ok && a.Action == action {
+		for _, status := range domain.SettledStaleStatuses {
+			if a.Status == status {
+				return nil, nil
+			}
+		}
+	}
+	return []domain.StaleCandidate{{ApplicationID: 1, LastActivityAt: time.Now().AddDate(0, 0, -20)}}, nil
+}
+
+func (f *fakeStale) SaveAction(action *models.StaleAction) error {
+	f.saves++
+	f.actions[action.ApplicationID] = *action
+	return nil
+}
+
+type fakeApps struct{ domain.AutomationRepository }
+
+func (fakeApps) Application(id uint) (*models.Application, error) {
+	assignee := uint(5)
+	application := &models.Application{CompanyID: 1, Stage: "screening", AssigneeID: &assignee}
+	application.ID = id
+	return application, nil
+}
+
+func (fakeApps) IsMember(uint, uint) (bool, error) { return true, nil }
+
+type fakeNotifier struct{ errs []error }
+
+func (f *fakeNotifier) Notify([]models.Notification) error {
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+// Un recordatorio que falló no cierra la fase: la pasada siguiente lo
+// reintenta y reemplaza el registro fallido.
+func TestSweepRetriesFailedAction(t *testing.T) {
+	repo := &fakeStale{
+		policy:  models.StalePolicy{CompanyID: 1, Stage: "screening", RemindAfterDays: 14, Active: true},
+		actions: map[uint]models.StaleAction{},
+	}
+	notifier := &fakeNotifier{errs: []error{errors.New("smtp down")}}
+	svc := NewStaleService(repo, fakeApps{}, nil, nil, notifier, nil, nil)
+
+	if err := svc.Sweep(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.actions[1].Status; got != models.AutomationActionFailed {
+		t.Fatalf("primera pasada: estado = %s, quería failed", got)
+	}
+
+	if err := svc.Sweep(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.actions[1]; got.Status != models.AutomationActionOK || got.RecipientID == nil || *got.RecipientID != 5 {
+		t.Fatalf("reintento: acción = %+v, quería ok al recruiter 5", got)
+	}
+
+	// Ya asentada: la tercera pasada no vuelve a avisar.
+	if err := svc.Sweep(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if repo.saves != 2 {
+		t.Errorf("acciones guardadas = %d, quería 2", repo.saves)
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// errBlockedAddress es el error de conexión a una IP no pública.
+var errBlockedAddress = errors.New("webhook address is not public")
+
+// newWebhookClient arma el cliente de los webhooks. La URL la define un
+// admin de la empresa, así que la IP se valida al conectar (ya resuelto el
+// DNS, así un nombre no puede apuntar a la red interna) y no se siguen
+// redirecciones: un 3xx es una respuesta fallida más. allow decide qué IPs
+// admite; en producción, publicIP.
+func newWebhookClient(timeout time.Duration, allow func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allow(ip) {
+				return fmt.Errorf("%w: %s", errBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// Sin proxy: la conexión debe ir a la IP que se validó.
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// nonPublicNets son los rangos no enrutables que net.IP no clasifica:
+// "esta red" (0.0.0.0/8, que en Linux llega al host local) y el espacio
+// compartido de CGNAT (100.64.0.0/10, interno en muchas nubes).
+var nonPublicNets = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+}
+
+// publicIP reporta si la IP es enrutable en Internet: descarta loopback,
+// redes privadas, link-local (169.254.0.0/16: metadata de la nube),
+// multicast, la dirección no especificada y nonPublicNets.
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
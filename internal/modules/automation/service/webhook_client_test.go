@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":              true,
+		"2001:4860:4860::8888": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.0.0.5":             false,
+		"172.16.3.4":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false, // metadata de la nube
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"0.0.0.0":              false,
+		"0.1.2.3":              false,
+		"100.64.0.1":           false, // CGNAT
+		"100.127.255.254":      false,
+		"100.128.0.1":          true,
+		"::ffff:127.0.0.1":     false,
+		"224.0.0.1":            false,
+	}
+	for addr, want := range cases {
+		if got := publicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("publicIP(%s) = %v, quería %v", addr, got, want)
+		}
+	}
+}
+
+// El webhook no llega a la red interna: la IP se valida ya resuelta.
+func TestWebhookClientBloqueaLoopback(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { hit = true }))
+	defer srv.Close()
+
+	_, err := newWebhookClient(time.Second, publicIP).Post(srv.URL, "application/json", nil)
+	if !errors.Is(err, errBlockedAddress) {
+		t.Errorf("err = %v, quería errBlockedAddress", err)
+	}
+	if hit {
+		t.Error("la petición llegó al servidor local")
+	}
+}
+
+// Una redirección no se sigue: el 3xx vuelve como respuesta.
+func TestWebhookClientNoSigueRedirecciones(t *testing.T) {
+	followed := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	})
+	mux.HandleFunc("/internal", func(http.ResponseWriter, *http.Request) { followed = true })
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	allowAll := func(net.IP) bool { return true }
+	resp, err := newWebhookClient(time.Second, allowAll).Post(srv.URL+"/hook", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound || followed {
+		t.Errorf("status = %d, seguida = %v; quería 302 sin seguir", resp.StatusCode, followed)
+	}
+}
@@ -0,0 +1,202 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/automation/domain"
+	"dvra-api/internal/modules/automation/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AutomationHandler struct {
+	svc *service.AutomationService
+}
+
+func NewAutomationHandler(svc *service.AutomationService) *AutomationHandler {
+	return &AutomationHandler{svc: svc}
+}
+
+// GetRules godoc
+// @Summary      Listar reglas de automatización
+// @Tags         Automations
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations [get]
+func (h *AutomationHandler) GetRules(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	rules, err := h.svc.ListRules(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": rules, "count": len(rules)}})
+}
+
+// GetRule godoc
+// @Summary      Obtener regla de automatización
+// @Tags         Automations
+// @Produce      json
+// @Param        id   path      int  true  "ID de la regla"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/{id} [get]
+func (h *AutomationHandler) GetRule(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid rule ID")
+	if !ok {
+		return
+	}
+
+	rule, err := h.svc.GetRule(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": rule})
+}
+
+// CreateRule godoc
+// @Summary      Crear regla de automatización
+// @Description  Disparador (application_created, stage_changed, rating_set, time_in_stage), condiciones (job, source, stage, rating, tag) y acciones en orden (send_email, assign_user, add_tag, move_stage, webhook https, add_to_pool). Corre en segundo plano.
+// @Tags         Automations
+// @Accept       json
+// @Produce      json
+// @Param        rule        body      dtos.AutomationRuleDTO  true   "Regla"
+// @Param        company_id  query     int                     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      201         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations [post]
+func (h *AutomationHandler) CreateRule(c *gin.Context) {
+	var dto dtos.AutomationRuleDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	rule, err := h.svc.CreateRule(companyID, actorID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": rule})
+}
+
+// UpdateRule godoc
+// @Summary      Editar regla de automatización
+// @Description  Reemplaza la regla. Un webhook sin secret conserva el anterior si la URL no cambió.
+// @Tags         Automations
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "ID de la regla"
+// @Param        rule  body      dtos.AutomationRuleDTO  true  "Regla"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/{id} [put]
+func (h *AutomationHandler) UpdateRule(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid rule ID")
+	if !ok {
+		return
+	}
+	var dto dtos.AutomationRuleDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.svc.UpdateRule(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": rule})
+}
+
+// DeleteRule godoc
+// @Summary      Eliminar regla de automatización
+// @Description  Su registro de ejecuciones se conserva
+// @Tags         Automations
+// @Produce      json
+// @Param        id   path      int  true  "ID de la regla"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/{id} [delete]
+func (h *AutomationHandler) DeleteRule(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid rule ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteRule(id, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Automation rule deleted"})
+}
+
+// GetRuns godoc
+// @Summary      Registro de ejecuciones
+// @Description  Ejecuciones de reglas con el resultado de cada acción, más recientes primero
+// @Tags         Automations
+// @Produce      json
+// @Param        rule_id         query     int     false  "Regla"
+// @Param        application_id  query     int     false  "Postulación"
+// @Param        status          query     string  false  "success, failed"
+// @Param        limit           query     int     false  "Máximo (por defecto 50, hasta 200)"
+// @Param        company_id      query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200             {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/runs [get]
+func (h *AutomationHandler) GetRuns(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	filter := domain.RunFilter{Status: c.Query("status")}
+	if v, err := strconv.ParseUint(c.Query("rule_id"), 10, 32); err == nil {
+		filter.RuleID = uint(v)
+	}
+	if v, err := strconv.ParseUint(c.Query("application_id"), 10, 32); err == nil {
+		filter.ApplicationID = uint(v)
+	}
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil {
+		filter.Limit = v
+	}
+
+	runs, err := h.svc.ListRuns(companyID, filter)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": runs, "count": len(runs)}})
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/automation/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.AutomationService, staleSvc *service.StaleService) {
+	h := NewAutomationHandler(svc)
+	stale := NewStaleHandler(staleSvc)
+
+	automations := rg.Group("/automations")
+	{
+		automations.GET("", middleware.RequirePermission(permissions.AutomationsView), h.GetRules)
+		automations.POST("", middleware.RequirePermission(permissions.AutomationsManage), h.CreateRule)
+		automations.GET("/runs", middleware.RequirePermission(permissions.AutomationsView), h.GetRuns)
+		automations.GET("/stale-policies", middleware.RequirePermission(permissions.AutomationsView), stale.GetPolicies)
+		automations.GET("/stale-policies/preview", middleware.RequirePermission(permissions.AutomationsView), stale.Preview)
+		automations.PUT("/stale-policies/:stage", middleware.RequirePermission(permissions.AutomationsManage), stale.UpsertPolicy)
+		automations.DELETE("/stale-policies/:stage", middleware.RequirePermission(permissions.AutomationsManage), stale.DeletePolicy)
+		automations.GET("/stale-actions", middleware.RequirePermission(permissions.AutomationsView), stale.GetActions)
+		automations.GET("/:id", middleware.RequirePermission(permissions.AutomationsView), h.GetRule)
+		automations.PUT("/:id", middleware.RequirePermission(permissions.AutomationsManage), h.UpdateRule)
+		automations.DELETE("/:id", middleware.RequirePermission(permissions.AutomationsManage), h.DeleteRule)
+	}
+}
@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/automation/domain"
+	"dvra-api/internal/modules/automation/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StaleHandler struct {
+	svc *service.StaleService
+}
+
+func NewStaleHandler(svc *service.StaleService) *StaleHandler {
+	return &StaleHandler{svc: svc}
+}
+
+// GetPolicies godoc
+// @Summary      Listar políticas de postulaciones estancadas
+// @Description  Umbrales de inactividad por etapa: recordatorio al recruiter asignado y rechazo automático
+// @Tags         Automations
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/stale-policies [get]
+func (h *StaleHandler) GetPolicies(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	policies, err := h.svc.ListPolicies(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": policies, "count": len(policies)}})
+}
+
+// UpsertPolicy godoc
+// @Summary      Configurar política de una etapa
+// @Description  Crea o reemplaza los umbrales de inactividad de la etapa (key). El rechazo exige un motivo del catálogo de la empresa.
+// @Tags         Automations
+// @Accept       json
+// @Produce      json
+// @Param        stage       path      string               true   "Key de la etapa"
+// @Param        policy      body      dtos.StalePolicyDTO  true   "Umbrales y rechazo"
+// @Param        company_id  query     int                  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/stale-policies/{stage} [put]
+func (h *StaleHandler) UpsertPolicy(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	var dto dtos.StalePolicyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.svc.UpsertPolicy(companyID, c.Param("stage"), userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": policy})
+}
+
+// DeletePolicy godoc
+// @Summary      Eliminar política de una etapa
+// @Tags         Automations
+// @Produce      json
+// @Param        stage       path      string  true   "Key de la etapa"
+// @Param        company_id  query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/stale-policies/{stage} [delete]
+func (h *StaleHandler) DeletePolicy(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeletePolicy(companyID, c.Param("stage")); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Stale policy deleted"})
+}
+
+// Preview godoc
+// @Summary      Vista previa (dry-run) de estancadas
+// @Description  Qué postulaciones recibirían recordatorio y cuáles se rechazarían si el barrido corriera ahora. No modifica datos.
+// @Tags         Automations
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/stale-policies/preview [get]
+func (h *StaleHandler) Preview(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	previews, err := h.svc.Preview(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": previews})
+}
+
+// GetActions godoc
+// @Summary      Registro del barrido de estancadas
+// @Description  Recordatorios y rechazos automáticos con su resultado, más recientes primero
+// @Tags         Automations
+// @Produce      json
+// @Param        stage           query     string  false  "Key de la etapa"
+// @Param        action          query     string  false  "reminder, rejection"
+// @Param        application_id  query     int     false  "Postulación"
+// @Param        limit           query     int     false  "Máximo (por defecto 50, hasta 200)"
+// @Param        company_id      query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200             {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /automations/stale-actions [get]
+func (h *StaleHandler) GetActions(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	filter := domain.StaleFilter{Stage: c.Query("stage"), Action: c.Query("action")}
+	if v, err := strconv.ParseUint(c.Query("application_id"), 10, 32); err == nil {
+		filter.ApplicationID = uint(v)
+	}
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil {
+		filter.Limit = v
+	}
+
+	actions, err := h.svc.ListActions(companyID, filter)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": actions, "count": len(actions)}})
+}
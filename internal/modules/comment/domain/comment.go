@@ -0,0 +1,117 @@
+// Package domain define el centro del módulo comment: hilos de comentarios
+// sobre candidatos y postulaciones, con menciones, visibilidad interna,
+// historial de ediciones y adjuntos. No importa gin ni gorm.
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"dvra-api/internal/app/models"
+)
+
+// Tipos de recurso que admiten comentarios.
+const (
+	SubjectCandidate   = models.CommentSubjectCandidate
+	SubjectApplication = models.CommentSubjectApplication
+)
+
+// Límites de adjuntos.
+const (
+	MaxAttachmentSize     = 10 << 20 // 10 MB
+	MaxAttachmentsPerNote = 10
+)
+
+// allowedAttachmentTypes son los tipos de archivo aceptados como adjunto.
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf":    true,
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.ms-excel": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+	"text/plain": true,
+	"text/csv":   true,
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// Subject identifica el candidato o la postulación comentada.
+type Subject struct {
+	Type string
+	ID   uint
+}
+
+// Viewer es quién lee o escribe: su empresa (0 = SuperAdmin) y lo que su rol
+// le permite sobre comentarios.
+type Viewer struct {
+	UserID         uint
+	CompanyID      uint
+	CanSeeInternal bool
+	CanModerate    bool
+}
+
+// Apply asigna el recurso al comentario.
+func (s Subject) Apply(c *models.Comment) {
+	id := s.ID
+	switch s.Type {
+	case SubjectCandidate:
+		c.CandidateID = &id
+	case SubjectApplication:
+		c.ApplicationID = &id
+	}
+}
+
+// Visible reporta si el lector puede ver el comentario.
+func Visible(c models.Comment, v Viewer) bool {
+	return !c.Internal || v.CanSeeInternal
+}
+
+// AllowedAttachmentType reporta si el tipo de archivo se acepta como adjunto.
+func AllowedAttachmentType(contentType string) bool {
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return allowedAttachmentTypes[strings.TrimSpace(strings.ToLower(contentType))]
+}
+
+// NormalizeMentions quita repetidos, ceros y al propio autor (no se notifica
+// a sí mismo), conservando el orden.
+func NormalizeMentions(ids []uint, authorID uint) []uint {
+	seen := map[uint]bool{0: true, authorID: true}
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// NewMentions devuelve las menciones de next que no estaban en current (a
+// quién notificar tras una edición).
+func NewMentions(current []models.CommentMention, next []uint) []uint {
+	had := make(map[uint]bool, len(current))
+	for _, m := range current {
+		had[m.UserID] = true
+	}
+	var added []uint
+	for _, id := range next {
+		if !had[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// Excerpt recorta el texto para una notificación.
+func Excerpt(body string, max int) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if utf8.RuneCountInString(body) <= max {
+		return body
+	}
+	runes := []rune(body)
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestNormalizeMentionsQuitaRepetidosCerosYAutor(t *testing.T) {
+	got := NormalizeMentions([]uint{4, 0, 7, 4, 9, 7}, 9)
+	if want := []uint{4, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeMentions = %v, se esperaba %v", got, want)
+	}
+}
+
+func TestNewMentionsSoloDevuelveLasAgregadas(t *testing.T) {
+	current := []models.CommentMention{{UserID: 4}, {UserID: 7}}
+	if got := NewMentions(current, []uint{7, 12, 4, 15}); !reflect.DeepEqual(got, []uint{12, 15}) {
+		t.Errorf("NewMentions = %v, se esperaba [12 15]", got)
+	}
+	if got := NewMentions(current, []uint{4}); len(got) != 0 {
+		t.Errorf("sin menciones nuevas no se notifica a nadie, got %v", got)
+	}
+}
+
+func TestAllowedAttachmentTypeIgnoraParametros(t *testing.T) {
+	if !AllowedAttachmentType("text/plain; charset=utf-8") {
+		t.Error("text/plain con charset debería aceptarse")
+	}
+	if AllowedAttachmentType("application/x-msdownload") {
+		t.Error("un ejecutable no debería aceptarse")
+	}
+}
+
+func TestExcerptRecortaPorRunas(t *testing.T) {
+	if got := Excerpt("  hola\n  mundo ", 20); got != "hola mundo" {
+		t.Errorf("Excerpt = %q", got)
+	}
+	if got := Excerpt("áéíóúáéíóú", 5); got != "áéíó…" {
+		t.Errorf("Excerpt = %q", got)
+	}
+}
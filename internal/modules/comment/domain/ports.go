@@ -0,0 +1,37 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// CommentRepository es el puerto de salida hacia la persistencia.
+type CommentRepository interface {
+	// SubjectCompanyID devuelve la empresa del candidato o postulación (0 si
+	// no existe).
+	SubjectCompanyID(subject Subject) (uint, error)
+	// MemberRoles devuelve el rol de cada usuario con membresía activa en la
+	// empresa; los que no son miembros no aparecen.
+	MemberRoles(companyID uint, userIDs []uint) (map[uint]string, error)
+	UserNames(ids []uint) (map[uint]string, error)
+
+	Create(comment *models.Comment) error
+	GetByID(id uint) (*models.Comment, error)
+	// ListBySubject devuelve los comentarios del recurso en orden cronológico,
+	// con menciones y adjuntos.
+	ListBySubject(subject Subject) ([]models.Comment, error)
+	// UpdateBody guarda el nuevo texto solo si la versión sigue siendo
+	// expectedVersion (false si otro la cambió), junto con la revisión del
+	// texto anterior y las menciones nuevas, en una transacción.
+	UpdateBody(comment *models.Comment, expectedVersion int, revision *models.CommentRevision, mentions []uint) (bool, error)
+	// Delete elimina (soft delete) el comentario y sus respuestas.
+	Delete(id uint) error
+	Revisions(commentID uint) ([]models.CommentRevision, error)
+
+	CountAttachments(commentID uint) (int64, error)
+	CreateAttachment(attachment *models.CommentAttachment) error
+	GetAttachment(id uint) (*models.CommentAttachment, error)
+}
+
+// Notifier entrega avisos in-app (menciones). Lo implementa el módulo
+// notification; el composition root lo inyecta.
+type Notifier interface {
+	Notify(notifications []models.Notification) error
+}
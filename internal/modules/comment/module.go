@@ -0,0 +1,40 @@
+// Package comment es el punto de ensamblaje del módulo de comentarios sobre
+// candidatos y postulaciones. Nadie importa este paquete salvo el composition
+// root.
+package comment
+
+import (
+	"dvra-api/internal/modules/comment/domain"
+	"dvra-api/internal/modules/comment/repository"
+	"dvra-api/internal/modules/comment/service"
+	"dvra-api/internal/modules/comment/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo comment.
+type Module struct {
+	// Service se expone porque applications y la career page registran en el
+	// hilo las notas de la postulación y la carta de presentación.
+	Service *service.CommentService
+
+	notifier domain.Notifier
+}
+
+// New construye el módulo. notifier entrega los avisos de mención (módulo
+// notification, inyectado por el composition root).
+func New(db *gorm.DB, notifier domain.Notifier) *Module {
+	return &Module{Service: service.NewCommentService(repository.NewCommentRepository(db), notifier), notifier: notifier}
+}
+
+// ServiceTx devuelve un CommentService que escribe en la transacción tx (la
+// carta de presentación se guarda junto con la postulación).
+func (m *Module) ServiceTx(tx *gorm.DB) *service.CommentService {
+	return service.NewCommentService(repository.NewCommentRepository(tx), m.notifier)
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
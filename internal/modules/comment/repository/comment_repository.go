@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/comment/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository devuelve la implementación del puerto.
+func NewCommentRepository(db *gorm.DB) domain.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) SubjectCompanyID(subject domain.Subject) (uint, error) {
+	table := "candidates"
+	if subject.Type == domain.SubjectApplication {
+		table = "applications"
+	}
+	var companyIDs []uint
+	if err := r.db.Table(table).
+		Where("id = ? AND deleted_at IS NULL", subject.ID).
+		Limit(1).
+		Pluck("company_id", &companyIDs).Error; err != nil {
+		return 0, err
+	}
+	if len(companyIDs) == 0 {
+		return 0, nil
+	}
+	return companyIDs[0], nil
+}
+
+func (r *commentRepository) MemberRoles(companyID uint, userIDs []uint) (map[uint]string, error) {
+	roles := make(map[uint]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return roles, nil
+	}
+
+	var memberships []models.Membership
+	if err := r.db.Select("user_id, role").
+		Where("company_id = ? AND user_id IN ? AND status = ?", companyID, userIDs, "active").
+		Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		roles[m.UserID] = m.Role
+	}
+	return roles, nil
+}
+
+func (r *commentRepository) UserNames(ids []uint) (map[uint]string, error) {
+	names := make(map[uint]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	var rows []struct {
+		ID   uint
+		Name string
+	}
+	if err := r.db.Table("users").
+		Select("id, NULLIF(CONCAT_WS(' ', first_name, last_name), '') AS name").
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		names[row.ID] = row.Name
+	}
+	return names, nil
+}
+
+func (r *commentRepository) Create(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *commentRepository) GetByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := r.db.Preload("Mentions").Preload("Attachments").First(&comment, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *commentRepository) ListBySubject(subject domain.Subject) ([]models.Comment, error) {
+	column := "candidate_id"
+	if subject.Type == domain.SubjectApplication {
+		column = "application_id"
+	}
+	var comments []models.Comment
+	if err := r.db.Preload("Mentions").Preload("Attachments").
+		Where(column+" = ?", subject.ID).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) UpdateBody(comment *models.Comment, expectedVersion int, revision *models.CommentRevision, mentions []uint) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Comment{}).
+			Where("id = ? AND version = ?", comment.ID, expectedVersion).
+			Updates(map[string]interface{}{
+				"body":      comment.Body,
+				"version":   comment.Version,
+				"edited_at": comment.EditedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		updated = true
+
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+		rows := make([]models.CommentMention, 0, len(mentions))
+		for _, userID := range mentions {
+			rows = append(rows, models.CommentMention{CommentID: comment.ID, UserID: userID})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	return updated, err
+}
+
+func (r *commentRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("parent_id = ?", id).Delete(&models.Comment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Comment{}, id).Error
+	})
+}
+
+func (r *commentRepository) Revisions(commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	if err := r.db.Where("comment_id = ?", commentID).
+		Order("version DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *commentRepository) CountAttachments(commentID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.CommentAttachment{}).Where("comment_id = ?", commentID).Count(&count).Error
+	return count, err
+}
+
+func (r *commentRepository) CreateAttachment(attachment *models.CommentAttachment) error {
+	return r.db.Create(attachment).Error
+}
+
+func (r *commentRepository) GetAttachment(id uint) (*models.CommentAttachment, error) {
+	var attachment models.CommentAttachment
+	if err := r.db.First(&attachment, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
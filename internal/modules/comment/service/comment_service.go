@@ -0,0 +1,395 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/comment/domain"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/permissions"
+)
+
+// notificationExcerpt es el largo del extracto del comentario en la notificación.
+const notificationExcerpt = 140
+
+// CommentService administra los hilos de comentarios de candidatos y
+// postulaciones. Los internos solo los ven roles con CommentsViewInternal;
+// las respuestas se aplanan en la raíz del hilo y heredan su visibilidad.
+type CommentService struct {
+	repo     domain.CommentRepository
+	notifier domain.Notifier
+}
+
+func NewCommentService(repo domain.CommentRepository, notifier domain.Notifier) *CommentService {
+	return &CommentService{repo: repo, notifier: notifier}
+}
+
+// List devuelve los hilos visibles del recurso, en orden cronológico.
+func (s *CommentService) List(subject domain.Subject, viewer domain.Viewer) ([]dtos.CommentDTO, error) {
+	if _, err := s.subjectCompany(subject, viewer); err != nil {
+		return nil, err
+	}
+	comments, err := s.repo.ListBySubject(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]models.Comment, 0, len(comments))
+	for _, c := range comments {
+		if domain.Visible(c, viewer) {
+			visible = append(visible, c)
+		}
+	}
+	names, err := s.repo.UserNames(authorIDs(visible))
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]dtos.CommentDTO, 0, len(visible))
+	index := make(map[uint]int, len(visible))
+	for _, c := range visible {
+		dto := toDTO(c, names)
+		if c.ParentID != nil {
+			if i, ok := index[*c.ParentID]; ok {
+				threads[i].Replies = append(threads[i].Replies, dto)
+			}
+			continue
+		}
+		index[c.ID] = len(threads)
+		threads = append(threads, dto)
+	}
+	return threads, nil
+}
+
+// Create publica un comentario o una respuesta y notifica a los mencionados.
+func (s *CommentService) Create(subject domain.Subject, viewer domain.Viewer, dto dtos.CreateCommentDTO) (*dtos.CommentDTO, error) {
+	companyID, err := s.subjectCompany(subject, viewer)
+	if err != nil {
+		return nil, err
+	}
+	body := strings.TrimSpace(dto.Body)
+	if body == "" {
+		return nil, apperr.BadRequest("comment body is required")
+	}
+
+	authorID := viewer.UserID
+	comment := &models.Comment{CompanyID: companyID, AuthorID: &authorID, Body: body, Internal: dto.Internal}
+	subject.Apply(comment)
+
+	if dto.ParentID != nil {
+		parent, err := s.repo.GetByID(*dto.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || !sameSubject(parent, comment) || !domain.Visible(*parent, viewer) {
+			return nil, apperr.BadRequest("parent comment not found in this thread")
+		}
+		// Las respuestas cuelgan de la raíz del hilo y heredan su visibilidad.
+		rootID := parent.ID
+		if parent.ParentID != nil {
+			rootID = *parent.ParentID
+		}
+		comment.ParentID = &rootID
+		comment.Internal = parent.Internal
+	}
+	if comment.Internal && !viewer.CanSeeInternal {
+		return nil, apperr.Forbidden("you cannot post internal comments")
+	}
+
+	mentions := domain.NormalizeMentions(dto.MentionIDs, viewer.UserID)
+	if err := s.validateMentions(companyID, mentions, comment.Internal); err != nil {
+		return nil, err
+	}
+	for _, userID := range mentions {
+		comment.Mentions = append(comment.Mentions, models.CommentMention{UserID: userID})
+	}
+
+	if err := s.repo.Create(comment); err != nil {
+		return nil, err
+	}
+	s.notifyMentions(comment, subject, viewer.UserID, mentions)
+	return s.present(*comment)
+}
+
+// AppendNote registra como comentario un texto que no escribió el equipo en
+// el hilo (notas enviadas con la postulación, carta de presentación). authorID
+// nil = sistema.
+func (s *CommentService) AppendNote(companyID uint, subjectType string, subjectID uint, authorID *uint, body, source string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil
+	}
+	comment := &models.Comment{CompanyID: companyID, AuthorID: authorID, Body: body, Source: source}
+	domain.Subject{Type: subjectType, ID: subjectID}.Apply(comment)
+	return s.repo.Create(comment)
+}
+
+// Update edita un comentario propio. Exige la versión leída: si otro lo
+// editó entretanto responde 409. El texto anterior queda en el historial.
+func (s *CommentService) Update(id uint, viewer domain.Viewer, dto dtos.UpdateCommentDTO) (*dtos.CommentDTO, error) {
+	comment, err := s.get(id, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID == nil || *comment.AuthorID != viewer.UserID {
+		return nil, apperr.Forbidden("only the author can edit a comment")
+	}
+	body := strings.TrimSpace(dto.Body)
+	if body == "" {
+		return nil, apperr.BadRequest("comment body is required")
+	}
+	if dto.Version != comment.Version {
+		return nil, apperr.Conflict("comment was edited by someone else; reload it and try again")
+	}
+
+	added := domain.NewMentions(comment.Mentions, domain.NormalizeMentions(dto.MentionIDs, viewer.UserID))
+	if err := s.validateMentions(comment.CompanyID, added, comment.Internal); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	editorID := viewer.UserID
+	revision := &models.CommentRevision{CommentID: comment.ID, Version: comment.Version, Body: comment.Body, EditedByID: &editorID, EditedAt: now}
+	comment.Body = body
+	comment.Version++
+	comment.EditedAt = &now
+
+	updated, err := s.repo.UpdateBody(comment, dto.Version, revision, added)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, apperr.Conflict("comment was edited by someone else; reload it and try again")
+	}
+	for _, userID := range added {
+		comment.Mentions = append(comment.Mentions, models.CommentMention{CommentID: comment.ID, UserID: userID})
+	}
+	s.notifyMentions(comment, subjectOf(comment), viewer.UserID, added)
+	return s.present(*comment)
+}
+
+// Delete elimina un comentario (y sus respuestas si es la raíz). El autor
+// borra los propios; CommentsModerate, los de cualquiera.
+func (s *CommentService) Delete(id uint, viewer domain.Viewer) error {
+	comment, err := s.get(id, viewer)
+	if err != nil {
+		return err
+	}
+	own := comment.AuthorID != nil && *comment.AuthorID == viewer.UserID
+	if !own && !viewer.CanModerate {
+		return apperr.Forbidden("only the author or a moderator can delete this comment")
+	}
+	return s.repo.Delete(comment.ID)
+}
+
+// Revisions devuelve el historial de ediciones, de la más reciente a la más
+// antigua.
+func (s *CommentService) Revisions(id uint, viewer domain.Viewer) ([]models.CommentRevision, error) {
+	if _, err := s.get(id, viewer); err != nil {
+		return nil, err
+	}
+	return s.repo.Revisions(id)
+}
+
+// AddAttachment adjunta un archivo a un comentario propio. save escribe el
+// archivo en la ruta (relativa a uploads) que decide el servicio; el registro
+// se guarda solo si el archivo se escribió.
+func (s *CommentService) AddAttachment(commentID uint, viewer domain.Viewer, fileName, contentType string, size int64, save func(path string) error) (*models.CommentAttachment, error) {
+	comment, err := s.get(commentID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID == nil || *comment.AuthorID != viewer.UserID {
+		return nil, apperr.Forbidden("only the author can attach files to a comment")
+	}
+	if size <= 0 || size > domain.MaxAttachmentSize {
+		return nil, apperr.BadRequest(fmt.Sprintf("attachment must be between 1 byte and %d MB", domain.MaxAttachmentSize>>20))
+	}
+	if !domain.AllowedAttachmentType(contentType) {
+		return nil, apperr.BadRequest("file type not allowed")
+	}
+	count, err := s.repo.CountAttachments(comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxAttachmentsPerNote {
+		return nil, apperr.BadRequest(fmt.Sprintf("a comment admits at most %d attachments", domain.MaxAttachmentsPerNote))
+	}
+
+	name := filepath.Base(fileName)
+	path := fmt.Sprintf("comments/%d/%d/%d_%s", comment.CompanyID, comment.ID, time.Now().UnixNano(), name)
+	if err := save(path); err != nil {
+		return nil, err
+	}
+
+	uploaderID := viewer.UserID
+	attachment := &models.CommentAttachment{
+		CommentID:    comment.ID,
+		CompanyID:    comment.CompanyID,
+		FileName:     name,
+		ContentType:  contentType,
+		Size:         size,
+		StoragePath:  path,
+		UploadedByID: &uploaderID,
+	}
+	if err := s.repo.CreateAttachment(attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAttachment devuelve un adjunto si el lector puede ver su comentario.
+func (s *CommentService) GetAttachment(commentID, attachmentID uint, viewer domain.Viewer) (*models.CommentAttachment, error) {
+	if _, err := s.get(commentID, viewer); err != nil {
+		return nil, err
+	}
+	attachment, err := s.repo.GetAttachment(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil || attachment.CommentID != commentID {
+		return nil, apperr.NotFound("attachment not found")
+	}
+	return attachment, nil
+}
+
+// subjectCompany valida que el recurso exista y sea del tenant del lector.
+func (s *CommentService) subjectCompany(subject domain.Subject, viewer domain.Viewer) (uint, error) {
+	companyID, err := s.repo.SubjectCompanyID(subject)
+	if err != nil {
+		return 0, err
+	}
+	if companyID == 0 || (viewer.CompanyID != 0 && companyID != viewer.CompanyID) {
+		return 0, apperr.NotFound(subject.Type + " not found")
+	}
+	return companyID, nil
+}
+
+// get devuelve un comentario validando tenant y visibilidad (un interno es
+// 404 para quien no puede verlo).
+func (s *CommentService) get(id uint, viewer domain.Viewer) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || (viewer.CompanyID != 0 && comment.CompanyID != viewer.CompanyID) || !domain.Visible(*comment, viewer) {
+		return nil, apperr.NotFound("comment not found")
+	}
+	return comment, nil
+}
+
+// validateMentions exige que los mencionados sean miembros activos de la
+// empresa y, si el comentario es interno, que su rol pueda verlo.
+func (s *CommentService) validateMentions(companyID uint, userIDs []uint, internal bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	roles, err := s.repo.MemberRoles(companyID, userIDs)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		role, ok := roles[userID]
+		if !ok {
+			return apperr.BadRequest(fmt.Sprintf("user %d is not a member of this company", userID))
+		}
+		if internal && !permissions.Can(role, permissions.CommentsViewInternal) {
+			return apperr.BadRequest(fmt.Sprintf("user %d cannot see internal comments", userID))
+		}
+	}
+	return nil
+}
+
+// notifyMentions avisa a los mencionados. Es best-effort: el comentario ya
+// quedó guardado y no se revierte si la notificación falla.
+func (s *CommentService) notifyMentions(comment *models.Comment, subject domain.Subject, actorID uint, userIDs []uint) {
+	if s.notifier == nil || len(userIDs) == 0 {
+		return
+	}
+	title := "Te mencionaron en un comentario"
+	if names, err := s.repo.UserNames([]uint{actorID}); err == nil && names[actorID] != "" {
+		title = names[actorID] + " te mencionó en un comentario"
+	}
+
+	actor := actorID
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, models.Notification{
+			CompanyID:    comment.CompanyID,
+			UserID:       userID,
+			Type:         models.NotificationTypeMention,
+			Title:        title,
+			Body:         domain.Excerpt(comment.Body, notificationExcerpt),
+			ResourceType: subject.Type,
+			ResourceID:   subject.ID,
+			ActorID:      &actor,
+		})
+	}
+	_ = s.notifier.Notify(notifications)
+}
+
+// present arma el DTO de un comentario recién creado o editado.
+func (s *CommentService) present(comment models.Comment) (*dtos.CommentDTO, error) {
+	names, err := s.repo.UserNames(authorIDs([]models.Comment{comment}))
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(comment, names)
+	return &dto, nil
+}
+
+func toDTO(c models.Comment, names map[uint]string) dtos.CommentDTO {
+	dto := dtos.CommentDTO{
+		ID:          c.ID,
+		ParentID:    c.ParentID,
+		AuthorID:    c.AuthorID,
+		Body:        c.Body,
+		Internal:    c.Internal,
+		Source:      c.Source,
+		Version:     c.Version,
+		CreatedAt:   c.CreatedAt,
+		EditedAt:    c.EditedAt,
+		MentionIDs:  make([]uint, 0, len(c.Mentions)),
+		Attachments: c.Attachments,
+	}
+	if c.AuthorID != nil {
+		dto.AuthorName = names[*c.AuthorID]
+	}
+	for _, m := range c.Mentions {
+		dto.MentionIDs = append(dto.MentionIDs, m.UserID)
+	}
+	if dto.Attachments == nil {
+		dto.Attachments = []models.CommentAttachment{}
+	}
+	return dto
+}
+
+func authorIDs(comments []models.Comment) []uint {
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, c := range comments {
+		if c.AuthorID != nil && !seen[*c.AuthorID] {
+			seen[*c.AuthorID] = true
+			ids = append(ids, *c.AuthorID)
+		}
+	}
+	return ids
+}
+
+func subjectOf(c *models.Comment) domain.Subject {
+	if c.ApplicationID != nil {
+		return domain.Subject{Type: domain.SubjectApplication, ID: *c.ApplicationID}
+	}
+	if c.CandidateID != nil {
+		return domain.Subject{Type: domain.SubjectCandidate, ID: *c.CandidateID}
+	}
+	return domain.Subject{}
+}
+
+func sameSubject(a, b *models.Comment) bool {
+	return subjectOf(a) == subjectOf(b)
+}
@@ -0,0 +1,329 @@
+package transport
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/comment/domain"
+	"dvra-api/internal/modules/comment/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uploadsDir es la raíz local de los archivos subidos (ver UploadResume).
+const uploadsDir = "./uploads"
+
+type CommentHandler struct {
+	svc *service.CommentService
+}
+
+func NewCommentHandler(svc *service.CommentService) *CommentHandler {
+	return &CommentHandler{svc: svc}
+}
+
+// GetCandidateComments godoc
+// @Summary      Comentarios de un candidato
+// @Description  Hilos visibles para el usuario (los internos solo para admin/recruiter), en orden cronológico con sus respuestas
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      int  true  "ID del candidato"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/comments [get]
+func (h *CommentHandler) GetCandidateComments(c *gin.Context) {
+	h.list(c, domain.SubjectCandidate, "Invalid candidate ID")
+}
+
+// GetApplicationComments godoc
+// @Summary      Comentarios de una postulación
+// @Description  Hilos visibles para el usuario (los internos solo para admin/recruiter), en orden cronológico con sus respuestas
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      int  true  "ID de la postulación"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/comments [get]
+func (h *CommentHandler) GetApplicationComments(c *gin.Context) {
+	h.list(c, domain.SubjectApplication, "Invalid application ID")
+}
+
+// CreateCandidateComment godoc
+// @Summary      Comentar un candidato
+// @Description  Publica un comentario o una respuesta (parent_id). mention_ids notifica a miembros de la empresa; en un comentario interno solo pueden mencionarse roles que lo vean.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "ID del candidato"
+// @Param        comment  body      dtos.CreateCommentDTO  true  "Comentario"
+// @Success      201      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/comments [post]
+func (h *CommentHandler) CreateCandidateComment(c *gin.Context) {
+	h.create(c, domain.SubjectCandidate, "Invalid candidate ID")
+}
+
+// CreateApplicationComment godoc
+// @Summary      Comentar una postulación
+// @Description  Publica un comentario o una respuesta (parent_id). mention_ids notifica a miembros de la empresa; en un comentario interno solo pueden mencionarse roles que lo vean.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "ID de la postulación"
+// @Param        comment  body      dtos.CreateCommentDTO  true  "Comentario"
+// @Success      201      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/comments [post]
+func (h *CommentHandler) CreateApplicationComment(c *gin.Context) {
+	h.create(c, domain.SubjectApplication, "Invalid application ID")
+}
+
+// UpdateComment godoc
+// @Summary      Editar comentario
+// @Description  Solo el autor. Exige la versión leída (409 si otro lo editó); el texto anterior queda en el historial.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "ID del comentario"
+// @Param        comment  body      dtos.UpdateCommentDTO  true  "Nuevo texto"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /comments/{id} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+	var dto dtos.UpdateCommentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.svc.Update(id, viewer, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": comment})
+}
+
+// DeleteComment godoc
+// @Summary      Eliminar comentario
+// @Description  El autor elimina los propios; un admin, cualquiera. Eliminar la raíz elimina el hilo.
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      int  true  "ID del comentario"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(id, viewer); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Comment deleted"})
+}
+
+// GetCommentRevisions godoc
+// @Summary      Historial de ediciones de un comentario
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      int  true  "ID del comentario"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /comments/{id}/revisions [get]
+func (h *CommentHandler) GetCommentRevisions(c *gin.Context) {
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.svc.Revisions(id, viewer)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": revisions, "count": len(revisions)}})
+}
+
+// UploadCommentAttachment godoc
+// @Summary      Adjuntar archivo a un comentario
+// @Description  Solo el autor. PDF, Office, texto o imagen; hasta 10 MB y 10 adjuntos por comentario.
+// @Tags         Comments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      int   true  "ID del comentario"
+// @Param        file  formData  file  true  "Archivo"
+// @Success      201   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /comments/{id}/attachments [post]
+func (h *CommentHandler) UploadCommentAttachment(c *gin.Context) {
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	save := func(path string) error {
+		return c.SaveUploadedFile(file, filepath.Join(uploadsDir, path))
+	}
+	attachment, err := h.svc.AddAttachment(id, viewer, file.Filename, file.Header.Get("Content-Type"), file.Size, save)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": attachment})
+}
+
+// DownloadCommentAttachment godoc
+// @Summary      Descargar adjunto de un comentario
+// @Tags         Comments
+// @Produce      octet-stream
+// @Param        id             path  int  true  "ID del comentario"
+// @Param        attachment_id  path  int  true  "ID del adjunto"
+// @Success      200
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /comments/{id}/attachments/{attachment_id} [get]
+func (h *CommentHandler) DownloadCommentAttachment(c *gin.Context) {
+	id, ok := commentID(c)
+	if !ok {
+		return
+	}
+	attachmentID, err := strconv.ParseUint(c.Param("attachment_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.svc.GetAttachment(id, uint(attachmentID), viewer)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.FileAttachment(filepath.Join(uploadsDir, attachment.StoragePath), attachment.FileName)
+}
+
+func (h *CommentHandler) list(c *gin.Context, subjectType, invalidMsg string) {
+	subject, ok := subjectParam(c, subjectType, invalidMsg)
+	if !ok {
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	threads, err := h.svc.List(subject, viewer)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": threads, "count": len(threads)}})
+}
+
+func (h *CommentHandler) create(c *gin.Context, subjectType, invalidMsg string) {
+	subject, ok := subjectParam(c, subjectType, invalidMsg)
+	if !ok {
+		return
+	}
+	var dto dtos.CreateCommentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	viewer, ok := currentViewer(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.svc.Create(subject, viewer, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": comment})
+}
+
+// currentViewer arma el lector desde el token: usuario, tenant (0 =
+// SuperAdmin) y lo que su rol permite sobre comentarios.
+func currentViewer(c *gin.Context) (domain.Viewer, bool) {
+	userID, ok := authctx.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "No user context"})
+		return domain.Viewer{}, false
+	}
+	viewer := domain.Viewer{UserID: userID}
+	if !authctx.IsSuperAdmin(c) {
+		companyID, ok := authctx.CompanyID(c)
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+			return domain.Viewer{}, false
+		}
+		viewer.CompanyID = companyID
+	}
+	role := authctx.Role(c)
+	viewer.CanSeeInternal = permissions.Can(role, permissions.CommentsViewInternal)
+	viewer.CanModerate = permissions.Can(role, permissions.CommentsModerate)
+	return viewer, true
+}
+
+func subjectParam(c *gin.Context, subjectType, invalidMsg string) (domain.Subject, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return domain.Subject{}, false
+	}
+	return domain.Subject{Type: subjectType, ID: uint(id)}, true
+}
+
+func commentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/comment/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg). La
+// visibilidad de internos, la autoría y la moderación se resuelven en el servicio.
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.CommentService) {
+	h := NewCommentHandler(svc)
+
+	candidates := rg.Group("/candidates")
+	{
+		candidates.GET("/:id/comments", middleware.RequirePermission(permissions.CommentsView), h.GetCandidateComments)
+		candidates.POST("/:id/comments", middleware.RequirePermission(permissions.CommentsCreate), h.CreateCandidateComment)
+	}
+
+	applications := rg.Group("/applications")
+	{
+		applications.GET("/:id/comments", middleware.RequirePermission(permissions.CommentsView), h.GetApplicationComments)
+		applications.POST("/:id/comments", middleware.RequirePermission(permissions.CommentsCreate), h.CreateApplicationComment)
+	}
+
+	comments := rg.Group("/comments")
+	{
+		comments.PUT("/:id", middleware.RequirePermission(permissions.CommentsCreate), h.UpdateComment)
+		comments.DELETE("/:id", middleware.RequirePermission(permissions.CommentsCreate), h.DeleteComment)
+		comments.GET("/:id/revisions", middleware.RequirePermission(permissions.CommentsView), h.GetCommentRevisions)
+		comments.POST("/:id/attachments", middleware.RequirePermission(permissions.CommentsCreate), h.UploadCommentAttachment)
+		comments.GET("/:id/attachments/:attachment_id", middleware.RequirePermission(permissions.CommentsView), h.DownloadCommentAttachment)
+	}
+}
@@ -0,0 +1,225 @@
+// Package domain define el centro del módulo dedup (candidatos duplicados):
+// la normalización de los datos de contacto, el puntaje de duplicado entre
+// dos candidatos y el puerto hacia la persistencia de las fusiones. No
+// importa gin ni gorm.
+package domain
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// Señales de duplicado y su peso en el puntaje (tope 100). Un email o un
+// perfil iguales bastan para sugerir la fusión; el teléfono solo, no.
+const (
+	ReasonEmail    = "email"
+	ReasonLinkedin = "linkedin"
+	ReasonGithub   = "github"
+	ReasonPhone    = "phone"
+	ReasonName     = "name"
+)
+
+var weights = map[string]int{
+	ReasonEmail:    60,
+	ReasonLinkedin: 50,
+	ReasonGithub:   50,
+	ReasonPhone:    35,
+	ReasonName:     15,
+}
+
+// reasonOrder fija el orden de Reasons en la respuesta.
+var reasonOrder = []string{ReasonEmail, ReasonLinkedin, ReasonGithub, ReasonPhone, ReasonName}
+
+const (
+	// MaxScore es el puntaje máximo de un par.
+	MaxScore = 100
+	// DefaultMinScore es el umbral por defecto de la lista de duplicados.
+	DefaultMinScore = 50
+	// DefaultPairLimit acota los pares que devuelve la búsqueda.
+	DefaultPairLimit = 100
+
+	// phoneDigits son los dígitos finales que se comparan: así coinciden
+	// el mismo número con y sin código de país.
+	phoneDigits = 10
+	// minPhoneDigits descarta teléfonos incompletos.
+	minPhoneDigits = 7
+)
+
+// UndoWindow es el plazo para deshacer una fusión.
+const UndoWindow = 30 * 24 * time.Hour
+
+// Pair es un par de candidatos posiblemente duplicados (CandidateID <
+// OtherID) con su puntaje y las señales que coinciden.
+type Pair struct {
+	CandidateID uint
+	OtherID     uint
+	Score       int
+	Reasons     []string
+}
+
+// NormalizeEmail unifica un email para comparar: minúsculas, sin la parte
+// "+etiqueta" y, en Gmail, sin puntos en el usuario.
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+	user, host := email[:at], email[at+1:]
+	if plus := strings.IndexByte(user, '+'); plus > 0 {
+		user = user[:plus]
+	}
+	if host == "gmail.com" || host == "googlemail.com" {
+		user = strings.ReplaceAll(user, ".", "")
+		host = "gmail.com"
+	}
+	return user + "@" + host
+}
+
+// NormalizePhone deja los últimos dígitos del teléfono; "" si tiene menos
+// de los necesarios para identificar a alguien.
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if len(digits) < minPhoneDigits {
+		return ""
+	}
+	if len(digits) > phoneDigits {
+		digits = digits[len(digits)-phoneDigits:]
+	}
+	return digits
+}
+
+// NormalizeProfileURL reduce una URL de perfil a host + ruta en minúsculas,
+// sin esquema, "www.", subdominio de idioma o móvil, query ni barra final:
+// "https://es.linkedin.com/in/Ana/?x=1" y "linkedin.com/in/ana" coinciden.
+func NormalizeProfileURL(raw string) string {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	if parts := strings.SplitN(host, ".", 2); len(parts) == 2 && strings.Count(parts[1], ".") >= 1 && (len(parts[0]) == 2 || parts[0] == "m") {
+		host = parts[1]
+	}
+	path := strings.TrimRight(u.Path, "/")
+	if path == "" {
+		return ""
+	}
+	return host + path
+}
+
+// normalizeName une nombre y apellido en minúsculas con espacios simples.
+func normalizeName(c *models.Candidate) string {
+	return strings.Join(strings.Fields(strings.ToLower(c.FirstName+" "+c.LastName)), " ")
+}
+
+// keys devuelve la clave normalizada de cada señal del candidato (solo las
+// no vacías).
+func keys(c *models.Candidate) map[string]string {
+	k := make(map[string]string, len(reasonOrder))
+	for reason, value := range map[string]string{
+		ReasonEmail:    NormalizeEmail(c.Email),
+		ReasonLinkedin: NormalizeProfileURL(c.LinkedinURL),
+		ReasonGithub:   NormalizeProfileURL(c.GithubURL),
+		ReasonPhone:    NormalizePhone(c.Phone),
+		ReasonName:     normalizeName(c),
+	} {
+		if value != "" {
+			k[reason] = value
+		}
+	}
+	return k
+}
+
+// Score compara dos candidatos y devuelve el puntaje (0–100) y las señales
+// que coinciden. El nombre solo suma si coincide alguna otra señal: dos
+// homónimos no son un duplicado.
+func Score(a, b *models.Candidate) (int, []string) {
+	return scoreKeys(keys(a), keys(b))
+}
+
+func scoreKeys(a, b map[string]string) (int, []string) {
+	score := 0
+	var reasons []string
+	for _, reason := range reasonOrder {
+		if v, ok := a[reason]; ok && v == b[reason] {
+			score += weights[reason]
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) == 1 && reasons[0] == ReasonName {
+		return 0, nil
+	}
+	if score > MaxScore {
+		score = MaxScore
+	}
+	return score, reasons
+}
+
+// FindPairs busca los pares de candidatos con puntaje >= minScore, de mayor
+// a menor puntaje (limit 0 = sin límite). Solo compara candidatos que
+// comparten al menos una señal fuerte (email, perfil o teléfono).
+func FindPairs(candidates []models.Candidate, minScore, limit int) []Pair {
+	candidateKeys := make([]map[string]string, len(candidates))
+	buckets := make(map[string][]int)
+	for i := range candidates {
+		candidateKeys[i] = keys(&candidates[i])
+		for reason, value := range candidateKeys[i] {
+			if reason != ReasonName {
+				buckets[reason+"\x00"+value] = append(buckets[reason+"\x00"+value], i)
+			}
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs []Pair
+	for _, bucket := range buckets {
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				i, j := bucket[x], bucket[y]
+				if candidates[i].ID > candidates[j].ID {
+					i, j = j, i
+				}
+				if seen[[2]int{i, j}] {
+					continue
+				}
+				seen[[2]int{i, j}] = true
+				score, reasons := scoreKeys(candidateKeys[i], candidateKeys[j])
+				if score >= minScore && score > 0 {
+					pairs = append(pairs, Pair{CandidateID: candidates[i].ID, OtherID: candidates[j].ID, Score: score, Reasons: reasons})
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].CandidateID != pairs[j].CandidateID {
+			return pairs[i].CandidateID < pairs[j].CandidateID
+		}
+		return pairs[i].OtherID < pairs[j].OtherID
+	})
+	if limit > 0 && len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestNormalize(t *testing.T) {
+	emails := map[string]string{
+		" Ana.Perez+jobs@GMail.com ": "anaperez@gmail.com",
+		"ana.perez@googlemail.com":   "anaperez@gmail.com",
+		"ana.perez+x@acme.com":       "ana.perez@acme.com",
+	}
+	for in, want := range emails {
+		if got := NormalizeEmail(in); got != want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	phones := map[string]string{
+		"+57 (300) 123-4567": "3001234567",
+		"300 123 4567":       "3001234567",
+		"12345":              "",
+	}
+	for in, want := range phones {
+		if got := NormalizePhone(in); got != want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	urls := map[string]string{
+		"https://es.linkedin.com/in/Ana-Perez/?trk=x": "linkedin.com/in/ana-perez",
+		"www.linkedin.com/in/ana-perez":               "linkedin.com/in/ana-perez",
+		"http://github.com/anaperez/":                 "github.com/anaperez",
+		"https://github.com/":                         "",
+	}
+	for in, want := range urls {
+		if got := NormalizeProfileURL(in); got != want {
+			t.Errorf("NormalizeProfileURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	ana := &models.Candidate{Email: "ana.perez@gmail.com", FirstName: "Ana", LastName: "Pérez", Phone: "+57 300 123 4567", LinkedinURL: "linkedin.com/in/ana"}
+
+	cases := []struct {
+		name    string
+		other   models.Candidate
+		score   int
+		reasons []string
+	}{
+		{"email con alias", models.Candidate{Email: "anaperez+cv@gmail.com"}, 60, []string{ReasonEmail}},
+		{"perfil y teléfono", models.Candidate{Email: "ana@acme.com", Phone: "3001234567", LinkedinURL: "https://www.linkedin.com/in/ana/"}, 85, []string{ReasonLinkedin, ReasonPhone}},
+		{"teléfono y nombre", models.Candidate{Email: "ana@acme.com", FirstName: "ana", LastName: "pérez", Phone: "300-123-4567"}, 50, []string{ReasonPhone, ReasonName}},
+		{"solo homónimo", models.Candidate{Email: "otra@acme.com", FirstName: "Ana", LastName: "Pérez"}, 0, nil},
+		{"todo", models.Candidate{Email: "ana.perez@gmail.com", FirstName: "Ana", LastName: "Pérez", Phone: "3001234567", LinkedinURL: "linkedin.com/in/ana"}, 100, []string{ReasonEmail, ReasonLinkedin, ReasonPhone, ReasonName}},
+	}
+	for _, c := range cases {
+		score, reasons := Score(ana, &c.other)
+		if score != c.score || !reflect.DeepEqual(reasons, c.reasons) {
+			t.Errorf("%s: Score = %d %v, want %d %v", c.name, score, reasons, c.score, c.reasons)
+		}
+	}
+}
+
+func TestFindPairs(t *testing.T) {
+	candidates := []models.Candidate{
+		{Email: "ana@acme.com", Phone: "3001234567"},
+		{Email: "ANA@acme.com"},
+		{Email: "otra@acme.com", Phone: "300 123 4567"},
+		{Email: "luis@acme.com"},
+	}
+	for i := range candidates {
+		candidates[i].ID = uint(i + 1)
+	}
+
+	pairs := FindPairs(candidates, DefaultMinScore, 0)
+	if len(pairs) != 1 || pairs[0].CandidateID != 1 || pairs[0].OtherID != 2 || pairs[0].Score != 60 {
+		t.Fatalf("FindPairs = %+v", pairs)
+	}
+	if pairs := FindPairs(candidates, 30, 0); len(pairs) != 2 || pairs[1].OtherID != 3 {
+		t.Errorf("FindPairs(min 30) = %+v", pairs)
+	}
+}
+
+func TestFillEmptyAndUnfill(t *testing.T) {
+	consent := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	survivor := &models.Candidate{Email: "ana@acme.com", FirstName: "Ana", GithubURL: "github.com/ana"}
+	merged := &models.Candidate{Email: "ana@gmail.com", FirstName: "Anita", Phone: "3001234567", ResumeURL: "/cv.pdf", GithubURL: "github.com/other", TalentPoolConsentAt: &consent}
+
+	filled := FillEmpty(survivor, merged)
+	if want := []string{"phone", "resume_url", talentPoolColumn}; !reflect.DeepEqual(filled, want) {
+		t.Fatalf("FillEmpty = %v, want %v", filled, want)
+	}
+	if survivor.FirstName != "Ana" || survivor.Phone != "3001234567" || survivor.TalentPoolConsentAt == nil {
+		t.Fatalf("sobreviviente = %+v", survivor)
+	}
+
+	// El teléfono se editó después de la fusión: se respeta.
+	survivor.Phone = "3119998877"
+	cleared := Unfill(survivor, merged, filled)
+	if want := []string{"resume_url", talentPoolColumn}; !reflect.DeepEqual(cleared, want) {
+		t.Fatalf("Unfill = %v, want %v", cleared, want)
+	}
+	if survivor.Phone != "3119998877" || survivor.ResumeURL != "" || survivor.TalentPoolConsentAt != nil {
+		t.Errorf("sobreviviente tras deshacer = %+v", survivor)
+	}
+}
+
+func TestCanUndo(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	merge := &models.CandidateMerge{UndoDeadline: now.Add(time.Hour)}
+	if !CanUndo(merge, now) {
+		t.Error("CanUndo dentro del plazo = false")
+	}
+	if CanUndo(merge, now.Add(2*time.Hour)) {
+		t.Error("CanUndo vencida = true")
+	}
+	merge.UndoneAt = &now
+	if CanUndo(merge, now) {
+		t.Error("CanUndo ya deshecha = true")
+	}
+}
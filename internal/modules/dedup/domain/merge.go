@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// fillable son los campos del candidato que la fusión completa con los del
+// duplicado cuando el sobreviviente los tiene vacíos (columna → campo). El
+// email no: es el del sobreviviente.
+var fillable = []struct {
+	column string
+	field  func(c *models.Candidate) *string
+}{
+	{"first_name", func(c *models.Candidate) *string { return &c.FirstName }},
+	{"last_name", func(c *models.Candidate) *string { return &c.LastName }},
+	{"phone", func(c *models.Candidate) *string { return &c.Phone }},
+	{"resume_url", func(c *models.Candidate) *string { return &c.ResumeURL }},
+	{"resume_text", func(c *models.Candidate) *string { return &c.ResumeText }},
+	{"github_url", func(c *models.Candidate) *string { return &c.GithubURL }},
+	{"linkedin_url", func(c *models.Candidate) *string { return &c.LinkedinURL }},
+	{"source", func(c *models.Candidate) *string { return &c.Source }},
+	{"work_mode", func(c *models.Candidate) *string { return &c.WorkMode }},
+}
+
+// talentPoolColumn se completa aparte: acompaña a los consentimientos que
+// pasan al sobreviviente.
+const talentPoolColumn = "talent_pool_consent_at"
+
+// FillEmpty completa los campos vacíos de survivor con los de merged y
+// devuelve las columnas que cambió.
+func FillEmpty(survivor, merged *models.Candidate) []string {
+	var columns []string
+	for _, f := range fillable {
+		dst, src := f.field(survivor), f.field(merged)
+		if *dst == "" && *src != "" {
+			*dst = *src
+			columns = append(columns, f.column)
+		}
+	}
+	if survivor.TalentPoolConsentAt == nil && merged.TalentPoolConsentAt != nil {
+		at := *merged.TalentPoolConsentAt
+		survivor.TalentPoolConsentAt = &at
+		columns = append(columns, talentPoolColumn)
+	}
+	return columns
+}
+
+// Unfill revierte FillEmpty al deshacer: vacía las columnas filled de
+// survivor que siguen con el valor de merged (las que se editaron después
+// de la fusión se respetan). Devuelve las columnas que cambió.
+func Unfill(survivor, merged *models.Candidate, filled []string) []string {
+	var columns []string
+	for _, column := range filled {
+		if column == talentPoolColumn {
+			if sameTime(survivor.TalentPoolConsentAt, merged.TalentPoolConsentAt) {
+				survivor.TalentPoolConsentAt = nil
+				columns = append(columns, column)
+			}
+			continue
+		}
+		for _, f := range fillable {
+			if f.column == column && *f.field(survivor) == *f.field(merged) {
+				*f.field(survivor) = ""
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns
+}
+
+// CanUndo reporta si la fusión sigue dentro de su plazo y no se deshizo.
+func CanUndo(merge *models.CandidateMerge, now time.Time) bool {
+	return merge.UndoneAt == nil && now.Before(merge.UndoDeadline)
+}
+
+func sameTime(a, b *time.Time) bool {
+	return a != nil && b != nil && a.Equal(*b)
+}
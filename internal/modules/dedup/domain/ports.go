@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// Errores de la fusión que el repositorio detecta dentro de la transacción
+// (con los candidatos bloqueados).
+var (
+	// ErrCandidateUnavailable: alguno de los candidatos ya no está activo,
+	// es de otra empresa o fue anonimizado.
+	ErrCandidateUnavailable = errors.New("candidate is not available for merging")
+	// ErrSharedJob: ambos candidatos postularon a la misma vacante.
+	ErrSharedJob = errors.New("both candidates applied to the same job")
+	// ErrMergeNotUndoable: la fusión ya se deshizo o venció su plazo, el
+	// sobreviviente ya no está activo (p. ej. se fusionó en otro), el
+	// duplicado se restauró o purgó desde la papelera o su email ya lo usa
+	// otro candidato.
+	ErrMergeNotUndoable = errors.New("merge can no longer be undone")
+)
+
+// MergeFilter filtra el historial de fusiones.
+type MergeFilter struct {
+	CandidateID uint // sobreviviente o duplicado
+	Limit       int
+}
+
+// MergeRepository es el puerto de salida hacia la persistencia.
+type MergeRepository interface {
+	// Profiles devuelve los candidatos activos y no anonimizados de la
+	// empresa.
+	Profiles(companyID uint) ([]models.Candidate, error)
+	// GetCandidate devuelve un candidato activo; nil si no existe.
+	GetCandidate(id uint) (*models.Candidate, error)
+	// Merge fusiona merge.MergedID en merge.SurvivorID en una transacción:
+	// mueve postulaciones, colocaciones, comentarios, consentimientos,
+	// etiquetas y talent pools, completa los campos vacíos del sobreviviente, elimina el
+	// duplicado y guarda merge con lo que movió.
+	Merge(merge *models.CandidateMerge) error
+	// Undo revierte la fusión si todavía es posible (ErrMergeNotUndoable).
+	Undo(mergeID, userID uint, now time.Time) (*models.CandidateMerge, error)
+	// GetMerge devuelve una fusión; nil si no existe.
+	GetMerge(id uint) (*models.CandidateMerge, error)
+	// ListMerges devuelve el historial de fusiones de la empresa, de la más
+	// reciente a la más antigua.
+	ListMerges(companyID uint, filter MergeFilter) ([]models.CandidateMerge, error)
+}
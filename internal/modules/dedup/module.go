@@ -0,0 +1,29 @@
+// Package dedup es el punto de ensamblaje del módulo de candidatos
+// duplicados: detección por email, teléfono y perfiles normalizados, y
+// fusión reversible de dos candidatos.
+// Nadie importa este paquete salvo el composition root.
+package dedup
+
+import (
+	"dvra-api/internal/modules/dedup/repository"
+	"dvra-api/internal/modules/dedup/service"
+	"dvra-api/internal/modules/dedup/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo dedup.
+type Module struct {
+	svc *service.MergeService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{svc: service.NewMergeService(repository.NewMergeRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.svc)
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/dedup/domain"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mergeRepository struct {
+	db *gorm.DB
+}
+
+// NewMergeRepository devuelve la implementación del puerto.
+func NewMergeRepository(db *gorm.DB) domain.MergeRepository {
+	return &mergeRepository{db: db}
+}
+
+func (r *mergeRepository) Profiles(companyID uint) ([]models.Candidate, error) {
+	var candidates []models.Candidate
+	if err := r.db.Where("company_id = ? AND anonymized_at IS NULL", companyID).Order("id ASC").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *mergeRepository) GetCandidate(id uint) (*models.Candidate, error) {
+	var candidate models.Candidate
+	if err := r.db.First(&candidate, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+// movedTable es una tabla cuyas filas siguen al candidato en una fusión y
+// el campo de CandidateMerge que guarda las que se movieron.
+type movedTable struct {
+	model interface{}
+	ids   *datatypes.JSONSlice[uint]
+}
+
+func moved(m *models.CandidateMerge) []movedTable {
+	return []movedTable{
+		{&models.Application{}, &m.MovedApplicationIDs},
+		{&models.Placement{}, &m.MovedPlacementIDs},
+		{&models.Comment{}, &m.MovedCommentIDs},
+		{&models.CandidateConsent{}, &m.MovedConsentIDs},
+		{&models.ResumeParse{}, &m.MovedResumeParseIDs},
+	}
+}
+
+// uniqueMovedTable es una tabla con una fila por (candidato, key): solo se
+// mueven las filas cuyo key el sobreviviente todavía no tiene.
+type uniqueMovedTable struct {
+	model interface{}
+	key   string
+	ids   *datatypes.JSONSlice[uint]
+}
+
+func uniqueMoved(m *models.CandidateMerge) []uniqueMovedTable {
+	return []uniqueMovedTable{
+		{&models.CandidateTag{}, "tag_id", &m.MovedTagIDs},
+		{&models.TalentPoolMember{}, "pool_id", &m.MovedPoolMemberIDs},
+		{&models.CandidateSkill{}, "skill_id", &m.MovedSkillIDs},
+	}
+}
+
+func (r *mergeRepository) Merge(merge *models.CandidateMerge) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Bloquear ambos candidatos (en orden de id) impide fusionarlos dos
+		// veces o editarlos a mitad de la fusión.
+		var locked []models.Candidate
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{merge.SurvivorID, merge.MergedID}).
+			Order("id ASC").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return domain.ErrCandidateUnavailable
+		}
+		var survivor, duplicate *models.Candidate
+		for i := range locked {
+			if locked[i].CompanyID != merge.CompanyID || locked[i].AnonymizedAt != nil {
+				return domain.ErrCandidateUnavailable
+			}
+			if locked[i].ID == merge.SurvivorID {
+				survivor = &locked[i]
+			} else {
+				duplicate = &locked[i]
+			}
+		}
+
+		var jobIDs []uint
+		if err := tx.Model(&models.Application{}).
+			Where("candidate_id = ?", merge.MergedID).
+			Where("job_id IN (?)", tx.Model(&models.Application{}).Select("job_id").Where("candidate_id = ?", merge.SurvivorID)).
+			Limit(1).
+			Pluck("job_id", &jobIDs).Error; err != nil {
+			return err
+		}
+		if len(jobIDs) > 0 {
+			return fmt.Errorf("%w (job %d)", domain.ErrSharedJob, jobIDs[0])
+		}
+
+		for _, t := range moved(merge) {
+			var ids []uint
+			if err := tx.Model(t.model).Where("candidate_id = ?", merge.MergedID).Order("id ASC").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if err := repoint(tx, t.model, ids, merge.MergedID, merge.SurvivorID); err != nil {
+				return err
+			}
+			*t.ids = ids
+		}
+
+		// Las etiquetas, los talent pools y las habilidades que el
+		// sobreviviente ya tiene se quedan con el duplicado: (candidate_id,
+		// tag_id), (pool_id, candidate_id) y (candidate_id, skill_id) son
+		// únicos.
+		for _, u := range uniqueMoved(merge) {
+			var ids []uint
+			if err := tx.Model(u.model).
+				Where("candidate_id = ?", merge.MergedID).
+				Where(u.key+" NOT IN (?)", tx.Unscoped().Model(u.model).Select(u.key).Where("candidate_id = ?", merge.SurvivorID)).
+				Order("id ASC").
+				Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if err := repoint(tx, u.model, ids, merge.MergedID, merge.SurvivorID); err != nil {
+				return err
+			}
+			*u.ids = ids
+		}
+
+		merge.FilledFields = domain.FillEmpty(survivor, duplicate)
+		if len(merge.FilledFields) > 0 {
+			if err := tx.Model(survivor).Select(merge.FilledFields).Updates(survivor).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&models.Candidate{}, merge.MergedID).Error; err != nil {
+			return err
+		}
+		return tx.Create(merge).Error
+	})
+}
+
+func (r *mergeRepository) Undo(mergeID, userID uint, now time.Time) (*models.CandidateMerge, error) {
+	var merge models.CandidateMerge
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&merge, mergeID).Error; err != nil {
+			return err
+		}
+		if !domain.CanUndo(&merge, now) {
+			return domain.ErrMergeNotUndoable
+		}
+
+		var survivor, duplicate models.Candidate
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&survivor, merge.SurvivorID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return domain.ErrMergeNotUndoable
+			}
+			return err
+		}
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&duplicate, merge.MergedID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return domain.ErrMergeNotUndoable
+			}
+			return err
+		}
+		if !duplicate.DeletedAt.Valid {
+			return domain.ErrMergeNotUndoable
+		}
+		var taken int64
+		if err := tx.Model(&models.Candidate{}).
+			Where("company_id = ? AND LOWER(email) = LOWER(?)", duplicate.CompanyID, duplicate.Email).
+			Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return domain.ErrMergeNotUndoable
+		}
+
+		// Solo vuelven las filas que movió la fusión y que siguen en el
+		// sobreviviente.
+		for _, t := range moved(&merge) {
+			if err := repoint(tx, t.model, *t.ids, merge.SurvivorID, merge.MergedID); err != nil {
+				return err
+			}
+		}
+		for _, u := range uniqueMoved(&merge) {
+			if err := repoint(tx, u.model, *u.ids, merge.SurvivorID, merge.MergedID); err != nil {
+				return err
+			}
+		}
+
+		if columns := domain.Unfill(&survivor, &duplicate, merge.FilledFields); len(columns) > 0 {
+			if err := tx.Model(&survivor).Select(columns).Updates(&survivor).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Model(&models.Candidate{}).Where("id = ?", duplicate.ID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		merge.UndoneAt = &now
+		merge.UndoneByID = &userID
+		return tx.Save(&merge).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &merge, nil
+}
+
+// repoint cambia el candidato de las filas ids que siguen apuntando a from.
+// No toca updated_at: fusionar no cuenta como actividad de la postulación.
+func repoint(tx *gorm.DB, model interface{}, ids []uint, from, to uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(model).Where("id IN ? AND candidate_id = ?", ids, from).UpdateColumn("candidate_id", to).Error
+}
+
+func (r *mergeRepository) GetMerge(id uint) (*models.CandidateMerge, error) {
+	var merge models.CandidateMerge
+	if err := r.db.First(&merge, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &merge, nil
+}
+
+func (r *mergeRepository) ListMerges(companyID uint, filter domain.MergeFilter) ([]models.CandidateMerge, error) {
+	query := r.db.Where("company_id = ?", companyID)
+	if filter.CandidateID != 0 {
+		query = query.Where("(survivor_id = ? OR merged_id = ?)", filter.CandidateID, filter.CandidateID)
+	}
+	var merges []models.CandidateMerge
+	if err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&merges).Error; err != nil {
+		return nil, err
+	}
+	return merges, nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/dedup/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// maxMergeHistory acota el historial de fusiones que se devuelve.
+const maxMergeHistory = 200
+
+// MergeService detecta candidatos duplicados de una empresa y los fusiona:
+// el duplicado se elimina y su historial pasa al sobreviviente. Cada fusión
+// queda registrada y se puede deshacer durante domain.UndoWindow.
+type MergeService struct {
+	repo domain.MergeRepository
+	now  func() time.Time
+}
+
+func NewMergeService(repo domain.MergeRepository) *MergeService {
+	return &MergeService{repo: repo, now: time.Now}
+}
+
+// FindDuplicates devuelve los pares de posibles duplicados de la empresa, de
+// mayor a menor puntaje.
+func (s *MergeService) FindDuplicates(companyID uint, query dtos.DuplicateQuery) ([]dtos.DuplicatePairDTO, error) {
+	minScore, limit := duplicateQuery(query)
+	candidates, err := s.repo.Profiles(companyID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.Candidate, len(candidates))
+	for i := range candidates {
+		byID[candidates[i].ID] = &candidates[i]
+	}
+
+	pairs := domain.FindPairs(candidates, minScore, limit)
+	result := make([]dtos.DuplicatePairDTO, len(pairs))
+	for i, p := range pairs {
+		result[i] = dtos.DuplicatePairDTO{
+			Score:     p.Score,
+			Reasons:   p.Reasons,
+			Candidate: dtos.ToCandidateResponse(byID[p.CandidateID]),
+			Other:     dtos.ToCandidateResponse(byID[p.OtherID]),
+		}
+	}
+	return result, nil
+}
+
+// CandidateDuplicates devuelve los posibles duplicados de un candidato.
+// companyID 0 = cualquier empresa (SuperAdmin).
+func (s *MergeService) CandidateDuplicates(id, companyID uint, query dtos.DuplicateQuery) ([]dtos.DuplicateMatchDTO, error) {
+	minScore, limit := duplicateQuery(query)
+	candidate, err := s.candidate(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.repo.Profiles(candidate.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []dtos.DuplicateMatchDTO{}
+	for i := range candidates {
+		if candidates[i].ID == candidate.ID {
+			continue
+		}
+		score, reasons := domain.Score(candidate, &candidates[i])
+		if score >= minScore && score > 0 {
+			matches = append(matches, dtos.DuplicateMatchDTO{Score: score, Reasons: reasons, Candidate: dtos.ToCandidateResponse(&candidates[i])})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// Merge fusiona el duplicado en el candidato survivorID (que sobrevive).
+// Ambos deben ser de la misma empresa, estar activos, no estar anonimizados
+// y no haber postulado a la misma vacante.
+func (s *MergeService) Merge(survivorID, companyID, actorID uint, dto dtos.MergeCandidateDTO) (*models.CandidateMerge, error) {
+	if dto.DuplicateID == survivorID {
+		return nil, apperr.BadRequest("a candidate cannot be merged into itself")
+	}
+	survivor, err := s.candidate(survivorID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.candidate(dto.DuplicateID, survivor.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if survivor.AnonymizedAt != nil || duplicate.AnonymizedAt != nil {
+		return nil, apperr.Unprocessable("anonymized candidates cannot be merged")
+	}
+
+	score, reasons := domain.Score(survivor, duplicate)
+	merge := &models.CandidateMerge{
+		CompanyID:    survivor.CompanyID,
+		SurvivorID:   survivor.ID,
+		MergedID:     duplicate.ID,
+		Score:        score,
+		Reasons:      reasons,
+		MergedByID:   actorID,
+		UndoDeadline: s.now().Add(domain.UndoWindow),
+	}
+	if err := s.repo.Merge(merge); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSharedJob):
+			return nil, apperr.Conflict(err.Error() + "; delete or withdraw one of the applications first")
+		case errors.Is(err, domain.ErrCandidateUnavailable):
+			return nil, apperr.Conflict("one of the candidates changed or was deleted; reload and try again")
+		}
+		return nil, err
+	}
+	return merge, nil
+}
+
+// Undo deshace una fusión dentro de su plazo: el duplicado vuelve con las
+// filas que se movieron y el sobreviviente pierde los campos que había
+// tomado de él (si nadie los editó después).
+func (s *MergeService) Undo(mergeID, companyID, actorID uint) (*models.CandidateMerge, error) {
+	merge, err := s.GetMerge(mergeID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanUndo(merge, s.now()) {
+		return nil, apperr.Conflict("this merge was already undone or its undo window expired")
+	}
+
+	merge, err = s.repo.Undo(merge.ID, actorID, s.now())
+	if err != nil {
+		if errors.Is(err, domain.ErrMergeNotUndoable) {
+			return nil, apperr.Conflict("this merge can no longer be undone: one of the candidates changed after it (deleted, merged again, restored from the trash or its email reused)")
+		}
+		return nil, err
+	}
+	if merge == nil {
+		return nil, apperr.NotFound("merge not found")
+	}
+	return merge, nil
+}
+
+// GetMerge devuelve una fusión de la empresa (companyID 0 = cualquiera).
+func (s *MergeService) GetMerge(id, companyID uint) (*models.CandidateMerge, error) {
+	merge, err := s.repo.GetMerge(id)
+	if err != nil {
+		return nil, err
+	}
+	if merge == nil || (companyID != 0 && merge.CompanyID != companyID) {
+		return nil, apperr.NotFound("merge not found")
+	}
+	return merge, nil
+}
+
+// ListMerges devuelve el historial de fusiones de la empresa, opcionalmente
+// de un candidato (como sobreviviente o como duplicado).
+func (s *MergeService) ListMerges(companyID, candidateID uint, limit int) ([]models.CandidateMerge, error) {
+	if limit <= 0 || limit > maxMergeHistory {
+		limit = maxMergeHistory
+	}
+	return s.repo.ListMerges(companyID, domain.MergeFilter{CandidateID: candidateID, Limit: limit})
+}
+
+// candidate devuelve un candidato activo de la empresa (0 = cualquiera).
+func (s *MergeService) candidate(id, companyID uint) (*models.Candidate, error) {
+	candidate, err := s.repo.GetCandidate(id)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || (companyID != 0 && candidate.CompanyID != companyID) {
+		return nil, apperr.NotFound("candidate not found")
+	}
+	return candidate, nil
+}
+
+func duplicateQuery(query dtos.DuplicateQuery) (int, int) {
+	minScore, limit := query.MinScore, query.Limit
+	if minScore == 0 {
+		minScore = domain.DefaultMinScore
+	}
+	if limit == 0 {
+		limit = domain.DefaultPairLimit
+	}
+	return minScore, limit
+}
@@ -0,0 +1,181 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/dedup/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MergeHandler struct {
+	svc *service.MergeService
+}
+
+func NewMergeHandler(svc *service.MergeService) *MergeHandler {
+	return &MergeHandler{svc: svc}
+}
+
+// GetDuplicates godoc
+// @Summary      Buscar candidatos duplicados
+// @Description  Pares de candidatos de la empresa que comparten email normalizado, teléfono o perfil de LinkedIn/GitHub, de mayor a menor puntaje (0–100). El nombre solo suma junto a otra señal.
+// @Tags         Candidates
+// @Produce      json
+// @Param        min_score   query     int  false  "Puntaje mínimo (por defecto 50)"
+// @Param        limit       query     int  false  "Máximo de pares (por defecto 100, hasta 500)"
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/duplicates [get]
+func (h *MergeHandler) GetDuplicates(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var query dtos.DuplicateQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pairs, err := h.svc.FindDuplicates(companyID, query)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": pairs, "count": len(pairs)}})
+}
+
+// GetCandidateDuplicates godoc
+// @Summary      Posibles duplicados de un candidato
+// @Tags         Candidates
+// @Produce      json
+// @Param        id         path      int  true   "ID del candidato"
+// @Param        min_score  query     int  false  "Puntaje mínimo (por defecto 50)"
+// @Param        limit      query     int  false  "Máximo de resultados (por defecto 100, hasta 500)"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/duplicates [get]
+func (h *MergeHandler) GetCandidateDuplicates(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+	var query dtos.DuplicateQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	matches, err := h.svc.CandidateDuplicates(id, companyID, query)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": matches, "count": len(matches)}})
+}
+
+// MergeCandidate godoc
+// @Summary      Fusionar un candidato duplicado
+// @Description  Fusiona duplicate_id en el candidato de la ruta, que sobrevive: sus postulaciones, colocaciones, comentarios, consentimientos y etiquetas pasan al sobreviviente, que completa sus campos vacíos con los del duplicado. El duplicado se elimina. Se puede deshacer durante 30 días. 409 si ambos postularon a la misma vacante.
+// @Tags         Candidates
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                         true  "ID del candidato que sobrevive"
+// @Param        merge  body      dtos.MergeCandidateDTO      true  "Duplicado"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/merge [post]
+func (h *MergeHandler) MergeCandidate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	var dto dtos.MergeCandidateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	merge, err := h.svc.Merge(id, companyID, userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": merge})
+}
+
+// GetMerges godoc
+// @Summary      Historial de fusiones de candidatos
+// @Description  De la más reciente a la más antigua; undone_at indica las deshechas
+// @Tags         Candidates
+// @Produce      json
+// @Param        candidate_id  query     int  false  "Solo fusiones de este candidato (sobreviviente o duplicado)"
+// @Param        limit         query     int  false  "Máximo (por defecto y tope 200)"
+// @Param        company_id    query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200           {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/merges [get]
+func (h *MergeHandler) GetMerges(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	candidateID, _ := strconv.ParseUint(c.Query("candidate_id"), 10, 32)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	merges, err := h.svc.ListMerges(companyID, uint(candidateID), limit)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": merges, "count": len(merges)}})
+}
+
+// UndoMerge godoc
+// @Summary      Deshacer una fusión de candidatos
+// @Description  Dentro de los 30 días: restaura el duplicado y le devuelve las filas que se movieron; el sobreviviente pierde los campos que tomó de él si nadie los editó. 409 si venció el plazo o alguno de los candidatos cambió desde la fusión.
+// @Tags         Candidates
+// @Produce      json
+// @Param        id   path      int  true  "ID de la fusión"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/merges/{id}/undo [post]
+func (h *MergeHandler) UndoMerge(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid merge ID")
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	merge, err := h.svc.Undo(id, companyID, userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": merge})
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
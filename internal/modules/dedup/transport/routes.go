@@ -0,0 +1,23 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/dedup/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.MergeService) {
+	h := NewMergeHandler(svc)
+
+	candidates := rg.Group("/candidates")
+	{
+		candidates.GET("/duplicates", middleware.RequirePermission(permissions.CandidatesMerge), h.GetDuplicates)
+		candidates.GET("/merges", middleware.RequirePermission(permissions.CandidatesMerge), h.GetMerges)
+		candidates.POST("/merges/:id/undo", middleware.RequirePermission(permissions.CandidatesMerge), h.UndoMerge)
+		candidates.GET("/:id/duplicates", middleware.RequirePermission(permissions.CandidatesMerge), h.GetCandidateDuplicates)
+		candidates.POST("/:id/merge", middleware.RequirePermission(permissions.CandidatesMerge), h.MergeCandidate)
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Idiomas soportados para fechas y montos.
+const (
+	LocaleES = "es"
+	LocaleEN = "en"
+	LocalePT = "pt"
+)
+
+// IsValidLocale reporta si el idioma está soportado.
+func IsValidLocale(locale string) bool {
+	return locale == LocaleES || locale == LocaleEN || locale == LocalePT
+}
+
+var monthNames = map[string][12]string{
+	LocaleES: {"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	LocaleEN: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	LocalePT: {"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+}
+
+// FormatDate escribe la fecha en forma larga: "19 de octubre de 2026" (es,
+// pt) u "October 19, 2026" (en).
+func FormatDate(t time.Time, locale string) string {
+	month := monthNames[normalize(locale)][t.Month()-1]
+	if normalize(locale) == LocaleEN {
+		return fmt.Sprintf("%s %d, %d", month, t.Day(), t.Year())
+	}
+	return fmt.Sprintf("%d de %s de %d", t.Day(), month, t.Year())
+}
+
+// FormatMoney escribe el monto con dos decimales y el código de moneda:
+// "5.000,00 USD" (es, pt) o "USD 5,000.00" (en).
+func FormatMoney(amount float64, currency, locale string) string {
+	thousands, decimal := ".", ","
+	if normalize(locale) == LocaleEN {
+		thousands, decimal = ",", "."
+	}
+
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	digits := fmt.Sprintf("%d", cents/100)
+	var grouped strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(thousands)
+		}
+		grouped.WriteRune(d)
+	}
+	number := fmt.Sprintf("%s%s%02d", grouped.String(), decimal, cents%100)
+	if amount < 0 {
+		number = "-" + number
+	}
+
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		return number
+	}
+	if normalize(locale) == LocaleEN {
+		return currency + " " + number
+	}
+	return number + " " + currency
+}
+
+// normalize cae en español ante un idioma no soportado.
+func normalize(locale string) string {
+	if IsValidLocale(locale) {
+		return locale
+	}
+	return LocaleES
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	cases := map[string]string{
+		LocaleES: "19 de octubre de 2026",
+		LocaleEN: "October 19, 2026",
+		LocalePT: "19 de outubro de 2026",
+		"fr":     "19 de octubre de 2026",
+	}
+	for locale, want := range cases {
+		if got := FormatDate(date, locale); got != want {
+			t.Errorf("FormatDate(%s) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	cases := []struct {
+		amount   float64
+		currency string
+		locale   string
+		want     string
+	}{
+		{5000, "usd", LocaleES, "5.000,00 USD"},
+		{5000, "USD", LocaleEN, "USD 5,000.00"},
+		{1234567.891, "BRL", LocalePT, "1.234.567,89 BRL"},
+		{999.995, "EUR", LocaleEN, "EUR 1,000.00"},
+		{-45.5, "", LocaleES, "-45,50"},
+	}
+	for _, c := range cases {
+		if got := FormatMoney(c.amount, c.currency, c.locale); got != c.want {
+			t.Errorf("FormatMoney(%v, %s, %s) = %q, want %q", c.amount, c.currency, c.locale, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// DocumentFilter indica de quién son los documentos a listar (uno de los dos).
+type DocumentFilter struct {
+	ApplicationID uint
+	PlacementID   uint
+}
+
+// DocumentRepository es el puerto de salida hacia la persistencia. Los Get
+// devuelven nil, nil si el registro no existe.
+type DocumentRepository interface {
+	ListTemplates(companyID uint, kind string) ([]models.DocumentTemplate, error)
+	GetTemplate(id uint) (*models.DocumentTemplate, error)
+	CreateTemplate(template *models.DocumentTemplate) error
+	SaveTemplate(template *models.DocumentTemplate) error
+	DeleteTemplate(id uint) error
+
+	// Application devuelve la postulación con candidato, vacante y empresa.
+	Application(id uint) (*models.Application, error)
+	// Placement devuelve el placement con candidato, vacante, empresa y
+	// cliente final.
+	Placement(id uint) (*models.Placement, error)
+	// Offer devuelve una oferta de la postulación con sus versiones. Con
+	// offerID 0 devuelve la más reciente que no esté retirada ni rechazada.
+	Offer(applicationID, offerID uint) (*models.Offer, error)
+
+	CreateDocument(document *models.Document) error
+	GetDocument(id uint) (*models.Document, error)
+	ListDocuments(companyID uint, filter DocumentFilter) ([]models.Document, error)
+	DeleteDocument(id uint) error
+}
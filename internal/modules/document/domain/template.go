@@ -0,0 +1,259 @@
+// Package domain define el centro del módulo document: plantillas por
+// empresa, las variables que exponen (candidato, vacante, oferta y
+// placement) ya formateadas según el idioma y el render a bloques de texto.
+// No importa gin ni gorm.
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"text/template"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// MaxBodyLength acota el cuerpo de una plantilla.
+const MaxBodyLength = 50000
+
+// ErrMissingData indica que la plantilla usa datos que el documento no tiene
+// (p. ej. variables de placement al generar sobre una postulación).
+var ErrMissingData = errors.New("template uses data that is not available for this document")
+
+// IsValidKind reporta si el tipo de plantilla existe.
+func IsValidKind(kind string) bool {
+	return kind == models.DocumentKindOfferLetter || kind == models.DocumentKindPlacementContract || kind == models.DocumentKindGeneral
+}
+
+// Vars son las variables de una plantilla. Todos los valores son texto ya
+// formateado; Offer y Placement son nil cuando el documento no los tiene.
+type Vars struct {
+	Today     string
+	Company   CompanyVars
+	Candidate CandidateVars
+	Job       JobVars
+	Offer     *OfferVars
+	Placement *PlacementVars
+}
+
+type CompanyVars struct {
+	Name string
+}
+
+type CandidateVars struct {
+	FirstName string
+	LastName  string
+	FullName  string
+	Email     string
+	Phone     string
+}
+
+type JobVars struct {
+	Title       string
+	Description string
+}
+
+type OfferVars struct {
+	Salary    string
+	Currency  string
+	Bonus     string
+	Equity    string
+	StartDate string
+	ExpiresAt string
+	Notes     string
+}
+
+type PlacementVars struct {
+	Position     string
+	Client       string
+	ContractType string
+	StartDate    string
+	EndDate      string
+	BillRate     string
+	PayRate      string
+	RateType     string
+}
+
+// Variable describe una variable disponible para el editor de plantillas.
+type Variable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Variables es el catálogo que se muestra al editar una plantilla.
+var Variables = []Variable{
+	{"{{.Today}}", "Fecha de generación"},
+	{"{{.Company.Name}}", "Nombre de la empresa"},
+	{"{{.Candidate.FirstName}}", "Nombre del candidato"},
+	{"{{.Candidate.LastName}}", "Apellido del candidato"},
+	{"{{.Candidate.FullName}}", "Nombre completo del candidato"},
+	{"{{.Candidate.Email}}", "Email del candidato"},
+	{"{{.Candidate.Phone}}", "Teléfono del candidato"},
+	{"{{.Job.Title}}", "Título de la vacante"},
+	{"{{.Job.Description}}", "Descripción de la vacante"},
+	{"{{.Offer.Salary}}", "Salario de la oferta con moneda"},
+	{"{{.Offer.Currency}}", "Moneda de la oferta"},
+	{"{{.Offer.Bonus}}", "Bono de la oferta con moneda"},
+	{"{{.Offer.Equity}}", "Equity de la oferta"},
+	{"{{.Offer.StartDate}}", "Fecha de inicio de la oferta"},
+	{"{{.Offer.ExpiresAt}}", "Vencimiento de la oferta"},
+	{"{{.Offer.Notes}}", "Notas de la oferta"},
+	{"{{.Placement.Position}}", "Cargo del placement"},
+	{"{{.Placement.Client}}", "Cliente final del placement"},
+	{"{{.Placement.ContractType}}", "Tipo de contrato del placement"},
+	{"{{.Placement.StartDate}}", "Inicio del placement"},
+	{"{{.Placement.EndDate}}", "Fin del placement (vacío si es indefinido)"},
+	{"{{.Placement.BillRate}}", "Tarifa al cliente con moneda"},
+	{"{{.Placement.PayRate}}", "Pago al candidato con moneda"},
+	{"{{.Placement.RateType}}", "Periodicidad de la tarifa (hourly, monthly)"},
+}
+
+// Source son los registros de los que salen las variables. Company,
+// Candidate y Job son obligatorios; Offer (con Version, la vigente) y
+// Placement son opcionales.
+type Source struct {
+	Company   *models.Company
+	Candidate *models.Candidate
+	Job       *models.Job
+	Offer     *models.OfferVersion
+	Placement *models.Placement
+}
+
+// BuildVars formatea las variables en el idioma indicado. now se expresa en
+// la zona horaria de la empresa.
+func BuildVars(src Source, locale string, now time.Time) Vars {
+	vars := Vars{Today: FormatDate(now, locale)}
+	if src.Company != nil {
+		vars.Company.Name = src.Company.Name
+	}
+	if c := src.Candidate; c != nil {
+		vars.Candidate = CandidateVars{
+			FirstName: c.FirstName,
+			LastName:  c.LastName,
+			FullName:  strings.TrimSpace(c.FirstName + " " + c.LastName),
+			Email:     c.Email,
+			Phone:     c.Phone,
+		}
+	}
+	if j := src.Job; j != nil {
+		vars.Job = JobVars{Title: j.Title, Description: j.Description}
+	}
+	if o := src.Offer; o != nil {
+		vars.Offer = &OfferVars{
+			Salary:    FormatMoney(o.Salary, o.Currency, locale),
+			Currency:  o.Currency,
+			Equity:    o.Equity,
+			StartDate: optionalDate(o.StartDate, locale),
+			ExpiresAt: FormatDate(o.ExpiresAt.In(now.Location()), locale),
+			Notes:     o.Notes,
+		}
+		if o.Bonus != nil {
+			vars.Offer.Bonus = FormatMoney(*o.Bonus, o.Currency, locale)
+		}
+	}
+	if p := src.Placement; p != nil {
+		vars.Placement = &PlacementVars{
+			Position:     p.Position,
+			ContractType: p.ContractType,
+			StartDate:    optionalDate(p.StartDate, locale),
+			EndDate:      optionalDate(p.EndDate, locale),
+			RateType:     p.BillRateType,
+		}
+		if p.StaffingClient != nil {
+			vars.Placement.Client = p.StaffingClient.Name
+		}
+		if p.BillRateAmount != nil {
+			vars.Placement.BillRate = FormatMoney(*p.BillRateAmount, p.BillRateCurrency, locale)
+		}
+		if p.PayRateAmount != nil {
+			vars.Placement.PayRate = FormatMoney(*p.PayRateAmount, p.BillRateCurrency, locale)
+		}
+	}
+	return vars
+}
+
+// SampleVars son datos de ejemplo para validar y previsualizar plantillas;
+// incluyen oferta y placement para que toda variable del catálogo resuelva.
+func SampleVars(locale string, now time.Time) Vars {
+	start := now.AddDate(0, 1, 0)
+	end := start.AddDate(1, 0, 0)
+	bonus := 2500.0
+	bill, pay := 45.0, 30.0
+	return BuildVars(Source{
+		Company:   &models.Company{Name: "Acme S.A."},
+		Candidate: &models.Candidate{FirstName: "Ana", LastName: "Pérez", Email: "ana.perez@example.com", Phone: "+57 300 000 0000"},
+		Job:       &models.Job{Title: "Backend Developer", Description: "Desarrollo de servicios en Go."},
+		Offer: &models.OfferVersion{
+			Salary: 5000, Currency: "USD", Bonus: &bonus, Equity: "0.1%",
+			StartDate: &start, ExpiresAt: now.AddDate(0, 0, 7), Notes: "Incluye seguro médico.",
+		},
+		Placement: &models.Placement{
+			Position: "Backend Developer", ContractType: "staffing", StartDate: &start, EndDate: &end,
+			BillRateAmount: &bill, BillRateCurrency: "USD", BillRateType: "hourly", PayRateAmount: &pay,
+			StaffingClient: &models.StaffingClient{Name: "Cliente Final S.A.S."},
+		},
+	}, locale, now)
+}
+
+// Block es un bloque del documento renderizado: un título o un párrafo.
+type Block struct {
+	Heading bool
+	Text    string
+}
+
+// Parse compila el cuerpo de una plantilla.
+func Parse(body string) (*template.Template, error) {
+	return template.New("document").Option("missingkey=error").Parse(body)
+}
+
+// Render ejecuta la plantilla y parte el resultado en bloques: las líneas
+// que empiezan con "# " son títulos y las líneas en blanco separan párrafos.
+// Usar variables de una oferta o un placement ausentes es ErrMissingData.
+func Render(body string, vars Vars) ([]Block, error) {
+	tpl, err := Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, vars); err != nil {
+		if strings.Contains(err.Error(), "nil pointer evaluating") {
+			return nil, ErrMissingData
+		}
+		return nil, err
+	}
+	return Blocks(out.String()), nil
+}
+
+// Blocks parte un texto ya renderizado en títulos y párrafos.
+func Blocks(text string) []Block {
+	var blocks []Block
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, Block{Text: strings.Join(paragraph, "\n")})
+			paragraph = nil
+		}
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "# "):
+			flush()
+			blocks = append(blocks, Block{Heading: true, Text: strings.TrimSpace(trimmed[2:])})
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+	return blocks
+}
+
+func optionalDate(t *time.Time, locale string) string {
+	if t == nil {
+		return ""
+	}
+	return FormatDate(*t, locale)
+}
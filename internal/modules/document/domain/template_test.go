@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+var now = time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+func TestRenderBlocks(t *testing.T) {
+	body := "# Carta de oferta\n\n{{.Today}}\n\nEstimada {{.Candidate.FullName}}:\nle ofrecemos el cargo de {{.Job.Title}} por {{.Offer.Salary}}.\n"
+	blocks, err := Render(body, SampleVars(LocaleES, now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Block{
+		{Heading: true, Text: "Carta de oferta"},
+		{Text: "19 de octubre de 2026"},
+		{Text: "Estimada Ana Pérez:\nle ofrecemos el cargo de Backend Developer por 5.000,00 USD."},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(blocks), len(want), blocks)
+	}
+	for i := range want {
+		if blocks[i] != want[i] {
+			t.Errorf("block %d = %+v, want %+v", i, blocks[i], want[i])
+		}
+	}
+}
+
+func TestRenderMissingPlacement(t *testing.T) {
+	vars := BuildVars(Source{
+		Company:   &models.Company{Name: "Acme"},
+		Candidate: &models.Candidate{FirstName: "Ana"},
+		Job:       &models.Job{Title: "Dev"},
+	}, LocaleEN, now)
+	if _, err := Render("{{.Placement.Client}}", vars); !errors.Is(err, ErrMissingData) {
+		t.Fatalf("expected ErrMissingData, got %v", err)
+	}
+	// Un condicional sobre la sección ausente sí es válido.
+	if _, err := Render("{{with .Placement}}{{.Client}}{{end}}", vars); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSampleVarsCoverCatalog(t *testing.T) {
+	body := ""
+	for _, v := range Variables {
+		body += v.Name + "\n"
+	}
+	if _, err := Render(body, SampleVars(LocalePT, now)); err != nil {
+		t.Fatalf("catalog variable does not resolve: %v", err)
+	}
+	if _, err := Render("{{.Candidate.Salary}}", SampleVars(LocaleES, now)); err == nil {
+		t.Fatal("expected error for unknown variable")
+	}
+}
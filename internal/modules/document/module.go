@@ -0,0 +1,28 @@
+// Package document es el punto de ensamblaje del módulo de documentos:
+// plantillas por empresa renderizadas a PDF y adjuntas a postulaciones o
+// placements. Nadie importa este paquete salvo el composition root.
+package document
+
+import (
+	"dvra-api/internal/modules/document/repository"
+	"dvra-api/internal/modules/document/service"
+	"dvra-api/internal/modules/document/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo document.
+type Module struct {
+	Service *service.DocumentService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{Service: service.NewDocumentService(repository.NewDocumentRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/document/domain"
+
+	"gorm.io/gorm"
+)
+
+type documentRepository struct {
+	db *gorm.DB
+}
+
+// NewDocumentRepository devuelve la implementación del puerto.
+func NewDocumentRepository(db *gorm.DB) domain.DocumentRepository {
+	return &documentRepository{db: db}
+}
+
+func (r *documentRepository) ListTemplates(companyID uint, kind string) ([]models.DocumentTemplate, error) {
+	query := r.db.Where("company_id = ?", companyID)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	var templates []models.DocumentTemplate
+	if err := query.Order("name ASC, id ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *documentRepository) GetTemplate(id uint) (*models.DocumentTemplate, error) {
+	var template models.DocumentTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *documentRepository) CreateTemplate(template *models.DocumentTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *documentRepository) SaveTemplate(template *models.DocumentTemplate) error {
+	return r.db.Save(template).Error
+}
+
+func (r *documentRepository) DeleteTemplate(id uint) error {
+	return r.db.Delete(&models.DocumentTemplate{}, id).Error
+}
+
+func (r *documentRepository) Application(id uint) (*models.Application, error) {
+	var app models.Application
+	if err := r.db.Preload("Company").Preload("Candidate").Preload("Job").First(&app, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &app, nil
+}
+
+func (r *documentRepository) Placement(id uint) (*models.Placement, error) {
+	var placement models.Placement
+	if err := r.db.Preload("Company").Preload("Candidate").Preload("Job").Preload("StaffingClient").
+		First(&placement, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &placement, nil
+}
+
+func (r *documentRepository) Offer(applicationID, offerID uint) (*models.Offer, error) {
+	query := r.db.
+		Preload("Versions", func(db *gorm.DB) *gorm.DB { return db.Order("version ASC") }).
+		Where("application_id = ?", applicationID)
+	if offerID != 0 {
+		query = query.Where("id = ?", offerID)
+	} else {
+		query = query.Where("status NOT IN ?", []string{models.OfferStatusWithdrawn, models.OfferStatusDeclined})
+	}
+
+	var offer models.Offer
+	if err := query.Order("created_at DESC, id DESC").First(&offer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+func (r *documentRepository) CreateDocument(document *models.Document) error {
+	return r.db.Create(document).Error
+}
+
+func (r *documentRepository) GetDocument(id uint) (*models.Document, error) {
+	var document models.Document
+	if err := r.db.First(&document, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (r *documentRepository) ListDocuments(companyID uint, filter domain.DocumentFilter) ([]models.Document, error) {
+	query := r.db.Where("company_id = ?", companyID)
+	if filter.ApplicationID != 0 {
+		query = query.Where("application_id = ?", filter.ApplicationID)
+	}
+	if filter.PlacementID != 0 {
+		query = query.Where("placement_id = ?", filter.PlacementID)
+	}
+	var documents []models.Document
+	if err := query.Order("created_at DESC, id DESC").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *documentRepository) DeleteDocument(id uint) error {
+	return r.db.Delete(&models.Document{}, id).Error
+}
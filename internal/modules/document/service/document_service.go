@@ -0,0 +1,337 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/document/domain"
+	"dvra-api/internal/platform/pdf"
+	"dvra-api/internal/shared/apperr"
+)
+
+// SaveFunc escribe el PDF en la ruta (relativa a uploads) que decide el
+// servicio.
+type SaveFunc func(path string, data []byte) error
+
+// DocumentService gestiona las plantillas de documentos de la empresa y
+// genera con ellas PDFs (cartas de oferta, contratos de placement) que quedan
+// adjuntos a la postulación o al placement.
+type DocumentService struct {
+	repo domain.DocumentRepository
+	now  func() time.Time
+}
+
+func NewDocumentService(repo domain.DocumentRepository) *DocumentService {
+	return &DocumentService{repo: repo, now: time.Now}
+}
+
+// ListTemplates devuelve las plantillas de la empresa, opcionalmente de un tipo.
+func (s *DocumentService) ListTemplates(companyID uint, kind string) ([]models.DocumentTemplate, error) {
+	return s.repo.ListTemplates(companyID, kind)
+}
+
+// GetTemplate devuelve una plantilla validando el tenant.
+func (s *DocumentService) GetTemplate(id, companyID uint) (*models.DocumentTemplate, error) {
+	template, err := s.repo.GetTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil || (companyID != 0 && template.CompanyID != companyID) {
+		return nil, apperr.NotFound("document template not found")
+	}
+	return template, nil
+}
+
+// CreateTemplate guarda una plantilla nueva. El cuerpo se valida
+// ejecutándolo con datos de ejemplo.
+func (s *DocumentService) CreateTemplate(companyID, actorID uint, dto dtos.DocumentTemplateDTO) (*models.DocumentTemplate, error) {
+	template := &models.DocumentTemplate{CompanyID: companyID, Active: true}
+	if actorID != 0 {
+		template.CreatedByID = &actorID
+	}
+	if err := s.apply(template, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTemplate reemplaza una plantilla. Los documentos ya generados no
+// cambian.
+func (s *DocumentService) UpdateTemplate(id, companyID uint, dto dtos.DocumentTemplateDTO) (*models.DocumentTemplate, error) {
+	template, err := s.GetTemplate(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(template, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate elimina una plantilla; los documentos generados se conservan.
+func (s *DocumentService) DeleteTemplate(id, companyID uint) error {
+	template, err := s.GetTemplate(id, companyID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteTemplate(template.ID)
+}
+
+// Preview renderiza la plantilla con datos de ejemplo. No guarda nada.
+func (s *DocumentService) Preview(id, companyID uint) ([]byte, string, error) {
+	template, err := s.GetTemplate(id, companyID)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := s.render(template, domain.SampleVars(template.Locale, s.now()))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, fileName(template.Title, "preview"), nil
+}
+
+// GenerateForApplication genera un documento sobre una postulación con los
+// datos del candidato, la vacante y, si la hay, la oferta.
+func (s *DocumentService) GenerateForApplication(applicationID, companyID, actorID uint, dto dtos.GenerateDocumentDTO, save SaveFunc) (*models.Document, error) {
+	app, err := s.application(applicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	offer, err := s.offerVersion(app.ID, dto.OfferID)
+	if err != nil {
+		return nil, err
+	}
+	src := domain.Source{Company: app.Company, Candidate: app.Candidate, Job: app.Job, Offer: offer.version}
+	document := &models.Document{CompanyID: app.CompanyID, ApplicationID: &app.ID, OfferID: offer.id}
+	return s.generate(document, dto.TemplateID, src, actorID, save)
+}
+
+// GenerateForPlacement genera un documento (típicamente el contrato) sobre un
+// placement. La oferta, si la hay, es la de su postulación de origen.
+func (s *DocumentService) GenerateForPlacement(placementID, companyID, actorID uint, dto dtos.GenerateDocumentDTO, save SaveFunc) (*models.Document, error) {
+	placement, err := s.placement(placementID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	offer, err := s.offerVersion(placement.ApplicationID, dto.OfferID)
+	if err != nil {
+		return nil, err
+	}
+	src := domain.Source{Company: placement.Company, Candidate: placement.Candidate, Job: placement.Job, Offer: offer.version, Placement: placement}
+	document := &models.Document{CompanyID: placement.CompanyID, PlacementID: &placement.ID, OfferID: offer.id}
+	return s.generate(document, dto.TemplateID, src, actorID, save)
+}
+
+// ListForApplication devuelve los documentos de una postulación.
+func (s *DocumentService) ListForApplication(applicationID, companyID uint) ([]models.Document, error) {
+	app, err := s.application(applicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocuments(app.CompanyID, domain.DocumentFilter{ApplicationID: app.ID})
+}
+
+// ListForPlacement devuelve los documentos de un placement.
+func (s *DocumentService) ListForPlacement(placementID, companyID uint) ([]models.Document, error) {
+	placement, err := s.placement(placementID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocuments(placement.CompanyID, domain.DocumentFilter{PlacementID: placement.ID})
+}
+
+// GetDocument devuelve un documento generado validando el tenant.
+func (s *DocumentService) GetDocument(id, companyID uint) (*models.Document, error) {
+	document, err := s.repo.GetDocument(id)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil || (companyID != 0 && document.CompanyID != companyID) {
+		return nil, apperr.NotFound("document not found")
+	}
+	return document, nil
+}
+
+// DeleteDocument elimina un documento generado (soft delete).
+func (s *DocumentService) DeleteDocument(id, companyID uint) error {
+	document, err := s.GetDocument(id, companyID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteDocument(document.ID)
+}
+
+// generate renderiza la plantilla, escribe el PDF y registra el documento;
+// el registro se guarda solo si el archivo se escribió.
+func (s *DocumentService) generate(document *models.Document, templateID uint, src domain.Source, actorID uint, save SaveFunc) (*models.Document, error) {
+	template, err := s.GetTemplate(templateID, document.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if !template.Active {
+		return nil, apperr.Unprocessable("document template is inactive")
+	}
+
+	data, err := s.render(template, domain.BuildVars(src, template.Locale, s.now().In(companyLocation(src.Company))))
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("documents/%d/%d.pdf", document.CompanyID, time.Now().UnixNano())
+	if err := save(path, data); err != nil {
+		return nil, err
+	}
+
+	document.TemplateID = &template.ID
+	document.Kind = template.Kind
+	document.FileName = fileName(template.Title, candidateName(src.Candidate))
+	document.StoragePath = path
+	document.Size = int64(len(data))
+	if actorID != 0 {
+		document.GeneratedByID = &actorID
+	}
+	if err := s.repo.CreateDocument(document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// render ejecuta la plantilla y compone el PDF.
+func (s *DocumentService) render(template *models.DocumentTemplate, vars domain.Vars) ([]byte, error) {
+	blocks, err := domain.Render(template.Body, vars)
+	if err != nil {
+		if errors.Is(err, domain.ErrMissingData) {
+			return nil, apperr.Unprocessable("the template uses offer or placement data that this document does not have")
+		}
+		return nil, apperr.BadRequest(fmt.Sprintf("invalid template: %v", err))
+	}
+
+	doc := pdf.New(template.Title)
+	doc.Heading(template.Title)
+	for _, block := range blocks {
+		if block.Heading {
+			doc.Heading(block.Text)
+		} else {
+			doc.Paragraph(block.Text)
+		}
+	}
+	return doc.Bytes(), nil
+}
+
+// apply valida el DTO y lo copia en la plantilla.
+func (s *DocumentService) apply(template *models.DocumentTemplate, dto dtos.DocumentTemplateDTO) error {
+	locale := dto.Locale
+	if locale == "" {
+		locale = domain.LocaleES
+	}
+	if !domain.IsValidKind(dto.Kind) || !domain.IsValidLocale(locale) {
+		return apperr.BadRequest("invalid template kind or locale")
+	}
+	if len(dto.Body) > domain.MaxBodyLength {
+		return apperr.BadRequest(fmt.Sprintf("template body exceeds %d characters", domain.MaxBodyLength))
+	}
+	if _, err := domain.Render(dto.Body, domain.SampleVars(locale, s.now())); err != nil {
+		return apperr.BadRequest(fmt.Sprintf("invalid template: %v", err))
+	}
+
+	template.Name = strings.TrimSpace(dto.Name)
+	template.Kind = dto.Kind
+	template.Locale = locale
+	template.Title = strings.TrimSpace(dto.Title)
+	template.Body = dto.Body
+	if dto.Active != nil {
+		template.Active = *dto.Active
+	}
+	return nil
+}
+
+// application resuelve la postulación validando el tenant.
+func (s *DocumentService) application(id, companyID uint) (*models.Application, error) {
+	app, err := s.repo.Application(id)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil || (companyID != 0 && app.CompanyID != companyID) {
+		return nil, apperr.NotFound("application not found")
+	}
+	return app, nil
+}
+
+// placement resuelve el placement validando el tenant.
+func (s *DocumentService) placement(id, companyID uint) (*models.Placement, error) {
+	placement, err := s.repo.Placement(id)
+	if err != nil {
+		return nil, err
+	}
+	if placement == nil || (companyID != 0 && placement.CompanyID != companyID) {
+		return nil, apperr.NotFound("placement not found")
+	}
+	return placement, nil
+}
+
+type offerRef struct {
+	id      *uint
+	version *models.OfferVersion
+}
+
+// offerVersion resuelve los términos vigentes de la oferta de la postulación:
+// la indicada (404 si no es de la postulación) o la más reciente vigente.
+func (s *DocumentService) offerVersion(applicationID, offerID uint) (offerRef, error) {
+	offer, err := s.repo.Offer(applicationID, offerID)
+	if err != nil {
+		return offerRef{}, err
+	}
+	if offer == nil {
+		if offerID != 0 {
+			return offerRef{}, apperr.NotFound("offer not found")
+		}
+		return offerRef{}, nil
+	}
+	ref := offerRef{id: &offer.ID}
+	for i := range offer.Versions {
+		if offer.Versions[i].Version == offer.CurrentVersion {
+			ref.version = &offer.Versions[i]
+		}
+	}
+	return ref, nil
+}
+
+func companyLocation(company *models.Company) *time.Location {
+	if company != nil {
+		if loc, err := time.LoadLocation(company.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+func candidateName(candidate *models.Candidate) string {
+	if candidate == nil {
+		return ""
+	}
+	return strings.TrimSpace(candidate.FirstName + " " + candidate.LastName)
+}
+
+// fileName arma el nombre de descarga ("Título - Nombre.pdf") sin
+// separadores de ruta.
+func fileName(title, suffix string) string {
+	name := strings.TrimSpace(title)
+	if suffix != "" {
+		name += " - " + suffix
+	}
+	name = strings.NewReplacer("/", "-", "\\", "-", "\"", "").Replace(name)
+	if runes := []rune(name); len(runes) > 200 {
+		name = string(runes[:200])
+	}
+	return name + ".pdf"
+}
@@ -0,0 +1,358 @@
+package transport
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/document/domain"
+	"dvra-api/internal/modules/document/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uploadsDir es la raíz local de los archivos subidos (ver UploadResume).
+const uploadsDir = "./uploads"
+
+type DocumentHandler struct {
+	svc *service.DocumentService
+}
+
+func NewDocumentHandler(svc *service.DocumentService) *DocumentHandler {
+	return &DocumentHandler{svc: svc}
+}
+
+// GetTemplates godoc
+// @Summary      Listar plantillas de documentos
+// @Tags         Documents
+// @Produce      json
+// @Param        kind        query     string  false  "offer_letter, placement_contract, general"
+// @Param        company_id  query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates [get]
+func (h *DocumentHandler) GetTemplates(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.svc.ListTemplates(companyID, c.Query("kind"))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": templates, "count": len(templates)}})
+}
+
+// GetTemplateVariables godoc
+// @Summary      Variables de plantilla
+// @Description  Catálogo de variables disponibles en el cuerpo de una plantilla
+// @Tags         Documents
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates/variables [get]
+func (h *DocumentHandler) GetTemplateVariables(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": domain.Variables, "count": len(domain.Variables)}})
+}
+
+// GetTemplate godoc
+// @Summary      Obtener plantilla de documento
+// @Tags         Documents
+// @Produce      json
+// @Param        id   path      int  true  "ID de la plantilla"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates/{id} [get]
+func (h *DocumentHandler) GetTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.svc.GetTemplate(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": template})
+}
+
+// CreateTemplate godoc
+// @Summary      Crear plantilla de documento
+// @Description  El cuerpo usa variables {{.Candidate.FullName}} (ver /document-templates/variables) y se valida con datos de ejemplo; las líneas "# " son títulos.
+// @Tags         Documents
+// @Accept       json
+// @Produce      json
+// @Param        template    body      dtos.DocumentTemplateDTO  true   "Plantilla"
+// @Param        company_id  query     int                       false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      201         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates [post]
+func (h *DocumentHandler) CreateTemplate(c *gin.Context) {
+	var dto dtos.DocumentTemplateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	template, err := h.svc.CreateTemplate(companyID, actorID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": template})
+}
+
+// UpdateTemplate godoc
+// @Summary      Editar plantilla de documento
+// @Description  Reemplaza la plantilla; los documentos ya generados no cambian
+// @Tags         Documents
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                       true  "ID de la plantilla"
+// @Param        template  body      dtos.DocumentTemplateDTO  true  "Plantilla"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates/{id} [put]
+func (h *DocumentHandler) UpdateTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+	var dto dtos.DocumentTemplateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.svc.UpdateTemplate(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": template})
+}
+
+// DeleteTemplate godoc
+// @Summary      Eliminar plantilla de documento
+// @Description  Los documentos ya generados se conservan
+// @Tags         Documents
+// @Produce      json
+// @Param        id   path      int  true  "ID de la plantilla"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates/{id} [delete]
+func (h *DocumentHandler) DeleteTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteTemplate(id, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Document template deleted"})
+}
+
+// PreviewTemplate godoc
+// @Summary      Vista previa de plantilla
+// @Description  Devuelve el PDF renderizado con datos de ejemplo; no se guarda
+// @Tags         Documents
+// @Produce      application/pdf
+// @Param        id   path  int  true  "ID de la plantilla"
+// @Success      200
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /document-templates/{id}/preview [post]
+func (h *DocumentHandler) PreviewTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	data, name, err := h.svc.Preview(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", "inline; filename=\""+name+"\"")
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// GetApplicationDocuments godoc
+// @Summary      Documentos de una postulación
+// @Tags         Documents
+// @Produce      json
+// @Param        id   path      int  true  "ID de la postulación"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/documents [get]
+func (h *DocumentHandler) GetApplicationDocuments(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+
+	documents, err := h.svc.ListForApplication(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": documents, "count": len(documents)}})
+}
+
+// GenerateApplicationDocument godoc
+// @Summary      Generar documento de una postulación
+// @Description  Renderiza la plantilla a PDF con los datos del candidato, la vacante y la oferta, y lo adjunta a la postulación. 422 si la plantilla usa datos de placement.
+// @Tags         Documents
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                       true  "ID de la postulación"
+// @Param        document  body      dtos.GenerateDocumentDTO  true  "Plantilla y oferta"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      422       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/documents [post]
+func (h *DocumentHandler) GenerateApplicationDocument(c *gin.Context) {
+	h.generate(c, "Invalid application ID", h.svc.GenerateForApplication)
+}
+
+// GetPlacementDocuments godoc
+// @Summary      Documentos de un placement
+// @Tags         Documents
+// @Produce      json
+// @Param        id   path      int  true  "ID del placement"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /placements/{id}/documents [get]
+func (h *DocumentHandler) GetPlacementDocuments(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid placement ID")
+	if !ok {
+		return
+	}
+
+	documents, err := h.svc.ListForPlacement(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": documents, "count": len(documents)}})
+}
+
+// GeneratePlacementDocument godoc
+// @Summary      Generar documento de un placement
+// @Description  Renderiza la plantilla (p. ej. el contrato) a PDF con los datos del placement, su cliente final y la oferta de origen, y lo adjunta al placement
+// @Tags         Documents
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                       true  "ID del placement"
+// @Param        document  body      dtos.GenerateDocumentDTO  true  "Plantilla y oferta"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      422       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /placements/{id}/documents [post]
+func (h *DocumentHandler) GeneratePlacementDocument(c *gin.Context) {
+	h.generate(c, "Invalid placement ID", h.svc.GenerateForPlacement)
+}
+
+// DownloadDocument godoc
+// @Summary      Descargar documento
+// @Tags         Documents
+// @Produce      application/pdf
+// @Param        id   path  int  true  "ID del documento"
+// @Success      200
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /documents/{id}/download [get]
+func (h *DocumentHandler) DownloadDocument(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid document ID")
+	if !ok {
+		return
+	}
+
+	document, err := h.svc.GetDocument(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.FileAttachment(filepath.Join(uploadsDir, document.StoragePath), document.FileName)
+}
+
+// DeleteDocument godoc
+// @Summary      Eliminar documento
+// @Tags         Documents
+// @Produce      json
+// @Param        id   path      int  true  "ID del documento"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /documents/{id} [delete]
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid document ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteDocument(id, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Document deleted"})
+}
+
+type generateFunc func(id, companyID, actorID uint, dto dtos.GenerateDocumentDTO, save service.SaveFunc) (*models.Document, error)
+
+func (h *DocumentHandler) generate(c *gin.Context, invalidMsg string, generate generateFunc) {
+	id, companyID, ok := target(c, invalidMsg)
+	if !ok {
+		return
+	}
+	var dto dtos.GenerateDocumentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	save := func(path string, data []byte) error {
+		full := filepath.Join(uploadsDir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(full, data, 0o644)
+	}
+	document, err := generate(id, companyID, actorID, dto, save)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": document})
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
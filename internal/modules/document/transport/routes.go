@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/document/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.DocumentService) {
+	h := NewDocumentHandler(svc)
+
+	templates := rg.Group("/document-templates")
+	{
+		templates.GET("", middleware.RequirePermission(permissions.DocumentsView), h.GetTemplates)
+		templates.GET("/variables", middleware.RequirePermission(permissions.DocumentsView), h.GetTemplateVariables)
+		templates.POST("", middleware.RequirePermission(permissions.DocumentTemplatesManage), h.CreateTemplate)
+		templates.GET("/:id", middleware.RequirePermission(permissions.DocumentsView), h.GetTemplate)
+		templates.PUT("/:id", middleware.RequirePermission(permissions.DocumentTemplatesManage), h.UpdateTemplate)
+		templates.DELETE("/:id", middleware.RequirePermission(permissions.DocumentTemplatesManage), h.DeleteTemplate)
+		templates.POST("/:id/preview", middleware.RequirePermission(permissions.DocumentsView), h.PreviewTemplate)
+	}
+
+	documents := rg.Group("/documents")
+	{
+		documents.GET("/:id/download", middleware.RequirePermission(permissions.DocumentsView), h.DownloadDocument)
+		documents.DELETE("/:id", middleware.RequirePermission(permissions.DocumentsGenerate), h.DeleteDocument)
+	}
+
+	// Los documentos generados cuelgan de la postulación o del placement.
+	applications := rg.Group("/applications")
+	{
+		applications.GET("/:id/documents", middleware.RequirePermission(permissions.DocumentsView), h.GetApplicationDocuments)
+		applications.POST("/:id/documents", middleware.RequirePermission(permissions.DocumentsGenerate), h.GenerateApplicationDocument)
+	}
+	placements := rg.Group("/placements")
+	{
+		placements.GET("/:id/documents", middleware.RequirePermission(permissions.DocumentsView), h.GetPlacementDocuments)
+		placements.POST("/:id/documents", middleware.RequirePermission(permissions.DocumentsGenerate), h.GeneratePlacementDocument)
+	}
+}
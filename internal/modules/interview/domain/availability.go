@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// Límites de autoagenda.
+const (
+	SlotStep         = 15 * time.Minute
+	MinNotice        = 2 * time.Hour
+	MaxSlots         = 300
+	DefaultLinkDays  = 14
+	MaxLinkDays      = 60
+	MaxBufferMinutes = 120
+)
+
+// Errores de Book: el enlace ya no admite reservas o el horario se ocupó
+// mientras el candidato elegía.
+var (
+	ErrLinkUnavailable = errors.New("scheduling link is no longer available")
+	ErrSlotTaken       = errors.New("slot is no longer available")
+)
+
+// HashToken es el hash con que se guarda y busca el token de un enlace de
+// autoagenda.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Interval es un rango [Start, End) en que un entrevistador está ocupado.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// PanelistAgenda es lo que se sabe de un entrevistador para calcular horarios:
+// sus franjas semanales, entrevistas agendadas y bloqueos.
+type PanelistAgenda struct {
+	Windows    []models.InterviewerAvailability
+	Interviews []Interval
+	Blackouts  []Interval
+}
+
+// SlotQuery describe la búsqueda de horarios libres.
+type SlotQuery struct {
+	From     time.Time
+	To       time.Time
+	Loc      *time.Location
+	Duration time.Duration
+	Buffer   time.Duration
+	Panel    []PanelistAgenda
+}
+
+// ParseClock interpreta "HH:MM" como minutos desde medianoche (24:00 vale
+// como fin del día).
+func ParseClock(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time '%s': use HH:MM", value)
+	}
+	h, errH := strconv.Atoi(parts[0])
+	m, errM := strconv.Atoi(parts[1])
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time '%s': use HH:MM", value)
+	}
+	return h*60 + m, nil
+}
+
+// FormatClock es la inversa de ParseClock.
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ValidateWindows exige weekday 0-6, inicio antes que fin y que las franjas
+// de un mismo día no se solapen.
+func ValidateWindows(windows []models.InterviewerAvailability) error {
+	byDay := map[int][]models.InterviewerAvailability{}
+	for _, w := range windows {
+		if w.Weekday < 0 || w.Weekday > 6 {
+			return fmt.Errorf("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if w.StartMinute >= w.EndMinute {
+			return fmt.Errorf("availability window must end after it starts (%s-%s)", FormatClock(w.StartMinute), FormatClock(w.EndMinute))
+		}
+		byDay[w.Weekday] = append(byDay[w.Weekday], w)
+	}
+	for day, list := range byDay {
+		sort.Slice(list, func(i, j int) bool { return list[i].StartMinute < list[j].StartMinute })
+		for i := 1; i < len(list); i++ {
+			if list[i].StartMinute < list[i-1].EndMinute {
+				return fmt.Errorf("availability windows overlap on %s", time.Weekday(day))
+			}
+		}
+	}
+	return nil
+}
+
+// Slots calcula los horarios en [From, To) en que todo el panel está
+// disponible: dentro de una franja semanal de cada uno, fuera de sus bloqueos
+// y sin entrevistas a menos de Buffer. Los inicios se alinean a SlotStep en
+// la hora local; se devuelven a lo sumo MaxSlots.
+func Slots(q SlotQuery) []Interval {
+	if len(q.Panel) == 0 || q.Duration <= 0 || !q.To.After(q.From) {
+		return nil
+	}
+	from, to := q.From.In(q.Loc), q.To.In(q.Loc)
+	step := int(SlotStep / time.Minute)
+	length := int(q.Duration / time.Minute)
+
+	var slots []Interval
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, q.Loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for minute := 0; minute+length <= 24*60; minute += step {
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, minute, 0, 0, q.Loc)
+			end := start.Add(q.Duration)
+			if start.Before(from) || end.After(to) {
+				continue
+			}
+			if panelFree(q, day.Weekday(), minute, minute+length, start, end) {
+				slots = append(slots, Interval{Start: start, End: end})
+				if len(slots) == MaxSlots {
+					return slots
+				}
+			}
+		}
+	}
+	return slots
+}
+
+// IsSlot reporta si start es uno de los horarios libres de la consulta.
+func IsSlot(q SlotQuery, start time.Time) bool {
+	for _, slot := range Slots(q) {
+		if slot.Start.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+func panelFree(q SlotQuery, weekday time.Weekday, startMin, endMin int, start, end time.Time) bool {
+	for _, p := range q.Panel {
+		if !inWindow(p.Windows, weekday, startMin, endMin) {
+			return false
+		}
+		for _, b := range p.Blackouts {
+			if Overlaps(start, end, b.Start, b.End) {
+				return false
+			}
+		}
+		for _, i := range p.Interviews {
+			if Overlaps(start.Add(-q.Buffer), end.Add(q.Buffer), i.Start, i.End) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func inWindow(windows []models.InterviewerAvailability, weekday time.Weekday, startMin, endMin int) bool {
+	for _, w := range windows {
+		if w.Weekday == int(weekday) && w.StartMinute <= startMin && endMin <= w.EndMinute {
+			return true
+		}
+	}
+	return false
+}
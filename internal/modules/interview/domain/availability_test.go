@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestParseClock(t *testing.T) {
+	if m, err := ParseClock("09:30"); err != nil || m != 570 {
+		t.Errorf("09:30 = 570 minutos, got %d (%v)", m, err)
+	}
+	if m, err := ParseClock("24:00"); err != nil || m != 1440 {
+		t.Errorf("24:00 es fin del día, got %d (%v)", m, err)
+	}
+	for _, bad := range []string{"9", "25:00", "10:75", "aa:bb"} {
+		if _, err := ParseClock(bad); err == nil {
+			t.Errorf("se esperaba error con %q", bad)
+		}
+	}
+}
+
+func TestValidateWindowsRechazaSolapes(t *testing.T) {
+	ok := []models.InterviewerAvailability{
+		{Weekday: 1, StartMinute: 540, EndMinute: 720},
+		{Weekday: 1, StartMinute: 720, EndMinute: 1020},
+	}
+	if err := ValidateWindows(ok); err != nil {
+		t.Errorf("franjas contiguas son válidas: %v", err)
+	}
+	overlap := append(ok, models.InterviewerAvailability{Weekday: 1, StartMinute: 600, EndMinute: 660})
+	if err := ValidateWindows(overlap); err == nil {
+		t.Error("se esperaba error por franjas solapadas")
+	}
+	if err := ValidateWindows([]models.InterviewerAvailability{{Weekday: 7, StartMinute: 0, EndMinute: 60}}); err == nil {
+		t.Error("weekday 7 no existe")
+	}
+}
+
+func TestSlotsRespetaPanelBufferYBloqueos(t *testing.T) {
+	loc := time.FixedZone("COT", -5*3600)
+	// Martes 20 de octubre de 2026.
+	day := func(h, m int) time.Time { return time.Date(2026, 10, 20, h, m, 0, 0, loc) }
+	tuesday := int(time.Tuesday)
+
+	ana := PanelistAgenda{
+		Windows: []models.InterviewerAvailability{{Weekday: tuesday, StartMinute: 9 * 60, EndMinute: 12 * 60}},
+		// Entrevista de 10:00 a 10:30: con 15 min de buffer no se puede
+		// empezar entre 9:30 y 10:30.
+		Interviews: []Interval{{Start: day(10, 0), End: day(10, 30)}},
+	}
+	beto := PanelistAgenda{
+		Windows:   []models.InterviewerAvailability{{Weekday: tuesday, StartMinute: 8 * 60, EndMinute: 18 * 60}},
+		Blackouts: []Interval{{Start: day(11, 30), End: day(13, 0)}},
+	}
+
+	slots := Slots(SlotQuery{
+		From:     day(0, 0),
+		To:       day(0, 0).AddDate(0, 0, 1),
+		Loc:      loc,
+		Duration: 30 * time.Minute,
+		Buffer:   15 * time.Minute,
+		Panel:    []PanelistAgenda{ana, beto},
+	})
+
+	var got []string
+	for _, s := range slots {
+		got = append(got, s.Start.Format("15:04"))
+	}
+	// Beto está bloqueado desde 11:30; Ana termina su franja a las 12:00.
+	want := []string{"09:00", "09:15", "10:45", "11:00"}
+	if len(got) != len(want) {
+		t.Fatalf("se esperaban %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("se esperaban %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSlotsSinDisponibilidadNoOfreceHorarios(t *testing.T) {
+	loc := time.UTC
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, loc)
+	withWindows := PanelistAgenda{Windows: []models.InterviewerAvailability{{Weekday: int(time.Monday), StartMinute: 540, EndMinute: 600}}}
+	slots := Slots(SlotQuery{
+		From: from, To: from.AddDate(0, 0, 7), Loc: loc, Duration: time.Hour,
+		Panel: []PanelistAgenda{withWindows, {}},
+	})
+	if len(slots) != 0 {
+		t.Errorf("un entrevistador sin franjas no debe dejar horarios, got %d", len(slots))
+	}
+}
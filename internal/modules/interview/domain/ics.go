@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Métodos iTIP (RFC 5546) de una invitación.
+const (
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+)
+
+// icsTimeLayout es la forma UTC de DATE-TIME (RFC 5545 §3.3.5). Usar UTC
+// evita incluir VTIMEZONE: cada cliente lo muestra en la zona del usuario.
+const icsTimeLayout = "20060102T150405Z"
+
+// Attendee es un participante (u organizador) de la invitación.
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// Event es lo necesario para generar una invitación. UID se mantiene en todas
+// las versiones del evento y Sequence crece con cada cambio enviado.
+type Event struct {
+	UID         string
+	Sequence    int
+	Method      string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Organizer   Attendee
+	Attendees   []Attendee
+}
+
+// BuildICS genera el VCALENDAR de una invitación (REQUEST) o cancelación
+// (CANCEL): líneas CRLF plegadas a 75 octetos y texto escapado.
+func BuildICS(e Event) []byte {
+	status := "CONFIRMED"
+	if e.Method == MethodCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//Dvra//ATS//ES",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + e.Method,
+		"BEGIN:VEVENT",
+		"UID:" + e.UID,
+		"DTSTAMP:" + e.Stamp.UTC().Format(icsTimeLayout),
+		"DTSTART:" + e.Start.UTC().Format(icsTimeLayout),
+		"DTEND:" + e.End.UTC().Format(icsTimeLayout),
+		"SEQUENCE:" + strconv.Itoa(e.Sequence),
+		"STATUS:" + status,
+		"SUMMARY:" + escapeText(e.Summary),
+	}
+	if e.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(e.Description))
+	}
+	if e.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeText(e.Location))
+	}
+	if e.URL != "" {
+		lines = append(lines, "URL:"+e.URL)
+	}
+	if e.Organizer.Email != "" {
+		lines = append(lines, "ORGANIZER"+cn(e.Organizer.Name)+":mailto:"+e.Organizer.Email)
+	}
+	for _, a := range e.Attendees {
+		if a.Email == "" {
+			continue
+		}
+		lines = append(lines, "ATTENDEE"+cn(a.Name)+";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:"+a.Email)
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(fold(line))
+	}
+	return []byte(b.String())
+}
+
+// escapeText escapa un valor TEXT (RFC 5545 §3.3.11).
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return r.Replace(s)
+}
+
+// cn arma el parámetro CN; va entre comillas y sin comillas internas porque
+// el nombre puede traer ':', ';' o ','.
+func cn(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, `"`, ""))
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
+
+// fold pliega una línea de contenido en tramos de hasta 75 octetos sin
+// partir caracteres UTF-8 (RFC 5545 §3.1) y le agrega el CRLF final.
+func fold(line string) string {
+	const limit = 75
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1 // el espacio de continuación cuenta
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,139 @@
+// Package domain define el centro del módulo interview: entrevistas de una
+// postulación con su panel, detección de solapamientos por entrevistador e
+// invitaciones iCalendar (RFC 5545). No importa gin ni gorm.
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// Límites de agenda.
+const (
+	DefaultDuration  = 60 * time.Minute
+	MaxDuration      = 12 * time.Hour
+	MaxCalendarRange = 93 * 24 * time.Hour
+	DefaultCalendar  = 7 * 24 * time.Hour
+)
+
+// localLayouts son los formatos aceptados sin zona: se interpretan en la zona
+// horaria de la empresa.
+var localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// Conflict es una entrevista ya agendada de un entrevistador que se solapa
+// con el rango pedido.
+type Conflict struct {
+	UserID      uint
+	InterviewID uint
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+// ListFilter acota el calendario.
+type ListFilter struct {
+	InterviewerID uint
+	ApplicationID uint
+	Status        string
+}
+
+// Location devuelve la zona horaria tz, o UTC si está vacía o no existe.
+func Location(tz string) *time.Location {
+	if tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ParseTime interpreta value como RFC 3339 (con zona) o, sin zona, como hora
+// local de loc.
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid time '" + value + "': use RFC 3339 or 2006-01-02T15:04 in the company timezone")
+}
+
+// ResolveEnd calcula el fin: endsAt explícito, o start + minutes (por defecto
+// DefaultDuration).
+func ResolveEnd(start time.Time, endsAt string, minutes int, loc *time.Location) (time.Time, error) {
+	if endsAt != "" {
+		return ParseTime(endsAt, loc)
+	}
+	if minutes > 0 {
+		return start.Add(time.Duration(minutes) * time.Minute), nil
+	}
+	return start.Add(DefaultDuration), nil
+}
+
+// ValidateRange exige que la entrevista termine después de empezar y no dure
+// más de MaxDuration.
+func ValidateRange(start, end time.Time) error {
+	if !end.After(start) {
+		return errors.New("interview must end after it starts")
+	}
+	if end.Sub(start) > MaxDuration {
+		return errors.New("interview cannot last more than 12 hours")
+	}
+	return nil
+}
+
+// Overlaps reporta si [aStart, aEnd) y [bStart, bEnd) se solapan. Una
+// entrevista que empieza justo cuando termina otra no se solapa.
+func Overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+// CanSetStatus reporta si una entrevista en from puede pasar a to. Solo una
+// agendada cambia de estado; completada, no-show y cancelada son finales.
+func CanSetStatus(from, to string) bool {
+	if from != models.InterviewStatusScheduled {
+		return false
+	}
+	switch to {
+	case models.InterviewStatusCompleted, models.InterviewStatusNoShow, models.InterviewStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// NormalizePanel quita repetidos y ceros, conservando el orden.
+func NormalizePanel(ids []uint) []uint {
+	seen := map[uint]bool{0: true}
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// PanelDiff devuelve los entrevistadores que salen del panel (a quienes se
+// envía la cancelación).
+func PanelDiff(current []models.InterviewPanelist, next []uint) []uint {
+	keep := make(map[uint]bool, len(next))
+	for _, id := range next {
+		keep[id] = true
+	}
+	var removed []uint
+	for _, p := range current {
+		if !keep[p.UserID] {
+			removed = append(removed, p.UserID)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestParseTimeUsaLaZonaDeLaEmpresaSinOffset(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*3600)
+
+	local, err := ParseTime("2026-10-20T09:30", bogota)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := local.UTC().Format("15:04"); got != "14:30" {
+		t.Errorf("09:30 en Bogotá debería ser 14:30 UTC, got %s", got)
+	}
+
+	explicit, err := ParseTime("2026-10-20T09:30:00+02:00", bogota)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := explicit.UTC().Format("15:04"); got != "07:30" {
+		t.Errorf("el offset explícito manda, got %s", got)
+	}
+
+	if _, err := ParseTime("mañana", bogota); err == nil {
+		t.Error("se esperaba error con un valor inválido")
+	}
+}
+
+func TestOverlapsNoCuentaEntrevistasContiguas(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 10, 20, h, 0, 0, 0, time.UTC) }
+	if !Overlaps(at(9), at(11), at(10), at(12)) {
+		t.Error("9-11 y 10-12 se solapan")
+	}
+	if Overlaps(at(9), at(10), at(10), at(11)) {
+		t.Error("9-10 y 10-11 son contiguas, no se solapan")
+	}
+}
+
+func TestBuildICSEscapaYPliegaLineas(t *testing.T) {
+	start := time.Date(2026, 10, 20, 14, 30, 0, 0, time.UTC)
+	ics := string(BuildICS(Event{
+		UID:       "abc@dvra.io",
+		Sequence:  2,
+		Method:    MethodCancel,
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Stamp:     start,
+		Summary:   "Entrevista técnica; backend, Go",
+		Location:  strings.Repeat("Sala de reuniones ñandú ", 5),
+		Organizer: Attendee{Name: `Ana "La Jefa" Pérez`, Email: "ana@acme.test"},
+		Attendees: []Attendee{{Name: "Luis", Email: "luis@acme.test"}, {Name: "Sin correo"}},
+	}))
+
+	for _, want := range []string{
+		"METHOD:CANCEL\r\n", "STATUS:CANCELLED\r\n", "SEQUENCE:2\r\n",
+		"DTSTART:20261020T143000Z\r\n",
+		`SUMMARY:Entrevista técnica\; backend\, Go` + "\r\n",
+		`ORGANIZER;CN="Ana La Jefa Pérez":mailto:ana@acme.test` + "\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("falta %q en\n%s", want, ics)
+		}
+	}
+	if strings.Count(ics, "ATTENDEE") != 1 {
+		t.Error("un participante sin email no debería incluirse")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("línea de %d octetos: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("el plegado partió un carácter UTF-8: %q", line)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// ApplicationRef es la vista mínima de una postulación que el módulo necesita:
+// tenant, etapas válidas de su vacante y los datos para la invitación.
+// CandidateEmail viene vacío si el candidato fue anonimizado.
+type ApplicationRef struct {
+	ID             uint
+	CompanyID      uint
+	Stage          string
+	Stages         []string
+	CandidateName  string
+	CandidateEmail string
+	JobTitle       string
+	CompanyName    string
+	Timezone       string
+}
+
+// ApplicationFinder resuelve postulaciones (módulo recruitment). Lo
+// implementa un adaptador del composition root; nil, nil si no existe.
+type ApplicationFinder interface {
+	FindByID(id uint) (*ApplicationRef, error)
+}
+
+// Person es un usuario con sus datos de contacto.
+type Person struct {
+	ID    uint
+	Name  string
+	Email string
+}
+
+// ApplicationLabel son los datos de la postulación que muestra el calendario.
+type ApplicationLabel struct {
+	CandidateName string
+	JobTitle      string
+}
+
+// ConflictCheck recibe los solapamientos del panel, ya bloqueado, y devuelve
+// un error si impiden guardar la entrevista.
+type ConflictCheck func(conflicts []Conflict) error
+
+// InterviewRepository es el puerto de salida hacia la persistencia.
+type InterviewRepository interface {
+	// Create guarda la entrevista con su panel. Si check no es nil, en la
+	// misma transacción bloquea a los entrevistadores y le pasa sus
+	// solapamientos; si check devuelve error no se inserta nada.
+	Create(interview *models.Interview, check ConflictCheck) error
+	GetByID(id uint) (*models.Interview, error)
+	// List devuelve las entrevistas de la empresa que empiezan en [from, to),
+	// por hora de inicio, con su panel.
+	List(companyID uint, from, to time.Time, filter ListFilter) ([]models.Interview, error)
+	ListByApplication(applicationID uint) ([]models.Interview, error)
+	// Update guarda los campos de la entrevista y reemplaza su panel por
+	// panel, en una transacción. check funciona como en Create.
+	Update(interview *models.Interview, panel []uint, check ConflictCheck) error
+	// Save guarda solo los campos de la entrevista (estado, cancelación).
+	Save(interview *models.Interview) error
+
+	// Conflicts devuelve las entrevistas agendadas de userIDs que se solapan
+	// con [start, end), excepto excludeID.
+	Conflicts(userIDs []uint, start, end time.Time, excludeID uint) ([]Conflict, error)
+	// Members devuelve los usuarios con membresía activa en la empresa; los
+	// que no son miembros no aparecen.
+	Members(companyID uint, userIDs []uint) (map[uint]Person, error)
+	People(ids []uint) (map[uint]Person, error)
+	CompanyTimezone(companyID uint) (string, error)
+	ApplicationLabels(ids []uint) (map[uint]ApplicationLabel, error)
+}
+
+// SchedulingRepository persiste la disponibilidad de los entrevistadores y
+// los enlaces de autoagenda.
+type SchedulingRepository interface {
+	Availability(companyID, userID uint) ([]models.InterviewerAvailability, error)
+	// ReplaceAvailability reemplaza todas las franjas del usuario en la
+	// empresa, en una transacción.
+	ReplaceAvailability(companyID, userID uint, windows []models.InterviewerAvailability) error
+	// Blackouts devuelve los bloqueos del usuario que terminan después de from.
+	Blackouts(companyID, userID uint, from time.Time) ([]models.AvailabilityBlackout, error)
+	CreateBlackout(blackout *models.AvailabilityBlackout) error
+	GetBlackout(id uint) (*models.AvailabilityBlackout, error)
+	DeleteBlackout(id uint) error
+	// Agendas devuelve, por usuario, franjas, bloqueos y entrevistas agendadas
+	// que tocan [from, to).
+	Agendas(companyID uint, userIDs []uint, from, to time.Time) (map[uint]PanelistAgenda, error)
+
+	CreateLink(link *models.SchedulingLink) error
+	GetLink(id uint) (*models.SchedulingLink, error)
+	GetLinkByTokenHash(hash string) (*models.SchedulingLink, error)
+	ListLinks(applicationID uint) ([]models.SchedulingLink, error)
+	SaveLink(link *models.SchedulingLink) error
+	// Book crea la entrevista del enlace y lo cierra en una transacción:
+	// bloquea el enlace y a los entrevistadores del panel y vuelve a buscar
+	// solapamientos (con el buffer del enlace) antes de insertar. Devuelve
+	// ErrLinkUnavailable o ErrSlotTaken si perdió la carrera.
+	Book(linkID uint, interview *models.Interview) error
+}
+
+// Mailer envía correos: invitaciones iCalendar como adjunto y avisos de
+// texto (enlaces de autoagenda). Lo implementa un adaptador del composition
+// root sobre el envío de correo.
+type Mailer interface {
+	SendInvite(to []string, subject, body string, ics []byte, method string) error
+	SendEmail(to []string, subject, body string) error
+}
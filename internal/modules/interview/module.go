@@ -0,0 +1,43 @@
+// Package interview es el punto de ensamblaje del módulo de entrevistas:
+// agenda por postulación y etapa, panel, solapamientos, invitaciones .ics y
+// autoagenda del candidato según la disponibilidad del panel.
+// Nadie importa este paquete salvo el composition root.
+package interview
+
+import (
+	"dvra-api/internal/modules/interview/domain"
+	"dvra-api/internal/modules/interview/repository"
+	"dvra-api/internal/modules/interview/service"
+	"dvra-api/internal/modules/interview/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo interview.
+type Module struct {
+	Service           *service.InterviewService
+	SchedulingService *service.SchedulingService
+}
+
+// New construye el módulo. apps resuelve postulaciones (recruitment) y
+// mailer envía invitaciones y enlaces por correo; ambos son adaptadores que
+// inyecta el composition root. publicURL es la base de los enlaces de
+// autoagenda (frontend).
+func New(db *gorm.DB, apps domain.ApplicationFinder, mailer domain.Mailer, publicURL string) *Module {
+	svc := service.NewInterviewService(repository.NewInterviewRepository(db), apps, mailer)
+	return &Module{
+		Service:           svc,
+		SchedulingService: service.NewSchedulingService(repository.NewSchedulingRepository(db), svc, publicURL),
+	}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service, m.SchedulingService)
+}
+
+// RegisterPublicRoutes monta la autoagenda del candidato.
+func (m *Module) RegisterPublicRoutes(public *gin.RouterGroup) {
+	transport.RegisterPublicRoutes(public, m.SchedulingService)
+}
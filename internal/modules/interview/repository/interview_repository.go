@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/interview/domain"
+
+	"gorm.io/gorm"
+)
+
+type interviewRepository struct {
+	db *gorm.DB
+}
+
+// NewInterviewRepository devuelve la implementación del puerto.
+func NewInterviewRepository(db *gorm.DB) domain.InterviewRepository {
+	return &interviewRepository{db: db}
+}
+
+func (r *interviewRepository) Create(interview *models.Interview, check domain.ConflictCheck) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		panel := make([]uint, len(interview.Panel))
+		for i, p := range interview.Panel {
+			panel[i] = p.UserID
+		}
+		if err := checkPanel(tx, panel, interview.StartsAt, interview.EndsAt, 0, check); err != nil {
+			return err
+		}
+		return tx.Create(interview).Error
+	})
+}
+
+func (r *interviewRepository) GetByID(id uint) (*models.Interview, error) {
+	var interview models.Interview
+	if err := r.db.Preload("Panel").First(&interview, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &interview, nil
+}
+
+func (r *interviewRepository) List(companyID uint, from, to time.Time, filter domain.ListFilter) ([]models.Interview, error) {
+	query := r.db.Preload("Panel").
+		Where("company_id = ? AND starts_at >= ? AND starts_at < ?", companyID, from, to).
+		// Las entrevistas de postulaciones en la papelera no ocupan agenda.
+		Where("application_id IN (?)", r.db.Table("applications").Select("id").Where("deleted_at IS NULL"))
+	if filter.InterviewerID != 0 {
+		query = query.Where("id IN (?)", r.db.Model(&models.InterviewPanelist{}).Select("interview_id").Where("user_id = ?", filter.InterviewerID))
+	}
+	if filter.ApplicationID != 0 {
+		query = query.Where("application_id = ?", filter.ApplicationID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var interviews []models.Interview
+	if err := query.Order("starts_at ASC, id ASC").Find(&interviews).Error; err != nil {
+		return nil, err
+	}
+	return interviews, nil
+}
+
+func (r *interviewRepository) ListByApplication(applicationID uint) ([]models.Interview, error) {
+	var interviews []models.Interview
+	if err := r.db.Preload("Panel").
+		Where("application_id = ?", applicationID).
+		Order("starts_at ASC, id ASC").
+		Find(&interviews).Error; err != nil {
+		return nil, err
+	}
+	return interviews, nil
+}
+
+func (r *interviewRepository) Update(interview *models.Interview, panel []uint, check domain.ConflictCheck) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkPanel(tx, panel, interview.StartsAt, interview.EndsAt, interview.ID, check); err != nil {
+			return err
+		}
+		if err := tx.Omit("Panel").Save(interview).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().
+			Where("interview_id = ? AND user_id NOT IN ?", interview.ID, panel).
+			Delete(&models.InterviewPanelist{}).Error; err != nil {
+			return err
+		}
+
+		var existing []uint
+		if err := tx.Model(&models.InterviewPanelist{}).
+			Where("interview_id = ?", interview.ID).
+			Pluck("user_id", &existing).Error; err != nil {
+			return err
+		}
+		has := make(map[uint]bool, len(existing))
+		for _, id := range existing {
+			has[id] = true
+		}
+		var added []models.InterviewPanelist
+		for _, userID := range panel {
+			if !has[userID] {
+				added = append(added, models.InterviewPanelist{InterviewID: interview.ID, UserID: userID})
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		return tx.Create(&added).Error
+	})
+}
+
+func (r *interviewRepository) Save(interview *models.Interview) error {
+	return r.db.Omit("Panel").Save(interview).Error
+}
+
+func (r *interviewRepository) Conflicts(userIDs []uint, start, end time.Time, excludeID uint) ([]domain.Conflict, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	var rows []struct {
+		UserID      uint
+		InterviewID uint
+		StartsAt    time.Time
+		EndsAt      time.Time
+	}
+	if err := r.db.Table("interview_panelists p").
+		Select("p.user_id, i.id AS interview_id, i.starts_at, i.ends_at").
+		Joins("JOIN interviews i ON i.id = p.interview_id AND i.deleted_at IS NULL").
+		Joins("JOIN applications a ON a.id = i.application_id AND a.deleted_at IS NULL").
+		Where("p.deleted_at IS NULL AND p.user_id IN ?", userIDs).
+		Where("i.status = ? AND i.id <> ?", models.InterviewStatusScheduled, excludeID).
+		Where("i.starts_at < ? AND i.ends_at > ?", end, start).
+		Order("i.starts_at ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]domain.Conflict, len(rows))
+	for i, row := range rows {
+		conflicts[i] = domain.Conflict{UserID: row.UserID, InterviewID: row.InterviewID, StartsAt: row.StartsAt, EndsAt: row.EndsAt}
+	}
+	return conflicts, nil
+}
+
+// lockedConflicts toma un advisory lock de transacción por cada entrevistador
+// del panel (en orden de clave, para no generar deadlocks) y devuelve sus
+// solapamientos con [start, end). Así se serializan las transacciones que
+// agendan al mismo entrevistador: la segunda espera el bloqueo y ya ve la
+// entrevista de la primera. Los locks se liberan con el commit o rollback y
+// no bloquean las filas de users.
+func lockedConflicts(tx *gorm.DB, panel []uint, start, end time.Time, excludeID uint) ([]domain.Conflict, error) {
+	keys := make([]int64, 0, len(panel))
+	seen := make(map[uint]bool, len(panel))
+	for _, id := range panel {
+		if !seen[id] {
+			seen[id] = true
+			keys = append(keys, interviewerLockKey(id))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, key := range keys {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", key).Error; err != nil {
+			return nil, err
+		}
+	}
+	return (&interviewRepository{db: tx}).Conflicts(panel, start, end, excludeID)
+}
+
+// interviewerLockKey deriva la clave int64 del advisory lock del entrevistador.
+func interviewerLockKey(userID uint) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("dvra-interviewer:" + strconv.FormatUint(uint64(userID), 10)))
+	return int64(h.Sum64())
+}
+
+// checkPanel aplica check a los solapamientos del panel bloqueado; sin check
+// (se agenda aunque haya solapamientos) no bloquea nada.
+func checkPanel(tx *gorm.DB, panel []uint, start, end time.Time, excludeID uint, check domain.ConflictCheck) error {
+	if check == nil || len(panel) == 0 {
+		return nil
+	}
+	conflicts, err := lockedConflicts(tx, panel, start, end, excludeID)
+	if err != nil {
+		return err
+	}
+	return check(conflicts)
+}
+
+func (r *interviewRepository) Members(companyID uint, userIDs []uint) (map[uint]domain.Person, error) {
+	members := make(map[uint]domain.Person, len(userIDs))
+	if len(userIDs) == 0 {
+		return members, nil
+	}
+	var rows []domain.Person
+	if err := r.db.Table("memberships m").
+		Select("u.id, NULLIF(CONCAT_WS(' ', u.first_name, u.last_name), '') AS name, u.email").
+		Joins("JOIN users u ON u.id = m.user_id AND u.deleted_at IS NULL").
+		Where("m.deleted_at IS NULL AND m.company_id = ? AND m.status = ? AND m.user_id IN ?", companyID, "active", userIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		members[row.ID] = row
+	}
+	return members, nil
+}
+
+func (r *interviewRepository) People(ids []uint) (map[uint]domain.Person, error) {
+	people := make(map[uint]domain.Person, len(ids))
+	if len(ids) == 0 {
+		return people, nil
+	}
+	var rows []domain.Person
+	if err := r.db.Table("users").
+		Select("id, NULLIF(CONCAT_WS(' ', first_name, last_name), '') AS name, email").
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		people[row.ID] = row
+	}
+	return people, nil
+}
+
+func (r *interviewRepository) CompanyTimezone(companyID uint) (string, error) {
+	var zones []string
+	if err := r.db.Table("companies").
+		Where("id = ? AND deleted_at IS NULL", companyID).
+		Limit(1).
+		Pluck("timezone", &zones).Error; err != nil {
+		return "", err
+	}
+	if len(zones) == 0 {
+		return "", nil
+	}
+	return zones[0], nil
+}
+
+func (r *interviewRepository) ApplicationLabels(ids []uint) (map[uint]domain.ApplicationLabel, error) {
+	labels := make(map[uint]domain.ApplicationLabel, len(ids))
+	if len(ids) == 0 {
+		return labels, nil
+	}
+	var rows []struct {
+		ID            uint
+		CandidateName string
+		JobTitle      string
+	}
+	if err := r.db.Table("applications a").
+		Select("a.id, CONCAT_WS(' ', c.first_name, c.last_name) AS candidate_name, j.title AS job_title").
+		Joins("LEFT JOIN candidates c ON c.id = a.candidate_id").
+		Joins("LEFT JOIN jobs j ON j.id = a.job_id").
+		Where("a.id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		labels[row.ID] = domain.ApplicationLabel{CandidateName: row.CandidateName, JobTitle: row.JobTitle}
+	}
+	return labels, nil
+}
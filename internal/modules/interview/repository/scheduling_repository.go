@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/interview/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type schedulingRepository struct {
+	db *gorm.DB
+}
+
+// NewSchedulingRepository devuelve la implementación del puerto.
+func NewSchedulingRepository(db *gorm.DB) domain.SchedulingRepository {
+	return &schedulingRepository{db: db}
+}
+
+func (r *schedulingRepository) Availability(companyID, userID uint) ([]models.InterviewerAvailability, error) {
+	var windows []models.InterviewerAvailability
+	if err := r.db.Where("company_id = ? AND user_id = ?", companyID, userID).
+		Order("weekday ASC, start_minute ASC").
+		Find(&windows).Error; err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (r *schedulingRepository) ReplaceAvailability(companyID, userID uint, windows []models.InterviewerAvailability) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("company_id = ? AND user_id = ?", companyID, userID).
+			Delete(&models.InterviewerAvailability{}).Error; err != nil {
+			return err
+		}
+		if len(windows) == 0 {
+			return nil
+		}
+		return tx.Create(&windows).Error
+	})
+}
+
+func (r *schedulingRepository) Blackouts(companyID, userID uint, from time.Time) ([]models.AvailabilityBlackout, error) {
+	var blackouts []models.AvailabilityBlackout
+	if err := r.db.Where("company_id = ? AND user_id = ? AND ends_at > ?", companyID, userID, from).
+		Order("starts_at ASC").
+		Find(&blackouts).Error; err != nil {
+		return nil, err
+	}
+	return blackouts, nil
+}
+
+func (r *schedulingRepository) CreateBlackout(blackout *models.AvailabilityBlackout) error {
+	return r.db.Create(blackout).Error
+}
+
+func (r *schedulingRepository) GetBlackout(id uint) (*models.AvailabilityBlackout, error) {
+	var blackout models.AvailabilityBlackout
+	if err := r.db.First(&blackout, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &blackout, nil
+}
+
+func (r *schedulingRepository) DeleteBlackout(id uint) error {
+	return r.db.Unscoped().Delete(&models.AvailabilityBlackout{}, id).Error
+}
+
+func (r *schedulingRepository) Agendas(companyID uint, userIDs []uint, from, to time.Time) (map[uint]domain.PanelistAgenda, error) {
+	agendas := make(map[uint]domain.PanelistAgenda, len(userIDs))
+	if len(userIDs) == 0 {
+		return agendas, nil
+	}
+
+	var windows []models.InterviewerAvailability
+	if err := r.db.Where("company_id = ? AND user_id IN ?", companyID, userIDs).
+		Find(&windows).Error; err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		agenda := agendas[w.UserID]
+		agenda.Windows = append(agenda.Windows, w)
+		agendas[w.UserID] = agenda
+	}
+
+	var blackouts []models.AvailabilityBlackout
+	if err := r.db.Where("company_id = ? AND user_id IN ? AND starts_at < ? AND ends_at > ?", companyID, userIDs, to, from).
+		Find(&blackouts).Error; err != nil {
+		return nil, err
+	}
+	for _, b := range blackouts {
+		agenda := agendas[b.UserID]
+		agenda.Blackouts = append(agenda.Blackouts, domain.Interval{Start: b.StartsAt, End: b.EndsAt})
+		agendas[b.UserID] = agenda
+	}
+
+	// Las entrevistas ocupan al entrevistador en cualquier empresa.
+	conflicts, err := (&interviewRepository{db: r.db}).Conflicts(userIDs, from, to, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		agenda := agendas[c.UserID]
+		agenda.Interviews = append(agenda.Interviews, domain.Interval{Start: c.StartsAt, End: c.EndsAt})
+		agendas[c.UserID] = agenda
+	}
+	return agendas, nil
+}
+
+func (r *schedulingRepository) CreateLink(link *models.SchedulingLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *schedulingRepository) GetLink(id uint) (*models.SchedulingLink, error) {
+	var link models.SchedulingLink
+	if err := r.db.First(&link, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *schedulingRepository) GetLinkByTokenHash(hash string) (*models.SchedulingLink, error) {
+	var link models.SchedulingLink
+	if err := r.db.Where("token_hash = ?", hash).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *schedulingRepository) ListLinks(applicationID uint) ([]models.SchedulingLink, error) {
+	var links []models.SchedulingLink
+	if err := r.db.Where("application_id = ?", applicationID).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *schedulingRepository) SaveLink(link *models.SchedulingLink) error {
+	return r.db.Save(link).Error
+}
+
+func (r *schedulingRepository) Book(linkID uint, interview *models.Interview) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Bloquear el enlace impide reservarlo dos veces.
+		var link models.SchedulingLink
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&link, linkID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return domain.ErrLinkUnavailable
+			}
+			return err
+		}
+		now := time.Now()
+		if link.Status != models.SchedulingLinkOpen || !link.ExpiresAt.After(now) {
+			return domain.ErrLinkUnavailable
+		}
+
+		// Bloquear a los entrevistadores serializa las reservas (y las
+		// entrevistas manuales) que comparten panel.
+		buffer := time.Duration(link.BufferMinutes) * time.Minute
+		conflicts, err := lockedConflicts(tx, []uint(link.PanelIDs), interview.StartsAt.Add(-buffer), interview.EndsAt.Add(buffer), 0)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return domain.ErrSlotTaken
+		}
+
+		if err := tx.Create(interview).Error; err != nil {
+			return err
+		}
+		link.Status = models.SchedulingLinkBooked
+		link.InterviewID = &interview.ID
+		link.BookedAt = &now
+		return tx.Save(&link).Error
+	})
+}
@@ -0,0 +1,577 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/interview/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// localFormat es como se muestran las horas en mensajes e invitaciones.
+const localFormat = "2006-01-02 15:04"
+
+// InterviewService agenda entrevistas de una postulación con su panel,
+// detecta solapamientos por entrevistador y envía invitaciones iCalendar
+// (alta, cambios y cancelaciones) al panel y al candidato.
+type InterviewService struct {
+	repo   domain.InterviewRepository
+	apps   domain.ApplicationFinder
+	mailer domain.Mailer
+}
+
+func NewInterviewService(repo domain.InterviewRepository, apps domain.ApplicationFinder, mailer domain.Mailer) *InterviewService {
+	return &InterviewService{repo: repo, apps: apps, mailer: mailer}
+}
+
+// List devuelve el calendario de la empresa entre from y to (fechas u horas
+// en su zona; por defecto los próximos 7 días desde hoy, máximo 93 días).
+func (s *InterviewService) List(companyID uint, from, to string, filter domain.ListFilter) ([]dtos.InterviewDTO, error) {
+	tz, err := s.repo.CompanyTimezone(companyID)
+	if err != nil {
+		return nil, err
+	}
+	loc := domain.Location(tz)
+
+	start := time.Now().In(loc)
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	if from != "" {
+		if start, err = domain.ParseTime(from, loc); err != nil {
+			return nil, apperr.BadRequest(err.Error())
+		}
+	}
+	end := start.Add(domain.DefaultCalendar)
+	if to != "" {
+		if end, err = domain.ParseTime(to, loc); err != nil {
+			return nil, apperr.BadRequest(err.Error())
+		}
+	}
+	if !end.After(start) {
+		return nil, apperr.BadRequest("'to' must be after 'from'")
+	}
+	if end.Sub(start) > domain.MaxCalendarRange {
+		return nil, apperr.BadRequest("calendar range cannot exceed 93 days")
+	}
+
+	interviews, err := s.repo.List(companyID, start, end, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.present(interviews)
+}
+
+// ListByApplication devuelve las entrevistas de una postulación.
+func (s *InterviewService) ListByApplication(applicationID, companyID uint) ([]dtos.InterviewDTO, error) {
+	if _, err := s.application(applicationID, companyID); err != nil {
+		return nil, err
+	}
+	interviews, err := s.repo.ListByApplication(applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.present(interviews)
+}
+
+// Get devuelve una entrevista validando el tenant (companyID 0 = SuperAdmin).
+func (s *InterviewService) Get(id, companyID uint) (*dtos.InterviewDTO, error) {
+	interview, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.presentOne(interview)
+}
+
+// Schedule agenda una entrevista y envía la invitación. Si un entrevistador
+// ya tiene otra entrevista en ese horario responde 409, salvo allow_conflicts.
+func (s *InterviewService) Schedule(companyID, organizerID uint, dto dtos.ScheduleInterviewDTO) (*dtos.InterviewDTO, error) {
+	app, err := s.application(dto.ApplicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	stage := dto.Stage
+	if stage == "" {
+		stage = app.Stage
+	}
+	if !contains(app.Stages, stage) {
+		return nil, apperr.BadRequest(fmt.Sprintf("stage '%s' is not part of this job's pipeline", stage))
+	}
+
+	loc := domain.Location(app.Timezone)
+	start, end, err := timeRange(dto.StartsAt, dto.EndsAt, dto.DurationMinutes, loc)
+	if err != nil {
+		return nil, err
+	}
+	panel := domain.NormalizePanel(dto.PanelIDs)
+	members, err := s.panel(app.CompanyID, panel)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := newUID()
+	if err != nil {
+		return nil, err
+	}
+	interview := &models.Interview{
+		CompanyID:     app.CompanyID,
+		ApplicationID: app.ID,
+		Stage:         stage,
+		Title:         title(dto.Title, app),
+		StartsAt:      start.UTC(),
+		EndsAt:        end.UTC(),
+		Timezone:      loc.String(),
+		Location:      strings.TrimSpace(dto.Location),
+		VideoURL:      strings.TrimSpace(dto.VideoURL),
+		Status:        models.InterviewStatusScheduled,
+		UID:           uid,
+	}
+	if organizerID != 0 {
+		interview.OrganizerID = &organizerID
+	}
+	for _, userID := range panel {
+		interview.Panel = append(interview.Panel, models.InterviewPanelist{UserID: userID})
+	}
+	if err := s.repo.Create(interview, conflictCheck(dto.AllowConflicts, members, loc)); err != nil {
+		return nil, err
+	}
+
+	s.send(interview, app, domain.MethodRequest, personsOf(panel, members), notify(dto.NotifyCandidate), "Entrevista")
+	return s.presentOne(interview)
+}
+
+// Update reagenda una entrevista: horario, lugar y panel. Si algo cambia se
+// incrementa la secuencia, se envía la invitación actualizada y quienes
+// salen del panel reciben la cancelación.
+func (s *InterviewService) Update(id, companyID uint, dto dtos.UpdateInterviewDTO) (*dtos.InterviewDTO, error) {
+	interview, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status != models.InterviewStatusScheduled {
+		return nil, apperr.Conflict("only scheduled interviews can be rescheduled")
+	}
+	app, err := s.application(interview.ApplicationID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := domain.Location(interview.Timezone)
+	start, end, err := timeRange(dto.StartsAt, dto.EndsAt, dto.DurationMinutes, loc)
+	if err != nil {
+		return nil, err
+	}
+	panel := domain.NormalizePanel(dto.PanelIDs)
+	members, err := s.panel(interview.CompanyID, panel)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := domain.PanelDiff(interview.Panel, panel)
+	changed := !start.Equal(interview.StartsAt) || !end.Equal(interview.EndsAt) ||
+		strings.TrimSpace(dto.Location) != interview.Location || strings.TrimSpace(dto.VideoURL) != interview.VideoURL ||
+		len(removed) > 0 || len(panel) != len(interview.Panel)
+	if dto.Title != "" && dto.Title != interview.Title {
+		interview.Title = strings.TrimSpace(dto.Title)
+		changed = true
+	}
+
+	interview.StartsAt = start.UTC()
+	interview.EndsAt = end.UTC()
+	interview.Location = strings.TrimSpace(dto.Location)
+	interview.VideoURL = strings.TrimSpace(dto.VideoURL)
+	if changed {
+		interview.Sequence++
+	}
+	if err := s.repo.Update(interview, panel, conflictCheck(dto.AllowConflicts, members, loc)); err != nil {
+		return nil, err
+	}
+	interview.Panel = interview.Panel[:0]
+	for _, userID := range panel {
+		interview.Panel = append(interview.Panel, models.InterviewPanelist{InterviewID: interview.ID, UserID: userID})
+	}
+
+	if changed {
+		if len(removed) > 0 {
+			gone, err := s.repo.People(removed)
+			if err == nil {
+				s.send(interview, app, domain.MethodCancel, personsOf(removed, gone), false, "Cancelada")
+			}
+		}
+		s.send(interview, app, domain.MethodRequest, personsOf(panel, members), notify(dto.NotifyCandidate), "Actualizada")
+	}
+	return s.presentOne(interview)
+}
+
+// Cancel cancela una entrevista agendada y envía la cancelación.
+func (s *InterviewService) Cancel(id, companyID uint, dto dtos.CancelInterviewDTO) (*dtos.InterviewDTO, error) {
+	interview, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanSetStatus(interview.Status, models.InterviewStatusCancelled) {
+		return nil, apperr.Conflict("only scheduled interviews can be cancelled")
+	}
+	app, err := s.application(interview.ApplicationID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	interview.Status = models.InterviewStatusCancelled
+	interview.CancelReason = strings.TrimSpace(dto.Reason)
+	interview.CancelledAt = &now
+	interview.Sequence++
+	if err := s.repo.Save(interview); err != nil {
+		return nil, err
+	}
+
+	people, err := s.repo.People(panelIDs(interview))
+	if err == nil {
+		s.send(interview, app, domain.MethodCancel, personsOf(panelIDs(interview), people), notify(dto.NotifyCandidate), "Cancelada")
+	}
+	return s.presentOne(interview)
+}
+
+// SetStatus cierra una entrevista agendada como completada o no-show.
+func (s *InterviewService) SetStatus(id, companyID uint, status string) (*dtos.InterviewDTO, error) {
+	interview, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if status == models.InterviewStatusCancelled {
+		return nil, apperr.BadRequest("use the cancel endpoint to cancel an interview")
+	}
+	if !domain.CanSetStatus(interview.Status, status) {
+		return nil, apperr.Conflict(fmt.Sprintf("cannot change an interview from '%s' to '%s'", interview.Status, status))
+	}
+	interview.Status = status
+	if err := s.repo.Save(interview); err != nil {
+		return nil, err
+	}
+	return s.presentOne(interview)
+}
+
+// Invite devuelve el .ics vigente de la entrevista (cancelación si se canceló),
+// para descargarlo o reenviarlo.
+func (s *InterviewService) Invite(id, companyID uint) ([]byte, error) {
+	interview, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	app, err := s.application(interview.ApplicationID, 0)
+	if err != nil {
+		return nil, err
+	}
+	people, err := s.repo.People(panelIDs(interview))
+	if err != nil {
+		return nil, err
+	}
+	method := domain.MethodRequest
+	if interview.Status == models.InterviewStatusCancelled {
+		method = domain.MethodCancel
+	}
+	event, err := s.event(interview, app, method, personsOf(panelIDs(interview), people), true)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildICS(event), nil
+}
+
+// Conflicts devuelve las entrevistas que se solapan con el horario para los
+// entrevistadores dados (chequeo previo a agendar). excludeID omite la
+// entrevista que se está reagendando.
+func (s *InterviewService) Conflicts(companyID uint, panelIDs []uint, startsAt, endsAt string, excludeID uint) ([]dtos.InterviewConflictDTO, error) {
+	tz, err := s.repo.CompanyTimezone(companyID)
+	if err != nil {
+		return nil, err
+	}
+	loc := domain.Location(tz)
+	start, end, err := timeRange(startsAt, endsAt, 0, loc)
+	if err != nil {
+		return nil, err
+	}
+	panel := domain.NormalizePanel(panelIDs)
+	conflicts, err := s.repo.Conflicts(panel, start, end, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	people, err := s.repo.People(panel)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.InterviewConflictDTO, len(conflicts))
+	for i, c := range conflicts {
+		result[i] = dtos.InterviewConflictDTO{
+			UserID: c.UserID, UserName: people[c.UserID].Name, InterviewID: c.InterviewID,
+			StartsAt: c.StartsAt.In(loc), EndsAt: c.EndsAt.In(loc),
+		}
+	}
+	return result, nil
+}
+
+// get devuelve una entrevista validando el tenant.
+func (s *InterviewService) get(id, companyID uint) (*models.Interview, error) {
+	interview, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if interview == nil || (companyID != 0 && interview.CompanyID != companyID) {
+		return nil, apperr.NotFound("interview not found")
+	}
+	return interview, nil
+}
+
+// application resuelve la postulación validando el tenant.
+func (s *InterviewService) application(id, companyID uint) (*domain.ApplicationRef, error) {
+	app, err := s.apps.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil || (companyID != 0 && app.CompanyID != companyID) {
+		return nil, apperr.NotFound("application not found")
+	}
+	return app, nil
+}
+
+// panel exige que todos los entrevistadores sean miembros activos de la empresa.
+func (s *InterviewService) panel(companyID uint, panel []uint) (map[uint]domain.Person, error) {
+	if len(panel) == 0 {
+		return nil, apperr.BadRequest("an interview needs at least one interviewer")
+	}
+	members, err := s.repo.Members(companyID, panel)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range panel {
+		if _, ok := members[userID]; !ok {
+			return nil, apperr.BadRequest(fmt.Sprintf("user %d is not a member of this company", userID))
+		}
+	}
+	return members, nil
+}
+
+// conflictCheck responde 409 si algún entrevistador ya tiene una entrevista
+// agendada que se solapa. El repositorio la evalúa con el panel bloqueado;
+// con allow_conflicts no hay comprobación.
+func conflictCheck(allow bool, members map[uint]domain.Person, loc *time.Location) domain.ConflictCheck {
+	if allow {
+		return nil
+	}
+	return func(conflicts []domain.Conflict) error {
+		if len(conflicts) == 0 {
+			return nil
+		}
+		parts := make([]string, len(conflicts))
+		for i, c := range conflicts {
+			name := members[c.UserID].Name
+			if name == "" {
+				name = fmt.Sprintf("user %d", c.UserID)
+			}
+			parts[i] = fmt.Sprintf("%s already has interview #%d (%s–%s)", name, c.InterviewID,
+				c.StartsAt.In(loc).Format(localFormat), c.EndsAt.In(loc).Format("15:04"))
+		}
+		return apperr.Conflict("scheduling conflict: " + strings.Join(parts, "; ") + "; send allow_conflicts=true to schedule anyway")
+	}
+}
+
+// send envía la invitación a recipients (y al candidato si includeCandidate).
+// Es best-effort: la entrevista ya quedó guardada.
+func (s *InterviewService) send(interview *models.Interview, app *domain.ApplicationRef, method string, recipients []domain.Person, includeCandidate bool, prefix string) {
+	if s.mailer == nil {
+		return
+	}
+	event, err := s.event(interview, app, method, recipients, includeCandidate)
+	if err != nil {
+		return
+	}
+	to := make([]string, 0, len(event.Attendees))
+	for _, a := range event.Attendees {
+		if a.Email != "" {
+			to = append(to, a.Email)
+		}
+	}
+	if len(to) == 0 {
+		return
+	}
+	subject := fmt.Sprintf("%s: %s — %s", prefix, interview.Title, app.CompanyName)
+	_ = s.mailer.SendInvite(to, subject, body(interview, app, method), domain.BuildICS(event), method)
+}
+
+func (s *InterviewService) event(interview *models.Interview, app *domain.ApplicationRef, method string, recipients []domain.Person, includeCandidate bool) (domain.Event, error) {
+	event := domain.Event{
+		UID:         interview.UID,
+		Sequence:    interview.Sequence,
+		Method:      method,
+		Start:       interview.StartsAt,
+		End:         interview.EndsAt,
+		Stamp:       time.Now(),
+		Summary:     interview.Title,
+		Description: body(interview, app, method),
+		Location:    interview.Location,
+		URL:         interview.VideoURL,
+	}
+	if event.Location == "" {
+		event.Location = interview.VideoURL
+	}
+	if interview.OrganizerID != nil {
+		organizers, err := s.repo.People([]uint{*interview.OrganizerID})
+		if err != nil {
+			return event, err
+		}
+		if o, ok := organizers[*interview.OrganizerID]; ok {
+			event.Organizer = domain.Attendee{Name: o.Name, Email: o.Email}
+		}
+	}
+	for _, p := range recipients {
+		event.Attendees = append(event.Attendees, domain.Attendee{Name: p.Name, Email: p.Email})
+	}
+	if includeCandidate && app.CandidateEmail != "" {
+		event.Attendees = append(event.Attendees, domain.Attendee{Name: app.CandidateName, Email: app.CandidateEmail})
+	}
+	return event, nil
+}
+
+// present arma los DTOs en la zona de cada entrevista, con candidato, vacante
+// y panel.
+func (s *InterviewService) present(interviews []models.Interview) ([]dtos.InterviewDTO, error) {
+	appIDs := make([]uint, 0, len(interviews))
+	var userIDs []uint
+	for _, i := range interviews {
+		appIDs = append(appIDs, i.ApplicationID)
+		userIDs = append(userIDs, panelIDs(&i)...)
+	}
+	labels, err := s.repo.ApplicationLabels(appIDs)
+	if err != nil {
+		return nil, err
+	}
+	people, err := s.repo.People(domain.NormalizePanel(userIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dtos.InterviewDTO, len(interviews))
+	for idx, i := range interviews {
+		loc := domain.Location(i.Timezone)
+		dto := dtos.InterviewDTO{
+			ID:            i.ID,
+			ApplicationID: i.ApplicationID,
+			CandidateName: labels[i.ApplicationID].CandidateName,
+			JobTitle:      labels[i.ApplicationID].JobTitle,
+			Stage:         i.Stage,
+			Title:         i.Title,
+			StartsAt:      i.StartsAt.In(loc),
+			EndsAt:        i.EndsAt.In(loc),
+			Timezone:      i.Timezone,
+			Location:      i.Location,
+			VideoURL:      i.VideoURL,
+			Status:        i.Status,
+			CancelReason:  i.CancelReason,
+			Sequence:      i.Sequence,
+			OrganizerID:   i.OrganizerID,
+			Panel:         make([]dtos.InterviewPanelistDTO, 0, len(i.Panel)),
+			CreatedAt:     i.CreatedAt,
+		}
+		for _, p := range i.Panel {
+			dto.Panel = append(dto.Panel, dtos.InterviewPanelistDTO{UserID: p.UserID, Name: people[p.UserID].Name, Email: people[p.UserID].Email})
+		}
+		result[idx] = dto
+	}
+	return result, nil
+}
+
+func (s *InterviewService) presentOne(interview *models.Interview) (*dtos.InterviewDTO, error) {
+	result, err := s.present([]models.Interview{*interview})
+	if err != nil {
+		return nil, err
+	}
+	return &result[0], nil
+}
+
+// timeRange interpreta inicio y fin en loc y valida el rango.
+func timeRange(startsAt, endsAt string, minutes int, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := domain.ParseTime(startsAt, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperr.BadRequest(err.Error())
+	}
+	end, err := domain.ResolveEnd(start, endsAt, minutes, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperr.BadRequest(err.Error())
+	}
+	if err := domain.ValidateRange(start, end); err != nil {
+		return time.Time{}, time.Time{}, apperr.BadRequest(err.Error())
+	}
+	return start, end, nil
+}
+
+// body es el texto del correo y la descripción del evento.
+func body(interview *models.Interview, app *domain.ApplicationRef, method string) string {
+	loc := domain.Location(interview.Timezone)
+	var b strings.Builder
+	if method == domain.MethodCancel {
+		b.WriteString("Esta entrevista fue cancelada.\n")
+		if interview.CancelReason != "" {
+			b.WriteString("Motivo: " + interview.CancelReason + "\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%s\nVacante: %s\nCandidato: %s\n", interview.Title, app.JobTitle, app.CandidateName)
+	fmt.Fprintf(&b, "Horario: %s – %s (%s)\n", interview.StartsAt.In(loc).Format(localFormat), interview.EndsAt.In(loc).Format("15:04"), interview.Timezone)
+	if interview.Location != "" {
+		b.WriteString("Lugar: " + interview.Location + "\n")
+	}
+	if interview.VideoURL != "" {
+		b.WriteString("Enlace: " + interview.VideoURL + "\n")
+	}
+	return b.String()
+}
+
+func title(requested string, app *domain.ApplicationRef) string {
+	if t := strings.TrimSpace(requested); t != "" {
+		return t
+	}
+	return fmt.Sprintf("Entrevista %s — %s", app.CandidateName, app.JobTitle)
+}
+
+func panelIDs(interview *models.Interview) []uint {
+	ids := make([]uint, len(interview.Panel))
+	for i, p := range interview.Panel {
+		ids[i] = p.UserID
+	}
+	return ids
+}
+
+func personsOf(ids []uint, people map[uint]domain.Person) []domain.Person {
+	result := make([]domain.Person, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := people[id]; ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func notify(flag *bool) bool {
+	return flag == nil || *flag
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// newUID genera el UID iCalendar de una entrevista (único y estable).
+func newUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b) + "@dvra.io", nil
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/interview/domain"
+	"dvra-api/internal/shared/apperr"
+
+	"gorm.io/datatypes"
+)
+
+// SchedulingService gestiona la disponibilidad semanal y los bloqueos de los
+// entrevistadores y los enlaces de autoagenda: el candidato elige un horario
+// libre de todo el panel y la reserva crea la entrevista.
+type SchedulingService struct {
+	repo       domain.SchedulingRepository
+	interviews *InterviewService
+	publicURL  string
+}
+
+func NewSchedulingService(repo domain.SchedulingRepository, interviews *InterviewService, publicURL string) *SchedulingService {
+	return &SchedulingService{repo: repo, interviews: interviews, publicURL: publicURL}
+}
+
+// Availability devuelve las franjas y los bloqueos vigentes de un
+// entrevistador.
+func (s *SchedulingService) Availability(companyID, userID uint) (*dtos.AvailabilityDTO, error) {
+	if _, err := s.interviews.panel(companyID, []uint{userID}); err != nil {
+		return nil, err
+	}
+	return s.availability(companyID, userID)
+}
+
+// SetAvailability reemplaza las franjas semanales de un entrevistador.
+func (s *SchedulingService) SetAvailability(companyID, userID uint, dto dtos.SetAvailabilityDTO) (*dtos.AvailabilityDTO, error) {
+	if _, err := s.interviews.panel(companyID, []uint{userID}); err != nil {
+		return nil, err
+	}
+	windows := make([]models.InterviewerAvailability, 0, len(dto.Windows))
+	for _, w := range dto.Windows {
+		start, err := domain.ParseClock(w.Start)
+		if err != nil {
+			return nil, apperr.BadRequest(err.Error())
+		}
+		end, err := domain.ParseClock(w.End)
+		if err != nil {
+			return nil, apperr.BadRequest(err.Error())
+		}
+		windows = append(windows, models.InterviewerAvailability{
+			CompanyID: companyID, UserID: userID, Weekday: w.Weekday, StartMinute: start, EndMinute: end,
+		})
+	}
+	if err := domain.ValidateWindows(windows); err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+	if err := s.repo.ReplaceAvailability(companyID, userID, windows); err != nil {
+		return nil, err
+	}
+	return s.availability(companyID, userID)
+}
+
+// AddBlackout bloquea un período del entrevistador. Si to es una fecha sin
+// hora, el bloqueo cubre ese día completo.
+func (s *SchedulingService) AddBlackout(companyID, userID uint, dto dtos.CreateBlackoutDTO) (*dtos.BlackoutDTO, error) {
+	if _, err := s.interviews.panel(companyID, []uint{userID}); err != nil {
+		return nil, err
+	}
+	loc, err := s.location(companyID)
+	if err != nil {
+		return nil, err
+	}
+	start, err := domain.ParseTime(dto.From, loc)
+	if err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+	end, err := domain.ParseTime(dto.To, loc)
+	if err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+	if len(strings.TrimSpace(dto.To)) == len("2006-01-02") {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return nil, apperr.BadRequest("'to' must be after 'from'")
+	}
+
+	blackout := &models.AvailabilityBlackout{
+		CompanyID: companyID,
+		UserID:    userID,
+		StartsAt:  start.UTC(),
+		EndsAt:    end.UTC(),
+		Reason:    strings.TrimSpace(dto.Reason),
+	}
+	if err := s.repo.CreateBlackout(blackout); err != nil {
+		return nil, err
+	}
+	return &dtos.BlackoutDTO{ID: blackout.ID, StartsAt: start, EndsAt: end, Reason: blackout.Reason}, nil
+}
+
+// DeleteBlackout elimina un bloqueo. Solo su dueño puede hacerlo, salvo que
+// manageOthers (quien gestiona entrevistas).
+func (s *SchedulingService) DeleteBlackout(id, companyID, actorID uint, manageOthers bool) error {
+	blackout, err := s.repo.GetBlackout(id)
+	if err != nil {
+		return err
+	}
+	if blackout == nil || (companyID != 0 && blackout.CompanyID != companyID) {
+		return apperr.NotFound("blackout not found")
+	}
+	if blackout.UserID != actorID && !manageOthers {
+		return apperr.Forbidden("you can only remove your own blackouts")
+	}
+	return s.repo.DeleteBlackout(id)
+}
+
+// CreateLink crea un enlace de autoagenda y, salvo send_email=false, lo envía
+// al candidato. El token solo se devuelve en esta respuesta. Si el panel no
+// tiene ningún horario en común en el período responde 422.
+func (s *SchedulingService) CreateLink(companyID, creatorID uint, dto dtos.CreateSchedulingLinkDTO) (*dtos.SchedulingLinkDTO, error) {
+	app, err := s.interviews.application(dto.ApplicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	stage := dto.Stage
+	if stage == "" {
+		stage = app.Stage
+	}
+	if !contains(app.Stages, stage) {
+		return nil, apperr.BadRequest(fmt.Sprintf("stage '%s' is not part of this job's pipeline", stage))
+	}
+	panel := domain.NormalizePanel(dto.PanelIDs)
+	if _, err := s.interviews.panel(app.CompanyID, panel); err != nil {
+		return nil, err
+	}
+
+	duration := dto.DurationMinutes
+	if duration == 0 {
+		duration = int(domain.DefaultDuration / time.Minute)
+	}
+	days := dto.Days
+	if days == 0 {
+		days = domain.DefaultLinkDays
+	}
+	link := &models.SchedulingLink{
+		CompanyID:       app.CompanyID,
+		ApplicationID:   app.ID,
+		Stage:           stage,
+		Title:           title(dto.Title, app),
+		PanelIDs:        datatypes.JSONSlice[uint](panel),
+		DurationMinutes: duration,
+		BufferMinutes:   dto.BufferMinutes,
+		Location:        strings.TrimSpace(dto.Location),
+		VideoURL:        strings.TrimSpace(dto.VideoURL),
+		Status:          models.SchedulingLinkOpen,
+		ExpiresAt:       time.Now().AddDate(0, 0, days).UTC(),
+	}
+	if creatorID != 0 {
+		link.CreatedByID = &creatorID
+	}
+
+	slots, err := s.slots(link, domain.Location(app.Timezone))
+	if err != nil {
+		return nil, err
+	}
+	if len(slots) == 0 {
+		return nil, apperr.Unprocessable(fmt.Sprintf("the panel has no common availability in the next %d days", days))
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	link.TokenHash = domain.HashToken(token)
+	if err := s.repo.CreateLink(link); err != nil {
+		return nil, err
+	}
+
+	result := presentLink(link)
+	result.Token = token
+	result.URL = s.publicURL + "/schedule/" + token
+	if notify(dto.SendEmail) && app.CandidateEmail != "" && s.interviews.mailer != nil {
+		subject := fmt.Sprintf("Agenda tu entrevista — %s", app.CompanyName)
+		body := fmt.Sprintf("Hola %s,\n\nElige el horario de tu entrevista para %s en %s:\n%s\n\nEl enlace vence el %s.\n",
+			app.CandidateName, app.JobTitle, app.CompanyName, result.URL, link.ExpiresAt.In(domain.Location(app.Timezone)).Format(localFormat))
+		_ = s.interviews.mailer.SendEmail([]string{app.CandidateEmail}, subject, body)
+	}
+	return result, nil
+}
+
+// ListLinks devuelve los enlaces de autoagenda de una postulación.
+func (s *SchedulingService) ListLinks(applicationID, companyID uint) ([]dtos.SchedulingLinkDTO, error) {
+	if _, err := s.interviews.application(applicationID, companyID); err != nil {
+		return nil, err
+	}
+	links, err := s.repo.ListLinks(applicationID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.SchedulingLinkDTO, len(links))
+	for i := range links {
+		result[i] = *presentLink(&links[i])
+	}
+	return result, nil
+}
+
+// RevokeLink anula un enlace abierto.
+func (s *SchedulingService) RevokeLink(id, companyID uint) (*dtos.SchedulingLinkDTO, error) {
+	link, err := s.repo.GetLink(id)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || (companyID != 0 && link.CompanyID != companyID) {
+		return nil, apperr.NotFound("scheduling link not found")
+	}
+	if link.Status != models.SchedulingLinkOpen {
+		return nil, apperr.Conflict("only open scheduling links can be revoked")
+	}
+	link.Status = models.SchedulingLinkRevoked
+	if err := s.repo.SaveLink(link); err != nil {
+		return nil, err
+	}
+	return presentLink(link), nil
+}
+
+// PublicView devuelve al candidato los datos del enlace y los horarios libres.
+func (s *SchedulingService) PublicView(token string) (*dtos.PublicSchedulingDTO, error) {
+	link, app, err := s.openLink(token)
+	if err != nil {
+		return nil, err
+	}
+	loc := domain.Location(app.Timezone)
+	slots, err := s.slots(link, loc)
+	if err != nil {
+		return nil, err
+	}
+	result := &dtos.PublicSchedulingDTO{
+		CompanyName:     app.CompanyName,
+		JobTitle:        app.JobTitle,
+		CandidateName:   app.CandidateName,
+		Title:           link.Title,
+		DurationMinutes: link.DurationMinutes,
+		Timezone:        loc.String(),
+		Location:        link.Location,
+		ExpiresAt:       link.ExpiresAt.In(loc),
+		Slots:           make([]dtos.SlotDTO, len(slots)),
+	}
+	if result.Location == "" && link.VideoURL != "" {
+		result.Location = "Videollamada"
+	}
+	for i, slot := range slots {
+		result.Slots[i] = dtos.SlotDTO{StartsAt: slot.Start, EndsAt: slot.End}
+	}
+	return result, nil
+}
+
+// Book reserva un horario: debe seguir siendo uno de los ofrecidos. La
+// entrevista se crea y el enlace se cierra en una transacción que serializa
+// las reservas del mismo panel; si otro tomó el horario responde 409.
+func (s *SchedulingService) Book(token string, dto dtos.BookSlotDTO) (*dtos.BookedInterviewDTO, error) {
+	link, app, err := s.openLink(token)
+	if err != nil {
+		return nil, err
+	}
+	loc := domain.Location(app.Timezone)
+	start, err := domain.ParseTime(dto.StartsAt, loc)
+	if err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+	query, err := s.query(link, loc)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsSlot(query, start) {
+		return nil, apperr.Conflict("this time is not available anymore; pick another slot")
+	}
+
+	uid, err := newUID()
+	if err != nil {
+		return nil, err
+	}
+	interview := &models.Interview{
+		CompanyID:     link.CompanyID,
+		ApplicationID: link.ApplicationID,
+		Stage:         link.Stage,
+		Title:         link.Title,
+		StartsAt:      start.UTC(),
+		EndsAt:        start.Add(query.Duration).UTC(),
+		Timezone:      loc.String(),
+		Location:      link.Location,
+		VideoURL:      link.VideoURL,
+		Status:        models.InterviewStatusScheduled,
+		UID:           uid,
+		OrganizerID:   link.CreatedByID,
+	}
+	for _, userID := range link.PanelIDs {
+		interview.Panel = append(interview.Panel, models.InterviewPanelist{UserID: userID})
+	}
+	if err := s.repo.Book(link.ID, interview); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSlotTaken):
+			return nil, apperr.Conflict("this time is not available anymore; pick another slot")
+		case errors.Is(err, domain.ErrLinkUnavailable):
+			return nil, apperr.Conflict("this scheduling link was already used or revoked")
+		}
+		return nil, err
+	}
+
+	members, err := s.interviews.repo.People(link.PanelIDs)
+	if err == nil {
+		s.interviews.send(interview, app, domain.MethodRequest, personsOf(link.PanelIDs, members), true, "Entrevista")
+	}
+	return &dtos.BookedInterviewDTO{
+		Title:    interview.Title,
+		StartsAt: interview.StartsAt.In(loc),
+		EndsAt:   interview.EndsAt.In(loc),
+		Timezone: interview.Timezone,
+		Location: interview.Location,
+		VideoURL: interview.VideoURL,
+	}, nil
+}
+
+func (s *SchedulingService) availability(companyID, userID uint) (*dtos.AvailabilityDTO, error) {
+	loc, err := s.location(companyID)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.repo.Availability(companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	blackouts, err := s.repo.Blackouts(companyID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result := &dtos.AvailabilityDTO{
+		UserID:    userID,
+		Timezone:  loc.String(),
+		Windows:   make([]dtos.AvailabilityWindowDTO, len(windows)),
+		Blackouts: make([]dtos.BlackoutDTO, len(blackouts)),
+	}
+	for i, w := range windows {
+		result.Windows[i] = dtos.AvailabilityWindowDTO{Weekday: w.Weekday, Start: domain.FormatClock(w.StartMinute), End: domain.FormatClock(w.EndMinute)}
+	}
+	for i, b := range blackouts {
+		result.Blackouts[i] = dtos.BlackoutDTO{ID: b.ID, StartsAt: b.StartsAt.In(loc), EndsAt: b.EndsAt.In(loc), Reason: b.Reason}
+	}
+	return result, nil
+}
+
+// openLink resuelve un token a su enlace abierto y vigente.
+func (s *SchedulingService) openLink(token string) (*models.SchedulingLink, *domain.ApplicationRef, error) {
+	link, err := s.repo.GetLinkByTokenHash(domain.HashToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+	if link == nil {
+		return nil, nil, apperr.NotFound("scheduling link not found")
+	}
+	switch {
+	case link.Status == models.SchedulingLinkBooked:
+		return nil, nil, apperr.Conflict("this scheduling link was already used")
+	case link.Status != models.SchedulingLinkOpen:
+		return nil, nil, apperr.Conflict("this scheduling link was revoked")
+	case !link.ExpiresAt.After(time.Now()):
+		return nil, nil, apperr.Conflict("this scheduling link has expired")
+	}
+	app, err := s.interviews.application(link.ApplicationID, link.CompanyID)
+	if err != nil {
+		return nil, nil, apperr.NotFound("scheduling link not found")
+	}
+	return link, app, nil
+}
+
+// query arma la búsqueda de horarios del enlace: desde ahora más
+// domain.MinNotice hasta que vence, con la agenda actual del panel.
+func (s *SchedulingService) query(link *models.SchedulingLink, loc *time.Location) (domain.SlotQuery, error) {
+	q := domain.SlotQuery{
+		From:     time.Now().Add(domain.MinNotice),
+		To:       link.ExpiresAt,
+		Loc:      loc,
+		Duration: time.Duration(link.DurationMinutes) * time.Minute,
+		Buffer:   time.Duration(link.BufferMinutes) * time.Minute,
+	}
+	agendas, err := s.repo.Agendas(link.CompanyID, link.PanelIDs, q.From.Add(-q.Buffer), q.To.Add(q.Buffer))
+	if err != nil {
+		return q, err
+	}
+	for _, userID := range link.PanelIDs {
+		q.Panel = append(q.Panel, agendas[userID])
+	}
+	return q, nil
+}
+
+func (s *SchedulingService) slots(link *models.SchedulingLink, loc *time.Location) ([]domain.Interval, error) {
+	q, err := s.query(link, loc)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Slots(q), nil
+}
+
+func (s *SchedulingService) location(companyID uint) (*time.Location, error) {
+	tz, err := s.interviews.repo.CompanyTimezone(companyID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Location(tz), nil
+}
+
+func presentLink(link *models.SchedulingLink) *dtos.SchedulingLinkDTO {
+	return &dtos.SchedulingLinkDTO{
+		ID:              link.ID,
+		ApplicationID:   link.ApplicationID,
+		Stage:           link.Stage,
+		Title:           link.Title,
+		PanelIDs:        []uint(link.PanelIDs),
+		DurationMinutes: link.DurationMinutes,
+		BufferMinutes:   link.BufferMinutes,
+		Status:          link.Status,
+		ExpiresAt:       link.ExpiresAt,
+		InterviewID:     link.InterviewID,
+		BookedAt:        link.BookedAt,
+		CreatedAt:       link.CreatedAt,
+	}
+}
+
+// newToken genera el token secreto de un enlace de autoagenda.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,310 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/interview/domain"
+	"dvra-api/internal/modules/interview/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InterviewHandler struct {
+	svc *service.InterviewService
+}
+
+func NewInterviewHandler(svc *service.InterviewService) *InterviewHandler {
+	return &InterviewHandler{svc: svc}
+}
+
+// GetInterviews godoc
+// @Summary      Calendario de entrevistas
+// @Description  Entrevistas de la empresa que empiezan en [from, to) (fechas u horas en la zona de la empresa; por defecto 7 días desde hoy, máximo 93). interviewer_id=me filtra las propias.
+// @Tags         Interviews
+// @Produce      json
+// @Param        from            query     string  false  "Desde (2006-01-02 o RFC 3339)"
+// @Param        to              query     string  false  "Hasta (exclusivo)"
+// @Param        interviewer_id  query     string  false  "Entrevistador (ID o 'me')"
+// @Param        application_id  query     int     false  "Postulación"
+// @Param        status          query     string  false  "scheduled, completed, no_show, cancelled"
+// @Param        company_id      query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200             {object}  map[string]interface{}
+// @Failure      400             {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews [get]
+func (h *InterviewHandler) GetInterviews(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	filter := domain.ListFilter{Status: c.Query("status")}
+	if v := c.Query("interviewer_id"); v == "me" {
+		filter.InterviewerID, _ = authctx.UserID(c)
+	} else if v != "" {
+		id, _ := strconv.ParseUint(v, 10, 32)
+		filter.InterviewerID = uint(id)
+	}
+	if v, err := strconv.ParseUint(c.Query("application_id"), 10, 32); err == nil {
+		filter.ApplicationID = uint(v)
+	}
+
+	interviews, err := h.svc.List(companyID, c.Query("from"), c.Query("to"), filter)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": interviews, "count": len(interviews)}})
+}
+
+// GetInterviewConflicts godoc
+// @Summary      Chequear solapamientos
+// @Description  Entrevistas agendadas de los entrevistadores que se solapan con el horario (antes de agendar o reagendar)
+// @Tags         Interviews
+// @Produce      json
+// @Param        panel_ids   query     string  true   "IDs separados por coma"
+// @Param        starts_at   query     string  true   "Inicio"
+// @Param        ends_at     query     string  true   "Fin"
+// @Param        exclude_id  query     int     false  "Entrevista a ignorar (la que se reagenda)"
+// @Param        company_id  query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/conflicts [get]
+func (h *InterviewHandler) GetInterviewConflicts(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var panel []uint
+	for _, part := range strings.Split(c.Query("panel_ids"), ",") {
+		if id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32); err == nil {
+			panel = append(panel, uint(id))
+		}
+	}
+	if len(panel) == 0 || c.Query("starts_at") == "" || c.Query("ends_at") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "panel_ids, starts_at and ends_at are required"})
+		return
+	}
+	excludeID, _ := strconv.ParseUint(c.Query("exclude_id"), 10, 32)
+
+	conflicts, err := h.svc.Conflicts(companyID, panel, c.Query("starts_at"), c.Query("ends_at"), uint(excludeID))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": conflicts, "count": len(conflicts)}})
+}
+
+// GetApplicationInterviews godoc
+// @Summary      Entrevistas de una postulación
+// @Tags         Interviews
+// @Produce      json
+// @Param        id   path      int  true  "ID de la postulación"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/interviews [get]
+func (h *InterviewHandler) GetApplicationInterviews(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+
+	interviews, err := h.svc.ListByApplication(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": interviews, "count": len(interviews)}})
+}
+
+// GetInterview godoc
+// @Summary      Obtener entrevista
+// @Tags         Interviews
+// @Produce      json
+// @Param        id   path      int  true  "ID de la entrevista"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/{id} [get]
+func (h *InterviewHandler) GetInterview(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid interview ID")
+	if !ok {
+		return
+	}
+
+	interview, err := h.svc.Get(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": interview})
+}
+
+// ScheduleInterview godoc
+// @Summary      Agendar entrevista
+// @Description  Agenda una entrevista de la postulación con su panel y envía la invitación .ics al panel y al candidato. 409 si un entrevistador ya tiene otra entrevista en ese horario (salvo allow_conflicts).
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        interview  body      dtos.ScheduleInterviewDTO  true  "Entrevista"
+// @Success      201        {object}  map[string]interface{}
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      409        {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews [post]
+func (h *InterviewHandler) ScheduleInterview(c *gin.Context) {
+	var dto dtos.ScheduleInterviewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	organizerID, _ := authctx.UserID(c)
+
+	interview, err := h.svc.Schedule(companyID, organizerID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": interview})
+}
+
+// UpdateInterview godoc
+// @Summary      Reagendar entrevista
+// @Description  Reemplaza horario, lugar y panel. Envía la invitación actualizada y la cancelación a quienes salen del panel.
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                      true  "ID de la entrevista"
+// @Param        interview  body      dtos.UpdateInterviewDTO  true  "Cambios"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      409        {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/{id} [put]
+func (h *InterviewHandler) UpdateInterview(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid interview ID")
+	if !ok {
+		return
+	}
+	var dto dtos.UpdateInterviewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	interview, err := h.svc.Update(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": interview})
+}
+
+// CancelInterview godoc
+// @Summary      Cancelar entrevista
+// @Description  Cancela una entrevista agendada y envía la cancelación (.ics METHOD:CANCEL) al panel y al candidato
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                      true   "ID de la entrevista"
+// @Param        cancel  body      dtos.CancelInterviewDTO  false  "Motivo"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      409     {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/{id}/cancel [post]
+func (h *InterviewHandler) CancelInterview(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid interview ID")
+	if !ok {
+		return
+	}
+	var dto dtos.CancelInterviewDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	interview, err := h.svc.Cancel(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": interview})
+}
+
+// SetInterviewStatus godoc
+// @Summary      Cerrar entrevista
+// @Description  Marca una entrevista agendada como completed o no_show
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                      true  "ID de la entrevista"
+// @Param        status  body      dtos.InterviewStatusDTO  true  "Estado"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      409     {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/{id}/status [patch]
+func (h *InterviewHandler) SetInterviewStatus(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid interview ID")
+	if !ok {
+		return
+	}
+	var dto dtos.InterviewStatusDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	interview, err := h.svc.SetStatus(id, companyID, dto.Status)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": interview})
+}
+
+// DownloadInterviewInvite godoc
+// @Summary      Descargar invitación .ics
+// @Description  Invitación iCalendar vigente de la entrevista (cancelación si se canceló)
+// @Tags         Interviews
+// @Produce      text/calendar
+// @Param        id   path  int  true  "ID de la entrevista"
+// @Success      200
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/{id}/invite.ics [get]
+func (h *InterviewHandler) DownloadInterviewInvite(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid interview ID")
+	if !ok {
+		return
+	}
+
+	ics, err := h.svc.Invite(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="interview-`+strconv.FormatUint(uint64(id), 10)+`.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
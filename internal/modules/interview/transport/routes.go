@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/interview/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.InterviewService, schedulingSvc *service.SchedulingService) {
+	h := NewInterviewHandler(svc)
+	sh := NewSchedulingHandler(schedulingSvc)
+
+	interviews := rg.Group("/interviews")
+	{
+		// Disponibilidad y autoagenda (antes de /:id).
+		interviews.GET("/availability", middleware.RequirePermission(permissions.InterviewsAvailability), sh.GetAvailability)
+		interviews.PUT("/availability", middleware.RequirePermission(permissions.InterviewsAvailability), sh.SetAvailability)
+		interviews.POST("/availability/blackouts", middleware.RequirePermission(permissions.InterviewsAvailability), sh.CreateBlackout)
+		interviews.DELETE("/availability/blackouts/:id", middleware.RequirePermission(permissions.InterviewsAvailability), sh.DeleteBlackout)
+		interviews.POST("/scheduling-links", middleware.RequirePermission(permissions.InterviewsManage), sh.CreateSchedulingLink)
+		interviews.DELETE("/scheduling-links/:id", middleware.RequirePermission(permissions.InterviewsManage), sh.RevokeSchedulingLink)
+
+		interviews.GET("", middleware.RequirePermission(permissions.InterviewsView), h.GetInterviews)
+		interviews.GET("/conflicts", middleware.RequirePermission(permissions.InterviewsView), h.GetInterviewConflicts)
+		interviews.POST("", middleware.RequirePermission(permissions.InterviewsManage), h.ScheduleInterview)
+		interviews.GET("/:id", middleware.RequirePermission(permissions.InterviewsView), h.GetInterview)
+		interviews.PUT("/:id", middleware.RequirePermission(permissions.InterviewsManage), h.UpdateInterview)
+		interviews.POST("/:id/cancel", middleware.RequirePermission(permissions.InterviewsManage), h.CancelInterview)
+		interviews.PATCH("/:id/status", middleware.RequirePermission(permissions.InterviewsManage), h.SetInterviewStatus)
+		interviews.GET("/:id/invite.ics", middleware.RequirePermission(permissions.InterviewsView), h.DownloadInterviewInvite)
+	}
+
+	// Las entrevistas también cuelgan de la postulación.
+	applications := rg.Group("/applications")
+	{
+		applications.GET("/:id/interviews", middleware.RequirePermission(permissions.InterviewsView), h.GetApplicationInterviews)
+		applications.GET("/:id/scheduling-links", middleware.RequirePermission(permissions.InterviewsManage), sh.GetApplicationSchedulingLinks)
+	}
+}
+
+// RegisterPublicRoutes monta la autoagenda del candidato (sin autenticación:
+// el token del enlace es la credencial).
+func RegisterPublicRoutes(public *gin.RouterGroup, schedulingSvc *service.SchedulingService) {
+	sh := NewSchedulingHandler(schedulingSvc)
+	public.GET("/schedule/:token", sh.GetPublicSchedule)
+	public.POST("/schedule/:token", sh.BookPublicSchedule)
+}
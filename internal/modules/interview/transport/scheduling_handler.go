@@ -0,0 +1,283 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/interview/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SchedulingHandler struct {
+	svc *service.SchedulingService
+}
+
+func NewSchedulingHandler(svc *service.SchedulingService) *SchedulingHandler {
+	return &SchedulingHandler{svc: svc}
+}
+
+// GetAvailability godoc
+// @Summary      Disponibilidad de un entrevistador
+// @Description  Franjas semanales y bloqueos vigentes (user_id vacío = el usuario autenticado)
+// @Tags         Interviews
+// @Produce      json
+// @Param        user_id     query     int  false  "Entrevistador"
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/availability [get]
+func (h *SchedulingHandler) GetAvailability(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	requested, _ := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	userID, ok := availabilityOwner(c, uint(requested))
+	if !ok {
+		return
+	}
+
+	availability, err := h.svc.Availability(companyID, userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": availability})
+}
+
+// SetAvailability godoc
+// @Summary      Publicar disponibilidad semanal
+// @Description  Reemplaza las franjas semanales del entrevistador (horas HH:MM en la zona de la empresa). Editar las de otro requiere interviews.manage.
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        availability  body      dtos.SetAvailabilityDTO  true  "Franjas"
+// @Param        company_id    query     int                      false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200           {object}  map[string]interface{}
+// @Failure      400           {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/availability [put]
+func (h *SchedulingHandler) SetAvailability(c *gin.Context) {
+	var dto dtos.SetAvailabilityDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	userID, ok := availabilityOwner(c, dto.UserID)
+	if !ok {
+		return
+	}
+
+	availability, err := h.svc.SetAvailability(companyID, userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": availability})
+}
+
+// CreateBlackout godoc
+// @Summary      Bloquear fechas
+// @Description  Período en que el entrevistador no está disponible (vacaciones, feriados). Con fechas sin hora, 'to' es inclusive.
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        blackout    body      dtos.CreateBlackoutDTO  true   "Bloqueo"
+// @Param        company_id  query     int                     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      201         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/availability/blackouts [post]
+func (h *SchedulingHandler) CreateBlackout(c *gin.Context) {
+	var dto dtos.CreateBlackoutDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	userID, ok := availabilityOwner(c, dto.UserID)
+	if !ok {
+		return
+	}
+
+	blackout, err := h.svc.AddBlackout(companyID, userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": blackout})
+}
+
+// DeleteBlackout godoc
+// @Summary      Quitar bloqueo
+// @Tags         Interviews
+// @Produce      json
+// @Param        id   path      int  true  "ID del bloqueo"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/availability/blackouts/{id} [delete]
+func (h *SchedulingHandler) DeleteBlackout(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid blackout ID")
+	if !ok {
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	if err := h.svc.DeleteBlackout(id, companyID, actorID, permissions.Can(authctx.Role(c), permissions.InterviewsManage)); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Blackout deleted"})
+}
+
+// CreateSchedulingLink godoc
+// @Summary      Crear enlace de autoagenda
+// @Description  Enlace público para que el candidato elija un horario libre de todo el panel. Se envía por correo salvo send_email=false; el token solo se devuelve aquí. 422 si el panel no tiene horarios en común.
+// @Tags         Interviews
+// @Accept       json
+// @Produce      json
+// @Param        link  body      dtos.CreateSchedulingLinkDTO  true  "Enlace"
+// @Success      201   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/scheduling-links [post]
+func (h *SchedulingHandler) CreateSchedulingLink(c *gin.Context) {
+	var dto dtos.CreateSchedulingLinkDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	creatorID, _ := authctx.UserID(c)
+
+	link, err := h.svc.CreateLink(companyID, creatorID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": link})
+}
+
+// GetApplicationSchedulingLinks godoc
+// @Summary      Enlaces de autoagenda de una postulación
+// @Tags         Interviews
+// @Produce      json
+// @Param        id   path      int  true  "ID de la postulación"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/scheduling-links [get]
+func (h *SchedulingHandler) GetApplicationSchedulingLinks(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.svc.ListLinks(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": links, "count": len(links)}})
+}
+
+// RevokeSchedulingLink godoc
+// @Summary      Anular enlace de autoagenda
+// @Tags         Interviews
+// @Produce      json
+// @Param        id   path      int  true  "ID del enlace"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /interviews/scheduling-links/{id} [delete]
+func (h *SchedulingHandler) RevokeSchedulingLink(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid scheduling link ID")
+	if !ok {
+		return
+	}
+
+	link, err := h.svc.RevokeLink(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": link})
+}
+
+// GetPublicSchedule godoc
+// @Summary      Horarios disponibles (público)
+// @Description  Datos del enlace de autoagenda y horarios libres del panel, en la zona de la empresa
+// @Tags         Public
+// @Produce      json
+// @Param        token  path      string  true  "Token del enlace"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Router       /public/schedule/{token} [get]
+func (h *SchedulingHandler) GetPublicSchedule(c *gin.Context) {
+	schedule, err := h.svc.PublicView(c.Param("token"))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": schedule})
+}
+
+// BookPublicSchedule godoc
+// @Summary      Reservar horario (público)
+// @Description  Reserva uno de los horarios ofrecidos: crea la entrevista y envía la invitación. 409 si el horario se ocupó o el enlace ya se usó.
+// @Tags         Public
+// @Accept       json
+// @Produce      json
+// @Param        token  path      string            true  "Token del enlace"
+// @Param        slot   body      dtos.BookSlotDTO  true  "Horario"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Router       /public/schedule/{token} [post]
+func (h *SchedulingHandler) BookPublicSchedule(c *gin.Context) {
+	var dto dtos.BookSlotDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	interview, err := h.svc.Book(c.Param("token"), dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": interview})
+}
+
+// availabilityOwner resuelve de quién es la disponibilidad: el usuario
+// autenticado, u otro si tiene interviews.manage.
+func availabilityOwner(c *gin.Context, requested uint) (uint, bool) {
+	self, _ := authctx.UserID(c)
+	if requested == 0 || requested == self {
+		if self == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+			return 0, false
+		}
+		return self, true
+	}
+	if !permissions.Can(authctx.Role(c), permissions.InterviewsManage) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only manage your own availability"})
+		return 0, false
+	}
+	return requested, true
+}
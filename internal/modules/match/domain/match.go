@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"dvra-api/internal/app/models"
+)
+
+// Componentes del puntaje y su peso sobre 100. Los que no aplican a la
+// vacante (p. ej. sin rango salarial) no cuentan: el resto se reescala.
+const (
+	ComponentSkills     = "skills"
+	ComponentExperience = "experience"
+	ComponentLocation   = "location" // modalidad + ciudad
+	ComponentSalary     = "salary"
+
+	WeightSkills     = 50
+	WeightExperience = 20
+	WeightLocation   = 15
+	WeightSalary     = 15
+)
+
+// Estado de cada componente.
+const (
+	StatusMatch         = "match"
+	StatusPartial       = "partial"
+	StatusMismatch      = "mismatch"
+	StatusUnknown       = "unknown" // falta el dato del candidato
+	StatusNotApplicable = "not_applicable"
+)
+
+// unknownFit es lo que vale un componente cuando el candidato no tiene el
+// dato: ni lo premia ni lo descarta.
+const unknownFit = 0.5
+
+// salaryTolerance es cuánto puede pasarse la expectativa del máximo de la
+// vacante antes de valer 0 (50 % por encima).
+const salaryTolerance = 0.5
+
+// SkillFit resume cómo cubre el candidato las habilidades de la vacante (lo
+// calcula el módulo skill). Below son las que tiene por debajo del nivel o
+// de los años pedidos.
+type SkillFit struct {
+	Required      int
+	RequiredMet   int
+	RequiredBelow int
+	Nice          int
+	NiceMet       int
+	NiceBelow     int
+	// Missing son los nombres de las requeridas que no tiene.
+	Missing []string
+}
+
+// Requirements es lo que pide la vacante. StateID es el de su ciudad.
+type Requirements struct {
+	ExperienceYearsMin *float64
+	LocationType       string
+	CityID             *uint
+	StateID            *uint
+	SalaryMax          *float64
+}
+
+// Profile es lo que se sabe del candidato. Years es el declarado o, si
+// falta, el del CV analizado.
+type Profile struct {
+	Skills            SkillFit
+	Years             *float64
+	WorkMode          string
+	CityID            *uint
+	StateID           *uint
+	OpenToRelocate    bool
+	SalaryExpectation *float64
+}
+
+// Component es una parte explicada del puntaje: Score va de 0 a 100.
+type Component struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	Score  int    `json:"score"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Match es la compatibilidad de un candidato con una vacante (0–100) y su
+// desglose.
+type Match struct {
+	CandidateID uint              `json:"candidate_id"`
+	Score       int               `json:"score"`
+	Components  []Component       `json:"components"`
+	Candidate   *models.Candidate `json:"candidate,omitempty"`
+}
+
+// Score calcula la compatibilidad del candidato con la vacante.
+func Score(req Requirements, p Profile) Match {
+	m := Match{Components: make([]Component, 0, 4)}
+	var total, weights float64
+	add := func(name string, weight int, fit float64, status, detail string) {
+		c := Component{Name: name, Weight: weight, Status: status, Detail: detail}
+		if status != StatusNotApplicable {
+			c.Score = int(math.Round(fit * 100))
+			total += float64(weight) * fit
+			weights += float64(weight)
+		}
+		m.Components = append(m.Components, c)
+	}
+
+	fit, status, detail := skills(p.Skills)
+	add(ComponentSkills, WeightSkills, fit, status, detail)
+	fit, status, detail = experience(req.ExperienceYearsMin, p.Years)
+	add(ComponentExperience, WeightExperience, fit, status, detail)
+	fit, status, detail = location(req, p)
+	add(ComponentLocation, WeightLocation, fit, status, detail)
+	fit, status, detail = salary(req.SalaryMax, p.SalaryExpectation)
+	add(ComponentSalary, WeightSalary, fit, status, detail)
+
+	if weights > 0 {
+		m.Score = int(math.Round(total / weights * 100))
+	}
+	return m
+}
+
+// Rank ordena de mayor a menor puntaje; a igual puntaje, el candidato más
+// reciente primero.
+func Rank(matches []Match) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].CandidateID > matches[j].CandidateID
+	})
+}
+
+// skills pondera doble las requeridas; una habilidad por debajo de lo
+// pedido vale la mitad.
+func skills(f SkillFit) (float64, string, string) {
+	if f.Required+f.Nice == 0 {
+		return 0, StatusNotApplicable, "the job lists no skills"
+	}
+	got := 2*(float64(f.RequiredMet)+0.5*float64(f.RequiredBelow)) +
+		float64(f.NiceMet) + 0.5*float64(f.NiceBelow)
+	fit := got / float64(2*f.Required+f.Nice)
+
+	var parts []string
+	if f.Required > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d required skills met", f.RequiredMet, f.Required))
+		if f.RequiredBelow > 0 {
+			parts = append(parts, fmt.Sprintf("%d below the requested level", f.RequiredBelow))
+		}
+		if len(f.Missing) > 0 {
+			parts = append(parts, "missing "+strings.Join(f.Missing, ", "))
+		}
+	}
+	if f.Nice > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d nice to have", f.NiceMet, f.Nice))
+	}
+	return fit, statusOf(fit), strings.Join(parts, "; ")
+}
+
+func experience(min, years *float64) (float64, string, string) {
+	if min == nil || *min <= 0 {
+		return 0, StatusNotApplicable, "the job asks for no minimum experience"
+	}
+	if years == nil {
+		return unknownFit, StatusUnknown, fmt.Sprintf("experience unknown; the job asks for %s years", num(*min))
+	}
+	fit := math.Min(*years / *min, 1)
+	return fit, statusOf(fit), fmt.Sprintf("%s years of experience for %s requested", num(*years), num(*min))
+}
+
+// location combina la modalidad preferida con la ciudad: en una vacante
+// remota solo cuenta la modalidad; en una presencial o híbrida, también
+// que viva en la ciudad (o la provincia) o acepte mudarse.
+func location(req Requirements, p Profile) (float64, string, string) {
+	mode, modeDetail := workMode(req.LocationType, p.WorkMode)
+	if req.LocationType == "remote" || req.CityID == nil {
+		return mode, statusOf(mode), modeDetail
+	}
+	var place float64
+	var placeDetail string
+	switch {
+	case p.CityID != nil && *p.CityID == *req.CityID:
+		place, placeDetail = 1, "lives in the job's city"
+	case p.CityID != nil && p.StateID != nil && req.StateID != nil && *p.StateID == *req.StateID:
+		place, placeDetail = 0.7, "lives in the job's state"
+	case p.OpenToRelocate:
+		place, placeDetail = 0.7, "open to relocate"
+	case p.CityID == nil:
+		if mode == 1 {
+			return unknownFit, StatusUnknown, modeDetail + "; location unknown"
+		}
+		place, placeDetail = unknownFit, "location unknown"
+	default:
+		place, placeDetail = 0, "lives elsewhere and is not open to relocate"
+	}
+	fit := mode * place
+	return fit, statusOf(fit), modeDetail + "; " + placeDetail
+}
+
+// workMode: la misma modalidad (o sin preferencia) vale 1, híbrido contra
+// cualquiera de las otras 0,5 y remoto contra presencial 0.
+func workMode(job, preferred string) (float64, string) {
+	switch {
+	case preferred == "":
+		return 1, "no work mode preference (" + job + " job)"
+	case preferred == job:
+		return 1, "prefers " + preferred
+	case preferred == "hybrid" || job == "hybrid":
+		return 0.5, "prefers " + preferred + ", job is " + job
+	default:
+		return 0, "prefers " + preferred + ", job is " + job
+	}
+}
+
+// salary vale 1 si la expectativa entra en el máximo de la vacante y baja
+// linealmente hasta 0 al pasarlo en salaryTolerance.
+func salary(max, expectation *float64) (float64, string, string) {
+	if max == nil || *max <= 0 {
+		return 0, StatusNotApplicable, "the job has no salary range"
+	}
+	if expectation == nil {
+		return unknownFit, StatusUnknown, "salary expectation unknown"
+	}
+	if *expectation <= *max {
+		return 1, StatusMatch, fmt.Sprintf("expects %s, within the %s maximum", num(*expectation), num(*max))
+	}
+	over := (*expectation - *max) / *max
+	fit := math.Max(0, 1-over/salaryTolerance)
+	return fit, statusOf(fit), fmt.Sprintf("expects %s, %d%% over the %s maximum", num(*expectation), int(math.Round(over*100)), num(*max))
+}
+
+func statusOf(fit float64) string {
+	switch {
+	case fit >= 1:
+		return StatusMatch
+	case fit <= 0:
+		return StatusMismatch
+	default:
+		return StatusPartial
+	}
+}
+
+// num formatea sin decimales innecesarios (5, 5.5).
+func num(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.1f", v), "0"), ".")
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	u := func(v uint) *uint { return &v }
+	req := Requirements{
+		ExperienceYearsMin: f(4),
+		LocationType:       "onsite",
+		CityID:             u(1),
+		StateID:            u(10),
+		SalaryMax:          f(1000),
+	}
+	p := Profile{
+		Skills:            SkillFit{Required: 2, RequiredMet: 1, RequiredBelow: 1, Nice: 1},
+		Years:             f(2),
+		WorkMode:          "hybrid",
+		CityID:            u(2),
+		StateID:           u(10),
+		SalaryExpectation: f(1250),
+	}
+	m := Score(req, p)
+
+	var scores []int
+	var statuses []string
+	for _, c := range m.Components {
+		scores = append(scores, c.Score)
+		statuses = append(statuses, c.Status)
+	}
+	// skills (2·1,5)/5 = 60; experiencia 2/4; ubicación 0,5·0,7; salario 25 % de 50 % por encima.
+	if want := []int{60, 50, 35, 50}; !reflect.DeepEqual(scores, want) {
+		t.Errorf("scores = %v, want %v", scores, want)
+	}
+	if want := []string{StatusPartial, StatusPartial, StatusPartial, StatusPartial}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses = %v", statuses)
+	}
+	// (50·0,6 + 20·0,5 + 15·0,35 + 15·0,5) / 100
+	if m.Score != 53 {
+		t.Errorf("score = %d, want 53", m.Score)
+	}
+}
+
+func TestScoreSkipsNotApplicable(t *testing.T) {
+	// Vacante remota sin habilidades, experiencia ni salario: solo cuenta la
+	// modalidad.
+	m := Score(Requirements{LocationType: "remote"}, Profile{WorkMode: "onsite"})
+	if m.Score != 0 {
+		t.Errorf("score = %d, want 0", m.Score)
+	}
+	m = Score(Requirements{LocationType: "remote"}, Profile{})
+	if m.Score != 100 {
+		t.Errorf("score = %d, want 100", m.Score)
+	}
+	for _, c := range m.Components[:1] {
+		if c.Status != StatusNotApplicable || c.Score != 0 {
+			t.Errorf("component = %+v", c)
+		}
+	}
+}
+
+func TestScoreUnknown(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	u := func(v uint) *uint { return &v }
+	req := Requirements{ExperienceYearsMin: f(3), LocationType: "hybrid", CityID: u(1), SalaryMax: f(500)}
+	m := Score(req, Profile{})
+	for _, c := range m.Components[1:] {
+		if c.Status != StatusUnknown || c.Score != 50 {
+			t.Errorf("component = %+v", c)
+		}
+	}
+	if m.Score != 50 {
+		t.Errorf("score = %d, want 50", m.Score)
+	}
+}
+
+func TestRank(t *testing.T) {
+	matches := []Match{{CandidateID: 1, Score: 40}, {CandidateID: 2, Score: 90}, {CandidateID: 3, Score: 40}}
+	Rank(matches)
+	var ids []uint
+	for _, m := range matches {
+		ids = append(ids, m.CandidateID)
+	}
+	if want := []uint{2, 3, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("order = %v", ids)
+	}
+}
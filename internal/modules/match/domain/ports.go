@@ -0,0 +1,29 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// MatchRepository es el puerto de salida hacia la persistencia. Los métodos
+// que buscan devuelven nil, nil si no hay resultado.
+type MatchRepository interface {
+	// Job busca una vacante de la empresa (companyID 0 = cualquiera).
+	Job(companyID, jobID uint) (*models.Job, error)
+	// Candidate busca un candidato de la empresa.
+	Candidate(companyID, candidateID uint) (*models.Candidate, error)
+	// Sourceable devuelve los candidatos contactables de la empresa
+	// (RN-GDPR-001) que no se postularon a la vacante.
+	Sourceable(companyID, jobID uint) ([]models.Candidate, error)
+	// Candidates busca candidatos por ID.
+	Candidates(ids []uint) ([]models.Candidate, error)
+	// States devuelve la provincia de cada ciudad (ciudad → provincia).
+	States(cityIDs []uint) (map[uint]uint, error)
+	// ResumeYears devuelve los años de experiencia del análisis completo del
+	// CV vigente de cada candidato que lo tenga.
+	ResumeYears(candidateIDs []uint) (map[uint]float64, error)
+}
+
+// SkillMatcher compara las habilidades de la vacante con las de cada
+// candidato (módulo skill). Puerto definido por el consumidor; el
+// composition root inyecta un adaptador.
+type SkillMatcher interface {
+	Fits(jobID uint, candidateIDs []uint) (map[uint]SkillFit, error)
+}
@@ -0,0 +1,32 @@
+// Package match es el punto de ensamblaje del módulo de compatibilidad
+// vacante ↔ candidato: un puntaje explicado (0–100) que combina habilidades,
+// experiencia, ubicación/modalidad y salario, calculado localmente. Nadie
+// importa este paquete salvo el composition root.
+package match
+
+import (
+	"dvra-api/internal/modules/match/domain"
+	"dvra-api/internal/modules/match/repository"
+	"dvra-api/internal/modules/match/service"
+	"dvra-api/internal/modules/match/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo match.
+type Module struct {
+	// Service implementa el puerto con el que el tablero de postulaciones
+	// ordena por compatibilidad.
+	Service *service.MatchService
+}
+
+// New construye el módulo. skills compara habilidades (módulo skill).
+func New(db *gorm.DB, skills domain.SkillMatcher) *Module {
+	return &Module{Service: service.NewMatchService(repository.NewMatchRepository(db), skills)}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
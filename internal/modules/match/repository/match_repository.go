@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/match/domain"
+
+	"gorm.io/gorm"
+)
+
+type matchRepository struct {
+	db *gorm.DB
+}
+
+// NewMatchRepository devuelve la implementación del puerto.
+func NewMatchRepository(db *gorm.DB) domain.MatchRepository {
+	return &matchRepository{db: db}
+}
+
+func (r *matchRepository) Job(companyID, jobID uint) (*models.Job, error) {
+	query := r.db.Where("id = ?", jobID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var job models.Job
+	if err := query.First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *matchRepository) Candidate(companyID, candidateID uint) (*models.Candidate, error) {
+	var candidate models.Candidate
+	if err := r.db.Where("id = ? AND company_id = ?", candidateID, companyID).First(&candidate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+func (r *matchRepository) Sourceable(companyID, jobID uint) ([]models.Candidate, error) {
+	applied := r.db.Model(&models.Application{}).Select("candidate_id").Where("job_id = ?", jobID)
+	var candidates []models.Candidate
+	if err := r.db.
+		Where("candidates.company_id = ?", companyID).
+		Where(models.ContactableCondition("candidates")).
+		Where("candidates.id NOT IN (?)", applied).
+		Order("candidates.id ASC").
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *matchRepository) Candidates(ids []uint) ([]models.Candidate, error) {
+	var candidates []models.Candidate
+	if len(ids) == 0 {
+		return candidates, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *matchRepository) States(cityIDs []uint) (map[uint]uint, error) {
+	states := make(map[uint]uint, len(cityIDs))
+	if len(cityIDs) == 0 {
+		return states, nil
+	}
+	var cities []models.City
+	if err := r.db.Select("id", "state_id").Where("id IN ?", cityIDs).Find(&cities).Error; err != nil {
+		return nil, err
+	}
+	for _, c := range cities {
+		states[c.ID] = c.StateID
+	}
+	return states, nil
+}
+
+func (r *matchRepository) ResumeYears(candidateIDs []uint) (map[uint]float64, error) {
+	years := make(map[uint]float64, len(candidateIDs))
+	if len(candidateIDs) == 0 {
+		return years, nil
+	}
+	var rows []struct {
+		CandidateID uint
+		Years       float64
+	}
+	// El análisis vigente es el del ResumeURL actual del candidato.
+	if err := r.db.Table("resume_parses AS rp").
+		Select("DISTINCT ON (rp.candidate_id) rp.candidate_id, (rp.data->>'years_of_experience')::numeric AS years").
+		Joins("JOIN candidates c ON c.id = rp.candidate_id AND c.resume_url = rp.resume_url").
+		Where("rp.candidate_id IN ? AND rp.status = ? AND rp.deleted_at IS NULL", candidateIDs, models.ResumeParseCompleted).
+		Order("rp.candidate_id, rp.parsed_at DESC NULLS LAST").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.Years > 0 {
+			years[row.CandidateID] = row.Years
+		}
+	}
+	return years, nil
+}
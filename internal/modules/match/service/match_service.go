@@ -0,0 +1,190 @@
+package service
+
+import (
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/match/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// Listado de mejores candidatos
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	// batch es de a cuántos candidatos se calculan los puntajes: acota las
+	// listas IN de cada consulta.
+	batch = 1000
+)
+
+// MatchService calcula la compatibilidad (0–100, explicada) entre vacantes
+// y candidatos: habilidades, experiencia, ubicación/modalidad y salario.
+type MatchService struct {
+	repo   domain.MatchRepository
+	skills domain.SkillMatcher
+}
+
+func NewMatchService(repo domain.MatchRepository, skills domain.SkillMatcher) *MatchService {
+	return &MatchService{repo: repo, skills: skills}
+}
+
+// Best devuelve los candidatos de toda la base con mayor puntaje para la
+// vacante. Como función de sourcing solo considera candidatos contactables
+// (RN-GDPR-001); los que ya se postularon están en el tablero.
+func (s *MatchService) Best(companyID, jobID uint, query dtos.MatchQuery) ([]domain.Match, error) {
+	if query.Limit <= 0 || query.Limit > maxLimit {
+		query.Limit = defaultLimit
+	}
+	job, err := s.job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.repo.Sourceable(job.CompanyID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	scored, err := s.score(job, candidates)
+	if err != nil {
+		return nil, err
+	}
+	matches := []domain.Match{}
+	for _, m := range scored {
+		if m.Score >= query.MinScore {
+			matches = append(matches, m)
+		}
+	}
+	domain.Rank(matches)
+	if len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+	return matches, nil
+}
+
+// Candidate devuelve el puntaje y el desglose de un candidato de la misma
+// empresa que la vacante.
+func (s *MatchService) Candidate(companyID, jobID, candidateID uint) (*domain.Match, error) {
+	job, err := s.job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := s.repo.Candidate(job.CompanyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil {
+		return nil, apperr.NotFound("candidate not found")
+	}
+	matches, err := s.score(job, []models.Candidate{*candidate})
+	if err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// Scores devuelve el puntaje de cada candidato para la vacante (candidato →
+// puntaje). Lo usa el tablero de postulaciones para ordenar por
+// compatibilidad.
+func (s *MatchService) Scores(companyID, jobID uint, candidateIDs []uint) (map[uint]int, error) {
+	job, err := s.job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[uint]int, len(candidateIDs))
+	for start := 0; start < len(candidateIDs); start += batch {
+		end := min(start+batch, len(candidateIDs))
+		candidates, err := s.repo.Candidates(candidateIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		matches, err := s.score(job, candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			scores[m.CandidateID] = m.Score
+		}
+	}
+	return scores, nil
+}
+
+// score calcula el puntaje de cada candidato, de a batch.
+func (s *MatchService) score(job *models.Job, candidates []models.Candidate) ([]domain.Match, error) {
+	req := domain.Requirements{
+		ExperienceYearsMin: job.ExperienceYearsMin,
+		LocationType:       job.LocationType,
+		CityID:             job.CityID,
+		SalaryMax:          job.SalaryMax,
+	}
+	if job.CityID != nil {
+		states, err := s.repo.States([]uint{*job.CityID})
+		if err != nil {
+			return nil, err
+		}
+		if state, ok := states[*job.CityID]; ok {
+			req.StateID = &state
+		}
+	}
+
+	matches := make([]domain.Match, 0, len(candidates))
+	for start := 0; start < len(candidates); start += batch {
+		page := candidates[start:min(start+batch, len(candidates))]
+		ids := make([]uint, 0, len(page))
+		var cityIDs, withoutYears []uint
+		for _, c := range page {
+			ids = append(ids, c.ID)
+			if c.CityID != nil {
+				cityIDs = append(cityIDs, *c.CityID)
+			}
+			if c.YearsOfExperience == nil {
+				withoutYears = append(withoutYears, c.ID)
+			}
+		}
+		fits, err := s.skills.Fits(job.ID, ids)
+		if err != nil {
+			return nil, err
+		}
+		years, err := s.repo.ResumeYears(withoutYears)
+		if err != nil {
+			return nil, err
+		}
+		states, err := s.repo.States(cityIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page {
+			c := &page[i]
+			p := domain.Profile{
+				Skills:            fits[c.ID],
+				Years:             c.YearsOfExperience,
+				WorkMode:          c.WorkMode,
+				CityID:            c.CityID,
+				OpenToRelocate:    c.OpenToRelocate,
+				SalaryExpectation: c.SalaryExpectation,
+			}
+			if y, ok := years[c.ID]; ok && p.Years == nil {
+				p.Years = &y
+			}
+			if c.CityID != nil {
+				if state, ok := states[*c.CityID]; ok {
+					p.StateID = &state
+				}
+			}
+			m := domain.Score(req, p)
+			m.CandidateID = c.ID
+			m.Candidate = c
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+func (s *MatchService) job(companyID, jobID uint) (*models.Job, error) {
+	job, err := s.repo.Job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, apperr.NotFound("job not found")
+	}
+	return job, nil
+}
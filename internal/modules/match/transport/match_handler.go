@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/match/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MatchHandler struct {
+	svc *service.MatchService
+}
+
+func NewMatchHandler(svc *service.MatchService) *MatchHandler {
+	return &MatchHandler{svc: svc}
+}
+
+// GetMatches godoc
+// @Summary      Mejores candidatos para una vacante
+// @Description  Busca en toda la base de candidatos contactables de la empresa (consentimiento de talent pool vigente, sin anonimizar) los de mayor compatibilidad con la vacante, sin los que ya se postularon. El puntaje (0–100) combina habilidades (50), experiencia (20), ubicación y modalidad (15) y salario (15); los componentes que la vacante no define no cuentan y un dato que le falta al candidato vale la mitad. Cada resultado trae el desglose por componente.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id         path      int  true   "Job ID"
+// @Param        limit      query     int  false  "Cantidad de candidatos (por defecto 20, hasta 100)"
+// @Param        min_score  query     int  false  "Puntaje mínimo (0–100)"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/matches [get]
+func (h *MatchHandler) GetMatches(c *gin.Context) {
+	id, companyID, ok := target(c)
+	if !ok {
+		return
+	}
+	var query dtos.MatchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	matches, err := h.svc.Best(companyID, id, query)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": matches, "count": len(matches)}})
+}
+
+// GetCandidateMatch godoc
+// @Summary      Compatibilidad de un candidato con una vacante
+// @Description  Puntaje (0–100) y desglose por componente (skills, experience, location, salary) con su peso, puntaje, estado (match, partial, mismatch, unknown, not_applicable) y explicación. El candidato debe ser de la empresa de la vacante; no hace falta que sea contactable.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id            path      int  true  "Job ID"
+// @Param        candidate_id  path      int  true  "Candidate ID"
+// @Success      200           {object}  map[string]interface{}
+// @Failure      400           {object}  map[string]interface{}
+// @Failure      404           {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/matches/{candidate_id} [get]
+func (h *MatchHandler) GetCandidateMatch(c *gin.Context) {
+	id, companyID, ok := target(c)
+	if !ok {
+		return
+	}
+	candidateID, err := strconv.ParseUint(c.Param("candidate_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+
+	match, err := h.svc.Candidate(companyID, id, uint(candidateID))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": match})
+}
+
+func target(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -0,0 +1,17 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/match/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.MatchService) {
+	h := NewMatchHandler(svc)
+
+	rg.GET("/jobs/:id/matches", middleware.RequirePermission(permissions.MatchesView), h.GetMatches)
+	rg.GET("/jobs/:id/matches/:candidate_id", middleware.RequirePermission(permissions.MatchesView), h.GetCandidateMatch)
+}
@@ -0,0 +1,18 @@
+// Package domain define el centro del módulo notification: los avisos a
+// usuarios dentro de una empresa (menciones, y en adelante automatizaciones).
+// No importa gin ni gorm.
+package domain
+
+import "dvra-api/internal/app/models"
+
+// NotificationRepository es el puerto de salida hacia la persistencia.
+type NotificationRepository interface {
+	CreateMany(notifications []models.Notification) error
+	// List devuelve las notificaciones del usuario en la empresa, más
+	// recientes primero (limit 0 = sin límite).
+	List(userID, companyID uint, unreadOnly bool, limit int) ([]models.Notification, error)
+	CountUnread(userID, companyID uint) (int64, error)
+	// MarkRead marca como leída una notificación del usuario; false si no es suya.
+	MarkRead(id, userID uint) (bool, error)
+	MarkAllRead(userID, companyID uint) (int64, error)
+}
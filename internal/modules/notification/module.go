@@ -0,0 +1,29 @@
+// Package notification es el punto de ensamblaje del módulo de notificaciones
+// in-app. Nadie importa este paquete salvo el composition root.
+package notification
+
+import (
+	"dvra-api/internal/modules/notification/repository"
+	"dvra-api/internal/modules/notification/service"
+	"dvra-api/internal/modules/notification/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo notification.
+type Module struct {
+	// Service se expone porque otros módulos notifican a través de él
+	// (puertos definidos por cada consumidor).
+	Service *service.NotificationService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{Service: service.NewNotificationService(repository.NewNotificationRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/notification/domain"
+
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository devuelve la implementación del puerto.
+func NewNotificationRepository(db *gorm.DB) domain.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateMany(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.Create(&notifications).Error
+}
+
+func (r *notificationRepository) List(userID, companyID uint, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := r.db.Where("user_id = ? AND company_id = ?", userID, companyID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("created_at DESC, id DESC").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(userID, companyID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND company_id = ? AND read_at IS NULL", userID, companyID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) MarkRead(id, userID uint) (bool, error) {
+	var notification models.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if notification.ReadAt != nil {
+		return true, nil
+	}
+	return true, r.db.Model(&notification).Update("read_at", time.Now()).Error
+}
+
+func (r *notificationRepository) MarkAllRead(userID, companyID uint) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND company_id = ? AND read_at IS NULL", userID, companyID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/notification/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// defaultListLimit acota la bandeja de notificaciones.
+const defaultListLimit = 50
+
+// NotificationService crea y entrega los avisos de cada usuario. Otros módulos
+// notifican a través de un puerto propio que el composition root adapta a Notify.
+type NotificationService struct {
+	repo domain.NotificationRepository
+}
+
+func NewNotificationService(repo domain.NotificationRepository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+// Notify guarda las notificaciones (una por destinatario).
+func (s *NotificationService) Notify(notifications []models.Notification) error {
+	return s.repo.CreateMany(notifications)
+}
+
+// List devuelve la bandeja del usuario en la empresa y cuántas hay sin leer.
+func (s *NotificationService) List(userID, companyID uint, unreadOnly bool) ([]models.Notification, int64, error) {
+	notifications, err := s.repo.List(userID, companyID, unreadOnly, defaultListLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := s.repo.CountUnread(userID, companyID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+func (s *NotificationService) MarkRead(id, userID uint) error {
+	found, err := s.repo.MarkRead(id, userID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperr.NotFound("notification not found")
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(userID, companyID uint) (int64, error) {
+	return s.repo.MarkAllRead(userID, companyID)
+}
@@ -0,0 +1,108 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/modules/notification/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	svc *service.NotificationService
+}
+
+func NewNotificationHandler(svc *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{svc: svc}
+}
+
+// GetNotifications godoc
+// @Summary      Mis notificaciones
+// @Description  Últimas 50 notificaciones del usuario en la empresa activa, con el total sin leer
+// @Tags         Notifications
+// @Produce      json
+// @Param        unread  query     bool  false  "Solo sin leer"
+// @Success      200     {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, companyID, ok := recipient(c)
+	if !ok {
+		return
+	}
+
+	notifications, unread, err := h.svc.List(userID, companyID, c.Query("unread") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve notifications"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": notifications, "count": len(notifications), "unread": unread}})
+}
+
+// MarkNotificationRead godoc
+// @Summary      Marcar notificación como leída
+// @Tags         Notifications
+// @Produce      json
+// @Param        id   path      int  true  "ID de la notificación"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /notifications/{id}/read [patch]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+	userID, ok := authctx.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "No user context"})
+		return
+	}
+
+	if err := h.svc.MarkRead(uint(id), userID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Notification marked as read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Marcar todas como leídas
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, companyID, ok := recipient(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.svc.MarkAllRead(userID, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"updated": updated}})
+}
+
+// recipient resuelve el usuario y la empresa activa del token. Las
+// notificaciones son por empresa: SuperAdmin sin empresa no tiene bandeja.
+func recipient(c *gin.Context) (uint, uint, bool) {
+	userID, ok := authctx.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "No user context"})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.CompanyID(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return 0, 0, false
+	}
+	return userID, companyID, true
+}
@@ -0,0 +1,22 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/notification/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+// Cada usuario solo accede a su propia bandeja.
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.NotificationService) {
+	h := NewNotificationHandler(svc)
+
+	notifications := rg.Group("/notifications")
+	{
+		notifications.GET("", middleware.RequirePermission(permissions.NotificationsView), h.GetNotifications)
+		notifications.POST("/read-all", middleware.RequirePermission(permissions.NotificationsView), h.MarkAllNotificationsRead)
+		notifications.PATCH("/:id/read", middleware.RequirePermission(permissions.NotificationsView), h.MarkNotificationRead)
+	}
+}
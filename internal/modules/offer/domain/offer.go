@@ -0,0 +1,110 @@
+// Package domain define el centro del módulo offer: ofertas versionadas de
+// una postulación, la cadena de aprobación por rol y la respuesta del
+// candidato. No importa gin ni gorm.
+package domain
+
+import (
+	"sort"
+	"strings"
+
+	"dvra-api/internal/app/models"
+)
+
+// FallbackApproverRole aprueba las ofertas que superan el salario máximo de
+// la vacante cuando la cadena de la empresa no tiene ningún paso que aplique:
+// ninguna oferta fuera de banda sale sin aprobación.
+const FallbackApproverRole = "admin"
+
+// Respuestas del candidato que se registran sobre una oferta enviada.
+const (
+	ResponseAccepted    = models.OfferStatusAccepted
+	ResponseDeclined    = models.OfferStatusDeclined
+	ResponseNegotiating = models.OfferStatusNegotiating
+)
+
+// ListFilter acota el listado de ofertas de la empresa.
+type ListFilter struct {
+	Status        string
+	ApplicationID uint
+}
+
+// AboveSalaryMax reporta si el salario supera el máximo de la vacante. Sin
+// máximo publicado no hay banda que superar; en una moneda distinta de la de
+// la banda no se puede comparar y se trata como fuera de banda (pasa por
+// aprobación).
+func AboveSalaryMax(salary float64, currency string, max *float64, maxCurrency string) bool {
+	if max == nil {
+		return false
+	}
+	if !strings.EqualFold(currency, maxCurrency) {
+		return true
+	}
+	return salary > *max
+}
+
+// EffectiveChain devuelve, en orden, los roles que deben aprobar una versión:
+// los pasos "always" y, si la oferta supera el máximo, los
+// "above_salary_max". Fuera de banda siempre hay al menos un paso.
+func EffectiveChain(steps []models.OfferApprovalStep, aboveMax bool) []string {
+	ordered := append([]models.OfferApprovalStep(nil), steps...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+
+	var roles []string
+	for _, step := range ordered {
+		if step.Condition == models.OfferStepAlways || (aboveMax && step.Condition == models.OfferStepAboveSalaryMax) {
+			roles = append(roles, step.Role)
+		}
+	}
+	if aboveMax && len(roles) == 0 {
+		roles = append(roles, FallbackApproverRole)
+	}
+	return roles
+}
+
+// CurrentStep devuelve el paso pendiente de menor orden de la versión, o nil
+// si no queda ninguno.
+func CurrentStep(approvals []models.OfferApproval, versionID uint) *models.OfferApproval {
+	var current *models.OfferApproval
+	for i := range approvals {
+		a := &approvals[i]
+		if a.OfferVersionID != versionID || a.Status != models.OfferApprovalPending {
+			continue
+		}
+		if current == nil || a.Step < current.Step {
+			current = a
+		}
+	}
+	return current
+}
+
+// CanRevise reporta si se pueden cambiar los términos: antes de enviarla o
+// mientras se negocia.
+func CanRevise(status string) bool {
+	switch status {
+	case models.OfferStatusDraft, models.OfferStatusPendingApproval, models.OfferStatusApproved, models.OfferStatusNegotiating:
+		return true
+	}
+	return false
+}
+
+// CanRespond reporta si se puede registrar la respuesta del candidato: sobre
+// una oferta enviada, o aceptar/declinar mientras se negocia (una
+// contrapropuesta se registra revisando los términos).
+func CanRespond(status, response string) bool {
+	switch status {
+	case models.OfferStatusSent:
+		return response == ResponseAccepted || response == ResponseDeclined || response == ResponseNegotiating
+	case models.OfferStatusNegotiating:
+		return response == ResponseAccepted || response == ResponseDeclined
+	}
+	return false
+}
+
+// IsOpen reporta si la oferta sigue en curso (ocupa la postulación).
+func IsOpen(status string) bool {
+	switch status {
+	case models.OfferStatusAccepted, models.OfferStatusDeclined, models.OfferStatusWithdrawn, models.OfferStatusExpired:
+		return false
+	}
+	return true
+}
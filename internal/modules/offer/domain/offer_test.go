@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestEffectiveChainAplicaPasosSegunBanda(t *testing.T) {
+	steps := []models.OfferApprovalStep{
+		{Position: 2, Role: "admin", Condition: models.OfferStepAlways},
+		{Position: 1, Role: "hiring_manager", Condition: models.OfferStepAlways},
+		{Position: 3, Role: "admin", Condition: models.OfferStepAboveSalaryMax},
+	}
+	if got := EffectiveChain(steps, false); !reflect.DeepEqual(got, []string{"hiring_manager", "admin"}) {
+		t.Errorf("dentro de banda: got %v", got)
+	}
+	if got := EffectiveChain(steps, true); !reflect.DeepEqual(got, []string{"hiring_manager", "admin", "admin"}) {
+		t.Errorf("fuera de banda: got %v", got)
+	}
+}
+
+func TestEffectiveChainFueraDeBandaNuncaSaleSinAprobacion(t *testing.T) {
+	if got := EffectiveChain(nil, false); len(got) != 0 {
+		t.Errorf("sin cadena y dentro de banda no hay pasos, got %v", got)
+	}
+	if got := EffectiveChain(nil, true); !reflect.DeepEqual(got, []string{FallbackApproverRole}) {
+		t.Errorf("sin cadena y fuera de banda aprueba admin, got %v", got)
+	}
+}
+
+func TestAboveSalaryMax(t *testing.T) {
+	max := 5000.0
+	if AboveSalaryMax(5000, "USD", &max, "USD") {
+		t.Error("igual al máximo está dentro de banda")
+	}
+	if !AboveSalaryMax(5000.01, "usd", &max, "USD") {
+		t.Error("sobre el máximo está fuera de banda")
+	}
+	if AboveSalaryMax(1e9, "USD", nil, "USD") {
+		t.Error("sin máximo publicado no hay banda que superar")
+	}
+	if !AboveSalaryMax(100, "MXN", &max, "USD") {
+		t.Error("en otra moneda no se puede comparar: pasa por aprobación")
+	}
+}
+
+func TestCurrentStepEsElPendienteDeMenorOrden(t *testing.T) {
+	approvals := []models.OfferApproval{
+		{OfferVersionID: 1, Step: 1, Status: models.OfferApprovalRejected},
+		{OfferVersionID: 2, Step: 2, Status: models.OfferApprovalPending, Role: "admin"},
+		{OfferVersionID: 2, Step: 1, Status: models.OfferApprovalApproved, Role: "hiring_manager"},
+	}
+	step := CurrentStep(approvals, 2)
+	if step == nil || step.Role != "admin" {
+		t.Fatalf("se esperaba el paso 2 (admin), got %+v", step)
+	}
+	step.Status = models.OfferApprovalApproved
+	if CurrentStep(approvals, 2) != nil {
+		t.Error("con todos aprobados no queda paso pendiente")
+	}
+}
+
+func TestCanRespond(t *testing.T) {
+	if !CanRespond(models.OfferStatusSent, ResponseNegotiating) {
+		t.Error("una oferta enviada admite negociación")
+	}
+	if CanRespond(models.OfferStatusNegotiating, ResponseNegotiating) {
+		t.Error("la contrapropuesta se registra revisando los términos")
+	}
+	if CanRespond(models.OfferStatusApproved, ResponseAccepted) {
+		t.Error("no se acepta una oferta que no se envió")
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// ApplicationRef es la vista mínima de una postulación que el módulo
+// necesita: tenant, vacante, su banda salarial y la zona horaria de la
+// empresa (vencimientos por fecha).
+type ApplicationRef struct {
+	ID             uint
+	CompanyID      uint
+	JobID          uint
+	Stage          string
+	CandidateName  string
+	JobTitle       string
+	SalaryMax      *float64
+	SalaryCurrency string
+	Timezone       string
+}
+
+// ApplicationFinder resuelve postulaciones (módulo recruitment). Lo
+// implementa un adaptador del composition root; nil, nil si no existe.
+type ApplicationFinder interface {
+	FindByID(id uint) (*ApplicationRef, error)
+}
+
+// Hirer mueve la postulación a la etapa hired de su pipeline a través del
+// servicio de etapas de recruitment (grafo de transiciones e historial).
+type Hirer interface {
+	Hire(applicationID, actorID uint, reason string) error
+}
+
+// RespondTx son los puertos con los que Respond escribe, ligados a una misma
+// transacción: la respuesta de la oferta y el paso a hired de la postulación
+// se confirman o se revierten juntos.
+type RespondTx struct {
+	Offers OfferRepository
+	Hirer  Hirer
+}
+
+// RespondTransactor abre la transacción de Respond; si fn devuelve error se
+// revierte. Lo implementa el composition root.
+type RespondTransactor interface {
+	Transaction(fn func(tx RespondTx) error) error
+}
+
+// Notifier entrega notificaciones in-app (módulo notification).
+type Notifier interface {
+	Notify(notifications []models.Notification) error
+}
+
+// OfferRepository es el puerto de salida hacia la persistencia.
+type OfferRepository interface {
+	// Create guarda la oferta con su primera versión.
+	Create(offer *models.Offer, version *models.OfferVersion) error
+	// GetByID devuelve la oferta con sus versiones y aprobaciones.
+	GetByID(id uint) (*models.Offer, error)
+	List(companyID uint, filter ListFilter) ([]models.Offer, error)
+	// HasOpen reporta si la postulación tiene una oferta en curso.
+	HasOpen(applicationID uint) (bool, error)
+	// AddVersion guarda la nueva versión y la oferta (versión actual, estado).
+	AddVersion(offer *models.Offer, version *models.OfferVersion) error
+	Save(offer *models.Offer) error
+	// Respond guarda la respuesta de la oferta si sigue en el estado from
+	// (false si otra respuesta o el vencimiento llegaron antes). El UPDATE
+	// condicional deja la oferta bloqueada hasta el fin de la transacción.
+	Respond(offer *models.Offer, from string) (bool, error)
+	// Submit guarda el estado de la oferta y los pasos de aprobación de la
+	// versión actual.
+	Submit(offer *models.Offer, approvals []models.OfferApproval) error
+	// Decide registra la decisión de un paso si sigue pendiente (false si otro
+	// lo decidió antes) y guarda la oferta, en una transacción.
+	Decide(offer *models.Offer, approval *models.OfferApproval) (bool, error)
+	// ExpireSent marca como vencidas las ofertas enviadas o en negociación
+	// cuya versión actual venció antes de now.
+	ExpireSent(now time.Time) (int64, error)
+
+	Chain(companyID uint) ([]models.OfferApprovalStep, error)
+	ReplaceChain(companyID uint, steps []models.OfferApprovalStep) error
+	// MembersWithRole devuelve los usuarios con membresía activa y ese rol.
+	MembersWithRole(companyID uint, role string) ([]uint, error)
+}
@@ -0,0 +1,49 @@
+// Package offer es el punto de ensamblaje del módulo de ofertas: términos
+// versionados, cadena de aprobación y respuesta del candidato. Nadie importa
+// este paquete salvo el composition root.
+package offer
+
+import (
+	"time"
+
+	"dvra-api/internal/modules/offer/domain"
+	"dvra-api/internal/modules/offer/repository"
+	"dvra-api/internal/modules/offer/service"
+	"dvra-api/internal/modules/offer/transport"
+	"dvra-api/internal/platform/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// expireInterval es cada cuánto se vencen las ofertas enviadas sin respuesta.
+const expireInterval = time.Hour
+
+// Module agrupa las dependencias ya cableadas del módulo offer.
+type Module struct {
+	Service *service.OfferService
+}
+
+// New construye el módulo. apps resuelve postulaciones, tx abre la
+// transacción en que se responde una oferta y se contrata (ambos sobre
+// recruitment) y notifier avisa a los aprobadores; todos son adaptadores que
+// inyecta el composition root.
+func New(db *gorm.DB, apps domain.ApplicationFinder, tx domain.RespondTransactor, notifier domain.Notifier) *Module {
+	return &Module{Service: service.NewOfferService(repository.NewOfferRepository(db), apps, tx, notifier)}
+}
+
+// RepositoryTx devuelve el repositorio de ofertas ligado a la transacción tx
+// (la que abre el RespondTransactor del composition root).
+func RepositoryTx(tx *gorm.DB) domain.OfferRepository {
+	return repository.NewOfferRepository(tx)
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
+
+// RegisterJobs registra las tareas periódicas del módulo.
+func (m *Module) RegisterJobs(s *scheduler.Scheduler) {
+	s.Add(scheduler.Job{Name: "offer.expire", Interval: expireInterval, Run: m.Service.ExpireOffers})
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/offer/domain"
+
+	"gorm.io/gorm"
+)
+
+type offerRepository struct {
+	db *gorm.DB
+}
+
+// NewOfferRepository devuelve la implementación del puerto.
+func NewOfferRepository(db *gorm.DB) domain.OfferRepository {
+	return &offerRepository{db: db}
+}
+
+// openStatuses son los estados en que una oferta sigue en curso.
+var openStatuses = []string{
+	models.OfferStatusDraft, models.OfferStatusPendingApproval, models.OfferStatusApproved,
+	models.OfferStatusSent, models.OfferStatusNegotiating,
+}
+
+func (r *offerRepository) Create(offer *models.Offer, version *models.OfferVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Versions", "Approvals").Create(offer).Error; err != nil {
+			return err
+		}
+		version.OfferID = offer.ID
+		return tx.Create(version).Error
+	})
+}
+
+func (r *offerRepository) GetByID(id uint) (*models.Offer, error) {
+	var offer models.Offer
+	if err := r.db.
+		Preload("Versions", func(db *gorm.DB) *gorm.DB { return db.Order("version ASC") }).
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB { return db.Order("offer_version_id ASC, step ASC") }).
+		First(&offer, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+func (r *offerRepository) List(companyID uint, filter domain.ListFilter) ([]models.Offer, error) {
+	query := r.db.
+		Preload("Versions", func(db *gorm.DB) *gorm.DB { return db.Order("version ASC") }).
+		Where("company_id = ?", companyID).
+		// Las ofertas de postulaciones en la papelera no se listan.
+		Where("application_id IN (?)", r.db.Table("applications").Select("id").Where("deleted_at IS NULL"))
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ApplicationID != 0 {
+		query = query.Where("application_id = ?", filter.ApplicationID)
+	}
+
+	var offers []models.Offer
+	if err := query.Order("created_at DESC, id DESC").Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+func (r *offerRepository) HasOpen(applicationID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Offer{}).
+		Where("application_id = ? AND status IN ?", applicationID, openStatuses).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *offerRepository) AddVersion(offer *models.Offer, version *models.OfferVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Versions", "Approvals").Save(offer).Error
+	})
+}
+
+func (r *offerRepository) Save(offer *models.Offer) error {
+	return r.db.Omit("Versions", "Approvals").Save(offer).Error
+}
+
+func (r *offerRepository) Respond(offer *models.Offer, from string) (bool, error) {
+	// El UPDATE condicional bloquea la fila hasta el commit: una respuesta
+	// concurrente espera y después ya no encuentra la oferta en from.
+	result := r.db.Model(&models.Offer{}).
+		Where("id = ? AND status = ?", offer.ID, from).
+		Updates(map[string]interface{}{
+			"status":        offer.Status,
+			"responded_at":  offer.RespondedAt,
+			"response_note": offer.ResponseNote,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *offerRepository) Submit(offer *models.Offer, approvals []models.OfferApproval) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(approvals) > 0 {
+			if err := tx.Create(&approvals).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Versions", "Approvals").Save(offer).Error
+	})
+}
+
+func (r *offerRepository) Decide(offer *models.Offer, approval *models.OfferApproval) (bool, error) {
+	decided := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Solo gana la primera decisión sobre un paso pendiente.
+		result := tx.Model(&models.OfferApproval{}).
+			Where("id = ? AND status = ?", approval.ID, models.OfferApprovalPending).
+			Updates(map[string]interface{}{
+				"status":      approval.Status,
+				"approver_id": approval.ApproverID,
+				"decided_at":  approval.DecidedAt,
+				"comment":     approval.Comment,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		decided = true
+		return tx.Omit("Versions", "Approvals").Save(offer).Error
+	})
+	return decided, err
+}
+
+func (r *offerRepository) ExpireSent(now time.Time) (int64, error) {
+	result := r.db.Model(&models.Offer{}).
+		Where("status IN ?", []string{models.OfferStatusSent, models.OfferStatusNegotiating}).
+		Where("EXISTS (SELECT 1 FROM offer_versions v WHERE v.offer_id = offers.id AND v.version = offers.current_version AND v.deleted_at IS NULL AND v.expires_at < ?)", now).
+		Update("status", models.OfferStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+func (r *offerRepository) Chain(companyID uint) ([]models.OfferApprovalStep, error) {
+	var steps []models.OfferApprovalStep
+	if err := r.db.Where("company_id = ?", companyID).
+		Order("position ASC").
+		Find(&steps).Error; err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+func (r *offerRepository) ReplaceChain(companyID uint, steps []models.OfferApprovalStep) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("company_id = ?", companyID).
+			Delete(&models.OfferApprovalStep{}).Error; err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			return nil
+		}
+		return tx.Create(&steps).Error
+	})
+}
+
+func (r *offerRepository) MembersWithRole(companyID uint, role string) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&models.Membership{}).
+		Where("company_id = ? AND role = ? AND status = ?", companyID, role, "active").
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
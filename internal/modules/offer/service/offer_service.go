@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/offer/domain"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/permissions"
+)
+
+// OfferService gestiona las ofertas de una postulación: términos versionados,
+// cadena de aprobación por rol antes de enviarla y respuesta del candidato.
+// Aceptar mueve la postulación a hired.
+type OfferService struct {
+	repo     domain.OfferRepository
+	apps     domain.ApplicationFinder
+	tx       domain.RespondTransactor
+	notifier domain.Notifier
+}
+
+func NewOfferService(repo domain.OfferRepository, apps domain.ApplicationFinder, tx domain.RespondTransactor, notifier domain.Notifier) *OfferService {
+	return &OfferService{repo: repo, apps: apps, tx: tx, notifier: notifier}
+}
+
+// List devuelve las ofertas de la empresa (más recientes primero).
+func (s *OfferService) List(companyID uint, filter domain.ListFilter) ([]dtos.OfferDTO, error) {
+	offers, err := s.repo.List(companyID, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.OfferDTO, len(offers))
+	for i := range offers {
+		result[i] = *present(&offers[i], nil)
+		result[i].Versions = nil
+	}
+	return result, nil
+}
+
+// ListByApplication devuelve las ofertas de una postulación.
+func (s *OfferService) ListByApplication(applicationID, companyID uint) ([]dtos.OfferDTO, error) {
+	app, err := s.application(applicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.List(app.CompanyID, domain.ListFilter{ApplicationID: app.ID})
+}
+
+// Get devuelve una oferta con su historial validando el tenant.
+func (s *OfferService) Get(id, companyID uint) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.presentFull(offer)
+}
+
+// Create abre la oferta de una postulación en borrador (versión 1). Una
+// postulación tiene a lo sumo una oferta en curso.
+func (s *OfferService) Create(companyID, actorID uint, dto dtos.CreateOfferDTO) (*dtos.OfferDTO, error) {
+	app, err := s.application(dto.ApplicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.repo.HasOpen(app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, apperr.Conflict("this application already has an offer in progress; revise or withdraw it")
+	}
+
+	version, err := newVersion(dto.OfferTermsDTO, 1, app, actorID)
+	if err != nil {
+		return nil, err
+	}
+	offer := &models.Offer{
+		CompanyID:      app.CompanyID,
+		ApplicationID:  app.ID,
+		Status:         models.OfferStatusDraft,
+		CurrentVersion: 1,
+		CreatedByID:    version.CreatedByID,
+	}
+	if err := s.repo.Create(offer, version); err != nil {
+		return nil, err
+	}
+	offer.Versions = []models.OfferVersion{*version}
+	return present(offer, app), nil
+}
+
+// Revise crea una nueva versión de los términos. La oferta vuelve a borrador
+// y debe pasar otra vez por la cadena de aprobación.
+func (s *OfferService) Revise(id, companyID, actorID uint, dto dtos.OfferTermsDTO) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanRevise(offer.Status) {
+		return nil, apperr.Conflict(fmt.Sprintf("an offer in status '%s' cannot be revised", offer.Status))
+	}
+	app, err := s.application(offer.ApplicationID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := newVersion(dto, offer.CurrentVersion+1, app, actorID)
+	if err != nil {
+		return nil, err
+	}
+	version.OfferID = offer.ID
+	offer.CurrentVersion = version.Version
+	offer.Status = models.OfferStatusDraft
+	if err := s.repo.AddVersion(offer, version); err != nil {
+		return nil, err
+	}
+	offer.Versions = append(offer.Versions, *version)
+	return present(offer, app), nil
+}
+
+// Submit envía la versión actual a la cadena de aprobación de la empresa. Sin
+// pasos que apliquen queda aprobada de inmediato; una oferta sobre el máximo
+// de la vacante siempre requiere al menos una aprobación.
+func (s *OfferService) Submit(id, companyID, actorID uint) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != models.OfferStatusDraft {
+		return nil, apperr.Conflict("only draft offers can be submitted for approval")
+	}
+	version := currentVersion(offer)
+	if version == nil {
+		return nil, apperr.Conflict("offer has no terms")
+	}
+	chain, err := s.repo.Chain(offer.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := domain.EffectiveChain(chain, version.AboveSalaryMax)
+	approvals := make([]models.OfferApproval, len(roles))
+	for i, role := range roles {
+		approvals[i] = models.OfferApproval{
+			OfferID: offer.ID, OfferVersionID: version.ID, Step: i + 1, Role: role, Status: models.OfferApprovalPending,
+		}
+	}
+	offer.Status = models.OfferStatusPendingApproval
+	if len(approvals) == 0 {
+		offer.Status = models.OfferStatusApproved
+	}
+	if err := s.repo.Submit(offer, approvals); err != nil {
+		return nil, err
+	}
+	offer.Approvals = append(offer.Approvals, approvals...)
+
+	if step := domain.CurrentStep(offer.Approvals, version.ID); step != nil {
+		s.notifyApprovers(offer, step.Role, actorID)
+	}
+	return s.presentFull(offer)
+}
+
+// Decide aprueba o rechaza el paso pendiente de la versión actual. Solo lo
+// decide un miembro con el rol del paso; los pasos van en orden. Rechazar
+// devuelve la oferta a borrador para revisarla.
+func (s *OfferService) Decide(id, companyID, actorID uint, role string, approve bool, dto dtos.OfferDecisionDTO) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != models.OfferStatusPendingApproval {
+		return nil, apperr.Conflict("offer is not awaiting approval")
+	}
+	version := currentVersion(offer)
+	if version == nil {
+		return nil, apperr.Conflict("offer has no terms")
+	}
+	step := domain.CurrentStep(offer.Approvals, version.ID)
+	if step == nil {
+		return nil, apperr.Conflict("offer is not awaiting approval")
+	}
+	if role != step.Role && role != permissions.RoleSuperAdmin {
+		return nil, apperr.Forbidden(fmt.Sprintf("step %d must be decided by a %s", step.Step, step.Role))
+	}
+	if approve && (isActor(offer.CreatedByID, actorID) || isActor(version.CreatedByID, actorID)) {
+		return nil, apperr.Forbidden("an offer cannot be approved by the person who drafted it")
+	}
+
+	now := time.Now()
+	step.ApproverID = &actorID
+	step.DecidedAt = &now
+	step.Comment = strings.TrimSpace(dto.Comment)
+	step.Status = models.OfferApprovalRejected
+	if approve {
+		step.Status = models.OfferApprovalApproved
+	}
+
+	next := domain.CurrentStep(offer.Approvals, version.ID)
+	switch {
+	case !approve:
+		offer.Status = models.OfferStatusDraft
+	case next == nil:
+		offer.Status = models.OfferStatusApproved
+	}
+	decided, err := s.repo.Decide(offer, step)
+	if err != nil {
+		return nil, err
+	}
+	if !decided {
+		return nil, apperr.Conflict("this approval step was already decided; reload the offer")
+	}
+
+	switch {
+	case !approve:
+		s.notifyCreator(offer, actorID, "Oferta rechazada en aprobación", step.Comment)
+	case next != nil:
+		s.notifyApprovers(offer, next.Role, actorID)
+	default:
+		s.notifyCreator(offer, actorID, "Oferta aprobada: lista para enviar", "")
+	}
+	return s.presentFull(offer)
+}
+
+// Send marca como enviada una oferta aprobada y vigente.
+func (s *OfferService) Send(id, companyID uint) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != models.OfferStatusApproved {
+		return nil, apperr.Conflict("only approved offers can be sent")
+	}
+	if version := currentVersion(offer); version == nil || !version.ExpiresAt.After(time.Now()) {
+		return nil, apperr.Conflict("offer has expired; revise its terms with a new expiration")
+	}
+
+	now := time.Now()
+	offer.Status = models.OfferStatusSent
+	offer.SentAt = &now
+	if err := s.repo.Save(offer); err != nil {
+		return nil, err
+	}
+	return s.presentFull(offer)
+}
+
+// Respond registra la respuesta del candidato. Aceptar mueve la postulación a
+// la etapa hired de su pipeline (si la transición no está permitida, la
+// oferta no cambia).
+func (s *OfferService) Respond(id, companyID, actorID uint, dto dtos.OfferResponseDTO) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanRespond(offer.Status, dto.Response) {
+		return nil, apperr.Conflict(fmt.Sprintf("cannot record '%s' on an offer in status '%s'", dto.Response, offer.Status))
+	}
+	if version := currentVersion(offer); version == nil || !version.ExpiresAt.After(time.Now()) {
+		return nil, apperr.Conflict("offer has expired")
+	}
+
+	from := offer.Status
+	now := time.Now()
+	offer.Status = dto.Response
+	offer.RespondedAt = &now
+	offer.ResponseNote = strings.TrimSpace(dto.Note)
+	// La respuesta se guarda solo si la oferta sigue en from, y aceptar
+	// contrata en la misma transacción: si Hire o el commit fallan ni la
+	// oferta queda aceptada ni la postulación contratada, y de dos
+	// aceptaciones simultáneas solo una contrata.
+	responded := false
+	err = s.tx.Transaction(func(tx domain.RespondTx) error {
+		ok, err := tx.Offers.Respond(offer, from)
+		if err != nil || !ok {
+			return err
+		}
+		if dto.Response == domain.ResponseAccepted {
+			if err := tx.Hirer.Hire(offer.ApplicationID, actorID, fmt.Sprintf("Offer #%d accepted", offer.ID)); err != nil {
+				return err
+			}
+		}
+		responded = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !responded {
+		return nil, apperr.Conflict("offer was already answered or is no longer open")
+	}
+	return s.presentFull(offer)
+}
+
+// Withdraw retira una oferta en curso.
+func (s *OfferService) Withdraw(id, companyID uint) (*dtos.OfferDTO, error) {
+	offer, err := s.get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsOpen(offer.Status) {
+		return nil, apperr.Conflict(fmt.Sprintf("an offer in status '%s' cannot be withdrawn", offer.Status))
+	}
+	offer.Status = models.OfferStatusWithdrawn
+	if err := s.repo.Save(offer); err != nil {
+		return nil, err
+	}
+	return s.presentFull(offer)
+}
+
+// Chain devuelve la cadena de aprobación de la empresa.
+func (s *OfferService) Chain(companyID uint) ([]models.OfferApprovalStep, error) {
+	return s.repo.Chain(companyID)
+}
+
+// SetChain reemplaza la cadena de aprobación. Las ofertas ya enviadas a
+// aprobación conservan sus pasos.
+func (s *OfferService) SetChain(companyID uint, dto dtos.OfferApprovalChainDTO) ([]models.OfferApprovalStep, error) {
+	steps := make([]models.OfferApprovalStep, len(dto.Steps))
+	for i, step := range dto.Steps {
+		condition := step.Condition
+		if condition == "" {
+			condition = models.OfferStepAlways
+		}
+		steps[i] = models.OfferApprovalStep{CompanyID: companyID, Position: i + 1, Role: step.Role, Condition: condition}
+	}
+	if err := s.repo.ReplaceChain(companyID, steps); err != nil {
+		return nil, err
+	}
+	return s.repo.Chain(companyID)
+}
+
+// ExpireOffers vence las ofertas enviadas cuya fecha límite pasó (tarea
+// periódica).
+func (s *OfferService) ExpireOffers(ctx context.Context) error {
+	n, err := s.repo.ExpireSent(time.Now())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("📄 Ofertas vencidas: %d", n)
+	}
+	return nil
+}
+
+// get devuelve una oferta validando el tenant.
+func (s *OfferService) get(id, companyID uint) (*models.Offer, error) {
+	offer, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if offer == nil || (companyID != 0 && offer.CompanyID != companyID) {
+		return nil, apperr.NotFound("offer not found")
+	}
+	return offer, nil
+}
+
+// application resuelve la postulación validando el tenant.
+func (s *OfferService) application(id, companyID uint) (*domain.ApplicationRef, error) {
+	app, err := s.apps.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil || (companyID != 0 && app.CompanyID != companyID) {
+		return nil, apperr.NotFound("application not found")
+	}
+	return app, nil
+}
+
+// notifyApprovers avisa a los miembros con el rol del paso pendiente. Es
+// best-effort: la oferta ya quedó guardada.
+func (s *OfferService) notifyApprovers(offer *models.Offer, role string, actorID uint) {
+	if s.notifier == nil {
+		return
+	}
+	userIDs, err := s.repo.MembersWithRole(offer.CompanyID, role)
+	if err != nil || len(userIDs) == 0 {
+		return
+	}
+	body := s.label(offer)
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, notification(offer, userID, actorID, models.NotificationTypeOfferApproval, "Oferta pendiente de tu aprobación", body))
+	}
+	_ = s.notifier.Notify(notifications)
+}
+
+// notifyCreator avisa a quien creó la oferta del resultado de la aprobación.
+func (s *OfferService) notifyCreator(offer *models.Offer, actorID uint, title, body string) {
+	if s.notifier == nil || offer.CreatedByID == nil || *offer.CreatedByID == actorID {
+		return
+	}
+	if body == "" {
+		body = s.label(offer)
+	}
+	_ = s.notifier.Notify([]models.Notification{
+		notification(offer, *offer.CreatedByID, actorID, models.NotificationTypeOfferDecision, title, body),
+	})
+}
+
+// label identifica la oferta en una notificación: candidato y vacante.
+func (s *OfferService) label(offer *models.Offer) string {
+	app, err := s.apps.FindByID(offer.ApplicationID)
+	if err != nil || app == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s — %s", app.CandidateName, app.JobTitle)
+}
+
+func notification(offer *models.Offer, userID, actorID uint, kind, title, body string) models.Notification {
+	n := models.Notification{
+		CompanyID:    offer.CompanyID,
+		UserID:       userID,
+		Type:         kind,
+		Title:        title,
+		Body:         body,
+		ResourceType: "offer",
+		ResourceID:   offer.ID,
+	}
+	if actorID != 0 {
+		n.ActorID = &actorID
+	}
+	return n
+}
+
+// presentFull arma el DTO con los datos de la postulación.
+func (s *OfferService) presentFull(offer *models.Offer) (*dtos.OfferDTO, error) {
+	app, err := s.apps.FindByID(offer.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	return present(offer, app), nil
+}
+
+func present(offer *models.Offer, app *domain.ApplicationRef) *dtos.OfferDTO {
+	dto := &dtos.OfferDTO{
+		ID:             offer.ID,
+		ApplicationID:  offer.ApplicationID,
+		Status:         offer.Status,
+		CurrentVersion: offer.CurrentVersion,
+		Terms:          currentVersion(offer),
+		SentAt:         offer.SentAt,
+		RespondedAt:    offer.RespondedAt,
+		ResponseNote:   offer.ResponseNote,
+		CreatedByID:    offer.CreatedByID,
+		Versions:       offer.Versions,
+		Approvals:      offer.Approvals,
+		CreatedAt:      offer.CreatedAt,
+	}
+	if app != nil {
+		dto.CandidateName = app.CandidateName
+		dto.JobTitle = app.JobTitle
+	}
+	if offer.Status == models.OfferStatusPendingApproval && dto.Terms != nil {
+		if step := domain.CurrentStep(offer.Approvals, dto.Terms.ID); step != nil {
+			dto.AwaitingRole = step.Role
+		}
+	}
+	return dto
+}
+
+// currentVersion devuelve los términos vigentes de la oferta.
+// isActor reporta si el usuario registrado es quien actúa.
+func isActor(userID *uint, actorID uint) bool {
+	return userID != nil && *userID == actorID
+}
+
+func currentVersion(offer *models.Offer) *models.OfferVersion {
+	for i := range offer.Versions {
+		if offer.Versions[i].Version == offer.CurrentVersion {
+			return &offer.Versions[i]
+		}
+	}
+	return nil
+}
+
+// newVersion valida los términos y arma la versión. Las fechas sin hora se
+// interpretan en la zona de la empresa; expires_at por fecha vence al
+// terminar ese día.
+func newVersion(dto dtos.OfferTermsDTO, number int, app *domain.ApplicationRef, actorID uint) (*models.OfferVersion, error) {
+	loc := location(app.Timezone)
+	expires, err := parseDeadline(dto.ExpiresAt, loc)
+	if err != nil {
+		return nil, err
+	}
+	if !expires.After(time.Now()) {
+		return nil, apperr.BadRequest("expires_at must be in the future")
+	}
+
+	version := &models.OfferVersion{
+		Version:        number,
+		Salary:         dto.Salary,
+		Currency:       strings.ToUpper(dto.Currency),
+		Equity:         strings.TrimSpace(dto.Equity),
+		Bonus:          dto.Bonus,
+		ExpiresAt:      expires.UTC(),
+		Notes:          strings.TrimSpace(dto.Notes),
+		AboveSalaryMax: domain.AboveSalaryMax(dto.Salary, dto.Currency, app.SalaryMax, app.SalaryCurrency),
+	}
+	if dto.StartDate != "" {
+		start, err := time.ParseInLocation("2006-01-02", dto.StartDate, loc)
+		if err != nil {
+			return nil, apperr.BadRequest("start_date must be a date (2006-01-02)")
+		}
+		version.StartDate = &start
+	}
+	if actorID != 0 {
+		version.CreatedByID = &actorID
+	}
+	return version, nil
+}
+
+func parseDeadline(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t.AddDate(0, 0, 1), nil
+	}
+	return time.Time{}, apperr.BadRequest("expires_at must be a date (2006-01-02) or an RFC 3339 time")
+}
+
+func location(tz string) *time.Location {
+	if tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
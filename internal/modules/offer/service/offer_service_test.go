@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/offer/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// fakeOffers guarda una oferta y simula el UPDATE condicional de Respond.
+type fakeOffers struct {
+	domain.OfferRepository
+	stored models.Offer
+	stale  *models.Offer // si está, GetByID devuelve esta lectura vieja
+}
+
+func (f *fakeOffers) GetByID(uint) (*models.Offer, error) {
+	offer := f.stored
+	if f.stale != nil {
+		offer = *f.stale
+	}
+	offer.Versions = append([]models.OfferVersion(nil), f.stored.Versions...)
+	return &offer, nil
+}
+
+func (f *fakeOffers) Respond(offer *models.Offer, from string) (bool, error) {
+	if f.stored.Status != from {
+		return false, nil
+	}
+	f.stored.Status = offer.Status
+	f.stored.RespondedAt = offer.RespondedAt
+	f.stored.ResponseNote = offer.ResponseNote
+	return true, nil
+}
+
+// fakeTx simula la transacción de Respond: si fn o el commit fallan, la
+// oferta y las contrataciones vuelven a como estaban.
+type fakeTx struct {
+	offers    *fakeOffers
+	hirer     *fakeHirer
+	commitErr error
+}
+
+func (f fakeTx) Transaction(fn func(tx domain.RespondTx) error) error {
+	stored, hired := f.offers.stored, append([]uint(nil), f.hirer.hired...)
+	err := fn(domain.RespondTx{Offers: f.offers, Hirer: f.hirer})
+	if err == nil {
+		err = f.commitErr
+	}
+	if err != nil {
+		f.offers.stored, f.hirer.hired = stored, hired
+	}
+	return err
+}
+
+type fakeApps struct{}
+
+func (fakeApps) FindByID(id uint) (*domain.ApplicationRef, error) {
+	return &domain.ApplicationRef{ID: id, CompanyID: 1}, nil
+}
+
+type fakeHirer struct {
+	err   error
+	hired []uint
+}
+
+func (f *fakeHirer) Hire(applicationID, _ uint, _ string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.hired = append(f.hired, applicationID)
+	return nil
+}
+
+func sentOffer() *fakeOffers {
+	offer := models.Offer{CompanyID: 1, ApplicationID: 40, Status: models.OfferStatusSent, CurrentVersion: 1,
+		Versions: []models.OfferVersion{{Version: 1, ExpiresAt: time.Now().Add(48 * time.Hour)}}}
+	offer.ID = 7
+	return &fakeOffers{stored: offer}
+}
+
+func TestRespondAceptarContrata(t *testing.T) {
+	repo, hirer := sentOffer(), &fakeHirer{}
+	svc := NewOfferService(repo, fakeApps{}, fakeTx{offers: repo, hirer: hirer}, nil)
+
+	dto, err := svc.Respond(7, 1, 3, dtos.OfferResponseDTO{Response: domain.ResponseAccepted, Note: " ok "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.Status != models.OfferStatusAccepted || repo.stored.Status != models.OfferStatusAccepted || repo.stored.ResponseNote != "ok" {
+		t.Errorf("oferta = %+v", repo.stored)
+	}
+	if len(hirer.hired) != 1 || hirer.hired[0] != 40 {
+		t.Errorf("contratadas = %v", hirer.hired)
+	}
+}
+
+func TestRespondSiHireFallaLaOfertaNoCambia(t *testing.T) {
+	repo := sentOffer()
+	hireErr := apperr.Unprocessable("transition not allowed")
+	svc := NewOfferService(repo, fakeApps{}, fakeTx{offers: repo, hirer: &fakeHirer{err: hireErr}}, nil)
+
+	_, err := svc.Respond(7, 1, 3, dtos.OfferResponseDTO{Response: domain.ResponseAccepted})
+	if !errors.Is(err, hireErr) {
+		t.Fatalf("err = %v, se esperaba el de Hire", err)
+	}
+	if repo.stored.Status != models.OfferStatusSent || repo.stored.RespondedAt != nil {
+		t.Errorf("la oferta no debería cambiar: %+v", repo.stored)
+	}
+}
+
+// Si el commit falla después de contratar, se revierten las dos cosas: ni la
+// oferta queda aceptada ni la postulación contratada.
+func TestRespondSiElCommitFallaNoContrata(t *testing.T) {
+	repo, hirer := sentOffer(), &fakeHirer{}
+	commitErr := errors.New("commit failed")
+	svc := NewOfferService(repo, fakeApps{}, fakeTx{offers: repo, hirer: hirer, commitErr: commitErr}, nil)
+
+	_, err := svc.Respond(7, 1, 3, dtos.OfferResponseDTO{Response: domain.ResponseAccepted})
+	if !errors.Is(err, commitErr) {
+		t.Fatalf("err = %v, se esperaba el del commit", err)
+	}
+	if repo.stored.Status != models.OfferStatusSent || len(hirer.hired) != 0 {
+		t.Errorf("oferta = %+v, contratadas = %v; no debería cambiar nada", repo.stored, hirer.hired)
+	}
+}
+
+func TestRespondConcurrenteSoloContrataUnaVez(t *testing.T) {
+	repo, hirer := sentOffer(), &fakeHirer{}
+	// Las dos peticiones leen la oferta todavía enviada.
+	stale := repo.stored
+	repo.stale = &stale
+	svc := NewOfferService(repo, fakeApps{}, fakeTx{offers: repo, hirer: hirer}, nil)
+
+	if _, err := svc.Respond(7, 1, 3, dtos.OfferResponseDTO{Response: domain.ResponseAccepted}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := svc.Respond(7, 1, 3, dtos.OfferResponseDTO{Response: domain.ResponseAccepted})
+	if apperr.StatusCode(err) != http.StatusConflict {
+		t.Errorf("err = %v, se esperaba 409", err)
+	}
+	if len(hirer.hired) != 1 {
+		t.Errorf("contratadas = %v, se esperaba una", hirer.hired)
+	}
+}
+
+func TestRespondDeclinarNoContrata(t *testing.T) {
+	repo, hirer := sentOffer(), &fakeHirer{}
+	svc := NewOfferService(repo, fakeApps{}, fakeTx{offers: repo, hirer: hirer}, nil)
+
+	if _, err := svc.Respond(7, 1, 3, dtos.OfferResponseDTO{Response: domain.ResponseDeclined}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.stored.Status != models.OfferStatusDeclined || len(hirer.hired) != 0 {
+		t.Errorf("oferta = %+v, contratadas = %v", repo.stored, hirer.hired)
+	}
+}
+
+func TestDecideQuienRedactoNoAprueba(t *testing.T) {
+	creator := uint(3)
+	version := models.OfferVersion{Version: 1, CreatedByID: &creator}
+	version.ID = 11
+	offer := models.Offer{CompanyID: 1, Status: models.OfferStatusPendingApproval, CurrentVersion: 1, CreatedByID: &creator,
+		Versions:  []models.OfferVersion{version},
+		Approvals: []models.OfferApproval{{OfferVersionID: 11, Step: 1, Role: "admin", Status: models.OfferApprovalPending}}}
+	repo := &fakeOffers{stored: offer}
+	svc := NewOfferService(repo, fakeApps{}, nil, nil)
+
+	_, err := svc.Decide(7, 1, creator, "admin", true, dtos.OfferDecisionDTO{})
+	if apperr.StatusCode(err) != http.StatusForbidden {
+		t.Errorf("err = %v, se esperaba 403", err)
+	}
+	if repo.stored.Approvals[0].Status != models.OfferApprovalPending {
+		t.Errorf("paso = %+v, debía seguir pendiente", repo.stored.Approvals[0])
+	}
+}
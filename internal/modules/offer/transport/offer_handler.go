@@ -0,0 +1,384 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/offer/domain"
+	"dvra-api/internal/modules/offer/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OfferHandler struct {
+	svc *service.OfferService
+}
+
+func NewOfferHandler(svc *service.OfferService) *OfferHandler {
+	return &OfferHandler{svc: svc}
+}
+
+// GetOffers godoc
+// @Summary      Listar ofertas
+// @Description  Ofertas de la empresa, más recientes primero
+// @Tags         Offers
+// @Produce      json
+// @Param        status          query     string  false  "draft, pending_approval, approved, sent, negotiating, accepted, declined, withdrawn, expired"
+// @Param        application_id  query     int     false  "Postulación"
+// @Param        company_id      query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200             {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers [get]
+func (h *OfferHandler) GetOffers(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	filter := domain.ListFilter{Status: c.Query("status")}
+	if v, err := strconv.ParseUint(c.Query("application_id"), 10, 32); err == nil {
+		filter.ApplicationID = uint(v)
+	}
+
+	offers, err := h.svc.List(companyID, filter)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": offers, "count": len(offers)}})
+}
+
+// GetApplicationOffers godoc
+// @Summary      Ofertas de una postulación
+// @Tags         Offers
+// @Produce      json
+// @Param        id   path      int  true  "ID de la postulación"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/offers [get]
+func (h *OfferHandler) GetApplicationOffers(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+
+	offers, err := h.svc.ListByApplication(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": offers, "count": len(offers)}})
+}
+
+// GetOffer godoc
+// @Summary      Obtener oferta
+// @Description  Términos vigentes, versiones anteriores y pasos de aprobación
+// @Tags         Offers
+// @Produce      json
+// @Param        id   path      int  true  "ID de la oferta"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id} [get]
+func (h *OfferHandler) GetOffer(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+
+	offer, err := h.svc.Get(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// CreateOffer godoc
+// @Summary      Crear oferta
+// @Description  Abre la oferta de una postulación en borrador (versión 1). 409 si ya tiene una en curso.
+// @Tags         Offers
+// @Accept       json
+// @Produce      json
+// @Param        offer  body      dtos.CreateOfferDTO  true  "Oferta"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers [post]
+func (h *OfferHandler) CreateOffer(c *gin.Context) {
+	var dto dtos.CreateOfferDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	offer, err := h.svc.Create(companyID, actorID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": offer})
+}
+
+// ReviseOffer godoc
+// @Summary      Revisar términos
+// @Description  Crea una nueva versión de la oferta; vuelve a borrador y debe aprobarse otra vez
+// @Tags         Offers
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true  "ID de la oferta"
+// @Param        terms  body      dtos.OfferTermsDTO  true  "Términos"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id} [put]
+func (h *OfferHandler) ReviseOffer(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+	var dto dtos.OfferTermsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	offer, err := h.svc.Revise(id, companyID, actorID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// SubmitOffer godoc
+// @Summary      Enviar a aprobación
+// @Description  Arma los pasos de la cadena de la empresa para la versión actual (fuera de banda salarial siempre hay al menos uno)
+// @Tags         Offers
+// @Produce      json
+// @Param        id   path      int  true  "ID de la oferta"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id}/submit [post]
+func (h *OfferHandler) SubmitOffer(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	offer, err := h.svc.Submit(id, companyID, actorID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// ApproveOffer godoc
+// @Summary      Aprobar paso
+// @Description  Aprueba el paso pendiente; el usuario debe tener el rol del paso
+// @Tags         Offers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                    true   "ID de la oferta"
+// @Param        decision  body      dtos.OfferDecisionDTO  false  "Comentario"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      403       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id}/approve [post]
+func (h *OfferHandler) ApproveOffer(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// RejectOffer godoc
+// @Summary      Rechazar paso
+// @Description  Rechaza el paso pendiente; la oferta vuelve a borrador para revisarla
+// @Tags         Offers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                    true   "ID de la oferta"
+// @Param        decision  body      dtos.OfferDecisionDTO  false  "Comentario"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      403       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id}/reject [post]
+func (h *OfferHandler) RejectOffer(c *gin.Context) {
+	h.decide(c, false)
+}
+
+func (h *OfferHandler) decide(c *gin.Context, approve bool) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+	var dto dtos.OfferDecisionDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	actorID, _ := authctx.UserID(c)
+
+	offer, err := h.svc.Decide(id, companyID, actorID, authctx.Role(c), approve, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// SendOffer godoc
+// @Summary      Marcar como enviada
+// @Description  Solo ofertas aprobadas y vigentes
+// @Tags         Offers
+// @Produce      json
+// @Param        id   path      int  true  "ID de la oferta"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id}/send [post]
+func (h *OfferHandler) SendOffer(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+
+	offer, err := h.svc.Send(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// RespondOffer godoc
+// @Summary      Registrar respuesta del candidato
+// @Description  accepted (mueve la postulación a hired), declined o negotiating (luego se revisan los términos)
+// @Tags         Offers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                    true  "ID de la oferta"
+// @Param        response  body      dtos.OfferResponseDTO  true  "Respuesta"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      409       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id}/response [post]
+func (h *OfferHandler) RespondOffer(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+	var dto dtos.OfferResponseDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actorID, _ := authctx.UserID(c)
+
+	offer, err := h.svc.Respond(id, companyID, actorID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// WithdrawOffer godoc
+// @Summary      Retirar oferta
+// @Tags         Offers
+// @Produce      json
+// @Param        id   path      int  true  "ID de la oferta"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/{id}/withdraw [post]
+func (h *OfferHandler) WithdrawOffer(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid offer ID")
+	if !ok {
+		return
+	}
+
+	offer, err := h.svc.Withdraw(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": offer})
+}
+
+// GetApprovalChain godoc
+// @Summary      Cadena de aprobación
+// @Description  Pasos en orden (rol y condición: always o above_salary_max)
+// @Tags         Offers
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/approval-chain [get]
+func (h *OfferHandler) GetApprovalChain(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	steps, err := h.svc.Chain(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": steps, "count": len(steps)}})
+}
+
+// SetApprovalChain godoc
+// @Summary      Configurar cadena de aprobación
+// @Description  Reemplaza los pasos (en orden). Las ofertas ya en aprobación conservan los suyos.
+// @Tags         Offers
+// @Accept       json
+// @Produce      json
+// @Param        chain       body      dtos.OfferApprovalChainDTO  true   "Pasos"
+// @Param        company_id  query     int                         false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /offers/approval-chain [put]
+func (h *OfferHandler) SetApprovalChain(c *gin.Context) {
+	var dto dtos.OfferApprovalChainDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	steps, err := h.svc.SetChain(companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": steps, "count": len(steps)}})
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
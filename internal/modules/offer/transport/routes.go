@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/offer/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.OfferService) {
+	h := NewOfferHandler(svc)
+
+	offers := rg.Group("/offers")
+	{
+		offers.GET("", middleware.RequirePermission(permissions.OffersView), h.GetOffers)
+		offers.GET("/approval-chain", middleware.RequirePermission(permissions.OffersView), h.GetApprovalChain)
+		offers.PUT("/approval-chain", middleware.RequirePermission(permissions.OffersChainManage), h.SetApprovalChain)
+		offers.POST("", middleware.RequirePermission(permissions.OffersManage), h.CreateOffer)
+		offers.GET("/:id", middleware.RequirePermission(permissions.OffersView), h.GetOffer)
+		offers.PUT("/:id", middleware.RequirePermission(permissions.OffersManage), h.ReviseOffer)
+		offers.POST("/:id/submit", middleware.RequirePermission(permissions.OffersManage), h.SubmitOffer)
+		offers.POST("/:id/approve", middleware.RequirePermission(permissions.OffersApprove), h.ApproveOffer)
+		offers.POST("/:id/reject", middleware.RequirePermission(permissions.OffersApprove), h.RejectOffer)
+		offers.POST("/:id/send", middleware.RequirePermission(permissions.OffersManage), h.SendOffer)
+		offers.POST("/:id/response", middleware.RequirePermission(permissions.OffersManage), h.RespondOffer)
+		offers.POST("/:id/withdraw", middleware.RequirePermission(permissions.OffersManage), h.WithdrawOffer)
+	}
+
+	// Las ofertas también cuelgan de la postulación.
+	applications := rg.Group("/applications")
+	{
+		applications.GET("/:id/offers", middleware.RequirePermission(permissions.OffersView), h.GetApplicationOffers)
+	}
+}
@@ -0,0 +1,148 @@
+// Package domain define el centro del módulo pipeline: las etapas por defecto
+// (RN-APP-001), las reglas de validación de un pipeline y el puerto hacia la
+// persistencia. No importa gin ni gorm.
+package domain
+
+import (
+	"fmt"
+	"regexp"
+
+	"dvra-api/internal/app/models"
+)
+
+// DefaultPipelineName es el nombre del pipeline que se crea para cada empresa.
+const DefaultPipelineName = "Default"
+
+var (
+	stageKeyPattern   = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+	stageColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// DefaultStages devuelve las etapas de RN-APP-001 con sus colores por defecto
+// y las transiciones de RN-APP-002.
+func DefaultStages() []models.PipelineStage {
+	return []models.PipelineStage{
+		{Key: "applied", Name: "Aplicado", Type: models.StageTypeActive, Color: "#94a3b8", Position: 1,
+			Transitions: []string{"screening"}},
+		{Key: "screening", Name: "En Revisión", Type: models.StageTypeActive, Color: "#38bdf8", Position: 2,
+			Transitions: []string{"technical", "rejected"}},
+		{Key: "technical", Name: "Prueba Técnica", Type: models.StageTypeActive, Color: "#818cf8", Position: 3,
+			Transitions: []string{"interview", "offer", "rejected"}},
+		{Key: "interview", Name: "Entrevista", Type: models.StageTypeActive, Color: "#c084fc", Position: 4,
+			Transitions: []string{"offer", "rejected"}},
+		{Key: "offer", Name: "Oferta", Type: models.StageTypeActive, Color: "#fbbf24", Position: 5,
+			Transitions: []string{"hired", "rejected"}},
+		{Key: "hired", Name: "Contratado", Type: models.StageTypeHired, Color: "#22c55e", Position: 6},
+		{Key: "rejected", Name: "Rechazado", Type: models.StageTypeRejected, Color: "#ef4444", Position: 7},
+	}
+}
+
+// ValidateStages verifica que las etapas formen un pipeline utilizable:
+// keys únicas con formato slug, tipo y color válidos, transiciones hacia keys
+// existentes solo desde etapas active, al menos una etapa active y
+// exactamente una hired y una rejected.
+func ValidateStages(stages []models.PipelineStage) error {
+	seen := make(map[string]bool, len(stages))
+	counts := make(map[string]int)
+	for _, s := range stages {
+		if !stageKeyPattern.MatchString(s.Key) {
+			return fmt.Errorf("invalid stage key %q: use lowercase letters, digits and underscores", s.Key)
+		}
+		if seen[s.Key] {
+			return fmt.Errorf("duplicated stage key %q", s.Key)
+		}
+		seen[s.Key] = true
+
+		if s.Name == "" {
+			return fmt.Errorf("stage %q requires a name", s.Key)
+		}
+		if s.Color != "" && !stageColorPattern.MatchString(s.Color) {
+			return fmt.Errorf("invalid color %q for stage %q: use #RRGGBB", s.Color, s.Key)
+		}
+		switch s.Type {
+		case models.StageTypeActive, models.StageTypeHired, models.StageTypeRejected:
+			counts[s.Type]++
+		default:
+			return fmt.Errorf("invalid type %q for stage %q", s.Type, s.Key)
+		}
+	}
+
+	for _, s := range stages {
+		if len(s.Transitions) > 0 && s.Type != models.StageTypeActive {
+			return fmt.Errorf("stage %q is final and cannot have transitions", s.Key)
+		}
+		for _, to := range s.Transitions {
+			if !seen[to] {
+				return fmt.Errorf("stage %q has a transition to unknown stage %q", s.Key, to)
+			}
+			if to == s.Key {
+				return fmt.Errorf("stage %q cannot transition to itself", s.Key)
+			}
+		}
+	}
+
+	if counts[models.StageTypeActive] == 0 {
+		return fmt.Errorf("pipeline requires at least one active stage")
+	}
+	if counts[models.StageTypeHired] != 1 {
+		return fmt.Errorf("pipeline requires exactly one hired stage")
+	}
+	if counts[models.StageTypeRejected] != 1 {
+		return fmt.Errorf("pipeline requires exactly one rejected stage")
+	}
+	return nil
+}
+
+// RemovedKeys devuelve las keys de current que ya no están en next.
+func RemovedKeys(current, next []models.PipelineStage) []string {
+	keep := make(map[string]bool, len(next))
+	for _, s := range next {
+		keep[s.Key] = true
+	}
+	removed := []string{}
+	for _, s := range current {
+		if !keep[s.Key] {
+			removed = append(removed, s.Key)
+		}
+	}
+	return removed
+}
+
+// MergeStages une las etapas de varios pipelines de una empresa: primero las
+// del pipeline base en su orden, luego las keys que solo existen en pipelines
+// de vacantes. Sirve para vistas que abarcan toda la empresa (tablero, dashboard).
+func MergeStages(base []models.PipelineStage, others ...[]models.PipelineStage) []models.PipelineStage {
+	seen := make(map[string]bool)
+	merged := make([]models.PipelineStage, 0, len(base))
+	for _, list := range append([][]models.PipelineStage{base}, others...) {
+		for _, s := range list {
+			if seen[s.Key] {
+				continue
+			}
+			seen[s.Key] = true
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// PipelineRepository es el puerto de salida hacia los pipelines.
+type PipelineRepository interface {
+	// GetCompanyDefault devuelve el pipeline por defecto (job_id NULL), o nil.
+	GetCompanyDefault(companyID uint) (*models.Pipeline, error)
+	// GetForJob devuelve el pipeline propio de la vacante, o nil.
+	GetForJob(jobID uint) (*models.Pipeline, error)
+	GetByID(id uint) (*models.Pipeline, error)
+	// ListByCompany devuelve todos los pipelines de la empresa con sus etapas.
+	ListByCompany(companyID uint) ([]models.Pipeline, error)
+	// Create guarda el pipeline con sus etapas.
+	Create(pipeline *models.Pipeline) error
+	// ReplaceStages reemplaza las etapas y el nombre del pipeline en una transacción.
+	ReplaceStages(pipeline *models.Pipeline, stages []models.PipelineStage) error
+	Delete(id uint) error
+	// CountApplicationsInStages cuenta las postulaciones que usan el pipeline
+	// y están en alguna de las keys.
+	CountApplicationsInStages(pipeline *models.Pipeline, keys []string) (int64, error)
+	// JobCompanyID devuelve la empresa de la vacante (0 si no existe).
+	JobCompanyID(jobID uint) (uint, error)
+}
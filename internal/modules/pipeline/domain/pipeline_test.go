@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestDefaultStagesSonValidas(t *testing.T) {
+	if err := ValidateStages(DefaultStages()); err != nil {
+		t.Fatalf("las etapas por defecto deberían ser válidas: %v", err)
+	}
+}
+
+func TestValidateStagesRechazaPipelinesInvalidos(t *testing.T) {
+	active := models.PipelineStage{Key: "applied", Name: "Aplicado", Type: models.StageTypeActive}
+	hired := models.PipelineStage{Key: "hired", Name: "Contratado", Type: models.StageTypeHired}
+	rejected := models.PipelineStage{Key: "rejected", Name: "Rechazado", Type: models.StageTypeRejected}
+
+	cases := map[string][]models.PipelineStage{
+		"sin hired":        {active, rejected},
+		"sin rejected":     {active, hired},
+		"sin active":       {hired, rejected},
+		"dos hired":        {active, hired, {Key: "hired_2", Name: "Otra", Type: models.StageTypeHired}, rejected},
+		"key duplicada":    {active, active, hired, rejected},
+		"key inválida":     {{Key: "En Revisión", Name: "x", Type: models.StageTypeActive}, hired, rejected},
+		"color inválido":   {{Key: "applied", Name: "x", Type: models.StageTypeActive, Color: "red"}, hired, rejected},
+		"tipo desconocido": {{Key: "applied", Name: "x", Type: "paused"}, hired, rejected},
+	}
+	for name, stages := range cases {
+		if err := ValidateStages(stages); err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+}
+
+func TestMergeStagesConservaOrdenBase(t *testing.T) {
+	base := DefaultStages()
+	job := []models.PipelineStage{{Key: "applied"}, {Key: "take_home"}, {Key: "hired"}}
+
+	merged := MergeStages(base, job)
+	if len(merged) != len(base)+1 {
+		t.Fatalf("se esperaban %d etapas, hay %d", len(base)+1, len(merged))
+	}
+	if merged[0].Key != "applied" || merged[len(merged)-1].Key != "take_home" {
+		t.Errorf("orden inesperado: primera %q, última %q", merged[0].Key, merged[len(merged)-1].Key)
+	}
+}
+
+func TestCheckTransitionSigueRNAPP002(t *testing.T) {
+	p := &models.Pipeline{Stages: DefaultStages()}
+
+	cases := []struct {
+		from, to string
+		want     error
+	}{
+		{"applied", "screening", nil},
+		{"technical", "offer", nil},
+		{"offer", "hired", nil},
+		{"screening", "rejected", nil},
+		{"offer", "offer", nil},
+		{"applied", "offer", ErrTransitionNotAllowed},
+		{"interview", "technical", ErrTransitionNotAllowed},
+		{"hired", "applied", ErrFinalStage},
+		{"rejected", "screening", ErrFinalStage},
+		{"screening", "limbo", ErrUnknownStage},
+	}
+	for _, tc := range cases {
+		err := CheckTransition(p, tc.from, tc.to)
+		if tc.want == nil && err != nil {
+			t.Errorf("%s → %s: no se esperaba error, hubo %v", tc.from, tc.to, err)
+		}
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Errorf("%s → %s: se esperaba %v, hubo %v", tc.from, tc.to, tc.want, err)
+		}
+	}
+}
+
+func TestNextStagesReglaPorDefecto(t *testing.T) {
+	p := &models.Pipeline{Stages: []models.PipelineStage{
+		{Key: "new", Type: models.StageTypeActive, Position: 1},
+		{Key: "call", Type: models.StageTypeActive, Position: 2},
+		{Key: "won", Type: models.StageTypeHired, Position: 3},
+		{Key: "lost", Type: models.StageTypeRejected, Position: 4},
+	}}
+
+	if got := p.NextStages("new"); len(got) != 2 || got[0] != "call" || got[1] != "lost" {
+		t.Errorf("new: se esperaba [call lost], hubo %v", got)
+	}
+	if got := p.NextStages("call"); len(got) != 2 || got[0] != "won" || got[1] != "lost" {
+		t.Errorf("call: se esperaba [won lost], hubo %v", got)
+	}
+	if got := p.NextStages("won"); got != nil {
+		t.Errorf("won es final, no debería tener salidas: %v", got)
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"dvra-api/internal/app/models"
+)
+
+// Errores de la máquina de estados (RN-APP-002). El servicio los traduce a
+// apperr; se comparan con errors.Is.
+var (
+	// ErrUnknownStage: la etapa destino no existe en el pipeline de la vacante.
+	ErrUnknownStage = errors.New("unknown stage")
+	// ErrFinalStage: la postulación está en hired/rejected; solo un override la reabre.
+	ErrFinalStage = errors.New("application is in a final stage")
+	// ErrTransitionNotAllowed: el destino no es una salida de la etapa actual.
+	ErrTransitionNotAllowed = errors.New("stage transition not allowed")
+)
+
+// CheckTransition valida mover una postulación de from a to dentro del
+// pipeline. from == to no es un movimiento y siempre es válido.
+func CheckTransition(p *models.Pipeline, from, to string) error {
+	target := p.Stage(to)
+	if target == nil {
+		return fmt.Errorf("%w: %q is not part of this job's pipeline", ErrUnknownStage, to)
+	}
+	if from == to {
+		return nil
+	}
+
+	current := p.Stage(from)
+	if current == nil {
+		// La etapa actual ya no existe en el pipeline (p. ej. la vacante
+		// cambió de pipeline): no hay grafo del que partir.
+		return fmt.Errorf("%w: current stage %q is not part of this job's pipeline", ErrTransitionNotAllowed, from)
+	}
+	if current.Type != models.StageTypeActive {
+		return fmt.Errorf("%w: %q cannot be left without an override", ErrFinalStage, from)
+	}
+
+	next := p.NextStages(from)
+	for _, key := range next {
+		if key == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q → %q (allowed: %v)", ErrTransitionNotAllowed, from, to, next)
+}
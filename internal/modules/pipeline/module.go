@@ -0,0 +1,30 @@
+// Package pipeline es el punto de ensamblaje del módulo de pipelines: las
+// etapas del proceso de selección de cada empresa (y opcionalmente de cada
+// vacante). Nadie importa este paquete salvo el composition root.
+package pipeline
+
+import (
+	"dvra-api/internal/modules/pipeline/repository"
+	"dvra-api/internal/modules/pipeline/service"
+	"dvra-api/internal/modules/pipeline/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo pipeline.
+type Module struct {
+	// Service se expone porque applications, dashboard, public y staffing
+	// resuelven las etapas a través de él (puertos definidos por cada consumidor).
+	Service *service.PipelineService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{Service: service.NewPipelineService(repository.NewPipelineRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
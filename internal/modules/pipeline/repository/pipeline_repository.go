@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/pipeline/domain"
+
+	"gorm.io/gorm"
+)
+
+type pipelineRepository struct {
+	db *gorm.DB
+}
+
+// NewPipelineRepository devuelve la implementación del puerto.
+func NewPipelineRepository(db *gorm.DB) domain.PipelineRepository {
+	return &pipelineRepository{db: db}
+}
+
+// withStages precarga las etapas ordenadas por posición.
+func withStages(db *gorm.DB) *gorm.DB {
+	return db.Preload("Stages", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC, id ASC") })
+}
+
+func (r *pipelineRepository) GetCompanyDefault(companyID uint) (*models.Pipeline, error) {
+	return r.first(r.db.Where("company_id = ? AND job_id IS NULL", companyID))
+}
+
+func (r *pipelineRepository) GetForJob(jobID uint) (*models.Pipeline, error) {
+	return r.first(r.db.Where("job_id = ?", jobID))
+}
+
+func (r *pipelineRepository) GetByID(id uint) (*models.Pipeline, error) {
+	return r.first(r.db.Where("id = ?", id))
+}
+
+func (r *pipelineRepository) first(query *gorm.DB) (*models.Pipeline, error) {
+	var pipeline models.Pipeline
+	if err := withStages(query).First(&pipeline).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+func (r *pipelineRepository) ListByCompany(companyID uint) ([]models.Pipeline, error) {
+	var pipelines []models.Pipeline
+	if err := withStages(r.db.Where("company_id = ?", companyID)).
+		Order("job_id IS NOT NULL, job_id ASC").
+		Find(&pipelines).Error; err != nil {
+		return nil, err
+	}
+	return pipelines, nil
+}
+
+func (r *pipelineRepository) Create(pipeline *models.Pipeline) error {
+	return r.db.Create(pipeline).Error
+}
+
+func (r *pipelineRepository) ReplaceStages(pipeline *models.Pipeline, stages []models.PipelineStage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(pipeline).Update("name", pipeline.Name).Error; err != nil {
+			return err
+		}
+		// Hard delete: la key es única por pipeline y una etapa quitada y
+		// vuelta a agregar no debe chocar con su versión soft-deleted.
+		if err := tx.Unscoped().Where("pipeline_id = ?", pipeline.ID).Delete(&models.PipelineStage{}).Error; err != nil {
+			return err
+		}
+		for i := range stages {
+			stages[i].ID = 0
+			stages[i].PipelineID = pipeline.ID
+		}
+		if err := tx.Create(&stages).Error; err != nil {
+			return err
+		}
+		pipeline.Stages = stages
+		return nil
+	})
+}
+
+func (r *pipelineRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("pipeline_id = ?", id).Delete(&models.PipelineStage{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.Pipeline{}, id).Error
+	})
+}
+
+func (r *pipelineRepository) CountApplicationsInStages(pipeline *models.Pipeline, keys []string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	query := r.db.Model(&models.Application{}).Where("stage IN ?", keys)
+	if pipeline.JobID != nil {
+		query = query.Where("job_id = ?", *pipeline.JobID)
+	} else {
+		// El pipeline por defecto rige para las vacantes sin pipeline propio.
+		query = query.Where("company_id = ?", pipeline.CompanyID).
+			Where("job_id NOT IN (SELECT job_id FROM pipelines WHERE company_id = ? AND job_id IS NOT NULL AND deleted_at IS NULL)", pipeline.CompanyID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *pipelineRepository) JobCompanyID(jobID uint) (uint, error) {
+	var job models.Job
+	if err := r.db.Select("id", "company_id").First(&job, jobID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return job.CompanyID, nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/pipeline/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// PipelineService resuelve y administra los pipelines de cada empresa. Es la
+// única fuente de verdad sobre qué etapas existen y de qué tipo es cada una:
+// applications, dashboard y staffing la consultan en lugar de usar literales.
+type PipelineService struct {
+	repo domain.PipelineRepository
+}
+
+func NewPipelineService(repo domain.PipelineRepository) *PipelineService {
+	return &PipelineService{repo: repo}
+}
+
+// EnsureDefault devuelve el pipeline por defecto de la empresa, creándolo con
+// las etapas de RN-APP-001 la primera vez que se necesita.
+func (s *PipelineService) EnsureDefault(companyID uint) (*models.Pipeline, error) {
+	pipeline, err := s.repo.GetCompanyDefault(companyID)
+	if err != nil || pipeline != nil {
+		return pipeline, err
+	}
+
+	pipeline = &models.Pipeline{CompanyID: companyID, Name: domain.DefaultPipelineName, Stages: domain.DefaultStages()}
+	if err := s.repo.Create(pipeline); err != nil {
+		// Otra petición pudo crearlo en paralelo (índice único parcial).
+		if existing, findErr := s.repo.GetCompanyDefault(companyID); findErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// Resolve devuelve el pipeline que rige para una vacante: el propio si tiene,
+// si no el de la empresa. jobID 0 = el de la empresa.
+func (s *PipelineService) Resolve(companyID, jobID uint) (*models.Pipeline, error) {
+	if jobID != 0 {
+		pipeline, err := s.repo.GetForJob(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if pipeline != nil {
+			return pipeline, nil
+		}
+	}
+	return s.EnsureDefault(companyID)
+}
+
+// CompanyStages devuelve todas las etapas que usa la empresa: las del pipeline
+// por defecto en su orden y luego las exclusivas de pipelines de vacantes.
+func (s *PipelineService) CompanyStages(companyID uint) ([]models.PipelineStage, error) {
+	pipelines, err := s.List(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var base []models.PipelineStage
+	others := [][]models.PipelineStage{}
+	for _, p := range pipelines {
+		if p.JobID == nil {
+			base = p.Stages
+		} else {
+			others = append(others, p.Stages)
+		}
+	}
+	return domain.MergeStages(base, others...), nil
+}
+
+// StageKeysOfType devuelve las keys de la empresa del tipo dado (p. ej. todas
+// las etapas hired, incluidas las de pipelines de vacantes).
+func (s *PipelineService) StageKeysOfType(companyID uint, stageType string) ([]string, error) {
+	stages, err := s.CompanyStages(companyID)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for _, st := range stages {
+		if st.Type == stageType {
+			keys = append(keys, st.Key)
+		}
+	}
+	return keys, nil
+}
+
+// CheckTransition valida un cambio de etapa según el grafo del pipeline
+// (RN-APP-002): 400 si la etapa destino no existe, 422 si el movimiento no
+// está permitido desde la etapa actual.
+func (s *PipelineService) CheckTransition(pipeline *models.Pipeline, from, to string) error {
+	err := domain.CheckTransition(pipeline, from, to)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrUnknownStage):
+		return apperr.BadRequest(err.Error())
+	default:
+		return apperr.Unprocessable(err.Error())
+	}
+}
+
+// List devuelve el pipeline por defecto y los de vacantes de la empresa.
+func (s *PipelineService) List(companyID uint) ([]models.Pipeline, error) {
+	if _, err := s.EnsureDefault(companyID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByCompany(companyID)
+}
+
+// Get devuelve un pipeline validando el tenant (companyID 0 = SuperAdmin).
+func (s *PipelineService) Get(id, companyID uint) (*models.Pipeline, error) {
+	pipeline, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline == nil || (companyID != 0 && pipeline.CompanyID != companyID) {
+		return nil, apperr.NotFound("pipeline not found")
+	}
+	return pipeline, nil
+}
+
+// CreateForJob crea un pipeline propio para una vacante. Sin etapas en el
+// request se copian las del pipeline de la empresa.
+func (s *PipelineService) CreateForJob(companyID uint, dto dtos.CreatePipelineDTO) (*models.Pipeline, error) {
+	jobCompanyID, err := s.repo.JobCompanyID(dto.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if jobCompanyID == 0 || (companyID != 0 && jobCompanyID != companyID) {
+		return nil, apperr.NotFound("job not found")
+	}
+	existing, err := s.repo.GetForJob(dto.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperr.Conflict("job already has its own pipeline")
+	}
+
+	base, err := s.EnsureDefault(jobCompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := dtos.ToPipelineStages(dto.Stages)
+	if len(dto.Stages) == 0 {
+		stages = copyStages(base.Stages)
+	}
+	if err := domain.ValidateStages(stages); err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+
+	// Las postulaciones existentes de la vacante siguen en sus etapas: todas
+	// deben existir en el pipeline nuevo.
+	jobID := dto.JobID
+	draft := &models.Pipeline{CompanyID: jobCompanyID, JobID: &jobID}
+	if err := s.ensureKeysNotInUse(draft, domain.RemovedKeys(base.Stages, stages)); err != nil {
+		return nil, err
+	}
+
+	name := dto.Name
+	if name == "" {
+		name = base.Name
+	}
+	pipeline := &models.Pipeline{CompanyID: jobCompanyID, JobID: &jobID, Name: name, Stages: stages}
+	if err := s.repo.Create(pipeline); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// Update reemplaza las etapas del pipeline. No se puede quitar una etapa que
+// tenga postulaciones: primero hay que moverlas.
+func (s *PipelineService) Update(id, companyID uint, dto dtos.UpdatePipelineDTO) (*models.Pipeline, error) {
+	pipeline, err := s.Get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := dtos.ToPipelineStages(dto.Stages)
+	if err := domain.ValidateStages(stages); err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+	if err := s.ensureKeysNotInUse(pipeline, domain.RemovedKeys(pipeline.Stages, stages)); err != nil {
+		return nil, err
+	}
+
+	if dto.Name != "" {
+		pipeline.Name = dto.Name
+	}
+	if err := s.repo.ReplaceStages(pipeline, stages); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// Delete elimina el pipeline propio de una vacante, que vuelve a usar el de la
+// empresa. El pipeline por defecto no se elimina.
+func (s *PipelineService) Delete(id, companyID uint) error {
+	pipeline, err := s.Get(id, companyID)
+	if err != nil {
+		return err
+	}
+	if pipeline.JobID == nil {
+		return apperr.BadRequest("the company default pipeline cannot be deleted")
+	}
+
+	base, err := s.EnsureDefault(pipeline.CompanyID)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureKeysNotInUse(pipeline, domain.RemovedKeys(pipeline.Stages, base.Stages)); err != nil {
+		return err
+	}
+	return s.repo.Delete(pipeline.ID)
+}
+
+func (s *PipelineService) ensureKeysNotInUse(pipeline *models.Pipeline, keys []string) error {
+	count, err := s.repo.CountApplicationsInStages(pipeline, keys)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperr.Conflict(fmt.Sprintf("%d application(s) are in stages %v; move them before removing those stages", count, keys))
+	}
+	return nil
+}
+
+func copyStages(stages []models.PipelineStage) []models.PipelineStage {
+	result := make([]models.PipelineStage, len(stages))
+	for i, s := range stages {
+		result[i] = models.PipelineStage{Key: s.Key, Name: s.Name, Type: s.Type, Color: s.Color, Position: s.Position, Transitions: s.Transitions}
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/pipeline/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PipelineHandler struct {
+	svc *service.PipelineService
+}
+
+func NewPipelineHandler(svc *service.PipelineService) *PipelineHandler {
+	return &PipelineHandler{svc: svc}
+}
+
+// GetPipelines godoc
+// @Summary      Listar pipelines
+// @Description  Pipeline por defecto de la empresa y los propios de vacantes, con sus etapas ordenadas
+// @Tags         Pipelines
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /pipelines [get]
+func (h *PipelineHandler) GetPipelines(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	pipelines, err := h.svc.List(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve pipelines"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": dtos.ToPipelineResponseList(pipelines), "count": len(pipelines)}})
+}
+
+// ResolvePipeline godoc
+// @Summary      Pipeline vigente de una vacante
+// @Description  Devuelve el pipeline propio de la vacante o, si no tiene, el de la empresa
+// @Tags         Pipelines
+// @Produce      json
+// @Param        job_id      query     int  false  "Vacante (vacío = pipeline de la empresa)"
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /pipelines/resolve [get]
+func (h *PipelineHandler) ResolvePipeline(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	jobID, _ := strconv.ParseUint(c.Query("job_id"), 10, 32)
+
+	pipeline, err := h.svc.Resolve(companyID, uint(jobID))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	if pipeline.CompanyID != companyID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pipeline not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToPipelineResponse(pipeline)})
+}
+
+// GetPipeline godoc
+// @Summary      Obtener pipeline
+// @Tags         Pipelines
+// @Produce      json
+// @Param        id   path      int  true  "ID del pipeline"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /pipelines/{id} [get]
+func (h *PipelineHandler) GetPipeline(c *gin.Context) {
+	id, companyID, ok := pipelineTarget(c)
+	if !ok {
+		return
+	}
+
+	pipeline, err := h.svc.Get(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToPipelineResponse(pipeline)})
+}
+
+// CreatePipeline godoc
+// @Summary      Crear pipeline para una vacante
+// @Description  La vacante deja de usar el pipeline de la empresa. Sin stages se copian las de la empresa.
+// @Tags         Pipelines
+// @Accept       json
+// @Produce      json
+// @Param        pipeline  body      dtos.CreatePipelineDTO  true  "Pipeline"
+// @Success      201       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /pipelines [post]
+func (h *PipelineHandler) CreatePipeline(c *gin.Context) {
+	var dto dtos.CreatePipelineDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+
+	pipeline, err := h.svc.CreateForJob(companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": dtos.ToPipelineResponse(pipeline)})
+}
+
+// UpdatePipeline godoc
+// @Summary      Reemplazar etapas de un pipeline
+// @Description  El orden del array define el orden de las etapas. Requiere al menos una etapa active y exactamente una hired y una rejected. No se pueden quitar etapas con postulaciones.
+// @Tags         Pipelines
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                     true  "ID del pipeline"
+// @Param        pipeline  body      dtos.UpdatePipelineDTO  true  "Etapas"
+// @Success      200       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /pipelines/{id} [put]
+func (h *PipelineHandler) UpdatePipeline(c *gin.Context) {
+	id, companyID, ok := pipelineTarget(c)
+	if !ok {
+		return
+	}
+
+	var dto dtos.UpdatePipelineDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pipeline, err := h.svc.Update(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToPipelineResponse(pipeline)})
+}
+
+// DeletePipeline godoc
+// @Summary      Eliminar pipeline de una vacante
+// @Description  La vacante vuelve a usar el pipeline de la empresa. El pipeline por defecto no se puede eliminar.
+// @Tags         Pipelines
+// @Produce      json
+// @Param        id   path      int  true  "ID del pipeline"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /pipelines/{id} [delete]
+func (h *PipelineHandler) DeletePipeline(c *gin.Context) {
+	id, companyID, ok := pipelineTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(id, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Pipeline deleted"})
+}
+
+// pipelineTarget resuelve el :id de la ruta y el tenant.
+func pipelineTarget(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -0,0 +1,24 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/pipeline/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.PipelineService) {
+	h := NewPipelineHandler(svc)
+
+	pipelines := rg.Group("/pipelines")
+	{
+		pipelines.GET("", middleware.RequirePermission(permissions.PipelinesView), h.GetPipelines)
+		pipelines.GET("/resolve", middleware.RequirePermission(permissions.PipelinesView), h.ResolvePipeline)
+		pipelines.POST("", middleware.RequirePermission(permissions.PipelinesManage), h.CreatePipeline)
+		pipelines.GET("/:id", middleware.RequirePermission(permissions.PipelinesView), h.GetPipeline)
+		pipelines.PUT("/:id", middleware.RequirePermission(permissions.PipelinesManage), h.UpdatePipeline)
+		pipelines.DELETE("/:id", middleware.RequirePermission(permissions.PipelinesManage), h.DeletePipeline)
+	}
+}
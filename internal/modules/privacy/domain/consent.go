@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// Finalidades de un consentimiento (RN-GDPR-001).
+const (
+	PurposeApplication = "application" // tratar los datos para la postulación
+	PurposeTalentPool  = "talent_pool" // conservarlos y contactarlo por futuras vacantes
+)
+
+// Cómo se obtuvo el consentimiento.
+const (
+	MethodCareerPage = "career_page" // casilla marcada por el candidato al postular
+	MethodRecruiter  = "recruiter"   // registrado por el equipo (p. ej. lo dio por email)
+)
+
+// PlatformNoticeVersion identifica el aviso de plataforma (PlatformSettings.PrivacyURL),
+// vigente mientras la empresa no publique uno propio.
+const PlatformNoticeVersion = 0
+
+// Notice es el aviso de privacidad vigente que se muestra al candidato.
+type Notice struct {
+	ID          *uint      `json:"id,omitempty"`
+	Version     int        `json:"version"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Source      string     `json:"source"` // company, platform
+}
+
+// FromNotice convierte una versión publicada en el aviso vigente.
+func FromNotice(n *models.PrivacyNotice) Notice {
+	id := n.ID
+	publishedAt := n.PublishedAt
+	return Notice{
+		ID:          &id,
+		Version:     n.Version,
+		Title:       n.Title,
+		Content:     n.Content,
+		URL:         n.URL,
+		PublishedAt: &publishedAt,
+		Source:      "company",
+	}
+}
+
+// PlatformNotice construye el aviso de respaldo a partir de la URL de plataforma.
+func PlatformNotice(url string) Notice {
+	return Notice{Version: PlatformNoticeVersion, Title: "Privacy Policy", URL: url, Source: "platform"}
+}
+
+// NoticeRepository es el puerto de salida hacia los avisos de privacidad.
+type NoticeRepository interface {
+	// Current devuelve la versión vigente de la empresa, o nil si no tiene.
+	Current(companyID uint) (*models.PrivacyNotice, error)
+	List(companyID uint) ([]models.PrivacyNotice, error)
+	// Create asigna la siguiente versión de la empresa y guarda el aviso.
+	Create(notice *models.PrivacyNotice) error
+	PlatformPrivacyURL() (string, error)
+	CompanyIDBySlug(slug string) (uint, error)
+}
+
+// ConsentRepository es el puerto de salida hacia los consentimientos.
+type ConsentRepository interface {
+	// Create guarda los consentimientos y, si alguno es de talent pool,
+	// marca al candidato como contactable (en una transacción).
+	Create(consents []models.CandidateConsent) error
+	// Revoke revoca los consentimientos vigentes de la finalidad; en talent
+	// pool además deja al candidato fuera del sourcing.
+	Revoke(candidateID uint, purpose string, at time.Time) (int64, error)
+	ListByCandidate(candidateID uint) ([]models.CandidateConsent, error)
+	// FindCandidate busca el candidato; companyID 0 = sin filtro de tenant.
+	FindCandidate(id, companyID uint) (*models.Candidate, error)
+}
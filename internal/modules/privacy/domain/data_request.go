@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+)
+
+// Tipos de solicitud del titular de datos.
+const (
+	RequestAccess  = "access"  // RN-GDPR-003: export JSON de todo lo que se guarda
+	RequestErasure = "erasure" // RN-GDPR-002: anonimizar la PII
+)
+
+// Estados de una solicitud.
+const (
+	RequestPending   = "pending"
+	RequestCompleted = "completed"
+	RequestCancelled = "cancelled"
+)
+
+// RequestDeadlineDays es el plazo legal para responder (RN-GDPR-002).
+const RequestDeadlineDays = 30
+
+// IsValidRequestType reporta si el tipo de solicitud existe.
+func IsValidRequestType(t string) bool {
+	return t == RequestAccess || t == RequestErasure
+}
+
+// NormalizeEmail unifica el email para buscar y hashear (minúsculas, sin espacios).
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// HashEmail devuelve el sha256 hex del email normalizado.
+func HashEmail(email string) string {
+	sum := sha256.Sum256([]byte(NormalizeEmail(email)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MaskEmail deja solo la primera letra del usuario y el dominio: "j***@acme.com".
+func MaskEmail(email string) string {
+	email = NormalizeEmail(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// DueDate calcula el vencimiento legal desde la recepción.
+func DueDate(receivedAt time.Time) time.Time {
+	return receivedAt.AddDate(0, 0, RequestDeadlineDays)
+}
+
+// SubjectRecords lista los registros del titular que procesó una solicitud.
+type SubjectRecords struct {
+	CandidateIDs   []uint `json:"candidate_ids"`
+	ApplicationIDs []uint `json:"application_ids"`
+	PlacementIDs   []uint `json:"placement_ids"`
+	CompanyIDs     []uint `json:"company_ids"`
+}
+
+// RequestProof es la evidencia que se guarda al completar una solicitud.
+type RequestProof struct {
+	Type        string    `json:"type"`
+	EmailHash   string    `json:"email_hash"`
+	CompletedAt time.Time `json:"completed_at"`
+	SubjectRecords
+	ExportSHA256 string `json:"export_sha256,omitempty"` // solo access
+}
+
+// DataRequestFilters filtra el listado de solicitudes.
+type DataRequestFilters struct {
+	Type   string
+	Status string
+}
+
+// DataRequestRepository es el puerto de salida hacia las solicitudes.
+type DataRequestRepository interface {
+	Create(req *models.DataSubjectRequest) error
+	// FindByID busca la solicitud; companyID 0 = sin filtro de tenant.
+	FindByID(id, companyID uint) (*models.DataSubjectRequest, error)
+	// List devuelve las solicitudes; companyID 0 = todas (SuperAdmin).
+	List(companyID uint, filters DataRequestFilters) ([]models.DataSubjectRequest, error)
+	Update(req *models.DataSubjectRequest) error
+}
+
+// SubjectRepository es el puerto de salida hacia los datos del titular.
+// companyID nil = todas las empresas. Incluye registros en la papelera: siguen
+// conteniendo PII.
+type SubjectRepository interface {
+	// FindCandidates devuelve los candidatos con el email, con sus
+	// postulaciones (y job) y consentimientos precargados.
+	FindCandidates(email string, companyID *uint) ([]models.Candidate, error)
+	// FindRecords devuelve el resto de lo que se guarda de esos candidatos y
+	// sus postulaciones: colocaciones, comentarios, evaluaciones, ofertas, etc.
+	FindRecords(candidates []models.Candidate) (*dtos.DataSubjectRecords, error)
+	// Anonymize borra la PII de los candidatos y de sus postulaciones y
+	// colocaciones, conservando los registros para las métricas agregadas.
+	// En la misma transacción llama a complete con lo anonimizado (cierra la
+	// solicitud req) y guarda req: o quedan ambos o ninguno. Devuelve los
+	// archivos que quedaron sin registro (rutas relativas al directorio de
+	// uploads) para borrarlos tras el commit.
+	Anonymize(candidateIDs []uint, req *models.DataSubjectRequest, complete func(*SubjectRecords) error) ([]string, error)
+}
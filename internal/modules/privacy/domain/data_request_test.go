@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashEmailIgnoraMayusculasYEspacios(t *testing.T) {
+	want := HashEmail("ana@acme.com")
+	for _, email := range []string{"Ana@Acme.com", "  ana@acme.com ", "ANA@ACME.COM"} {
+		if got := HashEmail(email); got != want {
+			t.Errorf("HashEmail(%q) = %s, se esperaba %s", email, got, want)
+		}
+	}
+	if HashEmail("ana@acme.co") == want {
+		t.Error("emails distintos no deberían compartir hash")
+	}
+	if len(want) != 64 {
+		t.Errorf("el hash debería ser sha256 hex: %s", want)
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	cases := map[string]string{
+		"Juan.Perez@Acme.com": "j***@acme.com",
+		"a@b.io":              "a***@b.io",
+		"sin-arroba":          "***",
+		"@acme.com":           "***",
+		"":                    "***",
+	}
+	for email, want := range cases {
+		if got := MaskEmail(email); got != want {
+			t.Errorf("MaskEmail(%q) = %q, se esperaba %q", email, got, want)
+		}
+	}
+}
+
+func TestDueDateSumaElPlazoLegal(t *testing.T) {
+	received := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	if got, want := DueDate(received), time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("DueDate = %s, se esperaba %s", got, want)
+	}
+}
+
+func TestIsValidRequestType(t *testing.T) {
+	for _, tc := range []struct {
+		t    string
+		want bool
+	}{{RequestAccess, true}, {RequestErasure, true}, {"rectification", false}, {"", false}} {
+		if got := IsValidRequestType(tc.t); got != tc.want {
+			t.Errorf("IsValidRequestType(%q) = %v", tc.t, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Package domain define el centro del módulo privacy (cumplimiento GDPR/LGPD y
+// Habeas Data): reglas de retención, anonimización y los puertos hacia la
+// persistencia. No importa gin ni gorm.
+package domain
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+// Reglas de retención (§6.7 adaptado al ATS).
+const (
+	// RuleRejectedApplications: postulaciones en 'rejected' con rejected_at anterior al corte.
+	RuleRejectedApplications = "rejected_applications"
+	// RuleInactiveCandidates: candidatos sin actividad (ni propia ni de sus postulaciones) desde el corte.
+	RuleInactiveCandidates = "inactive_candidates"
+	// RuleProspectClients: clientes finales en estado 'prospect' sin cambios desde el corte.
+	RuleProspectClients = "prospect_staffing_clients"
+)
+
+// Acciones al vencer la retención.
+const (
+	ActionAnonymize = "anonymize" // borra la PII, conserva el registro para métricas
+	ActionDelete    = "delete"    // soft delete (queda en la papelera hasta purgarse)
+)
+
+// Rules es el orden en que se listan y aplican las reglas.
+var Rules = []string{RuleRejectedApplications, RuleInactiveCandidates, RuleProspectClients}
+
+// allowedActions restringe las acciones por regla: un prospecto no tiene PII
+// de candidato que anonimizar, así que solo se elimina.
+var allowedActions = map[string][]string{
+	RuleRejectedApplications: {ActionAnonymize, ActionDelete},
+	RuleInactiveCandidates:   {ActionAnonymize, ActionDelete},
+	RuleProspectClients:      {ActionDelete},
+}
+
+// IsValidRule reporta si la regla existe.
+func IsValidRule(rule string) bool {
+	_, ok := allowedActions[rule]
+	return ok
+}
+
+// IsAllowedAction reporta si la acción es válida para la regla.
+func IsAllowedAction(rule, action string) bool {
+	for _, a := range allowedActions[rule] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectivePolicies combina las políticas de plataforma con las de empresa:
+// para cada regla gana la de empresa si existe. El resultado sigue el orden de
+// Rules y omite las reglas sin ninguna política.
+func EffectivePolicies(platform, company []models.RetentionPolicy) []models.RetentionPolicy {
+	byRule := make(map[string]models.RetentionPolicy)
+	for _, p := range platform {
+		byRule[p.Rule] = p
+	}
+	for _, p := range company {
+		byRule[p.Rule] = p
+	}
+
+	result := make([]models.RetentionPolicy, 0, len(byRule))
+	for _, rule := range Rules {
+		if p, ok := byRule[rule]; ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Cutoff calcula la fecha de corte de una política: lo anterior a ella vence.
+// Si el mes de destino es más corto, el día se ajusta a su último día (31 de
+// marzo menos un mes = 28/29 de febrero, no 3 de marzo como con AddDate).
+func Cutoff(now time.Time, months int) time.Time {
+	y, m, d := now.Date()
+	first := time.Date(y, m-time.Month(months), 1, 0, 0, 0, 0, now.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), now.Location())
+}
+
+// PolicyRepository es el puerto de salida hacia las políticas configuradas.
+type PolicyRepository interface {
+	// GetByCompany devuelve las políticas de la empresa; companyID nil = plataforma.
+	GetByCompany(companyID *uint) ([]models.RetentionPolicy, error)
+	GetByRule(companyID *uint, rule string) (*models.RetentionPolicy, error)
+	Save(policy *models.RetentionPolicy) (*models.RetentionPolicy, error)
+	Delete(id uint) error
+}
+
+// EnforcementRepository es el puerto de salida que localiza y procesa los
+// registros vencidos de cada regla.
+type EnforcementRepository interface {
+	// CompanyIDs devuelve las empresas activas sobre las que corre el barrido.
+	CompanyIDs() ([]uint, error)
+	// FindExpired devuelve los IDs vencidos de la regla para la empresa.
+	FindExpired(companyID uint, rule, action string, cutoff time.Time) ([]uint, error)
+	// Apply ejecuta la acción sobre los IDs (en una transacción) y devuelve
+	// los archivos que quedaron sin registro, como SubjectRepository.Anonymize.
+	Apply(rule, action string, ids []uint) ([]string, error)
+	CreateRun(run *models.RetentionRun) error
+	GetRuns(companyID uint, limit int) ([]models.RetentionRun, error)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+func policy(companyID *uint, rule string, months int, action string) models.RetentionPolicy {
+	return models.RetentionPolicy{CompanyID: companyID, Rule: rule, RetentionMonths: months, Action: action}
+}
+
+func TestEffectivePoliciesEmpresaReemplazaPlataforma(t *testing.T) {
+	company := uint(7)
+	platform := []models.RetentionPolicy{
+		policy(nil, RuleProspectClients, 12, ActionDelete),
+		policy(nil, RuleRejectedApplications, 24, ActionAnonymize),
+		policy(nil, RuleInactiveCandidates, 36, ActionAnonymize),
+	}
+	own := []models.RetentionPolicy{
+		policy(&company, RuleInactiveCandidates, 18, ActionDelete),
+	}
+
+	got := EffectivePolicies(platform, own)
+
+	want := []struct {
+		rule    string
+		months  int
+		action  string
+		company bool
+	}{
+		{RuleRejectedApplications, 24, ActionAnonymize, false},
+		{RuleInactiveCandidates, 18, ActionDelete, true},
+		{RuleProspectClients, 12, ActionDelete, false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("políticas = %+v", got)
+	}
+	for i, w := range want {
+		p := got[i]
+		if p.Rule != w.rule || p.RetentionMonths != w.months || p.Action != w.action || (p.CompanyID != nil) != w.company {
+			t.Errorf("posición %d = %+v, se esperaba %+v", i, p, w)
+		}
+	}
+}
+
+func TestEffectivePoliciesOmiteReglasSinPolitica(t *testing.T) {
+	company := uint(7)
+	got := EffectivePolicies(nil, []models.RetentionPolicy{policy(&company, RuleProspectClients, 6, ActionDelete)})
+	if len(got) != 1 || got[0].Rule != RuleProspectClients {
+		t.Errorf("políticas = %+v", got)
+	}
+	if got := EffectivePolicies(nil, nil); len(got) != 0 {
+		t.Errorf("sin políticas se esperaba vacío: %+v", got)
+	}
+}
+
+func TestIsAllowedAction(t *testing.T) {
+	cases := []struct {
+		rule, action string
+		want         bool
+	}{
+		{RuleRejectedApplications, ActionAnonymize, true},
+		{RuleRejectedApplications, ActionDelete, true},
+		{RuleInactiveCandidates, ActionAnonymize, true},
+		{RuleInactiveCandidates, ActionDelete, true},
+		// Un prospecto no tiene PII de candidato: solo se elimina.
+		{RuleProspectClients, ActionDelete, true},
+		{RuleProspectClients, ActionAnonymize, false},
+		{RuleRejectedApplications, "archive", false},
+		{"unknown_rule", ActionDelete, false},
+	}
+	for _, tc := range cases {
+		if got := IsAllowedAction(tc.rule, tc.action); got != tc.want {
+			t.Errorf("IsAllowedAction(%s, %s) = %v, se esperaba %v", tc.rule, tc.action, got, tc.want)
+		}
+	}
+}
+
+func TestCutoffAjustaFinDeMes(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 3, 30, 0, 0, time.UTC) }
+	cases := []struct {
+		name   string
+		now    time.Time
+		months int
+		want   time.Time
+	}{
+		{"mismo día", date(2026, 8, 15), 6, date(2026, 2, 15)},
+		{"31 de marzo a febrero", date(2026, 3, 31), 1, date(2026, 2, 28)},
+		{"febrero bisiesto", date(2028, 3, 31), 1, date(2028, 2, 29)},
+		{"31 a mes de 30 días", date(2026, 5, 31), 1, date(2026, 4, 30)},
+		{"cruza el año", date(2026, 1, 31), 2, date(2025, 11, 30)},
+		{"doce meses", date(2028, 2, 29), 12, date(2027, 2, 28)},
+		{"desde fin de febrero", date(2026, 2, 28), 1, date(2026, 1, 28)},
+		{"sin meses", date(2026, 3, 31), 0, date(2026, 3, 31)},
+	}
+	for _, tc := range cases {
+		if got := Cutoff(tc.now, tc.months); !got.Equal(tc.want) {
+			t.Errorf("%s: Cutoff(%s, %d) = %s, se esperaba %s", tc.name, tc.now.Format(time.DateOnly), tc.months, got, tc.want)
+		}
+	}
+}
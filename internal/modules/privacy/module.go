@@ -0,0 +1,73 @@
+// Package privacy es el punto de ensamblaje del módulo de cumplimiento
+// (GDPR/LGPD, Habeas Data): políticas de retención y su tarea programada, y
+// solicitudes del titular de datos (acceso y borrado), avisos de privacidad
+// versionados y consentimientos.
+// Nadie importa este paquete salvo el composition root.
+package privacy
+
+import (
+	"time"
+
+	"dvra-api/internal/modules/privacy/repository"
+	"dvra-api/internal/modules/privacy/service"
+	"dvra-api/internal/modules/privacy/transport"
+	"dvra-api/internal/platform/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// retentionInterval es la frecuencia del barrido de retención.
+const retentionInterval = 24 * time.Hour
+
+// Module agrupa las dependencias ya cableadas del módulo privacy.
+type Module struct {
+	retentionSvc   *service.RetentionService
+	dataRequestSvc *service.DataRequestService
+
+	// ConsentService se expone para que PublicService registre el
+	// consentimiento al postular (puerto definido por el consumidor).
+	ConsentService *service.ConsentService
+}
+
+// New construye el módulo. removeFiles borra del almacenamiento los archivos
+// (CVs, adjuntos, PDFs) de los registros anonimizados.
+func New(db *gorm.DB, removeFiles service.FileRemover) *Module {
+	return &Module{
+		retentionSvc: service.NewRetentionService(
+			repository.NewPolicyRepository(db),
+			repository.NewEnforcementRepository(db),
+			removeFiles,
+		),
+		dataRequestSvc: service.NewDataRequestService(
+			repository.NewDataRequestRepository(db),
+			repository.NewSubjectRepository(db),
+			removeFiles,
+		),
+		ConsentService: service.NewConsentService(
+			repository.NewNoticeRepository(db),
+			repository.NewConsentRepository(db),
+		),
+	}
+}
+
+// ConsentServiceTx devuelve un ConsentService que escribe en la transacción
+// tx (la career page guarda el consentimiento junto con la postulación).
+func (m *Module) ConsentServiceTx(tx *gorm.DB) *service.ConsentService {
+	return service.NewConsentService(repository.NewNoticeRepository(tx), repository.NewConsentRepository(tx))
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.retentionSvc, m.dataRequestSvc, m.ConsentService)
+}
+
+// RegisterPublicRoutes monta las rutas públicas (career page).
+func (m *Module) RegisterPublicRoutes(public *gin.RouterGroup) {
+	transport.RegisterPublicRoutes(public, m.ConsentService)
+}
+
+// RegisterJobs registra las tareas periódicas del módulo.
+func (m *Module) RegisterJobs(s *scheduler.Scheduler) {
+	s.Add(scheduler.Job{Name: "privacy.retention", Interval: retentionInterval, Run: m.retentionSvc.EnforceAll})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+
+	"gorm.io/gorm"
+)
+
+type consentRepository struct {
+	db *gorm.DB
+}
+
+// NewConsentRepository devuelve la implementación del puerto.
+func NewConsentRepository(db *gorm.DB) domain.ConsentRepository {
+	return &consentRepository{db: db}
+}
+
+func (r *consentRepository) Create(consents []models.CandidateConsent) error {
+	if len(consents) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&consents).Error; err != nil {
+			return err
+		}
+		for _, c := range consents {
+			if c.Purpose != domain.PurposeTalentPool {
+				continue
+			}
+			if err := tx.Model(&models.Candidate{}).
+				Where("id = ?", c.CandidateID).
+				Update("talent_pool_consent_at", c.GivenAt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *consentRepository) Revoke(candidateID uint, purpose string, at time.Time) (int64, error) {
+	var revoked int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.CandidateConsent{}).
+			Where("candidate_id = ? AND purpose = ? AND revoked_at IS NULL", candidateID, purpose).
+			Update("revoked_at", at)
+		if res.Error != nil {
+			return res.Error
+		}
+		revoked = res.RowsAffected
+
+		if purpose == domain.PurposeTalentPool {
+			return tx.Model(&models.Candidate{}).
+				Where("id = ?", candidateID).
+				Update("talent_pool_consent_at", nil).Error
+		}
+		return nil
+	})
+	return revoked, err
+}
+
+func (r *consentRepository) ListByCandidate(candidateID uint) ([]models.CandidateConsent, error) {
+	var consents []models.CandidateConsent
+	if err := r.db.Where("candidate_id = ?", candidateID).Order("given_at DESC, id DESC").Find(&consents).Error; err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+func (r *consentRepository) FindCandidate(id, companyID uint) (*models.Candidate, error) {
+	var candidate models.Candidate
+	query := r.db.Where("id = ?", id)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if err := query.First(&candidate).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &candidate, nil
+}
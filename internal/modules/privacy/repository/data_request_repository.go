@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+
+	"gorm.io/gorm"
+)
+
+type dataRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewDataRequestRepository devuelve la implementación del puerto.
+func NewDataRequestRepository(db *gorm.DB) domain.DataRequestRepository {
+	return &dataRequestRepository{db: db}
+}
+
+func (r *dataRequestRepository) Create(req *models.DataSubjectRequest) error {
+	return r.db.Create(req).Error
+}
+
+func (r *dataRequestRepository) FindByID(id, companyID uint) (*models.DataSubjectRequest, error) {
+	var req models.DataSubjectRequest
+	query := r.db.Where("id = ?", id)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if err := query.First(&req).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *dataRequestRepository) List(companyID uint, filters domain.DataRequestFilters) ([]models.DataSubjectRequest, error) {
+	var reqs []models.DataSubjectRequest
+	query := r.db.Model(&models.DataSubjectRequest{})
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if filters.Type != "" {
+		query = query.Where("type = ?", filters.Type)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if err := query.Order("due_at ASC, id ASC").Find(&reqs).Error; err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func (r *dataRequestRepository) Update(req *models.DataSubjectRequest) error {
+	return r.db.Save(req).Error
+}
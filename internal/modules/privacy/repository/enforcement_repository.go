@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+
+	"gorm.io/gorm"
+)
+
+type enforcementRepository struct {
+	db *gorm.DB
+}
+
+// NewEnforcementRepository devuelve la implementación del puerto.
+func NewEnforcementRepository(db *gorm.DB) domain.EnforcementRepository {
+	return &enforcementRepository{db: db}
+}
+
+func (r *enforcementRepository) CompanyIDs() ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&models.Company{}).Order("id ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *enforcementRepository) FindExpired(companyID uint, rule, action string, cutoff time.Time) ([]uint, error) {
+	var ids []uint
+	var query *gorm.DB
+
+	switch rule {
+	case domain.RuleRejectedApplications:
+		// Rechazada = en una etapa de tipo rejected de cualquier pipeline de la empresa.
+		rejectedStages := r.db.Table("pipeline_stages").
+			Select("pipeline_stages.key").
+			Joins("JOIN pipelines ON pipelines.id = pipeline_stages.pipeline_id AND pipelines.deleted_at IS NULL").
+			Where("pipelines.company_id = ? AND pipeline_stages.type = ?", companyID, models.StageTypeRejected)
+		query = r.db.Model(&models.Application{}).
+			Where("company_id = ? AND stage IN (?) AND rejected_at < ?", companyID, rejectedStages, cutoff)
+		if action == domain.ActionAnonymize {
+			query = query.Where("anonymized_at IS NULL")
+		}
+
+	case domain.RuleInactiveCandidates:
+		// Sin actividad = ni el candidato ni ninguna de sus postulaciones se
+		// tocaron desde el corte. Un candidato con colocación activa es un
+		// empleado vigente: nunca vence.
+		query = r.db.Model(&models.Candidate{}).
+			Where("company_id = ? AND updated_at < ?", companyID, cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM applications a WHERE a.candidate_id = candidates.id AND a.deleted_at IS NULL AND a.updated_at >= ?)", cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM placements p WHERE p.candidate_id = candidates.id AND p.deleted_at IS NULL AND p.status = ?)", "active")
+		if action == domain.ActionAnonymize {
+			query = query.Where("anonymized_at IS NULL")
+		}
+
+	case domain.RuleProspectClients:
+		query = r.db.Model(&models.StaffingClient{}).
+			Where("company_id = ? AND status = ? AND updated_at < ?", companyID, "prospect", cutoff)
+
+	default:
+		return nil, nil
+	}
+
+	if err := query.Order("id ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *enforcementRepository) Apply(rule, action string, ids []uint) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var files []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		switch rule {
+		case domain.RuleRejectedApplications:
+			if action == domain.ActionDelete {
+				return tx.Delete(&models.Application{}, ids).Error
+			}
+			files, err = anonymizeApplications(tx, "id", ids)
+			return err
+
+		case domain.RuleInactiveCandidates:
+			if action == domain.ActionDelete {
+				if err := tx.Where("candidate_id IN ?", ids).Delete(&models.Application{}).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&models.Candidate{}, ids).Error
+			}
+			files, err = anonymizeCandidates(tx, ids)
+			return err
+
+		case domain.RuleProspectClients:
+			return tx.Delete(&models.StaffingClient{}, ids).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *enforcementRepository) CreateRun(run *models.RetentionRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *enforcementRepository) GetRuns(companyID uint, limit int) ([]models.RetentionRun, error) {
+	var runs []models.RetentionRun
+	if err := r.db.Where("company_id = ?", companyID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// anonymizeCandidates reemplaza la PII de los candidatos por valores neutros,
+// limpia las notas de sus postulaciones y colocaciones y la evidencia técnica
+// de sus consentimientos, los saca de los talent pools y borra sus CVs
+// (vigente y anteriores). Se conservan
+// company_id, source, stage y timestamps: las métricas agregadas (dashboard)
+// no cambian. Incluye registros en la papelera (Unscoped): siguen teniendo PII.
+// Devuelve los archivos que quedaron sin registro (rutas relativas al
+// directorio de uploads): el llamador los borra tras el commit.
+func anonymizeCandidates(tx *gorm.DB, ids []uint) ([]string, error) {
+	// Los CVs son el vigente y los anteriores, que solo quedan en sus
+	// análisis. El de un candidato fusionado puede haber pasado al
+	// sobreviviente: solo se borra si ningún otro candidato lo usa.
+	current := tx.Unscoped().Model(&models.Candidate{}).Select("resume_url AS url").Where("id IN ?", ids)
+	parsed := tx.Unscoped().Model(&models.ResumeParse{}).Select("resume_url AS url").Where("candidate_id IN ?", ids)
+	var resumes []string
+	if err := tx.Table("(? UNION ?) AS resumes", current, parsed).
+		Where("resumes.url <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM candidates o WHERE o.resume_url = resumes.url AND o.id NOT IN ?)", ids).
+		Where("NOT EXISTS (SELECT 1 FROM resume_parses o WHERE o.resume_url = resumes.url AND o.candidate_id NOT IN ?)", ids).
+		Pluck("resumes.url", &resumes).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := tx.Unscoped().Model(&models.Candidate{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"email":                  gorm.Expr("'anonymized+' || id || '@anonymized.invalid'"),
+			"first_name":             "Anonymized",
+			"last_name":              "",
+			"phone":                  "",
+			"resume_url":             "",
+			"resume_text":            "",
+			"github_url":             "",
+			"linkedin_url":           "",
+			"city_id":                nil,
+			"work_mode":              "",
+			"open_to_relocate":       false,
+			"salary_expectation":     nil,
+			"years_of_experience":    nil,
+			"talent_pool_consent_at": nil,
+			"anonymized_at":          now,
+		}).Error; err != nil {
+		return nil, err
+	}
+	// La prueba de consentimiento se conserva (fecha, versión del aviso), pero
+	// sin IP ni user agent y revocada: el titular ya no es contactable.
+	if err := tx.Model(&models.CandidateConsent{}).
+		Where("candidate_id IN ?", ids).
+		Updates(map[string]interface{}{
+			"ip_address": "",
+			"user_agent": "",
+			"revoked_at": gorm.Expr("COALESCE(revoked_at, ?)", now),
+		}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.Placement{}).
+		Where("candidate_id IN ?", ids).
+		Update("notes", "").Error; err != nil {
+		return nil, err
+	}
+	// Sin consentimiento no pueden estar en un pool, y la nota de por qué se
+	// agregaron habla de la persona.
+	if err := tx.Unscoped().Where("candidate_id IN ?", ids).Delete(&models.TalentPoolMember{}).Error; err != nil {
+		return nil, err
+	}
+	// El índice de búsqueda se deriva de la PII.
+	if err := tx.Unscoped().Where("candidate_id IN ?", ids).Delete(&models.CandidateSearchDocument{}).Error; err != nil {
+		return nil, err
+	}
+	// Los análisis de CV son datos de contacto y trayectoria de la persona,
+	// igual que las habilidades con sus años de experiencia.
+	if err := tx.Unscoped().Where("candidate_id IN ?", ids).Delete(&models.ResumeParse{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Where("candidate_id IN ?", ids).Delete(&models.CandidateSkill{}).Error; err != nil {
+		return nil, err
+	}
+	// Los contratos generados llevan los datos del candidato impresos.
+	placementIDs := tx.Unscoped().Model(&models.Placement{}).Select("id").Where("candidate_id IN ?", ids)
+	contracts, err := deleteDocuments(tx, "placement_id", placementIDs)
+	if err != nil {
+		return nil, err
+	}
+	candidateIDs := tx.Unscoped().Model(&models.Candidate{}).Select("id").Where("id IN ?", ids)
+	attachments, err := anonymizeComments(tx, models.CommentSubjectCandidate, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	appFiles, err := anonymizeApplications(tx, "candidate_id", ids)
+	if err != nil {
+		return nil, err
+	}
+	files := append(uploadPaths(resumes), contracts...)
+	files = append(files, attachments...)
+	return append(files, appFiles...), nil
+}
+
+// anonymizeApplications limpia el contenido libre de las postulaciones cuyo
+// column está en ids (notas, comentarios, motivos de cambios de etapa, el
+// texto de los scorecards, las respuestas de screening, los datos de
+// entrevistas y enlaces de autoagenda y las notas de las ofertas) y elimina
+// sus documentos generados, conservando stage, rating, puntajes, montos y
+// timestamps.
+// Devuelve los archivos que quedaron sin registro, como anonymizeCandidates.
+func anonymizeApplications(tx *gorm.DB, column string, ids []uint) ([]string, error) {
+	appIDs := tx.Unscoped().Model(&models.Application{}).Select("id").Where(column+" IN ?", ids)
+	files, err := anonymizeComments(tx, models.CommentSubjectApplication, appIDs)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := deleteDocuments(tx, "application_id", appIDs)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, documents...)
+	if err := tx.Model(&models.ApplicationStageEvent{}).
+		Where("application_id IN (?)", appIDs).
+		Update("reason", "").Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.Scorecard{}).
+		Where("application_id IN (?)", appIDs).
+		Updates(map[string]interface{}{
+			"summary": "",
+			"answers": gorm.Expr("'[]'::jsonb"),
+			"ratings": gorm.Expr("COALESCE((SELECT jsonb_agg(r - 'note') FROM jsonb_array_elements(ratings) AS r), '[]'::jsonb)"),
+		}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.ScreeningAnswer{}).
+		Where("application_id IN (?)", appIDs).
+		Update("answer", "").Error; err != nil {
+		return nil, err
+	}
+	// Título, lugar y enlace de una entrevista pueden nombrar a la persona o
+	// su dirección; un enlace de autoagenda abierto ya no debe poder usarse.
+	if err := tx.Unscoped().Model(&models.Interview{}).
+		Where("application_id IN (?)", appIDs).
+		Updates(map[string]interface{}{
+			"title":         "",
+			"location":      "",
+			"video_url":     "",
+			"cancel_reason": "",
+		}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.SchedulingLink{}).
+		Where("application_id IN (?)", appIDs).
+		Updates(map[string]interface{}{
+			"title":     "",
+			"location":  "",
+			"video_url": "",
+			"status": gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END",
+				models.SchedulingLinkOpen, models.SchedulingLinkRevoked),
+		}).Error; err != nil {
+		return nil, err
+	}
+	offerIDs := tx.Unscoped().Model(&models.Offer{}).Select("id").Where("application_id IN (?)", appIDs)
+	if err := tx.Unscoped().Model(&models.Offer{}).
+		Where("application_id IN (?)", appIDs).
+		Update("response_note", "").Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.OfferVersion{}).
+		Where("offer_id IN (?)", offerIDs).
+		Update("notes", "").Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.OfferApproval{}).
+		Where("offer_id IN (?)", offerIDs).
+		Update("comment", "").Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.Application{}).
+		Where(column+" IN ?", ids).
+		Updates(map[string]interface{}{
+			"notes":         "",
+			"anonymized_at": time.Now(),
+		}).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// anonymizeComments vacía los comentarios de los recursos de la subconsulta
+// subjectIDs y el extracto de sus notificaciones, y elimina el historial de
+// ediciones y los adjuntos. Los hilos se conservan sin contenido. Devuelve
+// las rutas de los archivos adjuntos eliminados.
+func anonymizeComments(tx *gorm.DB, subjectType string, subjectIDs *gorm.DB) ([]string, error) {
+	column := subjectType + "_id"
+	commentIDs := tx.Unscoped().Model(&models.Comment{}).Select("id").Where(column+" IN (?)", subjectIDs)
+	if err := tx.Unscoped().Where("comment_id IN (?)", commentIDs).Delete(&models.CommentRevision{}).Error; err != nil {
+		return nil, err
+	}
+	var attachments []string
+	if err := tx.Unscoped().Model(&models.CommentAttachment{}).
+		Where("comment_id IN (?)", commentIDs).
+		Pluck("storage_path", &attachments).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Where("comment_id IN (?)", commentIDs).Delete(&models.CommentAttachment{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.Notification{}).
+		Where("resource_type = ? AND resource_id IN (?)", subjectType, subjectIDs).
+		Update("body", "").Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.Comment{}).
+		Where(column+" IN (?)", subjectIDs).
+		Update("body", "").Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// deleteDocuments elimina los documentos generados cuyo column está en la
+// subconsulta ids y devuelve las rutas de sus PDFs.
+func deleteDocuments(tx *gorm.DB, column string, ids *gorm.DB) ([]string, error) {
+	var paths []string
+	if err := tx.Unscoped().Model(&models.Document{}).
+		Where(column+" IN (?)", ids).
+		Pluck("storage_path", &paths).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Where(column+" IN (?)", ids).Delete(&models.Document{}).Error; err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// uploadPaths traduce las URLs de archivos subidos ("/uploads/...") a su ruta
+// relativa en el almacenamiento; las de almacenamiento externo se descartan.
+func uploadPaths(urls []string) []string {
+	paths := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if path, ok := strings.CutPrefix(url, "/uploads/"); ok && path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
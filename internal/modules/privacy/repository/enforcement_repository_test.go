@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// recorder abre una conexión en modo DryRun que anota cada sentencia SQL en
+// el orden en que se arma, sin tocar una base.
+func recorder(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statements []string
+	record := func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}
+	cb := db.Callback()
+	for name, err := range map[string]error{
+		"query":  cb.Query().After("gorm:query").Register("test:record", record),
+		"update": cb.Update().After("gorm:update").Register("test:record", record),
+		"delete": cb.Delete().After("gorm:delete").Register("test:record", record),
+	} {
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	return db, &statements
+}
+
+// position devuelve el índice de la primera sentencia que contiene todos los
+// fragmentos; -1 si ninguna.
+func position(statements []string, fragments ...string) int {
+	for i, sql := range statements {
+		found := true
+		for _, f := range fragments {
+			if !strings.Contains(sql, f) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+// Las rutas de los archivos se leen antes de borrar o limpiar las filas que
+// las guardan: después del commit ya no habría de dónde sacarlas.
+func TestAnonymizeCandidatesLeeLosArchivosAntesDeBorrar(t *testing.T) {
+	db, statements := recorder(t)
+	if _, err := anonymizeCandidates(db, []uint{11}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		read, write []string
+	}{
+		{"CV", []string{"UNION", "FROM \"candidates\"", "FROM \"resume_parses\""}, []string{"UPDATE \"candidates\"", "resume_url"}},
+		{"CVs anteriores", []string{"UNION", "FROM \"resume_parses\""}, []string{"DELETE FROM \"resume_parses\""}},
+		{"adjuntos", []string{"SELECT", "storage_path", "FROM \"comment_attachments\""}, []string{"DELETE FROM \"comment_attachments\""}},
+		{"contratos", []string{"SELECT", "storage_path", "FROM \"documents\"", "placement_id"}, []string{"DELETE FROM \"documents\"", "placement_id"}},
+		{"documentos de postulaciones", []string{"SELECT", "storage_path", "FROM \"documents\"", "application_id"}, []string{"DELETE FROM \"documents\"", "application_id"}},
+	}
+	for _, tc := range cases {
+		read, write := position(*statements, tc.read...), position(*statements, tc.write...)
+		if read < 0 || write < 0 || read > write {
+			t.Errorf("%s: lectura en %d, escritura en %d\n%s", tc.name, read, write, strings.Join(*statements, "\n"))
+		}
+	}
+}
+
+// La anonimización limpia el texto libre de entrevistas, enlaces de
+// autoagenda y ofertas de las postulaciones del candidato.
+func TestAnonymizeCandidatesLimpiaEntrevistasYOfertas(t *testing.T) {
+	db, statements := recorder(t)
+	if _, err := anonymizeCandidates(db, []uint{11}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, fragments := range map[string][]string{
+		"entrevistas":         {"UPDATE \"interviews\"", "\"location\"=", "\"title\"=", "\"video_url\"="},
+		"enlaces":             {"UPDATE \"scheduling_links\"", "\"location\"=", "\"status\"=CASE WHEN status"},
+		"respuesta de oferta": {"UPDATE \"offers\"", "\"response_note\"="},
+		"versiones de oferta": {"UPDATE \"offer_versions\"", "\"notes\"="},
+		"aprobaciones":        {"UPDATE \"offer_approvals\"", "\"comment\"="},
+	} {
+		if position(*statements, fragments...) < 0 {
+			t.Errorf("%s: falta la sentencia\n%s", name, strings.Join(*statements, "\n"))
+		}
+	}
+}
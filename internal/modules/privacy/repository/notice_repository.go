@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type noticeRepository struct {
+	db *gorm.DB
+}
+
+// NewNoticeRepository devuelve la implementación del puerto.
+func NewNoticeRepository(db *gorm.DB) domain.NoticeRepository {
+	return &noticeRepository{db: db}
+}
+
+func (r *noticeRepository) Current(companyID uint) (*models.PrivacyNotice, error) {
+	var notice models.PrivacyNotice
+	if err := r.db.Where("company_id = ?", companyID).Order("version DESC").First(&notice).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notice, nil
+}
+
+func (r *noticeRepository) List(companyID uint) ([]models.PrivacyNotice, error) {
+	var notices []models.PrivacyNotice
+	if err := r.db.Where("company_id = ?", companyID).Order("version DESC").Find(&notices).Error; err != nil {
+		return nil, err
+	}
+	return notices, nil
+}
+
+func (r *noticeRepository) Create(notice *models.PrivacyNotice) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Bloquear la empresa serializa las publicaciones concurrentes: dos
+		// admins no pueden obtener el mismo número de versión.
+		var company models.Company
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").First(&company, notice.CompanyID).Error; err != nil {
+			return err
+		}
+
+		var last int
+		if err := tx.Model(&models.PrivacyNotice{}).Unscoped().
+			Where("company_id = ?", notice.CompanyID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		notice.Version = last + 1
+		return tx.Create(notice).Error
+	})
+}
+
+func (r *noticeRepository) PlatformPrivacyURL() (string, error) {
+	var settings models.PlatformSettings
+	if err := r.db.Select("privacy_url").First(&settings).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return settings.PrivacyURL, nil
+}
+
+func (r *noticeRepository) CompanyIDBySlug(slug string) (uint, error) {
+	var company models.Company
+	if err := r.db.Select("id").Where("slug = ?", slug).First(&company).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return company.ID, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+
+	"gorm.io/gorm"
+)
+
+type policyRepository struct {
+	db *gorm.DB
+}
+
+// NewPolicyRepository devuelve la implementación del puerto.
+func NewPolicyRepository(db *gorm.DB) domain.PolicyRepository {
+	return &policyRepository{db: db}
+}
+
+func (r *policyRepository) GetByCompany(companyID *uint) ([]models.RetentionPolicy, error) {
+	var policies []models.RetentionPolicy
+	if err := scopeCompany(r.db, companyID).Order("rule ASC").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *policyRepository) GetByRule(companyID *uint, rule string) (*models.RetentionPolicy, error) {
+	var policy models.RetentionPolicy
+	if err := scopeCompany(r.db, companyID).Where("rule = ?", rule).First(&policy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *policyRepository) Save(policy *models.RetentionPolicy) (*models.RetentionPolicy, error) {
+	if err := r.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (r *policyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.RetentionPolicy{}, id).Error
+}
+
+// scopeCompany filtra por empresa; nil selecciona las políticas de plataforma.
+func scopeCompany(db *gorm.DB, companyID *uint) *gorm.DB {
+	if companyID == nil {
+		return db.Where("company_id IS NULL")
+	}
+	return db.Where("company_id = ?", *companyID)
+}
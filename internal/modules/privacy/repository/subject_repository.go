@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+
+	"gorm.io/gorm"
+)
+
+type subjectRepository struct {
+	db *gorm.DB
+}
+
+// NewSubjectRepository devuelve la implementación del puerto.
+func NewSubjectRepository(db *gorm.DB) domain.SubjectRepository {
+	return &subjectRepository{db: db}
+}
+
+func (r *subjectRepository) FindCandidates(email string, companyID *uint) ([]models.Candidate, error) {
+	var candidates []models.Candidate
+	query := r.db.Unscoped().
+		Preload("Company").
+		Preload("Applications", func(db *gorm.DB) *gorm.DB { return db.Unscoped().Order("id ASC") }).
+		Preload("Applications.Job", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Consents", func(db *gorm.DB) *gorm.DB { return db.Order("given_at ASC") }).
+		Where("LOWER(email) = ?", domain.NormalizeEmail(email))
+	if companyID != nil {
+		query = query.Where("company_id = ?", *companyID)
+	}
+	if err := query.Order("id ASC").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *subjectRepository) FindRecords(candidates []models.Candidate) (*dtos.DataSubjectRecords, error) {
+	records := &dtos.DataSubjectRecords{}
+	if len(candidates) == 0 {
+		return records, nil
+	}
+	ids := candidateIDs(candidates)
+	var appIDs []uint
+	for _, c := range candidates {
+		for _, a := range c.Applications {
+			appIDs = append(appIDs, a.ID)
+		}
+	}
+
+	// find carga dest con las filas cuyo column está en values, papelera incluida.
+	find := func(dest interface{}, column string, values []uint, preloads ...string) error {
+		query := r.db.Unscoped()
+		for _, p := range preloads {
+			query = query.Preload(p, func(db *gorm.DB) *gorm.DB { return db.Unscoped().Order("id ASC") })
+		}
+		return query.Where(column+" IN ?", values).Order("id ASC").Find(dest).Error
+	}
+
+	if err := find(&records.Placements, "candidate_id", ids, "StaffingClient"); err != nil {
+		return nil, err
+	}
+	if err := find(&records.Tags, "candidate_id", ids, "Tag"); err != nil {
+		return nil, err
+	}
+	if err := find(&records.Skills, "candidate_id", ids, "Skill"); err != nil {
+		return nil, err
+	}
+	if err := find(&records.PoolMemberships, "candidate_id", ids); err != nil {
+		return nil, err
+	}
+	if err := find(&records.ResumeParses, "candidate_id", ids); err != nil {
+		return nil, err
+	}
+	if err := find(&records.StageEvents, "application_id", appIDs); err != nil {
+		return nil, err
+	}
+	if err := find(&records.ScreeningAnswers, "application_id", appIDs); err != nil {
+		return nil, err
+	}
+	if err := find(&records.Scorecards, "application_id", appIDs); err != nil {
+		return nil, err
+	}
+	if err := find(&records.Interviews, "application_id", appIDs); err != nil {
+		return nil, err
+	}
+	if err := find(&records.Offers, "application_id", appIDs, "Versions"); err != nil {
+		return nil, err
+	}
+
+	// Comentarios del perfil y de sus postulaciones; documentos de sus
+	// postulaciones y colocaciones. GORM expande un IN vacío a IN (NULL).
+	placementIDs := make([]uint, len(records.Placements))
+	for i, p := range records.Placements {
+		placementIDs[i] = p.ID
+	}
+	if err := r.db.Unscoped().
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Unscoped().Order("id ASC") }).
+		Where("candidate_id IN ? OR application_id IN ?", ids, appIDs).
+		Order("id ASC").Find(&records.Comments).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Unscoped().
+		Where("application_id IN ? OR placement_id IN ?", appIDs, placementIDs).
+		Order("id ASC").Find(&records.Documents).Error; err != nil {
+		return nil, err
+	}
+	commentIDs := make([]uint, len(records.Comments))
+	for i, c := range records.Comments {
+		commentIDs[i] = c.ID
+	}
+	if err := find(&records.CommentRevisions, "comment_id", commentIDs); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *subjectRepository) Anonymize(ids []uint, req *models.DataSubjectRequest, complete func(*domain.SubjectRecords) error) ([]string, error) {
+	result := &domain.SubjectRecords{CandidateIDs: ids, ApplicationIDs: []uint{}, PlacementIDs: []uint{}, CompanyIDs: []uint{}}
+	var files []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(ids) > 0 {
+			if err := tx.Unscoped().Model(&models.Candidate{}).
+				Where("id IN ?", ids).
+				Distinct().Order("company_id ASC").
+				Pluck("company_id", &result.CompanyIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Model(&models.Application{}).
+				Where("candidate_id IN ?", ids).Order("id ASC").
+				Pluck("id", &result.ApplicationIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Model(&models.Placement{}).
+				Where("candidate_id IN ?", ids).Order("id ASC").
+				Pluck("id", &result.PlacementIDs).Error; err != nil {
+				return err
+			}
+			var err error
+			if files, err = anonymizeCandidates(tx, ids); err != nil {
+				return err
+			}
+		}
+		if err := complete(result); err != nil {
+			return err
+		}
+		return tx.Save(req).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func candidateIDs(candidates []models.Candidate) []uint {
+	ids := make([]uint, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ID
+	}
+	return ids
+}
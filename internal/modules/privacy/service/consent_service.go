@@ -0,0 +1,197 @@
+package service
+
+import (
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// ConsentService gestiona los avisos de privacidad versionados por empresa y
+// la evidencia de consentimiento de los candidatos (RN-GDPR-001).
+type ConsentService struct {
+	notices  domain.NoticeRepository
+	consents domain.ConsentRepository
+}
+
+func NewConsentService(notices domain.NoticeRepository, consents domain.ConsentRepository) *ConsentService {
+	return &ConsentService{notices: notices, consents: consents}
+}
+
+// CurrentNotice devuelve el aviso vigente de la empresa o, si no publicó
+// ninguno, el de plataforma (PlatformSettings.PrivacyURL).
+func (s *ConsentService) CurrentNotice(companyID uint) (domain.Notice, error) {
+	notice, err := s.notices.Current(companyID)
+	if err != nil {
+		return domain.Notice{}, err
+	}
+	if notice != nil {
+		return domain.FromNotice(notice), nil
+	}
+
+	url, err := s.notices.PlatformPrivacyURL()
+	if err != nil {
+		return domain.Notice{}, err
+	}
+	return domain.PlatformNotice(url), nil
+}
+
+// PublicNotice resuelve el aviso vigente por slug (career page).
+func (s *ConsentService) PublicNotice(slug string) (domain.Notice, error) {
+	companyID, err := s.notices.CompanyIDBySlug(slug)
+	if err != nil {
+		return domain.Notice{}, err
+	}
+	if companyID == 0 {
+		return domain.Notice{}, apperr.NotFound("company not found")
+	}
+	return s.CurrentNotice(companyID)
+}
+
+// ListNotices devuelve todas las versiones de la empresa, la vigente primero.
+func (s *ConsentService) ListNotices(companyID uint) ([]models.PrivacyNotice, error) {
+	return s.notices.List(companyID)
+}
+
+// PublishNotice crea una nueva versión, que pasa a ser la vigente. Las
+// anteriores no se modifican: son la referencia de los consentimientos dados.
+func (s *ConsentService) PublishNotice(companyID, userID uint, dto dtos.CreatePrivacyNoticeDTO) (*models.PrivacyNotice, error) {
+	if dto.Content == "" && dto.URL == "" {
+		return nil, apperr.BadRequest("privacy notice requires content or url")
+	}
+
+	notice := &models.PrivacyNotice{
+		CompanyID:   companyID,
+		Title:       dto.Title,
+		Content:     dto.Content,
+		URL:         dto.URL,
+		PublishedAt: time.Now(),
+		CreatedByID: userID,
+	}
+	if err := s.notices.Create(notice); err != nil {
+		return nil, err
+	}
+	return notice, nil
+}
+
+// ValidateNoticeVersion verifica que el candidato aceptó el aviso vigente.
+// version es obligatoria: sin ella no hay prueba de qué aviso se mostró.
+func (s *ConsentService) ValidateNoticeVersion(companyID uint, version *int) error {
+	if version == nil {
+		return apperr.BadRequest("notice_version is required")
+	}
+	current, err := s.CurrentNotice(companyID)
+	if err != nil {
+		return err
+	}
+	if *version != current.Version {
+		return apperr.Conflict("the privacy notice has changed, please review it and accept again")
+	}
+	return nil
+}
+
+// RecordApplicationConsent guarda la evidencia del consentimiento dado al
+// postular por la career page y, si lo marcó, el de talent pool.
+func (s *ConsentService) RecordApplicationConsent(companyID, candidateID, applicationID uint, talentPool bool, evidence dtos.ConsentEvidence) error {
+	notice, err := s.CurrentNotice(companyID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	base := models.CandidateConsent{
+		CompanyID:     companyID,
+		CandidateID:   candidateID,
+		ApplicationID: &applicationID,
+		Method:        domain.MethodCareerPage,
+		NoticeID:      notice.ID,
+		NoticeVersion: notice.Version,
+		NoticeURL:     notice.URL,
+		IPAddress:     evidence.IPAddress,
+		UserAgent:     evidence.UserAgent,
+		GivenAt:       now,
+	}
+
+	application := base
+	application.Purpose = domain.PurposeApplication
+	consents := []models.CandidateConsent{application}
+	if talentPool {
+		pool := base
+		pool.Purpose = domain.PurposeTalentPool
+		consents = append(consents, pool)
+	}
+	return s.consents.Create(consents)
+}
+
+// ListCandidateConsents devuelve el historial de consentimientos del candidato
+// validando el tenant (companyID 0 = SuperAdmin).
+func (s *ConsentService) ListCandidateConsents(candidateID, companyID uint) ([]models.CandidateConsent, error) {
+	if _, err := s.findCandidate(candidateID, companyID); err != nil {
+		return nil, err
+	}
+	return s.consents.ListByCandidate(candidateID)
+}
+
+// GrantTalentPool registra el consentimiento de talent pool obtenido fuera de
+// la career page (p. ej. por email), dejando constancia de quién lo registró.
+func (s *ConsentService) GrantTalentPool(candidateID, companyID, userID uint, evidence dtos.ConsentEvidence) (*models.CandidateConsent, error) {
+	candidate, err := s.findCandidate(candidateID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.AnonymizedAt != nil {
+		return nil, apperr.Conflict("candidate has been anonymized")
+	}
+	notice, err := s.CurrentNotice(candidate.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	consent := models.CandidateConsent{
+		CompanyID:     candidate.CompanyID,
+		CandidateID:   candidate.ID,
+		Purpose:       domain.PurposeTalentPool,
+		Method:        domain.MethodRecruiter,
+		NoticeID:      notice.ID,
+		NoticeVersion: notice.Version,
+		NoticeURL:     notice.URL,
+		IPAddress:     evidence.IPAddress,
+		UserAgent:     evidence.UserAgent,
+		GivenAt:       time.Now(),
+		RecordedByID:  &userID,
+	}
+	consents := []models.CandidateConsent{consent}
+	if err := s.consents.Create(consents); err != nil {
+		return nil, err
+	}
+	return &consents[0], nil
+}
+
+// RevokeTalentPool revoca el consentimiento de talent pool: el candidato sale
+// del sourcing pero conserva sus postulaciones en curso.
+func (s *ConsentService) RevokeTalentPool(candidateID, companyID uint) error {
+	if _, err := s.findCandidate(candidateID, companyID); err != nil {
+		return err
+	}
+	revoked, err := s.consents.Revoke(candidateID, domain.PurposeTalentPool, time.Now())
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return apperr.NotFound("candidate has no active talent pool consent")
+	}
+	return nil
+}
+
+func (s *ConsentService) findCandidate(candidateID, companyID uint) (*models.Candidate, error) {
+	candidate, err := s.consents.FindCandidate(candidateID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil {
+		return nil, apperr.NotFound("candidate not found")
+	}
+	return candidate, nil
+}
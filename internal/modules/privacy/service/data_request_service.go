@@ -0,0 +1,240 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// FileRemover borra archivos subidos por su ruta relativa al directorio de
+// uploads. Un archivo que ya no existe no es error.
+type FileRemover func(paths []string) error
+
+// DataRequestService gestiona las solicitudes del titular de datos
+// (RN-GDPR-002 derecho al olvido, RN-GDPR-003 portabilidad).
+type DataRequestService struct {
+	requests    domain.DataRequestRepository
+	subjects    domain.SubjectRepository
+	removeFiles FileRemover
+}
+
+func NewDataRequestService(requests domain.DataRequestRepository, subjects domain.SubjectRepository, removeFiles FileRemover) *DataRequestService {
+	return &DataRequestService{requests: requests, subjects: subjects, removeFiles: removeFiles}
+}
+
+// Create registra una solicitud. scope es la empresa sobre la que aplica; nil =
+// todas las empresas (el handler solo lo permite al SuperAdmin).
+func (s *DataRequestService) Create(scope *uint, userID uint, dto dtos.CreateDataSubjectRequestDTO) (*models.DataSubjectRequest, error) {
+	if !domain.IsValidRequestType(dto.Type) {
+		return nil, apperr.BadRequest("invalid request type")
+	}
+
+	receivedAt := time.Now()
+	if dto.ReceivedAt != nil {
+		if dto.ReceivedAt.After(receivedAt) {
+			return nil, apperr.BadRequest("received_at cannot be in the future")
+		}
+		receivedAt = *dto.ReceivedAt
+	}
+
+	req := &models.DataSubjectRequest{
+		CompanyID:        scope,
+		Type:             dto.Type,
+		Status:           domain.RequestPending,
+		SubjectEmail:     domain.NormalizeEmail(dto.Email),
+		SubjectEmailHash: domain.HashEmail(dto.Email),
+		Notes:            dto.Notes,
+		ReceivedAt:       receivedAt,
+		DueAt:            domain.DueDate(receivedAt),
+		RequestedByID:    userID,
+	}
+	if err := s.requests.Create(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// List devuelve las solicitudes (companyID 0 = todas, SuperAdmin), las más
+// próximas a vencer primero.
+func (s *DataRequestService) List(companyID uint, filters dtos.DataSubjectRequestFilters) ([]models.DataSubjectRequest, error) {
+	return s.requests.List(companyID, domain.DataRequestFilters{Type: filters.Type, Status: filters.Status})
+}
+
+// Get devuelve una solicitud validando el tenant (companyID 0 = SuperAdmin).
+func (s *DataRequestService) Get(id, companyID uint) (*models.DataSubjectRequest, error) {
+	req, err := s.requests.FindByID(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, apperr.NotFound("data subject request not found")
+	}
+	return req, nil
+}
+
+// Export genera el paquete de una solicitud de acceso. Se puede descargar
+// antes y después de completarla.
+func (s *DataRequestService) Export(id, companyID uint) (*dtos.DataSubjectExportDTO, error) {
+	req, err := s.Get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type != domain.RequestAccess {
+		return nil, apperr.BadRequest("only access requests can be exported")
+	}
+	if req.Status == domain.RequestCancelled {
+		return nil, apperr.Conflict("data subject request is cancelled")
+	}
+	return s.buildExport(req)
+}
+
+// Complete ejecuta la solicitud y guarda la prueba de cumplimiento.
+//   - access: genera el paquete (se devuelve) y guarda su hash.
+//   - erasure: anonimiza candidatos, postulaciones y colocaciones del titular;
+//     los registros se conservan para que las métricas agregadas no cambien.
+func (s *DataRequestService) Complete(id, companyID, userID uint) (*models.DataSubjectRequest, *dtos.DataSubjectExportDTO, error) {
+	req, err := s.Get(id, companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.Status != domain.RequestPending {
+		return nil, nil, apperr.Conflict("data subject request is not pending")
+	}
+
+	now := time.Now()
+	proof := domain.RequestProof{Type: req.Type, EmailHash: req.SubjectEmailHash, CompletedAt: now}
+
+	if req.Type == domain.RequestErasure {
+		candidates, err := s.subjects.FindCandidates(req.SubjectEmail, req.CompanyID)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make([]uint, len(candidates))
+		for i, c := range candidates {
+			ids[i] = c.ID
+		}
+		// La solicitud se cierra en la transacción de la anonimización: si no
+		// se puede guardar, el borrado se revierte y el reintento vuelve a
+		// encontrar al titular.
+		files, err := s.subjects.Anonymize(ids, req, func(result *domain.SubjectRecords) error {
+			proof.SubjectRecords = *result
+			// Tras el borrado solo queda el hash: la solicitud no debe conservar la PII.
+			req.SubjectEmail = domain.MaskEmail(req.SubjectEmail)
+			return seal(req, proof, userID, now)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		removeFiles(s.removeFiles, files)
+		return req, nil, nil
+	}
+
+	export, err := s.buildExport(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, err := json.Marshal(export)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof.ExportSHA256 = sha256Hex(raw)
+	proof.SubjectRecords = exportedIDs(export)
+	if err := seal(req, proof, userID, now); err != nil {
+		return nil, nil, err
+	}
+	if err := s.requests.Update(req); err != nil {
+		return nil, nil, err
+	}
+	return req, export, nil
+}
+
+// seal guarda en la solicitud la prueba de cumplimiento y su hash y la marca
+// completada.
+func seal(req *models.DataSubjectRequest, proof domain.RequestProof, userID uint, now time.Time) error {
+	raw, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+	req.Proof = raw
+	req.ProofHash = sha256Hex(raw)
+	req.Status = domain.RequestCompleted
+	req.CompletedAt = &now
+	req.CompletedByID = &userID
+	return nil
+}
+
+// Cancel descarta una solicitud pendiente (p. ej. identidad no verificada).
+func (s *DataRequestService) Cancel(id, companyID, userID uint) (*models.DataSubjectRequest, error) {
+	req, err := s.Get(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != domain.RequestPending {
+		return nil, apperr.Conflict("data subject request is not pending")
+	}
+
+	now := time.Now()
+	req.Status = domain.RequestCancelled
+	req.CompletedAt = &now
+	req.CompletedByID = &userID
+	if err := s.requests.Update(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *DataRequestService) buildExport(req *models.DataSubjectRequest) (*dtos.DataSubjectExportDTO, error) {
+	candidates, err := s.subjects.FindCandidates(req.SubjectEmail, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.subjects.FindRecords(candidates)
+	if err != nil {
+		return nil, err
+	}
+	export := dtos.ToDataSubjectExport(req, candidates, records, time.Now())
+	return &export, nil
+}
+
+// exportedIDs resume qué registros incluyó un paquete de acceso.
+func exportedIDs(export *dtos.DataSubjectExportDTO) domain.SubjectRecords {
+	result := domain.SubjectRecords{CandidateIDs: []uint{}, ApplicationIDs: []uint{}, PlacementIDs: []uint{}, CompanyIDs: []uint{}}
+	seen := make(map[uint]bool)
+	for _, c := range export.Candidates {
+		result.CandidateIDs = append(result.CandidateIDs, c.Profile.CandidateID)
+		if !seen[c.Profile.CompanyID] {
+			seen[c.Profile.CompanyID] = true
+			result.CompanyIDs = append(result.CompanyIDs, c.Profile.CompanyID)
+		}
+		for _, a := range c.Applications {
+			result.ApplicationIDs = append(result.ApplicationIDs, a.ID)
+		}
+		for _, p := range c.Placements {
+			result.PlacementIDs = append(result.PlacementIDs, p.ID)
+		}
+	}
+	return result
+}
+
+// removeFiles borra los archivos de registros ya anonimizados o eliminados.
+// Corre después del commit: un fallo se registra y no revierte la operación.
+func removeFiles(remove FileRemover, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	if err := remove(paths); err != nil {
+		log.Printf("⚠️ Privacidad: no se pudieron borrar %d archivos: %v", len(paths), err)
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+	"dvra-api/internal/platform/uploads"
+	"dvra-api/internal/shared/apperr"
+
+	"gorm.io/datatypes"
+)
+
+type fakeRequests struct {
+	domain.DataRequestRepository
+	req     *models.DataSubjectRequest
+	updated int
+}
+
+func (f *fakeRequests) FindByID(id, companyID uint) (*models.DataSubjectRequest, error) {
+	if f.req == nil || f.req.ID != id || (companyID != 0 && (f.req.CompanyID == nil || *f.req.CompanyID != companyID)) {
+		return nil, nil
+	}
+	return f.req, nil
+}
+
+func (f *fakeRequests) Update(*models.DataSubjectRequest) error {
+	f.updated++
+	return nil
+}
+
+// fakeSubjects devuelve siempre los mismos candidatos y anota qué se
+// anonimizó y con qué alcance se buscó.
+type fakeSubjects struct {
+	candidates []models.Candidate
+	data       dtos.DataSubjectRecords
+	scope      *uint
+	anonymized []uint
+	records    *domain.SubjectRecords
+	files      []string // archivos que deja sin registro Anonymize
+	removed    []string
+	saved      *models.DataSubjectRequest // solicitud guardada con la anonimización
+	saveErr    error
+}
+
+func (f *fakeSubjects) FindCandidates(_ string, companyID *uint) ([]models.Candidate, error) {
+	f.scope = companyID
+	return f.candidates, nil
+}
+
+func (f *fakeSubjects) FindRecords([]models.Candidate) (*dtos.DataSubjectRecords, error) {
+	return &f.data, nil
+}
+
+// Anonymize imita la transacción: si complete o el guardado fallan, no
+// queda nada anonimizado.
+func (f *fakeSubjects) Anonymize(ids []uint, req *models.DataSubjectRequest, complete func(*domain.SubjectRecords) error) ([]string, error) {
+	if err := complete(f.records); err != nil {
+		return nil, err
+	}
+	if f.saveErr != nil {
+		return nil, f.saveErr
+	}
+	f.anonymized = ids
+	f.saved = req
+	return f.files, nil
+}
+
+func (f *fakeSubjects) remove(paths []string) error {
+	f.removed = append(f.removed, paths...)
+	return nil
+}
+
+func subjectFixture() *fakeSubjects {
+	ana := models.Candidate{CompanyID: 1, Email: "ana@acme.com", FirstName: "Ana"}
+	ana.ID = 11
+	app := models.Application{CompanyID: 1, CandidateID: 11}
+	app.ID = 21
+	ana.Applications = []models.Application{app}
+	other := models.Candidate{CompanyID: 2, Email: "ana@acme.com", FirstName: "Ana"}
+	other.ID = 12
+	placement := models.Placement{CandidateID: 11}
+	placement.ID = 31
+	return &fakeSubjects{
+		candidates: []models.Candidate{ana, other},
+		data:       dtos.DataSubjectRecords{Placements: []models.Placement{placement}},
+		records: &domain.SubjectRecords{
+			CandidateIDs: []uint{11, 12}, ApplicationIDs: []uint{21}, PlacementIDs: []uint{31}, CompanyIDs: []uint{1, 2},
+		},
+	}
+}
+
+func pendingRequest(requestType string, companyID *uint) *models.DataSubjectRequest {
+	req := &models.DataSubjectRequest{
+		CompanyID:        companyID,
+		Type:             requestType,
+		Status:           domain.RequestPending,
+		SubjectEmail:     "ana@acme.com",
+		SubjectEmailHash: domain.HashEmail("ana@acme.com"),
+	}
+	req.ID = 5
+	return req
+}
+
+func proofOf(t *testing.T, req *models.DataSubjectRequest) domain.RequestProof {
+	t.Helper()
+	if req.ProofHash != sha256Hex(req.Proof) {
+		t.Errorf("proof_hash no corresponde a proof")
+	}
+	var proof domain.RequestProof
+	if err := json.Unmarshal(req.Proof, &proof); err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+func TestCompleteAccessGuardaElHashDelExport(t *testing.T) {
+	requests := &fakeRequests{req: pendingRequest(domain.RequestAccess, nil)}
+	subjects := subjectFixture()
+
+	req, export, err := NewDataRequestService(requests, subjects, subjects.remove).Complete(5, 0, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export == nil || len(export.Candidates) != 2 || len(export.Candidates[0].Applications) != 1 || len(export.Candidates[0].Placements) != 1 {
+		t.Fatalf("export = %+v", export)
+	}
+	if req.Status != domain.RequestCompleted || req.CompletedAt == nil || *req.CompletedByID != 9 || requests.updated != 1 {
+		t.Errorf("solicitud = %+v", req)
+	}
+	// El acceso no borra nada: el email queda tal cual.
+	if req.SubjectEmail != "ana@acme.com" || subjects.anonymized != nil {
+		t.Errorf("un acceso no debería anonimizar: %q %v", req.SubjectEmail, subjects.anonymized)
+	}
+
+	proof := proofOf(t, req)
+	raw, _ := json.Marshal(export)
+	if proof.ExportSHA256 != sha256Hex(raw) {
+		t.Errorf("export_sha256 no corresponde al paquete entregado")
+	}
+	want := domain.SubjectRecords{CandidateIDs: []uint{11, 12}, ApplicationIDs: []uint{21}, PlacementIDs: []uint{31}, CompanyIDs: []uint{1, 2}}
+	if !reflect.DeepEqual(proof.SubjectRecords, want) {
+		t.Errorf("registros = %+v, se esperaba %+v", proof.SubjectRecords, want)
+	}
+}
+
+// Cada tabla con datos del titular tiene su sección en el paquete.
+func TestExportIncluyeCadaTabla(t *testing.T) {
+	candidateID, appID, placementID := uint(11), uint(21), uint(31)
+	subjects := subjectFixture()
+
+	profileNote := models.Comment{CandidateID: &candidateID, Body: "perfil sólido", Version: 2}
+	profileNote.ID = 41
+	profileNote.Attachments = []models.CommentAttachment{{CommentID: 41, FileName: "referencias.pdf", Size: 10}}
+	appNote := models.Comment{ApplicationID: &appID, Body: "buena entrevista", Internal: true}
+	appNote.ID = 42
+	offer := models.Offer{ApplicationID: appID, Status: models.OfferStatusSent}
+	offer.Versions = []models.OfferVersion{{Version: 1, Salary: 1000, Currency: "USD"}}
+	parse := models.ResumeParse{CandidateID: candidateID, Status: models.ResumeParseCompleted}
+	parse.Data = datatypes.NewJSONType(models.ResumeData{Phones: []string{"+54 11 5555"}})
+	subjects.data.Comments = []models.Comment{profileNote, appNote}
+	subjects.data.CommentRevisions = []models.CommentRevision{{CommentID: 41, Version: 1, Body: "perfil flojo"}}
+	subjects.data.StageEvents = []models.ApplicationStageEvent{{ApplicationID: appID, ToStage: "screening", Reason: "pasa el filtro"}}
+	subjects.data.ScreeningAnswers = []models.ScreeningAnswer{{ApplicationID: appID, Prompt: "¿Años con Go?", Answer: "4"}}
+	subjects.data.Scorecards = []models.Scorecard{{ApplicationID: appID, Recommendation: models.RecommendationYes, Summary: "sabe"}}
+	subjects.data.Interviews = []models.Interview{{ApplicationID: appID, Title: "Técnica"}}
+	subjects.data.Offers = []models.Offer{offer}
+	subjects.data.ResumeParses = []models.ResumeParse{parse}
+	subjects.data.Documents = []models.Document{
+		{ApplicationID: &appID, Kind: models.DocumentKindOfferLetter, FileName: "oferta.pdf"},
+		{PlacementID: &placementID, Kind: models.DocumentKindPlacementContract, FileName: "contrato.pdf"},
+	}
+	subjects.data.Tags = []models.CandidateTag{{CandidateID: candidateID, Tag: &models.Tag{Name: "senior"}}}
+	subjects.data.Skills = []models.CandidateSkill{{CandidateID: candidateID, Source: models.CandidateSkillResume, Skill: &models.Skill{Name: "Go"}}}
+	subjects.data.PoolMemberships = []models.TalentPoolMember{{PoolID: 7, CandidateID: candidateID, Note: "backend"}}
+
+	export, err := NewDataRequestService(&fakeRequests{req: pendingRequest(domain.RequestAccess, nil)}, subjects, subjects.remove).Export(5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := export.Candidates[0]
+	a := c.Applications[0]
+	checks := []struct {
+		section string
+		ok      bool
+	}{
+		{"comments", len(c.Comments) == 1 && c.Comments[0].Body == "perfil sólido"},
+		{"comments.revisions", len(c.Comments) == 1 && len(c.Comments[0].Revisions) == 1 && c.Comments[0].Revisions[0].Body == "perfil flojo"},
+		{"comments.attachments", len(c.Comments) == 1 && len(c.Comments[0].Attachments) == 1 && c.Comments[0].Attachments[0].FileName == "referencias.pdf"},
+		{"tags", reflect.DeepEqual(c.Tags, []string{"senior"})},
+		{"skills", len(c.Skills) == 1 && c.Skills[0].Name == "Go"},
+		{"talent_pools", len(c.TalentPools) == 1 && c.TalentPools[0].PoolID == 7},
+		{"resume_parses", len(c.ResumeParses) == 1 && reflect.DeepEqual(c.ResumeParses[0].Data.Phones, []string{"+54 11 5555"})},
+		{"documents", len(c.Documents) == 1 && c.Documents[0].FileName == "contrato.pdf"},
+		{"applications.comments", len(a.Comments) == 1 && a.Comments[0].Body == "buena entrevista" && a.Comments[0].Internal},
+		{"applications.stage_history", len(a.StageHistory) == 1 && a.StageHistory[0].Reason == "pasa el filtro"},
+		{"applications.screening_answers", len(a.ScreeningAnswers) == 1 && a.ScreeningAnswers[0].Answer == "4"},
+		{"applications.scorecards", len(a.Scorecards) == 1 && a.Scorecards[0].Summary == "sabe"},
+		{"applications.interviews", len(a.Interviews) == 1 && a.Interviews[0].Title == "Técnica"},
+		{"applications.offers", len(a.Offers) == 1 && len(a.Offers[0].Versions) == 1 && a.Offers[0].Versions[0].Salary == 1000},
+		{"applications.documents", len(a.Documents) == 1 && a.Documents[0].FileName == "oferta.pdf"},
+	}
+	for _, check := range checks {
+		if !check.ok {
+			t.Errorf("sección %s incompleta: %+v", check.section, c)
+		}
+	}
+	// El otro perfil no hereda registros ajenos y sus secciones salen vacías, no null.
+	raw, _ := json.Marshal(export.Candidates[1])
+	var other map[string]interface{}
+	if err := json.Unmarshal(raw, &other); err != nil {
+		t.Fatal(err)
+	}
+	for _, section := range []string{"comments", "tags", "skills", "talent_pools", "resume_parses", "documents"} {
+		if items, ok := other[section].([]interface{}); !ok || len(items) != 0 {
+			t.Errorf("%s del otro perfil = %v", section, other[section])
+		}
+	}
+}
+
+func TestCompleteErasureAnonimizaYEnmascara(t *testing.T) {
+	company := uint(1)
+	requests := &fakeRequests{req: pendingRequest(domain.RequestErasure, &company)}
+	subjects := subjectFixture()
+
+	req, export, err := NewDataRequestService(requests, subjects, subjects.remove).Complete(5, 1, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export != nil {
+		t.Errorf("un borrado no entrega paquete: %+v", export)
+	}
+	if subjects.scope == nil || *subjects.scope != 1 {
+		t.Errorf("la búsqueda debería limitarse a la empresa de la solicitud: %v", subjects.scope)
+	}
+	if !reflect.DeepEqual(subjects.anonymized, []uint{11, 12}) {
+		t.Errorf("anonimizados = %v", subjects.anonymized)
+	}
+	// La solicitud se guarda con la anonimización, no aparte.
+	if subjects.saved != req || requests.updated != 0 {
+		t.Errorf("guardada = %v, updates aparte = %d", subjects.saved, requests.updated)
+	}
+	// Tras el borrado la solicitud solo conserva el hash.
+	if req.SubjectEmail != "a***@acme.com" || req.SubjectEmailHash != domain.HashEmail("ana@acme.com") {
+		t.Errorf("email = %q hash = %q", req.SubjectEmail, req.SubjectEmailHash)
+	}
+
+	proof := proofOf(t, req)
+	if proof.Type != domain.RequestErasure || proof.ExportSHA256 != "" || !reflect.DeepEqual(proof.SubjectRecords, *subjects.records) {
+		t.Errorf("proof = %+v", proof)
+	}
+}
+
+// El CV del titular se borra del almacenamiento una vez anonimizado.
+func TestCompleteErasureBorraLosArchivos(t *testing.T) {
+	dir := t.TempDir()
+	resume := filepath.Join(dir, "companies", "acme", "resumes", "1700000000_ana_acme_com.pdf")
+	if err := os.MkdirAll(filepath.Dir(resume), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(resume, []byte("%PDF"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	subjects := subjectFixture()
+	subjects.files = []string{"companies/acme/resumes/1700000000_ana_acme_com.pdf"}
+
+	svc := NewDataRequestService(&fakeRequests{req: pendingRequest(domain.RequestErasure, nil)}, subjects, uploads.New(dir).Remove)
+	if _, _, err := svc.Complete(5, 0, 9); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(resume); !os.IsNotExist(err) {
+		t.Errorf("el CV sigue en disco: %v", err)
+	}
+}
+
+// Si no se puede cerrar la solicitud, la anonimización se revierte con ella:
+// no se borran archivos ni se guarda nada aparte.
+func TestCompleteErasureFallaAlGuardar(t *testing.T) {
+	requests := &fakeRequests{req: pendingRequest(domain.RequestErasure, nil)}
+	subjects := subjectFixture()
+	subjects.files = []string{"companies/acme/resumes/cv.pdf"}
+	subjects.saveErr = errors.New("connection reset")
+
+	if _, _, err := NewDataRequestService(requests, subjects, subjects.remove).Complete(5, 0, 9); err == nil {
+		t.Fatal("se esperaba el error del guardado")
+	}
+	if subjects.anonymized != nil || subjects.removed != nil || requests.updated != 0 {
+		t.Errorf("anonimizados = %v, borrados = %v, updates = %d", subjects.anonymized, subjects.removed, requests.updated)
+	}
+}
+
+// Un acceso no borra archivos.
+func TestCompleteAccessConservaLosArchivos(t *testing.T) {
+	subjects := subjectFixture()
+	subjects.files = []string{"companies/acme/resumes/cv.pdf"}
+
+	if _, _, err := NewDataRequestService(&fakeRequests{req: pendingRequest(domain.RequestAccess, nil)}, subjects, subjects.remove).Complete(5, 0, 9); err != nil {
+		t.Fatal(err)
+	}
+	if subjects.removed != nil {
+		t.Errorf("borrados = %v", subjects.removed)
+	}
+}
+
+func TestCompleteYExportValidan(t *testing.T) {
+	company := uint(1)
+	cases := []struct {
+		name      string
+		req       *models.DataSubjectRequest
+		companyID uint
+		export    bool
+		want      int
+	}{
+		{"ya completada", func() *models.DataSubjectRequest {
+			r := pendingRequest(domain.RequestAccess, &company)
+			r.Status = domain.RequestCompleted
+			return r
+		}(), 1, false, http.StatusConflict},
+		{"otra empresa", pendingRequest(domain.RequestAccess, &company), 2, false, http.StatusNotFound},
+		{"export de un borrado", pendingRequest(domain.RequestErasure, &company), 1, true, http.StatusBadRequest},
+		{"export cancelado", func() *models.DataSubjectRequest {
+			r := pendingRequest(domain.RequestAccess, &company)
+			r.Status = domain.RequestCancelled
+			return r
+		}(), 1, true, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		requests := &fakeRequests{req: tc.req}
+		subjects := subjectFixture()
+		svc := NewDataRequestService(requests, subjects, subjects.remove)
+
+		var err error
+		if tc.export {
+			_, err = svc.Export(5, tc.companyID)
+		} else {
+			_, _, err = svc.Complete(5, tc.companyID, 9)
+		}
+		if apperr.StatusCode(err) != tc.want {
+			t.Errorf("%s: err = %v, se esperaba %d", tc.name, err, tc.want)
+		}
+		if requests.updated != 0 || subjects.anonymized != nil || subjects.removed != nil {
+			t.Errorf("%s: no debería modificar nada", tc.name)
+		}
+	}
+}
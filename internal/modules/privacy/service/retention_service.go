@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/privacy/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// Origen de una ejecución de retención.
+const (
+	TriggerScheduler = "scheduler"
+	TriggerManual    = "manual"
+)
+
+// previewSampleSize limita los IDs de ejemplo que devuelve el dry-run.
+const previewSampleSize = 50
+
+// RetentionService configura y aplica las políticas de retención (§6.7).
+// Depende de dos puertos: las políticas configuradas y el repositorio que
+// localiza/procesa los registros vencidos.
+type RetentionService struct {
+	policies    domain.PolicyRepository
+	enforcer    domain.EnforcementRepository
+	removeFiles FileRemover
+}
+
+func NewRetentionService(policies domain.PolicyRepository, enforcer domain.EnforcementRepository, removeFiles FileRemover) *RetentionService {
+	return &RetentionService{policies: policies, enforcer: enforcer, removeFiles: removeFiles}
+}
+
+// GetPolicies devuelve las políticas efectivas de la empresa (las de empresa
+// reemplazan a las de plataforma). companyID nil = solo las de plataforma.
+func (s *RetentionService) GetPolicies(companyID *uint) ([]models.RetentionPolicy, error) {
+	platform, err := s.policies.GetByCompany(nil)
+	if err != nil {
+		return nil, err
+	}
+	if companyID == nil {
+		return domain.EffectivePolicies(platform, nil), nil
+	}
+
+	company, err := s.policies.GetByCompany(companyID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.EffectivePolicies(platform, company), nil
+}
+
+// UpsertPolicy crea o actualiza la política de una regla. companyID nil edita
+// la política de plataforma (SuperAdmin).
+func (s *RetentionService) UpsertPolicy(companyID *uint, rule string, userID uint, dto dtos.UpsertRetentionPolicyDTO) (*models.RetentionPolicy, error) {
+	if !domain.IsValidRule(rule) {
+		return nil, apperr.BadRequest("invalid retention rule")
+	}
+	if !domain.IsAllowedAction(rule, dto.Action) {
+		return nil, apperr.BadRequest(fmt.Sprintf("action '%s' is not allowed for rule '%s'", dto.Action, rule))
+	}
+
+	policy, err := s.policies.GetByRule(companyID, rule)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &models.RetentionPolicy{CompanyID: companyID, Rule: rule, IsActive: true}
+	}
+
+	policy.RetentionMonths = dto.RetentionMonths
+	policy.Action = dto.Action
+	if dto.IsActive != nil {
+		policy.IsActive = *dto.IsActive
+	}
+	policy.UpdatedByID = &userID
+
+	return s.policies.Save(policy)
+}
+
+// DeletePolicy elimina la política propia de la empresa para la regla; a partir
+// de ahí vuelve a aplicar la de plataforma (si existe).
+func (s *RetentionService) DeletePolicy(companyID *uint, rule string) error {
+	if !domain.IsValidRule(rule) {
+		return apperr.BadRequest("invalid retention rule")
+	}
+	policy, err := s.policies.GetByRule(companyID, rule)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return apperr.NotFound("retention policy not found")
+	}
+	return s.policies.Delete(policy.ID)
+}
+
+// Preview es el dry-run: qué registros afectaría cada política activa si el
+// barrido corriera ahora. No modifica nada ni registra ejecuciones.
+func (s *RetentionService) Preview(companyID uint) ([]dtos.RetentionPreviewDTO, error) {
+	policies, err := s.GetPolicies(&companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	previews := []dtos.RetentionPreviewDTO{}
+	for _, p := range policies {
+		if !p.IsActive {
+			continue
+		}
+		cutoff := domain.Cutoff(now, p.RetentionMonths)
+		ids, err := s.enforcer.FindExpired(companyID, p.Rule, p.Action, cutoff)
+		if err != nil {
+			return nil, err
+		}
+
+		sample := ids
+		if len(sample) > previewSampleSize {
+			sample = sample[:previewSampleSize]
+		}
+		previews = append(previews, dtos.RetentionPreviewDTO{
+			Rule:            p.Rule,
+			Action:          p.Action,
+			RetentionMonths: p.RetentionMonths,
+			Cutoff:          cutoff,
+			AffectedCount:   len(ids),
+			SampleIDs:       sample,
+		})
+	}
+	return previews, nil
+}
+
+// Enforce aplica las políticas activas de una empresa y registra una
+// ejecución por regla (aunque no haya afectado nada: también es evidencia).
+// Un fallo en una regla queda en su ejecución y no detiene las demás.
+func (s *RetentionService) Enforce(companyID uint, trigger string, triggeredByID *uint) ([]models.RetentionRun, error) {
+	policies, err := s.GetPolicies(&companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []models.RetentionRun{}
+	for _, p := range policies {
+		if !p.IsActive {
+			continue
+		}
+		run := s.enforcePolicy(companyID, p, trigger, triggeredByID)
+		if err := s.enforcer.CreateRun(&run); err != nil {
+			return runs, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// EnforceAll es la tarea programada: recorre todas las empresas.
+func (s *RetentionService) EnforceAll(ctx context.Context) error {
+	companyIDs, err := s.enforcer.CompanyIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, companyID := range companyIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		runs, err := s.Enforce(companyID, TriggerScheduler, nil)
+		if err != nil {
+			return fmt.Errorf("company %d: %w", companyID, err)
+		}
+		for _, run := range runs {
+			if run.AffectedCount > 0 || run.Error != "" {
+				log.Printf("🧹 Retención company=%d rule=%s action=%s affected=%d error=%q",
+					companyID, run.Rule, run.Action, run.AffectedCount, run.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// GetRuns devuelve las últimas ejecuciones de retención de la empresa.
+func (s *RetentionService) GetRuns(companyID uint, limit int) ([]models.RetentionRun, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.enforcer.GetRuns(companyID, limit)
+}
+
+func (s *RetentionService) enforcePolicy(companyID uint, p models.RetentionPolicy, trigger string, triggeredByID *uint) models.RetentionRun {
+	now := time.Now()
+	run := models.RetentionRun{
+		CompanyID:     companyID,
+		Rule:          p.Rule,
+		Action:        p.Action,
+		Cutoff:        domain.Cutoff(now, p.RetentionMonths),
+		Trigger:       trigger,
+		TriggeredByID: triggeredByID,
+		StartedAt:     now,
+	}
+
+	ids, err := s.enforcer.FindExpired(companyID, p.Rule, p.Action, run.Cutoff)
+	if err == nil {
+		var files []string
+		files, err = s.enforcer.Apply(p.Rule, p.Action, ids)
+		removeFiles(s.removeFiles, files)
+	}
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+
+	run.AffectedCount = len(ids)
+	if encoded, err := json.Marshal(ids); err == nil {
+		run.AffectedIDs = encoded
+	}
+	return run
+}
@@ -0,0 +1,187 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/privacy/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConsentHandler struct {
+	svc *service.ConsentService
+}
+
+func NewConsentHandler(svc *service.ConsentService) *ConsentHandler {
+	return &ConsentHandler{svc: svc}
+}
+
+// GetNotices godoc
+// @Summary      Listar versiones del aviso de privacidad
+// @Description  Devuelve el aviso vigente (propio o el de plataforma) y el historial de versiones de la empresa
+// @Tags         Privacy
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/notices [get]
+func (h *ConsentHandler) GetNotices(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	current, err := h.svc.CurrentNotice(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve privacy notice"})
+		return
+	}
+	notices, err := h.svc.ListNotices(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve privacy notices"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{
+		"current":  current,
+		"versions": dtos.ToPrivacyNoticeResponseList(notices),
+	}})
+}
+
+// PublishNotice godoc
+// @Summary      Publicar nueva versión del aviso de privacidad
+// @Description  Crea la siguiente versión y la deja vigente. Las versiones anteriores no se modifican.
+// @Tags         Privacy
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     int                          false  "Empresa (obligatorio para SuperAdmin)"
+// @Param        notice      body      dtos.CreatePrivacyNoticeDTO  true   "Aviso"
+// @Success      201         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/notices [post]
+func (h *ConsentHandler) PublishNotice(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	var dto dtos.CreatePrivacyNoticeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	notice, err := h.svc.PublishNotice(companyID, userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": dtos.ToPrivacyNoticeResponse(notice)})
+}
+
+// GetCandidateConsents godoc
+// @Summary      Historial de consentimientos del candidato
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID del candidato"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/candidates/{id}/consents [get]
+func (h *ConsentHandler) GetCandidateConsents(c *gin.Context) {
+	candidateID, companyID, ok := candidateTarget(c)
+	if !ok {
+		return
+	}
+
+	consents, err := h.svc.ListCandidateConsents(candidateID, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": dtos.ToCandidateConsentResponseList(consents), "count": len(consents)}})
+}
+
+// GrantTalentPoolConsent godoc
+// @Summary      Registrar consentimiento de talent pool
+// @Description  Registra que el candidato aceptó ser contactado por futuras vacantes (obtenido fuera de la career page)
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID del candidato"
+// @Success      201  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/candidates/{id}/consents/talent-pool [post]
+func (h *ConsentHandler) GrantTalentPoolConsent(c *gin.Context) {
+	candidateID, companyID, ok := candidateTarget(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	consent, err := h.svc.GrantTalentPool(candidateID, companyID, userID, dtos.ConsentEvidence{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": dtos.ToCandidateConsentResponse(consent)})
+}
+
+// RevokeTalentPoolConsent godoc
+// @Summary      Revocar consentimiento de talent pool
+// @Description  El candidato deja de ser contactable para sourcing; sus postulaciones en curso no cambian
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID del candidato"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/candidates/{id}/consents/talent-pool [delete]
+func (h *ConsentHandler) RevokeTalentPoolConsent(c *gin.Context) {
+	candidateID, companyID, ok := candidateTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeTalentPool(candidateID, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Talent pool consent revoked"})
+}
+
+// GetPublicNotice godoc
+// @Summary      Aviso de privacidad vigente (career page)
+// @Description  Aviso que el candidato debe aceptar al postular. version=0 indica el aviso de plataforma.
+// @Tags         Public
+// @Produce      json
+// @Param        slug  path      string  true  "Company slug"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Router       /public/companies/{slug}/privacy-notice [get]
+func (h *ConsentHandler) GetPublicNotice(c *gin.Context) {
+	notice, err := h.svc.PublicNotice(c.Param("slug"))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": notice})
+}
+
+// candidateTarget resuelve el :id del candidato y el tenant (0 = SuperAdmin).
+func candidateTarget(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return 0, 0, false
+	}
+	companyID, ok := requestScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
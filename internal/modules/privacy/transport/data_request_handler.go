@@ -0,0 +1,214 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/privacy/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DataRequestHandler struct {
+	svc *service.DataRequestService
+}
+
+func NewDataRequestHandler(svc *service.DataRequestService) *DataRequestHandler {
+	return &DataRequestHandler{svc: svc}
+}
+
+// CreateRequest godoc
+// @Summary      Registrar solicitud del titular de datos
+// @Description  Registra una solicitud de acceso (export) o de borrado sobre los candidatos con el email. Plazo: 30 días. SuperAdmin puede omitir company_id para abarcar todas las empresas.
+// @Tags         Privacy
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dtos.CreateDataSubjectRequestDTO  true  "Solicitud"
+// @Success      201      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/requests [post]
+func (h *DataRequestHandler) CreateRequest(c *gin.Context) {
+	var dto dtos.CreateDataSubjectRequestDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope := dto.CompanyID
+	if !authctx.IsSuperAdmin(c) {
+		companyID, ok := authctx.CompanyID(c)
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+			return
+		}
+		scope = &companyID
+	}
+	userID, _ := authctx.UserID(c)
+
+	req, err := h.svc.Create(scope, userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": dtos.ToDataSubjectRequestResponse(req)})
+}
+
+// GetRequests godoc
+// @Summary      Listar solicitudes del titular de datos
+// @Description  Ordenadas por vencimiento; overdue=true si siguen pendientes pasado el plazo
+// @Tags         Privacy
+// @Produce      json
+// @Param        type    query     string  false  "access | erasure"
+// @Param        status  query     string  false  "pending | completed | cancelled"
+// @Success      200     {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/requests [get]
+func (h *DataRequestHandler) GetRequests(c *gin.Context) {
+	companyID, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	var filters dtos.DataSubjectRequestFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reqs, err := h.svc.List(companyID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve data subject requests"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": dtos.ToDataSubjectRequestResponseList(reqs), "count": len(reqs)}})
+}
+
+// GetRequest godoc
+// @Summary      Obtener solicitud del titular de datos
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID de la solicitud"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/requests/{id} [get]
+func (h *DataRequestHandler) GetRequest(c *gin.Context) {
+	id, companyID, ok := requestTarget(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.svc.Get(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToDataSubjectRequestResponse(req)})
+}
+
+// ExportRequest godoc
+// @Summary      Descargar paquete de acceso
+// @Description  Genera el JSON con perfil, postulaciones, notas, colocaciones y archivos del titular (solo solicitudes de acceso)
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID de la solicitud"
+// @Success      200  {object}  dtos.DataSubjectExportDTO
+// @Security     BearerAuth
+// @Router       /privacy/requests/{id}/export [get]
+func (h *DataRequestHandler) ExportRequest(c *gin.Context) {
+	id, companyID, ok := requestTarget(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.svc.Export(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=data-request-%d.json", id))
+	c.JSON(http.StatusOK, export)
+}
+
+// CompleteRequest godoc
+// @Summary      Ejecutar solicitud del titular de datos
+// @Description  access: genera el paquete y guarda su hash. erasure: anonimiza la PII del titular conservando las métricas agregadas. Guarda la prueba de cumplimiento.
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID de la solicitud"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/requests/{id}/complete [post]
+func (h *DataRequestHandler) CompleteRequest(c *gin.Context) {
+	id, companyID, ok := requestTarget(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	req, export, err := h.svc.Complete(id, companyID, userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	data := gin.H{"request": dtos.ToDataSubjectRequestResponse(req)}
+	if export != nil {
+		data["export"] = export
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// CancelRequest godoc
+// @Summary      Cancelar solicitud del titular de datos
+// @Tags         Privacy
+// @Produce      json
+// @Param        id   path      int  true  "ID de la solicitud"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/requests/{id}/cancel [post]
+func (h *DataRequestHandler) CancelRequest(c *gin.Context) {
+	id, companyID, ok := requestTarget(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	req, err := h.svc.Cancel(id, companyID, userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToDataSubjectRequestResponse(req)})
+}
+
+// requestScope devuelve el tenant para las solicitudes: 0 = todas (SuperAdmin).
+// Si falla, ya escribió la respuesta.
+func requestScope(c *gin.Context) (uint, bool) {
+	if authctx.IsSuperAdmin(c) {
+		return 0, true
+	}
+	companyID, ok := authctx.CompanyID(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return 0, false
+	}
+	return companyID, true
+}
+
+// requestTarget resuelve el :id de la ruta y el tenant.
+func requestTarget(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return 0, 0, false
+	}
+	companyID, ok := requestScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -0,0 +1,184 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/privacy/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RetentionHandler struct {
+	svc *service.RetentionService
+}
+
+func NewRetentionHandler(svc *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{svc: svc}
+}
+
+// GetPolicies godoc
+// @Summary      Listar políticas de retención
+// @Description  Políticas efectivas de la empresa (las propias reemplazan a las de plataforma). SuperAdmin ve las de plataforma.
+// @Tags         Privacy
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/retention/policies [get]
+func (h *RetentionHandler) GetPolicies(c *gin.Context) {
+	companyID, ok := policyScope(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return
+	}
+
+	policies, err := h.svc.GetPolicies(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve retention policies"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToRetentionPolicyResponseList(policies)})
+}
+
+// UpsertPolicy godoc
+// @Summary      Configurar política de retención
+// @Description  Crea o actualiza la ventana de retención de una regla (rejected_applications, inactive_candidates, prospect_staffing_clients). SuperAdmin edita la de plataforma.
+// @Tags         Privacy
+// @Accept       json
+// @Produce      json
+// @Param        rule    path      string                         true  "Regla"
+// @Param        policy  body      dtos.UpsertRetentionPolicyDTO  true  "Ventana y acción"
+// @Success      200     {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/retention/policies/{rule} [put]
+func (h *RetentionHandler) UpsertPolicy(c *gin.Context) {
+	companyID, ok := policyScope(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	var dto dtos.UpsertRetentionPolicyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.svc.UpsertPolicy(companyID, c.Param("rule"), userID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToRetentionPolicyResponse(policy)})
+}
+
+// DeletePolicy godoc
+// @Summary      Eliminar política propia
+// @Description  Elimina la política de la empresa para la regla; vuelve a aplicar la de plataforma
+// @Tags         Privacy
+// @Produce      json
+// @Param        rule  path      string  true  "Regla"
+// @Success      200   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/retention/policies/{rule} [delete]
+func (h *RetentionHandler) DeletePolicy(c *gin.Context) {
+	companyID, ok := policyScope(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return
+	}
+
+	if err := h.svc.DeletePolicy(companyID, c.Param("rule")); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Retention policy deleted"})
+}
+
+// Preview godoc
+// @Summary      Vista previa (dry-run) de retención
+// @Description  Muestra qué registros afectaría cada política activa si el barrido corriera ahora. No modifica datos.
+// @Tags         Privacy
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/retention/preview [get]
+func (h *RetentionHandler) Preview(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+
+	previews, err := h.svc.Preview(companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": previews})
+}
+
+// Run godoc
+// @Summary      Ejecutar retención ahora
+// @Description  Aplica de inmediato las políticas activas de la empresa (lo mismo que hace la tarea programada) y devuelve lo que hizo
+// @Tags         Privacy
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/retention/run [post]
+func (h *RetentionHandler) Run(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	userID, _ := authctx.UserID(c)
+
+	runs, err := h.svc.Enforce(companyID, service.TriggerManual, &userID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ToRetentionRunResponseList(runs)})
+}
+
+// GetRuns godoc
+// @Summary      Historial de ejecuciones de retención
+// @Tags         Privacy
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Param        limit       query     int  false  "Máximo de ejecuciones (default 50)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /privacy/retention/runs [get]
+func (h *RetentionHandler) GetRuns(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	runs, err := h.svc.GetRuns(companyID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve retention runs"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"runs": dtos.ToRetentionRunResponseList(runs), "count": len(runs)}})
+}
+
+// policyScope devuelve la empresa cuyas políticas se operan: la del token, o
+// nil (plataforma) para SuperAdmin.
+func policyScope(c *gin.Context) (*uint, bool) {
+	if authctx.IsSuperAdmin(c) {
+		return nil, true
+	}
+	companyID, ok := authctx.CompanyID(c)
+	if !ok {
+		return nil, false
+	}
+	return &companyID, true
+}
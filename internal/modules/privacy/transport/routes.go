@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/privacy/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, retentionSvc *service.RetentionService, dataRequestSvc *service.DataRequestService, consentSvc *service.ConsentService) {
+	retentionH := NewRetentionHandler(retentionSvc)
+	dataRequestH := NewDataRequestHandler(dataRequestSvc)
+	consentH := NewConsentHandler(consentSvc)
+
+	retention := rg.Group("/privacy/retention")
+	{
+		retention.GET("/policies", middleware.RequirePermission(permissions.RetentionView), retentionH.GetPolicies)
+		retention.PUT("/policies/:rule", middleware.RequirePermission(permissions.RetentionManage), retentionH.UpsertPolicy)
+		retention.DELETE("/policies/:rule", middleware.RequirePermission(permissions.RetentionManage), retentionH.DeletePolicy)
+		retention.GET("/preview", middleware.RequirePermission(permissions.RetentionView), retentionH.Preview)
+		retention.POST("/run", middleware.RequirePermission(permissions.RetentionManage), retentionH.Run)
+		retention.GET("/runs", middleware.RequirePermission(permissions.RetentionView), retentionH.GetRuns)
+	}
+
+	requests := rg.Group("/privacy/requests")
+	{
+		requests.GET("", middleware.RequirePermission(permissions.DataRequestsView), dataRequestH.GetRequests)
+		requests.POST("", middleware.RequirePermission(permissions.DataRequestsManage), dataRequestH.CreateRequest)
+		requests.GET("/:id", middleware.RequirePermission(permissions.DataRequestsView), dataRequestH.GetRequest)
+		requests.GET("/:id/export", middleware.RequirePermission(permissions.DataRequestsManage), dataRequestH.ExportRequest)
+		requests.POST("/:id/complete", middleware.RequirePermission(permissions.DataRequestsManage), dataRequestH.CompleteRequest)
+		requests.POST("/:id/cancel", middleware.RequirePermission(permissions.DataRequestsManage), dataRequestH.CancelRequest)
+	}
+
+	notices := rg.Group("/privacy/notices")
+	{
+		notices.GET("", middleware.RequirePermission(permissions.PrivacyNoticesManage), consentH.GetNotices)
+		notices.POST("", middleware.RequirePermission(permissions.PrivacyNoticesManage), consentH.PublishNotice)
+	}
+
+	consents := rg.Group("/privacy/candidates/:id/consents")
+	{
+		consents.GET("", middleware.RequirePermission(permissions.ConsentsView), consentH.GetCandidateConsents)
+		consents.POST("/talent-pool", middleware.RequirePermission(permissions.ConsentsManage), consentH.GrantTalentPoolConsent)
+		consents.DELETE("/talent-pool", middleware.RequirePermission(permissions.ConsentsManage), consentH.RevokeTalentPoolConsent)
+	}
+}
+
+// RegisterPublicRoutes monta las rutas sin autenticación (career page).
+func RegisterPublicRoutes(public *gin.RouterGroup, consentSvc *service.ConsentService) {
+	consentH := NewConsentHandler(consentSvc)
+	public.GET("/companies/:slug/privacy-notice", consentH.GetPublicNotice)
+}
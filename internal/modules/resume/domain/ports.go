@@ -0,0 +1,29 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// ParseRepository es el puerto de salida hacia la persistencia. Los métodos
+// que buscan devuelven nil, nil si no hay resultado.
+type ParseRepository interface {
+	Create(parse *models.ResumeParse) error
+	// Pending devuelve los análisis pendientes, los más antiguos primero.
+	Pending(limit int) ([]models.ResumeParse, error)
+	// Complete guarda el resultado y, si el candidato sigue teniendo ese CV,
+	// reemplaza su ResumeText por text (vacío si no se pudo leer) e informa
+	// true.
+	Complete(parse *models.ResumeParse, text string) (bool, error)
+	// Latest devuelve el último análisis del archivo resumeURL del candidato.
+	Latest(candidateID uint, resumeURL string) (*models.ResumeParse, error)
+	// Candidate busca un candidato de la empresa (companyID 0 = cualquiera).
+	Candidate(companyID, candidateID uint) (*models.Candidate, error)
+	UpdateCandidate(candidate *models.Candidate, fields map[string]interface{}) error
+	// CompanySlug devuelve el slug de la empresa del candidato ("" si no
+	// existe): sus CVs se guardan en companies/<slug>/resumes/.
+	CompanySlug(candidateID uint) (string, error)
+}
+
+// SkillSync recibe las habilidades de un CV analizado para cargarlas en la
+// ficha del candidato (módulo skill).
+type SkillSync interface {
+	SyncFromResume(companyID, candidateID uint, data models.ResumeData) error
+}
@@ -0,0 +1,618 @@
+// Package domain define el centro del módulo resume (análisis de CVs): la
+// inferencia de datos estructurados a partir del texto del CV, las
+// sugerencias para completar la ficha del candidato y el puerto hacia la
+// persistencia. No importa gin ni gorm.
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"dvra-api/internal/app/models"
+)
+
+// Topes de cada lista del perfil (un CV real no los alcanza; evitan que un
+// documento raro llene el JSON).
+const (
+	maxEmails     = 5
+	maxPhones     = 3
+	maxLinks      = 10
+	maxSkills     = 50
+	maxEducation  = 10
+	maxExperience = 30
+	maxTitle      = 150
+)
+
+// fold pasa a minúsculas y quita las tildes de español y portugués, para
+// comparar encabezados, meses y habilidades.
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "é", "e", "ê", "e", "í", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ú", "u", "ü", "u", "ñ", "n", "ç", "c",
+)
+
+func fold(s string) string {
+	return accents.Replace(strings.ToLower(s))
+}
+
+// Secciones de un CV que cambian cómo se leen sus renglones.
+type section int
+
+const (
+	sectionNone section = iota // antes del primer encabezado
+	sectionExperience
+	sectionEducation
+	sectionSkills
+	sectionOther
+)
+
+// headings son los títulos de sección reconocidos (ya pasados por fold).
+var headings = map[string]section{
+	"experiencia": sectionExperience, "experiencia laboral": sectionExperience,
+	"experiencia profesional": sectionExperience, "historial laboral": sectionExperience,
+	"experience": sectionExperience, "work experience": sectionExperience,
+	"professional experience": sectionExperience, "employment history": sectionExperience,
+	"experiencia de trabajo": sectionExperience, "experiencia profissional": sectionExperience,
+
+	"educacion": sectionEducation, "formacion": sectionEducation,
+	"formacion academica": sectionEducation, "estudios": sectionEducation,
+	"education": sectionEducation, "academic background": sectionEducation,
+	"educacao": sectionEducation, "formacao": sectionEducation,
+	"formacao academica": sectionEducation,
+
+	"habilidades": sectionSkills, "habilidades tecnicas": sectionSkills,
+	"competencias": sectionSkills, "conocimientos": sectionSkills,
+	"conocimientos tecnicos": sectionSkills, "aptitudes": sectionSkills,
+	"tecnologias": sectionSkills, "skills": sectionSkills,
+	"technical skills": sectionSkills, "stack": sectionSkills,
+	"conhecimentos": sectionSkills, "tech stack": sectionSkills,
+
+	"idiomas": sectionOther, "languages": sectionOther, "linguas": sectionOther,
+	"proyectos": sectionOther, "projects": sectionOther, "projetos": sectionOther,
+	"certificaciones": sectionOther, "certifications": sectionOther, "certificacoes": sectionOther,
+	"cursos": sectionOther, "courses": sectionOther, "referencias": sectionOther,
+	"references": sectionOther, "perfil": sectionOther, "profile": sectionOther,
+	"summary": sectionOther, "resumen": sectionOther, "resumo": sectionOther,
+	"sobre mi": sectionOther, "sobre mim": sectionOther, "about me": sectionOther,
+	"contacto": sectionOther, "contato": sectionOther, "contact": sectionOther,
+	"intereses": sectionOther, "interests": sectionOther, "premios": sectionOther,
+	"awards": sectionOther, "voluntariado": sectionOther, "volunteering": sectionOther,
+}
+
+// heading reconoce un renglón que es solo un título de sección ("EXPERIENCIA
+// LABORAL:", "Skills").
+func heading(line string) (section, bool) {
+	if len([]rune(line)) > 40 {
+		return sectionNone, false
+	}
+	key := strings.Join(strings.Fields(strings.Trim(fold(line), " :.-–|•")), " ")
+	s, ok := headings[key]
+	return s, ok
+}
+
+// ParseProfile infiere los datos estructurados del texto de un CV. Es
+// best-effort: reconoce datos de contacto en cualquier parte, y estudios,
+// puestos y habilidades por las secciones habituales en español, portugués e
+// inglés. now fija "presente" en los períodos abiertos.
+func ParseProfile(text string, now time.Time) models.ResumeData {
+	var data models.ResumeData
+	lines := strings.Split(text, "\n")
+
+	data.Emails = findEmails(text)
+	data.Phones = findPhones(lines)
+	data.LinkedinURL, data.GithubURL, data.Links = findLinks(text)
+	data.FirstName, data.LastName = findName(lines)
+
+	// Agrupa los renglones por sección.
+	current := sectionNone
+	hasExperience := false
+	bySection := map[section][]string{}
+	for _, raw := range lines {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if s, ok := heading(line); ok {
+			current = s
+			hasExperience = hasExperience || s == sectionExperience
+			continue
+		}
+		bySection[current] = append(bySection[current], line)
+	}
+
+	// Sin un encabezado de experiencia, los períodos sueltos antes de
+	// cualquier sección también cuentan como puestos.
+	experienceLines := bySection[sectionExperience]
+	if !hasExperience {
+		experienceLines = bySection[sectionNone]
+	}
+	data.Skills = findSkills(text, strings.Join(bySection[sectionSkills], "\n"))
+	data.Experience = findExperience(experienceLines, data.Skills, now)
+	data.YearsOfExperience = yearsOfExperience(data.Experience)
+	data.SkillYears = skillYears(data.Experience)
+	data.Education = findEducation(bySection[sectionEducation], now)
+	return data
+}
+
+var emailRe = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+func findEmails(text string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, m := range emailRe.FindAllString(text, -1) {
+		email := strings.ToLower(strings.TrimRight(m, "."))
+		if !seen[email] && len(out) < maxEmails {
+			seen[email] = true
+			out = append(out, email)
+		}
+	}
+	return out
+}
+
+var phoneRe = regexp.MustCompile(`\+?\(?\d[\d \t().\-]{6,}\d`)
+
+// findPhones toma las secuencias de 9 a 15 dígitos (con separadores
+// habituales) que no sean solo años, como "2015 - 2018 2019".
+func findPhones(lines []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, line := range lines {
+		for _, m := range phoneRe.FindAllString(line, -1) {
+			digits := strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, m)
+			if len(digits) < 9 || len(digits) > 15 || onlyYears(m) || seen[digits] {
+				continue
+			}
+			seen[digits] = true
+			if len(out) < maxPhones {
+				out = append(out, strings.TrimSpace(m))
+			}
+		}
+	}
+	return out
+}
+
+func onlyYears(s string) bool {
+	groups := strings.FieldsFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	for _, g := range groups {
+		if len(g) != 4 || (!strings.HasPrefix(g, "19") && !strings.HasPrefix(g, "20")) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	urlRe     = regexp.MustCompile(`(?i)\bhttps?://[^\s,;()<>"']+`)
+	profileRe = regexp.MustCompile(`(?i)\b(?:[a-z]{2,3}\.)?(?:www\.)?(linkedin\.com/in|github\.com)/([A-Za-z0-9_\-%.]+)`)
+)
+
+// findLinks separa el perfil de LinkedIn, el de GitHub (normalizados a su
+// URL canónica) y el resto de las URLs.
+func findLinks(text string) (linkedin, github string, links []string) {
+	for _, m := range profileRe.FindAllStringSubmatch(text, -1) {
+		handle := strings.TrimRight(m[2], ".")
+		if handle == "" {
+			continue
+		}
+		if strings.EqualFold(m[1], "github.com") {
+			if github == "" {
+				github = "https://github.com/" + handle
+			}
+		} else if linkedin == "" {
+			linkedin = "https://www.linkedin.com/in/" + handle
+		}
+	}
+	seen := map[string]bool{}
+	for _, m := range urlRe.FindAllString(text, -1) {
+		link := strings.TrimRight(m, ".")
+		lower := strings.ToLower(link)
+		if strings.Contains(lower, "linkedin.com/in/") || strings.Contains(lower, "github.com/") || seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		if len(links) < maxLinks {
+			links = append(links, link)
+		}
+	}
+	return linkedin, github, links
+}
+
+// nameParticles son las partículas que pueden ir en minúscula en un nombre.
+var nameParticles = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "los": true,
+	"da": true, "das": true, "do": true, "dos": true, "e": true, "y": true,
+}
+
+// notNameWords descartan renglones del encabezado que parecen nombres pero
+// son títulos del documento o del puesto.
+var notNameWords = map[string]bool{
+	"curriculum": true, "vitae": true, "cv": true, "resume": true,
+	"developer": true, "engineer": true, "desarrollador": true, "desarrolladora": true,
+	"ingeniero": true, "ingeniera": true, "analista": true, "analyst": true,
+	"manager": true, "gerente": true, "designer": true, "disenador": true,
+	"disenadora": true, "senior": true, "junior": true, "consultant": true,
+	"consultor": true, "consultora": true, "programador": true, "programadora": true,
+	"desenvolvedor": true, "desenvolvedora": true, "engenheiro": true, "engenheira": true,
+}
+
+// findName toma como nombre el primer renglón del encabezado (los primeros
+// cinco) con dos a cuatro palabras capitalizadas, sin dígitos ni símbolos.
+// Un nombre en mayúsculas se capitaliza.
+func findName(lines []string) (first, last string) {
+	checked := 0
+	for _, raw := range lines {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if checked++; checked > 5 {
+			break
+		}
+		words := strings.Fields(line)
+		if len(words) < 2 || len(words) > 5 || len([]rune(line)) > 60 || !nameWords(words) {
+			continue
+		}
+		if _, isHeading := heading(line); isHeading {
+			continue
+		}
+		for i, w := range words {
+			words[i] = capitalize(w)
+		}
+		return words[0], strings.Join(words[1:], " ")
+	}
+	return "", ""
+}
+
+func nameWords(words []string) bool {
+	capitalized := 0
+	for i, w := range words {
+		if notNameWords[fold(w)] {
+			return false
+		}
+		for _, r := range w {
+			if !unicode.IsLetter(r) && r != '\'' && r != '-' && r != '.' {
+				return false
+			}
+		}
+		first := []rune(w)[0]
+		switch {
+		case unicode.IsUpper(first):
+			capitalized++
+		case i > 0 && nameParticles[w]:
+		default:
+			return false
+		}
+	}
+	return capitalized >= 2
+}
+
+func capitalize(w string) string {
+	if nameParticles[w] {
+		return w
+	}
+	runes := []rune(strings.ToLower(w))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// months traduce los nombres de mes (completos o abreviados, ya pasados por
+// fold) en español, portugués e inglés.
+var months = map[string]int{
+	"ene": 1, "enero": 1, "jan": 1, "january": 1, "janeiro": 1,
+	"feb": 2, "febrero": 2, "february": 2, "fev": 2, "fevereiro": 2,
+	"mar": 3, "marzo": 3, "march": 3, "marco": 3,
+	"abr": 4, "abril": 4, "apr": 4, "april": 4,
+	"may": 5, "mayo": 5, "maio": 5,
+	"jun": 6, "junio": 6, "june": 6, "junho": 6,
+	"jul": 7, "julio": 7, "july": 7, "julho": 7,
+	"ago": 8, "agosto": 8, "aug": 8, "august": 8,
+	"sep": 9, "sept": 9, "septiembre": 9, "setiembre": 9, "set": 9, "september": 9, "setembro": 9,
+	"oct": 10, "octubre": 10, "october": 10, "out": 10, "outubro": 10,
+	"nov": 11, "noviembre": 11, "november": 11, "novembro": 11,
+	"dic": 12, "diciembre": 12, "dec": 12, "december": 12, "dez": 12, "dezembro": 12,
+}
+
+var rangeRe = func() *regexp.Regexp {
+	names := make([]string, 0, len(months)+1)
+	for name := range months {
+		names = append(names, name)
+	}
+	names = append(names, "março")
+	// Los nombres largos primero, para que "marzo" no quede como "mar".
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	date := `(?:\b(` + strings.Join(names, "|") + `)\.?\s*(?:de\s+|del\s+)?|\b(\d{1,2})\s*[/.\-]\s*)?\b((?:19|20)\d{2})\b`
+	present := `\b(presente|actualidad|actualmente|actual|hoy|present|currently|current|now|today|atualmente|atual|o momento|el momento|la fecha)\b`
+	sep := `\s*(?:-|–|—|\bto\b|\ba\b|\bhasta\b|\bat[eé]|\bal\b|\buntil\b)\s*`
+	return regexp.MustCompile(`(?i)` + date + sep + `(?:` + date + `|` + present + `)`)
+}()
+
+// findExperience arma un puesto por cada período ("mar 2019 - presente",
+// "03/2017 – 12/2018", "2015 a 2018"). El título es el resto del renglón o,
+// si no queda nada, el renglón anterior. Las habilidades de cada puesto son
+// las de known (las del CV) que aparecen en sus renglones.
+func findExperience(lines, known []string, now time.Time) []models.ResumeExperience {
+	var out []models.ResumeExperience
+	nowIdx := now.Year()*12 + int(now.Month()) - 1
+	var ranged []int
+	for i, line := range lines {
+		if rangeRe.MatchString(line) {
+			ranged = append(ranged, i)
+		}
+	}
+	for n, i := range ranged {
+		line := lines[i]
+		block := strings.Join(experienceBlock(lines, ranged, n), "\n")
+		var skills []string
+		// Dentro del puesto también cuentan las formas ambiguas ("Go"),
+		// pero solo de habilidades que el CV ya declara.
+		for _, name := range findSkills(block, block) {
+			if contains(known, name) {
+				skills = append(skills, name)
+			}
+		}
+		for _, m := range rangeRe.FindAllStringSubmatchIndex(line, -1) {
+			group := func(n int) string {
+				if m[2*n] < 0 {
+					return ""
+				}
+				return line[m[2*n]:m[2*n+1]]
+			}
+			startIdx := monthIndex(group(1), group(2), group(3), 1)
+			endIdx := nowIdx
+			current := group(7) != ""
+			if !current {
+				endIdx = monthIndex(group(4), group(5), group(6), 12)
+				if endIdx > nowIdx {
+					endIdx = nowIdx
+				}
+			}
+			months := endIdx - startIdx + 1
+			if startIdx > nowIdx || months <= 0 || months > 600 {
+				continue
+			}
+			exp := models.ResumeExperience{
+				Title:   experienceTitle(lines, i, line[:m[0]]+" "+line[m[1]:]),
+				Start:   formatMonth(startIdx),
+				Current: current,
+				Months:  months,
+				Skills:  skills,
+			}
+			if !current {
+				exp.End = formatMonth(endIdx)
+			}
+			if len(out) < maxExperience {
+				out = append(out, exp)
+			}
+		}
+	}
+	return out
+}
+
+// experienceBlock devuelve los renglones del puesto del renglón ranged[n]:
+// desde el período hasta antes del siguiente. El renglón previo al siguiente
+// período se descarta porque suele ser el título de ese puesto.
+func experienceBlock(lines []string, ranged []int, n int) []string {
+	start, end := ranged[n], len(lines)
+	if n+1 < len(ranged) {
+		end = ranged[n+1]
+		if end-1 > start+1 {
+			end--
+		}
+	}
+	if start > 0 && cleanTitle(rangeRe.ReplaceAllString(lines[start], "")) == "" && (n == 0 || ranged[n-1] < start-1) {
+		start--
+	}
+	return lines[start:end]
+}
+
+// monthIndex convierte un año y mes (nombre o número; defaultMonth si
+// falta) en meses desde el año 0.
+func monthIndex(name, number, year string, defaultMonth int) int {
+	y, _ := strconv.Atoi(year)
+	m := defaultMonth
+	if name != "" {
+		if v, ok := months[fold(name)]; ok {
+			m = v
+		}
+	} else if n, err := strconv.Atoi(number); err == nil && n >= 1 && n <= 12 {
+		m = n
+	}
+	return y*12 + m - 1
+}
+
+func formatMonth(idx int) string {
+	return fmt.Sprintf("%04d-%02d", idx/12, idx%12+1)
+}
+
+func experienceTitle(lines []string, i int, rest string) string {
+	title := cleanTitle(rest)
+	if title == "" && i > 0 && !rangeRe.MatchString(lines[i-1]) {
+		title = cleanTitle(lines[i-1])
+	}
+	if runes := []rune(title); len(runes) > maxTitle {
+		title = string(runes[:maxTitle])
+	}
+	return title
+}
+
+func cleanTitle(s string) string {
+	return strings.Join(strings.Fields(strings.Trim(s, " \t,;:|()[]-–—•")), " ")
+}
+
+// yearsOfExperience suma los meses de los puestos uniendo los períodos que
+// se superponen (dos trabajos a la vez no cuentan doble), en años con un
+// decimal.
+func yearsOfExperience(exps []models.ResumeExperience) float64 {
+	type interval struct{ start, end int }
+	var spans []interval
+	for _, e := range exps {
+		start := parseMonth(e.Start)
+		spans = append(spans, interval{start, start + e.Months - 1})
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	total := 0
+	for i := 0; i < len(spans); {
+		cur := spans[i]
+		j := i + 1
+		for ; j < len(spans) && spans[j].start <= cur.end+1; j++ {
+			if spans[j].end > cur.end {
+				cur.end = spans[j].end
+			}
+		}
+		total += cur.end - cur.start + 1
+		i = j
+	}
+	return math.Round(float64(total)/12*10) / 10
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// skillYears suma, por habilidad, los puestos que la mencionan.
+func skillYears(exps []models.ResumeExperience) map[string]float64 {
+	bySkill := map[string][]models.ResumeExperience{}
+	for _, e := range exps {
+		for _, name := range e.Skills {
+			bySkill[name] = append(bySkill[name], e)
+		}
+	}
+	if len(bySkill) == 0 {
+		return nil
+	}
+	out := make(map[string]float64, len(bySkill))
+	for name, list := range bySkill {
+		out[name] = yearsOfExperience(list)
+	}
+	return out
+}
+
+func parseMonth(s string) int {
+	var y, m int
+	fmt.Sscanf(s, "%d-%d", &y, &m)
+	return y*12 + m - 1
+}
+
+// Palabras que identifican un título y una institución en la sección de
+// estudios (ya pasadas por fold).
+var (
+	degreeWords = []string{
+		"licenciatura", "licenciado", "licenciada", "ingenieria", "ingeniero", "ingeniera",
+		"tecnicatura", "tecnico", "tecnica", "tecnologo", "bachiller", "bachelor",
+		"bacharelado", "graduacao", "master", "maestria", "magister", "mestrado", "mba",
+		"doctorado", "doutorado", "phd", "posgrado", "pos-graduacao", "especializacion",
+		"especializacao", "diplomado", "diploma", "degree", "grado en",
+	}
+	institutionWords = []string{
+		"universidad", "universidade", "university", "instituto", "institute",
+		"college", "escuela", "escola", "faculdade", "facultad", "school",
+		"colegio", "academia", "politecnico", "unam", "usp", "uba",
+	}
+	yearRe = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+)
+
+// findEducation recorre la sección de estudios: un renglón con un título o
+// una institución abre un estudio, y el siguiente renglón completa lo que
+// falte. El año es el mayor que aparezca (el de finalización).
+func findEducation(lines []string, now time.Time) []models.ResumeEducation {
+	var out []models.ResumeEducation
+	var cur *models.ResumeEducation
+	flush := func() {
+		if cur != nil && len(out) < maxEducation {
+			out = append(out, *cur)
+		}
+		cur = nil
+	}
+	for _, line := range lines {
+		folded := fold(line)
+		isDegree := containsAny(folded, degreeWords)
+		isInstitution := containsAny(folded, institutionWords)
+		year := lastYear(line, now.Year()+6)
+		if !isDegree && !isInstitution {
+			if cur != nil && cur.Year == 0 {
+				cur.Year = year
+			}
+			continue
+		}
+		if cur == nil || (isDegree && cur.Degree != "") || (isInstitution && cur.Institution != "") {
+			flush()
+			cur = &models.ResumeEducation{}
+		}
+		text := cleanTitle(yearRe.ReplaceAllString(rangeRe.ReplaceAllString(line, ""), ""))
+		switch {
+		case isDegree && isInstitution:
+			cur.Degree, cur.Institution = splitEducation(text)
+		case isDegree:
+			cur.Degree = text
+		default:
+			cur.Institution = text
+		}
+		if year > 0 {
+			cur.Year = year
+		}
+	}
+	flush()
+	return out
+}
+
+// splitEducation separa "Licenciatura en Sistemas - Universidad de Buenos
+// Aires" en título e institución.
+func splitEducation(text string) (degree, institution string) {
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == '|' || r == '–' || r == '—' || r == '•'
+	})
+	if len(parts) == 1 {
+		parts = strings.Split(text, " - ")
+	}
+	for _, p := range parts {
+		p = cleanTitle(p)
+		if institution == "" && containsAny(fold(p), institutionWords) {
+			institution = p
+		} else if degree == "" {
+			degree = p
+		}
+	}
+	if degree == "" {
+		degree = text
+	}
+	return degree, institution
+}
+
+func containsAny(folded string, words []string) bool {
+	for _, w := range words {
+		if containsTerm(folded, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func lastYear(line string, max int) int {
+	best := 0
+	for _, m := range yearRe.FindAllString(line, -1) {
+		if y, _ := strconv.Atoi(m); y > best && y <= max {
+			best = y
+		}
+	}
+	return best
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+var now = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+const sampleES = `CURRICULUM VITAE
+MARÍA JOSÉ PEÑA
+Desarrolladora Backend Senior
+maria.pena@Example.com | +54 9 11 5555-1234 | linkedin.com/in/mariapena/
+https://github.com/mpena · https://mpena.dev
+
+Experiencia Laboral
+Desarrolladora Backend — Acme S.A.
+Marzo 2021 - Presente
+Go, PostgreSQL, Kubernetes y microservicios.
+Freelance | ene. 2020 – jun. 2021
+Analista Programadora, Banco Sur 2015 - 2018
+
+Educación
+Ingeniería en Sistemas
+Universidad de Buenos Aires, 2010 - 2015
+
+Habilidades
+Go, Python, Docker, React.js, C++, REST
+Idiomas
+Inglés avanzado`
+
+func TestParseProfileSpanish(t *testing.T) {
+	got := ParseProfile(sampleES, now)
+
+	if got.FirstName != "María" || got.LastName != "José Peña" {
+		t.Errorf("name = %q %q", got.FirstName, got.LastName)
+	}
+	if want := []string{"maria.pena@example.com"}; !reflect.DeepEqual(got.Emails, want) {
+		t.Errorf("emails = %v", got.Emails)
+	}
+	if want := []string{"+54 9 11 5555-1234"}; !reflect.DeepEqual(got.Phones, want) {
+		t.Errorf("phones = %v", got.Phones)
+	}
+	if got.LinkedinURL != "https://www.linkedin.com/in/mariapena" || got.GithubURL != "https://github.com/mpena" {
+		t.Errorf("profiles = %q %q", got.LinkedinURL, got.GithubURL)
+	}
+	if want := []string{"https://mpena.dev"}; !reflect.DeepEqual(got.Links, want) {
+		t.Errorf("links = %v", got.Links)
+	}
+
+	wantExp := []models.ResumeExperience{
+		{Title: "Desarrolladora Backend — Acme S.A.", Start: "2021-03", Current: true, Months: 68,
+			Skills: []string{"Go", "Microservicios", "PostgreSQL", "Kubernetes"}},
+		{Title: "Freelance", Start: "2020-01", End: "2021-06", Months: 18},
+		{Title: "Analista Programadora, Banco Sur", Start: "2015-01", End: "2018-12", Months: 48},
+	}
+	if !reflect.DeepEqual(got.Experience, wantExp) {
+		t.Errorf("experience = %+v", got.Experience)
+	}
+	// 2020-01..2026-10 (82 meses, unidos) + 48 = 130 meses.
+	if got.YearsOfExperience != 10.8 {
+		t.Errorf("years = %v", got.YearsOfExperience)
+	}
+	wantSkillYears := map[string]float64{"Go": 5.7, "Microservicios": 5.7, "PostgreSQL": 5.7, "Kubernetes": 5.7}
+	if !reflect.DeepEqual(got.SkillYears, wantSkillYears) {
+		t.Errorf("skill years = %v", got.SkillYears)
+	}
+
+	wantEdu := []models.ResumeEducation{{Degree: "Ingeniería en Sistemas", Institution: "Universidad de Buenos Aires", Year: 2015}}
+	if !reflect.DeepEqual(got.Education, wantEdu) {
+		t.Errorf("education = %+v", got.Education)
+	}
+
+	wantSkills := []string{"Go", "Python", "C++", "React", "REST", "Microservicios", "PostgreSQL", "Docker", "Kubernetes"}
+	if !reflect.DeepEqual(got.Skills, wantSkills) {
+		t.Errorf("skills = %v", got.Skills)
+	}
+}
+
+func TestParseProfilePortugueseWithoutHeadings(t *testing.T) {
+	text := "João da Silva\njoao@exemplo.com.br\nDesenvolvedor Java  março 2019 até atualmente\nSuporte técnico 02/2017 a 12/2018\nGo e Java"
+	got := ParseProfile(text, now)
+	if got.FirstName != "João" || got.LastName != "da Silva" {
+		t.Errorf("name = %q %q", got.FirstName, got.LastName)
+	}
+	if len(got.Experience) != 2 || got.Experience[0].Start != "2019-03" || !got.Experience[0].Current ||
+		got.Experience[1].Start != "2017-02" || got.Experience[1].End != "2018-12" {
+		t.Fatalf("experience = %+v", got.Experience)
+	}
+	// "go" fuera de la sección de habilidades no cuenta, tampoco en un puesto.
+	if want := []string{"Java"}; !reflect.DeepEqual(got.Skills, want) {
+		t.Errorf("skills = %v", got.Skills)
+	}
+	// 2019-03..2026-10 (92 meses) + 2017-02..2018-12 (23 meses).
+	if want := map[string]float64{"Java": 9.6}; !reflect.DeepEqual(got.SkillYears, want) {
+		t.Errorf("skill years = %v", got.SkillYears)
+	}
+}
+
+func TestContainsTerm(t *testing.T) {
+	cases := []struct {
+		text, term string
+		want       bool
+	}{
+		{"java y javascript", "java", true},
+		{"javascript", "java", false},
+		{"c++ y c#", "c", false},
+		{"lenguaje c, c++", "c", true},
+		{"node.js", "js", false},
+		{"vue.js.", "vue.js", true},
+		{"react-native", "react", false},
+		{"html/css", "html", true},
+		{"experiencia en .net core", ".net", true},
+	}
+	for _, c := range cases {
+		if got := containsTerm(c.text, c.term); got != c.want {
+			t.Errorf("containsTerm(%q, %q) = %v", c.text, c.term, got)
+		}
+	}
+}
+
+func TestYearsOfExperienceMergesOverlaps(t *testing.T) {
+	exps := []models.ResumeExperience{
+		{Start: "2018-01", Months: 24},
+		{Start: "2019-01", Months: 24}, // se superpone un año
+		{Start: "2022-01", Months: 6},
+	}
+	if got := yearsOfExperience(exps); got != 3.5 {
+		t.Fatalf("got %v, want 3.5", got)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	c := &models.Candidate{FirstName: "María", Phone: "+54 9 11 5555 1234", GithubURL: "https://github.com/mpena/"}
+	data := models.ResumeData{FirstName: "María", LastName: "Peña", Phones: []string{"+54 9 11 5555-1234"},
+		LinkedinURL: "https://www.linkedin.com/in/mariapena", GithubURL: "https://github.com/mpena"}
+	got := Suggest(c, data)
+	want := []Suggestion{
+		{Field: FieldLastName, Value: "Peña"},
+		{Field: FieldLinkedin, Value: "https://www.linkedin.com/in/mariapena"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestUploadPath(t *testing.T) {
+	cases := map[string]string{
+		"/uploads/companies/acme/resumes/x.docx":                 "companies/acme/resumes/x.docx",
+		"/uploads/companies/acme/resumes/a/../b.pdf":             "companies/acme/resumes/b.pdf",
+		"/uploads/companies/globex/resumes/1700000000_a_b.pdf":   "", // otra empresa
+		"/uploads/companies/acme/resumes/../../globex/resumes/x": "",
+		"/uploads/companies/acme/comments/1/a.pdf":               "", // no es un CV
+		"/uploads/resumes/1_2_cv.pdf":                            "", // fuera del directorio de la empresa
+		"/uploads/resumes/../../etc/passwd":                      "",
+		"/uploads/../secret":                                     "",
+		"https://storage.example.com/uploads/cv.pdf":             "",
+		"/uploads/":                                    "",
+		"/uploads/companies/acme/resumes/":             "",
+		"/uploads/companies\\acme\\resumes\\..\\x.pdf": "",
+	}
+	for url, want := range cases {
+		got, ok := UploadPath(url, "acme")
+		if got != want || ok != (want != "") {
+			t.Errorf("UploadPath(%q) = %q, %v", url, got, ok)
+		}
+	}
+	for _, slug := range []string{"", "..", "acme/../globex"} {
+		if _, ok := UploadPath("/uploads/companies/acme/resumes/x.pdf", slug); ok {
+			t.Errorf("slug %q aceptado", slug)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package domain
+
+import "strings"
+
+// skill es una habilidad del catálogo: Name es como se muestra; aliases son
+// las formas de escribirla (ya pasadas por fold) que se buscan en todo el CV,
+// y sectionAliases las que son palabras comunes ("go", "r", "spring") y solo
+// cuentan dentro de la sección de habilidades.
+type skill struct {
+	name           string
+	aliases        []string
+	sectionAliases []string
+}
+
+// catalog es el catálogo de habilidades que reconoce el análisis.
+var catalog = []skill{
+	// Lenguajes
+	{name: "Go", aliases: []string{"golang"}, sectionAliases: []string{"go"}},
+	{name: "Python", aliases: []string{"python"}},
+	{name: "Java", aliases: []string{"java"}},
+	{name: "JavaScript", aliases: []string{"javascript", "ecmascript"}, sectionAliases: []string{"js"}},
+	{name: "TypeScript", aliases: []string{"typescript"}, sectionAliases: []string{"ts"}},
+	{name: "C#", aliases: []string{"c#", "csharp"}},
+	{name: "C++", aliases: []string{"c++", "cpp"}},
+	{name: "C", sectionAliases: []string{"c"}},
+	{name: "PHP", aliases: []string{"php"}},
+	{name: "Ruby", aliases: []string{"ruby"}},
+	{name: "Rust", aliases: []string{"rust"}},
+	{name: "Kotlin", aliases: []string{"kotlin"}},
+	{name: "Swift", sectionAliases: []string{"swift"}},
+	{name: "Scala", aliases: []string{"scala"}},
+	{name: "R", sectionAliases: []string{"r"}},
+	{name: "Elixir", aliases: []string{"elixir"}},
+	{name: "Dart", aliases: []string{"dart"}},
+	{name: "SQL", aliases: []string{"sql"}},
+	{name: "Bash", aliases: []string{"bash", "shell scripting"}},
+
+	// Frontend
+	{name: "React", aliases: []string{"react", "react.js", "reactjs"}},
+	{name: "Angular", aliases: []string{"angular", "angularjs"}},
+	{name: "Vue.js", aliases: []string{"vue.js", "vuejs"}, sectionAliases: []string{"vue"}},
+	{name: "Next.js", aliases: []string{"next.js", "nextjs"}},
+	{name: "Svelte", aliases: []string{"svelte"}},
+	{name: "HTML", aliases: []string{"html", "html5"}},
+	{name: "CSS", aliases: []string{"css", "css3"}},
+	{name: "Sass", aliases: []string{"sass", "scss"}},
+	{name: "Tailwind CSS", aliases: []string{"tailwind", "tailwindcss"}},
+	{name: "jQuery", aliases: []string{"jquery"}},
+	{name: "Redux", aliases: []string{"redux"}},
+
+	// Backend
+	{name: "Node.js", aliases: []string{"node.js", "nodejs"}, sectionAliases: []string{"node"}},
+	{name: "Express", aliases: []string{"express.js", "expressjs"}, sectionAliases: []string{"express"}},
+	{name: "Django", aliases: []string{"django"}},
+	{name: "Flask", aliases: []string{"flask"}},
+	{name: "FastAPI", aliases: []string{"fastapi"}},
+	{name: "Spring", aliases: []string{"spring boot", "spring framework"}, sectionAliases: []string{"spring"}},
+	{name: ".NET", aliases: []string{".net", "dotnet", "asp.net"}},
+	{name: "Laravel", aliases: []string{"laravel"}},
+	{name: "Ruby on Rails", aliases: []string{"ruby on rails", "rails"}},
+	{name: "GraphQL", aliases: []string{"graphql"}},
+	{name: "REST", aliases: []string{"rest api", "api rest", "restful"}, sectionAliases: []string{"rest"}},
+	{name: "gRPC", aliases: []string{"grpc"}},
+	{name: "Microservicios", aliases: []string{"microservicios", "microservices", "microsservicos"}},
+
+	// Datos
+	{name: "PostgreSQL", aliases: []string{"postgresql", "postgres"}},
+	{name: "MySQL", aliases: []string{"mysql"}},
+	{name: "SQL Server", aliases: []string{"sql server", "mssql"}},
+	{name: "Oracle", aliases: []string{"oracle"}},
+	{name: "MongoDB", aliases: []string{"mongodb"}, sectionAliases: []string{"mongo"}},
+	{name: "Redis", aliases: []string{"redis"}},
+	{name: "Elasticsearch", aliases: []string{"elasticsearch"}},
+	{name: "Kafka", aliases: []string{"kafka"}},
+	{name: "RabbitMQ", aliases: []string{"rabbitmq"}},
+	{name: "Spark", aliases: []string{"apache spark", "pyspark"}, sectionAliases: []string{"spark"}},
+	{name: "Pandas", aliases: []string{"pandas"}},
+	{name: "NumPy", aliases: []string{"numpy"}},
+	{name: "TensorFlow", aliases: []string{"tensorflow"}},
+	{name: "PyTorch", aliases: []string{"pytorch"}},
+	{name: "scikit-learn", aliases: []string{"scikit-learn", "sklearn"}},
+	{name: "Machine Learning", aliases: []string{"machine learning", "aprendizaje automatico", "aprendizado de maquina"}},
+	{name: "Power BI", aliases: []string{"power bi", "powerbi"}},
+	{name: "Tableau", aliases: []string{"tableau"}},
+	{name: "Excel", sectionAliases: []string{"excel"}},
+
+	// Cloud y DevOps
+	{name: "AWS", aliases: []string{"aws", "amazon web services"}},
+	{name: "Azure", aliases: []string{"azure"}},
+	{name: "Google Cloud", aliases: []string{"gcp", "google cloud"}},
+	{name: "Docker", aliases: []string{"docker"}},
+	{name: "Kubernetes", aliases: []string{"kubernetes", "k8s"}},
+	{name: "Terraform", aliases: []string{"terraform"}},
+	{name: "Ansible", aliases: []string{"ansible"}},
+	{name: "Jenkins", aliases: []string{"jenkins"}},
+	{name: "GitHub Actions", aliases: []string{"github actions"}},
+	{name: "GitLab CI", aliases: []string{"gitlab ci", "gitlab-ci"}},
+	{name: "CI/CD", aliases: []string{"ci/cd"}},
+	{name: "Linux", aliases: []string{"linux"}},
+	{name: "Git", aliases: []string{"git"}},
+	{name: "Nginx", aliases: []string{"nginx"}},
+
+	// Mobile
+	{name: "Android", aliases: []string{"android"}},
+	{name: "iOS", aliases: []string{"ios"}},
+	{name: "Flutter", aliases: []string{"flutter"}},
+	{name: "React Native", aliases: []string{"react native"}},
+
+	// Testing
+	{name: "Selenium", aliases: []string{"selenium"}},
+	{name: "Cypress", aliases: []string{"cypress"}},
+	{name: "Jest", aliases: []string{"jest"}},
+	{name: "JUnit", aliases: []string{"junit"}},
+
+	// Producto, diseño y gestión
+	{name: "Figma", aliases: []string{"figma"}},
+	{name: "Scrum", aliases: []string{"scrum"}},
+	{name: "Kanban", aliases: []string{"kanban"}},
+	{name: "Jira", aliases: []string{"jira"}},
+	{name: "UX", aliases: []string{"ux", "ux/ui", "ui/ux"}},
+	{name: "SAP", aliases: []string{"sap"}},
+	{name: "Salesforce", aliases: []string{"salesforce"}},
+}
+
+// findSkills devuelve las habilidades del catálogo que aparecen en el CV, en
+// el orden del catálogo.
+func findSkills(text, skillsSection string) []string {
+	all, section := fold(text), fold(skillsSection)
+	var out []string
+	for _, s := range catalog {
+		found := false
+		for _, a := range s.aliases {
+			if found = containsTerm(all, a); found {
+				break
+			}
+		}
+		for _, a := range s.sectionAliases {
+			if found {
+				break
+			}
+			found = containsTerm(section, a)
+		}
+		if found && len(out) < maxSkills {
+			out = append(out, s.name)
+		}
+	}
+	return out
+}
+
+// containsTerm busca term como palabra completa: "java" no coincide con
+// "javascript", "c" no coincide con "c++" ni "js" con "node.js". Un punto o
+// guion seguido de letra ("vue.js", "react-native") continúa la palabra.
+func containsTerm(text, term string) bool {
+	for from := 0; ; {
+		i := strings.Index(text[from:], term)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(term)
+		if !wordBefore(text, start) && !wordAfter(text, end) {
+			return true
+		}
+		from = start + 1
+	}
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '+' || c == '#' || c >= 0x80
+}
+
+func wordBefore(text string, i int) bool {
+	if i == 0 {
+		return false
+	}
+	c := text[i-1]
+	if (c == '.' || c == '-') && i >= 2 {
+		return isWordByte(text[i-2])
+	}
+	return isWordByte(c)
+}
+
+func wordAfter(text string, i int) bool {
+	if i >= len(text) {
+		return false
+	}
+	c := text[i]
+	if (c == '.' || c == '-') && i+1 < len(text) {
+		return isWordByte(text[i+1])
+	}
+	return isWordByte(c)
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"path"
+	"strings"
+
+	"dvra-api/internal/app/models"
+)
+
+// Campos de la ficha del candidato que se pueden completar desde el CV. El
+// email no: identifica al candidato en la empresa.
+const (
+	FieldFirstName = "first_name"
+	FieldLastName  = "last_name"
+	FieldPhone     = "phone"
+	FieldLinkedin  = "linkedin_url"
+	FieldGithub    = "github_url"
+)
+
+// Suggestion es un valor leído del CV que difiere del de la ficha. Current
+// vacío = la ficha no tiene el dato.
+type Suggestion struct {
+	Field   string
+	Value   string
+	Current string
+}
+
+// Suggest compara los datos del CV con la ficha del candidato, en el orden
+// de la ficha.
+func Suggest(c *models.Candidate, data models.ResumeData) []Suggestion {
+	phone := ""
+	if len(data.Phones) > 0 {
+		phone = data.Phones[0]
+	}
+	fields := []Suggestion{
+		{Field: FieldFirstName, Value: data.FirstName, Current: c.FirstName},
+		{Field: FieldLastName, Value: data.LastName, Current: c.LastName},
+		{Field: FieldPhone, Value: phone, Current: c.Phone},
+		{Field: FieldLinkedin, Value: data.LinkedinURL, Current: c.LinkedinURL},
+		{Field: FieldGithub, Value: data.GithubURL, Current: c.GithubURL},
+	}
+	var out []Suggestion
+	for _, s := range fields {
+		if s.Value != "" && !sameValue(s.Field, s.Value, s.Current) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// sameValue ignora mayúsculas, y en los teléfonos todo lo que no sea dígito.
+func sameValue(field, a, b string) bool {
+	if field == FieldPhone {
+		return digits(a) == digits(b)
+	}
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// UploadPath traduce la URL de un CV subido ("/uploads/...") a su ruta
+// relativa dentro del directorio de uploads. Solo acepta los CVs de la
+// empresa del candidato (companies/<slug>/resumes/, donde los guardan los
+// handlers de subida): ok=false si la URL apunta a otro lado (almacenamiento
+// externo, otra empresa) o intenta salir del directorio.
+func UploadPath(url, companySlug string) (string, bool) {
+	rel, found := strings.CutPrefix(url, "/uploads/")
+	if !found || rel == "" || strings.Contains(rel, "\\") {
+		return "", false
+	}
+	if companySlug == "" || companySlug == "." || companySlug == ".." || strings.ContainsAny(companySlug, "/\\") {
+		return "", false
+	}
+	clean := path.Clean(rel)
+	if !strings.HasPrefix(clean, "companies/"+companySlug+"/resumes/") {
+		return "", false
+	}
+	return clean, true
+}
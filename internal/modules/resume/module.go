@@ -0,0 +1,72 @@
+// Package resume es el punto de ensamblaje del módulo de análisis de CVs:
+// extrae el texto de los PDF y DOCX subidos (para la búsqueda) e infiere
+// datos de contacto, enlaces, habilidades, estudios y experiencia para
+// completar la ficha del candidato. Nadie importa este paquete salvo el
+// composition root.
+package resume
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"dvra-api/internal/modules/resume/domain"
+	"dvra-api/internal/modules/resume/repository"
+	"dvra-api/internal/modules/resume/service"
+	"dvra-api/internal/modules/resume/transport"
+	"dvra-api/internal/platform/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// uploadsDir es donde los handlers guardan los archivos subidos.
+	uploadsDir = "./uploads"
+	// maxResumeSize acota el archivo que se lee (los handlers aceptan hasta
+	// 10 MB).
+	maxResumeSize = 20 << 20
+	// parseInterval es la frecuencia de la tarea que analiza los CVs.
+	parseInterval = 30 * time.Second
+)
+
+// Module agrupa las dependencias ya cableadas del módulo resume. Service
+// implementa el puerto con el que candidates y la career page agendan el
+// análisis.
+type Module struct {
+	Service *service.ResumeService
+}
+
+// New construye el módulo. skills carga en la ficha las habilidades de cada
+// CV analizado.
+func New(db *gorm.DB, skills domain.SkillSync) *Module {
+	return &Module{Service: service.NewResumeService(repository.NewParseRepository(db), readUpload, skills)}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
+
+// RegisterJobs registra las tareas periódicas del módulo.
+func (m *Module) RegisterJobs(s *scheduler.Scheduler) {
+	s.Add(scheduler.Job{Name: "resume.parse", Interval: parseInterval, Run: m.Service.ProcessPending})
+}
+
+func readUpload(path string) ([]byte, error) {
+	f, err := os.Open(filepath.Join(uploadsDir, filepath.FromSlash(path)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxResumeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResumeSize {
+		return nil, fmt.Errorf("resume file exceeds %d bytes", maxResumeSize)
+	}
+	return data, nil
+}
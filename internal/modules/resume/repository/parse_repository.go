@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/resume/domain"
+
+	"gorm.io/gorm"
+)
+
+type parseRepository struct {
+	db *gorm.DB
+}
+
+// NewParseRepository devuelve la implementación del puerto.
+func NewParseRepository(db *gorm.DB) domain.ParseRepository {
+	return &parseRepository{db: db}
+}
+
+func (r *parseRepository) Create(parse *models.ResumeParse) error {
+	return r.db.Create(parse).Error
+}
+
+func (r *parseRepository) Pending(limit int) ([]models.ResumeParse, error) {
+	var parses []models.ResumeParse
+	err := r.db.Where("status = ?", models.ResumeParsePending).
+		Order("id").Limit(limit).Find(&parses).Error
+	return parses, err
+}
+
+func (r *parseRepository) Complete(parse *models.ResumeParse, text string) (bool, error) {
+	current := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(parse).Updates(map[string]interface{}{
+			"status":    parse.Status,
+			"error":     parse.Error,
+			"data":      parse.Data,
+			"parsed_at": parse.ParsedAt,
+		}).Error; err != nil {
+			return err
+		}
+		// Solo si el CV sigue siendo el vigente: si lo reemplazaron mientras
+		// tanto, el texto lo pone el análisis del archivo nuevo. Actualizar
+		// updated_at hace que la búsqueda lo reindexe.
+		result := tx.Model(&models.Candidate{}).
+			Where("id = ? AND resume_url = ?", parse.CandidateID, parse.ResumeURL).
+			Updates(map[string]interface{}{"resume_text": text})
+		current = result.RowsAffected > 0
+		return result.Error
+	})
+	return current, err
+}
+
+func (r *parseRepository) Latest(candidateID uint, resumeURL string) (*models.ResumeParse, error) {
+	var parse models.ResumeParse
+	err := r.db.Where("candidate_id = ? AND resume_url = ?", candidateID, resumeURL).
+		Order("id DESC").First(&parse).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &parse, nil
+}
+
+func (r *parseRepository) Candidate(companyID, candidateID uint) (*models.Candidate, error) {
+	query := r.db.Where("id = ? AND anonymized_at IS NULL", candidateID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var candidate models.Candidate
+	err := query.First(&candidate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+func (r *parseRepository) UpdateCandidate(candidate *models.Candidate, fields map[string]interface{}) error {
+	return r.db.Model(candidate).Updates(fields).Error
+}
+
+func (r *parseRepository) CompanySlug(candidateID uint) (string, error) {
+	var slugs []string
+	err := r.db.Model(&models.Company{}).
+		Joins("JOIN candidates ON candidates.company_id = companies.id").
+		Where("candidates.id = ?", candidateID).
+		Limit(1).Pluck("companies.slug", &slugs).Error
+	if err != nil || len(slugs) == 0 {
+		return "", err
+	}
+	return slugs[0], nil
+}
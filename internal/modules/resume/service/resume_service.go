@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/resume/domain"
+	"dvra-api/internal/platform/textextract"
+	"dvra-api/internal/shared/apperr"
+
+	"gorm.io/datatypes"
+)
+
+// processBatch es la cantidad de CVs que se analizan por pasada.
+const processBatch = 20
+
+// FileReader lee un archivo subido por su ruta relativa al directorio de
+// uploads (ver domain.UploadPath).
+type FileReader func(path string) ([]byte, error)
+
+// ResumeService analiza los CVs subidos en segundo plano y ofrece sus datos
+// para completar la ficha del candidato.
+type ResumeService struct {
+	repo   domain.ParseRepository
+	read   FileReader
+	skills domain.SkillSync
+}
+
+func NewResumeService(repo domain.ParseRepository, read FileReader, skills domain.SkillSync) *ResumeService {
+	return &ResumeService{repo: repo, read: read, skills: skills}
+}
+
+// Enqueue agenda el análisis del CV resumeURL del candidato (lo hace la
+// tarea resume.parse). Lo llaman candidates y la career page al guardar un
+// CV: un fallo se registra y nunca afecta la operación que lo originó.
+func (s *ResumeService) Enqueue(companyID, candidateID uint, resumeURL string) {
+	if resumeURL == "" {
+		return
+	}
+	parse := &models.ResumeParse{
+		CompanyID:   companyID,
+		CandidateID: candidateID,
+		ResumeURL:   resumeURL,
+		Status:      models.ResumeParsePending,
+	}
+	if err := s.repo.Create(parse); err != nil {
+		log.Printf("⚠️  CV: no se pudo encolar el análisis del candidato %d: %v", candidateID, err)
+	}
+}
+
+// ProcessPending analiza los CVs pendientes: extrae el texto (que pasa a la
+// búsqueda) e infiere el perfil, cuyas habilidades se suman a la ficha si el
+// CV sigue vigente. Un archivo ilegible queda failed o unsupported con el
+// motivo; no se reintenta.
+func (s *ResumeService) ProcessPending(ctx context.Context) error {
+	parses, err := s.repo.Pending(processBatch)
+	if err != nil {
+		return err
+	}
+	for i := range parses {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		parse := &parses[i]
+		slug, err := s.repo.CompanySlug(parse.CandidateID)
+		if err != nil {
+			return err
+		}
+		text := s.analyze(parse, slug)
+		current, err := s.repo.Complete(parse, text)
+		if err != nil {
+			return err
+		}
+		if current && parse.Status == models.ResumeParseCompleted {
+			if err := s.skills.SyncFromResume(parse.CompanyID, parse.CandidateID, parse.Data.Data()); err != nil {
+				log.Printf("⚠️  CV: no se pudieron cargar las habilidades del candidato %d: %v", parse.CandidateID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// analyze completa Status, Error, Data y ParsedAt del análisis y devuelve el
+// texto extraído ("" si falló). Solo lee archivos de la carpeta de CVs de la
+// empresa del candidato (companySlug).
+func (s *ResumeService) analyze(parse *models.ResumeParse, companySlug string) string {
+	now := time.Now()
+	parse.ParsedAt = &now
+	path, ok := domain.UploadPath(parse.ResumeURL, companySlug)
+	if !ok {
+		parse.Status, parse.Error = models.ResumeParseUnsupported, "the resume is not stored in the company's uploads"
+		return ""
+	}
+	data, err := s.read(path)
+	if err != nil {
+		parse.Status, parse.Error = models.ResumeParseFailed, "the resume file could not be read"
+		return ""
+	}
+	text, err := textextract.Extract(data)
+	switch {
+	case errors.Is(err, textextract.ErrUnsupported):
+		parse.Status, parse.Error = models.ResumeParseUnsupported, err.Error()
+		return ""
+	case err != nil:
+		parse.Status, parse.Error = models.ResumeParseFailed, err.Error()
+		return ""
+	}
+	parse.Status, parse.Error = models.ResumeParseCompleted, ""
+	parse.Data = datatypes.NewJSONType(domain.ParseProfile(text, now))
+	return text
+}
+
+// Get devuelve el análisis del CV vigente del candidato y, si terminó, los
+// datos que difieren de la ficha. companyID 0 = SuperAdmin.
+func (s *ResumeService) Get(companyID, candidateID uint) (*dtos.CandidateResumeDTO, error) {
+	candidate, err := s.candidate(companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	result := &dtos.CandidateResumeDTO{Suggestions: []dtos.ResumeFieldSuggestionDTO{}}
+	if candidate.ResumeURL == "" {
+		return result, nil
+	}
+	parse, err := s.repo.Latest(candidate.ID, candidate.ResumeURL)
+	if err != nil || parse == nil {
+		return result, err
+	}
+	result.Parse = dtos.ToResumeParseDTO(parse)
+	if parse.Status == models.ResumeParseCompleted {
+		for _, sg := range domain.Suggest(candidate, parse.Data.Data()) {
+			result.Suggestions = append(result.Suggestions, dtos.ResumeFieldSuggestionDTO{
+				Field: sg.Field, Value: sg.Value, Current: sg.Current,
+			})
+		}
+	}
+	return result, nil
+}
+
+// Reparse vuelve a agendar el análisis del CV vigente (p. ej. después de un
+// fallo o de mejoras en el análisis).
+func (s *ResumeService) Reparse(companyID, candidateID uint) (*dtos.ResumeParseDTO, error) {
+	candidate, err := s.candidate(companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.ResumeURL == "" {
+		return nil, apperr.Unprocessable("the candidate has no resume")
+	}
+	parse := &models.ResumeParse{
+		CompanyID:   candidate.CompanyID,
+		CandidateID: candidate.ID,
+		ResumeURL:   candidate.ResumeURL,
+		Status:      models.ResumeParsePending,
+	}
+	if err := s.repo.Create(parse); err != nil {
+		return nil, err
+	}
+	return dtos.ToResumeParseDTO(parse), nil
+}
+
+// Apply copia a la ficha los campos elegidos con el valor leído del CV
+// vigente. Cada campo debe tener una sugerencia: si el CV no lo trae o ya
+// coincide, la operación no se aplica.
+func (s *ResumeService) Apply(companyID, candidateID uint, fields []string) (*dtos.CandidateResponseDTO, error) {
+	candidate, err := s.candidate(companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	var parse *models.ResumeParse
+	if candidate.ResumeURL != "" {
+		if parse, err = s.repo.Latest(candidate.ID, candidate.ResumeURL); err != nil {
+			return nil, err
+		}
+	}
+	if parse == nil || parse.Status != models.ResumeParseCompleted {
+		return nil, apperr.Unprocessable("the candidate's current resume has not been parsed")
+	}
+	suggested := map[string]string{}
+	for _, sg := range domain.Suggest(candidate, parse.Data.Data()) {
+		suggested[sg.Field] = sg.Value
+	}
+	updates := map[string]interface{}{}
+	for _, field := range fields {
+		value, ok := suggested[field]
+		if !ok {
+			return nil, apperr.Unprocessable("the resume has no new value for " + field)
+		}
+		updates[field] = value
+	}
+	if err := s.repo.UpdateCandidate(candidate, updates); err != nil {
+		return nil, err
+	}
+	response := dtos.ToCandidateResponse(candidate)
+	return &response, nil
+}
+
+func (s *ResumeService) candidate(companyID, candidateID uint) (*models.Candidate, error) {
+	candidate, err := s.repo.Candidate(companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil {
+		return nil, apperr.NotFound("candidate not found")
+	}
+	return candidate, nil
+}
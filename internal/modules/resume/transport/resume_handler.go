@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/resume/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResumeHandler struct {
+	svc *service.ResumeService
+}
+
+func NewResumeHandler(svc *service.ResumeService) *ResumeHandler {
+	return &ResumeHandler{svc: svc}
+}
+
+// GetResume godoc
+// @Summary      Ver el análisis del CV de un candidato
+// @Description  Devuelve el análisis del CV vigente (status pending, completed, failed o unsupported; se analiza en segundo plano al subirlo o al postularse por la career page) con los datos inferidos: emails, teléfonos, LinkedIn, GitHub, otros enlaces, habilidades, estudios, puestos y años de experiencia. suggestions lista los datos del CV que difieren de la ficha (first_name, last_name, phone, linkedin_url, github_url). parse es null si el candidato no tiene CV o todavía no se agendó su análisis.
+// @Tags         Candidates
+// @Produce      json
+// @Param        id   path      int  true  "Candidate ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/resume [get]
+func (h *ResumeHandler) GetResume(c *gin.Context) {
+	candidateID, companyID, ok := target(c)
+	if !ok {
+		return
+	}
+	result, err := h.svc.Get(companyID, candidateID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// ReparseResume godoc
+// @Summary      Volver a analizar el CV de un candidato
+// @Description  Agenda de nuevo el análisis del CV vigente (p. ej. si falló). El resultado se consulta en GET /candidates/{id}/resume.
+// @Tags         Candidates
+// @Produce      json
+// @Param        id   path      int  true  "Candidate ID"
+// @Success      202  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      422  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/resume/reparse [post]
+func (h *ResumeHandler) ReparseResume(c *gin.Context) {
+	candidateID, companyID, ok := target(c)
+	if !ok {
+		return
+	}
+	parse, err := h.svc.Reparse(companyID, candidateID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"status": "success", "data": parse})
+}
+
+// ApplyResume godoc
+// @Summary      Completar la ficha con datos del CV
+// @Description  Copia a la ficha del candidato los campos elegidos con el valor sugerido por el análisis del CV vigente. Cada campo debe figurar en suggestions de GET /candidates/{id}/resume (422 si no).
+// @Tags         Candidates
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                        true  "Candidate ID"
+// @Param        request  body      dtos.ApplyResumeFieldsDTO  true  "Campos a copiar"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      422      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/resume/apply [post]
+func (h *ResumeHandler) ApplyResume(c *gin.Context) {
+	candidateID, companyID, ok := target(c)
+	if !ok {
+		return
+	}
+	var req dtos.ApplyResumeFieldsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	candidate, err := h.svc.Apply(companyID, candidateID, req.Fields)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": candidate})
+}
+
+func target(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -0,0 +1,18 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/resume/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.ResumeService) {
+	h := NewResumeHandler(svc)
+
+	rg.GET("/candidates/:id/resume", middleware.RequirePermission(permissions.CandidatesView), h.GetResume)
+	rg.POST("/candidates/:id/resume/reparse", middleware.RequirePermission(permissions.CandidatesUploadResume), h.ReparseResume)
+	rg.POST("/candidates/:id/resume/apply", middleware.RequirePermission(permissions.CandidatesUpdate), h.ApplyResume)
+}
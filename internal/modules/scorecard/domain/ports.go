@@ -0,0 +1,49 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// TemplateRepository es el puerto de salida hacia las plantillas.
+type TemplateRepository interface {
+	ListByJob(companyID, jobID uint) ([]models.ScorecardTemplate, error)
+	GetByID(id uint) (*models.ScorecardTemplate, error)
+	// FindForStage devuelve la plantilla de la etapa o, si no hay, la general
+	// de la vacante (stage vacío). nil si no hay ninguna.
+	FindForStage(jobID uint, stage string) (*models.ScorecardTemplate, error)
+	ExistsForStage(jobID uint, stage string, excludeID uint) (bool, error)
+	// JobCompanyID devuelve la empresa de la vacante (0 si no existe).
+	JobCompanyID(jobID uint) (uint, error)
+	Create(template *models.ScorecardTemplate) error
+	Update(template *models.ScorecardTemplate) error
+	Delete(id uint) error
+}
+
+// ScorecardRepository es el puerto de salida hacia las evaluaciones.
+type ScorecardRepository interface {
+	ListByApplication(applicationID uint) ([]models.Scorecard, error)
+	Find(applicationID uint, stage string, interviewerID uint) (*models.Scorecard, error)
+	Save(scorecard *models.Scorecard) error
+	// InterviewerNames devuelve el nombre de cada entrevistador por ID.
+	InterviewerNames(ids []uint) (map[uint]string, error)
+}
+
+// ApplicationRef es la vista mínima que scorecard necesita de una Application
+// (que vive en el módulo recruitment): su empresa, vacante, etapa actual y las
+// etapas de su pipeline. Puerto definido por el consumidor.
+type ApplicationRef struct {
+	ID        uint
+	CompanyID uint
+	JobID     uint
+	Stage     string
+	Stages    []string
+}
+
+// ApplicationFinder resuelve una Application por ID (nil si no existe). Lo
+// implementa un adaptador en el composition root.
+type ApplicationFinder interface {
+	FindByID(id uint) (*ApplicationRef, error)
+}
+
+// JobStages resuelve las etapas del pipeline vigente de una vacante.
+type JobStages interface {
+	JobStageKeys(companyID, jobID uint) ([]string, error)
+}
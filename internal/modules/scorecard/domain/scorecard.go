@@ -0,0 +1,222 @@
+// Package domain define el centro del módulo scorecard: las reglas de una
+// plantilla, la validación de una evaluación contra ella, la visibilidad
+// entre entrevistadores y la agregación. No importa gin ni gorm.
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+)
+
+// Escala por defecto y límites de una competencia.
+const (
+	DefaultScaleMax = 5
+	MinScaleMax     = 2
+	MaxScaleMax     = 10
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// recommendationScores ordena las recomendaciones de 1 (strong_no) a 4
+// (strong_yes) para promediarlas.
+var recommendationScores = map[string]int{
+	models.RecommendationStrongNo:  1,
+	models.RecommendationNo:        2,
+	models.RecommendationYes:       3,
+	models.RecommendationStrongYes: 4,
+}
+
+// Recommendations devuelve las recomendaciones válidas de peor a mejor.
+func Recommendations() []string {
+	return []string{models.RecommendationStrongNo, models.RecommendationNo, models.RecommendationYes, models.RecommendationStrongYes}
+}
+
+// NormalizeTemplate completa la escala por defecto de las competencias que no
+// la indican.
+func NormalizeTemplate(t *models.ScorecardTemplate) {
+	for i := range t.Competencies {
+		if t.Competencies[i].ScaleMax == 0 {
+			t.Competencies[i].ScaleMax = DefaultScaleMax
+		}
+	}
+}
+
+// ValidateTemplate verifica que la plantilla tenga nombre, al menos una
+// competencia, keys únicas con formato slug y escalas dentro de los límites.
+func ValidateTemplate(t *models.ScorecardTemplate) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("template requires a name")
+	}
+	if len(t.Competencies) == 0 {
+		return fmt.Errorf("template requires at least one competency")
+	}
+
+	seen := map[string]bool{}
+	for _, c := range t.Competencies {
+		if !keyPattern.MatchString(c.Key) {
+			return fmt.Errorf("invalid competency key %q: use lowercase letters, digits and underscores", c.Key)
+		}
+		if seen[c.Key] {
+			return fmt.Errorf("duplicated competency key %q", c.Key)
+		}
+		seen[c.Key] = true
+		if strings.TrimSpace(c.Name) == "" {
+			return fmt.Errorf("competency %q requires a name", c.Key)
+		}
+		if c.ScaleMax < MinScaleMax || c.ScaleMax > MaxScaleMax {
+			return fmt.Errorf("competency %q scale must be between %d and %d", c.Key, MinScaleMax, MaxScaleMax)
+		}
+	}
+
+	seen = map[string]bool{}
+	for _, q := range t.Questions {
+		if !keyPattern.MatchString(q.Key) {
+			return fmt.Errorf("invalid question key %q: use lowercase letters, digits and underscores", q.Key)
+		}
+		if seen[q.Key] {
+			return fmt.Errorf("duplicated question key %q", q.Key)
+		}
+		seen[q.Key] = true
+		if strings.TrimSpace(q.Text) == "" {
+			return fmt.Errorf("question %q requires a text", q.Key)
+		}
+	}
+	return nil
+}
+
+// ValidateSubmission verifica una evaluación contra la plantilla de su etapa
+// (nil = la vacante no tiene plantilla: solo recomendación y resumen). Copia
+// la escala de cada competencia en su nota.
+func ValidateSubmission(t *models.ScorecardTemplate, sc *models.Scorecard) error {
+	if _, ok := recommendationScores[sc.Recommendation]; !ok {
+		return fmt.Errorf("invalid recommendation %q: use one of %v", sc.Recommendation, Recommendations())
+	}
+	if t == nil {
+		if len(sc.Ratings) > 0 || len(sc.Answers) > 0 {
+			return fmt.Errorf("there is no scorecard template for this stage: only recommendation and summary are accepted")
+		}
+		return nil
+	}
+
+	competencies := map[string]models.ScorecardCompetency{}
+	for _, c := range t.Competencies {
+		competencies[c.Key] = c
+	}
+	rated := map[string]bool{}
+	for i, r := range sc.Ratings {
+		c, ok := competencies[r.Competency]
+		if !ok {
+			return fmt.Errorf("unknown competency %q", r.Competency)
+		}
+		if rated[r.Competency] {
+			return fmt.Errorf("competency %q is rated twice", r.Competency)
+		}
+		rated[r.Competency] = true
+		if r.Score < 1 || r.Score > c.ScaleMax {
+			return fmt.Errorf("score for %q must be between 1 and %d", r.Competency, c.ScaleMax)
+		}
+		sc.Ratings[i].ScaleMax = c.ScaleMax
+	}
+	for _, c := range t.Competencies {
+		if c.Required && !rated[c.Key] {
+			return fmt.Errorf("competency %q is required", c.Key)
+		}
+	}
+
+	questions := map[string]bool{}
+	for _, q := range t.Questions {
+		questions[q.Key] = true
+	}
+	answered := map[string]bool{}
+	for _, a := range sc.Answers {
+		if !questions[a.Question] {
+			return fmt.Errorf("unknown question %q", a.Question)
+		}
+		if strings.TrimSpace(a.Answer) != "" {
+			answered[a.Question] = true
+		}
+	}
+	for _, q := range t.Questions {
+		if q.Required && !answered[q.Key] {
+			return fmt.Errorf("question %q is required", q.Key)
+		}
+	}
+	return nil
+}
+
+// Visible reporta si viewerID puede ver el contenido de la evaluación: la
+// propia siempre; las de otros solo si ya envió la suya para esa etapa (evita
+// el sesgo de leer a los demás antes de opinar) o si ve todas (viewAll).
+func Visible(sc models.Scorecard, viewerID uint, submittedStages map[string]bool, viewAll bool) bool {
+	return viewAll || sc.InterviewerID == viewerID || submittedStages[sc.Stage]
+}
+
+// Summarize agrega las evaluaciones visibles: conteo por recomendación,
+// recomendación promedio (1 = strong_no … 4 = strong_yes) y promedio por
+// competencia, también en porcentaje de su escala para comparar escalas
+// distintas. hidden es cuántas evaluaciones quedaron fuera por visibilidad.
+func Summarize(visible []models.Scorecard, hidden int) dtos.ScorecardSummaryDTO {
+	summary := dtos.ScorecardSummaryDTO{
+		Submitted:       len(visible) + hidden,
+		Hidden:          hidden,
+		Recommendations: map[string]int{},
+		Competencies:    []dtos.CompetencyScoreDTO{},
+	}
+	for _, r := range Recommendations() {
+		summary.Recommendations[r] = 0
+	}
+	if len(visible) == 0 {
+		return summary
+	}
+
+	type acc struct {
+		sum, pct float64
+		count    int
+		scale    int
+	}
+	byKey := map[string]*acc{}
+	var order []string
+	var recTotal int
+	for _, sc := range visible {
+		summary.Recommendations[sc.Recommendation]++
+		recTotal += recommendationScores[sc.Recommendation]
+		for _, r := range sc.Ratings {
+			a := byKey[r.Competency]
+			if a == nil {
+				a = &acc{}
+				byKey[r.Competency] = a
+				order = append(order, r.Competency)
+			}
+			a.sum += float64(r.Score)
+			if r.ScaleMax > 0 {
+				a.pct += float64(r.Score) * 100 / float64(r.ScaleMax)
+			}
+			a.count++
+			if r.ScaleMax > a.scale {
+				a.scale = r.ScaleMax
+			}
+		}
+	}
+
+	summary.AverageRecommendation = round2(float64(recTotal) / float64(len(visible)))
+	for _, key := range order {
+		a := byKey[key]
+		summary.Competencies = append(summary.Competencies, dtos.CompetencyScoreDTO{
+			Key:        key,
+			Count:      a.count,
+			ScaleMax:   a.scale,
+			AvgScore:   round2(a.sum / float64(a.count)),
+			AvgPercent: round2(a.pct / float64(a.count)),
+		})
+	}
+	return summary
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func template() *models.ScorecardTemplate {
+	return &models.ScorecardTemplate{
+		Name: "Backend",
+		Competencies: []models.ScorecardCompetency{
+			{Key: "go", Name: "Go", ScaleMax: 5, Required: true},
+			{Key: "design", Name: "System design", ScaleMax: 10},
+		},
+		Questions: []models.ScorecardQuestion{{Key: "red_flags", Text: "¿Alguna alerta?", Required: true}},
+	}
+}
+
+func TestValidateSubmissionExigeRequeridosYRespetaEscala(t *testing.T) {
+	answers := []models.ScorecardAnswer{{Question: "red_flags", Answer: "Ninguna"}}
+	cases := map[string]models.Scorecard{
+		"recomendación inválida": {Recommendation: "maybe", Ratings: []models.ScorecardRating{{Competency: "go", Score: 3}}, Answers: answers},
+		"falta competencia":      {Recommendation: models.RecommendationYes, Answers: answers},
+		"fuera de escala":        {Recommendation: models.RecommendationYes, Ratings: []models.ScorecardRating{{Competency: "go", Score: 6}}, Answers: answers},
+		"competencia ajena":      {Recommendation: models.RecommendationYes, Ratings: []models.ScorecardRating{{Competency: "go", Score: 3}, {Competency: "sql", Score: 3}}, Answers: answers},
+		"falta respuesta":        {Recommendation: models.RecommendationYes, Ratings: []models.ScorecardRating{{Competency: "go", Score: 3}}},
+	}
+	for name, sc := range cases {
+		if err := ValidateSubmission(template(), &sc); err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+
+	ok := models.Scorecard{Recommendation: models.RecommendationStrongYes, Ratings: []models.ScorecardRating{{Competency: "design", Score: 8}, {Competency: "go", Score: 4}}, Answers: answers}
+	if err := ValidateSubmission(template(), &ok); err != nil {
+		t.Fatalf("evaluación válida rechazada: %v", err)
+	}
+	if ok.Ratings[0].ScaleMax != 10 {
+		t.Errorf("la escala de la plantilla debería copiarse en la nota, got %d", ok.Ratings[0].ScaleMax)
+	}
+}
+
+func TestVisibleOcultaHastaEnviarLaPropia(t *testing.T) {
+	other := models.Scorecard{InterviewerID: 2, Stage: "interview"}
+	if Visible(other, 1, map[string]bool{}, false) {
+		t.Error("no debería ver la evaluación de otro sin enviar la suya")
+	}
+	if Visible(other, 1, map[string]bool{"technical": true}, false) {
+		t.Error("enviar en otra etapa no habilita ver esta")
+	}
+	if !Visible(other, 1, map[string]bool{"interview": true}, false) || !Visible(other, 1, nil, true) {
+		t.Error("debería verla tras enviar la suya o con viewAll")
+	}
+}
+
+func TestSummarizePromediaSobreLaEscala(t *testing.T) {
+	visible := []models.Scorecard{
+		{Recommendation: models.RecommendationStrongYes, Ratings: []models.ScorecardRating{{Competency: "go", Score: 5, ScaleMax: 5}}},
+		{Recommendation: models.RecommendationNo, Ratings: []models.ScorecardRating{{Competency: "go", Score: 3, ScaleMax: 5}}},
+	}
+	s := Summarize(visible, 1)
+	if s.Submitted != 3 || s.Hidden != 1 {
+		t.Errorf("submitted/hidden = %d/%d, se esperaba 3/1", s.Submitted, s.Hidden)
+	}
+	if s.AverageRecommendation != 3 {
+		t.Errorf("average_recommendation = %v, se esperaba 3", s.AverageRecommendation)
+	}
+	if len(s.Competencies) != 1 || s.Competencies[0].AvgScore != 4 || s.Competencies[0].AvgPercent != 80 {
+		t.Errorf("competencias = %+v", s.Competencies)
+	}
+}
@@ -0,0 +1,37 @@
+// Package scorecard es el punto de ensamblaje del módulo de evaluaciones
+// estructuradas: plantillas por vacante y scorecards por entrevistador. Nadie
+// importa este paquete salvo el composition root.
+package scorecard
+
+import (
+	"dvra-api/internal/modules/scorecard/domain"
+	"dvra-api/internal/modules/scorecard/repository"
+	"dvra-api/internal/modules/scorecard/service"
+	"dvra-api/internal/modules/scorecard/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo scorecard.
+type Module struct {
+	// Service se expone porque applications adjunta el resumen de
+	// evaluaciones al detalle de la postulación.
+	Service *service.ScorecardService
+}
+
+// New construye el módulo. apps y stages son adaptadores hacia recruitment y
+// pipeline que inyecta el composition root.
+func New(db *gorm.DB, apps domain.ApplicationFinder, stages domain.JobStages) *Module {
+	return &Module{Service: service.NewScorecardService(
+		repository.NewTemplateRepository(db),
+		repository.NewScorecardRepository(db),
+		apps,
+		stages,
+	)}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
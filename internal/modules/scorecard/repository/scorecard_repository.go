@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/scorecard/domain"
+
+	"gorm.io/gorm"
+)
+
+type scorecardRepository struct {
+	db *gorm.DB
+}
+
+// NewScorecardRepository devuelve la implementación del puerto.
+func NewScorecardRepository(db *gorm.DB) domain.ScorecardRepository {
+	return &scorecardRepository{db: db}
+}
+
+func (r *scorecardRepository) ListByApplication(applicationID uint) ([]models.Scorecard, error) {
+	var scorecards []models.Scorecard
+	if err := r.db.Where("application_id = ?", applicationID).
+		Order("submitted_at ASC, id ASC").
+		Find(&scorecards).Error; err != nil {
+		return nil, err
+	}
+	return scorecards, nil
+}
+
+func (r *scorecardRepository) Find(applicationID uint, stage string, interviewerID uint) (*models.Scorecard, error) {
+	var scorecard models.Scorecard
+	if err := r.db.Where("application_id = ? AND stage = ? AND interviewer_id = ?", applicationID, stage, interviewerID).
+		First(&scorecard).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &scorecard, nil
+}
+
+func (r *scorecardRepository) Save(scorecard *models.Scorecard) error {
+	return r.db.Save(scorecard).Error
+}
+
+func (r *scorecardRepository) InterviewerNames(ids []uint) (map[uint]string, error) {
+	names := make(map[uint]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	var rows []struct {
+		ID   uint
+		Name string
+	}
+	if err := r.db.Table("users").
+		Select("id, NULLIF(CONCAT_WS(' ', first_name, last_name), '') AS name").
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		names[row.ID] = row.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/scorecard/domain"
+
+	"gorm.io/gorm"
+)
+
+type templateRepository struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository devuelve la implementación del puerto.
+func NewTemplateRepository(db *gorm.DB) domain.TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+func (r *templateRepository) ListByJob(companyID, jobID uint) ([]models.ScorecardTemplate, error) {
+	var templates []models.ScorecardTemplate
+	query := r.db.Where("company_id = ?", companyID)
+	if jobID != 0 {
+		query = query.Where("job_id = ?", jobID)
+	}
+	if err := query.Order("job_id ASC, stage ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *templateRepository) GetByID(id uint) (*models.ScorecardTemplate, error) {
+	return r.first(r.db.Where("id = ?", id))
+}
+
+func (r *templateRepository) FindForStage(jobID uint, stage string) (*models.ScorecardTemplate, error) {
+	// La plantilla de la etapa gana sobre la general (stage vacío).
+	return r.first(r.db.Where("job_id = ? AND stage IN ?", jobID, []string{stage, ""}).Order("stage DESC"))
+}
+
+func (r *templateRepository) first(query *gorm.DB) (*models.ScorecardTemplate, error) {
+	var template models.ScorecardTemplate
+	if err := query.First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *templateRepository) ExistsForStage(jobID uint, stage string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.ScorecardTemplate{}).Where("job_id = ? AND stage = ?", jobID, stage)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *templateRepository) JobCompanyID(jobID uint) (uint, error) {
+	var job models.Job
+	if err := r.db.Select("id", "company_id").First(&job, jobID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return job.CompanyID, nil
+}
+
+func (r *templateRepository) Create(template *models.ScorecardTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *templateRepository) Update(template *models.ScorecardTemplate) error {
+	return r.db.Save(template).Error
+}
+
+func (r *templateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.ScorecardTemplate{}, id).Error
+}
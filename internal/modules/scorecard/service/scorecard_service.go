@@ -0,0 +1,278 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/scorecard/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// ScorecardService administra las plantillas de evaluación de cada vacante y
+// las evaluaciones (scorecards) de cada entrevistador. Las evaluaciones de
+// otros se ocultan hasta que el usuario envía la suya para esa etapa.
+type ScorecardService struct {
+	templates  domain.TemplateRepository
+	scorecards domain.ScorecardRepository
+	apps       domain.ApplicationFinder
+	stages     domain.JobStages
+}
+
+func NewScorecardService(templates domain.TemplateRepository, scorecards domain.ScorecardRepository, apps domain.ApplicationFinder, stages domain.JobStages) *ScorecardService {
+	return &ScorecardService{templates: templates, scorecards: scorecards, apps: apps, stages: stages}
+}
+
+// ListTemplates devuelve las plantillas de la empresa (jobID 0 = de todas
+// sus vacantes).
+func (s *ScorecardService) ListTemplates(companyID, jobID uint) ([]models.ScorecardTemplate, error) {
+	return s.templates.ListByJob(companyID, jobID)
+}
+
+// GetTemplate devuelve una plantilla validando el tenant (companyID 0 = SuperAdmin).
+func (s *ScorecardService) GetTemplate(id, companyID uint) (*models.ScorecardTemplate, error) {
+	template, err := s.templates.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil || (companyID != 0 && template.CompanyID != companyID) {
+		return nil, apperr.NotFound("scorecard template not found")
+	}
+	return template, nil
+}
+
+// CreateTemplate crea la plantilla de una vacante, general o de una etapa.
+// Cada etapa (y la general) admite una sola plantilla.
+func (s *ScorecardService) CreateTemplate(companyID uint, dto dtos.ScorecardTemplateDTO) (*models.ScorecardTemplate, error) {
+	jobCompanyID, err := s.templates.JobCompanyID(dto.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if jobCompanyID == 0 || (companyID != 0 && jobCompanyID != companyID) {
+		return nil, apperr.NotFound("job not found")
+	}
+
+	template := &models.ScorecardTemplate{CompanyID: jobCompanyID, JobID: dto.JobID}
+	if err := s.applyTemplate(template, dto, 0); err != nil {
+		return nil, err
+	}
+	if err := s.templates.Create(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTemplate reemplaza la plantilla. Las evaluaciones ya enviadas no
+// cambian: guardan la escala con la que se calificó.
+func (s *ScorecardService) UpdateTemplate(id, companyID uint, dto dtos.ScorecardTemplateDTO) (*models.ScorecardTemplate, error) {
+	template, err := s.GetTemplate(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if dto.JobID != template.JobID {
+		return nil, apperr.BadRequest("a template cannot be moved to another job")
+	}
+	if err := s.applyTemplate(template, dto, template.ID); err != nil {
+		return nil, err
+	}
+	if err := s.templates.Update(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *ScorecardService) DeleteTemplate(id, companyID uint) error {
+	if _, err := s.GetTemplate(id, companyID); err != nil {
+		return err
+	}
+	return s.templates.Delete(id)
+}
+
+func (s *ScorecardService) applyTemplate(template *models.ScorecardTemplate, dto dtos.ScorecardTemplateDTO, excludeID uint) error {
+	stage := strings.TrimSpace(dto.Stage)
+	if stage != "" {
+		keys, err := s.stages.JobStageKeys(template.CompanyID, template.JobID)
+		if err != nil {
+			return err
+		}
+		if !contains(keys, stage) {
+			return apperr.BadRequest(fmt.Sprintf("stage '%s' is not part of this job's pipeline", stage))
+		}
+	}
+	exists, err := s.templates.ExistsForStage(template.JobID, stage, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return apperr.Conflict("this job already has a scorecard template for that stage")
+	}
+
+	template.Stage = stage
+	template.Name = strings.TrimSpace(dto.Name)
+	template.Competencies = dto.Competencies
+	template.Questions = dto.Questions
+	domain.NormalizeTemplate(template)
+	if err := domain.ValidateTemplate(template); err != nil {
+		return apperr.BadRequest(err.Error())
+	}
+	return nil
+}
+
+// Submit guarda la evaluación del entrevistador para la postulación y etapa
+// (vacía = la actual). Reenviarla reemplaza la anterior.
+func (s *ScorecardService) Submit(applicationID, companyID, interviewerID uint, dto dtos.SubmitScorecardDTO) (*dtos.ScorecardDTO, error) {
+	if interviewerID == 0 {
+		return nil, apperr.BadRequest("a user is required to submit a scorecard")
+	}
+	app, err := s.application(applicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	stage := strings.TrimSpace(dto.Stage)
+	if stage == "" {
+		stage = app.Stage
+	}
+	if !contains(app.Stages, stage) {
+		return nil, apperr.BadRequest(fmt.Sprintf("stage '%s' is not part of this job's pipeline", stage))
+	}
+
+	template, err := s.templates.FindForStage(app.JobID, stage)
+	if err != nil {
+		return nil, err
+	}
+	scorecard, err := s.scorecards.Find(app.ID, stage, interviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if scorecard == nil {
+		scorecard = &models.Scorecard{CompanyID: app.CompanyID, ApplicationID: app.ID, Stage: stage, InterviewerID: interviewerID}
+	}
+	scorecard.Ratings = dto.Ratings
+	scorecard.Answers = dto.Answers
+	scorecard.Recommendation = dto.Recommendation
+	scorecard.Summary = strings.TrimSpace(dto.Summary)
+	scorecard.SubmittedAt = time.Now()
+	scorecard.TemplateID = nil
+	if template != nil {
+		scorecard.TemplateID = &template.ID
+	}
+	if err := domain.ValidateSubmission(template, scorecard); err != nil {
+		return nil, apperr.BadRequest(err.Error())
+	}
+
+	if err := s.scorecards.Save(scorecard); err != nil {
+		return nil, err
+	}
+	result := toScorecardDTO(*scorecard, false)
+	return &result, nil
+}
+
+// ListForApplication devuelve las evaluaciones de la postulación tal como las
+// ve viewerID, su resumen y la plantilla de la etapa actual. viewAll = ve
+// todas sin haber enviado la suya.
+func (s *ScorecardService) ListForApplication(applicationID, companyID, viewerID uint, viewAll bool) (*dtos.ApplicationScorecardsDTO, error) {
+	app, err := s.application(applicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	scorecards, err := s.scorecards.ListByApplication(app.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(scorecards))
+	for _, sc := range scorecards {
+		ids = append(ids, sc.InterviewerID)
+	}
+	names, err := s.scorecards.InterviewerNames(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted := submittedStages(scorecards, viewerID)
+	result := &dtos.ApplicationScorecardsDTO{Items: make([]dtos.ScorecardDTO, 0, len(scorecards))}
+	var visible []models.Scorecard
+	for _, sc := range scorecards {
+		show := domain.Visible(sc, viewerID, submitted, viewAll)
+		if show {
+			visible = append(visible, sc)
+		}
+		item := toScorecardDTO(sc, !show)
+		item.InterviewerName = names[sc.InterviewerID]
+		result.Items = append(result.Items, item)
+	}
+	result.Summary = domain.Summarize(visible, len(scorecards)-len(visible))
+
+	if result.Template, err = s.templates.FindForStage(app.JobID, app.Stage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Summary agrega las evaluaciones de la postulación visibles para viewerID
+// (la vista que se adjunta al detalle de la postulación).
+func (s *ScorecardService) Summary(applicationID, viewerID uint, viewAll bool) (*dtos.ScorecardSummaryDTO, error) {
+	scorecards, err := s.scorecards.ListByApplication(applicationID)
+	if err != nil {
+		return nil, err
+	}
+	submitted := submittedStages(scorecards, viewerID)
+	var visible []models.Scorecard
+	for _, sc := range scorecards {
+		if domain.Visible(sc, viewerID, submitted, viewAll) {
+			visible = append(visible, sc)
+		}
+	}
+	summary := domain.Summarize(visible, len(scorecards)-len(visible))
+	return &summary, nil
+}
+
+func (s *ScorecardService) application(id, companyID uint) (*domain.ApplicationRef, error) {
+	app, err := s.apps.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil || (companyID != 0 && app.CompanyID != companyID) {
+		return nil, apperr.NotFound("application not found")
+	}
+	return app, nil
+}
+
+// submittedStages son las etapas en las que viewerID ya envió su evaluación.
+func submittedStages(scorecards []models.Scorecard, viewerID uint) map[string]bool {
+	stages := map[string]bool{}
+	for _, sc := range scorecards {
+		if sc.InterviewerID == viewerID {
+			stages[sc.Stage] = true
+		}
+	}
+	return stages
+}
+
+func toScorecardDTO(sc models.Scorecard, hidden bool) dtos.ScorecardDTO {
+	result := dtos.ScorecardDTO{
+		ID:            sc.ID,
+		Stage:         sc.Stage,
+		InterviewerID: sc.InterviewerID,
+		SubmittedAt:   sc.SubmittedAt,
+		Hidden:        hidden,
+	}
+	if !hidden {
+		result.Recommendation = sc.Recommendation
+		result.Ratings = sc.Ratings
+		result.Answers = sc.Answers
+		result.Summary = sc.Summary
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/scorecard/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.ScorecardService) {
+	h := NewScorecardHandler(svc)
+
+	templates := rg.Group("/scorecard-templates")
+	{
+		templates.GET("", middleware.RequirePermission(permissions.ApplicationsView), h.GetTemplates)
+		templates.POST("", middleware.RequirePermission(permissions.ScorecardTemplatesManage), h.CreateTemplate)
+		templates.GET("/:id", middleware.RequirePermission(permissions.ApplicationsView), h.GetTemplate)
+		templates.PUT("/:id", middleware.RequirePermission(permissions.ScorecardTemplatesManage), h.UpdateTemplate)
+		templates.DELETE("/:id", middleware.RequirePermission(permissions.ScorecardTemplatesManage), h.DeleteTemplate)
+	}
+
+	// Las evaluaciones cuelgan de la postulación.
+	applications := rg.Group("/applications")
+	{
+		applications.GET("/:id/scorecards", middleware.RequirePermission(permissions.ApplicationsView), h.GetApplicationScorecards)
+		applications.POST("/:id/scorecards", middleware.RequirePermission(permissions.ApplicationsRate), h.SubmitScorecard)
+	}
+}
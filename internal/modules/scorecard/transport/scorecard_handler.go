@@ -0,0 +1,221 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/scorecard/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScorecardHandler struct {
+	svc *service.ScorecardService
+}
+
+func NewScorecardHandler(svc *service.ScorecardService) *ScorecardHandler {
+	return &ScorecardHandler{svc: svc}
+}
+
+// GetTemplates godoc
+// @Summary      Listar plantillas de scorecard
+// @Description  Plantillas de evaluación de la empresa, opcionalmente de una vacante
+// @Tags         Scorecards
+// @Produce      json
+// @Param        job_id      query     int  false  "Vacante"
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /scorecard-templates [get]
+func (h *ScorecardHandler) GetTemplates(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	jobID, _ := strconv.ParseUint(c.Query("job_id"), 10, 32)
+
+	templates, err := h.svc.ListTemplates(companyID, uint(jobID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve scorecard templates"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": templates, "count": len(templates)}})
+}
+
+// GetTemplate godoc
+// @Summary      Obtener plantilla de scorecard
+// @Tags         Scorecards
+// @Produce      json
+// @Param        id   path      int  true  "ID de la plantilla"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /scorecard-templates/{id} [get]
+func (h *ScorecardHandler) GetTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.svc.GetTemplate(id, companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": template})
+}
+
+// CreateTemplate godoc
+// @Summary      Crear plantilla de scorecard
+// @Description  Competencias (escala 2-10, por defecto 1-5) y preguntas de una vacante. Stage vacío = plantilla general; una por etapa.
+// @Tags         Scorecards
+// @Accept       json
+// @Produce      json
+// @Param        template  body      dtos.ScorecardTemplateDTO  true  "Plantilla"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      409       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /scorecard-templates [post]
+func (h *ScorecardHandler) CreateTemplate(c *gin.Context) {
+	var dto dtos.ScorecardTemplateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.svc.CreateTemplate(companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": template})
+}
+
+// UpdateTemplate godoc
+// @Summary      Actualizar plantilla de scorecard
+// @Description  Reemplaza competencias y preguntas. Las evaluaciones ya enviadas conservan su escala.
+// @Tags         Scorecards
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                        true  "ID de la plantilla"
+// @Param        template  body      dtos.ScorecardTemplateDTO  true  "Plantilla"
+// @Success      200       {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /scorecard-templates/{id} [put]
+func (h *ScorecardHandler) UpdateTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+	var dto dtos.ScorecardTemplateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.svc.UpdateTemplate(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": template})
+}
+
+// DeleteTemplate godoc
+// @Summary      Eliminar plantilla de scorecard
+// @Tags         Scorecards
+// @Produce      json
+// @Param        id   path      int  true  "ID de la plantilla"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /scorecard-templates/{id} [delete]
+func (h *ScorecardHandler) DeleteTemplate(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid template ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteTemplate(id, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Scorecard template deleted successfully"})
+}
+
+// GetApplicationScorecards godoc
+// @Summary      Scorecards de una postulación
+// @Description  Evaluaciones por entrevistador y etapa con su resumen. Las de otros entrevistadores llegan ocultas (hidden=true) hasta que el usuario envía la suya para esa etapa, salvo permiso scorecards.view_all.
+// @Tags         Scorecards
+// @Produce      json
+// @Param        id   path      int  true  "ID de la postulación"
+// @Success      200  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/scorecards [get]
+func (h *ScorecardHandler) GetApplicationScorecards(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+	viewerID, _ := authctx.UserID(c)
+	viewAll := permissions.Can(authctx.Role(c), permissions.ScorecardsViewAll)
+
+	result, err := h.svc.ListForApplication(id, companyID, viewerID, viewAll)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// SubmitScorecard godoc
+// @Summary      Enviar scorecard
+// @Description  Evaluación del usuario para la postulación en una etapa (vacía = la actual): notas por competencia, respuestas y recomendación strong_no/no/yes/strong_yes. Reenviar reemplaza la anterior.
+// @Tags         Scorecards
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                      true  "ID de la postulación"
+// @Param        scorecard  body      dtos.SubmitScorecardDTO  true  "Evaluación"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      400        {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/scorecards [post]
+func (h *ScorecardHandler) SubmitScorecard(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+	var dto dtos.SubmitScorecardDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	interviewerID, _ := authctx.UserID(c)
+
+	scorecard, err := h.svc.Submit(id, companyID, interviewerID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": scorecard})
+}
+
+// target resuelve el :id de la ruta y el tenant.
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
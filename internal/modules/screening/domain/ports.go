@@ -0,0 +1,30 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// ScreeningRepository es el puerto de salida hacia la persistencia. Los
+// métodos que buscan devuelven nil, nil si no hay resultado.
+type ScreeningRepository interface {
+	// Job busca una vacante de la empresa (companyID 0 = cualquiera).
+	Job(companyID, jobID uint) (*models.Job, error)
+	// Questions devuelve las preguntas de la vacante por posición.
+	Questions(jobID uint) ([]models.ScreeningQuestion, error)
+	// ReplaceQuestions deja en la vacante exactamente questions: actualiza
+	// las que traen ID, crea las demás y elimina (soft delete) las que faltan.
+	ReplaceQuestions(jobID uint, questions []models.ScreeningQuestion) error
+
+	// Application busca una postulación de la empresa (companyID 0 =
+	// cualquiera).
+	Application(companyID, applicationID uint) (*models.Application, error)
+	// Answers devuelve las respuestas de la postulación en el orden de las
+	// preguntas.
+	Answers(applicationID uint) ([]models.ScreeningAnswer, error)
+	// CreateAnswers guarda las respuestas de una postulación.
+	CreateAnswers(answers []models.ScreeningAnswer) error
+}
+
+// ReasonCatalog da los motivos de rechazo vigentes de la empresa por
+// categoría (globales + propios).
+type ReasonCatalog interface {
+	GetByCategory(category string, companyID *uint) ([]models.SystemValue, error)
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"dvra-api/internal/app/models"
+)
+
+// Límites de las preguntas de una vacante.
+const (
+	MaxQuestions  = 20
+	MaxOptions    = 20
+	MaxTextAnswer = 2000
+)
+
+var (
+	// ErrInvalidQuestion envuelve los errores de configuración de una pregunta.
+	ErrInvalidQuestion = errors.New("invalid screening question")
+	// ErrInvalidAnswers envuelve los errores de las respuestas al postularse.
+	ErrInvalidAnswers = errors.New("invalid screening answers")
+)
+
+// ValidateQuestion revisa la pregunta y su regla knockout, y descarta las
+// reglas que no corresponden a su tipo (o todas, si no es knockout). Una
+// knockout es siempre obligatoria: dejarla en blanco no puede evitar el
+// descarte. El motivo de rechazo se valida contra el catálogo en el servicio.
+func ValidateQuestion(q *models.ScreeningQuestion) error {
+	q.Prompt = strings.TrimSpace(q.Prompt)
+	if q.Prompt == "" {
+		return invalidQuestion("prompt is required")
+	}
+	if len([]rune(q.Prompt)) > 500 {
+		return invalidQuestion("prompt admits at most 500 characters")
+	}
+
+	switch q.Kind {
+	case models.ScreeningYesNo:
+		q.Options, q.AcceptedOptions, q.MinValue, q.MaxValue = nil, nil, nil, nil
+		if q.Knockout && q.ExpectedAnswer == nil {
+			return invalidQuestion("a yes_no knockout needs expected_answer")
+		}
+	case models.ScreeningChoice:
+		q.ExpectedAnswer, q.MinValue, q.MaxValue = nil, nil, nil
+		q.Options = cleanOptions(q.Options)
+		if len(q.Options) < 2 || len(q.Options) > MaxOptions {
+			return invalidQuestion("a choice question needs between 2 and %d distinct options", MaxOptions)
+		}
+		accepted := q.AcceptedOptions[:0]
+		for _, a := range cleanOptions(q.AcceptedOptions) {
+			option, ok := findOption(q.Options, a)
+			if !ok {
+				return invalidQuestion("accepted option '%s' is not one of the options", a)
+			}
+			accepted = append(accepted, option)
+		}
+		q.AcceptedOptions = accepted
+		if q.Knockout && len(q.AcceptedOptions) == 0 {
+			return invalidQuestion("a choice knockout needs accepted_options")
+		}
+	case models.ScreeningNumber:
+		q.ExpectedAnswer, q.Options, q.AcceptedOptions = nil, nil, nil
+		if q.Knockout && q.MinValue == nil && q.MaxValue == nil {
+			return invalidQuestion("a number knockout needs min_value or max_value")
+		}
+		if q.MinValue != nil && q.MaxValue != nil && *q.MinValue > *q.MaxValue {
+			return invalidQuestion("min_value cannot be greater than max_value")
+		}
+	case models.ScreeningText:
+		q.ExpectedAnswer, q.Options, q.AcceptedOptions, q.MinValue, q.MaxValue = nil, nil, nil, nil, nil
+		if q.Knockout {
+			return invalidQuestion("text questions cannot be knockout")
+		}
+	default:
+		return invalidQuestion("unknown kind '%s'", q.Kind)
+	}
+
+	if !q.Knockout {
+		q.ExpectedAnswer, q.AcceptedOptions, q.MinValue, q.MaxValue = nil, nil, nil, nil
+		q.RejectionReason = ""
+		return nil
+	}
+	q.RejectionReason = strings.TrimSpace(q.RejectionReason)
+	if q.RejectionReason == "" {
+		return invalidQuestion("a knockout question needs rejection_reason")
+	}
+	q.Required = true
+	return nil
+}
+
+// Evaluate valida las respuestas (pregunta → texto) contra las preguntas de
+// la vacante. Devuelve las respuestas normalizadas en el orden de las
+// preguntas y las knockout que no se cumplieron. Una pregunta no
+// obligatoria sin responder no descarta; una knockout se exige aunque se
+// haya guardado como no obligatoria.
+func Evaluate(questions []models.ScreeningQuestion, answers map[uint]string) ([]models.ScreeningAnswer, []*models.ScreeningQuestion, error) {
+	known := make(map[uint]bool, len(questions))
+	for _, q := range questions {
+		known[q.ID] = true
+	}
+	for id := range answers {
+		if !known[id] {
+			return nil, nil, invalidAnswers("question %d is not part of this job", id)
+		}
+	}
+
+	var rows []models.ScreeningAnswer
+	var failed []*models.ScreeningQuestion
+	for i := range questions {
+		q := &questions[i]
+		raw := strings.TrimSpace(answers[q.ID])
+		if raw == "" {
+			if q.Required || q.Knockout {
+				return nil, nil, invalidAnswers("an answer is required for \"%s\"", q.Prompt)
+			}
+			continue
+		}
+		answer, pass, err := check(q, raw)
+		if err != nil {
+			return nil, nil, invalidAnswers("\"%s\": %v", q.Prompt, err)
+		}
+		knockedOut := q.Knockout && !pass
+		if knockedOut {
+			failed = append(failed, q)
+		}
+		rows = append(rows, models.ScreeningAnswer{
+			QuestionID: q.ID,
+			Prompt:     q.Prompt,
+			Kind:       q.Kind,
+			Answer:     answer,
+			KnockedOut: knockedOut,
+		})
+	}
+	return rows, failed, nil
+}
+
+// KnockoutReason resume las preguntas que descartaron la postulación (va al
+// historial de etapas).
+func KnockoutReason(failed []*models.ScreeningQuestion) string {
+	prompts := make([]string, len(failed))
+	for i, q := range failed {
+		prompts[i] = q.Prompt
+	}
+	return "screening knockout: " + strings.Join(prompts, "; ")
+}
+
+// check normaliza la respuesta según el tipo e indica si cumple la regla
+// knockout (siempre true si la pregunta no la tiene).
+func check(q *models.ScreeningQuestion, raw string) (string, bool, error) {
+	switch q.Kind {
+	case models.ScreeningYesNo:
+		var yes bool
+		switch strings.ToLower(raw) {
+		case "yes", "true", "si", "sí", "sim":
+			yes = true
+		case "no", "false", "não", "nao":
+		default:
+			return "", false, errors.New("answer yes or no")
+		}
+		answer := "no"
+		if yes {
+			answer = "yes"
+		}
+		return answer, q.ExpectedAnswer == nil || *q.ExpectedAnswer == yes, nil
+	case models.ScreeningChoice:
+		option, ok := findOption(q.Options, raw)
+		if !ok {
+			return "", false, errors.New("pick one of the options")
+		}
+		if len(q.AcceptedOptions) == 0 {
+			return option, true, nil
+		}
+		_, accepted := findOption(q.AcceptedOptions, option)
+		return option, accepted, nil
+	case models.ScreeningNumber:
+		v, err := strconv.ParseFloat(strings.ReplaceAll(raw, " ", ""), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", false, errors.New("answer a number")
+		}
+		pass := (q.MinValue == nil || v >= *q.MinValue) && (q.MaxValue == nil || v <= *q.MaxValue)
+		return strconv.FormatFloat(v, 'f', -1, 64), pass, nil
+	default:
+		if len([]rune(raw)) > MaxTextAnswer {
+			return "", false, fmt.Errorf("answer admits at most %d characters", MaxTextAnswer)
+		}
+		return raw, true, nil
+	}
+}
+
+// cleanOptions recorta las opciones y descarta las vacías o repetidas (sin
+// distinguir mayúsculas).
+func cleanOptions(options []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if k := strings.ToLower(o); o != "" && !seen[k] {
+			seen[k] = true
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func findOption(options []string, value string) (string, bool) {
+	for _, o := range options {
+		if strings.EqualFold(o, strings.TrimSpace(value)) {
+			return o, true
+		}
+	}
+	return "", false
+}
+
+func invalidQuestion(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidQuestion, fmt.Sprintf(format, args...))
+}
+
+func invalidAnswers(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidAnswers, fmt.Sprintf(format, args...))
+}
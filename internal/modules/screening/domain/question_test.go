@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestValidateQuestion(t *testing.T) {
+	yes := true
+	f := func(v float64) *float64 { return &v }
+	cases := []struct {
+		name string
+		q    models.ScreeningQuestion
+		ok   bool
+	}{
+		{"yes_no knockout", models.ScreeningQuestion{Kind: models.ScreeningYesNo, Prompt: "Work permit?", Knockout: true, ExpectedAnswer: &yes, RejectionReason: "other"}, true},
+		{"yes_no knockout without expected answer", models.ScreeningQuestion{Kind: models.ScreeningYesNo, Prompt: "Work permit?", Knockout: true, RejectionReason: "other"}, false},
+		{"knockout without reason", models.ScreeningQuestion{Kind: models.ScreeningYesNo, Prompt: "Work permit?", Knockout: true, ExpectedAnswer: &yes}, false},
+		{"empty prompt", models.ScreeningQuestion{Kind: models.ScreeningText, Prompt: "  "}, false},
+		{"unknown kind", models.ScreeningQuestion{Kind: "date", Prompt: "Start date"}, false},
+		{"choice with one option", models.ScreeningQuestion{Kind: models.ScreeningChoice, Prompt: "English", Options: []string{"B2", " b2 "}}, false},
+		{"accepted option not listed", models.ScreeningQuestion{Kind: models.ScreeningChoice, Prompt: "English", Options: []string{"B1", "B2"}, Knockout: true, AcceptedOptions: []string{"C1"}, RejectionReason: "other"}, false},
+		{"number knockout without bounds", models.ScreeningQuestion{Kind: models.ScreeningNumber, Prompt: "Years with Go", Knockout: true, RejectionReason: "other"}, false},
+		{"number min above max", models.ScreeningQuestion{Kind: models.ScreeningNumber, Prompt: "Salary", Knockout: true, MinValue: f(10), MaxValue: f(5), RejectionReason: "other"}, false},
+		{"text knockout", models.ScreeningQuestion{Kind: models.ScreeningText, Prompt: "Why us?", Knockout: true, RejectionReason: "other"}, false},
+	}
+	for _, tc := range cases {
+		err := ValidateQuestion(&tc.q)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: err = %v", tc.name, err)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidQuestion) {
+			t.Errorf("%s: err = %v, want ErrInvalidQuestion", tc.name, err)
+		}
+	}
+}
+
+func TestValidateQuestionCleansRules(t *testing.T) {
+	yes := true
+	q := models.ScreeningQuestion{
+		Kind:            models.ScreeningChoice,
+		Prompt:          " English level ",
+		Options:         []string{"B1", " B2", "b2", "", "C1"},
+		AcceptedOptions: []string{"c1", "b2"},
+		ExpectedAnswer:  &yes,
+		Knockout:        true,
+		RejectionReason: " skills_gap ",
+	}
+	if err := ValidateQuestion(&q); err != nil {
+		t.Fatal(err)
+	}
+	if q.Prompt != "English level" || q.RejectionReason != "skills_gap" || q.ExpectedAnswer != nil || !q.Required {
+		t.Errorf("question not cleaned: %+v", q)
+	}
+	if want := []string{"B1", "B2", "C1"}; !reflect.DeepEqual([]string(q.Options), want) {
+		t.Errorf("options = %v, want %v", q.Options, want)
+	}
+	if want := []string{"C1", "B2"}; !reflect.DeepEqual([]string(q.AcceptedOptions), want) {
+		t.Errorf("accepted = %v, want %v", q.AcceptedOptions, want)
+	}
+
+	// Sin knockout no quedan reglas.
+	q.Knockout = false
+	if err := ValidateQuestion(&q); err != nil {
+		t.Fatal(err)
+	}
+	if q.AcceptedOptions != nil || q.RejectionReason != "" {
+		t.Errorf("rules kept without knockout: %+v", q)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	yes := true
+	f := func(v float64) *float64 { return &v }
+	questions := []models.ScreeningQuestion{
+		{BaseModel: models.BaseModel{ID: 1}, Kind: models.ScreeningYesNo, Prompt: "Work permit?", Required: true, Knockout: true, ExpectedAnswer: &yes, RejectionReason: "location_or_availability"},
+		{BaseModel: models.BaseModel{ID: 2}, Kind: models.ScreeningChoice, Prompt: "English", Options: []string{"B1", "B2", "C1"}, Knockout: true, AcceptedOptions: []string{"B2", "C1"}, RejectionReason: "skills_gap"},
+		{BaseModel: models.BaseModel{ID: 3}, Kind: models.ScreeningNumber, Prompt: "Salary", Knockout: true, MaxValue: f(3000), RejectionReason: "salary_expectations"},
+		{BaseModel: models.BaseModel{ID: 4}, Kind: models.ScreeningText, Prompt: "Why us?"},
+	}
+
+	rows, failed, err := Evaluate(questions, map[uint]string{1: "Sí", 2: "c1", 3: "2500.50"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 0 {
+		t.Errorf("failed = %v, want none", failed)
+	}
+	var answers []string
+	for _, r := range rows {
+		answers = append(answers, r.Answer)
+	}
+	// La pregunta de texto no obligatoria y sin responder no se guarda.
+	if want := []string{"yes", "C1", "2500.5"}; !reflect.DeepEqual(answers, want) {
+		t.Errorf("answers = %v, want %v", answers, want)
+	}
+
+	rows, failed, err = Evaluate(questions, map[uint]string{1: "no", 2: "B1", 3: "2000", 4: "Great team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 2 || failed[0].ID != 1 || failed[1].ID != 2 {
+		t.Fatalf("failed = %v, want questions 1 and 2", failed)
+	}
+	if !rows[0].KnockedOut || !rows[1].KnockedOut || rows[2].KnockedOut || rows[3].KnockedOut {
+		t.Errorf("knocked out flags = %+v", rows)
+	}
+	if got := KnockoutReason(failed); got != "screening knockout: Work permit?; English" {
+		t.Errorf("reason = %q", got)
+	}
+}
+
+func TestEvaluateRejectsInvalidAnswers(t *testing.T) {
+	questions := []models.ScreeningQuestion{
+		{BaseModel: models.BaseModel{ID: 1}, Kind: models.ScreeningYesNo, Prompt: "Work permit?", Required: true},
+		{BaseModel: models.BaseModel{ID: 2}, Kind: models.ScreeningNumber, Prompt: "Years with Go"},
+		// Knockout guardada sin required (antes de exigirlo): en blanco no
+		// puede evitar el descarte.
+		{BaseModel: models.BaseModel{ID: 3}, Kind: models.ScreeningYesNo, Prompt: "Work permit?", Knockout: true, ExpectedAnswer: new(bool), RejectionReason: "other"},
+	}
+	cases := map[string]map[uint]string{
+		"missing required": {2: "3"},
+		"blank knockout":   {1: "yes", 2: "3", 3: "  "},
+		"unknown question": {1: "yes", 9: "x"},
+		"not yes or no":    {1: "maybe"},
+		"not a number":     {1: "yes", 2: "three"},
+	}
+	for name, answers := range cases {
+		if _, _, err := Evaluate(questions, answers); !errors.Is(err, ErrInvalidAnswers) {
+			t.Errorf("%s: err = %v, want ErrInvalidAnswers", name, err)
+		}
+	}
+}
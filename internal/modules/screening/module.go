@@ -0,0 +1,42 @@
+// Package screening es el punto de ensamblaje del módulo de preguntas de
+// screening: las que cada vacante hace al postularse por la career page
+// (con reglas knockout que rechazan automáticamente) y las respuestas
+// guardadas con cada postulación. Nadie importa este paquete salvo el
+// composition root.
+package screening
+
+import (
+	"dvra-api/internal/modules/screening/domain"
+	"dvra-api/internal/modules/screening/repository"
+	"dvra-api/internal/modules/screening/service"
+	"dvra-api/internal/modules/screening/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo screening.
+type Module struct {
+	// Service implementa el puerto con el que la career page muestra las
+	// preguntas, evalúa y guarda las respuestas.
+	Service *service.ScreeningService
+
+	reasons domain.ReasonCatalog
+}
+
+// New construye el módulo. reasons valida los motivos de rechazo de las
+// preguntas knockout.
+func New(db *gorm.DB, reasons domain.ReasonCatalog) *Module {
+	return &Module{Service: service.NewScreeningService(repository.NewScreeningRepository(db), reasons), reasons: reasons}
+}
+
+// ServiceTx devuelve un ScreeningService que escribe en la transacción tx
+// (las respuestas se guardan junto con la postulación).
+func (m *Module) ServiceTx(tx *gorm.DB) *service.ScreeningService {
+	return service.NewScreeningService(repository.NewScreeningRepository(tx), m.reasons)
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
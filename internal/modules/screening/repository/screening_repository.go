@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/screening/domain"
+
+	"gorm.io/gorm"
+)
+
+type screeningRepository struct {
+	db *gorm.DB
+}
+
+// NewScreeningRepository devuelve la implementación del puerto.
+func NewScreeningRepository(db *gorm.DB) domain.ScreeningRepository {
+	return &screeningRepository{db: db}
+}
+
+func (r *screeningRepository) Job(companyID, jobID uint) (*models.Job, error) {
+	query := r.db.Where("id = ?", jobID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var job models.Job
+	if err := query.First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *screeningRepository) Questions(jobID uint) ([]models.ScreeningQuestion, error) {
+	var questions []models.ScreeningQuestion
+	if err := r.db.Where("job_id = ?", jobID).Order("position ASC, id ASC").Find(&questions).Error; err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (r *screeningRepository) ReplaceQuestions(jobID uint, questions []models.ScreeningQuestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		keep := make([]uint, 0, len(questions))
+		for _, q := range questions {
+			if q.ID != 0 {
+				keep = append(keep, q.ID)
+			}
+		}
+		stale := tx.Where("job_id = ?", jobID)
+		if len(keep) > 0 {
+			stale = stale.Where("id NOT IN ?", keep)
+		}
+		if err := stale.Delete(&models.ScreeningQuestion{}).Error; err != nil {
+			return err
+		}
+		for i := range questions {
+			if err := tx.Save(&questions[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *screeningRepository) Application(companyID, applicationID uint) (*models.Application, error) {
+	query := r.db.Where("id = ?", applicationID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var application models.Application
+	if err := query.First(&application).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+func (r *screeningRepository) Answers(applicationID uint) ([]models.ScreeningAnswer, error) {
+	var answers []models.ScreeningAnswer
+	// La pregunta puede haberse quitado después: se ordena igual por su
+	// posición de entonces.
+	if err := r.db.
+		Joins("LEFT JOIN screening_questions q ON q.id = screening_answers.question_id").
+		Where("screening_answers.application_id = ?", applicationID).
+		Order("q.position ASC, screening_answers.id ASC").
+		Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (r *screeningRepository) CreateAnswers(answers []models.ScreeningAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	return r.db.Create(&answers).Error
+}
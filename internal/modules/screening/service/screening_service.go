@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/screening/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// ScreeningService gestiona las preguntas de screening de cada vacante y las
+// respuestas que el candidato da al postularse por la career page.
+type ScreeningService struct {
+	repo    domain.ScreeningRepository
+	reasons domain.ReasonCatalog
+}
+
+func NewScreeningService(repo domain.ScreeningRepository, reasons domain.ReasonCatalog) *ScreeningService {
+	return &ScreeningService{repo: repo, reasons: reasons}
+}
+
+// JobQuestions devuelve las preguntas de la vacante, con sus reglas.
+func (s *ScreeningService) JobQuestions(companyID, jobID uint) ([]models.ScreeningQuestion, error) {
+	if _, err := s.job(companyID, jobID); err != nil {
+		return nil, err
+	}
+	return s.repo.Questions(jobID)
+}
+
+// SetJobQuestions reemplaza las preguntas de la vacante en el orden dado.
+// Las que traen id deben ser de la vacante; las que faltan se eliminan (las
+// respuestas ya dadas conservan su copia de la pregunta).
+func (s *ScreeningService) SetJobQuestions(companyID, jobID uint, dto dtos.SetScreeningQuestionsDTO) ([]models.ScreeningQuestion, error) {
+	job, err := s.job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(dto.Questions) > domain.MaxQuestions {
+		return nil, apperr.BadRequest(fmt.Sprintf("a job admits at most %d questions", domain.MaxQuestions))
+	}
+	current, err := s.repo.Questions(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uint]*models.ScreeningQuestion, len(current))
+	for i := range current {
+		existing[current[i].ID] = &current[i]
+	}
+	reasons, err := s.rejectionReasons(job.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[uint]bool{}
+	questions := make([]models.ScreeningQuestion, 0, len(dto.Questions))
+	for i, in := range dto.Questions {
+		q := models.ScreeningQuestion{CompanyID: job.CompanyID, JobID: job.ID}
+		if in.ID != 0 {
+			prev, ok := existing[in.ID]
+			if !ok {
+				return nil, apperr.BadRequest(fmt.Sprintf("question %d is not part of this job", in.ID))
+			}
+			if seen[in.ID] {
+				return nil, apperr.BadRequest(fmt.Sprintf("question %d is listed twice", in.ID))
+			}
+			seen[in.ID] = true
+			q = *prev
+		}
+		q.Position = i
+		q.Kind = in.Kind
+		q.Prompt = in.Prompt
+		q.Options = in.Options
+		q.Required = in.Required
+		q.Knockout = in.Knockout
+		q.ExpectedAnswer = in.ExpectedAnswer
+		q.AcceptedOptions = in.AcceptedOptions
+		q.MinValue = in.MinValue
+		q.MaxValue = in.MaxValue
+		q.RejectionReason = in.RejectionReason
+		if err := domain.ValidateQuestion(&q); err != nil {
+			return nil, apperr.BadRequest(fmt.Sprintf("question %d: %v", i+1, err))
+		}
+		if q.Knockout && !reasons[q.RejectionReason] {
+			return nil, apperr.BadRequest(fmt.Sprintf("rejection_reason '%s' is not in the %s catalog", q.RejectionReason, models.RejectionTypeRejected))
+		}
+		questions = append(questions, q)
+	}
+
+	if err := s.repo.ReplaceQuestions(job.ID, questions); err != nil {
+		return nil, err
+	}
+	return s.repo.Questions(job.ID)
+}
+
+// ApplicationAnswers devuelve las respuestas de screening de la postulación.
+func (s *ScreeningService) ApplicationAnswers(companyID, applicationID uint) ([]models.ScreeningAnswer, error) {
+	application, err := s.repo.Application(companyID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperr.NotFound("application not found")
+	}
+	return s.repo.Answers(application.ID)
+}
+
+// Questions devuelve las preguntas que la career page muestra para la
+// vacante.
+func (s *ScreeningService) Questions(jobID uint) ([]models.ScreeningQuestion, error) {
+	return s.repo.Questions(jobID)
+}
+
+// Evaluate valida las respuestas de una postulación a la vacante (pregunta →
+// respuesta). Si alguna knockout no se cumple, el motivo de rechazo es el de
+// la primera, en el orden de las preguntas.
+func (s *ScreeningService) Evaluate(jobID uint, answers map[uint]string) (*dtos.ScreeningOutcome, error) {
+	questions, err := s.repo.Questions(jobID)
+	if err != nil {
+		return nil, err
+	}
+	rows, failed, err := domain.Evaluate(questions, answers)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAnswers) {
+			return nil, apperr.BadRequest(err.Error())
+		}
+		return nil, err
+	}
+	outcome := &dtos.ScreeningOutcome{Answers: rows}
+	if len(failed) > 0 {
+		outcome.RejectionReason = failed[0].RejectionReason
+		outcome.Reason = domain.KnockoutReason(failed)
+	}
+	return outcome, nil
+}
+
+// Record guarda las respuestas evaluadas con la postulación.
+func (s *ScreeningService) Record(applicationID uint, answers []models.ScreeningAnswer) error {
+	rows := make([]models.ScreeningAnswer, len(answers))
+	for i, a := range answers {
+		a.ApplicationID = applicationID
+		rows[i] = a
+	}
+	return s.repo.CreateAnswers(rows)
+}
+
+func (s *ScreeningService) job(companyID, jobID uint) (*models.Job, error) {
+	job, err := s.repo.Job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, apperr.NotFound("job not found")
+	}
+	return job, nil
+}
+
+// rejectionReasons devuelve los motivos rejected_by_us vigentes de la
+// empresa.
+func (s *ScreeningService) rejectionReasons(companyID uint) (map[string]bool, error) {
+	values, err := s.reasons.GetByCategory(models.RejectionCategory(models.RejectionTypeRejected), &companyID)
+	if err != nil {
+		return nil, err
+	}
+	reasons := make(map[string]bool, len(values))
+	for _, v := range values {
+		reasons[v.Value] = true
+	}
+	return reasons, nil
+}
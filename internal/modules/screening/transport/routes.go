@@ -0,0 +1,18 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/screening/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.ScreeningService) {
+	h := NewScreeningHandler(svc)
+
+	rg.GET("/jobs/:id/screening-questions", middleware.RequirePermission(permissions.JobsView), h.GetJobQuestions)
+	rg.PUT("/jobs/:id/screening-questions", middleware.RequirePermission(permissions.JobsUpdate), h.SetJobQuestions)
+	rg.GET("/applications/:id/screening", middleware.RequirePermission(permissions.ApplicationsView), h.GetApplicationAnswers)
+}
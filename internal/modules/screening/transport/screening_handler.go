@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/screening/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScreeningHandler struct {
+	svc *service.ScreeningService
+}
+
+func NewScreeningHandler(svc *service.ScreeningService) *ScreeningHandler {
+	return &ScreeningHandler{svc: svc}
+}
+
+// GetJobQuestions godoc
+// @Summary      Ver las preguntas de screening de una vacante
+// @Description  Preguntas que la career page hace al postularse, en orden, con sus reglas knockout (expected_answer, accepted_options, min_value/max_value) y el motivo de rechazo.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/screening-questions [get]
+func (h *ScreeningHandler) GetJobQuestions(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid job ID")
+	if !ok {
+		return
+	}
+	questions, err := h.svc.JobQuestions(companyID, id)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": questions, "count": len(questions)}})
+}
+
+// SetJobQuestions godoc
+// @Summary      Definir las preguntas de screening de una vacante
+// @Description  Reemplaza las preguntas en el orden dado (hasta 20; lista vacía = quitarlas todas). Con id se edita la existente, sin id se crea; las que faltan se eliminan y las respuestas ya dadas conservan su copia. kind es yes_no, choice (2 a 20 opciones), number o text. Una pregunta knockout descarta la postulación si la respuesta no cumple su regla: expected_answer (yes_no), accepted_options (choice, de entre options) o min_value/max_value (number); text no admite knockout. rejection_reason es obligatorio en las knockout y debe estar en el catálogo rejected_by_us.
+// @Tags         Jobs
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "Job ID"
+// @Param        body  body      dtos.SetScreeningQuestionsDTO  true  "Preguntas"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/screening-questions [put]
+func (h *ScreeningHandler) SetJobQuestions(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid job ID")
+	if !ok {
+		return
+	}
+	var dto dtos.SetScreeningQuestionsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	questions, err := h.svc.SetJobQuestions(companyID, id, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": questions, "count": len(questions)}})
+}
+
+// GetApplicationAnswers godoc
+// @Summary      Ver las respuestas de screening de una postulación
+// @Description  Respuestas dadas al postularse por la career page, en el orden de las preguntas, con la pregunta tal como se mostró. answer va normalizado (yes/no, la opción elegida, el número); knocked_out indica que no cumplió una regla knockout.
+// @Tags         Applications
+// @Produce      json
+// @Param        id   path      int  true  "Application ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /applications/{id}/screening [get]
+func (h *ScreeningHandler) GetApplicationAnswers(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid application ID")
+	if !ok {
+		return
+	}
+	answers, err := h.svc.ApplicationAnswers(companyID, id)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": answers, "count": len(answers)}})
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// Filters acotan una búsqueda. Varios TagIDs exigen todas las etiquetas;
+// Stage y JobID se refieren a la misma postulación activa.
+type Filters struct {
+	Source      string
+	TagIDs      []uint
+	Stage       string
+	JobID       uint
+	Contactable bool
+}
+
+// Hit es un candidato que coincide con la búsqueda y su relevancia.
+type Hit struct {
+	CandidateID uint
+	Rank        float64
+}
+
+// FacetCount es un valor de una faceta con la cantidad de candidatos que
+// coinciden. ID es el de la etiqueta o la vacante (0 en source y stage).
+type FacetCount struct {
+	ID    uint
+	Value string
+	Count int64
+}
+
+// Facets cuentan los resultados (todos, no solo la página) por fuente,
+// etiqueta, etapa y vacante de sus postulaciones activas.
+type Facets struct {
+	Sources []FacetCount
+	Tags    []FacetCount
+	Stages  []FacetCount
+	Jobs    []FacetCount
+}
+
+// SearchRepository es el puerto de salida hacia la persistencia. tsquery
+// está en la sintaxis de to_tsquery (ver Query.TSQuery).
+type SearchRepository interface {
+	// Search devuelve una página de coincidencias de la empresa, de la más
+	// relevante a la menos, y el total.
+	Search(companyID uint, tsquery string, filters Filters, limit, offset int) ([]Hit, int64, error)
+	// Facets cuenta todas las coincidencias por faceta.
+	Facets(companyID uint, tsquery string, filters Filters) (*Facets, error)
+	// Snippets devuelve, por candidato, los fragmentos de su texto con las
+	// coincidencias entre HighlightStart y HighlightStop.
+	Snippets(candidateIDs []uint, tsquery string) (map[uint]string, error)
+	// Candidates devuelve los candidatos activos entre ids.
+	Candidates(ids []uint) ([]models.Candidate, error)
+
+	// Index reconstruye el documento de los candidatos; los eliminados o
+	// anonimizados pierden el suyo.
+	Index(candidateIDs []uint) error
+	// StaleIDs devuelve candidatos sin documento o cuyo documento es anterior
+	// a su último cambio (perfil, postulaciones o comentarios).
+	StaleIDs(limit int) ([]uint, error)
+	// CandidateIDs devuelve, por id, los candidatos activos de la empresa
+	// (0 = todas) con id mayor que afterID.
+	CandidateIDs(companyID, afterID uint, limit int) ([]uint, error)
+	// PurgeOrphans borra los documentos de candidatos eliminados o
+	// anonimizados.
+	PurgeOrphans() (int64, error)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// MaxTerms acota los términos de una búsqueda.
+const MaxTerms = 10
+
+// ErrEmptyQuery: la búsqueda no tiene ningún término que buscar.
+var ErrEmptyQuery = errors.New("search query has no searchable terms")
+
+// stopwords son palabras vacías frecuentes en español, portugués e inglés. El
+// índice no las descarta (la configuración no tiene stemming ni stopwords),
+// pero buscadas como prefijo ("de:*") coinciden con casi todo.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "com": true, "con": true,
+	"da": true, "de": true, "del": true, "do": true, "dos": true, "e": true,
+	"el": true, "em": true, "en": true, "for": true, "in": true, "la": true,
+	"las": true, "los": true, "na": true, "no": true, "o": true, "of": true,
+	"or": true, "os": true, "para": true, "por": true, "the": true, "to": true,
+	"um": true, "un": true, "una": true, "uma": true, "with": true, "y": true,
+}
+
+// Query es una búsqueda ya normalizada: Terms deben aparecer todos (como
+// prefijo: "desarr" encuentra "desarrollador") y Excluded no debe aparecer
+// ninguno ("-junior").
+type Query struct {
+	Terms    []string
+	Excluded []string
+}
+
+// ParseQuery separa la búsqueda en palabras (letras y dígitos; el resto es
+// separador), en minúsculas, sin repetidas ni palabras vacías. Un "-" al
+// inicio de la palabra la excluye. Las tildes se conservan: las quita
+// PostgreSQL con la misma configuración del índice.
+func ParseQuery(raw string) (Query, error) {
+	var q Query
+	seen := map[string]bool{}
+	for _, field := range strings.Fields(raw) {
+		excluded := strings.HasPrefix(field, "-")
+		words := strings.FieldsFunc(strings.ToLower(field), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, w := range words {
+			if stopwords[w] || seen[w] || len(q.Terms)+len(q.Excluded) >= MaxTerms {
+				continue
+			}
+			seen[w] = true
+			if excluded {
+				q.Excluded = append(q.Excluded, w)
+			} else {
+				q.Terms = append(q.Terms, w)
+			}
+		}
+	}
+	if len(q.Terms) == 0 {
+		return Query{}, ErrEmptyQuery
+	}
+	return q, nil
+}
+
+// TSQuery devuelve la búsqueda en la sintaxis de to_tsquery. Los términos
+// solo tienen letras y dígitos, así que no pueden inyectar operadores.
+func (q Query) TSQuery() string {
+	parts := make([]string, 0, len(q.Terms)+len(q.Excluded))
+	for _, t := range q.Terms {
+		parts = append(parts, t+":*")
+	}
+	for _, t := range q.Excluded {
+		parts = append(parts, "!"+t+":*")
+	}
+	return strings.Join(parts, " & ")
+}
+
+// Marcadores que ts_headline pone alrededor de cada coincidencia. Son
+// caracteres de uso privado para poder escapar el resto del texto antes de
+// convertirlos en <mark>.
+const (
+	HighlightStart = "\ue000"
+	HighlightStop  = "\ue001"
+)
+
+// Highlight convierte el fragmento de ts_headline en HTML seguro: escapa el
+// texto (viene de comentarios y CVs) y marca las coincidencias con <mark>.
+func Highlight(fragment string) string {
+	escaped := html.EscapeString(strings.Join(strings.Fields(fragment), " "))
+	escaped = strings.ReplaceAll(escaped, HighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, HighlightStop, "</mark>")
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		raw      string
+		terms    []string
+		excluded []string
+		tsquery  string
+	}{
+		{"react native bogotá", []string{"react", "native", "bogotá"}, nil, "react:* & native:* & bogotá:*"},
+		{"Desarrollador de React-Native en São Paulo", []string{"desarrollador", "react", "native", "são", "paulo"}, nil, "desarrollador:* & react:* & native:* & são:* & paulo:*"},
+		{"java -junior JAVA", []string{"java"}, []string{"junior"}, "java:* & !junior:*"},
+		{"go'); DROP TABLE candidates; --", []string{"go", "drop", "table", "candidates"}, nil, "go:* & drop:* & table:* & candidates:*"},
+	}
+	for _, c := range cases {
+		q, err := ParseQuery(c.raw)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", c.raw, err)
+		}
+		if !reflect.DeepEqual(q.Terms, c.terms) || !reflect.DeepEqual(q.Excluded, c.excluded) {
+			t.Errorf("ParseQuery(%q) = %+v", c.raw, q)
+		}
+		if got := q.TSQuery(); got != c.tsquery {
+			t.Errorf("TSQuery(%q) = %q, want %q", c.raw, got, c.tsquery)
+		}
+	}
+
+	for _, raw := range []string{"", "  de la ", "-junior", "¿?"} {
+		if _, err := ParseQuery(raw); err != ErrEmptyQuery {
+			t.Errorf("ParseQuery(%q) err = %v, want ErrEmptyQuery", raw, err)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	in := "Lead <b>" + HighlightStart + "React" + HighlightStop + "</b>\n  en " + HighlightStart + "Bogotá" + HighlightStop
+	want := "Lead &lt;b&gt;<mark>React</mark>&lt;/b&gt; en <mark>Bogotá</mark>"
+	if got := Highlight(in); got != want {
+		t.Errorf("Highlight = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+// Package search es el punto de ensamblaje del módulo de búsqueda de texto
+// completo de candidatos: índice tsvector con unaccent (español, portugués),
+// ranking, fragmentos resaltados y facetas, más las tareas que lo mantienen.
+// Nadie importa este paquete salvo el composition root.
+package search
+
+import (
+	"time"
+
+	"dvra-api/internal/modules/search/repository"
+	"dvra-api/internal/modules/search/service"
+	"dvra-api/internal/modules/search/transport"
+	"dvra-api/internal/platform/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Intervalos de las tareas del módulo.
+const (
+	indexInterval   = 2 * time.Minute // candidatos nuevos o con cambios
+	rebuildInterval = 24 * time.Hour  // reconstrucción completa
+)
+
+// Module agrupa las dependencias ya cableadas del módulo search.
+type Module struct {
+	svc *service.SearchService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{svc: service.NewSearchService(repository.NewSearchRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.svc)
+}
+
+// RegisterJobs registra las tareas periódicas del módulo.
+func (m *Module) RegisterJobs(s *scheduler.Scheduler) {
+	s.Add(scheduler.Job{Name: "search.index", Interval: indexInterval, Run: m.svc.IndexStale})
+	s.Add(scheduler.Job{Name: "search.rebuild", Interval: rebuildInterval, Run: m.svc.Rebuild})
+}
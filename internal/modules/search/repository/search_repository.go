@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"time"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/search/domain"
+
+	"gorm.io/gorm"
+)
+
+// facetLimit acota los valores que se devuelven por faceta.
+const facetLimit = 20
+
+// Texto indexado de un candidato (alias c), por peso. El email también se
+// indexa partido ("ana.perez@acme.com" → "ana perez acme com") para que
+// buscar un nombre o un dominio lo encuentre. Los comentarios internos no
+// entran: su fragmento aparecería a quien no puede verlos.
+const (
+	nameSQL   = `concat_ws(' ', c.first_name, c.last_name, c.email, regexp_replace(c.email, '[@._+-]+', ' ', 'g'))`
+	sourceSQL = `coalesce(c.source, '')`
+	notesSQL  = `concat_ws(' ',
+		(SELECT string_agg(a.notes, ' ') FROM applications a
+			WHERE a.candidate_id = c.id AND a.deleted_at IS NULL),
+		(SELECT string_agg(m.body, ' ') FROM comments m
+			WHERE m.deleted_at IS NULL AND m.internal = false
+			AND (m.candidate_id = c.id OR m.application_id IN
+				(SELECT a.id FROM applications a WHERE a.candidate_id = c.id AND a.deleted_at IS NULL))))`
+	resumeSQL = `coalesce(c.resume_text, '')`
+
+	documentSQL = `setweight(to_tsvector('` + models.SearchConfig + `', ` + nameSQL + `), 'A') ||
+		setweight(to_tsvector('` + models.SearchConfig + `', ` + sourceSQL + `), 'B') ||
+		setweight(to_tsvector('` + models.SearchConfig + `', ` + notesSQL + `), 'C') ||
+		setweight(to_tsvector('` + models.SearchConfig + `', ` + resumeSQL + `), 'D')`
+	contentSQL = `concat_ws(' | ', concat_ws(' ', c.first_name, c.last_name, c.email), ` + sourceSQL + `, ` + notesSQL + `, ` + resumeSQL + `)`
+
+	tsquerySQL = `to_tsquery('` + models.SearchConfig + `', ?)`
+)
+
+// headlineOptions configuran ts_headline: hasta dos fragmentos cortos con las
+// coincidencias entre los marcadores del dominio.
+const headlineOptions = "StartSel=" + domain.HighlightStart + ", StopSel=" + domain.HighlightStop +
+	`, MaxWords=20, MinWords=8, MaxFragments=2, FragmentDelimiter=" … "`
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository devuelve la implementación del puerto.
+func NewSearchRepository(db *gorm.DB) domain.SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// matches arma la consulta de los documentos de la empresa que coinciden con
+// la búsqueda y los filtros (alias d para el documento, c para el candidato).
+func (r *searchRepository) matches(companyID uint, tsquery string, filters domain.Filters) *gorm.DB {
+	query := r.db.Table("candidate_search_documents AS d").
+		Joins("JOIN candidates c ON c.id = d.candidate_id AND c.deleted_at IS NULL AND c.anonymized_at IS NULL").
+		Where("d.deleted_at IS NULL AND d.company_id = ?", companyID).
+		Where("d.document @@ "+tsquerySQL, tsquery)
+
+	if filters.Source != "" {
+		query = query.Where("LOWER(c.source) = LOWER(?)", filters.Source)
+	}
+	for _, tagID := range filters.TagIDs {
+		query = query.Where("EXISTS (SELECT 1 FROM candidate_tags ct WHERE ct.candidate_id = c.id AND ct.tag_id = ? AND ct.deleted_at IS NULL)", tagID)
+	}
+	if filters.Stage != "" || filters.JobID != 0 {
+		app := r.db.Table("applications a").Select("1").Where("a.candidate_id = c.id AND a.deleted_at IS NULL")
+		if filters.Stage != "" {
+			app = app.Where("a.stage = ?", filters.Stage)
+		}
+		if filters.JobID != 0 {
+			app = app.Where("a.job_id = ?", filters.JobID)
+		}
+		query = query.Where("EXISTS (?)", app)
+	}
+	if filters.Contactable {
+		query = query.Where(models.ContactableCondition("c"))
+	}
+	return query
+}
+
+func (r *searchRepository) Search(companyID uint, tsquery string, filters domain.Filters, limit, offset int) ([]domain.Hit, int64, error) {
+	var total int64
+	if err := r.matches(companyID, tsquery, filters).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	hits := []domain.Hit{}
+	if total == 0 {
+		return hits, 0, nil
+	}
+	if err := r.matches(companyID, tsquery, filters).
+		Select("d.candidate_id, ts_rank_cd(d.document, "+tsquerySQL+") AS rank", tsquery).
+		Order("rank DESC, d.candidate_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&hits).Error; err != nil {
+		return nil, 0, err
+	}
+	return hits, total, nil
+}
+
+func (r *searchRepository) Facets(companyID uint, tsquery string, filters domain.Filters) (*domain.Facets, error) {
+	ids := func() *gorm.DB { return r.matches(companyID, tsquery, filters).Select("d.candidate_id") }
+	facets := &domain.Facets{}
+
+	if err := r.matches(companyID, tsquery, filters).
+		Select("c.source AS value, COUNT(*) AS count").
+		Where("c.source <> ''").
+		Group("c.source").
+		Order("count DESC, value ASC").
+		Limit(facetLimit).
+		Scan(&facets.Sources).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Table("candidate_tags ct").
+		Joins("JOIN tags t ON t.id = ct.tag_id AND t.deleted_at IS NULL").
+		Select("t.id AS id, t.name AS value, COUNT(DISTINCT ct.candidate_id) AS count").
+		Where("ct.deleted_at IS NULL AND ct.candidate_id IN (?)", ids()).
+		Group("t.id, t.name").
+		Order("count DESC, value ASC").
+		Limit(facetLimit).
+		Scan(&facets.Tags).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Table("applications a").
+		Select("a.stage AS value, COUNT(DISTINCT a.candidate_id) AS count").
+		Where("a.deleted_at IS NULL AND a.candidate_id IN (?)", ids()).
+		Group("a.stage").
+		Order("count DESC, value ASC").
+		Limit(facetLimit).
+		Scan(&facets.Stages).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Table("applications a").
+		Joins("JOIN jobs j ON j.id = a.job_id AND j.deleted_at IS NULL").
+		Select("j.id AS id, j.title AS value, COUNT(DISTINCT a.candidate_id) AS count").
+		Where("a.deleted_at IS NULL AND a.candidate_id IN (?)", ids()).
+		Group("j.id, j.title").
+		Order("count DESC, value ASC").
+		Limit(facetLimit).
+		Scan(&facets.Jobs).Error; err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func (r *searchRepository) Snippets(candidateIDs []uint, tsquery string) (map[uint]string, error) {
+	snippets := map[uint]string{}
+	if len(candidateIDs) == 0 {
+		return snippets, nil
+	}
+	var rows []struct {
+		CandidateID uint
+		Snippet     string
+	}
+	if err := r.db.Table("candidates c").
+		Select("c.id AS candidate_id, ts_headline('"+models.SearchConfig+"', "+contentSQL+", "+tsquerySQL+", ?) AS snippet", tsquery, headlineOptions).
+		Where("c.id IN ?", candidateIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		snippets[row.CandidateID] = row.Snippet
+	}
+	return snippets, nil
+}
+
+func (r *searchRepository) Candidates(ids []uint) ([]models.Candidate, error) {
+	var candidates []models.Candidate
+	if len(ids) == 0 {
+		return candidates, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *searchRepository) Index(candidateIDs []uint) error {
+	if len(candidateIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`INSERT INTO candidate_search_documents (candidate_id, company_id, document, indexed_at, created_at, updated_at)
+			SELECT c.id, c.company_id, `+documentSQL+`, ?, ?, ?
+			FROM candidates c
+			WHERE c.id IN ? AND c.deleted_at IS NULL AND c.anonymized_at IS NULL
+			ON CONFLICT (candidate_id) DO UPDATE SET
+				company_id = EXCLUDED.company_id,
+				document = EXCLUDED.document,
+				indexed_at = EXCLUDED.indexed_at,
+				updated_at = EXCLUDED.updated_at,
+				deleted_at = NULL`, now, now, now, candidateIDs).Error; err != nil {
+			return err
+		}
+		indexable := tx.Model(&models.Candidate{}).Select("id").Where("id IN ? AND anonymized_at IS NULL", candidateIDs)
+		return tx.Unscoped().
+			Where("candidate_id IN ? AND candidate_id NOT IN (?)", candidateIDs, indexable).
+			Delete(&models.CandidateSearchDocument{}).Error
+	})
+}
+
+func (r *searchRepository) StaleIDs(limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Table("candidates c").
+		Joins("LEFT JOIN candidate_search_documents d ON d.candidate_id = c.id").
+		Where("c.deleted_at IS NULL AND c.anonymized_at IS NULL").
+		// GREATEST ignora los NULL: un soft delete solo cambia deleted_at.
+		Where(`(d.id IS NULL OR c.updated_at > d.indexed_at
+			OR EXISTS (SELECT 1 FROM applications a WHERE a.candidate_id = c.id
+				AND GREATEST(a.updated_at, a.deleted_at) > d.indexed_at)
+			OR EXISTS (SELECT 1 FROM comments m WHERE GREATEST(m.updated_at, m.deleted_at) > d.indexed_at
+				AND (m.candidate_id = c.id OR m.application_id IN (SELECT a.id FROM applications a WHERE a.candidate_id = c.id))))`).
+		Order("c.id ASC").
+		Limit(limit).
+		Pluck("c.id", &ids).Error
+	return ids, err
+}
+
+func (r *searchRepository) CandidateIDs(companyID, afterID uint, limit int) ([]uint, error) {
+	query := r.db.Model(&models.Candidate{}).Where("id > ? AND anonymized_at IS NULL", afterID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var ids []uint
+	err := query.Order("id ASC").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *searchRepository) PurgeOrphans() (int64, error) {
+	active := r.db.Model(&models.Candidate{}).Select("id").Where("anonymized_at IS NULL")
+	result := r.db.Unscoped().Where("candidate_id NOT IN (?)", active).Delete(&models.CandidateSearchDocument{})
+	return result.RowsAffected, result.Error
+}
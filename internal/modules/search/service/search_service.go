@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/search/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+const (
+	// defaultLimit es el tamaño de página si no se indica limit.
+	defaultLimit = 20
+	// indexBatch es la cantidad de candidatos que se indexan por sentencia.
+	indexBatch = 200
+	// maxStaleBatches acota una pasada incremental; lo que quede lo toma la
+	// siguiente.
+	maxStaleBatches = 50
+)
+
+// SearchService busca candidatos por texto completo (nombre, email, fuente,
+// notas, comentarios no internos y CV) y mantiene el índice al día.
+type SearchService struct {
+	repo domain.SearchRepository
+}
+
+func NewSearchService(repo domain.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search devuelve una página de candidatos de la empresa que coinciden, de
+// mayor a menor relevancia, con un fragmento resaltado de cada uno y las
+// facetas de todas las coincidencias.
+func (s *SearchService) Search(companyID uint, query dtos.CandidateSearchQuery) (*dtos.CandidateSearchResultDTO, error) {
+	q, err := domain.ParseQuery(query.Q)
+	if err != nil {
+		return nil, apperr.BadRequest("q must contain at least one word to search for (stopwords like \"de\" or \"the\" are ignored)")
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	tsquery := q.TSQuery()
+	filters := domain.Filters{
+		Source:      query.Source,
+		TagIDs:      query.TagIDs,
+		Stage:       query.Stage,
+		JobID:       query.JobID,
+		Contactable: query.Contactable,
+	}
+
+	hits, total, err := s.repo.Search(companyID, tsquery, filters, limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	facets, err := s.repo.Facets(companyID, tsquery, filters)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(hits))
+	for i, h := range hits {
+		ids[i] = h.CandidateID
+	}
+	candidates, err := s.repo.Candidates(ids)
+	if err != nil {
+		return nil, err
+	}
+	snippets, err := s.repo.Snippets(ids, tsquery)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.Candidate, len(candidates))
+	for i := range candidates {
+		byID[candidates[i].ID] = &candidates[i]
+	}
+
+	items := make([]dtos.CandidateSearchHitDTO, 0, len(hits))
+	for _, h := range hits {
+		candidate, ok := byID[h.CandidateID]
+		if !ok {
+			continue // eliminado entre una consulta y otra
+		}
+		items = append(items, dtos.CandidateSearchHitDTO{
+			Candidate: dtos.ToCandidateResponse(candidate),
+			Rank:      h.Rank,
+			Snippet:   domain.Highlight(snippets[h.CandidateID]),
+		})
+	}
+	return &dtos.CandidateSearchResultDTO{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: query.Offset,
+		Facets: dtos.CandidateSearchFacetsDTO{
+			Source: facetDTOs(facets.Sources),
+			Tags:   facetDTOs(facets.Tags),
+			Stage:  facetDTOs(facets.Stages),
+			Job:    facetDTOs(facets.Jobs),
+		},
+	}, nil
+}
+
+// Reindex reconstruye el documento de todos los candidatos de la empresa
+// (0 = todas) y devuelve cuántos se indexaron.
+func (s *SearchService) Reindex(ctx context.Context, companyID uint) (int, error) {
+	indexed := 0
+	var afterID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return indexed, err
+		}
+		ids, err := s.repo.CandidateIDs(companyID, afterID, indexBatch)
+		if err != nil {
+			return indexed, err
+		}
+		if len(ids) == 0 {
+			return indexed, nil
+		}
+		if err := s.repo.Index(ids); err != nil {
+			return indexed, err
+		}
+		indexed += len(ids)
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// IndexStale es la tarea incremental: indexa los candidatos nuevos o con
+// cambios desde su último documento y borra los de candidatos eliminados o
+// anonimizados.
+func (s *SearchService) IndexStale(ctx context.Context) error {
+	indexed := 0
+	for i := 0; i < maxStaleBatches; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ids, err := s.repo.StaleIDs(indexBatch)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Index(ids); err != nil {
+			return err
+		}
+		indexed += len(ids)
+		if len(ids) < indexBatch {
+			break
+		}
+	}
+	purged, err := s.repo.PurgeOrphans()
+	if err != nil {
+		return err
+	}
+	if indexed > 0 || purged > 0 {
+		log.Printf("🔎 Índice de búsqueda: %d candidato(s) reindexado(s), %d documento(s) eliminado(s)", indexed, purged)
+	}
+	return nil
+}
+
+// Rebuild es la tarea nocturna: reconstruye todo el índice. Cubre los cambios
+// que no tocan updated_at (p. ej. filas movidas al fusionar candidatos).
+func (s *SearchService) Rebuild(ctx context.Context) error {
+	indexed, err := s.Reindex(ctx, 0)
+	if err != nil {
+		return err
+	}
+	log.Printf("🔎 Índice de búsqueda reconstruido: %d candidato(s)", indexed)
+	return nil
+}
+
+func facetDTOs(counts []domain.FacetCount) []dtos.SearchFacetDTO {
+	result := make([]dtos.SearchFacetDTO, len(counts))
+	for i, c := range counts {
+		result[i] = dtos.SearchFacetDTO{ID: c.ID, Value: c.Value, Count: c.Count}
+	}
+	return result
+}
@@ -0,0 +1,17 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/search/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.SearchService) {
+	h := NewSearchHandler(svc)
+
+	rg.GET("/candidates/search", middleware.RequirePermission(permissions.CandidatesView), h.SearchCandidates)
+	rg.POST("/candidates/search/reindex", middleware.RequirePermission(permissions.CandidatesReindex), h.Reindex)
+}
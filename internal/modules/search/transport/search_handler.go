@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/search/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	svc *service.SearchService
+}
+
+func NewSearchHandler(svc *service.SearchService) *SearchHandler {
+	return &SearchHandler{svc: svc}
+}
+
+// SearchCandidates godoc
+// @Summary      Buscar candidatos por texto completo
+// @Description  Busca en nombre, email, fuente, notas, comentarios no internos y texto del CV. Todas las palabras deben aparecer (como prefijo y sin importar tildes: "react native bogota" encuentra "React Native en Bogotá"); "-palabra" excluye. Resultados por relevancia con un fragmento resaltado (HTML escapado, coincidencias en <mark>), total y facetas (source, tags, stage, job) de todas las coincidencias. El índice se actualiza cada pocos minutos.
+// @Tags         Candidates
+// @Produce      json
+// @Param        q            query     string  true   "Texto a buscar"
+// @Param        source       query     string  false  "Fuente"
+// @Param        tag_id       query     []int   false  "Etiquetas (se exigen todas)"
+// @Param        stage        query     string  false  "Etapa de una postulación activa"
+// @Param        job_id       query     int     false  "Vacante de una postulación activa"
+// @Param        contactable  query     bool    false  "Solo contactables (consentimiento de talent pool vigente)"
+// @Param        limit        query     int     false  "Tamaño de página (1-100, por defecto 20)"
+// @Param        offset       query     int     false  "Desplazamiento"
+// @Param        company_id   query     int     false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200          {object}  map[string]interface{}
+// @Failure      400          {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/search [get]
+func (h *SearchHandler) SearchCandidates(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var query dtos.CandidateSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.Search(companyID, query)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// Reindex godoc
+// @Summary      Reconstruir el índice de búsqueda
+// @Description  Reindexa ya todos los candidatos de la empresa (SuperAdmin sin company_id: todas). Normalmente no hace falta: una tarea incremental lo mantiene al día y otra lo reconstruye cada noche.
+// @Tags         Candidates
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (SuperAdmin; sin ella, todas)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/search/reindex [post]
+func (h *SearchHandler) Reindex(c *gin.Context) {
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+	if companyID == 0 && c.Query("company_id") != "" {
+		id, err := strconv.ParseUint(c.Query("company_id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company_id"})
+			return
+		}
+		companyID = uint(id)
+	}
+
+	indexed, err := h.svc.Reindex(c.Request.Context(), companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": dtos.ReindexResultDTO{Indexed: indexed}})
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// Resultado de comparar una habilidad de la vacante con el candidato.
+const (
+	GapMet     = "met"     // la tiene con el nivel y los años pedidos
+	GapBelow   = "below"   // la tiene, pero con menos nivel o años (o no constan)
+	GapMissing = "missing" // no la tiene
+)
+
+// Gap es una habilidad que pide la vacante frente a la del candidato.
+type Gap struct {
+	SkillID        uint     `json:"skill_id"`
+	Name           string   `json:"name"`
+	Category       string   `json:"category"`
+	Requirement    string   `json:"requirement"`
+	Level          string   `json:"level,omitempty"`
+	MinYears       *float64 `json:"min_years,omitempty"`
+	CandidateLevel string   `json:"candidate_level,omitempty"` // declarado o derivado de los años
+	CandidateYears *float64 `json:"candidate_years,omitempty"`
+	Status         string   `json:"status"`
+}
+
+// GapReport compara las habilidades de una vacante con las de un candidato.
+type GapReport struct {
+	JobID         uint  `json:"job_id"`
+	CandidateID   uint  `json:"candidate_id"`
+	RequiredMet   int   `json:"required_met"`
+	RequiredTotal int   `json:"required_total"`
+	NiceMet       int   `json:"nice_to_have_met"`
+	NiceTotal     int   `json:"nice_to_have_total"`
+	Gaps          []Gap `json:"gaps"`
+}
+
+// Gaps compara cada habilidad de la vacante (con su Skill cargada) con las
+// del candidato. Un nivel pedido se cumple con el nivel efectivo (ver
+// EffectiveLevel); un mínimo de años, solo si los años constan.
+func Gaps(jobID, candidateID uint, required []models.JobSkill, has []models.CandidateSkill) *GapReport {
+	byID := make(map[uint]*models.CandidateSkill, len(has))
+	for i := range has {
+		byID[has[i].SkillID] = &has[i]
+	}
+	report := &GapReport{JobID: jobID, CandidateID: candidateID, Gaps: []Gap{}}
+	for _, js := range required {
+		gap := Gap{
+			SkillID:     js.SkillID,
+			Requirement: js.Requirement,
+			Level:       js.Level,
+			MinYears:    js.MinYears,
+			Status:      GapMissing,
+		}
+		if js.Skill != nil {
+			gap.Name, gap.Category = js.Skill.Name, js.Skill.Category
+		}
+		if cs, ok := byID[js.SkillID]; ok {
+			gap.CandidateYears = cs.Years
+			level := EffectiveLevel(cs)
+			if level > 0 {
+				gap.CandidateLevel = Levels[level-1]
+			}
+			gap.Status = GapMet
+			if level < LevelRank(js.Level) || (js.MinYears != nil && (cs.Years == nil || *cs.Years < *js.MinYears)) {
+				gap.Status = GapBelow
+			}
+		}
+		if js.Requirement == models.JobSkillRequired {
+			report.RequiredTotal++
+			if gap.Status == GapMet {
+				report.RequiredMet++
+			}
+		} else {
+			report.NiceTotal++
+			if gap.Status == GapMet {
+				report.NiceMet++
+			}
+		}
+		report.Gaps = append(report.Gaps, gap)
+	}
+	return report
+}
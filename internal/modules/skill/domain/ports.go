@@ -0,0 +1,38 @@
+package domain
+
+import "dvra-api/internal/app/models"
+
+// SkillRepository es el puerto de salida hacia la persistencia. Los métodos
+// que buscan devuelven nil, nil si no hay resultado.
+type SkillRepository interface {
+	// Catalog devuelve las habilidades globales y las de la empresa
+	// (companyID 0 = solo las globales), por nombre.
+	Catalog(companyID uint) ([]models.Skill, error)
+	GetSkill(id uint) (*models.Skill, error)
+	CreateSkill(skill *models.Skill) error
+	UpdateSkill(skill *models.Skill) error
+	// DeleteSkill elimina la habilidad y la quita de vacantes y candidatos.
+	DeleteSkill(id uint) error
+
+	// Job busca una vacante de la empresa (companyID 0 = cualquiera).
+	Job(companyID, jobID uint) (*models.Job, error)
+	// JobSkills devuelve las habilidades de la vacante con su Skill: las
+	// requeridas primero y luego por nombre.
+	JobSkills(jobID uint) ([]models.JobSkill, error)
+	// ReplaceJobSkills deja en la vacante exactamente skills.
+	ReplaceJobSkills(jobID uint, skills []models.JobSkill) error
+
+	// Candidate busca un candidato de la empresa (companyID 0 = cualquiera).
+	Candidate(companyID, candidateID uint) (*models.Candidate, error)
+	// CandidateSkills devuelve las habilidades del candidato con su Skill,
+	// por nombre.
+	CandidateSkills(candidateID uint) ([]models.CandidateSkill, error)
+	// CandidatesSkills devuelve las habilidades de varios candidatos con su
+	// Skill (candidato → habilidades).
+	CandidatesSkills(candidateIDs []uint) (map[uint][]models.CandidateSkill, error)
+	// ReplaceCandidateSkills deja en el candidato exactamente skills.
+	ReplaceCandidateSkills(candidateID uint, skills []models.CandidateSkill) error
+	// AddResumeSkills agrega las habilidades que el candidato no tiene y
+	// actualiza los años de las que ya venían del CV; las manuales no se tocan.
+	AddResumeSkills(candidateID uint, skills []models.CandidateSkill) error
+}
@@ -0,0 +1,115 @@
+// Package domain contiene las reglas del catálogo de habilidades: cómo se
+// identifica una habilidad por su nombre o alias, los niveles de dominio y
+// la comparación entre lo que pide una vacante y lo que tiene un candidato.
+package domain
+
+import (
+	"strings"
+
+	"dvra-api/internal/app/models"
+)
+
+// Categories son las categorías válidas del catálogo.
+var Categories = []string{
+	models.SkillCategoryLanguage, models.SkillCategoryFrontend, models.SkillCategoryBackend,
+	models.SkillCategoryData, models.SkillCategoryCloud, models.SkillCategoryMobile,
+	models.SkillCategoryTesting, models.SkillCategoryManagement, models.SkillCategorySoft,
+	models.SkillCategoryOther,
+}
+
+// Levels son los niveles de dominio, de menor a mayor.
+var Levels = []string{models.SkillLevelBasic, models.SkillLevelIntermediate, models.SkillLevelAdvanced, models.SkillLevelExpert}
+
+var accents = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"à", "a", "â", "a", "ã", "a", "ê", "e", "ô", "o", "õ", "o", "ç", "c",
+)
+
+// Key es la forma con la que se comparan nombres y alias: sin mayúsculas,
+// acentos ni espacios repetidos ("Node JS " → "node js").
+func Key(s string) string {
+	return accents.Replace(strings.Join(strings.Fields(strings.ToLower(s)), " "))
+}
+
+// Keys devuelve las claves de la habilidad: su nombre y sus alias.
+func Keys(s *models.Skill) []string {
+	keys := []string{Key(s.Name)}
+	for _, a := range s.Aliases {
+		keys = append(keys, Key(a))
+	}
+	return keys
+}
+
+// Catalog indexa las habilidades que ve una empresa (las globales y las
+// suyas) por nombre y alias.
+type Catalog struct {
+	byKey map[string]*models.Skill
+}
+
+// NewCatalog arma el índice. Si una habilidad de la empresa y una global
+// comparten una clave, prevalece la de la empresa.
+func NewCatalog(skills []models.Skill) *Catalog {
+	c := &Catalog{byKey: map[string]*models.Skill{}}
+	for i := range skills {
+		s := &skills[i]
+		for _, k := range Keys(s) {
+			if prev, ok := c.byKey[k]; ok && (prev.CompanyID != nil || s.CompanyID == nil) {
+				continue
+			}
+			c.byKey[k] = s
+		}
+	}
+	return c
+}
+
+// Lookup busca la habilidad por nombre o alias; nil si no existe.
+func (c *Catalog) Lookup(name string) *models.Skill {
+	return c.byKey[Key(name)]
+}
+
+// Conflict devuelve la clave de s que ya usa otra habilidad del catálogo
+// ("" si no hay).
+func (c *Catalog) Conflict(s *models.Skill) string {
+	for _, k := range Keys(s) {
+		if other, ok := c.byKey[k]; ok && other.ID != s.ID {
+			return k
+		}
+	}
+	return ""
+}
+
+// Visible indica si la empresa puede usar la habilidad: es global o suya.
+func Visible(s *models.Skill, companyID uint) bool {
+	return s.CompanyID == nil || *s.CompanyID == companyID
+}
+
+// LevelRank ordena los niveles (basic = 1 … expert = 4; 0 si no consta).
+func LevelRank(level string) int {
+	for i, l := range Levels {
+		if l == level {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// EffectiveLevel es el nivel del candidato en una habilidad: el declarado
+// o, si no consta, el que corresponde a sus años de experiencia (menos de 2
+// basic, menos de 4 intermediate, menos de 7 advanced, luego expert).
+func EffectiveLevel(cs *models.CandidateSkill) int {
+	if rank := LevelRank(cs.Level); rank > 0 {
+		return rank
+	}
+	if cs.Years == nil {
+		return 0
+	}
+	switch y := *cs.Years; {
+	case y < 2:
+		return 1
+	case y < 4:
+		return 2
+	case y < 7:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestKey(t *testing.T) {
+	cases := map[string]string{
+		"  Node   JS ": "node js",
+		"Comunicación": "comunicacion",
+		"C#":           "c#",
+	}
+	for in, want := range cases {
+		if got := Key(in); got != want {
+			t.Errorf("Key(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	company := uint(7)
+	skills := []models.Skill{
+		{BaseModel: models.BaseModel{ID: 1}, Name: "JavaScript", Aliases: []string{"JS"}},
+		{BaseModel: models.BaseModel{ID: 2}, Name: "SAP"},
+		{BaseModel: models.BaseModel{ID: 3}, CompanyID: &company, Name: "SAP ABAP", Aliases: []string{"sap"}},
+	}
+	c := NewCatalog(skills)
+
+	if s := c.Lookup("js"); s == nil || s.ID != 1 {
+		t.Errorf("js = %+v", s)
+	}
+	// La de la empresa prevalece sobre la global.
+	if s := c.Lookup("SAP"); s == nil || s.ID != 3 {
+		t.Errorf("sap = %+v", s)
+	}
+	if s := c.Lookup("cobol"); s != nil {
+		t.Errorf("cobol = %+v", s)
+	}
+
+	if key := c.Conflict(&models.Skill{Name: "ECMAScript", Aliases: []string{"Js"}}); key != "js" {
+		t.Errorf("conflict = %q", key)
+	}
+	// Renombrar una habilidad no choca con sus propias claves.
+	if key := c.Conflict(&models.Skill{BaseModel: models.BaseModel{ID: 1}, Name: "Javascript", Aliases: []string{"JS"}}); key != "" {
+		t.Errorf("self conflict = %q", key)
+	}
+}
+
+func TestEffectiveLevel(t *testing.T) {
+	years := func(v float64) *float64 { return &v }
+	cases := []struct {
+		skill models.CandidateSkill
+		want  int
+	}{
+		{models.CandidateSkill{}, 0},
+		{models.CandidateSkill{Level: models.SkillLevelAdvanced, Years: years(1)}, 3},
+		{models.CandidateSkill{Years: years(1.5)}, 1},
+		{models.CandidateSkill{Years: years(3)}, 2},
+		{models.CandidateSkill{Years: years(6.9)}, 3},
+		{models.CandidateSkill{Years: years(10)}, 4},
+	}
+	for _, c := range cases {
+		if got := EffectiveLevel(&c.skill); got != c.want {
+			t.Errorf("EffectiveLevel(%+v) = %d, want %d", c.skill, got, c.want)
+		}
+	}
+}
+
+func TestGaps(t *testing.T) {
+	years := func(v float64) *float64 { return &v }
+	goSkill := &models.Skill{BaseModel: models.BaseModel{ID: 1}, Name: "Go", Category: models.SkillCategoryLanguage}
+	required := []models.JobSkill{
+		{SkillID: 1, Skill: goSkill, Requirement: models.JobSkillRequired, Level: models.SkillLevelAdvanced},
+		{SkillID: 2, Requirement: models.JobSkillRequired, MinYears: years(2)},
+		{SkillID: 3, Requirement: models.JobSkillRequired},
+		{SkillID: 4, Requirement: models.JobSkillNiceToHave},
+	}
+	has := []models.CandidateSkill{
+		{SkillID: 1, Years: years(5)},                // advanced por años
+		{SkillID: 2, Level: models.SkillLevelExpert}, // sin años: no acredita el mínimo
+		{SkillID: 4, Source: models.CandidateSkillResume},
+	}
+	got := Gaps(10, 20, required, has)
+
+	var statuses []string
+	for _, g := range got.Gaps {
+		statuses = append(statuses, g.Status)
+	}
+	if want := []string{GapMet, GapBelow, GapMissing, GapMet}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses = %v", statuses)
+	}
+	if got.Gaps[0].Name != "Go" || got.Gaps[0].CandidateLevel != models.SkillLevelAdvanced {
+		t.Errorf("gap[0] = %+v", got.Gaps[0])
+	}
+	if got.RequiredMet != 1 || got.RequiredTotal != 3 || got.NiceMet != 1 || got.NiceTotal != 1 {
+		t.Errorf("summary = %+v", got)
+	}
+}
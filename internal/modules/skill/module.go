@@ -0,0 +1,32 @@
+// Package skill es el punto de ensamblaje del módulo de habilidades: el
+// catálogo (global y de cada empresa, con categorías y alias), las
+// habilidades que pide cada vacante y las que tiene cada candidato, y la
+// comparación entre ambas. Nadie importa este paquete salvo el composition
+// root.
+package skill
+
+import (
+	"dvra-api/internal/modules/skill/repository"
+	"dvra-api/internal/modules/skill/service"
+	"dvra-api/internal/modules/skill/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo skill.
+type Module struct {
+	// Service implementa el puerto con el que el módulo resume carga las
+	// habilidades de cada CV analizado.
+	Service *service.SkillService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{Service: service.NewSkillService(repository.NewSkillRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
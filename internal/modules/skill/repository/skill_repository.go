@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"errors"
+
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/skill/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type skillRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillRepository devuelve la implementación del puerto.
+func NewSkillRepository(db *gorm.DB) domain.SkillRepository {
+	return &skillRepository{db: db}
+}
+
+func (r *skillRepository) Catalog(companyID uint) ([]models.Skill, error) {
+	query := r.db.Where("company_id IS NULL")
+	if companyID != 0 {
+		query = r.db.Where("company_id IS NULL OR company_id = ?", companyID)
+	}
+	var skills []models.Skill
+	if err := query.Order("LOWER(name) ASC, id ASC").Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *skillRepository) GetSkill(id uint) (*models.Skill, error) {
+	var skill models.Skill
+	if err := r.db.First(&skill, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &skill, nil
+}
+
+func (r *skillRepository) CreateSkill(skill *models.Skill) error {
+	return r.db.Create(skill).Error
+}
+
+func (r *skillRepository) UpdateSkill(skill *models.Skill) error {
+	return r.db.Save(skill).Error
+}
+
+func (r *skillRepository) DeleteSkill(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("skill_id = ?", id).Delete(&models.JobSkill{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("skill_id = ?", id).Delete(&models.CandidateSkill{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Skill{}, id).Error
+	})
+}
+
+func (r *skillRepository) Job(companyID, jobID uint) (*models.Job, error) {
+	query := r.db.Where("id = ?", jobID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var job models.Job
+	if err := query.First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *skillRepository) JobSkills(jobID uint) ([]models.JobSkill, error) {
+	var skills []models.JobSkill
+	if err := r.db.
+		Joins("Skill").
+		Where("job_skills.job_id = ?", jobID).
+		Order("CASE WHEN job_skills.requirement = 'required' THEN 0 ELSE 1 END, LOWER(\"Skill\".name) ASC").
+		Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *skillRepository) ReplaceJobSkills(jobID uint, skills []models.JobSkill) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := make([]uint, 0, len(skills))
+		for _, s := range skills {
+			ids = append(ids, s.SkillID)
+		}
+		stale := tx.Unscoped().Where("job_id = ?", jobID)
+		if len(ids) > 0 {
+			stale = stale.Where("skill_id NOT IN ?", ids)
+		}
+		if err := stale.Delete(&models.JobSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "job_id"}, {Name: "skill_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"requirement", "level", "min_years", "updated_at"}),
+		}).Create(&skills).Error
+	})
+}
+
+func (r *skillRepository) Candidate(companyID, candidateID uint) (*models.Candidate, error) {
+	query := r.db.Where("id = ?", candidateID)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var candidate models.Candidate
+	if err := query.First(&candidate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+func (r *skillRepository) CandidateSkills(candidateID uint) ([]models.CandidateSkill, error) {
+	var skills []models.CandidateSkill
+	if err := r.db.
+		Joins("Skill").
+		Where("candidate_skills.candidate_id = ?", candidateID).
+		Order("LOWER(\"Skill\".name) ASC").
+		Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *skillRepository) CandidatesSkills(candidateIDs []uint) (map[uint][]models.CandidateSkill, error) {
+	out := make(map[uint][]models.CandidateSkill, len(candidateIDs))
+	if len(candidateIDs) == 0 {
+		return out, nil
+	}
+	var skills []models.CandidateSkill
+	if err := r.db.
+		Joins("Skill").
+		Where("candidate_skills.candidate_id IN ?", candidateIDs).
+		Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range skills {
+		out[s.CandidateID] = append(out[s.CandidateID], s)
+	}
+	return out, nil
+}
+
+func (r *skillRepository) ReplaceCandidateSkills(candidateID uint, skills []models.CandidateSkill) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := make([]uint, 0, len(skills))
+		for _, s := range skills {
+			ids = append(ids, s.SkillID)
+		}
+		stale := tx.Unscoped().Where("candidate_id = ?", candidateID)
+		if len(ids) > 0 {
+			stale = stale.Where("skill_id NOT IN ?", ids)
+		}
+		if err := stale.Delete(&models.CandidateSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "candidate_id"}, {Name: "skill_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"level", "years", "source", "updated_at"}),
+		}).Create(&skills).Error
+	})
+}
+
+func (r *skillRepository) AddResumeSkills(candidateID uint, skills []models.CandidateSkill) error {
+	if len(skills) == 0 {
+		return nil
+	}
+	for i := range skills {
+		skills[i].CandidateID = candidateID
+		skills[i].Source = models.CandidateSkillResume
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "candidate_id"}, {Name: "skill_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"years", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "candidate_skills.source = ?", Vars: []interface{}{models.CandidateSkillResume}},
+		}},
+	}).Create(&skills).Error
+}
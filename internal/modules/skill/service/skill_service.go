@@ -0,0 +1,377 @@
+package service
+
+import (
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/skill/domain"
+	"dvra-api/internal/shared/apperr"
+
+	"gorm.io/datatypes"
+)
+
+// SkillService gestiona el catálogo de habilidades (global y de cada
+// empresa), las que pide cada vacante y las que tiene cada candidato.
+type SkillService struct {
+	repo domain.SkillRepository
+}
+
+func NewSkillService(repo domain.SkillRepository) *SkillService {
+	return &SkillService{repo: repo}
+}
+
+// List devuelve el catálogo que ve la empresa (companyID 0 = solo las
+// globales), filtrado por categoría y por texto en nombre o alias.
+func (s *SkillService) List(companyID uint, filters dtos.SkillFilters) ([]models.Skill, error) {
+	skills, err := s.repo.Catalog(companyID)
+	if err != nil {
+		return nil, err
+	}
+	q := domain.Key(filters.Q)
+	out := []models.Skill{}
+	for i := range skills {
+		if filters.Category != "" && skills[i].Category != filters.Category {
+			continue
+		}
+		if q != "" && !matches(&skills[i], q) {
+			continue
+		}
+		out = append(out, skills[i])
+	}
+	return out, nil
+}
+
+func matches(skill *models.Skill, q string) bool {
+	for _, k := range domain.Keys(skill) {
+		if strings.Contains(k, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// Create agrega una habilidad al catálogo de la empresa (companyID 0 =
+// global, solo SuperAdmin). Nombre y alias no pueden coincidir con otra
+// habilidad que vea la empresa.
+func (s *SkillService) Create(companyID uint, dto dtos.CreateSkillDTO) (*models.Skill, error) {
+	skill := &models.Skill{
+		Name:     strings.TrimSpace(dto.Name),
+		Category: dto.Category,
+		Aliases:  cleanAliases(dto.Name, dto.Aliases),
+	}
+	if companyID != 0 {
+		skill.CompanyID = &companyID
+	}
+	if skill.Name == "" {
+		return nil, apperr.BadRequest("name is required")
+	}
+	if err := s.checkConflict(companyID, skill); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateSkill(skill); err != nil {
+		return nil, err
+	}
+	return skill, nil
+}
+
+// Update cambia nombre, categoría o alias. Las globales solo las edita
+// SuperAdmin (companyID 0).
+func (s *SkillService) Update(id, companyID uint, dto dtos.UpdateSkillDTO) (*models.Skill, error) {
+	skill, err := s.editable(id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, apperr.BadRequest("name cannot be empty")
+		}
+		skill.Name = name
+	}
+	if dto.Category != nil {
+		skill.Category = *dto.Category
+	}
+	if dto.Aliases != nil {
+		skill.Aliases = cleanAliases(skill.Name, dto.Aliases)
+	}
+	scope := uint(0)
+	if skill.CompanyID != nil {
+		scope = *skill.CompanyID
+	}
+	if err := s.checkConflict(scope, skill); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSkill(skill); err != nil {
+		return nil, err
+	}
+	return skill, nil
+}
+
+// Delete elimina la habilidad y la quita de vacantes y candidatos.
+func (s *SkillService) Delete(id, companyID uint) error {
+	if _, err := s.editable(id, companyID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSkill(id)
+}
+
+// editable busca una habilidad que companyID puede modificar: las suyas
+// (SuperAdmin, cualquiera).
+func (s *SkillService) editable(id, companyID uint) (*models.Skill, error) {
+	skill, err := s.repo.GetSkill(id)
+	if err != nil {
+		return nil, err
+	}
+	if skill == nil || (companyID != 0 && !domain.Visible(skill, companyID)) {
+		return nil, apperr.NotFound("skill not found")
+	}
+	if companyID != 0 && skill.CompanyID == nil {
+		return nil, apperr.Forbidden("global skills can only be changed by the platform")
+	}
+	return skill, nil
+}
+
+func (s *SkillService) checkConflict(companyID uint, skill *models.Skill) error {
+	skills, err := s.repo.Catalog(companyID)
+	if err != nil {
+		return err
+	}
+	if key := domain.NewCatalog(skills).Conflict(skill); key != "" {
+		return apperr.Conflict("another skill is already named or aliased " + key)
+	}
+	return nil
+}
+
+// cleanAliases recorta los alias y descarta los vacíos, repetidos o iguales
+// al nombre.
+func cleanAliases(name string, aliases []string) datatypes.JSONSlice[string] {
+	seen := map[string]bool{domain.Key(name): true}
+	out := datatypes.JSONSlice[string]{}
+	for _, a := range aliases {
+		a = strings.TrimSpace(a)
+		if k := domain.Key(a); k != "" && !seen[k] {
+			seen[k] = true
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// JobSkills devuelve las habilidades que pide la vacante.
+func (s *SkillService) JobSkills(companyID, jobID uint) ([]models.JobSkill, error) {
+	if _, err := s.job(companyID, jobID); err != nil {
+		return nil, err
+	}
+	return s.repo.JobSkills(jobID)
+}
+
+// SetJobSkills reemplaza las habilidades de la vacante. Todas deben ser del
+// catálogo que ve la empresa de la vacante.
+func (s *SkillService) SetJobSkills(companyID, jobID uint, dto dtos.SetJobSkillsDTO) ([]models.JobSkill, error) {
+	job, err := s.job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(dto.Skills))
+	for _, in := range dto.Skills {
+		ids = append(ids, in.SkillID)
+	}
+	if err := s.checkSkills(job.CompanyID, ids); err != nil {
+		return nil, err
+	}
+	rows := make([]models.JobSkill, 0, len(dto.Skills))
+	for _, in := range dto.Skills {
+		rows = append(rows, models.JobSkill{
+			JobID:       job.ID,
+			SkillID:     in.SkillID,
+			Requirement: in.Requirement,
+			Level:       in.Level,
+			MinYears:    in.MinYears,
+		})
+	}
+	if err := s.repo.ReplaceJobSkills(job.ID, rows); err != nil {
+		return nil, err
+	}
+	return s.repo.JobSkills(job.ID)
+}
+
+// CandidateSkills devuelve las habilidades del candidato.
+func (s *SkillService) CandidateSkills(companyID, candidateID uint) ([]models.CandidateSkill, error) {
+	if _, err := s.candidate(companyID, candidateID); err != nil {
+		return nil, err
+	}
+	return s.repo.CandidateSkills(candidateID)
+}
+
+// SetCandidateSkills reemplaza las habilidades del candidato. Las que
+// llegan sin cambios respecto de lo inferido del CV conservan ese origen;
+// el resto queda como carga manual, que el CV ya no modifica.
+func (s *SkillService) SetCandidateSkills(companyID, candidateID uint, dto dtos.SetCandidateSkillsDTO) ([]models.CandidateSkill, error) {
+	candidate, err := s.candidate(companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(dto.Skills))
+	for _, in := range dto.Skills {
+		ids = append(ids, in.SkillID)
+	}
+	if err := s.checkSkills(candidate.CompanyID, ids); err != nil {
+		return nil, err
+	}
+	current, err := s.repo.CandidateSkills(candidate.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uint]*models.CandidateSkill, len(current))
+	for i := range current {
+		existing[current[i].SkillID] = &current[i]
+	}
+	rows := make([]models.CandidateSkill, 0, len(dto.Skills))
+	for _, in := range dto.Skills {
+		row := models.CandidateSkill{
+			CandidateID: candidate.ID,
+			SkillID:     in.SkillID,
+			Level:       in.Level,
+			Years:       in.Years,
+			Source:      models.CandidateSkillManual,
+		}
+		if prev, ok := existing[in.SkillID]; ok && prev.Source == models.CandidateSkillResume &&
+			prev.Level == in.Level && sameYears(prev.Years, in.Years) {
+			row.Source = models.CandidateSkillResume
+		}
+		rows = append(rows, row)
+	}
+	if err := s.repo.ReplaceCandidateSkills(candidate.ID, rows); err != nil {
+		return nil, err
+	}
+	return s.repo.CandidateSkills(candidate.ID)
+}
+
+func sameYears(a, b *float64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// SyncFromResume suma a la ficha las habilidades de un CV analizado que
+// existen en el catálogo de la empresa (por nombre o alias), con los años
+// de los puestos que las mencionan. Lo llama el módulo resume.
+func (s *SkillService) SyncFromResume(companyID, candidateID uint, data models.ResumeData) error {
+	if len(data.Skills) == 0 {
+		return nil
+	}
+	skills, err := s.repo.Catalog(companyID)
+	if err != nil {
+		return err
+	}
+	catalog := domain.NewCatalog(skills)
+	seen := map[uint]bool{}
+	var rows []models.CandidateSkill
+	for _, name := range data.Skills {
+		skill := catalog.Lookup(name)
+		if skill == nil || seen[skill.ID] {
+			continue
+		}
+		seen[skill.ID] = true
+		row := models.CandidateSkill{SkillID: skill.ID}
+		if years, ok := data.SkillYears[name]; ok && years > 0 {
+			row.Years = &years
+		}
+		rows = append(rows, row)
+	}
+	return s.repo.AddResumeSkills(candidateID, rows)
+}
+
+// Gaps compara las habilidades que pide la vacante con las del candidato.
+// Ambos deben ser de la misma empresa.
+func (s *SkillService) Gaps(companyID, jobID, candidateID uint) (*domain.GapReport, error) {
+	job, err := s.job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.candidate(job.CompanyID, candidateID); err != nil {
+		return nil, err
+	}
+	required, err := s.repo.JobSkills(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	has, err := s.repo.CandidateSkills(candidateID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Gaps(job.ID, candidateID, required, has), nil
+}
+
+// Reports compara las habilidades de la vacante con las de cada candidato
+// (hay un reporte por cada ID, aunque no tenga habilidades). No valida la
+// empresa: lo usa el módulo match con una vacante ya resuelta.
+func (s *SkillService) Reports(jobID uint, candidateIDs []uint) (map[uint]*domain.GapReport, error) {
+	required, err := s.repo.JobSkills(jobID)
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[uint]*domain.GapReport, len(candidateIDs))
+	if len(required) == 0 {
+		for _, id := range candidateIDs {
+			reports[id] = domain.Gaps(jobID, id, nil, nil)
+		}
+		return reports, nil
+	}
+	has, err := s.repo.CandidatesSkills(candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range candidateIDs {
+		reports[id] = domain.Gaps(jobID, id, required, has[id])
+	}
+	return reports, nil
+}
+
+// checkSkills valida que los IDs no se repitan y sean del catálogo que ve
+// la empresa.
+func (s *SkillService) checkSkills(companyID uint, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	skills, err := s.repo.Catalog(companyID)
+	if err != nil {
+		return err
+	}
+	known := make(map[uint]bool, len(skills))
+	for _, sk := range skills {
+		known[sk.ID] = true
+	}
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return apperr.BadRequest("each skill can only be listed once")
+		}
+		seen[id] = true
+		if !known[id] {
+			return apperr.Unprocessable("skill not found in the company's catalog")
+		}
+	}
+	return nil
+}
+
+func (s *SkillService) job(companyID, jobID uint) (*models.Job, error) {
+	job, err := s.repo.Job(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, apperr.NotFound("job not found")
+	}
+	return job, nil
+}
+
+func (s *SkillService) candidate(companyID, candidateID uint) (*models.Candidate, error) {
+	candidate, err := s.repo.Candidate(companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil {
+		return nil, apperr.NotFound("candidate not found")
+	}
+	return candidate, nil
+}
@@ -0,0 +1,28 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/skill/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.SkillService) {
+	h := NewSkillHandler(svc)
+
+	skills := rg.Group("/skills")
+	{
+		skills.GET("", middleware.RequirePermission(permissions.SkillsView), h.GetSkills)
+		skills.POST("", middleware.RequirePermission(permissions.SkillsManage), h.CreateSkill)
+		skills.PUT("/:id", middleware.RequirePermission(permissions.SkillsManage), h.UpdateSkill)
+		skills.DELETE("/:id", middleware.RequirePermission(permissions.SkillsManage), h.DeleteSkill)
+	}
+
+	rg.GET("/jobs/:id/skills", middleware.RequirePermission(permissions.JobsView), h.GetJobSkills)
+	rg.PUT("/jobs/:id/skills", middleware.RequirePermission(permissions.JobsUpdate), h.SetJobSkills)
+	rg.GET("/jobs/:id/skill-gaps", middleware.RequirePermission(permissions.CandidatesView), h.GetSkillGaps)
+	rg.GET("/candidates/:id/skills", middleware.RequirePermission(permissions.CandidatesView), h.GetCandidateSkills)
+	rg.PUT("/candidates/:id/skills", middleware.RequirePermission(permissions.CandidatesUpdate), h.SetCandidateSkills)
+}
@@ -0,0 +1,309 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/skill/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SkillHandler struct {
+	svc *service.SkillService
+}
+
+func NewSkillHandler(svc *service.SkillService) *SkillHandler {
+	return &SkillHandler{svc: svc}
+}
+
+// GetSkills godoc
+// @Summary      Listar el catálogo de habilidades
+// @Description  Habilidades globales y de la empresa, por nombre, con su categoría y alias. q busca en nombre y alias sin distinguir mayúsculas ni acentos. SuperAdmin sin company_id ve solo las globales.
+// @Tags         Skills
+// @Produce      json
+// @Param        q           query     string  false  "Texto a buscar en nombre o alias"
+// @Param        category    query     string  false  "Categoría (language, frontend, backend, data, cloud, mobile, testing, management, soft, other)"
+// @Param        company_id  query     int     false  "Empresa (solo SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /skills [get]
+func (h *SkillHandler) GetSkills(c *gin.Context) {
+	companyID, ok := catalogScope(c)
+	if !ok {
+		return
+	}
+	var filters dtos.SkillFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	skills, err := h.svc.List(companyID, filters)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": skills, "count": len(skills)}})
+}
+
+// CreateSkill godoc
+// @Summary      Crear una habilidad
+// @Description  Agrega una habilidad al catálogo de la empresa (SuperAdmin sin company_id: al catálogo global). Ni el nombre ni los alias pueden coincidir con otra habilidad visible para la empresa (409).
+// @Tags         Skills
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     int                  false  "Empresa (solo SuperAdmin)"
+// @Param        body        body      dtos.CreateSkillDTO  true   "Habilidad"
+// @Success      201         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      409         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /skills [post]
+func (h *SkillHandler) CreateSkill(c *gin.Context) {
+	companyID, ok := catalogScope(c)
+	if !ok {
+		return
+	}
+	var dto dtos.CreateSkillDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.svc.Create(companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": skill})
+}
+
+// UpdateSkill godoc
+// @Summary      Actualizar una habilidad
+// @Description  Cambia nombre, categoría o alias (aliases reemplaza la lista). Las habilidades globales solo las edita SuperAdmin (403).
+// @Tags         Skills
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                  true  "ID de la habilidad"
+// @Param        body  body      dtos.UpdateSkillDTO  true  "Cambios"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Failure      409   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /skills/{id} [put]
+func (h *SkillHandler) UpdateSkill(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid skill ID")
+	if !ok {
+		return
+	}
+	var dto dtos.UpdateSkillDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.svc.Update(id, companyID, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": skill})
+}
+
+// DeleteSkill godoc
+// @Summary      Eliminar una habilidad
+// @Description  Elimina la habilidad del catálogo y la quita de las vacantes y candidatos que la tenían. Las globales solo las elimina SuperAdmin (403).
+// @Tags         Skills
+// @Produce      json
+// @Param        id   path      int  true  "ID de la habilidad"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /skills/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid skill ID")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(id, companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Skill deleted successfully"})
+}
+
+// GetJobSkills godoc
+// @Summary      Ver las habilidades de una vacante
+// @Description  Habilidades que pide la vacante: requeridas primero y luego las deseables (nice_to_have), con el nivel mínimo y los años pedidos si constan.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/skills [get]
+func (h *SkillHandler) GetJobSkills(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid job ID")
+	if !ok {
+		return
+	}
+	skills, err := h.svc.JobSkills(companyID, id)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": skills, "count": len(skills)}})
+}
+
+// SetJobSkills godoc
+// @Summary      Definir las habilidades de una vacante
+// @Description  Reemplaza la lista de habilidades de la vacante (lista vacía = quitarlas todas). requirement es required o nice_to_have; level (basic, intermediate, advanced, expert) y min_years son opcionales. Cada skill_id va una sola vez (400) y debe ser del catálogo de la empresa (422).
+// @Tags         Jobs
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true  "Job ID"
+// @Param        body  body      dtos.SetJobSkillsDTO  true  "Habilidades"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/skills [put]
+func (h *SkillHandler) SetJobSkills(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid job ID")
+	if !ok {
+		return
+	}
+	var dto dtos.SetJobSkillsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skills, err := h.svc.SetJobSkills(companyID, id, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": skills, "count": len(skills)}})
+}
+
+// GetSkillGaps godoc
+// @Summary      Brechas de habilidades de un candidato para una vacante
+// @Description  Compara cada habilidad de la vacante con las del candidato: met (la tiene con el nivel y los años pedidos), below (la tiene, pero con menos nivel o años, o no constan) o missing. Si el candidato no declara nivel se usa el que corresponde a sus años. Incluye cuántas requeridas y deseables cumple.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id            path      int  true  "Job ID"
+// @Param        candidate_id  query     int  true  "Candidate ID"
+// @Success      200           {object}  map[string]interface{}
+// @Failure      400           {object}  map[string]interface{}
+// @Failure      404           {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /jobs/{id}/skill-gaps [get]
+func (h *SkillHandler) GetSkillGaps(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid job ID")
+	if !ok {
+		return
+	}
+	var query dtos.SkillGapsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.svc.Gaps(companyID, id, query.CandidateID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": report})
+}
+
+// GetCandidateSkills godoc
+// @Summary      Ver las habilidades de un candidato
+// @Description  Habilidades del candidato por nombre, con nivel y años si constan. source indica si se cargó a mano (manual) o se infirió del CV (resume).
+// @Tags         Candidates
+// @Produce      json
+// @Param        id   path      int  true  "Candidate ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/skills [get]
+func (h *SkillHandler) GetCandidateSkills(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+	skills, err := h.svc.CandidateSkills(companyID, id)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": skills, "count": len(skills)}})
+}
+
+// SetCandidateSkills godoc
+// @Summary      Definir las habilidades de un candidato
+// @Description  Reemplaza la lista de habilidades del candidato (lista vacía = quitarlas todas). Las que llegan sin cambios respecto de lo inferido del CV conservan source=resume; el resto queda como manual y el análisis del CV ya no las modifica. Cada skill_id va una sola vez (400) y debe ser del catálogo de la empresa (422).
+// @Tags         Candidates
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true  "Candidate ID"
+// @Param        body  body      dtos.SetCandidateSkillsDTO  true  "Habilidades"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Failure      422   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/skills [put]
+func (h *SkillHandler) SetCandidateSkills(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+	var dto dtos.SetCandidateSkillsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skills, err := h.svc.SetCandidateSkills(companyID, id, dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": skills, "count": len(skills)}})
+}
+
+// catalogScope es como authctx.TenantScope, pero SuperAdmin puede indicar
+// company_id para operar sobre el catálogo de una empresa (sin él, el
+// global).
+func catalogScope(c *gin.Context) (uint, bool) {
+	if authctx.IsSuperAdmin(c) && c.Query("company_id") != "" {
+		id, err := strconv.ParseUint(c.Query("company_id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company_id"})
+			return 0, false
+		}
+		return uint(id), true
+	}
+	return authctx.TenantScope(c)
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
@@ -36,6 +36,7 @@ type HiredApplication struct {
 	CandidateID uint
 	JobID       uint
 	Stage       string
+	StageType   string // tipo de la etapa en el pipeline de la vacante (models.StageType*)
 }
 
 // ApplicationFinder resuelve una Application por ID. Lo implementa un adaptador
@@ -42,7 +42,7 @@ func (s *PlacementService) GetByID(id uint) (*models.Placement, error) {
 // Create valida la integridad antes de colocar:
 //  1. el cliente final pertenece al mismo tenant (companyID),
 //  2. la application existe y pertenece al mismo tenant,
-//  3. la application está en etapa 'hired',
+//  3. la application está en una etapa de tipo hired de su pipeline,
 //  4. no existe ya un placement para esa application.
 //
 // CandidateID y JobID se copian de la application (no se confía en el body).
@@ -68,7 +68,7 @@ func (s *PlacementService) Create(companyID uint, dto dtos.CreatePlacementDTO) (
 	if app.CompanyID != companyID {
 		return nil, apperr.Forbidden("application does not belong to your company")
 	}
-	if app.Stage != "hired" {
+	if app.StageType != models.StageTypeHired {
 		return nil, apperr.BadRequest("application must be in 'hired' stage to create a placement")
 	}
 
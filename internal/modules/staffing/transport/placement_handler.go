@@ -95,7 +95,7 @@ func (h *PlacementHandler) GetPlacement(c *gin.Context) {
 
 // CreatePlacement godoc
 // @Summary      Crear colocación
-// @Description  Coloca a un candidato en un cliente final a partir de una Application en etapa 'hired'.
+// @Description  Coloca a un candidato en un cliente final a partir de una Application en una etapa de tipo hired de su pipeline.
 // @Tags         Placements
 // @Accept       json
 // @Produce      json
@@ -0,0 +1,40 @@
+// Package domain contiene las reglas de los talent pools: listas con nombre
+// de candidatos para futuras búsquedas, compartidas con el equipo o
+// privadas de su dueño.
+package domain
+
+import "dvra-api/internal/app/models"
+
+// Motivos por los que un candidato no se agrega a un pool.
+const (
+	SkipNotFound       = "not_found"       // no existe o es de otra empresa
+	SkipNotContactable = "not_contactable" // sin consentimiento de talent pool o anonimizado
+	SkipAlreadyMember  = "already_member"
+)
+
+// Viewer es quien consulta o modifica un pool. SuperAdmin ve todos,
+// incluidos los privados.
+type Viewer struct {
+	UserID     uint
+	Admin      bool
+	SuperAdmin bool
+}
+
+// CanSee indica si el pool es visible para v: los compartidos los ve todo
+// el equipo; los privados, solo su dueño. Quien lo ve puede agregar y
+// quitar candidatos.
+func CanSee(pool *models.TalentPool, v Viewer) bool {
+	return v.SuperAdmin || pool.Shared || pool.OwnerID == v.UserID
+}
+
+// CanManage indica si v puede renombrar, compartir o eliminar el pool: su
+// dueño, o un admin si el pool es compartido.
+func CanManage(pool *models.TalentPool, v Viewer) bool {
+	return v.SuperAdmin || pool.OwnerID == v.UserID || (pool.Shared && v.Admin)
+}
+
+// Contactable indica si el candidato puede entrar a un pool: aceptó ser
+// contactado por futuras vacantes y no fue anonimizado (RN-GDPR-001).
+func Contactable(c *models.Candidate) bool {
+	return c.TalentPoolConsentAt != nil && c.AnonymizedAt == nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"dvra-api/internal/app/models"
+)
+
+func TestVisibility(t *testing.T) {
+	private := &models.TalentPool{OwnerID: 1}
+	shared := &models.TalentPool{OwnerID: 1, Shared: true}
+
+	cases := []struct {
+		name           string
+		pool           *models.TalentPool
+		viewer         Viewer
+		see, canManage bool
+	}{
+		{"dueño de privado", private, Viewer{UserID: 1}, true, true},
+		{"otro en privado", private, Viewer{UserID: 2}, false, false},
+		{"admin en privado ajeno", private, Viewer{UserID: 2, Admin: true}, false, false},
+		{"superadmin en privado", private, Viewer{SuperAdmin: true}, true, true},
+		{"otro en compartido", shared, Viewer{UserID: 2}, true, false},
+		{"admin en compartido", shared, Viewer{UserID: 2, Admin: true}, true, true},
+	}
+	for _, c := range cases {
+		if got := CanSee(c.pool, c.viewer); got != c.see {
+			t.Errorf("%s: CanSee = %v, want %v", c.name, got, c.see)
+		}
+		if got := CanManage(c.pool, c.viewer); got != c.canManage {
+			t.Errorf("%s: CanManage = %v, want %v", c.name, got, c.canManage)
+		}
+	}
+}
+
+func TestContactable(t *testing.T) {
+	now := time.Now()
+	if Contactable(&models.Candidate{}) {
+		t.Error("sin consentimiento = contactable")
+	}
+	if !Contactable(&models.Candidate{TalentPoolConsentAt: &now}) {
+		t.Error("con consentimiento = no contactable")
+	}
+	if Contactable(&models.Candidate{TalentPoolConsentAt: &now, AnonymizedAt: &now}) {
+		t.Error("anonimizado = contactable")
+	}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+
+	"dvra-api/internal/app/models"
+)
+
+// Errores de AddFromAutomation: la regla se registra como omitida.
+var (
+	ErrPoolNotFound   = errors.New("talent pool not found")
+	ErrNotContactable = errors.New("candidate has not consented to be contacted for future jobs")
+)
+
+// PoolWithCount es un pool con la cantidad de miembros contactables.
+type PoolWithCount struct {
+	models.TalentPool
+	MemberCount int64 `json:"member_count"`
+}
+
+// PoolRepository es el puerto de salida hacia la persistencia.
+type PoolRepository interface {
+	// ListPools devuelve los pools de la empresa visibles para viewerID
+	// (0 = todos), por nombre.
+	ListPools(companyID, viewerID uint) ([]PoolWithCount, error)
+	// GetPool devuelve un pool activo; nil si no existe.
+	GetPool(id uint) (*models.TalentPool, error)
+	CreatePool(pool *models.TalentPool) error
+	UpdatePool(pool *models.TalentPool) error
+	// DeletePool elimina el pool (soft delete; sus miembros quedan para
+	// restaurarlo desde la papelera).
+	DeletePool(id uint) error
+	// CandidatePools devuelve los pools activos en los que está el
+	// candidato.
+	CandidatePools(candidateID uint) ([]models.TalentPool, error)
+
+	// Members devuelve los miembros contactables del pool con su
+	// candidato, del más reciente al más antiguo.
+	Members(poolID uint) ([]models.TalentPoolMember, error)
+	// GetMember devuelve la fila del candidato en el pool; nil si no está.
+	GetMember(poolID, candidateID uint) (*models.TalentPoolMember, error)
+	// MemberIDs devuelve cuáles de candidateIDs ya están en el pool.
+	MemberIDs(poolID uint, candidateIDs []uint) ([]uint, error)
+	// AddMembers inserta los miembros; los que ya están se ignoran.
+	AddMembers(members []models.TalentPoolMember) (int64, error)
+	UpdateMember(member *models.TalentPoolMember) error
+	// RemoveMember borra la fila (sin papelera); false si no estaba.
+	RemoveMember(poolID, candidateID uint) (bool, error)
+
+	// Candidates devuelve los candidatos activos de la empresa entre ids.
+	Candidates(companyID uint, ids []uint) ([]models.Candidate, error)
+	// ApplicationCandidate devuelve el candidato y la empresa de una
+	// postulación activa; ok=false si no existe.
+	ApplicationCandidate(applicationID uint) (candidateID, companyID uint, ok bool, err error)
+}
@@ -0,0 +1,31 @@
+// Package talentpool es el punto de ensamblaje del módulo de talent pools:
+// listas con nombre de candidatos contactables (p. ej. silver medalists),
+// compartidas con el equipo o privadas.
+// Nadie importa este paquete salvo el composition root.
+package talentpool
+
+import (
+	"dvra-api/internal/modules/talentpool/repository"
+	"dvra-api/internal/modules/talentpool/service"
+	"dvra-api/internal/modules/talentpool/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo talentpool.
+type Module struct {
+	// Service lo usan las automatizaciones (acción add_to_pool) vía un
+	// adaptador en el composition root.
+	Service *service.PoolService
+}
+
+// New construye el módulo.
+func New(db *gorm.DB) *Module {
+	return &Module{Service: service.NewPoolService(repository.NewPoolRepository(db))}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/talentpool/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type poolRepository struct {
+	db *gorm.DB
+}
+
+// NewPoolRepository devuelve la implementación del puerto.
+func NewPoolRepository(db *gorm.DB) domain.PoolRepository {
+	return &poolRepository{db: db}
+}
+
+func (r *poolRepository) ListPools(companyID, viewerID uint) ([]domain.PoolWithCount, error) {
+	query := r.db.Model(&models.TalentPool{}).
+		Select("talent_pools.*, (SELECT COUNT(*) FROM talent_pool_members m"+
+			" JOIN candidates ON candidates.id = m.candidate_id AND candidates.deleted_at IS NULL AND "+models.ContactableCondition("candidates")+
+			" WHERE m.pool_id = talent_pools.id AND m.deleted_at IS NULL) AS member_count").
+		Where("talent_pools.company_id = ?", companyID)
+	if viewerID != 0 {
+		query = query.Where("(talent_pools.shared OR talent_pools.owner_id = ?)", viewerID)
+	}
+	var pools []domain.PoolWithCount
+	if err := query.Order("LOWER(talent_pools.name) ASC, talent_pools.id ASC").Scan(&pools).Error; err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (r *poolRepository) GetPool(id uint) (*models.TalentPool, error) {
+	var pool models.TalentPool
+	if err := r.db.First(&pool, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (r *poolRepository) CreatePool(pool *models.TalentPool) error {
+	return r.db.Create(pool).Error
+}
+
+func (r *poolRepository) UpdatePool(pool *models.TalentPool) error {
+	return r.db.Save(pool).Error
+}
+
+func (r *poolRepository) DeletePool(id uint) error {
+	return r.db.Delete(&models.TalentPool{}, id).Error
+}
+
+func (r *poolRepository) CandidatePools(candidateID uint) ([]models.TalentPool, error) {
+	var pools []models.TalentPool
+	if err := r.db.
+		Where("id IN (?)", r.db.Model(&models.TalentPoolMember{}).Select("pool_id").Where("candidate_id = ?", candidateID)).
+		Order("LOWER(name) ASC, id ASC").
+		Find(&pools).Error; err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (r *poolRepository) Members(poolID uint) ([]models.TalentPoolMember, error) {
+	var members []models.TalentPoolMember
+	if err := r.db.
+		Joins("Candidate").
+		Where("talent_pool_members.pool_id = ?", poolID).
+		Where(models.ContactableCondition(`"Candidate"`)).
+		Order("talent_pool_members.created_at DESC, talent_pool_members.id DESC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *poolRepository) GetMember(poolID, candidateID uint) (*models.TalentPoolMember, error) {
+	var member models.TalentPoolMember
+	if err := r.db.Where("pool_id = ? AND candidate_id = ?", poolID, candidateID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *poolRepository) MemberIDs(poolID uint, candidateIDs []uint) ([]uint, error) {
+	var ids []uint
+	// Unscoped: una fila eliminada desde la papelera sigue ocupando el
+	// índice único (pool_id, candidate_id).
+	if err := r.db.Unscoped().Model(&models.TalentPoolMember{}).
+		Where("pool_id = ? AND candidate_id IN ?", poolID, candidateIDs).
+		Pluck("candidate_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *poolRepository) AddMembers(members []models.TalentPoolMember) (int64, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&members, 500)
+	return result.RowsAffected, result.Error
+}
+
+func (r *poolRepository) UpdateMember(member *models.TalentPoolMember) error {
+	return r.db.Model(member).Update("note", member.Note).Error
+}
+
+func (r *poolRepository) RemoveMember(poolID, candidateID uint) (bool, error) {
+	result := r.db.Unscoped().Where("pool_id = ? AND candidate_id = ?", poolID, candidateID).Delete(&models.TalentPoolMember{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *poolRepository) Candidates(companyID uint, ids []uint) ([]models.Candidate, error) {
+	var candidates []models.Candidate
+	if err := r.db.Where("company_id = ? AND id IN ?", companyID, ids).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (r *poolRepository) ApplicationCandidate(applicationID uint) (uint, uint, bool, error) {
+	var app models.Application
+	if err := r.db.Select("id", "candidate_id", "company_id").First(&app, applicationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, err
+	}
+	return app.CandidateID, app.CompanyID, true, nil
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
+	"dvra-api/internal/modules/talentpool/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// PoolService gestiona los talent pools de la empresa y sus miembros. Solo
+// entran candidatos contactables (RN-GDPR-001) y solo se listan los que
+// siguen siéndolo.
+type PoolService struct {
+	repo domain.PoolRepository
+}
+
+func NewPoolService(repo domain.PoolRepository) *PoolService {
+	return &PoolService{repo: repo}
+}
+
+// List devuelve los pools de la empresa visibles para el usuario.
+func (s *PoolService) List(companyID uint, viewer domain.Viewer) ([]domain.PoolWithCount, error) {
+	viewerID := viewer.UserID
+	if viewer.SuperAdmin {
+		viewerID = 0
+	}
+	return s.repo.ListPools(companyID, viewerID)
+}
+
+// Get devuelve un pool visible (companyID 0 = cualquier empresa).
+func (s *PoolService) Get(id, companyID uint, viewer domain.Viewer) (*models.TalentPool, error) {
+	pool, err := s.repo.GetPool(id)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil || (companyID != 0 && pool.CompanyID != companyID) || !domain.CanSee(pool, viewer) {
+		return nil, apperr.NotFound("talent pool not found")
+	}
+	return pool, nil
+}
+
+func (s *PoolService) Create(companyID uint, viewer domain.Viewer, dto dtos.CreateTalentPoolDTO) (*models.TalentPool, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, apperr.BadRequest("name is required")
+	}
+	pool := &models.TalentPool{
+		CompanyID:   companyID,
+		Name:        name,
+		Description: dto.Description,
+		Shared:      dto.Shared == nil || *dto.Shared,
+		OwnerID:     viewer.UserID,
+	}
+	if err := s.repo.CreatePool(pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// Update cambia nombre, descripción o visibilidad. Solo el dueño (o un
+// admin, si el pool es compartido).
+func (s *PoolService) Update(id, companyID uint, viewer domain.Viewer, dto dtos.UpdateTalentPoolDTO) (*models.TalentPool, error) {
+	pool, err := s.managed(id, companyID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, apperr.BadRequest("name cannot be empty")
+		}
+		pool.Name = name
+	}
+	if dto.Description != nil {
+		pool.Description = *dto.Description
+	}
+	if dto.Shared != nil {
+		pool.Shared = *dto.Shared
+	}
+	if err := s.repo.UpdatePool(pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// Delete envía el pool a la papelera; si se restaura vuelve con sus
+// miembros.
+func (s *PoolService) Delete(id, companyID uint, viewer domain.Viewer) error {
+	pool, err := s.managed(id, companyID, viewer)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeletePool(pool.ID)
+}
+
+// CandidatePools devuelve los pools visibles en los que está el candidato.
+func (s *PoolService) CandidatePools(candidateID, companyID uint, viewer domain.Viewer) ([]models.TalentPool, error) {
+	if companyID != 0 {
+		found, err := s.repo.Candidates(companyID, []uint{candidateID})
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, apperr.NotFound("candidate not found")
+		}
+	}
+	pools, err := s.repo.CandidatePools(candidateID)
+	if err != nil {
+		return nil, err
+	}
+	visible := []models.TalentPool{}
+	for i := range pools {
+		if domain.CanSee(&pools[i], viewer) {
+			visible = append(visible, pools[i])
+		}
+	}
+	return visible, nil
+}
+
+// Members devuelve los miembros contactables del pool.
+func (s *PoolService) Members(id, companyID uint, viewer domain.Viewer) ([]models.TalentPoolMember, error) {
+	pool, err := s.Get(id, companyID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Members(pool.ID)
+}
+
+// AddMembers agrega candidatos de la empresa al pool. Los que no existen,
+// no son contactables o ya estaban se informan en Skipped.
+func (s *PoolService) AddMembers(id, companyID uint, viewer domain.Viewer, dto dtos.AddPoolMembersDTO) (*dtos.AddPoolMembersResultDTO, error) {
+	pool, err := s.Get(id, companyID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if dto.ApplicationID != nil {
+		if len(dto.CandidateIDs) != 1 {
+			return nil, apperr.BadRequest("application_id requires exactly one candidate")
+		}
+		if err := s.checkApplication(*dto.ApplicationID, dto.CandidateIDs[0], pool.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates, err := s.repo.Candidates(pool.CompanyID, dto.CandidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.MemberIDs(pool.ID, dto.CandidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.Candidate, len(candidates))
+	for i := range candidates {
+		byID[candidates[i].ID] = &candidates[i]
+	}
+	member := make(map[uint]bool, len(existing))
+	for _, cid := range existing {
+		member[cid] = true
+	}
+
+	result := &dtos.AddPoolMembersResultDTO{Skipped: []dtos.SkippedPoolCandidateDTO{}}
+	var rows []models.TalentPoolMember
+	seen := make(map[uint]bool, len(dto.CandidateIDs))
+	for _, cid := range dto.CandidateIDs {
+		if seen[cid] {
+			continue
+		}
+		seen[cid] = true
+		reason := ""
+		switch c := byID[cid]; {
+		case c == nil:
+			reason = domain.SkipNotFound
+		case !domain.Contactable(c):
+			reason = domain.SkipNotContactable
+		case member[cid]:
+			reason = domain.SkipAlreadyMember
+		}
+		if reason != "" {
+			result.Skipped = append(result.Skipped, dtos.SkippedPoolCandidateDTO{CandidateID: cid, Reason: reason})
+			continue
+		}
+		addedBy := viewer.UserID
+		rows = append(rows, models.TalentPoolMember{PoolID: pool.ID, CandidateID: cid, ApplicationID: dto.ApplicationID, Note: dto.Note, AddedByID: &addedBy})
+	}
+	if result.Added, err = s.repo.AddMembers(rows); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateMember cambia la nota de un miembro.
+func (s *PoolService) UpdateMember(id, candidateID, companyID uint, viewer domain.Viewer, dto dtos.UpdatePoolMemberDTO) (*models.TalentPoolMember, error) {
+	pool, err := s.Get(id, companyID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.repo.GetMember(pool.ID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, apperr.NotFound("candidate is not in this talent pool")
+	}
+	member.Note = dto.Note
+	if err := s.repo.UpdateMember(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember saca al candidato del pool.
+func (s *PoolService) RemoveMember(id, candidateID, companyID uint, viewer domain.Viewer) error {
+	pool, err := s.Get(id, companyID, viewer)
+	if err != nil {
+		return err
+	}
+	removed, err := s.repo.RemoveMember(pool.ID, candidateID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return apperr.NotFound("candidate is not in this talent pool")
+	}
+	return nil
+}
+
+// Exists reporta si el pool existe en la empresa (validación de las reglas
+// de automatización).
+func (s *PoolService) Exists(companyID, poolID uint) (bool, error) {
+	pool, err := s.repo.GetPool(poolID)
+	if err != nil {
+		return false, err
+	}
+	return pool != nil && pool.CompanyID == companyID, nil
+}
+
+// AddFromAutomation agrega el candidato de una postulación al pool de una
+// regla de automatización (sin autor). Devuelve domain.ErrPoolNotFound si
+// el pool ya no existe en la empresa y domain.ErrNotContactable si el
+// candidato no consintió; estar ya en el pool no es error.
+func (s *PoolService) AddFromAutomation(companyID, poolID, candidateID, applicationID uint, note string) error {
+	pool, err := s.repo.GetPool(poolID)
+	if err != nil {
+		return err
+	}
+	if pool == nil || pool.CompanyID != companyID {
+		return domain.ErrPoolNotFound
+	}
+	candidates, err := s.repo.Candidates(companyID, []uint{candidateID})
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 || !domain.Contactable(&candidates[0]) {
+		return domain.ErrNotContactable
+	}
+	var appID *uint
+	if applicationID != 0 {
+		appID = &applicationID
+	}
+	_, err = s.repo.AddMembers([]models.TalentPoolMember{{PoolID: pool.ID, CandidateID: candidateID, ApplicationID: appID, Note: note}})
+	return err
+}
+
+// managed devuelve un pool que el usuario puede administrar.
+func (s *PoolService) managed(id, companyID uint, viewer domain.Viewer) (*models.TalentPool, error) {
+	pool, err := s.Get(id, companyID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanManage(pool, viewer) {
+		return nil, apperr.Forbidden("only the owner of this talent pool can change it")
+	}
+	return pool, nil
+}
+
+func (s *PoolService) checkApplication(applicationID, candidateID, companyID uint) error {
+	appCandidate, appCompany, ok, err := s.repo.ApplicationCandidate(applicationID)
+	if err != nil {
+		return err
+	}
+	if !ok || appCompany != companyID {
+		return apperr.NotFound("application not found")
+	}
+	if appCandidate != candidateID {
+		return apperr.BadRequest("application_id does not belong to the candidate")
+	}
+	return nil
+}
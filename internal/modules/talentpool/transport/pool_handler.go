@@ -0,0 +1,324 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/talentpool/domain"
+	"dvra-api/internal/modules/talentpool/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PoolHandler struct {
+	svc *service.PoolService
+}
+
+func NewPoolHandler(svc *service.PoolService) *PoolHandler {
+	return &PoolHandler{svc: svc}
+}
+
+// GetPools godoc
+// @Summary      Listar talent pools
+// @Description  Pools de la empresa visibles para el usuario (compartidos y propios) con la cantidad de miembros contactables.
+// @Tags         Talent Pools
+// @Produce      json
+// @Param        company_id  query     int  false  "Empresa (obligatorio para SuperAdmin)"
+// @Success      200         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools [get]
+func (h *PoolHandler) GetPools(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	pools, err := h.svc.List(companyID, viewer(c))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": pools, "count": len(pools)}})
+}
+
+// CreatePool godoc
+// @Summary      Crear un talent pool
+// @Description  Crea una lista con nombre. Por defecto es compartida con el equipo; shared=false la deja privada para quien la crea.
+// @Tags         Talent Pools
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     int                       false  "Empresa (obligatorio para SuperAdmin)"
+// @Param        body        body      dtos.CreateTalentPoolDTO  true   "Pool"
+// @Success      201         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools [post]
+func (h *PoolHandler) CreatePool(c *gin.Context) {
+	companyID, ok := authctx.CompanyScope(c)
+	if !ok {
+		return
+	}
+	var dto dtos.CreateTalentPoolDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pool, err := h.svc.Create(companyID, viewer(c), dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": pool})
+}
+
+// GetPool godoc
+// @Summary      Obtener un talent pool
+// @Tags         Talent Pools
+// @Produce      json
+// @Param        id   path      int  true  "ID del pool"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id} [get]
+func (h *PoolHandler) GetPool(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	pool, err := h.svc.Get(id, companyID, viewer(c))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": pool})
+}
+
+// UpdatePool godoc
+// @Summary      Actualizar un talent pool
+// @Description  Cambia nombre, descripción o visibilidad. Solo el dueño; un admin también puede en los pools compartidos.
+// @Tags         Talent Pools
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                       true  "ID del pool"
+// @Param        body  body      dtos.UpdateTalentPoolDTO  true  "Cambios"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id} [put]
+func (h *PoolHandler) UpdatePool(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	var dto dtos.UpdateTalentPoolDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pool, err := h.svc.Update(id, companyID, viewer(c), dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": pool})
+}
+
+// DeletePool godoc
+// @Summary      Eliminar un talent pool
+// @Description  Envía el pool a la papelera (los candidatos no se tocan). Solo el dueño; un admin también puede en los pools compartidos.
+// @Tags         Talent Pools
+// @Produce      json
+// @Param        id   path      int  true  "ID del pool"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id} [delete]
+func (h *PoolHandler) DeletePool(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(id, companyID, viewer(c)); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Talent pool deleted successfully"})
+}
+
+// GetMembers godoc
+// @Summary      Listar los candidatos de un talent pool
+// @Description  Miembros con su nota y candidato, del más reciente al más antiguo. Solo aparecen los que siguen siendo contactables (consentimiento vigente, no anonimizados).
+// @Tags         Talent Pools
+// @Produce      json
+// @Param        id   path      int  true  "ID del pool"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id}/members [get]
+func (h *PoolHandler) GetMembers(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	members, err := h.svc.Members(id, companyID, viewer(c))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": members, "count": len(members)}})
+}
+
+// AddMembers godoc
+// @Summary      Agregar candidatos a un talent pool
+// @Description  Agrega hasta 500 candidatos con una nota. Solo entran los contactables; los demás vuelven en skipped con el motivo (not_found, not_contactable, already_member). application_id registra la postulación de origen (un solo candidato).
+// @Tags         Talent Pools
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "ID del pool"
+// @Param        body  body      dtos.AddPoolMembersDTO  true  "Candidatos"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id}/members [post]
+func (h *PoolHandler) AddMembers(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	var dto dtos.AddPoolMembersDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.AddMembers(id, companyID, viewer(c), dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// UpdateMember godoc
+// @Summary      Cambiar la nota de un miembro
+// @Tags         Talent Pools
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                       true  "ID del pool"
+// @Param        candidateId  path      int                       true  "ID del candidato"
+// @Param        body         body      dtos.UpdatePoolMemberDTO  true  "Nota"
+// @Success      200          {object}  map[string]interface{}
+// @Failure      404          {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id}/members/{candidateId} [put]
+func (h *PoolHandler) UpdateMember(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	candidateID, ok := candidateParam(c)
+	if !ok {
+		return
+	}
+	var dto dtos.UpdatePoolMemberDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.svc.UpdateMember(id, candidateID, companyID, viewer(c), dto)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": member})
+}
+
+// RemoveMember godoc
+// @Summary      Quitar un candidato de un talent pool
+// @Tags         Talent Pools
+// @Produce      json
+// @Param        id           path      int  true  "ID del pool"
+// @Param        candidateId  path      int  true  "ID del candidato"
+// @Success      200          {object}  map[string]interface{}
+// @Failure      404          {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /talent-pools/{id}/members/{candidateId} [delete]
+func (h *PoolHandler) RemoveMember(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid talent pool ID")
+	if !ok {
+		return
+	}
+	candidateID, ok := candidateParam(c)
+	if !ok {
+		return
+	}
+	if err := h.svc.RemoveMember(id, candidateID, companyID, viewer(c)); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Candidate removed from talent pool"})
+}
+
+// GetCandidatePools godoc
+// @Summary      Talent pools de un candidato
+// @Description  Pools visibles para el usuario en los que está el candidato.
+// @Tags         Talent Pools
+// @Produce      json
+// @Param        id   path      int  true  "ID del candidato"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /candidates/{id}/talent-pools [get]
+func (h *PoolHandler) GetCandidatePools(c *gin.Context) {
+	id, companyID, ok := target(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+	pools, err := h.svc.CandidatePools(id, companyID, viewer(c))
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": pools, "count": len(pools)}})
+}
+
+// viewer arma el domain.Viewer del usuario autenticado.
+func viewer(c *gin.Context) domain.Viewer {
+	userID, _ := authctx.UserID(c)
+	return domain.Viewer{
+		UserID:     userID,
+		Admin:      authctx.Role(c) == permissions.RoleAdmin,
+		SuperAdmin: authctx.IsSuperAdmin(c),
+	}
+}
+
+func target(c *gin.Context, invalidMsg string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMsg})
+		return 0, 0, false
+	}
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), companyID, true
+}
+
+func candidateParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("candidateId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
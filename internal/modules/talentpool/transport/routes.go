@@ -0,0 +1,29 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/talentpool/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.PoolService) {
+	h := NewPoolHandler(svc)
+
+	pools := rg.Group("/talent-pools")
+	{
+		pools.GET("", middleware.RequirePermission(permissions.TalentPoolsView), h.GetPools)
+		pools.POST("", middleware.RequirePermission(permissions.TalentPoolsManage), h.CreatePool)
+		pools.GET("/:id", middleware.RequirePermission(permissions.TalentPoolsView), h.GetPool)
+		pools.PUT("/:id", middleware.RequirePermission(permissions.TalentPoolsManage), h.UpdatePool)
+		pools.DELETE("/:id", middleware.RequirePermission(permissions.TalentPoolsManage), h.DeletePool)
+		pools.GET("/:id/members", middleware.RequirePermission(permissions.TalentPoolsView), h.GetMembers)
+		pools.POST("/:id/members", middleware.RequirePermission(permissions.TalentPoolsManage), h.AddMembers)
+		pools.PUT("/:id/members/:candidateId", middleware.RequirePermission(permissions.TalentPoolsManage), h.UpdateMember)
+		pools.DELETE("/:id/members/:candidateId", middleware.RequirePermission(permissions.TalentPoolsManage), h.RemoveMember)
+	}
+
+	rg.GET("/candidates/:id/talent-pools", middleware.RequirePermission(permissions.TalentPoolsView), h.GetCandidatePools)
+}
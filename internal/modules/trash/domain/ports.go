@@ -0,0 +1,193 @@
+// Package domain define el centro del módulo trash (papelera): los tipos de
+// registro recuperables, el grafo de dependencias entre ellos y el puerto hacia
+// la persistencia. No importa gin ni gorm.
+package domain
+
+import "time"
+
+// Tipos de registro que admiten papelera (todos embeben BaseModel con DeletedAt).
+const (
+	TypeJob            = "job"
+	TypeCandidate      = "candidate"
+	TypeApplication    = "application"
+	TypeStaffingClient = "staffing_client"
+	TypePlacement      = "placement"
+	TypeTalentPool     = "talent_pool"
+)
+
+// Types es el orden en que se listan los tipos en GET /trash.
+var Types = []string{TypeJob, TypeCandidate, TypeApplication, TypeStaffingClient, TypePlacement, TypeTalentPool}
+
+// Tipos que no se listan ni restauran por sí solos: solo acompañan a su padre
+// al purgarlo (no se eliminan con soft delete).
+const (
+	TypeCandidateConsent      = "candidate_consent"
+	TypeApplicationStageEvent = "application_stage_event"
+	TypeCandidateTag          = "candidate_tag"
+	TypeScorecard             = "scorecard"
+	TypeScorecardTemplate     = "scorecard_template"
+	TypeComment               = "comment"
+	TypeCommentRevision       = "comment_revision"
+	TypeCommentMention        = "comment_mention"
+	TypeCommentAttachment     = "comment_attachment"
+	TypeInterview             = "interview"
+	TypeInterviewPanelist     = "interview_panelist"
+	TypeSchedulingLink        = "scheduling_link"
+	TypeOffer                 = "offer"
+	TypeOfferVersion          = "offer_version"
+	TypeOfferApproval         = "offer_approval"
+	TypeDocument              = "document"
+	TypeAutomationEvent       = "automation_event"
+	TypeAutomationRun         = "automation_run"
+	TypeStaleAction           = "stale_action"
+	TypeCandidateMerge        = "candidate_merge"
+	TypeTalentPoolMember      = "talent_pool_member"
+	TypeResumeParse           = "resume_parse"
+	TypeJobSkill              = "job_skill"
+	TypeCandidateSkill        = "candidate_skill"
+	TypeScreeningQuestion     = "screening_question"
+	TypeScreeningAnswer       = "screening_answer"
+)
+
+// Dependency describe filas hijas que apuntan a un registro padre vía ForeignKey.
+// Nullable indica que la relación es opcional: al purgar el padre la FK se pone
+// en NULL en lugar de eliminar la fila hija, y al restaurar no se arrastra.
+type Dependency struct {
+	Type       string
+	ForeignKey string
+	Nullable   bool
+}
+
+// Dependencies es el grafo padre → hijos que recorren restore y purge.
+// Los hijos de un hijo se recorren recursivamente (p. ej. candidate →
+// application → placement), así que basta declarar la relación directa.
+var Dependencies = map[string][]Dependency{
+	TypeJob: {
+		{Type: TypeApplication, ForeignKey: "job_id"},
+		{Type: TypePlacement, ForeignKey: "job_id"},
+		{Type: TypeScorecardTemplate, ForeignKey: "job_id"},
+		{Type: TypeJobSkill, ForeignKey: "job_id"},
+		{Type: TypeScreeningQuestion, ForeignKey: "job_id"},
+	},
+	TypeCandidate: {
+		{Type: TypeApplication, ForeignKey: "candidate_id"},
+		{Type: TypePlacement, ForeignKey: "candidate_id"},
+		{Type: TypeCandidateConsent, ForeignKey: "candidate_id"},
+		{Type: TypeCandidateTag, ForeignKey: "candidate_id"},
+		{Type: TypeComment, ForeignKey: "candidate_id"},
+		{Type: TypeCandidateMerge, ForeignKey: "survivor_id"},
+		{Type: TypeCandidateMerge, ForeignKey: "merged_id"},
+		{Type: TypeTalentPoolMember, ForeignKey: "candidate_id"},
+		{Type: TypeResumeParse, ForeignKey: "candidate_id"},
+		{Type: TypeCandidateSkill, ForeignKey: "candidate_id"},
+	},
+	TypeApplication: {
+		{Type: TypePlacement, ForeignKey: "application_id"},
+		{Type: TypeCandidateConsent, ForeignKey: "application_id", Nullable: true},
+		{Type: TypeApplicationStageEvent, ForeignKey: "application_id"},
+		{Type: TypeScorecard, ForeignKey: "application_id"},
+		{Type: TypeComment, ForeignKey: "application_id"},
+		{Type: TypeInterview, ForeignKey: "application_id"},
+		{Type: TypeSchedulingLink, ForeignKey: "application_id"},
+		{Type: TypeOffer, ForeignKey: "application_id"},
+		{Type: TypeDocument, ForeignKey: "application_id"},
+		{Type: TypeAutomationEvent, ForeignKey: "application_id"},
+		{Type: TypeAutomationRun, ForeignKey: "application_id"},
+		{Type: TypeStaleAction, ForeignKey: "application_id"},
+		{Type: TypeTalentPoolMember, ForeignKey: "application_id", Nullable: true},
+		{Type: TypeScreeningAnswer, ForeignKey: "application_id"},
+	},
+	TypePlacement: {
+		{Type: TypeDocument, ForeignKey: "placement_id"},
+	},
+	TypeComment: {
+		{Type: TypeCommentRevision, ForeignKey: "comment_id"},
+		{Type: TypeCommentMention, ForeignKey: "comment_id"},
+		{Type: TypeCommentAttachment, ForeignKey: "comment_id"},
+	},
+	TypeInterview: {
+		{Type: TypeInterviewPanelist, ForeignKey: "interview_id"},
+	},
+	TypeOffer: {
+		{Type: TypeOfferVersion, ForeignKey: "offer_id"},
+		{Type: TypeOfferApproval, ForeignKey: "offer_id"},
+	},
+	TypeTalentPool: {
+		{Type: TypeTalentPoolMember, ForeignKey: "pool_id"},
+	},
+	TypeStaffingClient: {
+		{Type: TypePlacement, ForeignKey: "staffing_client_id"},
+		{Type: TypeJob, ForeignKey: "staffing_client_id", Nullable: true},
+	},
+}
+
+// UniqueKeys son las columnas que no pueden repetirse entre registros
+// activos de un tipo. La unicidad la valida la aplicación (no hay índice
+// único que cubra los soft-deleted), así que restore la revisa: mientras el
+// registro estuvo en la papelera pudo crearse otro igual (el mismo email, o
+// el candidato volvió a postularse a la vacante).
+var UniqueKeys = map[string][]string{
+	TypeCandidate:   {"company_id", "email"},
+	TypeApplication: {"candidate_id", "job_id"},
+}
+
+// IsValidType reporta si el tipo admite papelera.
+func IsValidType(entityType string) bool {
+	for _, t := range Types {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+// Parents devuelve las relaciones en las que entityType es hijo, es decir, las
+// FKs que deben apuntar a registros activos para poder restaurarlo.
+func Parents(entityType string) map[string]string {
+	parents := make(map[string]string)
+	for parentType, deps := range Dependencies {
+		for _, dep := range deps {
+			if dep.Type == entityType {
+				parents[dep.ForeignKey] = parentType
+			}
+		}
+	}
+	return parents
+}
+
+// TrashedRecord es la vista mínima de un registro eliminado (soft delete).
+type TrashedRecord struct {
+	Type      string
+	ID        uint
+	CompanyID uint
+	Label     string
+	DeletedAt time.Time
+}
+
+// TrashRepository es el puerto de salida hacia la persistencia. Todas las
+// operaciones ignoran el scope de soft delete (equivalente a Unscoped).
+type TrashRepository interface {
+	// List devuelve los registros eliminados de un tipo para una empresa
+	// (0 = todas, SuperAdmin).
+	List(companyID uint, entityType string) ([]TrashedRecord, error)
+	// FindTrashed devuelve el registro si existe Y está eliminado; nil si no.
+	FindTrashed(entityType string, id uint) (*TrashedRecord, error)
+	// ForeignKeys lee las columnas FK indicadas del registro (nil si son NULL).
+	ForeignKeys(entityType string, id uint, columns []string) (map[string]*uint, error)
+	// IsActive reporta si el registro existe y no está eliminado.
+	IsActive(entityType string, id uint) (bool, error)
+	// ActiveDuplicate devuelve el id de un registro activo del mismo tipo
+	// con los mismos valores en columns que el registro id; 0 si no hay.
+	ActiveDuplicate(entityType string, id uint, columns []string) (uint, error)
+	// SoftDelete elimina (soft delete) el registro y, en la misma
+	// transacción, sus dependientes activos con la misma marca at, para que
+	// Restore los recupere. Devuelve cuántos dependientes se eliminaron.
+	SoftDelete(entityType string, id uint, at time.Time) (int64, error)
+	// Restore restaura el registro y, en cascada, los dependientes eliminados
+	// a partir de since. Devuelve cuántos dependientes se restauraron.
+	Restore(entityType string, id uint, since time.Time) (int64, error)
+	// Purge elimina físicamente el registro y todos sus dependientes. Devuelve
+	// las rutas (relativas al directorio de uploads) de los archivos que
+	// quedaron sin registro, para borrarlos tras el commit.
+	Purge(entityType string, id uint) ([]string, error)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import "testing"
+
+func TestParentsDeUnaPostulacion(t *testing.T) {
+	got := Parents(TypeApplication)
+	want := map[string]string{"job_id": TypeJob, "candidate_id": TypeCandidate}
+	if len(got) != len(want) {
+		t.Fatalf("padres = %v, se esperaba %v", got, want)
+	}
+	for col, parent := range want {
+		if got[col] != parent {
+			t.Errorf("%s → %s, se esperaba %s", col, got[col], parent)
+		}
+	}
+}
+
+func TestParentsDeUnaColocacion(t *testing.T) {
+	got := Parents(TypePlacement)
+	want := map[string]string{
+		"job_id":             TypeJob,
+		"candidate_id":       TypeCandidate,
+		"application_id":     TypeApplication,
+		"staffing_client_id": TypeStaffingClient,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("padres = %v, se esperaba %v", got, want)
+	}
+	for col, parent := range want {
+		if got[col] != parent {
+			t.Errorf("%s → %s, se esperaba %s", col, got[col], parent)
+		}
+	}
+}
+
+func TestParentsDeTiposRaiz(t *testing.T) {
+	for _, entityType := range []string{TypeCandidate, TypeStaffingClient, TypeTalentPool} {
+		if got := Parents(entityType); len(got) != 0 {
+			t.Errorf("%s no debería tener padres: %v", entityType, got)
+		}
+	}
+}
+
+// Restore y purge recorren el grafo recursivamente: un ciclo no terminaría.
+func TestDependenciesSinCiclos(t *testing.T) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var visit func(entityType string, path []string)
+	visit = func(entityType string, path []string) {
+		path = append(path, entityType)
+		switch state[entityType] {
+		case visiting:
+			t.Fatalf("ciclo en el grafo: %v", path)
+		case done:
+			return
+		}
+		state[entityType] = visiting
+		for _, dep := range Dependencies[entityType] {
+			visit(dep.Type, path)
+		}
+		state[entityType] = done
+	}
+	for entityType := range Dependencies {
+		visit(entityType, nil)
+	}
+}
+
+func TestDependenciesSinRelacionesRepetidas(t *testing.T) {
+	for parent, deps := range Dependencies {
+		seen := map[string]bool{}
+		for _, dep := range deps {
+			key := dep.Type + "." + dep.ForeignKey
+			if seen[key] {
+				t.Errorf("%s declara dos veces %s", parent, key)
+			}
+			seen[key] = true
+			if dep.ForeignKey == "" {
+				t.Errorf("%s → %s sin foreign key", parent, dep.Type)
+			}
+		}
+	}
+}
+
+func TestIsValidType(t *testing.T) {
+	for _, entityType := range Types {
+		if !IsValidType(entityType) {
+			t.Errorf("%s debería admitir papelera", entityType)
+		}
+	}
+	// Los dependientes solo acompañan a su padre.
+	for _, entityType := range []string{TypeApplicationStageEvent, TypeCandidateConsent, TypeOffer, ""} {
+		if IsValidType(entityType) {
+			t.Errorf("%s no debería admitir papelera", entityType)
+		}
+	}
+}
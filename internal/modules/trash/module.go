@@ -0,0 +1,30 @@
+// Package trash es el punto de ensamblaje del módulo papelera: permite ver,
+// restaurar y purgar los registros eliminados por soft delete (BaseModel.DeletedAt).
+// Nadie importa este paquete salvo el composition root.
+package trash
+
+import (
+	"dvra-api/internal/modules/trash/repository"
+	"dvra-api/internal/modules/trash/service"
+	"dvra-api/internal/modules/trash/transport"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module agrupa las dependencias ya cableadas del módulo trash. Service se
+// expone para que candidates, jobs y applications eliminen en cascada.
+type Module struct {
+	Service *service.TrashService
+}
+
+// New construye el módulo. removeFiles borra del almacenamiento los archivos
+// de lo purgado.
+func New(db *gorm.DB, removeFiles service.FileRemover) *Module {
+	return &Module{Service: service.NewTrashService(repository.NewTrashRepository(db), removeFiles)}
+}
+
+// RegisterRoutes monta las rutas del módulo bajo el grupo protegido.
+func (m *Module) RegisterRoutes(rg *gin.RouterGroup) {
+	transport.RegisterRoutes(rg, m.Service)
+}
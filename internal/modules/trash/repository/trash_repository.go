@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dvra-api/internal/modules/trash/domain"
+
+	"gorm.io/gorm"
+)
+
+// entityTable mapea cada tipo recuperable a su tabla y a la expresión SQL que
+// produce una etiqueta legible para la UI de la papelera. file es la columna
+// con la ruta del archivo subido de la fila, si tiene uno.
+type entityTable struct {
+	table string
+	label string
+	file  string
+}
+
+var tables = map[string]entityTable{
+	domain.TypeJob:       {table: "jobs", label: "title"},
+	domain.TypeCandidate: {table: "candidates", label: "CONCAT_WS(' ', first_name, last_name, '<' || email || '>')"},
+	domain.TypeApplication: {
+		table: "applications",
+		label: "CONCAT_WS(' → '," +
+			" (SELECT CONCAT_WS(' ', c.first_name, c.last_name) FROM candidates c WHERE c.id = applications.candidate_id)," +
+			" (SELECT j.title FROM jobs j WHERE j.id = applications.job_id))",
+	},
+	domain.TypeStaffingClient: {table: "staffing_clients", label: "name"},
+	domain.TypePlacement:      {table: "placements", label: "COALESCE(NULLIF(position, ''), 'placement #' || id)"},
+	domain.TypeTalentPool:     {table: "talent_pools", label: "name"},
+
+	domain.TypeCandidateConsent:      {table: "candidate_consents"},
+	domain.TypeApplicationStageEvent: {table: "application_stage_events"},
+	domain.TypeCandidateTag:          {table: "candidate_tags"},
+	domain.TypeScorecard:             {table: "scorecards"},
+	domain.TypeScorecardTemplate:     {table: "scorecard_templates"},
+	domain.TypeComment:               {table: "comments"},
+	domain.TypeCommentRevision:       {table: "comment_revisions"},
+	domain.TypeCommentMention:        {table: "comment_mentions"},
+	domain.TypeCommentAttachment:     {table: "comment_attachments", file: "storage_path"},
+	domain.TypeInterview:             {table: "interviews"},
+	domain.TypeInterviewPanelist:     {table: "interview_panelists"},
+	domain.TypeSchedulingLink:        {table: "scheduling_links"},
+	domain.TypeOffer:                 {table: "offers"},
+	domain.TypeOfferVersion:          {table: "offer_versions"},
+	domain.TypeOfferApproval:         {table: "offer_approvals"},
+	domain.TypeDocument:              {table: "documents", file: "storage_path"},
+	domain.TypeAutomationEvent:       {table: "automation_events"},
+	domain.TypeAutomationRun:         {table: "automation_runs"},
+	domain.TypeStaleAction:           {table: "stale_actions"},
+	domain.TypeCandidateMerge:        {table: "candidate_merges"},
+	domain.TypeTalentPoolMember:      {table: "talent_pool_members"},
+	domain.TypeResumeParse:           {table: "resume_parses"},
+	domain.TypeJobSkill:              {table: "job_skills"},
+	domain.TypeCandidateSkill:        {table: "candidate_skills"},
+	domain.TypeScreeningQuestion:     {table: "screening_questions"},
+	domain.TypeScreeningAnswer:       {table: "screening_answers"},
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository devuelve la implementación del puerto.
+func NewTrashRepository(db *gorm.DB) domain.TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// trashedRow es el resultado de escanear un registro eliminado.
+type trashedRow struct {
+	ID        uint
+	CompanyID uint
+	Label     string
+	DeletedAt time.Time
+}
+
+func (r *trashRepository) List(companyID uint, entityType string) ([]domain.TrashedRecord, error) {
+	t := tables[entityType]
+	query := r.db.Table(t.table).
+		Select("id, company_id, " + t.label + " AS label, deleted_at").
+		Where("deleted_at IS NOT NULL")
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var rows []trashedRow
+	if err := query.Order("deleted_at DESC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.TrashedRecord, len(rows))
+	for i, row := range rows {
+		records[i] = toRecord(entityType, row)
+	}
+	return records, nil
+}
+
+func (r *trashRepository) FindTrashed(entityType string, id uint) (*domain.TrashedRecord, error) {
+	t := tables[entityType]
+	var rows []trashedRow
+	if err := r.db.Table(t.table).
+		Select("id, company_id, "+t.label+" AS label, deleted_at").
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Limit(1).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	record := toRecord(entityType, rows[0])
+	return &record, nil
+}
+
+func (r *trashRepository) ForeignKeys(entityType string, id uint, columns []string) (map[string]*uint, error) {
+	result := make(map[string]*uint, len(columns))
+	if len(columns) == 0 {
+		return result, nil
+	}
+
+	row := make(map[string]interface{})
+	if err := r.db.Table(tables[entityType].table).
+		Select(columns).
+		Where("id = ?", id).
+		Take(&row).Error; err != nil {
+		return nil, err
+	}
+
+	for _, col := range columns {
+		switch v := row[col].(type) {
+		case int64:
+			u := uint(v)
+			result[col] = &u
+		case int32:
+			u := uint(v)
+			result[col] = &u
+		default:
+			result[col] = nil
+		}
+	}
+	return result, nil
+}
+
+func (r *trashRepository) IsActive(entityType string, id uint) (bool, error) {
+	var count int64
+	if err := r.db.Table(tables[entityType].table).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *trashRepository) ActiveDuplicate(entityType string, id uint, columns []string) (uint, error) {
+	table := tables[entityType].table
+	query := r.db.Table(table+" other").
+		Joins("JOIN "+table+" restored ON restored.id = ?", id).
+		Where("other.id <> restored.id AND other.deleted_at IS NULL")
+	for _, col := range columns {
+		query = query.Where(fmt.Sprintf("other.%s = restored.%s", col, col))
+	}
+	var ids []uint
+	if err := query.Limit(1).Pluck("other.id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+func (r *trashRepository) SoftDelete(entityType string, id uint, at time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		n, err := softDeleteTree(tx, entityType, []uint{id}, at)
+		deleted = n
+		return err
+	})
+	return deleted, err
+}
+
+// softDeleteTree elimina (soft delete) los registros ids y, recursivamente,
+// sus hijos activos, todos con la misma marca at: restoreDependents toma la
+// marca del padre como since y así los recupera juntos. Un hijo ya eliminado
+// conserva su marca (se borró por su cuenta) y las relaciones opcionales no
+// se arrastran, igual que al restaurar. Devuelve cuántos dependientes se
+// eliminaron.
+func softDeleteTree(tx *gorm.DB, entityType string, ids []uint, at time.Time) (int64, error) {
+	if err := tx.Table(tables[entityType].table).
+		Where("id IN ? AND deleted_at IS NULL", ids).
+		Update("deleted_at", at).Error; err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, dep := range domain.Dependencies[entityType] {
+		if dep.Nullable {
+			continue
+		}
+		var childIDs []uint
+		if err := tx.Table(tables[dep.Type].table).
+			Where(dep.ForeignKey+" IN ? AND deleted_at IS NULL", ids).
+			Pluck("id", &childIDs).Error; err != nil {
+			return deleted, err
+		}
+		if len(childIDs) == 0 {
+			continue
+		}
+		deleted += int64(len(childIDs))
+
+		n, err := softDeleteTree(tx, dep.Type, childIDs, at)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+func (r *trashRepository) Restore(entityType string, id uint, since time.Time) (int64, error) {
+	var restored int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(tables[entityType].table).
+			Where("id = ?", id).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		n, err := restoreDependents(tx, entityType, []uint{id}, since)
+		restored = n
+		return err
+	})
+	return restored, err
+}
+
+// restoreDependents restaura recursivamente los hijos eliminados a partir de
+// since. Un hijo eliminado ANTES que el padre se borró por su cuenta y no se
+// resucita. Un hijo alcanzable por dos caminos (p. ej. placement vía candidate
+// y vía application) se cuenta una sola vez: tras restaurarse ya no matchea.
+func restoreDependents(tx *gorm.DB, entityType string, parentIDs []uint, since time.Time) (int64, error) {
+	var restored int64
+	for _, dep := range domain.Dependencies[entityType] {
+		if dep.Nullable {
+			continue
+		}
+		child := tables[dep.Type].table
+
+		var childIDs []uint
+		if err := tx.Table(child).
+			Where(dep.ForeignKey+" IN ? AND deleted_at IS NOT NULL AND deleted_at >= ?", parentIDs, since).
+			Pluck("id", &childIDs).Error; err != nil {
+			return restored, err
+		}
+		if len(childIDs) == 0 {
+			continue
+		}
+
+		if err := tx.Table(child).Where("id IN ?", childIDs).Update("deleted_at", nil).Error; err != nil {
+			return restored, err
+		}
+		restored += int64(len(childIDs))
+
+		n, err := restoreDependents(tx, dep.Type, childIDs, since)
+		restored += n
+		if err != nil {
+			return restored, err
+		}
+	}
+	return restored, nil
+}
+
+func (r *trashRepository) Purge(entityType string, id uint) ([]string, error) {
+	var files []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return purgeTree(tx, entityType, []uint{id}, &files)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// purgeTree elimina físicamente los registros y, antes, a todos sus hijos
+// (activos o no): un hijo sin padre quedaría huérfano. Las relaciones
+// opcionales solo se desenganchan (FK a NULL). Agrega a files las rutas de
+// los archivos subidos de las filas eliminadas.
+func purgeTree(tx *gorm.DB, entityType string, ids []uint, files *[]string) error {
+	for _, dep := range domain.Dependencies[entityType] {
+		child := tables[dep.Type].table
+
+		if dep.Nullable {
+			if err := tx.Table(child).
+				Where(dep.ForeignKey+" IN ?", ids).
+				Update(dep.ForeignKey, nil).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		var childIDs []uint
+		if err := tx.Table(child).Where(dep.ForeignKey+" IN ?", ids).Pluck("id", &childIDs).Error; err != nil {
+			return err
+		}
+		if len(childIDs) == 0 {
+			continue
+		}
+		if err := purgeTree(tx, dep.Type, childIDs, files); err != nil {
+			return err
+		}
+	}
+
+	t := tables[entityType]
+	if entityType == domain.TypeCandidate {
+		resumes, err := unsharedResumes(tx, ids)
+		if err != nil {
+			return err
+		}
+		*files = append(*files, resumes...)
+	}
+	if t.file != "" {
+		var paths []string
+		if err := tx.Table(t.table).Where("id IN ? AND "+t.file+" <> ''", ids).Pluck(t.file, &paths).Error; err != nil {
+			return err
+		}
+		*files = append(*files, paths...)
+	}
+	return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id IN ?", t.table), ids).Error
+}
+
+// unsharedResumes devuelve la ruta en uploads de los CVs de los candidatos
+// ids que ningún otro candidato usa: al fusionar, el CV del eliminado pudo
+// pasar al sobreviviente. Las URLs externas se descartan.
+func unsharedResumes(tx *gorm.DB, ids []uint) ([]string, error) {
+	var urls []string
+	if err := tx.Table("candidates").
+		Where("id IN ? AND resume_url <> ''", ids).
+		Where("NOT EXISTS (SELECT 1 FROM candidates o WHERE o.resume_url = candidates.resume_url AND o.id NOT IN ?)", ids).
+		Distinct().Pluck("resume_url", &urls).Error; err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if path, ok := strings.CutPrefix(url, "/uploads/"); ok && path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func toRecord(entityType string, row trashedRow) domain.TrashedRecord {
+	return domain.TrashedRecord{
+		Type:      entityType,
+		ID:        row.ID,
+		CompanyID: row.CompanyID,
+		Label:     row.Label,
+		DeletedAt: row.DeletedAt,
+	}
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"dvra-api/internal/modules/trash/domain"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Cada tipo del grafo necesita su tabla; los recuperables además su etiqueta
+// para el listado.
+func TestTablesCubrenElGrafo(t *testing.T) {
+	for _, entityType := range domain.Types {
+		if tables[entityType].table == "" || tables[entityType].label == "" {
+			t.Errorf("%s sin tabla o etiqueta: %+v", entityType, tables[entityType])
+		}
+	}
+	for parent, deps := range domain.Dependencies {
+		if tables[parent].table == "" {
+			t.Errorf("%s sin tabla", parent)
+		}
+		for _, dep := range deps {
+			if tables[dep.Type].table == "" {
+				t.Errorf("%s (hijo de %s) sin tabla", dep.Type, parent)
+			}
+		}
+	}
+}
+
+// Los tipos con claves únicas necesitan su tabla para buscar duplicados.
+func TestTablesCubrenUniqueKeys(t *testing.T) {
+	for entityType := range domain.UniqueKeys {
+		if tables[entityType].table == "" {
+			t.Errorf("%s sin tabla", entityType)
+		}
+	}
+}
+
+// dryRun abre una conexión que arma el SQL sin ejecutarlo.
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// Al purgar una fila con archivo se lee su ruta antes de borrarla y se
+// devuelve para borrar el archivo tras el commit.
+func TestPurgeTreeLeeLosArchivos(t *testing.T) {
+	for _, entityType := range []string{domain.TypeCommentAttachment, domain.TypeDocument} {
+		db := dryRun(t)
+		var statements []string
+		record := func(tx *gorm.DB) { statements = append(statements, tx.Statement.SQL.String()) }
+		if err := db.Callback().Query().After("gorm:query").Register("test:record", record); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Callback().Raw().After("gorm:raw").Register("test:record", record); err != nil {
+			t.Fatal(err)
+		}
+
+		var files []string
+		if err := purgeTree(db, entityType, []uint{5}, &files); err != nil {
+			t.Fatal(err)
+		}
+		table := tables[entityType].table
+		if len(statements) != 2 ||
+			!strings.Contains(statements[0], `SELECT "storage_path" FROM "`+table+`"`) ||
+			!strings.HasPrefix(statements[1], "DELETE FROM "+table) {
+			t.Errorf("%s: sentencias = %q", entityType, statements)
+		}
+	}
+}
+
+// El CV de un candidato purgado se borra salvo que otro candidato lo use.
+func TestUnsharedResumes(t *testing.T) {
+	db := dryRun(t)
+	var sql string
+	if err := db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		sql = tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := unsharedResumes(db, []uint{5}); err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT DISTINCT "resume_url" FROM "candidates" WHERE (id IN (5) AND resume_url <> '') AND (NOT EXISTS (SELECT 1 FROM candidates o WHERE o.resume_url = candidates.resume_url AND o.id NOT IN (5)))`
+	if sql != want {
+		t.Errorf("sql =\n%s\nse esperaba\n%s", sql, want)
+	}
+}
+
+// fakeRow es una fila de memTables: sus FKs y su marca de soft delete.
+type fakeRow struct {
+	fks       map[string]uint
+	deletedAt *time.Time
+}
+
+// memTables simula sobre una conexión dry run las sentencias de
+// softDeleteTree y restoreDependents (Pluck de ids por FK y Update de
+// deleted_at), leyendo las condiciones de la cláusula WHERE.
+type memTables map[string]map[uint]*fakeRow
+
+func (m memTables) register(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	where := func(tx *gorm.DB) clause.Expr {
+		return tx.Statement.Clauses["WHERE"].Expression.(clause.Where).Exprs[0].(clause.Expr)
+	}
+	ids := func(v interface{}) []uint {
+		if id, ok := v.(uint); ok {
+			return []uint{id}
+		}
+		return v.([]uint)
+	}
+	query := func(tx *gorm.DB) {
+		cond := where(tx)
+		column := strings.Fields(cond.SQL)[0]
+		parents := ids(cond.Vars[0])
+		var found []uint
+		for id, row := range m[tx.Statement.Table] {
+			if !slices.Contains(parents, row.fks[column]) {
+				continue
+			}
+			switch {
+			case strings.Contains(cond.SQL, "deleted_at IS NULL"):
+				if row.deletedAt == nil {
+					found = append(found, id)
+				}
+			case row.deletedAt != nil && !row.deletedAt.Before(cond.Vars[1].(time.Time)):
+				found = append(found, id)
+			}
+		}
+		*tx.Statement.Dest.(*[]uint) = found
+	}
+	update := func(tx *gorm.DB) {
+		cond := where(tx)
+		onlyActive := strings.Contains(cond.SQL, "deleted_at IS NULL")
+		value := tx.Statement.Dest.(map[string]interface{})["deleted_at"]
+		for _, id := range ids(cond.Vars[0]) {
+			row := m[tx.Statement.Table][id]
+			if row == nil || (onlyActive && row.deletedAt != nil) {
+				continue
+			}
+			if at, ok := value.(time.Time); ok {
+				row.deletedAt = &at
+			} else {
+				row.deletedAt = nil
+			}
+		}
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:mem", query); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("test:mem", update); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (m memTables) add(table string, id uint, fks map[string]uint, deletedAt *time.Time) {
+	if m[table] == nil {
+		m[table] = map[uint]*fakeRow{}
+	}
+	m[table][id] = &fakeRow{fks: fks, deletedAt: deletedAt}
+}
+
+// Eliminar un candidato arrastra a sus dependientes con la misma marca y
+// restaurarlo los recupera a todos; lo que se eliminó antes por su cuenta y
+// lo de otros candidatos no se toca.
+func TestSoftDeleteYRestore(t *testing.T) {
+	db := dryRun(t)
+	earlier := time.Now().Add(-time.Hour)
+	m := memTables{}
+	m.add("candidates", 1, nil, nil)
+	m.add("candidates", 2, nil, nil)
+	m.add("applications", 10, map[string]uint{"candidate_id": 1, "job_id": 99}, nil)
+	m.add("applications", 11, map[string]uint{"candidate_id": 2, "job_id": 99}, nil)
+	m.add("application_stage_events", 20, map[string]uint{"application_id": 10}, nil)
+	m.add("interviews", 30, map[string]uint{"application_id": 10}, nil)
+	m.add("interview_panelists", 31, map[string]uint{"interview_id": 30}, nil)
+	m.add("offers", 40, map[string]uint{"application_id": 10}, nil)
+	m.add("offer_versions", 41, map[string]uint{"offer_id": 40}, nil)
+	m.add("placements", 50, map[string]uint{"candidate_id": 1, "application_id": 10}, nil) // por dos caminos
+	m.add("documents", 51, map[string]uint{"placement_id": 50}, nil)
+	m.add("comments", 60, map[string]uint{"candidate_id": 1}, nil)
+	m.add("comment_attachments", 61, map[string]uint{"comment_id": 60}, nil)
+	m.add("comments", 62, map[string]uint{"candidate_id": 1}, &earlier) // eliminado antes
+	m.add("candidate_consents", 70, map[string]uint{"candidate_id": 1, "application_id": 10}, nil)
+	m.add("talent_pool_members", 80, map[string]uint{"candidate_id": 1, "pool_id": 5}, nil)
+	m.register(t, db)
+
+	at := time.Now().Truncate(time.Microsecond)
+	deleted, err := softDeleteTree(db, domain.TypeCandidate, []uint{1}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 12 {
+		t.Errorf("dependientes eliminados = %d, se esperaban 12", deleted)
+	}
+	for table, rows := range m {
+		for id, row := range rows {
+			switch {
+			case table == "comments" && id == 62:
+				if !row.deletedAt.Equal(earlier) {
+					t.Errorf("%s #%d: la marca previa cambió a %v", table, id, row.deletedAt)
+				}
+			case id == 2 || id == 11:
+				if row.deletedAt != nil {
+					t.Errorf("%s #%d de otro candidato eliminado", table, id)
+				}
+			case row.deletedAt == nil || !row.deletedAt.Equal(at):
+				t.Errorf("%s #%d: deleted_at = %v, se esperaba %v", table, id, row.deletedAt, at)
+			}
+		}
+	}
+
+	// Restore: el padre y luego sus dependientes desde su marca.
+	if err := db.Table("candidates").Where("id = ?", uint(1)).Update("deleted_at", nil).Error; err != nil {
+		t.Fatal(err)
+	}
+	restored, err := restoreDependents(db, domain.TypeCandidate, []uint{1}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != deleted {
+		t.Errorf("restaurados = %d, eliminados = %d", restored, deleted)
+	}
+	for table, rows := range m {
+		for id, row := range rows {
+			if table == "comments" && id == 62 {
+				if row.deletedAt == nil {
+					t.Errorf("%s #%d eliminado antes fue restaurado", table, id)
+				}
+			} else if row.deletedAt != nil {
+				t.Errorf("%s #%d sigue eliminado", table, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/trash/domain"
+	"dvra-api/internal/shared/apperr"
+)
+
+// FileRemover borra archivos subidos por su ruta relativa al directorio de
+// uploads. Un archivo que ya no existe no es error.
+type FileRemover func(paths []string) error
+
+// TrashService expone la papelera: lista lo eliminado por soft delete, lo
+// restaura (con sus dependientes) o lo elimina físicamente.
+type TrashService struct {
+	repo        domain.TrashRepository
+	removeFiles FileRemover
+}
+
+func NewTrashService(repo domain.TrashRepository, removeFiles FileRemover) *TrashService {
+	return &TrashService{repo: repo, removeFiles: removeFiles}
+}
+
+// List devuelve la papelera de la empresa (0 = todas, SuperAdmin), del más
+// reciente al más antiguo. Sin filtro de tipo incluye todos los tipos
+// recuperables.
+func (s *TrashService) List(companyID uint, filters dtos.TrashFilters) ([]dtos.TrashItemDTO, error) {
+	types := domain.Types
+	if filters.Type != "" {
+		types = []string{filters.Type}
+	}
+
+	items := []dtos.TrashItemDTO{}
+	for _, t := range types {
+		records, err := s.repo.List(companyID, t)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			items = append(items, dtos.TrashItemDTO{Type: r.Type, ID: r.ID, CompanyID: r.CompanyID, Label: r.Label, DeletedAt: r.DeletedAt})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].DeletedAt.After(items[j].DeletedAt) })
+	return items, nil
+}
+
+// Delete manda un registro a la papelera junto con sus dependientes (p. ej.
+// las postulaciones de un candidato y sus entrevistas, ofertas y
+// comentarios), en una sola transacción. Restaurarlo los recupera a todos.
+// Lo usan candidates, jobs y applications al eliminar; el registro ya fue
+// validado por ellos.
+func (s *TrashService) Delete(entityType string, id uint) error {
+	_, err := s.repo.SoftDelete(entityType, id, time.Now())
+	return err
+}
+
+// Restore valida y restaura un registro de la papelera:
+//  1. el tipo es recuperable y el registro está eliminado,
+//  2. pertenece al tenant (companyID = 0 omite la validación, SuperAdmin),
+//  3. sus padres (p. ej. candidate y job de una application) siguen activos,
+//  4. no hay otro registro activo con sus mismas claves únicas (p. ej. el
+//     email del candidato en la empresa).
+//
+// Los dependientes eliminados junto con o después del registro se restauran
+// en la misma transacción.
+func (s *TrashService) Restore(entityType string, id, companyID uint) (*dtos.TrashRestoreResultDTO, error) {
+	record, err := s.findTrashed(entityType, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := domain.Parents(entityType)
+	columns := make([]string, 0, len(parents))
+	for col := range parents {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	fks, err := s.repo.ForeignKeys(entityType, id, columns)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range columns {
+		parentID := fks[col]
+		if parentID == nil {
+			continue
+		}
+		active, err := s.repo.IsActive(parents[col], *parentID)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return nil, apperr.Conflict(fmt.Sprintf("restore the parent %s #%d first", parents[col], *parentID))
+		}
+	}
+
+	if columns := domain.UniqueKeys[entityType]; len(columns) > 0 {
+		duplicate, err := s.repo.ActiveDuplicate(entityType, id, columns)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate != 0 {
+			return nil, apperr.Conflict(fmt.Sprintf("an active %s #%d already has the same %s", entityType, duplicate, strings.Join(columns, ", ")))
+		}
+	}
+
+	restored, err := s.repo.Restore(entityType, id, record.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.TrashRestoreResultDTO{Type: entityType, ID: id, RestoredDependents: restored}, nil
+}
+
+// Purge elimina físicamente un registro que ya está en la papelera, junto con
+// todos sus dependientes y sus archivos subidos (CVs, adjuntos de comentarios,
+// PDFs generados). Es
+// irreversible: solo se permite sobre lo eliminado. Los archivos se borran
+// tras el commit; un fallo ahí se registra y no revierte la purga.
+func (s *TrashService) Purge(entityType string, id, companyID uint) error {
+	if _, err := s.findTrashed(entityType, id, companyID); err != nil {
+		return err
+	}
+	files, err := s.repo.Purge(entityType, id)
+	if err != nil {
+		return err
+	}
+	if len(files) > 0 {
+		if err := s.removeFiles(files); err != nil {
+			log.Printf("⚠️ Papelera: no se pudieron borrar %d archivos de %s #%d: %v", len(files), entityType, id, err)
+		}
+	}
+	return nil
+}
+
+func (s *TrashService) findTrashed(entityType string, id, companyID uint) (*domain.TrashedRecord, error) {
+	if !domain.IsValidType(entityType) {
+		return nil, apperr.BadRequest("invalid trash type")
+	}
+	record, err := s.repo.FindTrashed(entityType, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, apperr.NotFound(entityType + " not found in trash")
+	}
+	if companyID > 0 && record.CompanyID != companyID {
+		return nil, apperr.Forbidden("access denied")
+	}
+	return record, nil
+}
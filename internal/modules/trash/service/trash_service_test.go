@@ -0,0 +1,256 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"dvra-api/internal/modules/trash/domain"
+	"dvra-api/internal/platform/uploads"
+	"dvra-api/internal/shared/apperr"
+)
+
+type key struct {
+	entityType string
+	id         uint
+}
+
+// fakeTrash simula la persistencia: registros eliminados, FKs y registros
+// activos. Anota las llamadas a Restore y Purge.
+type fakeTrash struct {
+	domain.TrashRepository
+	trashed  map[key]domain.TrashedRecord
+	fks      map[key]map[string]*uint
+	active   map[key]bool
+	dups     map[key]uint // registro activo que repite las claves únicas
+	restored []time.Time
+	purged   []key
+	files    []string // archivos de lo purgado
+	removed  []string
+}
+
+func (f *fakeTrash) FindTrashed(entityType string, id uint) (*domain.TrashedRecord, error) {
+	r, ok := f.trashed[key{entityType, id}]
+	if !ok {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+func (f *fakeTrash) ForeignKeys(entityType string, id uint, columns []string) (map[string]*uint, error) {
+	result := map[string]*uint{}
+	for _, col := range columns {
+		result[col] = f.fks[key{entityType, id}][col]
+	}
+	return result, nil
+}
+
+func (f *fakeTrash) IsActive(entityType string, id uint) (bool, error) {
+	return f.active[key{entityType, id}], nil
+}
+
+func (f *fakeTrash) ActiveDuplicate(entityType string, id uint, _ []string) (uint, error) {
+	return f.dups[key{entityType, id}], nil
+}
+
+func (f *fakeTrash) Restore(_ string, _ uint, since time.Time) (int64, error) {
+	f.restored = append(f.restored, since)
+	return 3, nil
+}
+
+func (f *fakeTrash) Purge(entityType string, id uint) ([]string, error) {
+	f.purged = append(f.purged, key{entityType, id})
+	return f.files, nil
+}
+
+func (f *fakeTrash) remove(paths []string) error {
+	f.removed = append(f.removed, paths...)
+	return nil
+}
+
+func ptr(v uint) *uint { return &v }
+
+var deletedAt = time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC)
+
+// trashedApplication deja la postulación 10 (empresa 1) en la papelera,
+// apuntando al candidato 5 y la vacante 8.
+func trashedApplication() *fakeTrash {
+	return &fakeTrash{
+		trashed: map[key]domain.TrashedRecord{
+			{domain.TypeApplication, 10}: {Type: domain.TypeApplication, ID: 10, CompanyID: 1, DeletedAt: deletedAt},
+		},
+		fks: map[key]map[string]*uint{
+			{domain.TypeApplication, 10}: {"candidate_id": ptr(5), "job_id": ptr(8)},
+		},
+		active: map[key]bool{},
+	}
+}
+
+func TestRestoreConPadresActivos(t *testing.T) {
+	repo := trashedApplication()
+	repo.active[key{domain.TypeCandidate, 5}] = true
+	repo.active[key{domain.TypeJob, 8}] = true
+
+	result, err := NewTrashService(repo, repo.remove).Restore(domain.TypeApplication, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RestoredDependents != 3 {
+		t.Errorf("resultado = %+v", result)
+	}
+	// Los dependientes se restauran desde el borrado del registro.
+	if len(repo.restored) != 1 || !repo.restored[0].Equal(deletedAt) {
+		t.Errorf("restore desde %v, se esperaba %v", repo.restored, deletedAt)
+	}
+}
+
+func TestRestoreExigePadresActivos(t *testing.T) {
+	for _, inactive := range []key{{domain.TypeCandidate, 5}, {domain.TypeJob, 8}} {
+		repo := trashedApplication()
+		repo.active[key{domain.TypeCandidate, 5}] = true
+		repo.active[key{domain.TypeJob, 8}] = true
+		repo.active[inactive] = false
+
+		_, err := NewTrashService(repo, repo.remove).Restore(domain.TypeApplication, 10, 1)
+		if apperr.StatusCode(err) != http.StatusConflict {
+			t.Errorf("%s inactivo: err = %v, se esperaba 409", inactive.entityType, err)
+		}
+		if len(repo.restored) != 0 {
+			t.Errorf("%s inactivo: no debería restaurar", inactive.entityType)
+		}
+	}
+}
+
+func TestRestoreIgnoraRelacionesOpcionalesVacias(t *testing.T) {
+	repo := &fakeTrash{
+		trashed: map[key]domain.TrashedRecord{
+			{domain.TypeJob, 8}: {Type: domain.TypeJob, ID: 8, CompanyID: 1, DeletedAt: deletedAt},
+		},
+		fks:    map[key]map[string]*uint{{domain.TypeJob, 8}: {"staffing_client_id": nil}},
+		active: map[key]bool{},
+	}
+	if _, err := NewTrashService(repo, repo.remove).Restore(domain.TypeJob, 8, 1); err != nil {
+		t.Fatalf("una vacante sin cliente final debería restaurarse: %v", err)
+	}
+}
+
+func TestRestoreYPurgeValidan(t *testing.T) {
+	cases := []struct {
+		name       string
+		entityType string
+		id         uint
+		companyID  uint
+		want       int
+	}{
+		{"tipo no recuperable", domain.TypeOffer, 10, 1, http.StatusBadRequest},
+		{"no está en la papelera", domain.TypeApplication, 11, 1, http.StatusNotFound},
+		{"otra empresa", domain.TypeApplication, 10, 2, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		repo := trashedApplication()
+		svc := NewTrashService(repo, repo.remove)
+		if _, err := svc.Restore(tc.entityType, tc.id, tc.companyID); apperr.StatusCode(err) != tc.want {
+			t.Errorf("restore %s: err = %v, se esperaba %d", tc.name, err, tc.want)
+		}
+		if err := svc.Purge(tc.entityType, tc.id, tc.companyID); apperr.StatusCode(err) != tc.want {
+			t.Errorf("purge %s: err = %v, se esperaba %d", tc.name, err, tc.want)
+		}
+		if len(repo.restored) != 0 || len(repo.purged) != 0 || len(repo.removed) != 0 {
+			t.Errorf("%s: no debería tocar la persistencia", tc.name)
+		}
+	}
+}
+
+func TestPurgeSuperAdmin(t *testing.T) {
+	repo := trashedApplication()
+	// companyID 0 = SuperAdmin: sin validación de tenant.
+	if err := NewTrashService(repo, repo.remove).Purge(domain.TypeApplication, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.purged) != 1 || repo.purged[0] != (key{domain.TypeApplication, 10}) {
+		t.Errorf("purgados = %v", repo.purged)
+	}
+}
+
+// Purgar una postulación borra del disco los adjuntos de sus comentarios.
+func TestPurgeBorraLosAdjuntos(t *testing.T) {
+	dir := t.TempDir()
+	attachment := filepath.Join(dir, "comments", "1", "cv-anotado.pdf")
+	if err := os.MkdirAll(filepath.Dir(attachment), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(attachment, []byte("%PDF"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	repo := trashedApplication()
+	repo.files = []string{"comments/1/cv-anotado.pdf"}
+
+	if err := NewTrashService(repo, uploads.New(dir).Remove).Purge(domain.TypeApplication, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(attachment); !os.IsNotExist(err) {
+		t.Errorf("el adjunto sigue en disco: %v", err)
+	}
+}
+
+// Los registros ya se purgaron: un fallo al borrar archivos no es un error
+// de la operación.
+func TestPurgeConFalloDeArchivos(t *testing.T) {
+	repo := trashedApplication()
+	repo.files = []string{"comments/1/a.png"}
+	failing := func([]string) error { return errors.New("disco de solo lectura") }
+
+	if err := NewTrashService(repo, failing).Purge(domain.TypeApplication, 10, 1); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if len(repo.purged) != 1 {
+		t.Errorf("purgados = %v", repo.purged)
+	}
+}
+
+// El candidato volvió a postularse a la vacante mientras la postulación
+// estaba en la papelera: restaurarla la duplicaría.
+func TestRestorePostulacionDuplicada(t *testing.T) {
+	repo := trashedApplication()
+	repo.active[key{domain.TypeCandidate, 5}] = true
+	repo.active[key{domain.TypeJob, 8}] = true
+	repo.dups = map[key]uint{{domain.TypeApplication, 10}: 11}
+
+	_, err := NewTrashService(repo, repo.remove).Restore(domain.TypeApplication, 10, 1)
+	if apperr.StatusCode(err) != http.StatusConflict {
+		t.Fatalf("err = %v, se esperaba 409", err)
+	}
+	if len(repo.restored) != 0 {
+		t.Error("no debería restaurar")
+	}
+}
+
+// Otro candidato activo de la empresa usa el mismo email.
+func TestRestoreCandidatoConEmailReutilizado(t *testing.T) {
+	repo := &fakeTrash{
+		trashed: map[key]domain.TrashedRecord{
+			{domain.TypeCandidate, 5}: {Type: domain.TypeCandidate, ID: 5, CompanyID: 1, DeletedAt: deletedAt},
+		},
+		active: map[key]bool{},
+		dups:   map[key]uint{{domain.TypeCandidate, 5}: 6},
+	}
+
+	_, err := NewTrashService(repo, repo.remove).Restore(domain.TypeCandidate, 5, 1)
+	if apperr.StatusCode(err) != http.StatusConflict {
+		t.Fatalf("err = %v, se esperaba 409", err)
+	}
+	if len(repo.restored) != 0 {
+		t.Error("no debería restaurar")
+	}
+
+	repo.dups = nil
+	if _, err := NewTrashService(repo, repo.remove).Restore(domain.TypeCandidate, 5, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.restored) != 1 {
+		t.Error("sin duplicado debería restaurar")
+	}
+}
@@ -0,0 +1,21 @@
+package transport
+
+import (
+	"dvra-api/internal/modules/trash/service"
+	"dvra-api/internal/shared/middleware"
+	"dvra-api/internal/shared/permissions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes monta las rutas de la papelera bajo el grupo protegido (rg).
+func RegisterRoutes(rg *gin.RouterGroup, svc *service.TrashService) {
+	h := NewTrashHandler(svc)
+
+	trash := rg.Group("/trash")
+	{
+		trash.GET("", middleware.RequirePermission(permissions.TrashView), h.GetTrash)
+		trash.POST("/:type/:id/restore", middleware.RequirePermission(permissions.TrashRestore), h.RestoreItem)
+		trash.DELETE("/:type/:id", middleware.RequirePermission(permissions.TrashPurge), h.PurgeItem)
+	}
+}
@@ -0,0 +1,110 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/modules/trash/service"
+	"dvra-api/internal/shared/apperr"
+	"dvra-api/internal/shared/authctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	svc *service.TrashService
+}
+
+func NewTrashHandler(svc *service.TrashService) *TrashHandler {
+	return &TrashHandler{svc: svc}
+}
+
+// GetTrash godoc
+// @Summary      Listar papelera
+// @Description  Lista jobs, candidatos, postulaciones, clientes finales y colocaciones eliminados de la empresa (SuperAdmin: de todas)
+// @Tags         Trash
+// @Produce      json
+// @Param        type  query     string  false  "Filtrar por tipo (job, candidate, application, staffing_client, placement, talent_pool)"
+// @Success      200   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /trash [get]
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	var filters dtos.TrashFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.svc.List(companyID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve trash"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"items": items, "count": len(items)}})
+}
+
+// RestoreItem godoc
+// @Summary      Restaurar registro eliminado
+// @Description  Restaura un registro de la papelera junto con los dependientes eliminados con él (p. ej. las postulaciones de un candidato)
+// @Tags         Trash
+// @Produce      json
+// @Param        type  path      string  true  "Tipo (job, candidate, application, staffing_client, placement, talent_pool)"
+// @Param        id    path      int     true  "ID del registro"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      409   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /trash/{type}/{id}/restore [post]
+func (h *TrashHandler) RestoreItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.Restore(c.Param("type"), uint(id), companyID)
+	if err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// PurgeItem godoc
+// @Summary      Eliminar definitivamente
+// @Description  Elimina físicamente un registro que ya está en la papelera, junto con sus dependientes. Irreversible.
+// @Tags         Trash
+// @Produce      json
+// @Param        type  path      string  true  "Tipo (job, candidate, application, staffing_client, placement, talent_pool)"
+// @Param        id    path      int     true  "ID del registro"
+// @Success      200   {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /trash/{type}/{id} [delete]
+func (h *TrashHandler) PurgeItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	companyID, ok := authctx.TenantScope(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Purge(c.Param("type"), uint(id), companyID); err != nil {
+		c.JSON(apperr.StatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Record permanently deleted"})
+}
@@ -15,6 +15,15 @@ type Config struct {
 	// CORS
 	CorsAllowedOrigins []string
 
+	// Proxies (IPs o CIDR) cuyo X-Forwarded-For se acepta para obtener la IP
+	// del cliente. Vacío = ninguno: se usa la IP de la conexión, que no se
+	// puede falsificar con un header (evidencia de consentimiento).
+	TrustedProxies []string
+
+	// URL pública del frontend, para armar enlaces que se envían por correo
+	// (autoagenda de entrevistas).
+	AppPublicURL string
+
 	// Base de datos
 	DatabaseURL string
 	DBHost      string
@@ -27,6 +36,18 @@ type Config struct {
 	// JWT (para futuras implementaciones)
 	JWTSecret        string
 	JWTRefreshSecret string
+
+	// Tareas periódicas (retención, barridos). Desactivar en réplicas que no
+	// deban ejecutarlas; igual hay advisory lock entre réplicas.
+	SchedulerEnabled bool
+
+	// Correo saliente (invitaciones de entrevista, etc.). Sin SMTPHost los
+	// correos solo se registran en el log.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	MailFrom     string
 }
 
 // Load carga la configuración desde variables de entorno
@@ -37,7 +58,9 @@ func Load() *Config {
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 
 		// CORS
-		CorsAllowedOrigins: parseCorsOrigins(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:8080")),
+		CorsAllowedOrigins: parseList(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:8080")),
+		TrustedProxies:     parseList(getEnv("TRUSTED_PROXIES", "")),
+		AppPublicURL:       strings.TrimRight(getEnv("APP_PUBLIC_URL", "http://localhost:3000"), "/"),
 
 		// Base de datos
 		DatabaseURL: getEnv("DATABASE_URL", ""),
@@ -51,6 +74,16 @@ func Load() *Config {
 		// JWT
 		JWTSecret:        getEnv("JWT_SECRET", "your-default-secret-change-in-production"),
 		JWTRefreshSecret: getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-change-in-production"),
+
+		// Scheduler
+		SchedulerEnabled: getEnv("SCHEDULER_ENABLED", "true") == "true",
+
+		// Correo
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUser:     getEnv("SMTP_USER", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@dvra.io"),
 	}
 }
 
@@ -62,8 +95,8 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-// parseCorsOrigins parsea la lista de orígenes CORS desde una cadena separada por comas
-func parseCorsOrigins(origins string) []string {
+// parseList parsea una lista separada por comas (orígenes CORS, proxies)
+func parseList(origins string) []string {
 	if origins == "" {
 		return []string{}
 	}
@@ -0,0 +1,138 @@
+// Package mail envía correos salientes del API. Con SMTP configurado usa
+// net/smtp; sin él, solo registra en el log lo que habría enviado (desarrollo).
+// Los módulos no lo importan: definen su propio puerto y el composition root
+// lo adapta.
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"dvra-api/internal/platform/config"
+)
+
+// Attachment es un archivo adjunto. ContentType puede llevar parámetros
+// (p. ej. "text/calendar; method=REQUEST").
+type Attachment struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// Message es un correo de texto plano con adjuntos opcionales.
+type Message struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Sender envía un correo.
+type Sender interface {
+	Send(msg Message) error
+}
+
+// New devuelve el Sender según la configuración.
+func New(cfg *config.Config) Sender {
+	if cfg.SMTPHost == "" {
+		return logSender{from: cfg.MailFrom}
+	}
+	s := &smtpSender{addr: cfg.SMTPHost + ":" + cfg.SMTPPort, from: cfg.MailFrom}
+	if cfg.SMTPUser != "" {
+		s.auth = smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return s
+}
+
+type smtpSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func (s *smtpSender) Send(msg Message) error {
+	if len(msg.To) == 0 {
+		return nil
+	}
+	data, err := build(s.from, msg)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from, msg.To, data)
+}
+
+type logSender struct {
+	from string
+}
+
+func (s logSender) Send(msg Message) error {
+	names := make([]string, len(msg.Attachments))
+	for i, a := range msg.Attachments {
+		names[i] = a.FileName
+	}
+	log.Printf("✉️  [mail sin SMTP] from=%s to=%s subject=%q adjuntos=%v", s.from, strings.Join(msg.To, ","), msg.Subject, names)
+	return nil
+}
+
+// build arma el mensaje MIME (multipart/mixed si hay adjuntos).
+func build(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+
+	header("From", from)
+	header("To", strings.Join(msg.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if len(msg.Attachments) == 0 {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "base64")
+		buf.WriteString("\r\n")
+		writeBase64(&buf, []byte(msg.Body))
+		return buf.Bytes(), nil
+	}
+
+	boundary, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+	header("Content-Type", `multipart/mixed; boundary="`+boundary+`"`)
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "--%s\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: base64\r\n\r\n", boundary)
+	writeBase64(&buf, []byte(msg.Body))
+	for _, a := range msg.Attachments {
+		fmt.Fprintf(&buf, "--%s\r\nContent-Type: %s; name=%q\r\nContent-Disposition: attachment; filename=%q\r\nContent-Transfer-Encoding: base64\r\n\r\n",
+			boundary, a.ContentType, a.FileName, a.FileName)
+		writeBase64(&buf, a.Data)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes(), nil
+}
+
+// writeBase64 escribe data en base64 con líneas de 76 caracteres (RFC 2045).
+func writeBase64(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+}
+
+func newBoundary() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "dvra-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,293 @@
+// Package pdf genera documentos PDF 1.4 de texto (títulos y párrafos con
+// ajuste de línea, A4, varias páginas) sin dependencias externas. Usa las
+// fuentes estándar Helvetica y Helvetica-Bold con WinAnsiEncoding, que cubre
+// los acentos del español y portugués; no incrusta fuentes ni imágenes.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Medidas de página en puntos (A4).
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 56.0
+
+	bodySize    = 11.0
+	headingSize = 15.0
+	lineFactor  = 1.45
+)
+
+type line struct {
+	text string
+	bold bool
+	size float64
+	gap  float64 // espacio extra antes de la línea
+}
+
+// Document acumula el contenido y lo pagina al generar los bytes.
+type Document struct {
+	title string
+	lines []line
+}
+
+// New crea un documento; title va en los metadatos del PDF.
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// Heading agrega un título en negrita.
+func (d *Document) Heading(text string) {
+	gap := bodySize
+	if len(d.lines) == 0 {
+		gap = 0
+	}
+	for i, l := range wrap(text, headingSize, true) {
+		entry := line{text: l, bold: true, size: headingSize}
+		if i == 0 {
+			entry.gap = gap
+		}
+		d.lines = append(d.lines, entry)
+	}
+}
+
+// Paragraph agrega un párrafo; los saltos de línea internos se respetan.
+func (d *Document) Paragraph(text string) {
+	gap := bodySize * 0.6
+	if len(d.lines) == 0 {
+		gap = 0
+	}
+	first := true
+	for _, raw := range strings.Split(text, "\n") {
+		wrapped := wrap(raw, bodySize, false)
+		if len(wrapped) == 0 {
+			wrapped = []string{""}
+		}
+		for _, l := range wrapped {
+			entry := line{text: l, size: bodySize}
+			if first {
+				entry.gap = gap
+				first = false
+			}
+			d.lines = append(d.lines, entry)
+		}
+	}
+}
+
+// Bytes genera el PDF.
+func (d *Document) Bytes() []byte {
+	pages := d.paginate()
+
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// 1 catálogo, 2 árbol de páginas, 3-4 fuentes, 5 info; luego cada página
+	// y su contenido.
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (Dvra) >>", escape(d.title)))
+
+	for i, content := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+i*2))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// paginate reparte las líneas en páginas y devuelve el stream de cada una.
+func (d *Document) paginate() []string {
+	var pages []string
+	var page strings.Builder
+	y := pageHeight - margin
+	for _, l := range d.lines {
+		height := l.size*lineFactor + l.gap
+		if y-height < margin && page.Len() > 0 {
+			pages = append(pages, page.String())
+			page.Reset()
+			y = pageHeight - margin
+			height = l.size * lineFactor
+		}
+		y -= height
+		if l.text == "" {
+			continue
+		}
+		font := "F1"
+		if l.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, l.size, margin, y, escape(l.text))
+	}
+	if page.Len() > 0 || len(pages) == 0 {
+		pages = append(pages, page.String())
+	}
+	return pages
+}
+
+// wrap corta text en líneas que caben en el ancho útil de la página.
+func wrap(text string, size float64, bold bool) []string {
+	limit := pageWidth - 2*margin
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if current != "" && width(candidate, size, bold) > limit {
+			lines = append(lines, current)
+			candidate = word
+		}
+		// Una palabra más ancha que la página se corta por caracteres.
+		for width(candidate, size, bold) > limit {
+			cut := fit(candidate, size, bold, limit)
+			lines = append(lines, candidate[:cut])
+			candidate = candidate[cut:]
+		}
+		current = candidate
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// fit devuelve cuántos bytes de s caben en limit (al menos una runa).
+func fit(s string, size float64, bold bool, limit float64) int {
+	total := 0.0
+	for i, r := range s {
+		total += glyphWidth(r, bold) * size / 1000
+		if total > limit && i > 0 {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// width mide text en puntos con las métricas de Helvetica.
+func width(text string, size float64, bold bool) float64 {
+	total := 0.0
+	for _, r := range text {
+		total += glyphWidth(r, bold)
+	}
+	return total * size / 1000
+}
+
+func glyphWidth(r rune, bold bool) float64 {
+	r = baseLetter(r)
+	table := regularWidths
+	if bold {
+		table = boldWidths
+	}
+	if r >= 32 && r < 127 {
+		return float64(table[r-32])
+	}
+	return 556
+}
+
+// baseLetter devuelve la letra sin acento (en Helvetica miden lo mismo).
+func baseLetter(r rune) rune {
+	if r < 0xC0 || r > 0xFF {
+		return r
+	}
+	const from = "ÀÁÂÃÄÅàáâãäåÈÉÊËèéêëÌÍÎÏìíîïÒÓÔÕÖòóôõöÙÚÛÜùúûüÑñÇçÝýÿ"
+	const to = "AAAAAAaaaaaaEEEEeeeeIIIIiiiiOOOOOoooooUUUUuuuuNnCcYyy"
+	toRunes := []rune(to)
+	i := 0
+	for _, f := range from {
+		if f == r {
+			return toRunes[i]
+		}
+		i++
+	}
+	return r
+}
+
+// escape codifica text en WinAnsi y escapa los delimitadores de string PDF.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		c, ok := winAnsi(r)
+		if !ok {
+			c = '?'
+		}
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c < 32 {
+				continue
+			}
+			if c > 126 {
+				fmt.Fprintf(&b, "\\%03o", c)
+				continue
+			}
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// winAnsi traduce una runa a WinAnsiEncoding (cp1252).
+func winAnsi(r rune) (byte, bool) {
+	switch {
+	case r < 128:
+		return byte(r), true
+	case r >= 0xA0 && r <= 0xFF:
+		return byte(r), true
+	}
+	special := map[rune]byte{
+		'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+		'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+	}
+	if c, ok := special[r]; ok {
+		return c, true
+	}
+	if unicode.IsSpace(r) {
+		return ' ', true
+	}
+	return 0, false
+}
+
+// Anchos (1/1000 em) de los caracteres 32-126 según las métricas AFM de
+// Helvetica y Helvetica-Bold.
+var regularWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var boldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -0,0 +1,51 @@
+package pdf
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBytesStructure(t *testing.T) {
+	doc := New("Carta de oferta")
+	doc.Heading("Oferta de empleo")
+	doc.Paragraph("Señora Peña: le ofrecemos el cargo (tiempo completo) por 5.000,00 €.")
+	out := doc.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	// startxref debe apuntar a la tabla xref.
+	s := string(out)
+	i := strings.LastIndex(s, "startxref\n")
+	offset, err := strconv.Atoi(strings.SplitN(s[i+len("startxref\n"):], "\n", 2)[0])
+	if err != nil || !strings.HasPrefix(s[offset:], "xref\n") {
+		t.Fatalf("startxref does not point to xref table (offset %d)", offset)
+	}
+	for _, want := range []string{`Se\361ora Pe\361a`, `\(tiempo completo\)`, `\200`} {
+		if !strings.Contains(s, want) {
+			t.Errorf("content missing %q", want)
+		}
+	}
+}
+
+func TestPagination(t *testing.T) {
+	doc := New("Largo")
+	for i := 0; i < 200; i++ {
+		doc.Paragraph("Cláusula de prueba con texto suficiente para ocupar una línea completa del documento generado.")
+	}
+	if pages := len(doc.paginate()); pages < 2 {
+		t.Fatalf("expected several pages, got %d", pages)
+	}
+}
+
+func TestWrapFitsWidth(t *testing.T) {
+	text := strings.Repeat("palabra ", 60) + strings.Repeat("x", 200)
+	limit := pageWidth - 2*margin
+	for _, l := range wrap(text, bodySize, false) {
+		if w := width(l, bodySize, false); w > limit {
+			t.Fatalf("line %q is %.1fpt wide (limit %.1f)", l, w, limit)
+		}
+	}
+}
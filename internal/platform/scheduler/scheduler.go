@@ -0,0 +1,126 @@
+// Package scheduler ejecuta tareas periódicas en segundo plano (retención de
+// datos, barridos de pipeline, etc.) dentro del mismo proceso del API.
+//
+// Cada ejecución toma un advisory lock de PostgreSQL con el nombre de la tarea:
+// si hay varias réplicas del API, solo una corre la tarea en cada tick y el
+// resto la omite sin bloquearse.
+package scheduler
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Job es una tarea periódica. Run recibe un contexto que se cancela al detener
+// el scheduler; debe respetarlo en operaciones largas.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler corre un conjunto de Jobs, cada uno en su propia goroutine.
+type Scheduler struct {
+	db     *gorm.DB
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New crea un scheduler que usa db para los advisory locks.
+func New(db *gorm.DB) *Scheduler {
+	return &Scheduler{db: db}
+}
+
+// Add registra una tarea. Debe llamarse antes de Start.
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start lanza todas las tareas registradas. La primera ejecución de cada una
+// ocurre tras su primer intervalo, no al arrancar (evita picos en cada deploy).
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+	if len(s.jobs) > 0 {
+		log.Printf("⏰ Scheduler iniciado con %d tarea(s)", len(s.jobs))
+	}
+}
+
+// Stop cancela las tareas en curso y espera a que terminen.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runLocked(ctx, job)
+		}
+	}
+}
+
+// runLocked ejecuta la tarea solo si esta réplica obtiene el advisory lock.
+// El lock es de sesión, por eso se fija una conexión dedicada del pool.
+func (s *Scheduler) runLocked(ctx context.Context, job Job) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		log.Printf("❌ Scheduler %s: %v", job.Name, err)
+		return
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		log.Printf("❌ Scheduler %s: %v", job.Name, err)
+		return
+	}
+	defer conn.Close()
+
+	key := lockKey(job.Name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		log.Printf("❌ Scheduler %s: %v", job.Name, err)
+		return
+	}
+	if !acquired {
+		return
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		log.Printf("❌ Scheduler %s falló tras %s: %v", job.Name, time.Since(start).Round(time.Millisecond), err)
+		return
+	}
+	log.Printf("✅ Scheduler %s completado en %s", job.Name, time.Since(start).Round(time.Millisecond))
+}
+
+// lockKey deriva la clave int64 del advisory lock a partir del nombre.
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("dvra-scheduler:" + name))
+	return int64(h.Sum64())
+}
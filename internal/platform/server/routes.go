@@ -3,7 +3,24 @@ package server
 import (
 	"dvra-api/internal/app/handlers"
 	"dvra-api/internal/app/services"
+	"dvra-api/internal/modules/automation"
+	"dvra-api/internal/modules/comment"
+	"dvra-api/internal/modules/dedup"
+	"dvra-api/internal/modules/document"
+	"dvra-api/internal/modules/interview"
+	"dvra-api/internal/modules/match"
+	"dvra-api/internal/modules/notification"
+	"dvra-api/internal/modules/offer"
+	"dvra-api/internal/modules/pipeline"
+	"dvra-api/internal/modules/privacy"
+	"dvra-api/internal/modules/resume"
+	"dvra-api/internal/modules/scorecard"
+	"dvra-api/internal/modules/screening"
+	"dvra-api/internal/modules/search"
+	"dvra-api/internal/modules/skill"
 	"dvra-api/internal/modules/staffing"
+	"dvra-api/internal/modules/talentpool"
+	"dvra-api/internal/modules/trash"
 	"dvra-api/internal/platform/config"
 	"dvra-api/internal/shared/middleware"
 	"dvra-api/internal/shared/permissions"
@@ -24,12 +41,31 @@ func registerRoutes(
 	companyHandler *handlers.CompanyHandler,
 	membershipHandler *handlers.MembershipHandler,
 	candidateHandler *handlers.CandidateHandler,
+	tagHandler *handlers.TagHandler,
 	applicationHandler *handlers.ApplicationHandler,
 	jobHandler *handlers.JobHandler,
 	staffingModule *staffing.Module,
+	trashModule *trash.Module,
+	privacyModule *privacy.Module,
+	pipelineModule *pipeline.Module,
+	scorecardModule *scorecard.Module,
+	notificationModule *notification.Module,
+	commentModule *comment.Module,
+	interviewModule *interview.Module,
+	offerModule *offer.Module,
+	documentModule *document.Module,
+	automationModule *automation.Module,
+	dedupModule *dedup.Module,
+	talentPoolModule *talentpool.Module,
+	searchModule *search.Module,
+	resumeModule *resume.Module,
+	skillModule *skill.Module,
+	matchModule *match.Module,
+	screeningModule *screening.Module,
 	planHandler *handlers.PlanHandler,
 	planService services.PlanService,
 	systemValueHandler *handlers.SystemValueHandler,
+	rejectionReasonHandler *handlers.RejectionReasonHandler,
 	locationHandler *handlers.LocationHandler,
 	dashboardHandler *handlers.DashboardHandler,
 	publicHandler *handlers.PublicHandler,
@@ -45,21 +81,35 @@ func registerRoutes(
 			"version":        "v1.4.0",
 			"generated_with": "Loom",
 			"endpoints": gin.H{
-				"health":           "/api/v1/health",
-				"auth":             "/api/v1/auth",
-				"users":            "/api/v1/users",
-				"companies":        "/api/v1/companies",
-				"memberships":      "/api/v1/memberships",
-				"jobs":             "/api/v1/jobs",
-				"staffing_clients": "/api/v1/staffing-clients",
-				"placements":       "/api/v1/placements",
-				"candidates":       "/api/v1/candidates",
-				"applications":     "/api/v1/applications",
-				"dashboard":        "/api/v1/dashboard",
-				"plans":            "/api/v1/plans (public)",
-				"locations":        "/api/v1/locations (public)",
-				"public":           "/api/v1/public (career page)",
-				"swagger":          "/swagger/index.html",
+				"health":            "/api/v1/health",
+				"auth":              "/api/v1/auth",
+				"users":             "/api/v1/users",
+				"companies":         "/api/v1/companies",
+				"memberships":       "/api/v1/memberships",
+				"jobs":              "/api/v1/jobs · /api/v1/jobs/:id/skills · /api/v1/jobs/:id/skill-gaps · /api/v1/jobs/:id/matches",
+				"staffing_clients":  "/api/v1/staffing-clients",
+				"placements":        "/api/v1/placements",
+				"candidates":        "/api/v1/candidates · /api/v1/candidates/duplicates · /api/v1/candidates/merges · /api/v1/candidates/bulk-tags · /api/v1/candidates/search · /api/v1/candidates/:id/resume · /api/v1/candidates/:id/skills",
+				"tags":              "/api/v1/tags · /api/v1/candidates/:id/tags",
+				"talent_pools":      "/api/v1/talent-pools · /api/v1/candidates/:id/talent-pools",
+				"skills":            "/api/v1/skills",
+				"applications":      "/api/v1/applications",
+				"dashboard":         "/api/v1/dashboard",
+				"trash":             "/api/v1/trash",
+				"privacy":           "/api/v1/privacy",
+				"pipelines":         "/api/v1/pipelines",
+				"rejection_reasons": "/api/v1/rejection-reasons",
+				"scorecards":        "/api/v1/scorecard-templates · /api/v1/applications/:id/scorecards",
+				"comments":          "/api/v1/comments · /api/v1/candidates/:id/comments · /api/v1/applications/:id/comments",
+				"notifications":     "/api/v1/notifications",
+				"interviews":        "/api/v1/interviews · /api/v1/applications/:id/interviews · /api/v1/public/schedule/:token",
+				"offers":            "/api/v1/offers · /api/v1/applications/:id/offers",
+				"documents":         "/api/v1/document-templates · /api/v1/applications/:id/documents · /api/v1/placements/:id/documents",
+				"automations":       "/api/v1/automations · /api/v1/automations/runs",
+				"plans":             "/api/v1/plans (public)",
+				"locations":         "/api/v1/locations (public)",
+				"public":            "/api/v1/public (career page)",
+				"swagger":           "/swagger/index.html",
 			},
 		})
 	})
@@ -155,6 +205,19 @@ func registerRoutes(
 				candidates.PUT("/:id", middleware.RequirePermission(permissions.CandidatesUpdate), candidateHandler.UpdateCandidate)
 				candidates.DELETE("/:id", middleware.RequirePermission(permissions.CandidatesDelete), candidateHandler.DeleteCandidate)
 				candidates.POST("/:id/upload-resume", middleware.RequirePermission(permissions.CandidatesUploadResume), candidateHandler.UploadResume)
+				candidates.POST("/bulk-tags", middleware.RequirePermission(permissions.CandidatesUpdate), tagHandler.BulkTagCandidates)
+				candidates.GET("/:id/tags", middleware.RequirePermission(permissions.CandidatesView), tagHandler.GetCandidateTags)
+				candidates.POST("/:id/tags", middleware.RequirePermission(permissions.CandidatesUpdate), tagHandler.TagCandidate)
+				candidates.DELETE("/:id/tags/:tagId", middleware.RequirePermission(permissions.CandidatesUpdate), tagHandler.UntagCandidate)
+			}
+
+			// Tag routes (catálogo de etiquetas de candidatos)
+			tags := protected.Group("/tags")
+			{
+				tags.GET("", middleware.RequirePermission(permissions.CandidatesView), tagHandler.List)
+				tags.POST("", middleware.RequirePermission(permissions.TagsManage), tagHandler.Create)
+				tags.PUT("/:id", middleware.RequirePermission(permissions.TagsManage), tagHandler.Update)
+				tags.DELETE("/:id", middleware.RequirePermission(permissions.TagsManage), tagHandler.Delete)
 			}
 
 			// Application routes
@@ -162,14 +225,49 @@ func registerRoutes(
 			{
 				applications.GET("", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.GetApplications)
 				applications.GET("/by-stage", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.GetApplicationsByStage)
+				applications.GET("/stage-stats", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.GetStageStats)
+				applications.GET("/rejection-stats", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.GetRejectionStats)
 				applications.POST("", middleware.RequirePermission(permissions.ApplicationsCreate), applicationHandler.CreateApplication)
+				// El permiso de cada acción (move/rate/delete) se valida en el handler.
+				applications.POST("/bulk", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.BulkApplications)
 				applications.GET("/:id", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.GetApplication)
+				applications.GET("/:id/timeline", middleware.RequirePermission(permissions.ApplicationsView), applicationHandler.GetApplicationTimeline)
 				applications.PUT("/:id", middleware.RequirePermission(permissions.ApplicationsUpdate), applicationHandler.UpdateApplication)
 				applications.PATCH("/:id/move", middleware.RequirePermission(permissions.ApplicationsMove), applicationHandler.MoveApplication)
+				applications.PATCH("/:id/reorder", middleware.RequirePermission(permissions.ApplicationsMove), applicationHandler.ReorderApplication)
+				applications.PATCH("/:id/override-stage", middleware.RequirePermission(permissions.ApplicationsOverrideStage), applicationHandler.OverrideApplicationStage)
 				applications.PATCH("/:id/rate", middleware.RequirePermission(permissions.ApplicationsRate), applicationHandler.RateApplication)
 				applications.DELETE("/:id", middleware.RequirePermission(permissions.ApplicationsDelete), applicationHandler.DeleteApplication)
 			}
 
+			// Papelera: ver/restaurar/purgar registros eliminados (soft delete).
+			trashModule.RegisterRoutes(protected)
+			privacyModule.RegisterRoutes(protected)
+			pipelineModule.RegisterRoutes(protected)
+			scorecardModule.RegisterRoutes(protected)
+			notificationModule.RegisterRoutes(protected)
+			commentModule.RegisterRoutes(protected)
+			interviewModule.RegisterRoutes(protected)
+			offerModule.RegisterRoutes(protected)
+			documentModule.RegisterRoutes(protected)
+			automationModule.RegisterRoutes(protected)
+			dedupModule.RegisterRoutes(protected)
+			talentPoolModule.RegisterRoutes(protected)
+			searchModule.RegisterRoutes(protected)
+			resumeModule.RegisterRoutes(protected)
+			skillModule.RegisterRoutes(protected)
+			matchModule.RegisterRoutes(protected)
+			screeningModule.RegisterRoutes(protected)
+
+			// Rejection reasons routes (catálogo global + propio de la empresa)
+			rejectionReasons := protected.Group("/rejection-reasons")
+			{
+				rejectionReasons.GET("", middleware.RequirePermission(permissions.RejectionReasonsView), rejectionReasonHandler.List)
+				rejectionReasons.POST("", middleware.RequirePermission(permissions.RejectionReasonsManage), rejectionReasonHandler.Create)
+				rejectionReasons.PUT("/:id", middleware.RequirePermission(permissions.RejectionReasonsManage), rejectionReasonHandler.Update)
+				rejectionReasons.DELETE("/:id", middleware.RequirePermission(permissions.RejectionReasonsManage), rejectionReasonHandler.Delete)
+			}
+
 			// Dashboard routes
 			dashboard := protected.Group("/dashboard")
 			{
@@ -203,6 +301,12 @@ func registerRoutes(
 			// Job details and applications
 			public.GET("/jobs/:id", publicHandler.GetPublishedJobByID)
 			public.POST("/jobs/:id/apply", publicHandler.ApplyToJob)
+
+			// Aviso de privacidad vigente (lo que acepta el candidato al postular)
+			privacyModule.RegisterPublicRoutes(public)
+
+			// Autoagenda de entrevistas (token del enlace)
+			interviewModule.RegisterPublicRoutes(public)
 		}
 
 		// Public Location routes (no auth required - READ ONLY)
@@ -2,14 +2,35 @@ package server
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"time"
 
 	"dvra-api/internal/app/handlers"
 	"dvra-api/internal/app/repositories"
 	"dvra-api/internal/app/services"
+	"dvra-api/internal/modules/automation"
+	"dvra-api/internal/modules/comment"
+	"dvra-api/internal/modules/dedup"
+	"dvra-api/internal/modules/document"
+	"dvra-api/internal/modules/interview"
+	"dvra-api/internal/modules/match"
+	"dvra-api/internal/modules/notification"
+	"dvra-api/internal/modules/offer"
+	"dvra-api/internal/modules/pipeline"
+	"dvra-api/internal/modules/privacy"
+	"dvra-api/internal/modules/resume"
+	"dvra-api/internal/modules/scorecard"
+	"dvra-api/internal/modules/screening"
+	"dvra-api/internal/modules/search"
+	"dvra-api/internal/modules/skill"
 	"dvra-api/internal/modules/staffing"
+	"dvra-api/internal/modules/talentpool"
+	"dvra-api/internal/modules/trash"
 	"dvra-api/internal/platform/config"
+	"dvra-api/internal/platform/mail"
+	"dvra-api/internal/platform/scheduler"
+	"dvra-api/internal/platform/uploads"
 
 	_ "dvra-api/docs" // Importar documentación generada por Swagger
 
@@ -23,6 +44,7 @@ type Server struct {
 	router     *gin.Engine
 	httpServer *http.Server
 	db         *gorm.DB
+	scheduler  *scheduler.Scheduler
 }
 
 // New creates a new server instance with all dependencies injected
@@ -41,6 +63,8 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 	membershipRepo := repositories.NewMembershipRepository()
 	candidateRepo := repositories.NewCandidateRepository()
 	applicationRepo := repositories.NewApplicationRepository()
+	stageEventRepo := repositories.NewApplicationStageEventRepository()
+	tagRepo := repositories.NewTagRepository()
 	jobRepo := repositories.NewJobRepository()
 	planRepo := repositories.NewPlanRepository(db)
 	systemValueRepo := repositories.NewSystemValueRepository(db)
@@ -53,18 +77,82 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 	userService := services.NewUserService(userRepo)
 	companyService := services.NewCompanyService(companyRepo)
 	membershipService := services.NewMembershipService(membershipRepo)
-	candidateService := services.NewCandidateService(candidateRepo)
-	applicationService := services.NewApplicationService(applicationRepo)
+	// Módulo skill: catálogo de habilidades y las de vacantes y candidatos.
+	// Módulo resume: candidates y la career page le agendan el análisis de
+	// cada CV guardado (texto para la búsqueda y datos para la ficha); las
+	// habilidades del CV pasan a la ficha vía skillModule.
+	skillModule := skill.New(db)
+	resumeModule := resume.New(db, skillModule.Service)
+	// Módulo trash: al purgar borra también los archivos subidos.
+	// candidates, jobs y applications eliminan vía trash, que arrastra a los
+	// dependientes para poder restaurarlos juntos.
+	uploadStore := uploads.New("./uploads")
+	trashModule := trash.New(db, uploadStore.Remove)
+	candidateService := services.NewCandidateService(candidateRepo, resumeModule.Service, trashModule.Service)
+	tagService := services.NewTagService(tagRepo, candidateRepo)
+	// Módulo pipeline: etapas configurables por empresa/vacante. Va primero
+	// porque applications, dashboard, career page y staffing lo consultan.
+	pipelineModule := pipeline.New(db)
+	// Módulos notification y comment: las menciones se notifican in-app;
+	// applications y la career page registran notas y cartas en el hilo.
+	notificationModule := notification.New(db)
+	commentModule := comment.New(db, notificationModule.Service)
+	// Módulo scorecard: lee postulaciones y etapas vía adaptadores; applications
+	// adjunta su resumen al detalle de la postulación.
+	scorecardModule := scorecard.New(db, scorecardAppFinder{repo: applicationRepo, pipelines: pipelineModule.Service}, scorecardJobStages{pipelines: pipelineModule.Service})
+	// Módulo automation: applications y la career page le avisan los eventos
+	// de las postulaciones; la acción move_stage vuelve a applications vía
+	// automationMover, que se completa una vez creado el servicio.
+	// Módulo talentpool: listas de candidatos contactables; la acción
+	// add_to_pool de las automatizaciones agrega candidatos vía adaptador.
+	talentPoolModule := talentpool.New(db)
+	searchModule := search.New(db)
+	mailSender := mail.New(cfg)
+	mover := &automationMover{}
+	automationModule := automation.New(db, mover, automationTagger{repo: tagRepo}, automationPooler{pools: talentPoolModule.Service}, automationMailer{sender: mailSender}, notificationModule.Service, pipelineModule.Service, systemValueRepo)
+	// Módulo match: compatibilidad vacante ↔ candidato; compara habilidades
+	// vía adaptador del módulo skill y ordena el tablero de applications.
+	matchModule := match.New(db, matchSkills{skills: skillModule.Service})
+	applicationService := services.NewApplicationService(applicationRepo, stageEventRepo, tagRepo, systemValueRepo, pipelineModule.Service, scorecardModule.Service, commentModule.Service, automationModule.Service, matchModule.Service, trashModule.Service)
+	mover.applications = applicationService
 	// Módulo staffing (monolito modular + hexagonal-lite, ver ADR-001). Se cablea
 	// con db + un adaptador del repo de applications hacia su puerto ApplicationFinder.
-	staffingModule := staffing.New(db, staffingAppFinder{repo: applicationRepo})
-
-	jobService := services.NewJobService(jobRepo, staffingModule.ClientRepo)
+	staffingModule := staffing.New(db, staffingAppFinder{repo: applicationRepo, pipelines: pipelineModule.Service})
+	// Módulo interview: agenda, invitaciones .ics por correo (SMTP o log) y
+	// enlaces de autoagenda hacia el frontend.
+	interviewModule := interview.New(db, interviewAppFinder{repo: applicationRepo, pipelines: pipelineModule.Service}, interviewMailer{sender: mailSender}, cfg.AppPublicURL)
+	// Módulo offer: aceptar una oferta mueve la postulación a hired vía el
+	// servicio de applications, en la transacción de la respuesta; los
+	// aprobadores reciben notificaciones.
+	offerTx := offerRespondTx{db: db, repo: applicationRepo, applications: applicationService, automation: automationModule.Service}
+	offerModule := offer.New(db, offerAppFinder{repo: applicationRepo}, offerTx, notificationModule.Service)
+	documentModule := document.New(db)
+	dedupModule := dedup.New(db)
+	// Módulo privacy: al anonimizar borra también los archivos subidos.
+	privacyModule := privacy.New(db, uploadStore.Remove)
+	// Módulo screening: preguntas de cada vacante que la career page muestra,
+	// evalúa y guarda; los motivos knockout se validan contra el catálogo.
+	screeningModule := screening.New(db, systemValueRepo)
+
+	// Tareas periódicas (retención de datos, etc.). Se pueden apagar con
+	// SCHEDULER_ENABLED=false en réplicas que no deban ejecutarlas.
+	jobScheduler := scheduler.New(db)
+	privacyModule.RegisterJobs(jobScheduler)
+	offerModule.RegisterJobs(jobScheduler)
+	automationModule.RegisterJobs(jobScheduler)
+	searchModule.RegisterJobs(jobScheduler)
+	resumeModule.RegisterJobs(jobScheduler)
+
+	jobService := services.NewJobService(jobRepo, staffingModule.ClientRepo, trashModule.Service)
 	planService := services.NewPlanService(planRepo, companyRepo, db)
 	systemValueService := services.NewSystemValueService(systemValueRepo)
+	rejectionReasonService := services.NewRejectionReasonService(systemValueRepo)
 	locationService := services.NewLocationService(locationRepo)
-	dashboardService := services.NewDashboardService(dashboardRepo)
-	publicService := services.NewPublicService(companyRepo, jobRepo, candidateRepo, applicationRepo)
+	dashboardService := services.NewDashboardService(dashboardRepo, pipelineModule.Service)
+	// La career page guarda candidato, postulación, carta, consentimiento y
+	// respuestas de screening en una sola transacción (publicApplyTx).
+	applyTx := publicApplyTx{db: db, candidates: candidateRepo, applications: applicationRepo, privacy: privacyModule, comments: commentModule, screening: screeningModule}
+	publicService := services.NewPublicService(companyRepo, jobRepo, privacyModule.ConsentService, pipelineModule.Service, automationModule.Service, resumeModule.Service, screeningModule.Service, applyTx)
 	platformSettingsService := services.NewPlatformSettingsService(platformSettingsRepo)
 
 	// Create handlers (injecting services)
@@ -74,10 +162,12 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 	companyHandler := handlers.NewCompanyHandler(companyService)
 	membershipHandler := handlers.NewMembershipHandler(membershipService)
 	candidateHandler := handlers.NewCandidateHandler(candidateService)
+	tagHandler := handlers.NewTagHandler(tagService)
 	applicationHandler := handlers.NewApplicationHandler(applicationService)
 	jobHandler := handlers.NewJobHandler(jobService)
 	planHandler := handlers.NewPlanHandler(planService)
 	systemValueHandler := handlers.NewSystemValueHandler(systemValueService)
+	rejectionReasonHandler := handlers.NewRejectionReasonHandler(rejectionReasonService)
 	locationHandler := handlers.NewLocationHandler(locationService)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
 	publicHandler := handlers.NewPublicHandler(publicService)
@@ -86,11 +176,17 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 	// Create Gin router
 	router := gin.Default()
 
+	// c.ClientIP() solo lee X-Forwarded-For si la conexión viene de un proxy
+	// de TRUSTED_PROXIES; sin ninguno devuelve la IP de la conexión.
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("TRUSTED_PROXIES inválido: %v", err)
+	}
+
 	// Configure CORS middleware
 	router.Use(corsMiddleware(cfg.CorsAllowedOrigins))
 
 	// Register routes (passing config for dynamic Swagger host)
-	registerRoutes(router, healthHandler, authHandler, userHandler, companyHandler, membershipHandler, candidateHandler, applicationHandler, jobHandler, staffingModule, planHandler, planService, systemValueHandler, locationHandler, dashboardHandler, publicHandler, platformSettingsHandler, jwtService, cfg)
+	registerRoutes(router, healthHandler, authHandler, userHandler, companyHandler, membershipHandler, candidateHandler, tagHandler, applicationHandler, jobHandler, staffingModule, trashModule, privacyModule, pipelineModule, scorecardModule, notificationModule, commentModule, interviewModule, offerModule, documentModule, automationModule, dedupModule, talentPoolModule, searchModule, resumeModule, skillModule, matchModule, screeningModule, planHandler, planService, systemValueHandler, rejectionReasonHandler, locationHandler, dashboardHandler, publicHandler, platformSettingsHandler, jwtService, cfg)
 
 	// Configure HTTP server
 	httpServer := &http.Server{
@@ -106,16 +202,21 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 		router:     router,
 		httpServer: httpServer,
 		db:         db,
+		scheduler:  jobScheduler,
 	}
 }
 
 // Start starts the HTTP server
 func (s *Server) Start() error {
+	if s.config.SchedulerEnabled {
+		s.scheduler.Start()
+	}
 	return s.httpServer.ListenAndServe()
 }
 
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.scheduler.Stop()
 	return s.httpServer.Shutdown(ctx)
 }
 
@@ -1,15 +1,41 @@
 package server
 
 import (
+	"errors"
+	"log"
+	"strings"
+
+	"dvra-api/internal/app/dtos"
+	"dvra-api/internal/app/models"
 	"dvra-api/internal/app/repositories"
+	"dvra-api/internal/app/services"
+	automationdomain "dvra-api/internal/modules/automation/domain"
+	automationservice "dvra-api/internal/modules/automation/service"
+	"dvra-api/internal/modules/comment"
+	interviewdomain "dvra-api/internal/modules/interview/domain"
+	matchdomain "dvra-api/internal/modules/match/domain"
+	"dvra-api/internal/modules/offer"
+	offerdomain "dvra-api/internal/modules/offer/domain"
+	pipelineservice "dvra-api/internal/modules/pipeline/service"
+	"dvra-api/internal/modules/privacy"
+	scorecarddomain "dvra-api/internal/modules/scorecard/domain"
+	"dvra-api/internal/modules/screening"
+	skilldomain "dvra-api/internal/modules/skill/domain"
+	skillservice "dvra-api/internal/modules/skill/service"
 	staffingdomain "dvra-api/internal/modules/staffing/domain"
+	talentpooldomain "dvra-api/internal/modules/talentpool/domain"
+	talentpoolservice "dvra-api/internal/modules/talentpool/service"
+	"dvra-api/internal/platform/mail"
+
+	"gorm.io/gorm"
 )
 
 // staffingAppFinder adapta el repositorio de applications (módulo recruitment) al
 // puerto staffingdomain.ApplicationFinder que el módulo staffing necesita. Vive en
 // el composition root: ni staffing ni recruitment se importan mutuamente.
 type staffingAppFinder struct {
-	repo repositories.ApplicationRepository
+	repo      repositories.ApplicationRepository
+	pipelines *pipelineservice.PipelineService
 }
 
 func (a staffingAppFinder) FindByID(id uint) (*staffingdomain.HiredApplication, error) {
@@ -17,11 +43,360 @@ func (a staffingAppFinder) FindByID(id uint) (*staffingdomain.HiredApplication,
 	if err != nil || app == nil {
 		return nil, err
 	}
+	pipeline, err := a.pipelines.Resolve(app.CompanyID, app.JobID)
+	if err != nil {
+		return nil, err
+	}
+	stageType := ""
+	if stage := pipeline.Stage(app.Stage); stage != nil {
+		stageType = stage.Type
+	}
 	return &staffingdomain.HiredApplication{
 		ID:          app.ID,
 		CompanyID:   app.CompanyID,
 		CandidateID: app.CandidateID,
 		JobID:       app.JobID,
 		Stage:       app.Stage,
+		StageType:   stageType,
 	}, nil
 }
+
+// scorecardAppFinder adapta el repositorio de applications y el pipeline de la
+// vacante al puerto scorecarddomain.ApplicationFinder.
+type scorecardAppFinder struct {
+	repo      repositories.ApplicationRepository
+	pipelines *pipelineservice.PipelineService
+}
+
+func (a scorecardAppFinder) FindByID(id uint) (*scorecarddomain.ApplicationRef, error) {
+	app, err := a.repo.GetByID(id)
+	if err != nil || app == nil {
+		return nil, err
+	}
+	stages, err := scorecardJobStages{pipelines: a.pipelines}.JobStageKeys(app.CompanyID, app.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return &scorecarddomain.ApplicationRef{
+		ID:        app.ID,
+		CompanyID: app.CompanyID,
+		JobID:     app.JobID,
+		Stage:     app.Stage,
+		Stages:    stages,
+	}, nil
+}
+
+// scorecardJobStages adapta el servicio de pipelines al puerto
+// scorecarddomain.JobStages.
+type scorecardJobStages struct {
+	pipelines *pipelineservice.PipelineService
+}
+
+func (a scorecardJobStages) JobStageKeys(companyID, jobID uint) ([]string, error) {
+	pipeline, err := a.pipelines.Resolve(companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(pipeline.Stages))
+	for i, st := range pipeline.Stages {
+		keys[i] = st.Key
+	}
+	return keys, nil
+}
+
+// interviewAppFinder adapta el repositorio de applications y el pipeline de la
+// vacante al puerto interviewdomain.ApplicationFinder. El email del candidato
+// va vacío si fue anonimizado: no se le envían invitaciones.
+type interviewAppFinder struct {
+	repo      repositories.ApplicationRepository
+	pipelines *pipelineservice.PipelineService
+}
+
+func (a interviewAppFinder) FindByID(id uint) (*interviewdomain.ApplicationRef, error) {
+	app, err := a.repo.GetByID(id)
+	if err != nil || app == nil {
+		return nil, err
+	}
+	stages, err := scorecardJobStages{pipelines: a.pipelines}.JobStageKeys(app.CompanyID, app.JobID)
+	if err != nil {
+		return nil, err
+	}
+	ref := &interviewdomain.ApplicationRef{
+		ID:        app.ID,
+		CompanyID: app.CompanyID,
+		Stage:     app.Stage,
+		Stages:    stages,
+	}
+	if c := app.Candidate; c != nil {
+		ref.CandidateName = strings.TrimSpace(c.FirstName + " " + c.LastName)
+		if c.AnonymizedAt == nil {
+			ref.CandidateEmail = c.Email
+		}
+	}
+	if app.Job != nil {
+		ref.JobTitle = app.Job.Title
+	}
+	if app.Company != nil {
+		ref.CompanyName = app.Company.Name
+		ref.Timezone = app.Company.Timezone
+	}
+	return ref, nil
+}
+
+// interviewMailer adapta el envío de correo al puerto interviewdomain.Mailer:
+// la invitación va como adjunto text/calendar. Se envía en segundo plano para
+// no atar la respuesta al servidor SMTP; los fallos quedan en el log.
+type interviewMailer struct {
+	sender mail.Sender
+}
+
+func (a interviewMailer) SendInvite(to []string, subject, body string, ics []byte, method string) error {
+	msg := mail.Message{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		Attachments: []mail.Attachment{{
+			FileName:    "invite.ics",
+			ContentType: "text/calendar; charset=utf-8; method=" + method,
+			Data:        ics,
+		}},
+	}
+	go func() {
+		if err := a.sender.Send(msg); err != nil {
+			log.Printf("⚠️  No se pudo enviar la invitación %q: %v", subject, err)
+		}
+	}()
+	return nil
+}
+
+func (a interviewMailer) SendEmail(to []string, subject, body string) error {
+	msg := mail.Message{To: to, Subject: subject, Body: body}
+	go func() {
+		if err := a.sender.Send(msg); err != nil {
+			log.Printf("⚠️  No se pudo enviar el correo %q: %v", subject, err)
+		}
+	}()
+	return nil
+}
+
+// offerAppFinder adapta el repositorio de applications al puerto
+// offerdomain.ApplicationFinder: tenant, vacante y su salario máximo.
+type offerAppFinder struct {
+	repo repositories.ApplicationRepository
+}
+
+func (a offerAppFinder) FindByID(id uint) (*offerdomain.ApplicationRef, error) {
+	app, err := a.repo.GetByID(id)
+	if err != nil || app == nil {
+		return nil, err
+	}
+	ref := &offerdomain.ApplicationRef{
+		ID:        app.ID,
+		CompanyID: app.CompanyID,
+		JobID:     app.JobID,
+		Stage:     app.Stage,
+	}
+	if c := app.Candidate; c != nil {
+		ref.CandidateName = strings.TrimSpace(c.FirstName + " " + c.LastName)
+	}
+	if app.Job != nil {
+		ref.JobTitle = app.Job.Title
+		ref.SalaryMax = app.Job.SalaryMax
+		ref.SalaryCurrency = app.Job.SalaryCurrency
+	}
+	if app.Company != nil {
+		ref.Timezone = app.Company.Timezone
+	}
+	return ref, nil
+}
+
+// offerRespondTx adapta la transacción de la base al puerto
+// offerdomain.RespondTransactor: la respuesta de la oferta y el paso a hired
+// de la postulación escriben en la misma transacción. El aviso del cambio de
+// etapa a las automatizaciones sale después del commit.
+type offerRespondTx struct {
+	db           *gorm.DB
+	repo         repositories.ApplicationRepository
+	applications services.ApplicationService
+	automation   *automationservice.AutomationService
+}
+
+func (a offerRespondTx) Transaction(fn func(tx offerdomain.RespondTx) error) error {
+	hirer := &offerHirer{applications: a.applications}
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		hirer.repo = a.repo.WithTx(tx)
+		return fn(offerdomain.RespondTx{Offers: offer.RepositoryTx(tx), Hirer: hirer})
+	})
+	if err == nil && hirer.event != nil {
+		a.automation.Fire(models.AutomationTriggerStageChanged, hirer.hired, hirer.event.FromStage)
+	}
+	return err
+}
+
+// offerHirer adapta el servicio de applications al puerto offerdomain.Hirer
+// dentro de la transacción de offerRespondTx: aceptar una oferta contrata la
+// postulación con HireTx, que queda en el historial (como override si el
+// grafo no llega a hired desde la etapa actual).
+type offerHirer struct {
+	repo         repositories.ApplicationRepository
+	applications services.ApplicationService
+
+	// hired y event son la contratación hecha, para avisar tras el commit.
+	hired *models.Application
+	event *models.ApplicationStageEvent
+}
+
+func (a *offerHirer) Hire(applicationID, actorID uint, reason string) error {
+	var err error
+	a.hired, a.event, err = a.applications.HireTx(a.repo, applicationID, reason, actorID)
+	return err
+}
+
+// automationMover adapta el servicio de applications al puerto
+// automationdomain.StageMover: la acción move_stage respeta el grafo de
+// transiciones; el rechazo de estancadas es un rechazo del sistema (override
+// si el grafo no lo permite). Ambos validan el motivo de rechazo y quedan en
+// el historial sin actor. applications se asigna después de crear el
+// servicio, que a su vez recibe el módulo automation (dependencia circular
+// resuelta en el composition root).
+type automationMover struct {
+	applications services.ApplicationService
+}
+
+func (a *automationMover) Move(applicationID uint, stage, rejectionType, rejectionReason string) error {
+	_, err := a.applications.MoveToStage(applicationID, dtos.MoveApplicationDTO{
+		Stage:           stage,
+		Reason:          "automation",
+		RejectionType:   rejectionType,
+		RejectionReason: rejectionReason,
+	}, 0)
+	return err
+}
+
+func (a *automationMover) Reject(applicationID uint, reason, rejectionType, rejectionReason string) error {
+	_, err := a.applications.SystemReject(applicationID, reason, rejectionType, rejectionReason)
+	return err
+}
+
+// automationTagger adapta el repositorio de tags al puerto
+// automationdomain.Tagger (acción add_tag).
+type automationTagger struct {
+	repo repositories.TagRepository
+}
+
+func (a automationTagger) AddTag(companyID, candidateID uint, name string) error {
+	tags, err := a.repo.FindOrCreateByNames(companyID, []string{name})
+	if err != nil {
+		return err
+	}
+	ids := make([]uint, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	return a.repo.AttachToCandidate(candidateID, ids, nil)
+}
+
+func (a automationTagger) CandidateTags(candidateID uint) ([]string, error) {
+	tags, err := a.repo.CandidateTags(candidateID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+// automationPooler adapta el servicio de talent pools al puerto
+// automationdomain.Pooler (acción add_to_pool).
+type automationPooler struct {
+	pools *talentpoolservice.PoolService
+}
+
+func (a automationPooler) PoolExists(companyID, poolID uint) (bool, error) {
+	return a.pools.Exists(companyID, poolID)
+}
+
+func (a automationPooler) AddToPool(companyID, poolID, candidateID, applicationID uint, note string) error {
+	err := a.pools.AddFromAutomation(companyID, poolID, candidateID, applicationID, note)
+	if errors.Is(err, talentpooldomain.ErrNotContactable) {
+		return automationdomain.ErrNotContactable
+	}
+	return err
+}
+
+// automationMailer adapta el envío de correo al puerto
+// automationdomain.Mailer. Envía en línea: las reglas ya corren en segundo
+// plano y el fallo debe quedar en el registro de la ejecución.
+type automationMailer struct {
+	sender mail.Sender
+}
+
+func (a automationMailer) SendEmail(to []string, subject, body string) error {
+	return a.sender.Send(mail.Message{To: to, Subject: subject, Body: body})
+}
+
+// matchSkills adapta la comparación de habilidades del módulo skill al
+// puerto matchdomain.SkillMatcher.
+type matchSkills struct {
+	skills *skillservice.SkillService
+}
+
+func (a matchSkills) Fits(jobID uint, candidateIDs []uint) (map[uint]matchdomain.SkillFit, error) {
+	reports, err := a.skills.Reports(jobID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	fits := make(map[uint]matchdomain.SkillFit, len(reports))
+	for id, report := range reports {
+		var fit matchdomain.SkillFit
+		for _, g := range report.Gaps {
+			if g.Requirement != models.JobSkillRequired {
+				fit.Nice++
+				switch g.Status {
+				case skilldomain.GapMet:
+					fit.NiceMet++
+				case skilldomain.GapBelow:
+					fit.NiceBelow++
+				}
+				continue
+			}
+			fit.Required++
+			switch g.Status {
+			case skilldomain.GapMet:
+				fit.RequiredMet++
+			case skilldomain.GapBelow:
+				fit.RequiredBelow++
+			default:
+				fit.Missing = append(fit.Missing, g.Name)
+			}
+		}
+		fits[id] = fit
+	}
+	return fits, nil
+}
+
+// publicApplyTx abre la transacción de la postulación por la career page y
+// liga a ella los repos de candidates y applications y los servicios de
+// privacy, comment y screening que escriben en ella.
+type publicApplyTx struct {
+	db           *gorm.DB
+	candidates   repositories.CandidateRepository
+	applications repositories.ApplicationRepository
+	privacy      *privacy.Module
+	comments     *comment.Module
+	screening    *screening.Module
+}
+
+func (a publicApplyTx) Transaction(fn func(tx services.ApplyTx) error) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		return fn(services.ApplyTx{
+			Candidates:   a.candidates.WithTx(tx),
+			Applications: a.applications.WithTx(tx),
+			Consents:     a.privacy.ConsentServiceTx(tx),
+			Notes:        a.comments.ServiceTx(tx),
+			Screening:    a.screening.ServiceTx(tx),
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// extractDOCX lee word/document.xml: un renglón por párrafo, tabulaciones y
+// saltos de línea explícitos. Los enlaces externos (que en el texto suelen
+// decir solo "LinkedIn") se agregan al final, uno por renglón.
+func extractDOCX(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", ErrUnsupported
+	}
+	var document, rels *zip.File
+	for _, f := range archive.File {
+		switch f.Name {
+		case "word/document.xml":
+			document = f
+		case "word/_rels/document.xml.rels":
+			rels = f
+		}
+	}
+	if document == nil {
+		return "", ErrUnsupported
+	}
+
+	var b strings.Builder
+	if err := walkXML(document, func(dec *xml.Decoder, tok xml.Token) error {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				var text string
+				if err := dec.DecodeElement(&text, &t); err != nil {
+					return err
+				}
+				b.WriteString(text)
+			case "tab":
+				b.WriteByte('\t')
+			case "br", "cr":
+				b.WriteByte('\n')
+			}
+		case xml.EndElement:
+			if t.Name.Local == "p" {
+				b.WriteByte('\n')
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	if rels != nil {
+		_ = walkXML(rels, func(_ *xml.Decoder, tok xml.Token) error {
+			if t, ok := tok.(xml.StartElement); ok && t.Name.Local == "Relationship" {
+				var target string
+				external := false
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "Target":
+						target = attr.Value
+					case "TargetMode":
+						external = attr.Value == "External"
+					}
+				}
+				if external && strings.HasPrefix(target, "http") {
+					b.WriteString(target + "\n")
+				}
+			}
+			return nil
+		})
+	}
+	return b.String(), nil
+}
+
+// walkXML recorre los tokens de una parte del archivo.
+func walkXML(f *zip.File, visit func(*xml.Decoder, xml.Token) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(io.LimitReader(rc, maxDecoded))
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := visit(dec, tok); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,513 @@
+package textextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pdfObject es un objeto indirecto: su valor y, si es un stream, los datos
+// todavía sin decodificar.
+type pdfObject struct {
+	value  interface{}
+	stream []byte
+}
+
+type pdfDocument struct {
+	objects map[int]*pdfObject
+	fonts   map[int]*pdfFont
+	out     strings.Builder
+	last    byte // último byte escrito en out
+}
+
+// objHeader encuentra los objetos indirectos ("12 0 obj") recorriendo el
+// archivo: no depende de la tabla xref, que suele estar rota en archivos
+// editados o truncados. Si un número aparece dos veces gana el último
+// (actualizaciones incrementales).
+var objHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// maxFormDepth acota los Form XObjects anidados.
+const maxFormDepth = 8
+
+func extractPDF(data []byte) (string, error) {
+	doc := &pdfDocument{objects: map[int]*pdfObject{}, fonts: map[int]*pdfFont{}}
+	doc.scanObjects(data)
+	if doc.encrypted(data) {
+		return "", ErrEncrypted
+	}
+	doc.expandObjectStreams()
+
+	for _, page := range doc.pages() {
+		doc.runContent(doc.pageContent(page.dict), page.resources, 0)
+		doc.newline()
+	}
+	doc.appendLinks()
+	return doc.out.String(), nil
+}
+
+func (d *pdfDocument) scanObjects(data []byte) {
+	for _, m := range objHeader.FindAllSubmatchIndex(data, -1) {
+		num := atoi(data[m[2]:m[3]])
+		lex := &pdfLexer{data: data, pos: m[1]}
+		value, ok := lex.value(0)
+		if !ok {
+			continue
+		}
+		obj := &pdfObject{value: value}
+		save := lex.pos
+		if tok, ok := lex.token(); ok && tok == pdfKeyword("stream") {
+			start := lex.pos
+			if start < len(data) && data[start] == '\r' {
+				start++
+			}
+			if start < len(data) && data[start] == '\n' {
+				start++
+			}
+			end := bytes.Index(data[start:], []byte("endstream"))
+			if end < 0 {
+				end = len(data) - start
+			}
+			obj.stream = bytes.TrimRight(data[start:start+end], "\r\n")
+		} else {
+			lex.pos = save
+		}
+		d.objects[num] = obj
+	}
+}
+
+// encrypted reporta si algún trailer (o stream de xref) declara /Encrypt.
+func (d *pdfDocument) encrypted(data []byte) bool {
+	for _, obj := range d.objects {
+		if dict, ok := obj.value.(pdfDict); ok && dict["Type"] == pdfName("XRef") && dict["Encrypt"] != nil {
+			return true
+		}
+	}
+	for i := bytes.Index(data, []byte("trailer")); i >= 0; {
+		lex := &pdfLexer{data: data, pos: i + len("trailer")}
+		if v, ok := lex.value(0); ok {
+			if dict, ok := v.(pdfDict); ok && dict["Encrypt"] != nil {
+				return true
+			}
+		}
+		next := bytes.Index(data[i+1:], []byte("trailer"))
+		if next < 0 {
+			break
+		}
+		i += 1 + next
+	}
+	return false
+}
+
+// expandObjectStreams agrega los objetos comprimidos dentro de streams
+// /ObjStm (PDF 1.5+), salvo los que ya existen como objetos directos.
+func (d *pdfDocument) expandObjectStreams() {
+	var streams []*pdfObject
+	for _, obj := range d.objects {
+		if dict, ok := obj.value.(pdfDict); ok && dict["Type"] == pdfName("ObjStm") {
+			streams = append(streams, obj)
+		}
+	}
+	for _, obj := range streams {
+		dict := obj.value.(pdfDict)
+		data := d.decode(obj)
+		n, first := int(d.number(dict["N"])), int(d.number(dict["First"]))
+		if data == nil || first <= 0 || first > len(data) {
+			continue
+		}
+		header := &pdfLexer{data: data[:first]}
+		for i := 0; i < n; i++ {
+			numTok, ok1 := header.token()
+			offTok, ok2 := header.token()
+			num, isNum := numTok.(float64)
+			off, isOff := offTok.(float64)
+			if !ok1 || !ok2 || !isNum || !isOff {
+				break
+			}
+			if _, exists := d.objects[int(num)]; exists {
+				continue
+			}
+			pos := first + int(off)
+			if pos < 0 || pos >= len(data) {
+				continue
+			}
+			lex := &pdfLexer{data: data, pos: pos}
+			if value, ok := lex.value(0); ok {
+				d.objects[int(num)] = &pdfObject{value: value}
+			}
+		}
+	}
+}
+
+// resolve sigue una referencia (una sola vez por nivel; las cadenas de
+// referencias son raras).
+func (d *pdfDocument) resolve(v interface{}) interface{} {
+	for i := 0; i < 4; i++ {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		obj := d.objects[ref.num]
+		if obj == nil {
+			return nil
+		}
+		v = obj.value
+	}
+	return v
+}
+
+func (d *pdfDocument) dict(v interface{}) pdfDict {
+	dict, _ := d.resolve(v).(pdfDict)
+	return dict
+}
+
+func (d *pdfDocument) number(v interface{}) float64 {
+	n, _ := d.resolve(v).(float64)
+	return n
+}
+
+// stream devuelve los datos decodificados del stream al que apunta v.
+func (d *pdfDocument) stream(v interface{}) []byte {
+	ref, ok := v.(pdfRef)
+	if !ok {
+		return nil
+	}
+	obj := d.objects[ref.num]
+	if obj == nil || obj.stream == nil {
+		return nil
+	}
+	return d.decode(obj)
+}
+
+// decode aplica los filtros del stream. Solo entiende FlateDecode (el único
+// que se usa para texto en la práctica); con otros filtros devuelve nil.
+func (d *pdfDocument) decode(obj *pdfObject) []byte {
+	dict, _ := obj.value.(pdfDict)
+	var filters []interface{}
+	switch f := d.resolve(dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{f}
+	case pdfArray:
+		filters = f
+	}
+	data := obj.stream
+	for _, f := range filters {
+		switch d.resolve(f) {
+		case pdfName("FlateDecode"), pdfName("Fl"):
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil
+			}
+			// Un stream truncado conserva lo que se pudo leer.
+			decoded, _ := io.ReadAll(io.LimitReader(r, maxDecoded))
+			r.Close()
+			data = decoded
+		default:
+			return nil
+		}
+	}
+	return data
+}
+
+// pdfPage es una página con los recursos que hereda de sus ancestros.
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages recorre el árbol de páginas desde el catálogo. Si no lo encuentra,
+// usa las páginas sueltas en orden de número de objeto.
+func (d *pdfDocument) pages() []pdfPage {
+	var pages []pdfPage
+	seen := map[int]bool{}
+	var walk func(v interface{}, inherited pdfDict, depth int)
+	walk = func(v interface{}, inherited pdfDict, depth int) {
+		if ref, ok := v.(pdfRef); ok {
+			if seen[ref.num] {
+				return
+			}
+			seen[ref.num] = true
+		}
+		node := d.dict(v)
+		if node == nil || depth > maxDepth {
+			return
+		}
+		resources := inherited
+		if r := d.dict(node["Resources"]); r != nil {
+			resources = r
+		}
+		if node["Type"] == pdfName("Page") || (node["Kids"] == nil && node["Contents"] != nil) {
+			pages = append(pages, pdfPage{dict: node, resources: resources})
+			return
+		}
+		if kids, ok := d.resolve(node["Kids"]).(pdfArray); ok {
+			for _, kid := range kids {
+				walk(kid, resources, depth+1)
+			}
+		}
+	}
+
+	for _, num := range d.sortedNums() {
+		if dict, ok := d.objects[num].value.(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+			walk(dict["Pages"], nil, 0)
+			if len(pages) > 0 {
+				return pages
+			}
+		}
+	}
+	for _, num := range d.sortedNums() {
+		if dict, ok := d.objects[num].value.(pdfDict); ok && dict["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: dict, resources: d.dict(dict["Resources"])})
+		}
+	}
+	return pages
+}
+
+func (d *pdfDocument) sortedNums() []int {
+	nums := make([]int, 0, len(d.objects))
+	for num := range d.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// pageContent concatena los content streams de la página.
+func (d *pdfDocument) pageContent(page pdfDict) []byte {
+	switch c := page["Contents"].(type) {
+	case pdfRef:
+		if arr, ok := d.resolve(c).(pdfArray); ok {
+			return d.concat(arr)
+		}
+		return d.stream(c)
+	case pdfArray:
+		return d.concat(c)
+	}
+	return nil
+}
+
+func (d *pdfDocument) concat(refs pdfArray) []byte {
+	var b bytes.Buffer
+	for _, ref := range refs {
+		b.Write(d.stream(ref))
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// textState sigue la posición del texto para inferir espacios y saltos de
+// línea (solo la traslación; rotaciones y escalas se ignoran).
+type textState struct {
+	font       *pdfFont
+	x, y       float64 // inicio de la línea actual
+	lastY      float64
+	hasLast    bool
+	moved      bool    // hubo un desplazamiento desde el último texto
+	fontSize   float64 // para decidir si un salto horizontal es un espacio
+	leading    float64
+	horizontal float64 // desplazamiento horizontal pendiente
+}
+
+// runContent interpreta un content stream y agrega su texto a la salida.
+func (d *pdfDocument) runContent(content []byte, resources pdfDict, depth int) {
+	if len(content) == 0 || depth > maxFormDepth {
+		return
+	}
+	lex := &pdfLexer{data: content}
+	st := &textState{fontSize: 10}
+	var operands []interface{}
+	for {
+		tok, ok := lex.token()
+		if !ok {
+			return
+		}
+		op, isOp := tok.(pdfKeyword)
+		if !isOp {
+			if v, ok := lex.build(tok, 0); ok {
+				operands = append(operands, v)
+			}
+			continue
+		}
+		switch op {
+		case "BT":
+			st.x, st.y = 0, 0
+			st.moved = true
+		case "Tf":
+			if len(operands) >= 2 {
+				name, _ := operands[len(operands)-2].(pdfName)
+				st.font = d.font(resources, name)
+				if size, ok := operands[len(operands)-1].(float64); ok && size != 0 {
+					st.fontSize = math.Abs(size)
+				}
+			}
+		case "TL":
+			st.leading = lastNumber(operands)
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				tx, _ := operands[len(operands)-2].(float64)
+				ty, _ := operands[len(operands)-1].(float64)
+				if op == "TD" {
+					st.leading = -ty
+				}
+				st.x += tx
+				st.y += ty
+				st.horizontal = tx
+				st.moved = true
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				e, _ := operands[len(operands)-2].(float64)
+				f, _ := operands[len(operands)-1].(float64)
+				st.horizontal = e - st.x
+				st.x, st.y = e, f
+				st.moved = true
+			}
+		case "T*":
+			st.y -= st.leading
+			st.moved = true
+		case "Tj":
+			d.show(st, lastOperand(operands))
+		case "'", "\"":
+			st.y -= st.leading
+			st.moved = true
+			d.show(st, lastOperand(operands))
+		case "TJ":
+			if arr, ok := lastOperand(operands).(pdfArray); ok {
+				for _, item := range arr {
+					switch v := item.(type) {
+					case pdfString:
+						d.show(st, v)
+					case float64:
+						// Un ajuste negativo grande (milésimas de em) es un
+						// espacio entre palabras.
+						if v < -180 {
+							d.space()
+						}
+					}
+				}
+			}
+		case "Do":
+			if name, ok := lastOperand(operands).(pdfName); ok {
+				d.form(resources, name, depth)
+			}
+		case "ID":
+			lex.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+}
+
+// show escribe un string del content stream decodificado con la fuente
+// actual, precedido del espacio o salto de línea que corresponda.
+func (d *pdfDocument) show(st *textState, v interface{}) {
+	s, ok := v.(pdfString)
+	if !ok {
+		return
+	}
+	text := st.font.decode(s)
+	if text == "" {
+		return
+	}
+	if st.moved {
+		switch {
+		case st.hasLast && math.Abs(st.y-st.lastY) > st.fontSize*0.5:
+			d.newline()
+		case st.hasLast && st.horizontal > st.fontSize*0.15:
+			d.space()
+		}
+		st.moved = false
+		st.horizontal = 0
+	}
+	st.lastY = st.y
+	st.hasLast = true
+	d.write(text)
+}
+
+func (d *pdfDocument) write(text string) {
+	if text != "" {
+		d.out.WriteString(text)
+		d.last = text[len(text)-1]
+	}
+}
+
+func (d *pdfDocument) space() {
+	if d.out.Len() > 0 && d.last != ' ' && d.last != '\n' {
+		d.write(" ")
+	}
+}
+
+func (d *pdfDocument) newline() {
+	if d.out.Len() > 0 && d.last != '\n' {
+		d.write("\n")
+	}
+}
+
+// form interpreta un Form XObject (contenido reutilizable, p. ej. plantillas
+// de página) con sus propios recursos.
+func (d *pdfDocument) form(resources pdfDict, name pdfName, depth int) {
+	xobjects := d.dict(resources["XObject"])
+	ref := xobjects[name]
+	obj := d.dict(ref)
+	if obj == nil || obj["Subtype"] != pdfName("Form") {
+		return
+	}
+	formResources := d.dict(obj["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	d.newline()
+	d.runContent(d.stream(ref), formResources, depth+1)
+	d.newline()
+}
+
+// appendLinks agrega al final las URLs de las anotaciones de enlace (un CV
+// suele mostrar "LinkedIn" con el enlace detrás).
+func (d *pdfDocument) appendLinks() {
+	seen := map[string]bool{}
+	for _, num := range d.sortedNums() {
+		dict, ok := d.objects[num].value.(pdfDict)
+		if !ok {
+			continue
+		}
+		action := d.dict(dict["A"])
+		if action == nil {
+			action = dict
+		}
+		uri, ok := d.resolve(action["URI"]).(pdfString)
+		if !ok {
+			continue
+		}
+		link := strings.TrimSpace(string(uri))
+		if strings.HasPrefix(link, "http") && !seen[link] {
+			seen[link] = true
+			d.newline()
+			d.write(link)
+		}
+	}
+}
+
+func lastOperand(operands []interface{}) interface{} {
+	if len(operands) == 0 {
+		return nil
+	}
+	return operands[len(operands)-1]
+}
+
+func lastNumber(operands []interface{}) float64 {
+	n, _ := lastOperand(operands).(float64)
+	return n
+}
+
+func atoi(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n*10 + int(c-'0')
+		if n > math.MaxInt32 {
+			return -1
+		}
+	}
+	return n
+}
@@ -0,0 +1,280 @@
+package textextract
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// pdfFont traduce los códigos de un string del content stream a texto.
+// Con ToUnicode se usa su CMap; sin ella, las fuentes simples se leen como
+// WinAnsi (con las /Differences que declare la fuente) y las compuestas
+// (Type0) no se pueden leer.
+type pdfFont struct {
+	cmap        map[uint32]string
+	codeLen     int
+	composite   bool
+	differences map[byte]string
+}
+
+// maxCMapRange acota cada bfrange (una CMap real no pasa de unos miles).
+const maxCMapRange = 1 << 16
+
+// font devuelve la fuente name de los recursos, leyéndola una sola vez por
+// objeto.
+func (d *pdfDocument) font(resources pdfDict, name pdfName) *pdfFont {
+	ref := d.dict(resources["Font"])[name]
+	r, isRef := ref.(pdfRef)
+	if isRef {
+		if f, ok := d.fonts[r.num]; ok {
+			return f
+		}
+	}
+	dict := d.dict(ref)
+	if dict == nil {
+		return nil
+	}
+	f := &pdfFont{composite: dict["Subtype"] == pdfName("Type0"), codeLen: 1}
+	if f.composite {
+		f.codeLen = 2
+	}
+	if cmap := d.stream(dict["ToUnicode"]); cmap != nil {
+		f.parseCMap(cmap)
+	}
+	if enc := d.dict(dict["Encoding"]); enc != nil {
+		f.parseDifferences(d, enc)
+	}
+	if isRef {
+		d.fonts[r.num] = f
+	}
+	return f
+}
+
+func (f *pdfFont) decode(s pdfString) string {
+	if f == nil {
+		return decodeWinAnsi(s, nil)
+	}
+	if f.cmap != nil {
+		var b strings.Builder
+		for i := 0; i+f.codeLen <= len(s); i += f.codeLen {
+			var code uint32
+			for _, c := range s[i : i+f.codeLen] {
+				code = code<<8 | uint32(c)
+			}
+			b.WriteString(f.cmap[code])
+		}
+		return b.String()
+	}
+	if f.composite {
+		return ""
+	}
+	return decodeWinAnsi(s, f.differences)
+}
+
+// parseCMap lee los bfchar y bfrange de una CMap ToUnicode.
+func (f *pdfFont) parseCMap(data []byte) {
+	f.cmap = map[uint32]string{}
+	lex := &pdfLexer{data: data}
+	var operands []interface{}
+	mode := ""
+	for {
+		tok, ok := lex.token()
+		if !ok {
+			return
+		}
+		if kw, isKw := tok.(pdfKeyword); isKw {
+			switch kw {
+			case "begincodespacerange", "beginbfchar", "beginbfrange":
+				mode = string(kw)
+			case "endcodespacerange":
+				for i := 0; i+1 < len(operands); i += 2 {
+					if lo, ok := operands[i].(pdfString); ok && len(lo) > 0 {
+						f.codeLen = len(lo)
+					}
+				}
+				mode = ""
+			case "endbfchar":
+				for i := 0; i+1 < len(operands); i += 2 {
+					src, ok1 := operands[i].(pdfString)
+					dst, ok2 := operands[i+1].(pdfString)
+					if ok1 && ok2 {
+						f.cmap[codeOf(src)] = utf16BE(dst)
+					}
+				}
+				mode = ""
+			case "endbfrange":
+				for i := 0; i+2 < len(operands); i += 3 {
+					lo, ok1 := operands[i].(pdfString)
+					hi, ok2 := operands[i+1].(pdfString)
+					if !ok1 || !ok2 {
+						continue
+					}
+					start, end := codeOf(lo), codeOf(hi)
+					if end < start || end-start > maxCMapRange {
+						continue
+					}
+					switch dst := operands[i+2].(type) {
+					case pdfString:
+						units := utf16.Decode(toUint16(dst))
+						for code := start; code <= end; code++ {
+							if len(units) > 0 {
+								shifted := append([]rune{}, units...)
+								shifted[len(shifted)-1] += rune(code - start)
+								f.cmap[code] = string(shifted)
+							}
+						}
+					case pdfArray:
+						for j, item := range dst {
+							if s, ok := item.(pdfString); ok && start+uint32(j) <= end {
+								f.cmap[start+uint32(j)] = utf16BE(s)
+							}
+						}
+					}
+				}
+				mode = ""
+			}
+			operands = operands[:0]
+			continue
+		}
+		if mode == "" {
+			continue
+		}
+		if v, ok := lex.build(tok, 0); ok {
+			operands = append(operands, v)
+		}
+	}
+}
+
+// parseDifferences lee el /Differences de una fuente simple: desde cada
+// número, los nombres de glifo reemplazan los códigos siguientes.
+func (f *pdfFont) parseDifferences(d *pdfDocument, enc pdfDict) {
+	diffs, ok := d.resolve(enc["Differences"]).(pdfArray)
+	if !ok {
+		return
+	}
+	f.differences = map[byte]string{}
+	code := 0
+	for _, item := range diffs {
+		switch v := item.(type) {
+		case float64:
+			code = int(v)
+		case pdfName:
+			if code >= 0 && code < 256 {
+				if s := glyphText(string(v)); s != "" {
+					f.differences[byte(code)] = s
+				}
+			}
+			code++
+		}
+	}
+}
+
+func codeOf(b []byte) uint32 {
+	var code uint32
+	for _, c := range b {
+		code = code<<8 | uint32(c)
+	}
+	return code
+}
+
+func toUint16(b []byte) []uint16 {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+func utf16BE(b []byte) string {
+	return string(utf16.Decode(toUint16(b)))
+}
+
+// winAnsiHigh son los caracteres de 0x80-0x9F en WinAnsiEncoding (cp1252);
+// el resto del rango alto coincide con Latin-1.
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x84: '„', 0x85: '…', 0x91: '‘', 0x92: '’',
+	0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x99: '™',
+}
+
+func decodeWinAnsi(s []byte, differences map[byte]string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if text, ok := differences[c]; ok {
+			b.WriteString(text)
+			continue
+		}
+		switch {
+		case c >= 0x80 && c <= 0x9F:
+			if r, ok := winAnsiHigh[c]; ok {
+				b.WriteRune(r)
+			}
+		case c < 0x20 && c != '\t' && c != '\n':
+			// códigos de control: sin texto
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
+
+// accentedGlyphs son los nombres de glifo de las letras acentuadas de
+// español y portugués.
+var accentedGlyphs = map[string]rune{
+	"aacute": 'á', "eacute": 'é', "iacute": 'í', "oacute": 'ó', "uacute": 'ú',
+	"Aacute": 'Á', "Eacute": 'É', "Iacute": 'Í', "Oacute": 'Ó', "Uacute": 'Ú',
+	"agrave": 'à', "Agrave": 'À', "acircumflex": 'â', "ecircumflex": 'ê', "ocircumflex": 'ô',
+	"Acircumflex": 'Â', "Ecircumflex": 'Ê', "Ocircumflex": 'Ô', "atilde": 'ã', "otilde": 'õ',
+	"Atilde": 'Ã', "Otilde": 'Õ', "ntilde": 'ñ', "Ntilde": 'Ñ', "udieresis": 'ü',
+	"Udieresis": 'Ü', "ccedilla": 'ç', "Ccedilla": 'Ç',
+}
+
+// glyphText traduce un nombre de glifo de Adobe a texto: letras y dígitos
+// sueltos, uniXXXX, los acentuados y algunos signos.
+func glyphText(name string) string {
+	if len(name) == 1 {
+		return name
+	}
+	if strings.HasPrefix(name, "uni") && len(name) == 7 {
+		if v, err := strconv.ParseUint(name[3:], 16, 32); err == nil {
+			return string(rune(v))
+		}
+	}
+	switch name {
+	case "space":
+		return " "
+	case "hyphen", "endash", "emdash":
+		return "-"
+	case "period":
+		return "."
+	case "comma":
+		return ","
+	case "colon":
+		return ":"
+	case "at":
+		return "@"
+	case "slash":
+		return "/"
+	case "parenleft":
+		return "("
+	case "parenright":
+		return ")"
+	case "bullet":
+		return "•"
+	case "quoteright":
+		return "’"
+	case "fi":
+		return "fi"
+	case "fl":
+		return "fl"
+	}
+	digits := []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+	for i, digit := range digits {
+		if name == digit {
+			return strconv.Itoa(i)
+		}
+	}
+	if r, ok := accentedGlyphs[name]; ok {
+		return string(r)
+	}
+	return ""
+}
@@ -0,0 +1,282 @@
+package textextract
+
+import "strconv"
+
+// Valores de la sintaxis PDF que entiende el extractor. Los números son
+// float64, los booleanos y null quedan como keyword.
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfString  []byte
+	pdfArray   []interface{}
+	pdfDict    map[pdfName]interface{}
+	pdfRef     struct{ num, gen int }
+)
+
+// Marcas de estructura que devuelve el lexer.
+type pdfDelim byte
+
+const (
+	delimArrayStart pdfDelim = '['
+	delimArrayEnd   pdfDelim = ']'
+	delimDictStart  pdfDelim = '<'
+	delimDictEnd    pdfDelim = '>'
+)
+
+// maxDepth acota el anidamiento de arrays y diccionarios.
+const maxDepth = 64
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelim(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token devuelve el siguiente token: un valor simple (número, nombre,
+// string, keyword) o un pdfDelim. ok=false al final de los datos.
+func (l *pdfLexer) token() (interface{}, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		l.pos++
+		return l.name(), true
+	case c == '(':
+		l.pos++
+		return l.literal(), true
+	case c == '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return delimDictStart, true
+		}
+		l.pos++
+		return l.hex(), true
+	case c == '>':
+		l.pos++
+		if l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+		}
+		return delimDictEnd, true
+	case c == '[':
+		l.pos++
+		return delimArrayStart, true
+	case c == ']':
+		l.pos++
+		return delimArrayEnd, true
+	case c == '{' || c == '}' || c == ')':
+		l.pos++
+		return pdfKeyword(c), true
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := l.data[start:l.pos]
+	if n, err := strconv.ParseFloat(string(word), 64); err == nil && (word[0] == '-' || word[0] == '+' || word[0] == '.' || (word[0] >= '0' && word[0] <= '9')) {
+		return n, true
+	}
+	return pdfKeyword(word), true
+}
+
+func (l *pdfLexer) name() pdfName {
+	var b []byte
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return pdfName(b)
+}
+
+func (l *pdfLexer) literal() pdfString {
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return b
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return b
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func (l *pdfLexer) hex() pdfString {
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		c := l.data[l.pos]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // '>'
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	b := make([]byte, len(digits)/2)
+	for i := range b {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		b[i] = byte(v)
+	}
+	return b
+}
+
+// value lee un valor completo: arrays y diccionarios se arman recursivamente
+// y "n g R" se convierte en pdfRef.
+func (l *pdfLexer) value(depth int) (interface{}, bool) {
+	tok, ok := l.token()
+	if !ok {
+		return nil, false
+	}
+	return l.build(tok, depth)
+}
+
+func (l *pdfLexer) build(tok interface{}, depth int) (interface{}, bool) {
+	if depth > maxDepth {
+		return nil, false
+	}
+	switch t := tok.(type) {
+	case pdfDelim:
+		switch t {
+		case delimArrayStart:
+			arr := pdfArray{}
+			for {
+				next, ok := l.token()
+				if !ok || next == delimArrayEnd {
+					return arr, true
+				}
+				v, ok := l.build(next, depth+1)
+				if !ok {
+					return arr, true
+				}
+				arr = append(arr, v)
+			}
+		case delimDictStart:
+			dict := pdfDict{}
+			for {
+				next, ok := l.token()
+				if !ok || next == delimDictEnd {
+					return dict, true
+				}
+				key, isName := next.(pdfName)
+				if !isName {
+					continue
+				}
+				v, ok := l.value(depth + 1)
+				if !ok {
+					return dict, true
+				}
+				dict[key] = v
+			}
+		}
+		return t, true
+	case float64:
+		// ¿Es una referencia "num gen R"?
+		save := l.pos
+		if gen, ok := l.token(); ok {
+			if g, isNum := gen.(float64); isNum {
+				if r, ok := l.token(); ok && r == pdfKeyword("R") {
+					return pdfRef{num: int(t), gen: int(g)}, true
+				}
+			}
+		}
+		l.pos = save
+		return t, true
+	}
+	return tok, true
+}
+
+// skipInlineImage salta los datos binarios de una imagen inline (entre ID y
+// EI) de un content stream.
+func (l *pdfLexer) skipInlineImage() {
+	for i := l.pos; i+2 < len(l.data); i++ {
+		if isPDFSpace(l.data[i]) && l.data[i+1] == 'E' && l.data[i+2] == 'I' &&
+			(i+3 == len(l.data) || isPDFSpace(l.data[i+3]) || isPDFDelim(l.data[i+3])) {
+			l.pos = i + 3
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
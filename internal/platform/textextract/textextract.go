@@ -0,0 +1,89 @@
+// Package textextract obtiene el texto plano de documentos PDF y DOCX sin
+// dependencias externas, para indexarlo o analizarlo (p. ej. el CV de un
+// candidato). Es best-effort: conserva el orden de lectura y los saltos de
+// línea que se pueden inferir, no el formato. No hace OCR: un PDF escaneado
+// (solo imágenes) devuelve ErrNoText.
+package textextract
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// MaxTextLength acota el texto devuelto (en runas); un CV real queda muy por
+// debajo.
+const MaxTextLength = 200000
+
+// maxDecoded acota lo que se descomprime de cada stream o parte del archivo
+// (evita bombas de compresión).
+const maxDecoded = 20 << 20
+
+var (
+	// ErrUnsupported: el formato no es PDF ni DOCX (p. ej. un .doc binario).
+	ErrUnsupported = errors.New("unsupported document format (only PDF and DOCX can be read)")
+	// ErrEncrypted: el PDF está cifrado.
+	ErrEncrypted = errors.New("the PDF is encrypted")
+	// ErrNoText: el documento no tiene texto (p. ej. un PDF escaneado).
+	ErrNoText = errors.New("the document has no extractable text (scanned image?)")
+)
+
+// Extract devuelve el texto del documento; el formato se detecta por su
+// contenido, no por la extensión.
+func Extract(data []byte) (string, error) {
+	var text string
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		text, err = extractPDF(data)
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		text, err = extractDOCX(data)
+	default:
+		return "", ErrUnsupported
+	}
+	if err != nil {
+		return "", err
+	}
+	text = normalize(text)
+	if strings.TrimSpace(text) == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+// ligatures son los caracteres de ligadura que algunos PDFs mapean tal cual,
+// más el guion blando (invisible) que cortan algunos procesadores de texto.
+var ligatures = strings.NewReplacer("ﬀ", "ff", "ﬁ", "fi", "ﬂ", "fl", "ﬃ", "ffi", "ﬄ", "ffl", "\u00ad", "")
+
+// normalize deja una línea por renglón, sin espacios repetidos ni líneas
+// vacías consecutivas, y recorta a MaxTextLength.
+func normalize(text string) string {
+	text = ligatures.Replace(text)
+	var b strings.Builder
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || (unicode.IsControl(r) && r != '\t')
+		}), " ")
+		if line == "" {
+			if b.Len() > 0 {
+				blank = true
+			}
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+			if blank {
+				b.WriteByte('\n')
+			}
+		}
+		blank = false
+		b.WriteString(line)
+	}
+	out := b.String()
+	if runes := []rune(out); len(runes) > MaxTextLength {
+		out = string(runes[:MaxTextLength])
+	}
+	return out
+}
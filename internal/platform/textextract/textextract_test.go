@@ -0,0 +1,136 @@
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"dvra-api/internal/platform/pdf"
+)
+
+func TestExtractGeneratedPDF(t *testing.T) {
+	doc := pdf.New("CV")
+	doc.Heading("María Peña")
+	doc.Paragraph("Ingeniera de software (Go, PostgreSQL)\nmaria@example.com")
+	text, err := Extract(doc.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "María Peña\nIngeniera de software (Go, PostgreSQL)\nmaria@example.com"
+	if text != want {
+		t.Fatalf("got %q, want %q", text, want)
+	}
+}
+
+// rawPDF arma un PDF mínimo con los objetos dados (sin xref: el extractor
+// no la usa).
+func rawPDF(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+	for i, body := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+func flateStream(dict string, data string) string {
+	var z bytes.Buffer
+	w := zlib.NewWriter(&z)
+	w.Write([]byte(data))
+	w.Close()
+	return fmt.Sprintf("<< %s /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream", dict, z.Len(), z.String())
+}
+
+func TestExtractCompositeFontWithToUnicode(t *testing.T) {
+	cmap := `/CIDInit /ProcSet findresource begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar <0001> <0053> <0002> <00E3> endbfchar
+1 beginbfrange <0003> <0005> <006F> endbfrange
+endcmap`
+	// Códigos 1-5 = "S", "ã", "o", "p", "q"; el ajuste -250 es un espacio.
+	content := "BT /F1 12 Tf 72 700 Td [<0001><0002><0003>-250<00030004>] TJ 0 -20 Td <0005> Tj ET"
+	data := rawPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 6 0 R /Annots [7 0 R] >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /ABC /ToUnicode 5 0 R >>",
+		flateStream("", cmap),
+		flateStream("", content),
+		"<< /Type /Annot /Subtype /Link /A << /S /URI /URI (https://www.linkedin.com/in/joao) >> >>",
+	)
+	text, err := Extract(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "São op\nq\nhttps://www.linkedin.com/in/joao"
+	if text != want {
+		t.Fatalf("got %q, want %q", text, want)
+	}
+}
+
+func TestExtractPDFErrors(t *testing.T) {
+	encrypted := append(rawPDF("<< /Type /Catalog >>"), []byte("trailer\n<< /Encrypt 9 0 R >>\n")...)
+	if _, err := Extract(encrypted); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("encrypted: got %v", err)
+	}
+	scanned := rawPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+		flateStream("", "q 595 0 0 842 0 0 cm /Im1 Do Q"),
+	)
+	if _, err := Extract(scanned); !errors.Is(err, ErrNoText) {
+		t.Errorf("scanned: got %v", err)
+	}
+	if _, err := Extract([]byte("\xd0\xcf\x11\xe0 old word file")); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("doc: got %v", err)
+	}
+}
+
+func TestExtractDOCX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Ana</w:t></w:r><w:r><w:t xml:space="preserve"> Gómez</w:t></w:r></w:p>
+<w:p></w:p>
+<w:p><w:r><w:t>Experiencia</w:t><w:tab/><w:t>2019 - Presente</w:t><w:br/><w:t>Backend &amp; APIs</w:t></w:r></w:p>
+</w:body></w:document>`,
+		"word/_rels/document.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="styles" Target="styles.xml"/>
+<Relationship Id="rId2" Type="hyperlink" Target="https://github.com/anagomez" TargetMode="External"/>
+</Relationships>`,
+	}
+	for name, body := range files {
+		w, _ := zw.Create(name)
+		w.Write([]byte(body))
+	}
+	zw.Close()
+
+	text, err := Extract(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Ana Gómez\n\nExperiencia 2019 - Presente\nBackend & APIs\nhttps://github.com/anagomez"
+	if text != want {
+		t.Fatalf("got %q, want %q", text, want)
+	}
+}
+
+func TestNormalizeTruncates(t *testing.T) {
+	text := normalize("  o\ufb03ce  \n\n\n\nsoft\u00adware " + strings.Repeat("á", MaxTextLength))
+	if !strings.HasPrefix(text, "office\n\nsoftware ") {
+		t.Fatalf("unexpected prefix %q", text[:20])
+	}
+	if n := len([]rune(text)); n != MaxTextLength {
+		t.Fatalf("got %d runes", n)
+	}
+}
@@ -0,0 +1,39 @@
+// Package uploads es el almacenamiento local de los archivos subidos (CVs,
+// adjuntos de comentarios y PDFs generados), servidos bajo /uploads/. Los
+// módulos no lo importan: definen su propio puerto y el composition root lo
+// adapta.
+package uploads
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Store guarda los archivos bajo un directorio raíz.
+type Store struct {
+	dir string
+}
+
+// New devuelve el almacenamiento con raíz dir.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Remove elimina los archivos por su ruta relativa a la raíz (StoragePath, o
+// la URL sin el prefijo /uploads/). Un archivo que ya no existe no es error y
+// una ruta que sale de la raíz se ignora. Sigue con el resto si uno falla y
+// devuelve los errores juntos.
+func (s *Store) Remove(paths []string) error {
+	var errs []error
+	for _, p := range paths {
+		rel := filepath.FromSlash(p)
+		if !filepath.IsLocal(rel) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, rel)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,35 @@
+package uploads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemove(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+	if err := os.MkdirAll(filepath.Join(dir, "comments"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(root, "secret.txt")
+	for _, f := range []string{filepath.Join(dir, "cv.pdf"), filepath.Join(dir, "comments", "a.png"), outside} {
+		if err := os.WriteFile(f, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := New(dir).Remove([]string{"cv.pdf", "comments/a.png", "missing.pdf", "", "../secret.txt", outside})
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	for _, gone := range []string{"cv.pdf", "comments/a.png"} {
+		if _, err := os.Stat(filepath.Join(dir, gone)); !os.IsNotExist(err) {
+			t.Errorf("%s sigue en disco: %v", gone, err)
+		}
+	}
+	// Nada fuera de la raíz se toca.
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("se borró un archivo fuera de uploads: %v", err)
+	}
+}
@@ -19,6 +19,12 @@ func NotFound(msg string) *AppError   { return &AppError{Code: http.StatusNotFou
 func Conflict(msg string) *AppError   { return &AppError{Code: http.StatusConflict, Message: msg} }
 func BadRequest(msg string) *AppError { return &AppError{Code: http.StatusBadRequest, Message: msg} }
 func Forbidden(msg string) *AppError  { return &AppError{Code: http.StatusForbidden, Message: msg} }
+
+// Unprocessable señala una petición bien formada que las reglas de negocio no
+// permiten en el estado actual del recurso (p. ej. una transición de etapa ilegal).
+func Unprocessable(msg string) *AppError {
+	return &AppError{Code: http.StatusUnprocessableEntity, Message: msg}
+}
 func Unauthorized(msg string) *AppError {
 	return &AppError{Code: http.StatusUnauthorized, Message: msg}
 }
@@ -5,6 +5,9 @@
 package authctx
 
 import (
+	"net/http"
+	"strconv"
+
 	"dvra-api/internal/shared/permissions"
 
 	"github.com/gin-gonic/gin"
@@ -53,3 +56,32 @@ func CompanyID(c *gin.Context) (uint, bool) {
 	id, ok := v.(uint)
 	return id, ok
 }
+
+// TenantScope devuelve la empresa del token para filtrar, o 0 para
+// SuperAdmin (sin filtro). Si no hay empresa, ya respondió 403.
+func TenantScope(c *gin.Context) (uint, bool) {
+	if IsSuperAdmin(c) {
+		return 0, true
+	}
+	companyID, ok := CompanyID(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No company context"})
+		return 0, false
+	}
+	return companyID, true
+}
+
+// CompanyScope resuelve la empresa concreta sobre la que operar: la del
+// token, o la del query param company_id para SuperAdmin. Si falla, ya
+// escribió la respuesta.
+func CompanyScope(c *gin.Context) (uint, bool) {
+	if IsSuperAdmin(c) {
+		id, err := strconv.ParseUint(c.Query("company_id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "SuperAdmin must provide company_id query parameter"})
+			return 0, false
+		}
+		return uint(id), true
+	}
+	return TenantScope(c)
+}
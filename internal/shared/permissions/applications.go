@@ -8,10 +8,13 @@ const (
 	ApplicationsMove   = "applications.move"
 	ApplicationsRate   = "applications.rate"
 	ApplicationsDelete = "applications.delete"
+	// ApplicationsOverrideStage permite saltar el grafo de transiciones
+	// (RN-APP-002), p. ej. reabrir una postulación hired/rejected. Con motivo.
+	ApplicationsOverrideStage = "applications.override_stage"
 )
 
 func init() {
-	grant(RoleAdmin, ApplicationsView, ApplicationsCreate, ApplicationsUpdate, ApplicationsMove, ApplicationsRate, ApplicationsDelete)
+	grant(RoleAdmin, ApplicationsView, ApplicationsCreate, ApplicationsUpdate, ApplicationsMove, ApplicationsRate, ApplicationsDelete, ApplicationsOverrideStage)
 	grant(RoleRecruiter, ApplicationsView, ApplicationsCreate, ApplicationsUpdate, ApplicationsMove, ApplicationsRate)
 	// hiring_manager: califica y comenta; mover stage solo en sus jobs (matriz 3.2),
 	// pendiente de chequeo a nivel de recurso (RN-MEMB-007).
@@ -0,0 +1,15 @@
+package permissions
+
+// Permisos del módulo Automations (reglas del pipeline)
+const (
+	// AutomationsView permite ver las reglas y su registro de ejecuciones.
+	AutomationsView = "automations.view"
+	// AutomationsManage permite crear, editar y eliminar reglas. Solo admin:
+	// las reglas envían correos y llaman webhooks externos.
+	AutomationsManage = "automations.manage"
+)
+
+func init() {
+	grant(RoleAdmin, AutomationsView, AutomationsManage)
+	grant(RoleRecruiter, AutomationsView)
+}
@@ -7,11 +7,17 @@ const (
 	CandidatesUpdate       = "candidates.update"
 	CandidatesDelete       = "candidates.delete"
 	CandidatesUploadResume = "candidates.upload_resume"
+	// CandidatesMerge permite buscar duplicados y fusionarlos (la fusión
+	// elimina al duplicado, pero se puede deshacer).
+	CandidatesMerge = "candidates.merge"
+	// CandidatesReindex permite reconstruir a demanda el índice de búsqueda
+	// de la empresa (normalmente lo mantienen las tareas programadas).
+	CandidatesReindex = "candidates.reindex"
 )
 
 func init() {
-	grant(RoleAdmin, CandidatesView, CandidatesCreate, CandidatesUpdate, CandidatesDelete, CandidatesUploadResume)
-	grant(RoleRecruiter, CandidatesView, CandidatesCreate, CandidatesUpdate, CandidatesUploadResume)
+	grant(RoleAdmin, CandidatesView, CandidatesCreate, CandidatesUpdate, CandidatesDelete, CandidatesUploadResume, CandidatesMerge, CandidatesReindex)
+	grant(RoleRecruiter, CandidatesView, CandidatesCreate, CandidatesUpdate, CandidatesUploadResume, CandidatesMerge)
 	// hiring_manager y user ven candidatos solo de sus jobs (matriz 3.2) —
 	// el filtrado por job asignado es a nivel de recurso, pendiente (RN-MEMB-007).
 	grant(RoleHiringManager, CandidatesView)
@@ -0,0 +1,19 @@
+package permissions
+
+// Permisos del módulo Comments (hilos sobre candidatos y postulaciones)
+const (
+	CommentsView   = "comments.view"
+	CommentsCreate = "comments.create"
+	// CommentsViewInternal permite ver (y escribir) comentarios internos: los
+	// del equipo de reclutamiento, ocultos al resto de roles.
+	CommentsViewInternal = "comments.view_internal"
+	// CommentsModerate permite eliminar comentarios de otros.
+	CommentsModerate = "comments.moderate"
+)
+
+func init() {
+	grant(RoleAdmin, CommentsView, CommentsCreate, CommentsViewInternal, CommentsModerate)
+	grant(RoleRecruiter, CommentsView, CommentsCreate, CommentsViewInternal)
+	grant(RoleHiringManager, CommentsView, CommentsCreate)
+	grant(RoleUser, CommentsView)
+}
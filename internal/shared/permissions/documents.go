@@ -0,0 +1,16 @@
+package permissions
+
+// Permisos del módulo Documents (plantillas y PDFs generados)
+const (
+	DocumentsView = "documents.view"
+	// DocumentsGenerate permite generar y eliminar documentos.
+	DocumentsGenerate = "documents.generate"
+	// DocumentTemplatesManage permite crear, editar y eliminar plantillas.
+	DocumentTemplatesManage = "documents.templates_manage"
+)
+
+func init() {
+	grant(RoleAdmin, DocumentsView, DocumentsGenerate, DocumentTemplatesManage)
+	grant(RoleRecruiter, DocumentsView, DocumentsGenerate, DocumentTemplatesManage)
+	grant(RoleHiringManager, DocumentsView)
+}
@@ -0,0 +1,19 @@
+package permissions
+
+// Permisos del módulo Interviews (agenda de entrevistas)
+const (
+	InterviewsView = "interviews.view"
+	// InterviewsManage permite agendar, reagendar, cancelar y cerrar
+	// entrevistas.
+	InterviewsManage = "interviews.manage"
+	// InterviewsAvailability permite publicar la disponibilidad semanal y los
+	// bloqueos propios (los de otros requieren además InterviewsManage).
+	InterviewsAvailability = "interviews.availability"
+)
+
+func init() {
+	grant(RoleAdmin, InterviewsView, InterviewsManage, InterviewsAvailability)
+	grant(RoleRecruiter, InterviewsView, InterviewsManage, InterviewsAvailability)
+	grant(RoleHiringManager, InterviewsView, InterviewsAvailability)
+	grant(RoleUser, InterviewsView, InterviewsAvailability)
+}
@@ -0,0 +1,14 @@
+package permissions
+
+// Permisos de la compatibilidad vacante ↔ candidato. Ordenar el tablero por
+// puntaje solo pide ApplicationsView.
+const (
+	// MatchesView permite ver los mejores candidatos de toda la base para
+	// una vacante y el desglose del puntaje de un candidato (sourcing).
+	MatchesView = "matches.view"
+)
+
+func init() {
+	grant(RoleAdmin, MatchesView)
+	grant(RoleRecruiter, MatchesView)
+}
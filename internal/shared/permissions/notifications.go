@@ -0,0 +1,13 @@
+package permissions
+
+// Permisos del módulo Notifications (bandeja propia de cada usuario)
+const (
+	NotificationsView = "notifications.view"
+)
+
+func init() {
+	grant(RoleAdmin, NotificationsView)
+	grant(RoleRecruiter, NotificationsView)
+	grant(RoleHiringManager, NotificationsView)
+	grant(RoleUser, NotificationsView)
+}
@@ -0,0 +1,20 @@
+package permissions
+
+// Permisos del módulo Offers (ofertas con cadena de aprobación)
+const (
+	OffersView = "offers.view"
+	// OffersManage permite crear, revisar, enviar, retirar ofertas y
+	// registrar la respuesta del candidato.
+	OffersManage = "offers.manage"
+	// OffersApprove permite decidir pasos de aprobación; cada paso exige
+	// además el rol que indica la cadena.
+	OffersApprove = "offers.approve"
+	// OffersChainManage permite configurar la cadena de aprobación.
+	OffersChainManage = "offers.chain_manage"
+)
+
+func init() {
+	grant(RoleAdmin, OffersView, OffersManage, OffersApprove, OffersChainManage)
+	grant(RoleRecruiter, OffersView, OffersManage, OffersApprove)
+	grant(RoleHiringManager, OffersView, OffersApprove)
+}
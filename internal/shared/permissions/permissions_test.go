@@ -22,6 +22,13 @@ func TestMatrixPorRol(t *testing.T) {
 		{RoleAdmin, CompaniesCreate, false},
 		{RoleAdmin, CompaniesDelete, false},
 		{RoleAdmin, MembershipsCreate, false}, // RN-MEMB-004: solo SuperAdmin en MVP
+		{RoleAdmin, TrashPurge, true},
+		{RoleAdmin, RetentionManage, true},
+		{RoleAdmin, DataRequestsManage, true},
+		{RoleAdmin, ApplicationsOverrideStage, true},
+		{RoleAdmin, RejectionReasonsManage, true},
+		{RoleAdmin, CandidatesReindex, true},
+		{RoleAdmin, CommentsModerate, true},
 
 		// recruiter: gestiona jobs y candidatos, no usuarios ni billing
 		{RoleRecruiter, JobsCreate, true},
@@ -31,6 +38,32 @@ func TestMatrixPorRol(t *testing.T) {
 		{RoleRecruiter, UsersCreate, false},
 		{RoleRecruiter, CompaniesView, false},
 		{RoleRecruiter, JobsDelete, false},
+		{RoleRecruiter, TrashRestore, true},
+		{RoleRecruiter, TrashPurge, false}, // eliminación definitiva solo admin
+		{RoleRecruiter, RetentionView, false},
+		{RoleRecruiter, DataRequestsManage, false},
+		{RoleRecruiter, ConsentsManage, true},
+		{RoleRecruiter, PrivacyNoticesManage, false},
+		{RoleRecruiter, PipelinesManage, true},
+		{RoleRecruiter, ApplicationsOverrideStage, false}, // reabrir hired/rejected solo admin
+		{RoleRecruiter, RejectionReasonsView, true},
+		{RoleRecruiter, RejectionReasonsManage, false},
+		{RoleRecruiter, ScorecardTemplatesManage, true},
+		{RoleRecruiter, CommentsViewInternal, true},
+		{RoleRecruiter, CommentsModerate, false}, // borra solo sus propios comentarios
+		{RoleRecruiter, InterviewsManage, true},
+		{RoleRecruiter, OffersManage, true},
+		{RoleRecruiter, OffersChainManage, false}, // la cadena la define admin
+		{RoleRecruiter, DocumentTemplatesManage, true},
+		{RoleRecruiter, DocumentsGenerate, true},
+		{RoleRecruiter, AutomationsView, true},
+		{RoleRecruiter, AutomationsManage, false}, // las reglas llaman webhooks externos
+		{RoleRecruiter, CandidatesMerge, true},
+		{RoleRecruiter, CandidatesReindex, false},
+		{RoleRecruiter, TagsManage, true},
+		{RoleRecruiter, TalentPoolsManage, true},
+		{RoleRecruiter, SkillsManage, true},
+		{RoleRecruiter, MatchesView, true},
 
 		// hiring_manager: lectura + calificar
 		{RoleHiringManager, JobsView, true},
@@ -38,12 +71,42 @@ func TestMatrixPorRol(t *testing.T) {
 		{RoleHiringManager, JobsCreate, false},
 		{RoleHiringManager, ApplicationsMove, false},
 		{RoleHiringManager, CandidatesCreate, false},
+		{RoleHiringManager, PipelinesView, true},
+		{RoleHiringManager, PipelinesManage, false},
+		{RoleHiringManager, ScorecardsViewAll, false}, // ve las demás evaluaciones tras enviar la suya
+		{RoleHiringManager, ScorecardTemplatesManage, false},
+		{RoleHiringManager, CommentsCreate, true},
+		{RoleHiringManager, CommentsViewInternal, false},
+		{RoleHiringManager, InterviewsView, true},
+		{RoleHiringManager, InterviewsManage, false},
+		{RoleHiringManager, InterviewsAvailability, true}, // publica su disponibilidad
+		{RoleHiringManager, OffersApprove, true},
+		{RoleHiringManager, OffersManage, false},
+		{RoleHiringManager, DocumentsView, true},
+		{RoleHiringManager, DocumentsGenerate, false},
+		{RoleHiringManager, AutomationsView, false},
+		{RoleHiringManager, CandidatesMerge, false},
+		{RoleHiringManager, CandidatesReindex, false},
+		{RoleHiringManager, TagsManage, false},
+		{RoleHiringManager, TalentPoolsView, false}, // el sourcing es del equipo de reclutamiento
+		{RoleHiringManager, SkillsView, true},
+		{RoleHiringManager, SkillsManage, false},
+		{RoleHiringManager, MatchesView, false}, // sourcing
 
 		// user: solo lectura
 		{RoleUser, JobsView, true},
 		{RoleUser, DashboardView, true},
 		{RoleUser, JobsCreate, false},
 		{RoleUser, ApplicationsRate, false},
+		{RoleUser, CommentsView, true},
+		{RoleUser, CommentsCreate, false},
+		{RoleUser, NotificationsView, true},
+		{RoleUser, InterviewsAvailability, true},
+		{RoleUser, OffersView, false}, // la compensación no es pública dentro de la empresa
+		{RoleUser, DocumentsView, false},
+		{RoleUser, SkillsView, true},
+		{RoleUser, SkillsManage, false},
+		{RoleUser, MatchesView, false},
 	}
 
 	for _, tc := range cases {
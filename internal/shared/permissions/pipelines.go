@@ -0,0 +1,16 @@
+package permissions
+
+// Permisos del módulo Pipelines (etapas del proceso de selección)
+const (
+	PipelinesView   = "pipelines.view"
+	PipelinesManage = "pipelines.manage"
+)
+
+func init() {
+	// Todos los roles ven las etapas (el tablero las necesita).
+	grant(RoleAdmin, PipelinesView, PipelinesManage)
+	// recruiter: adapta el proceso de cada vacante.
+	grant(RoleRecruiter, PipelinesView, PipelinesManage)
+	grant(RoleHiringManager, PipelinesView)
+	grant(RoleUser, PipelinesView)
+}
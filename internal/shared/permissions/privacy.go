@@ -0,0 +1,25 @@
+package permissions
+
+// Permisos del módulo Privacy (retención de datos, GDPR/LGPD)
+const (
+	RetentionView   = "retention.view"
+	RetentionManage = "retention.manage"
+
+	DataRequestsView   = "data_requests.view"
+	DataRequestsManage = "data_requests.manage"
+
+	PrivacyNoticesManage = "privacy_notices.manage"
+	ConsentsView         = "consents.view"
+	ConsentsManage       = "consents.manage"
+)
+
+func init() {
+	// Las políticas de retención eliminan/anonimizan datos: solo admin.
+	grant(RoleAdmin, RetentionView, RetentionManage)
+	// Las solicitudes del titular exportan o borran toda su PII: solo admin.
+	grant(RoleAdmin, DataRequestsView, DataRequestsManage)
+	grant(RoleAdmin, PrivacyNoticesManage, ConsentsView, ConsentsManage)
+	// El recruiter registra/revoca el consentimiento de talent pool que obtiene
+	// al contactar candidatos.
+	grant(RoleRecruiter, ConsentsView, ConsentsManage)
+}
@@ -0,0 +1,15 @@
+package permissions
+
+// Permisos del catálogo de motivos de rechazo
+const (
+	RejectionReasonsView   = "rejection_reasons.view"
+	RejectionReasonsManage = "rejection_reasons.manage"
+)
+
+func init() {
+	// Todos los roles ven el catálogo (el modal de rechazo lo necesita).
+	grant(RoleAdmin, RejectionReasonsView, RejectionReasonsManage)
+	grant(RoleRecruiter, RejectionReasonsView)
+	grant(RoleHiringManager, RejectionReasonsView)
+	grant(RoleUser, RejectionReasonsView)
+}
@@ -0,0 +1,16 @@
+package permissions
+
+// Permisos del módulo Scorecards (evaluaciones estructuradas). Enviar una
+// evaluación usa ApplicationsRate; verlas, ApplicationsView.
+const (
+	ScorecardTemplatesManage = "scorecards.templates_manage"
+	// ScorecardsViewAll permite ver las evaluaciones de otros entrevistadores
+	// sin haber enviado la propia (quien coordina el proceso, no evalúa).
+	ScorecardsViewAll = "scorecards.view_all"
+)
+
+func init() {
+	grant(RoleAdmin, ScorecardTemplatesManage, ScorecardsViewAll)
+	grant(RoleRecruiter, ScorecardTemplatesManage, ScorecardsViewAll)
+	// hiring_manager evalúa: ve las demás evaluaciones después de enviar la suya.
+}
@@ -0,0 +1,18 @@
+package permissions
+
+// Permisos del catálogo de habilidades. Las habilidades de una vacante se
+// ven con JobsView y se editan con JobsUpdate; las de un candidato, con
+// CandidatesView y CandidatesUpdate.
+const (
+	SkillsView = "skills.view"
+	// SkillsManage permite crear, editar y eliminar las habilidades propias
+	// de la empresa (las globales solo las edita SuperAdmin).
+	SkillsManage = "skills.manage"
+)
+
+func init() {
+	grant(RoleAdmin, SkillsView, SkillsManage)
+	grant(RoleRecruiter, SkillsView, SkillsManage)
+	grant(RoleHiringManager, SkillsView)
+	grant(RoleUser, SkillsView)
+}
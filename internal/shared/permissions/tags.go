@@ -0,0 +1,13 @@
+package permissions
+
+// Permisos del catálogo de etiquetas de candidatos. Ver las etiquetas va con
+// CandidatesView y asignarlas con CandidatesUpdate.
+const (
+	// TagsManage permite crear, renombrar, recolorear y eliminar etiquetas.
+	TagsManage = "tags.manage"
+)
+
+func init() {
+	grant(RoleAdmin, TagsManage)
+	grant(RoleRecruiter, TagsManage)
+}
@@ -0,0 +1,15 @@
+package permissions
+
+// Permisos del módulo Talent Pools (listas de sourcing)
+const (
+	// TalentPoolsView permite ver los pools compartidos (y los propios) y
+	// sus miembros.
+	TalentPoolsView = "talent_pools.view"
+	// TalentPoolsManage permite crear pools y agregar o quitar candidatos.
+	TalentPoolsManage = "talent_pools.manage"
+)
+
+func init() {
+	grant(RoleAdmin, TalentPoolsView, TalentPoolsManage)
+	grant(RoleRecruiter, TalentPoolsView, TalentPoolsManage)
+}
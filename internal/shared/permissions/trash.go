@@ -0,0 +1,15 @@
+package permissions
+
+// Permisos de la papelera (registros eliminados por soft delete)
+const (
+	TrashView    = "trash.view"
+	TrashRestore = "trash.restore"
+	TrashPurge   = "trash.purge"
+)
+
+func init() {
+	// La eliminación definitiva es irreversible: reservada a admin.
+	grant(RoleAdmin, TrashView, TrashRestore, TrashPurge)
+	// recruiter: deshace sus propios errores de borrado, no purga.
+	grant(RoleRecruiter, TrashView, TrashRestore)
+}
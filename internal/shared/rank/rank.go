@@ -0,0 +1,172 @@
+// Package rank genera keys de orden fraccionario (estilo LexoRank) para
+// listas ordenadas a mano, como las columnas del tablero de postulaciones.
+// Las keys usan los dígitos 0-9a-z y se comparan byte a byte (en Postgres,
+// ORDER BY ... COLLATE "C"); siempre hay una key entre dos distintas, así
+// que mover un elemento solo reescribe el suyo.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+	base   = len(digits)
+
+	// width son los dígitos de las keys que generan After, Before y Spread.
+	width = 6
+	// step es el salto de After/Before: deja 1295 keys libres entre dos
+	// elementos agregados al final de una lista.
+	step = base * base
+
+	// MaxLength es el largo a partir del cual conviene redistribuir la lista
+	// con Spread (muchas inserciones seguidas en el mismo hueco).
+	MaxLength = 48
+)
+
+// ErrNoRoom indica que no hay key posible entre los límites: no están en
+// orden estricto o alguno no es una key válida.
+var ErrNoRoom = errors.New("rank: no key between the given bounds")
+
+// Between devuelve una key estrictamente entre lower y upper. "" significa
+// sin límite por ese lado.
+func Between(lower, upper string) (string, error) {
+	if !valid(lower) || !valid(upper) || (upper != "" && lower >= upper) {
+		return "", ErrNoRoom
+	}
+	if upper == "" {
+		return After(lower), nil
+	}
+	if lower == "" {
+		return Before(upper), nil
+	}
+	return midpoint(lower, upper, true), nil
+}
+
+// After devuelve una key mayor que key dejando espacio para seguir
+// agregando al final ("" = primera key de una lista vacía).
+func After(key string) string {
+	if key == "" {
+		return format(pow(width) / 2)
+	}
+	if n := prefix(key) + step; n < pow(width) {
+		return format(n)
+	}
+	return midpoint(key, "", false)
+}
+
+// Before devuelve una key menor que key, con el mismo criterio que After.
+func Before(key string) string {
+	if key == "" {
+		return After("")
+	}
+	if n := prefix(key) - step; n > 0 {
+		return format(n)
+	}
+	return midpoint("", key, true)
+}
+
+// Spread devuelve n keys de igual largo repartidas en todo el rango, para
+// redistribuir una lista completa.
+func Spread(n int) []string {
+	keys := make([]string, n)
+	gap := pow(width) / (n + 1)
+	if gap == 0 {
+		gap = 1
+	}
+	for i := range keys {
+		keys[i] = format((i + 1) * gap)
+	}
+	return keys
+}
+
+// midpoint es el punto medio de dos keys (upper solo cuenta si hasUpper).
+// Ninguna termina en "0", así que siempre queda espacio a la izquierda.
+func midpoint(lower, upper string, hasUpper bool) string {
+	if hasUpper {
+		n := 0
+		for n < len(upper) && digitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lower) {
+				rest = lower[n:]
+			}
+			return upper[:n] + midpoint(rest, upper[n:], true)
+		}
+	}
+
+	digitLower := 0
+	if lower != "" {
+		digitLower = strings.IndexByte(digits, lower[0])
+	}
+	digitUpper := base
+	if hasUpper {
+		digitUpper = strings.IndexByte(digits, upper[0])
+	}
+	if digitUpper-digitLower > 1 {
+		return string(digits[(digitLower+digitUpper+1)/2])
+	}
+	if hasUpper && len(upper) > 1 {
+		return upper[:1]
+	}
+	rest := ""
+	if lower != "" {
+		rest = lower[1:]
+	}
+	return string(digits[digitLower]) + midpoint(rest, "", false)
+}
+
+// digitAt devuelve el dígito i de key, o "0" si key es más corta.
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
+
+// prefix interpreta los primeros width dígitos de key como un entero.
+func prefix(key string) int {
+	n := 0
+	for i := 0; i < width; i++ {
+		n = n*base + strings.IndexByte(digits, digitAt(key, i))
+	}
+	return n
+}
+
+// format escribe n con width dígitos y sin ceros finales.
+func format(n int) string {
+	buf := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = digits[n%base]
+		n /= base
+	}
+	return strings.TrimRight(string(buf), digits[:1])
+}
+
+func pow(exp int) int {
+	n := 1
+	for i := 0; i < exp; i++ {
+		n *= base
+	}
+	return n
+}
+
+// valid reporta si key es vacía o una key bien formada (dígitos del
+// alfabeto, sin "0" final).
+func valid(key string) bool {
+	if key == "" {
+		return true
+	}
+	if key[len(key)-1] == digits[0] {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package rank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestAfterAndBeforeKeepOrder(t *testing.T) {
+	key := After("")
+	for i := 0; i < 2000; i++ {
+		next := After(key)
+		if next <= key || !valid(next) {
+			t.Fatalf("After(%q) = %q", key, next)
+		}
+		key = next
+	}
+	if len(key) > width {
+		t.Errorf("2000 altas al final no deberían alargar la key: %q", key)
+	}
+
+	key = After("")
+	for i := 0; i < 2000; i++ {
+		prev := Before(key)
+		if prev >= key || !valid(prev) {
+			t.Fatalf("Before(%q) = %q", key, prev)
+		}
+		key = prev
+	}
+}
+
+func TestBetween(t *testing.T) {
+	cases := [][2]string{
+		{"", ""}, {"", "i"}, {"i", ""}, {"a", "b"}, {"a", "a1"}, {"az", "b"}, {"1", "2"}, {"hzzzzz", "i"}, {"zzzzzz", ""}, {"", "000001"},
+	}
+	for _, c := range cases {
+		got, err := Between(c[0], c[1])
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", c[0], c[1], err)
+		}
+		if !valid(got) || (c[0] != "" && got <= c[0]) || (c[1] != "" && got >= c[1]) {
+			t.Errorf("Between(%q, %q) = %q", c[0], c[1], got)
+		}
+	}
+
+	for _, c := range [][2]string{{"b", "a"}, {"a", "a"}, {"a0", "b"}, {"A", "b"}} {
+		if _, err := Between(c[0], c[1]); err != ErrNoRoom {
+			t.Errorf("Between(%q, %q) err = %v, want ErrNoRoom", c[0], c[1], err)
+		}
+	}
+}
+
+func TestRandomInsertsStayOrdered(t *testing.T) {
+	rng := rand.New(rand.NewSource(43))
+	keys := []string{After("")}
+	for i := 0; i < 3000; i++ {
+		pos := rng.Intn(len(keys) + 1)
+		lower, upper := "", ""
+		if pos > 0 {
+			lower = keys[pos-1]
+		}
+		if pos < len(keys) {
+			upper = keys[pos]
+		}
+		key, err := Between(lower, upper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys[:pos], append([]string{key}, keys[pos:]...)...)
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Fatal("las keys perdieron el orden")
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i] == keys[i-1] {
+			t.Fatalf("key repetida %q", keys[i])
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	keys := Spread(2000)
+	if !sort.StringsAreSorted(keys) {
+		t.Fatal("Spread no devuelve keys ordenadas")
+	}
+	for i, k := range keys {
+		if !valid(k) || k == "" || len(k) > width || (i > 0 && k == keys[i-1]) {
+			t.Fatalf("key %d inválida: %q", i, k)
+		}
+	}
+}